@@ -311,7 +311,11 @@ func (bs *BatcherService) initPlasmaDA(cfg *CLIConfig) error {
 	if err := config.Check(); err != nil {
 		return err
 	}
-	bs.PlasmaDA = config.NewDAClient()
+	plasmaDA, err := config.NewDAClient()
+	if err != nil {
+		return err
+	}
+	bs.PlasmaDA = plasmaDA
 	bs.UsePlasma = config.Enabled
 	return nil
 }
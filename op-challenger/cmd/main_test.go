@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
@@ -29,6 +30,17 @@ var (
 	datadir                 = "./test_data"
 	cannonL2                = "http://example.com:9545"
 	rollupRpc               = "http://example.com:8555"
+	asteriscNetwork         = "op-mainnet"
+	asteriscBin             = "./bin/asterisc"
+	asteriscServer          = "./bin/op-program"
+	asteriscPreState        = "./pre.json"
+	cartesiNetwork          = "op-mainnet"
+	cartesiBin              = "./bin/cartesi-machine"
+	cartesiServer           = "./bin/op-program"
+	cartesiSnapshotDir      = "./machine"
+	externalCommand         = "./bin/trace-provider"
+	cartesiComputeServer    = "http://example.com:7000"
+	remoteTraceURL          = "http://example.com:7001"
 )
 
 func TestLogLevel(t *testing.T) {
@@ -243,6 +255,23 @@ func TestPollInterval(t *testing.T) {
 	})
 }
 
+func TestPlayerCreationTimeout(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultPlayerCreationTimeout, cfg.PlayerCreationTimeout)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--player-creation-timeout", "5s"))
+		require.Equal(t, 5*time.Second, cfg.PlayerCreationTimeout)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--player-creation-timeout", "0"))
+		require.Zero(t, cfg.PlayerCreationTimeout)
+	})
+}
+
 func TestCannonRequiredArgs(t *testing.T) {
 	for _, traceType := range []config.TraceType{config.TraceTypeCannon, config.TraceTypePermissioned} {
 		traceType := traceType
@@ -291,6 +320,36 @@ func TestCannonRequiredArgs(t *testing.T) {
 			})
 		})
 
+		t.Run(fmt.Sprintf("TestCannonPrestateDownloadTimeout-%v", traceType), func(t *testing.T) {
+			t.Run("UsesDefault", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType))
+				require.Equal(t, config.DefaultCannonPreStateTimeout, cfg.CannonPreStateTimeout)
+			})
+
+			t.Run("Valid", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType, "--cannon-prestate-download-timeout=1m"))
+				require.Equal(t, time.Minute, cfg.CannonPreStateTimeout)
+			})
+		})
+
+		t.Run(fmt.Sprintf("TestCannonAbsolutePrestates-%v", traceType), func(t *testing.T) {
+			t.Run("NotRequired", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType))
+				require.Empty(t, cfg.CannonAbsolutePreStates)
+			})
+
+			t.Run("Valid", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType, "--cannon-prestates=./old.json", "--cannon-prestates=./prestates"))
+				require.Equal(t, []string{"./old.json", "./prestates"}, cfg.CannonAbsolutePreStates)
+			})
+
+			t.Run("ReplacesPrestate", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--cannon-prestate", "--cannon-prestates=./prestates"))
+				require.Equal(t, "", cfg.CannonAbsolutePreState)
+				require.Equal(t, []string{"./prestates"}, cfg.CannonAbsolutePreStates)
+			})
+		})
+
 		t.Run(fmt.Sprintf("TestCannonL2-%v", traceType), func(t *testing.T) {
 			t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
 				configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--cannon-l2"))
@@ -403,6 +462,297 @@ func TestCannonRequiredArgs(t *testing.T) {
 	}
 }
 
+func TestAsteriscRequiredArgs(t *testing.T) {
+	traceType := config.TraceTypeAsterisc
+	for _, flag := range []string{"--asterisc-bin", "--asterisc-server", "--asterisc-prestate", "--cannon-l2"} {
+		flag := flag
+		t.Run(flag, func(t *testing.T) {
+			t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+				configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, flag))
+			})
+
+			t.Run("Required", func(t *testing.T) {
+				verifyArgsInvalid(t, fmt.Sprintf("flag %v is required", flag[2:]), addRequiredArgsExcept(traceType, flag))
+			})
+		})
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType))
+		require.Equal(t, asteriscBin, cfg.AsteriscBin)
+		require.Equal(t, asteriscServer, cfg.AsteriscServer)
+		require.Equal(t, asteriscPreState, cfg.AsteriscAbsolutePreState)
+		require.Equal(t, asteriscNetwork, cfg.AsteriscNetwork)
+		require.Equal(t, cannonL2, cfg.CannonL2)
+		require.Equal(t, config.DefaultAsteriscSnapshotFreq, cfg.AsteriscSnapshotFreq)
+		require.Equal(t, config.DefaultAsteriscInfoFreq, cfg.AsteriscInfoFreq)
+	})
+
+	t.Run("SnapshotAndInfoFreq", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType, "--asterisc-snapshot-freq=1234", "--asterisc-info-freq=5678"))
+		require.Equal(t, uint(1234), cfg.AsteriscSnapshotFreq)
+		require.Equal(t, uint(5678), cfg.AsteriscInfoFreq)
+	})
+
+	t.Run("NetworkOrRollupConfig", func(t *testing.T) {
+		verifyArgsInvalid(t,
+			"flag asterisc-network or asterisc-rollup-config and asterisc-l2-genesis is required",
+			addRequiredArgsExcept(traceType, "--asterisc-network"))
+		verifyArgsInvalid(t,
+			"flag asterisc-network can not be used with asterisc-rollup-config and asterisc-l2-genesis",
+			addRequiredArgs(traceType, "--asterisc-rollup-config=rollup.json"))
+		cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--asterisc-network",
+			"--asterisc-rollup-config=rollup.json", "--asterisc-l2-genesis=genesis.json"))
+		require.Equal(t, "rollup.json", cfg.AsteriscRollupConfigPath)
+		require.Equal(t, "genesis.json", cfg.AsteriscL2GenesisPath)
+	})
+}
+
+func TestCartesiRequiredArgs(t *testing.T) {
+	traceType := config.TraceTypeCartesi
+	for _, flag := range []string{"--cartesi-bin", "--cartesi-server", "--cartesi-snapshot-dir", "--cannon-l2"} {
+		flag := flag
+		t.Run(flag, func(t *testing.T) {
+			t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+				configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, flag))
+			})
+
+			t.Run("Required", func(t *testing.T) {
+				verifyArgsInvalid(t, fmt.Sprintf("flag %v is required", flag[2:]), addRequiredArgsExcept(traceType, flag))
+			})
+		})
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType))
+		require.Equal(t, cartesiBin, cfg.CartesiBin)
+		require.Equal(t, cartesiServer, cfg.CartesiServer)
+		require.Equal(t, cartesiSnapshotDir, cfg.CartesiSnapshotDir)
+		require.Equal(t, cartesiNetwork, cfg.CartesiNetwork)
+		require.Equal(t, cannonL2, cfg.CannonL2)
+		require.Empty(t, cfg.CartesiMachineURL)
+		require.Equal(t, config.DefaultCartesiSnapshotFreq, cfg.CartesiSnapshotFreq)
+	})
+
+	t.Run("MachineURLAndSnapshotFreq", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType, "--cartesi-machine-url=http://localhost:5000", "--cartesi-snapshot-freq=1234"))
+		require.Equal(t, "http://localhost:5000", cfg.CartesiMachineURL)
+		require.Equal(t, uint(1234), cfg.CartesiSnapshotFreq)
+	})
+
+	t.Run("NetworkOrRollupConfig", func(t *testing.T) {
+		verifyArgsInvalid(t,
+			"flag cartesi-network or cartesi-rollup-config and cartesi-l2-genesis is required",
+			addRequiredArgsExcept(traceType, "--cartesi-network"))
+		verifyArgsInvalid(t,
+			"flag cartesi-network can not be used with cartesi-rollup-config and cartesi-l2-genesis",
+			addRequiredArgs(traceType, "--cartesi-rollup-config=rollup.json"))
+		cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--cartesi-network",
+			"--cartesi-rollup-config=rollup.json", "--cartesi-l2-genesis=genesis.json"))
+		require.Equal(t, "rollup.json", cfg.CartesiRollupConfigPath)
+		require.Equal(t, "genesis.json", cfg.CartesiL2GenesisPath)
+	})
+}
+
+func TestExternalRequiredArgs(t *testing.T) {
+	traceType := config.TraceTypeExternal
+	for _, flag := range []string{"--external-command", "--cannon-l2"} {
+		flag := flag
+		t.Run(flag, func(t *testing.T) {
+			t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+				configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, flag))
+			})
+
+			t.Run("Required", func(t *testing.T) {
+				verifyArgsInvalid(t, fmt.Sprintf("flag %v is required", flag[2:]), addRequiredArgsExcept(traceType, flag))
+			})
+		})
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType))
+		require.Equal(t, externalCommand, cfg.ExternalCommand)
+		require.Empty(t, cfg.ExternalArgs)
+		require.Equal(t, config.DefaultExternalTimeout, cfg.ExternalTimeout)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("ArgsAndTimeout", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType, "--external-args=--network", "--external-args=op-sepolia", "--external-timeout=5m"))
+		require.Equal(t, []string{"--network", "op-sepolia"}, cfg.ExternalArgs)
+		require.Equal(t, 5*time.Minute, cfg.ExternalTimeout)
+	})
+
+	t.Run("GameTypeRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--game-types"))
+		require.ErrorIs(t, cfg.Check(), config.ErrMissingExternalGameType)
+	})
+}
+
+func TestCartesiComputeRequiredArgs(t *testing.T) {
+	traceType := config.TraceTypeCartesiCompute
+	t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+		configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--cartesi-compute-server"))
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag cartesi-compute-server is required", addRequiredArgsExcept(traceType, "--cartesi-compute-server"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType))
+		require.Equal(t, cartesiComputeServer, cfg.CartesiComputeServer)
+		require.Equal(t, config.DefaultCartesiComputeTimeout, cfg.CartesiComputeTimeout)
+		require.Equal(t, []config.GameTypeConfig{{GameType: 43, TraceType: traceType}}, cfg.GameTypes)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType, "--cartesi-compute-timeout=5s"))
+		require.Equal(t, 5*time.Second, cfg.CartesiComputeTimeout)
+	})
+
+	t.Run("GameTypeRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--game-types"))
+		require.ErrorIs(t, cfg.Check(), config.ErrMissingCartesiComputeGameType)
+	})
+}
+
+func TestRemoteRequiredArgs(t *testing.T) {
+	traceType := config.TraceTypeRemote
+	for _, flag := range []string{"--remote-trace-url", "--cannon-l2"} {
+		flag := flag
+		t.Run(flag, func(t *testing.T) {
+			t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+				configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, flag))
+			})
+
+			t.Run("Required", func(t *testing.T) {
+				verifyArgsInvalid(t, fmt.Sprintf("flag %v is required", flag[2:]), addRequiredArgsExcept(traceType, flag))
+			})
+		})
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType))
+		require.Equal(t, map[uint32]string{44: remoteTraceURL}, cfg.RemoteTraceURLs)
+		require.Equal(t, config.DefaultRemoteTraceTimeout, cfg.RemoteTraceTimeout)
+		require.Equal(t, []config.GameTypeConfig{{GameType: 44, TraceType: traceType}}, cfg.GameTypes)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("MultipleGameTypes", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--game-types", "--game-types=44=remote,45=remote", "--remote-trace-url=45=http://example.com:7002"))
+		require.Equal(t, map[uint32]string{44: remoteTraceURL, 45: "http://example.com:7002"}, cfg.RemoteTraceURLs)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid remote-trace-url value \"44\"", addRequiredArgsExcept(traceType, "--remote-trace-url", "--remote-trace-url=44"))
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(traceType, "--remote-trace-timeout=1m"))
+		require.Equal(t, time.Minute, cfg.RemoteTraceTimeout)
+	})
+
+	t.Run("MissingGameTypeURL", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--game-types", "--game-types=44=remote,45=remote"))
+		require.ErrorIs(t, cfg.Check(), config.ErrMissingRemoteTraceURL)
+	})
+}
+
+func TestGameTypes(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.Empty(t, cfg.GameTypes)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--game-types=0=cannon", "--game-types=42=cannon:./custom.json"))
+		require.Equal(t, []config.GameTypeConfig{
+			{GameType: 0, TraceType: config.TraceTypeCannon},
+			{GameType: 42, TraceType: config.TraceTypeCannon, AbsolutePreState: "./custom.json"},
+		}, cfg.GameTypes)
+	})
+
+	t.Run("OverriddenPreStateReplacesFlag", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate", "--game-types=42=cannon:./custom.json"))
+		require.Equal(t, []config.GameTypeConfig{{GameType: 42, TraceType: config.TraceTypeCannon, AbsolutePreState: "./custom.json"}}, cfg.GameTypes)
+	})
+
+	t.Run("PreStateFlagRequiredWithoutOverride", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag cannon-prestate is required", addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate", "--game-types=42=cannon"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <game-type>=<trace-type>", addRequiredArgs(config.TraceTypeCannon, "--game-types=cannon"))
+	})
+
+	t.Run("InvalidGameType", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid game-types game type", addRequiredArgs(config.TraceTypeCannon, "--game-types=foo=cannon"))
+	})
+
+	t.Run("InvalidTraceType", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown trace type", addRequiredArgs(config.TraceTypeCannon, "--game-types=42=foo"))
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		verifyArgsInvalid(t, config.ErrDuplicateGameType.Error(), addRequiredArgs(config.TraceTypeCannon, "--game-types=42=cannon", "--game-types=42=cannon"))
+	})
+
+	t.Run("TraceTypeMustBeEnabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--game-types=42=alphabet"))
+		require.ErrorIs(t, cfg.Check(), config.ErrGameTypeTraceTypeNotEnabled)
+	})
+}
+
+func TestCannonL2Fallbacks(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.Empty(t, cfg.CannonL2Fallbacks)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--cannon-l2-fallbacks=http://l2-a", "--cannon-l2-fallbacks=http://l2-b"))
+		require.Equal(t, []string{"http://l2-a", "http://l2-b"}, cfg.CannonL2Fallbacks)
+	})
+}
+
+func TestL2Rpcs(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.Empty(t, cfg.L2Rpcs)
+		require.Equal(t, cannonL2, cfg.L2Rpc(config.TraceTypeCannon))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCartesi, "--l2-rpcs=cartesi=http://cartesi-l2", "--l2-rpcs=asterisc=http://asterisc-l2"))
+		require.Equal(t, map[config.TraceType]string{
+			config.TraceTypeCartesi:  "http://cartesi-l2",
+			config.TraceTypeAsterisc: "http://asterisc-l2",
+		}, cfg.L2Rpcs)
+		require.Equal(t, "http://cartesi-l2", cfg.L2Rpc(config.TraceTypeCartesi))
+	})
+
+	t.Run("ReplacesCannonL2", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeCartesi, "--cannon-l2", "--l2-rpcs=cartesi=http://cartesi-l2"))
+		require.Equal(t, "http://cartesi-l2", cfg.L2Rpc(config.TraceTypeCartesi))
+	})
+
+	t.Run("OnlyReplacesCannonL2ForConfiguredType", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag cannon-l2 is required", addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-l2", "--l2-rpcs=cartesi=http://cartesi-l2"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <trace-type>=<url>", addRequiredArgs(config.TraceTypeCannon, "--l2-rpcs=http://l2"))
+	})
+
+	t.Run("InvalidTraceType", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown trace type", addRequiredArgs(config.TraceTypeCannon, "--l2-rpcs=foo=http://l2"))
+	})
+}
+
 func TestDataDir(t *testing.T) {
 	for _, traceType := range config.TraceTypes {
 		traceType := traceType
@@ -450,6 +800,211 @@ func TestGameWindow(t *testing.T) {
 	})
 }
 
+func TestGameParams(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultGameParams, cfg.GameParams)
+		require.Empty(t, cfg.GameTypeParams)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-max-depth=50", "--game-split-depth=14", "--game-duration=40m"))
+		require.Equal(t, config.GameParams{MaxGameDepth: 50, SplitDepth: 14, GameDuration: 40 * time.Minute}, cfg.GameParams)
+	})
+
+	t.Run("Unchecked", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-max-depth=0", "--game-split-depth=0", "--game-duration=0"))
+		require.Equal(t, config.GameParams{}, cfg.GameParams)
+	})
+
+	t.Run("GameTypeParams", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-type-params=42=50:14:40m", "--game-type-params=43=0:0:0s"))
+		require.Equal(t, map[uint32]config.GameParams{
+			42: {MaxGameDepth: 50, SplitDepth: 14, GameDuration: 40 * time.Minute},
+			43: {},
+		}, cfg.GameTypeParams)
+	})
+
+	t.Run("InvalidGameTypeParams", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <game-type>=<max-depth>:<split-depth>:<duration>", addRequiredArgs(config.TraceTypeAlphabet, "--game-type-params=42=50:14"))
+	})
+
+	t.Run("InvalidGameTypeParamsDuration", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <game-type>=<max-depth>:<split-depth>:<duration>", addRequiredArgs(config.TraceTypeAlphabet, "--game-type-params=42=50:14:foo"))
+	})
+
+	t.Run("SplitDepthMustBeLessThanMaxGameDepth", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-max-depth=14", "--game-split-depth=50"))
+		require.ErrorIs(t, cfg.Check(), config.ErrInvalidGameParams)
+	})
+}
+
+func TestParticipation(t *testing.T) {
+	t.Run("DefaultAct", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.ParticipationAct, cfg.Participation)
+		require.Empty(t, cfg.GameTypeParticipation)
+	})
+
+	for _, mode := range config.ParticipationModes {
+		mode := mode
+		t.Run(string(mode), func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--participation="+string(mode)))
+			require.Equal(t, mode, cfg.Participation)
+		})
+	}
+
+	t.Run("GameTypeParticipation", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-type-participation=0=defend-only", "--game-type-participation=255=observe"))
+		require.Equal(t, map[uint32]config.ParticipationMode{
+			0:   config.ParticipationDefendOnly,
+			255: config.ParticipationObserve,
+		}, cfg.GameTypeParticipation)
+	})
+
+	t.Run("InvalidGameTypeParticipation", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <game-type>=<mode>", addRequiredArgs(config.TraceTypeAlphabet, "--game-type-participation=observe"))
+	})
+
+	t.Run("InvalidMode", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--participation=foo"))
+		require.ErrorIs(t, cfg.Check(), config.ErrInvalidParticipationMode)
+	})
+}
+
+func TestLargePreimageChunkSize(t *testing.T) {
+	t.Run("DefaultMax", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, preimages.MaxChunkSize, cfg.LargePreimageChunkSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-chunk-size=1360"))
+		require.Equal(t, 1360, cfg.LargePreimageChunkSize)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-chunk-size=1000"))
+		require.ErrorIs(t, cfg.Check(), config.ErrInvalidLargePreimageChunkSize)
+	})
+}
+
+func TestOracleParams(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultOracleParams, cfg.OracleParams)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--oracle-version=1.0.0", "--oracle-challenge-period=2m", "--oracle-min-bond=1000"))
+		require.Equal(t, config.OracleParams{
+			Version:         "1.0.0",
+			ChallengePeriod: 2 * time.Minute,
+			MinBond:         1000,
+		}, cfg.OracleParams)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--oracle-challenge-period=0", "--oracle-min-bond=0"))
+		require.Equal(t, config.OracleParams{}, cfg.OracleParams)
+	})
+}
+
+func TestGameTypePreStates(t *testing.T) {
+	t.Run("DefaultNone", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.Empty(t, cfg.GameTypePreStates)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--game-type-prestate=0=./cannon.json", "--game-type-prestate=1=https://example.com/prestates/"))
+		require.Equal(t, map[uint32]string{0: "./cannon.json", 1: "https://example.com/prestates/"}, cfg.GameTypePreStates)
+	})
+
+	t.Run("ReplacesPreStateFlag", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate", "--game-type-prestate=0=./cannon.json"))
+		require.Equal(t, map[uint32]string{0: "./cannon.json"}, cfg.GameTypePreStates)
+	})
+
+	t.Run("PreStateFlagRequiredForMissingEntry", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag cannon-prestate is required", addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate", "--trace-type=permissioned", "--game-type-prestate=0=./cannon.json"))
+	})
+
+	t.Run("MissingPreState", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <game-type>=<absolute-prestate>", addRequiredArgs(config.TraceTypeCannon, "--game-type-prestate=0"))
+	})
+
+	t.Run("InvalidGameType", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid game-type-prestate game type", addRequiredArgs(config.TraceTypeCannon, "--game-type-prestate=foo=./cannon.json"))
+	})
+
+	t.Run("GameTypeNotRegistered", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--game-type-prestate=42=./custom.json"))
+		require.ErrorIs(t, cfg.Check(), config.ErrGameTypePreStateNotRegistered)
+	})
+}
+
+func TestGameTypeOracles(t *testing.T) {
+	t.Run("DefaultNone", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.GameTypeOracles)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		oracle1 := common.Address{0xaa}
+		oracle2 := common.Address{0xbb}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-type-oracle=0="+oracle1.Hex(), "--game-type-oracle=255="+oracle2.Hex()))
+		require.Equal(t, map[uint32]common.Address{0: oracle1, 255: oracle2}, cfg.GameTypeOracles)
+	})
+
+	t.Run("MissingGameType", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <game-type>=<address>", addRequiredArgs(config.TraceTypeAlphabet, "--game-type-oracle="+common.Address{0xaa}.Hex()))
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid game-type-oracle address", addRequiredArgs(config.TraceTypeAlphabet, "--game-type-oracle=0=foo"))
+	})
+}
+
+func TestGameTypeDelayedWETH(t *testing.T) {
+	t.Run("DefaultNone", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.GameTypeDelayedWETH)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		weth := common.Address{0xaa}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-type-delayed-weth=255="+weth.Hex()))
+		require.Equal(t, map[uint32]common.Address{255: weth}, cfg.GameTypeDelayedWETH)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "must be <game-type>=<address>", addRequiredArgs(config.TraceTypeAlphabet, "--game-type-delayed-weth=255"))
+	})
+}
+
+func TestSyncThresholds(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultSyncThresholds, cfg.SyncThresholds)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--sync-reference=finalized", "--sync-max-lag-blocks=5", "--sync-max-lag-time=2m"))
+		require.Equal(t, config.SyncThresholds{
+			Reference:    config.SyncReferenceFinalized,
+			MaxLagBlocks: 5,
+			MaxLagTime:   2 * time.Minute,
+		}, cfg.SyncThresholds)
+	})
+
+	t.Run("InvalidReference", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--sync-reference=foo"))
+		require.ErrorIs(t, cfg.Check(), config.ErrInvalidSyncReference)
+	})
+}
+
 func TestUnsafeAllowInvalidPrestate(t *testing.T) {
 	t.Run("DefaultsToFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--unsafe-allow-invalid-prestate"))
@@ -472,6 +1027,18 @@ func TestUnsafeAllowInvalidPrestate(t *testing.T) {
 	})
 }
 
+func TestAllowPrestateMismatch(t *testing.T) {
+	t.Run("DefaultsToFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.False(t, cfg.AllowPrestateMismatch)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--allow-prestate-mismatch"))
+		require.True(t, cfg.AllowPrestateMismatch)
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := dryRunWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -522,12 +1089,31 @@ func requiredArgs(traceType config.TraceType) map[string]string {
 	switch traceType {
 	case config.TraceTypeCannon, config.TraceTypePermissioned:
 		addRequiredCannonArgs(args)
-	case config.TraceTypeAlphabet:
+	case config.TraceTypeAsterisc:
+		addRequiredAsteriscArgs(args)
+	case config.TraceTypeCartesi:
+		addRequiredCartesiArgs(args)
+	case config.TraceTypeExternal:
+		addRequiredExternalArgs(args)
+	case config.TraceTypeCartesiCompute:
+		addRequiredCartesiComputeArgs(args)
+	case config.TraceTypeRemote:
+		addRequiredRemoteArgs(args)
+	case config.TraceTypeAlphabet, config.TraceTypePermissionedAlphabet:
 		addRequiredOutputArgs(args)
 	}
 	return args
 }
 
+func addRequiredAsteriscArgs(args map[string]string) {
+	args["--asterisc-network"] = asteriscNetwork
+	args["--asterisc-bin"] = asteriscBin
+	args["--asterisc-server"] = asteriscServer
+	args["--asterisc-prestate"] = asteriscPreState
+	args["--cannon-l2"] = cannonL2
+	addRequiredOutputArgs(args)
+}
+
 func addRequiredCannonArgs(args map[string]string) {
 	args["--cannon-network"] = cannonNetwork
 	args["--cannon-bin"] = cannonBin
@@ -537,6 +1123,35 @@ func addRequiredCannonArgs(args map[string]string) {
 	addRequiredOutputArgs(args)
 }
 
+func addRequiredCartesiArgs(args map[string]string) {
+	args["--cartesi-network"] = cartesiNetwork
+	args["--cartesi-bin"] = cartesiBin
+	args["--cartesi-server"] = cartesiServer
+	args["--cartesi-snapshot-dir"] = cartesiSnapshotDir
+	args["--cannon-l2"] = cannonL2
+	addRequiredOutputArgs(args)
+}
+
+func addRequiredExternalArgs(args map[string]string) {
+	args["--external-command"] = externalCommand
+	args["--game-types"] = "42=external"
+	args["--cannon-l2"] = cannonL2
+	addRequiredOutputArgs(args)
+}
+
+func addRequiredCartesiComputeArgs(args map[string]string) {
+	args["--cartesi-compute-server"] = cartesiComputeServer
+	args["--game-types"] = "43=cartesi-compute"
+	addRequiredOutputArgs(args)
+}
+
+func addRequiredRemoteArgs(args map[string]string) {
+	args["--remote-trace-url"] = "44=" + remoteTraceURL
+	args["--game-types"] = "44=remote"
+	args["--cannon-l2"] = cannonL2
+	addRequiredOutputArgs(args)
+}
+
 func addRequiredOutputArgs(args map[string]string) {
 	args["--rollup-rpc"] = rollupRpc
 }
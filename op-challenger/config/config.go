@@ -3,12 +3,16 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"runtime"
 	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -34,17 +38,97 @@ var (
 	ErrCannonNetworkAndL2Genesis     = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown          = errors.New("unknown cannon network")
 	ErrMissingRollupRpc              = errors.New("missing rollup rpc url")
+	ErrInvalidL2RpcTraceType         = errors.New("l2 rpc configured for invalid trace type")
+	ErrInvalidGameTypeTraceType      = errors.New("game type mapped to invalid trace type")
+	ErrGameTypeTraceTypeNotEnabled   = errors.New("game type mapped to trace type that is not enabled")
+	ErrDuplicateGameType             = errors.New("game type mapped more than once")
+	ErrGameTypePreStateUnsupported   = errors.New("absolute pre-state can not be overridden for trace type")
+	ErrGameTypePreStateNotRegistered = errors.New("absolute pre-state configured for game type that is not registered")
+	ErrDuplicateGameTypePreState     = errors.New("absolute pre-state configured more than once for game type")
+	ErrInvalidGameParams             = errors.New("split depth must be less than max game depth")
+	ErrInvalidSyncReference          = errors.New("invalid sync reference")
+	ErrInvalidParticipationMode      = errors.New("invalid participation mode")
+	ErrInvalidLargePreimageChunkSize = errors.New("invalid large preimage chunk size")
+	ErrInvalidGameTypeOracle         = errors.New("invalid preimage oracle address for game type")
+	ErrInvalidGameTypeDelayedWETH    = errors.New("invalid DelayedWETH address for game type")
+
+	ErrMissingAsteriscBin              = errors.New("missing asterisc bin")
+	ErrMissingAsteriscServer           = errors.New("missing asterisc server")
+	ErrMissingAsteriscAbsolutePreState = errors.New("missing asterisc absolute pre-state")
+	ErrMissingAsteriscSnapshotFreq     = errors.New("missing asterisc snapshot freq")
+	ErrMissingAsteriscInfoFreq         = errors.New("missing asterisc info freq")
+	ErrMissingAsteriscRollupConfig     = errors.New("missing asterisc network or rollup config path")
+	ErrMissingAsteriscL2Genesis        = errors.New("missing asterisc network or l2 genesis path")
+	ErrAsteriscNetworkAndRollupConfig  = errors.New("only specify one of asterisc network or rollup config path")
+	ErrAsteriscNetworkAndL2Genesis     = errors.New("only specify one of asterisc network or l2 genesis path")
+	ErrAsteriscNetworkUnknown          = errors.New("unknown asterisc network")
+
+	ErrMissingCartesiBin             = errors.New("missing cartesi bin")
+	ErrMissingCartesiServer          = errors.New("missing cartesi server")
+	ErrMissingCartesiSnapshotDir     = errors.New("missing cartesi snapshot dir")
+	ErrInvalidCartesiMachineURL      = errors.New("invalid cartesi machine url")
+	ErrMissingCartesiSnapshotFreq    = errors.New("missing cartesi snapshot freq")
+	ErrMissingCartesiRollupConfig    = errors.New("missing cartesi network or rollup config path")
+	ErrMissingCartesiL2Genesis       = errors.New("missing cartesi network or l2 genesis path")
+	ErrCartesiNetworkAndRollupConfig = errors.New("only specify one of cartesi network or rollup config path")
+	ErrCartesiNetworkAndL2Genesis    = errors.New("only specify one of cartesi network or l2 genesis path")
+	ErrCartesiNetworkUnknown         = errors.New("unknown cartesi network")
+
+	ErrMissingExternalCommand  = errors.New("missing external trace provider command")
+	ErrMissingExternalGameType = errors.New("external trace type must be mapped to a game type")
+
+	ErrMissingCartesiComputeServer   = errors.New("missing cartesi compute pre-image server url")
+	ErrInvalidCartesiComputeServer   = errors.New("invalid cartesi compute pre-image server url")
+	ErrMissingCartesiComputeGameType = errors.New("cartesi compute trace type must be mapped to a game type")
+
+	ErrMissingRemoteGameType = errors.New("remote trace type must be mapped to a game type")
+	ErrMissingRemoteTraceURL = errors.New("missing remote trace url")
+	ErrInvalidRemoteTraceURL = errors.New("invalid remote trace url")
 )
 
 type TraceType string
 
+// GameTypeConfig maps a game type to the trace type used to play it.
+type GameTypeConfig struct {
+	GameType  uint32
+	TraceType TraceType
+	// AbsolutePreState overrides the trace type's absolute pre-state for this game type (optional).
+	// For the cartesi trace type this is the directory of the stored initial machine.
+	AbsolutePreState string
+}
+
 const (
-	TraceTypeAlphabet     TraceType = "alphabet"
-	TraceTypeCannon       TraceType = "cannon"
-	TraceTypePermissioned TraceType = "permissioned"
+	TraceTypeAlphabet             TraceType = "alphabet"
+	TraceTypePermissionedAlphabet TraceType = "permissioned-alphabet"
+	TraceTypeCannon               TraceType = "cannon"
+	TraceTypePermissioned         TraceType = "permissioned"
+	TraceTypeAsterisc             TraceType = "asterisc"
+	TraceTypeCartesi              TraceType = "cartesi"
+	TraceTypeExternal             TraceType = "external"
+	TraceTypeCartesiCompute       TraceType = "cartesi-compute"
+	TraceTypeRemote               TraceType = "remote"
 )
 
-var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypePermissioned}
+var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypePermissionedAlphabet, TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc, TraceTypeCartesi, TraceTypeExternal, TraceTypeCartesiCompute, TraceTypeRemote}
+
+// BuiltinGameTypes are the game types registered for each enabled trace type when no game type mapping is configured.
+var BuiltinGameTypes = []GameTypeConfig{
+	{GameType: faultTypes.CannonGameType, TraceType: TraceTypeCannon},
+	{GameType: faultTypes.PermissionedGameType, TraceType: TraceTypePermissioned},
+	{GameType: faultTypes.AsteriscGameType, TraceType: TraceTypeAsterisc},
+	{GameType: faultTypes.CartesiGameType, TraceType: TraceTypeCartesi},
+	{GameType: faultTypes.PermissionedAlphabetGameType, TraceType: TraceTypePermissionedAlphabet},
+	{GameType: faultTypes.AlphabetGameType, TraceType: TraceTypeAlphabet},
+}
+
+// AlphabetTraceTypes are the trace types played with the alphabet trace provider.
+var AlphabetTraceTypes = []TraceType{TraceTypeAlphabet, TraceTypePermissionedAlphabet}
+
+// NoPreStateTraceTypes are the trace types whose absolute pre-state can't be configured.
+var NoPreStateTraceTypes = []TraceType{TraceTypeAlphabet, TraceTypePermissionedAlphabet, TraceTypeExternal, TraceTypeCartesiCompute, TraceTypeRemote}
+
+// L2TraceTypes are the trace types that read from an L2 node to generate their traces.
+var L2TraceTypes = []TraceType{TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc, TraceTypeCartesi, TraceTypeExternal, TraceTypeRemote}
 
 func (t TraceType) String() string {
 	return string(t)
@@ -74,17 +158,127 @@ func ValidTraceType(value TraceType) bool {
 }
 
 const (
-	DefaultPollInterval       = time.Second * 12
-	DefaultCannonSnapshotFreq = uint(1_000_000_000)
-	DefaultCannonInfoFreq     = uint(10_000_000)
+	DefaultPollInterval         = time.Second * 12
+	DefaultCannonSnapshotFreq   = uint(1_000_000_000)
+	DefaultCannonInfoFreq       = uint(10_000_000)
+	DefaultAsteriscSnapshotFreq = uint(1_000_000_000)
+	DefaultAsteriscInfoFreq     = uint(10_000_000)
+	DefaultCartesiSnapshotFreq  = uint(1_000_000_000)
+	DefaultExternalTimeout      = time.Hour
 	// DefaultGameWindow is the default maximum time duration in the past
 	// that the challenger will look for games to progress.
 	// The default value is 11 days, which is a 4 day resolution buffer
 	// and bond claiming buffer plus the 7 day game finalization window.
 	DefaultGameWindow   = time.Duration(11 * 24 * time.Hour)
 	DefaultMaxPendingTx = 10
+
+	// DefaultPlayerCreationTimeout is the default maximum time to spend loading a game's data to create its player.
+	DefaultPlayerCreationTimeout = 30 * time.Second
+
+	// DefaultCannonPreStateTimeout is the default maximum time to spend downloading the cannon absolute
+	// pre-state from an http(s) URL.
+	DefaultCannonPreStateTimeout = 10 * time.Minute
+
+	// DefaultCartesiComputeTimeout is the default maximum time to wait for a response from the cartesi compute
+	// pre-image server.
+	DefaultCartesiComputeTimeout = 30 * time.Second
+
+	// DefaultRemoteTraceTimeout is the default maximum time to wait for a response from a remote trace service,
+	// which may run the VM to compute the response.
+	DefaultRemoteTraceTimeout = 10 * time.Minute
+)
+
+// DefaultGameParams are the expected parameters of games on public networks.
+var DefaultGameParams = GameParams{
+	MaxGameDepth: 73,
+	SplitDepth:   32,
+	GameDuration: 7 * 24 * time.Hour,
+}
+
+// GameParams are the parameters a game must be deployed with for the challenger to play it.
+// A zero value means the parameter is not checked. GameDuration is the game's max clock duration,
+// the dispute game contract has no separate clock extension to check.
+type GameParams struct {
+	MaxGameDepth uint64
+	SplitDepth   uint64
+	GameDuration time.Duration
+}
+
+func (p GameParams) Check() error {
+	if p.MaxGameDepth != 0 && p.SplitDepth >= p.MaxGameDepth {
+		return fmt.Errorf("%w: split depth %v, max game depth %v", ErrInvalidGameParams, p.SplitDepth, p.MaxGameDepth)
+	}
+	return nil
+}
+
+// DefaultOracleParams are the expected parameters of preimage oracles on public networks.
+// The version is not checked by default as the PreimageOracle contract does not report one.
+var DefaultOracleParams = OracleParams{
+	ChallengePeriod: 24 * time.Hour,
+	MinBond:         250_000_000_000_000_000, // 0.25 ETH
+}
+
+// OracleParams are the parameters the preimage oracle used by a game type must have for the game type to be registered.
+// A zero value means the parameter is not checked.
+type OracleParams struct {
+	Version         string
+	ChallengePeriod time.Duration
+	MinBond         uint64 // Minimum bond for large preimage proposals in wei
+}
+
+// SyncReference is the rollup node progress the challenger requires to be past a game's L1 head before acting on it.
+type SyncReference string
+
+const (
+	// SyncReferenceCurrentL1 measures progress by the L1 block the node has derived up to.
+	SyncReferenceCurrentL1 SyncReference = "current-l1"
+	// SyncReferenceSafe measures progress by the L1 origin of the node's safe L2 head.
+	SyncReferenceSafe SyncReference = "safe"
+	// SyncReferenceFinalized measures progress by the L1 origin of the node's finalized L2 head.
+	SyncReferenceFinalized SyncReference = "finalized"
 )
 
+var SyncReferences = []SyncReference{SyncReferenceCurrentL1, SyncReferenceSafe, SyncReferenceFinalized}
+
+// DefaultSyncThresholds require the node to have derived past the game's L1 head with no lag allowed.
+var DefaultSyncThresholds = SyncThresholds{
+	Reference: SyncReferenceCurrentL1,
+}
+
+// SyncThresholds control how far behind the rollup node may be for the challenger to act on a game.
+type SyncThresholds struct {
+	Reference    SyncReference // Node progress compared against the game's L1 head
+	MaxLagBlocks uint64        // Number of blocks the reference may be short of passing the game's L1 head
+	MaxLagTime   time.Duration // Maximum age of the reference relative to the L1 head (0 == no limit)
+}
+
+func (s SyncThresholds) Check() error {
+	if !slices.Contains(SyncReferences, s.Reference) {
+		return fmt.Errorf("%w: %v", ErrInvalidSyncReference, s.Reference)
+	}
+	return nil
+}
+
+// ParticipationMode controls which transactions the challenger sends for a game.
+type ParticipationMode string
+
+const (
+	// ParticipationAct plays games fully.
+	ParticipationAct ParticipationMode = "act"
+	// ParticipationDefendOnly only posts claims and steps that support the root claim.
+	ParticipationDefendOnly ParticipationMode = "defend-only"
+	// ParticipationChallengeOnly only posts claims and steps that dispute the root claim.
+	ParticipationChallengeOnly ParticipationMode = "challenge-only"
+	// ParticipationObserve sends no transactions, only logging and recording metrics for the actions it would take.
+	ParticipationObserve ParticipationMode = "observe"
+)
+
+var ParticipationModes = []ParticipationMode{ParticipationAct, ParticipationDefendOnly, ParticipationChallengeOnly, ParticipationObserve}
+
+func ValidParticipationMode(value ParticipationMode) bool {
+	return slices.Contains(ParticipationModes, value)
+}
+
 // Config is a well typed config that is parsed from the CLI params.
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
@@ -99,24 +293,103 @@ type Config struct {
 	PollInterval         time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
 	AllowInvalidPrestate bool             // Whether to allow responding to games where the prestate does not match
 
+	// Whether to register game types whose local absolute prestate does not match the game implementation.
+	AllowPrestateMismatch bool
+
+	// Maximum time to spend loading a game's data to create its player (0 == no limit).
+	// Players that time out are created again the next time the game is scheduled.
+	PlayerCreationTimeout time.Duration
+
 	TraceTypes []TraceType // Type of traces supported
 
+	// Game types to register and the trace type used for each.
+	// When empty the built-in game type of each enabled trace type is registered.
+	GameTypes []GameTypeConfig
+
+	// Absolute pre-states of specific game types, overriding the absolute pre-state of their trace type.
+	// Applies to built-in game types as well as those in GameTypes.
+	GameTypePreStates map[uint32]string
+
 	// Specific to the output cannon trace type
 	RollupRpc string
 
 	// Specific to the cannon trace provider
 	CannonBin              string // Path to the cannon executable to run when generating trace data
 	CannonServer           string // Path to the op-program executable that provides the pre-image oracle server
-	CannonAbsolutePreState string // File to load the absolute pre-state for Cannon traces from
+	CannonAbsolutePreState string // File or http(s) URL to load the absolute pre-state for Cannon traces from
 	CannonNetwork          string
 	CannonRollupConfigPath string
 	CannonL2GenesisPath    string
-	CannonL2               string // L2 RPC Url
+	CannonL2               string // L2 RPC Url, used by every trace type without an entry in L2Rpcs
 	CannonSnapshotFreq     uint   // Frequency of snapshots to create when executing cannon (in VM instructions)
 	CannonInfoFreq         uint   // Frequency of cannon progress log messages (in VM instructions)
 
+	// Maximum time to spend downloading CannonAbsolutePreState when it is an http(s) URL (0 == no limit).
+	CannonPreStateTimeout time.Duration
+
+	// Additional absolute pre-state files, or directories of them, for Cannon traces.
+	// When set, each game uses the pre-state matching its on-chain absolute pre-state hash.
+	CannonAbsolutePreStates []string
+
+	// Additional L2 RPC Urls that Cannon traces fail over to when fetching L2 headers.
+	CannonL2Fallbacks []string
+
+	L2Rpcs map[TraceType]string // L2 RPC Urls for specific trace types, overriding CannonL2
+
+	// Specific to the asterisc trace provider
+	AsteriscBin              string // Path to the asterisc executable to run when generating trace data
+	AsteriscServer           string // Path to the op-program executable that provides the pre-image oracle server
+	AsteriscAbsolutePreState string // File to load the absolute pre-state for Asterisc traces from
+	AsteriscNetwork          string
+	AsteriscRollupConfigPath string
+	AsteriscL2GenesisPath    string
+	AsteriscSnapshotFreq     uint // Frequency of snapshots to create when executing asterisc (in VM instructions)
+	AsteriscInfoFreq         uint // Frequency of asterisc progress log messages (in VM instructions)
+
+	// Specific to the cartesi trace provider
+	CartesiBin              string // Path to the Cartesi machine emulator executable to run when generating trace data
+	CartesiServer           string // Path to the op-program executable that provides the pre-image oracle server
+	CartesiSnapshotDir      string // Directory of the stored initial machine, its root hash is the absolute pre-state
+	CartesiMachineURL       string // Machine server to run the machine on instead of loading the snapshot locally (optional)
+	CartesiNetwork          string
+	CartesiRollupConfigPath string
+	CartesiL2GenesisPath    string
+	CartesiSnapshotFreq     uint // Frequency of snapshots to create when running the machine (in machine cycles)
+
+	// Specific to the external trace provider
+	ExternalCommand string        // Path to the external trace provider executable, run for each game
+	ExternalArgs    []string      // Arguments to pass to the external trace provider before the game parameters
+	ExternalTimeout time.Duration // Maximum time to wait for a response from the external trace provider (0 == no limit)
+
+	// Specific to the cartesi compute trace provider
+	CartesiComputeServer  string        // URL of the trusted pre-image server HTTP API that resolves cartesi compute claims
+	CartesiComputeTimeout time.Duration // Maximum time to wait for each response from the pre-image server (0 == no limit)
+
+	// Specific to the remote trace provider
+	RemoteTraceURLs    map[uint32]string // URL of the remote trace service of each game type played with the remote trace type
+	RemoteTraceTimeout time.Duration     // Maximum time to wait for each response from a remote trace service (0 == no limit)
+
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
+	GameParams     GameParams            // Expected parameters of games
+	GameTypeParams map[uint32]GameParams // Expected parameters of games of specific game types, overriding GameParams
+
+	SyncThresholds SyncThresholds // How far behind the rollup node may be when acting on games
+
+	Participation         ParticipationMode            // Transactions to send for games
+	GameTypeParticipation map[uint32]ParticipationMode // Transactions to send for games of specific game types, overriding Participation
+
+	LargePreimageChunkSize int // Maximum number of bytes of a large preimage to add to the oracle per transaction
+
+	OracleParams OracleParams // Expected parameters of the preimage oracle used by each game type
+
+	// Preimage oracle addresses for specific game types, used instead of the oracle of the game type's implementation.
+	GameTypeOracles map[uint32]common.Address
+
+	// DelayedWETH contracts holding the bonds of games of specific game types.
+	// Bonds of other game types are held by the game contract itself.
+	GameTypeDelayedWETH map[uint32]common.Address
+
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -136,6 +409,8 @@ func NewConfig(
 		MaxConcurrency:     uint(runtime.NumCPU()),
 		PollInterval:       DefaultPollInterval,
 
+		PlayerCreationTimeout: DefaultPlayerCreationTimeout,
+
 		TraceTypes: supportedTraceTypes,
 
 		MaxPendingTx: DefaultMaxPendingTx,
@@ -146,16 +421,97 @@ func NewConfig(
 
 		Datadir: datadir,
 
-		CannonSnapshotFreq: DefaultCannonSnapshotFreq,
-		CannonInfoFreq:     DefaultCannonInfoFreq,
-		GameWindow:         DefaultGameWindow,
+		CannonSnapshotFreq:   DefaultCannonSnapshotFreq,
+		CannonInfoFreq:       DefaultCannonInfoFreq,
+		AsteriscSnapshotFreq: DefaultAsteriscSnapshotFreq,
+		AsteriscInfoFreq:     DefaultAsteriscInfoFreq,
+		CartesiSnapshotFreq:  DefaultCartesiSnapshotFreq,
+		ExternalTimeout:      DefaultExternalTimeout,
+		GameWindow:           DefaultGameWindow,
+		GameParams:           DefaultGameParams,
+		SyncThresholds:       DefaultSyncThresholds,
+		Participation:        ParticipationAct,
+
+		CannonPreStateTimeout: DefaultCannonPreStateTimeout,
+		CartesiComputeTimeout: DefaultCartesiComputeTimeout,
+		RemoteTraceTimeout:    DefaultRemoteTraceTimeout,
+
+		LargePreimageChunkSize: preimages.MaxChunkSize,
+		OracleParams:           DefaultOracleParams,
+	}
+}
+
+// ExpectedGameParams returns the parameters games of gameType must be deployed with, falling back to GameParams.
+func (c Config) ExpectedGameParams(gameType uint32) GameParams {
+	if params, ok := c.GameTypeParams[gameType]; ok {
+		return params
+	}
+	return c.GameParams
+}
+
+// ParticipationMode returns the participation mode for games of gameType, falling back to Participation.
+func (c Config) ParticipationMode(gameType uint32) ParticipationMode {
+	if mode, ok := c.GameTypeParticipation[gameType]; ok {
+		return mode
+	}
+	return c.Participation
+}
+
+// L2Rpc returns the L2 RPC URL to use for games of traceType, falling back to CannonL2.
+func (c Config) L2Rpc(traceType TraceType) string {
+	if l2Rpc, ok := c.L2Rpcs[traceType]; ok && l2Rpc != "" {
+		return l2Rpc
+	}
+	return c.CannonL2
+}
+
+// L2Fallbacks returns the L2 RPC URLs to fail over to when fetching L2 headers for games of traceType.
+func (c Config) L2Fallbacks(traceType TraceType) []string {
+	if traceType == TraceTypeCannon || traceType == TraceTypePermissioned {
+		return c.CannonL2Fallbacks
 	}
+	return nil
 }
 
 func (c Config) TraceTypeEnabled(t TraceType) bool {
 	return slices.Contains(c.TraceTypes, t)
 }
 
+// GameTypesToRegister returns the configured game type mapping, or the built-in game types of the enabled trace types.
+// Game types without an absolute pre-state in the mapping use their entry in GameTypePreStates, if any.
+func (c Config) GameTypesToRegister() []GameTypeConfig {
+	gameTypes := c.GameTypes
+	if len(gameTypes) == 0 {
+		for _, gameType := range BuiltinGameTypes {
+			if c.TraceTypeEnabled(gameType.TraceType) {
+				gameTypes = append(gameTypes, gameType)
+			}
+		}
+	}
+	if len(c.GameTypePreStates) == 0 {
+		return gameTypes
+	}
+	withPreStates := make([]GameTypeConfig, len(gameTypes))
+	for i, gameType := range gameTypes {
+		if prestate, ok := c.GameTypePreStates[gameType.GameType]; ok && gameType.AbsolutePreState == "" {
+			gameType.AbsolutePreState = prestate
+		}
+		withPreStates[i] = gameType
+	}
+	return withPreStates
+}
+
+// requiresDefaultPreState returns true if a game type is played with one of traceTypes
+// without overriding the absolute pre-state.
+func (c Config) requiresDefaultPreState(traceTypes ...TraceType) bool {
+	for _, gameType := range c.GameTypesToRegister() {
+		if slices.Contains(traceTypes, gameType.TraceType) && gameType.AbsolutePreState == "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (c Config) Check() error {
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
@@ -178,6 +534,77 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	for traceType := range c.L2Rpcs {
+		if !ValidTraceType(traceType) {
+			return fmt.Errorf("%w: %v", ErrInvalidL2RpcTraceType, traceType)
+		}
+	}
+	if err := c.GameParams.Check(); err != nil {
+		return err
+	}
+	if err := c.SyncThresholds.Check(); err != nil {
+		return err
+	}
+	if !ValidParticipationMode(c.Participation) {
+		return fmt.Errorf("%w: %v", ErrInvalidParticipationMode, c.Participation)
+	}
+	for gameType, mode := range c.GameTypeParticipation {
+		if !ValidParticipationMode(mode) {
+			return fmt.Errorf("%w: game type %v mode %v", ErrInvalidParticipationMode, gameType, mode)
+		}
+	}
+	if c.LargePreimageChunkSize <= 0 || c.LargePreimageChunkSize > preimages.MaxChunkSize || c.LargePreimageChunkSize%keccakTypes.BlockSize != 0 {
+		return fmt.Errorf("%w: %v must be a multiple of %v up to %v", ErrInvalidLargePreimageChunkSize, c.LargePreimageChunkSize, keccakTypes.BlockSize, preimages.MaxChunkSize)
+	}
+	for gameType, oracle := range c.GameTypeOracles {
+		if oracle == (common.Address{}) {
+			return fmt.Errorf("%w: %v", ErrInvalidGameTypeOracle, gameType)
+		}
+	}
+	for gameType, weth := range c.GameTypeDelayedWETH {
+		if weth == (common.Address{}) {
+			return fmt.Errorf("%w: %v", ErrInvalidGameTypeDelayedWETH, gameType)
+		}
+	}
+	for gameType, params := range c.GameTypeParams {
+		if err := params.Check(); err != nil {
+			return fmt.Errorf("game type %v: %w", gameType, err)
+		}
+	}
+	gameTypes := make(map[uint32]bool)
+	for _, gameType := range c.GameTypes {
+		if !ValidTraceType(gameType.TraceType) {
+			return fmt.Errorf("%w: game type %v trace type %v", ErrInvalidGameTypeTraceType, gameType.GameType, gameType.TraceType)
+		}
+		if !c.TraceTypeEnabled(gameType.TraceType) {
+			return fmt.Errorf("%w: game type %v trace type %v", ErrGameTypeTraceTypeNotEnabled, gameType.GameType, gameType.TraceType)
+		}
+		if slices.Contains(NoPreStateTraceTypes, gameType.TraceType) && gameType.AbsolutePreState != "" {
+			return fmt.Errorf("%w: %v", ErrGameTypePreStateUnsupported, gameType.TraceType)
+		}
+		if gameTypes[gameType.GameType] {
+			return fmt.Errorf("%w: %v", ErrDuplicateGameType, gameType.GameType)
+		}
+		gameTypes[gameType.GameType] = true
+	}
+	registered := c.GameTypesToRegister()
+	for gameType := range c.GameTypePreStates {
+		idx := slices.IndexFunc(registered, func(g GameTypeConfig) bool { return g.GameType == gameType })
+		if idx < 0 {
+			return fmt.Errorf("%w: %v", ErrGameTypePreStateNotRegistered, gameType)
+		}
+		if len(c.GameTypes) > 0 && c.GameTypes[idx].AbsolutePreState != "" {
+			return fmt.Errorf("%w: %v", ErrDuplicateGameTypePreState, gameType)
+		}
+		if traceType := registered[idx].TraceType; slices.Contains(NoPreStateTraceTypes, traceType) {
+			return fmt.Errorf("%w: %v", ErrGameTypePreStateUnsupported, traceType)
+		}
+	}
+	for _, traceType := range c.TraceTypes {
+		if slices.Contains(L2TraceTypes, traceType) && c.L2Rpc(traceType) == "" {
+			return fmt.Errorf("%w for trace type %v", ErrMissingCannonL2, traceType)
+		}
+	}
 	if c.TraceTypeEnabled(TraceTypeCannon) || c.TraceTypeEnabled(TraceTypePermissioned) {
 		if c.CannonBin == "" {
 			return ErrMissingCannonBin
@@ -203,12 +630,9 @@ func (c Config) Check() error {
 				return fmt.Errorf("%w: %v", ErrCannonNetworkUnknown, c.CannonNetwork)
 			}
 		}
-		if c.CannonAbsolutePreState == "" {
+		if c.CannonAbsolutePreState == "" && len(c.CannonAbsolutePreStates) == 0 && c.requiresDefaultPreState(TraceTypeCannon, TraceTypePermissioned) {
 			return ErrMissingCannonAbsolutePreState
 		}
-		if c.CannonL2 == "" {
-			return ErrMissingCannonL2
-		}
 		if c.CannonSnapshotFreq == 0 {
 			return ErrMissingCannonSnapshotFreq
 		}
@@ -216,6 +640,117 @@ func (c Config) Check() error {
 			return ErrMissingCannonInfoFreq
 		}
 	}
+	if c.TraceTypeEnabled(TraceTypeAsterisc) {
+		if c.AsteriscBin == "" {
+			return ErrMissingAsteriscBin
+		}
+		if c.AsteriscServer == "" {
+			return ErrMissingAsteriscServer
+		}
+		if c.AsteriscNetwork == "" {
+			if c.AsteriscRollupConfigPath == "" {
+				return ErrMissingAsteriscRollupConfig
+			}
+			if c.AsteriscL2GenesisPath == "" {
+				return ErrMissingAsteriscL2Genesis
+			}
+		} else {
+			if c.AsteriscRollupConfigPath != "" {
+				return ErrAsteriscNetworkAndRollupConfig
+			}
+			if c.AsteriscL2GenesisPath != "" {
+				return ErrAsteriscNetworkAndL2Genesis
+			}
+			if ch := chaincfg.ChainByName(c.AsteriscNetwork); ch == nil {
+				return fmt.Errorf("%w: %v", ErrAsteriscNetworkUnknown, c.AsteriscNetwork)
+			}
+		}
+		if c.AsteriscAbsolutePreState == "" && c.requiresDefaultPreState(TraceTypeAsterisc) {
+			return ErrMissingAsteriscAbsolutePreState
+		}
+		if c.AsteriscSnapshotFreq == 0 {
+			return ErrMissingAsteriscSnapshotFreq
+		}
+		if c.AsteriscInfoFreq == 0 {
+			return ErrMissingAsteriscInfoFreq
+		}
+	}
+	if c.TraceTypeEnabled(TraceTypeCartesi) {
+		if c.CartesiBin == "" {
+			return ErrMissingCartesiBin
+		}
+		if c.CartesiServer == "" {
+			return ErrMissingCartesiServer
+		}
+		if c.CartesiNetwork == "" {
+			if c.CartesiRollupConfigPath == "" {
+				return ErrMissingCartesiRollupConfig
+			}
+			if c.CartesiL2GenesisPath == "" {
+				return ErrMissingCartesiL2Genesis
+			}
+		} else {
+			if c.CartesiRollupConfigPath != "" {
+				return ErrCartesiNetworkAndRollupConfig
+			}
+			if c.CartesiL2GenesisPath != "" {
+				return ErrCartesiNetworkAndL2Genesis
+			}
+			if ch := chaincfg.ChainByName(c.CartesiNetwork); ch == nil {
+				return fmt.Errorf("%w: %v", ErrCartesiNetworkUnknown, c.CartesiNetwork)
+			}
+		}
+		if c.CartesiSnapshotDir == "" && c.requiresDefaultPreState(TraceTypeCartesi) {
+			return ErrMissingCartesiSnapshotDir
+		}
+		if c.CartesiMachineURL != "" {
+			if u, err := url.Parse(c.CartesiMachineURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%w: %v", ErrInvalidCartesiMachineURL, c.CartesiMachineURL)
+			}
+		}
+		if c.CartesiSnapshotFreq == 0 {
+			return ErrMissingCartesiSnapshotFreq
+		}
+	}
+	if c.TraceTypeEnabled(TraceTypeExternal) {
+		if c.ExternalCommand == "" {
+			return ErrMissingExternalCommand
+		}
+		// There is no built-in game type for external trace providers.
+		if !slices.ContainsFunc(c.GameTypes, func(gameType GameTypeConfig) bool { return gameType.TraceType == TraceTypeExternal }) {
+			return ErrMissingExternalGameType
+		}
+	}
+	if c.TraceTypeEnabled(TraceTypeCartesiCompute) {
+		if c.CartesiComputeServer == "" {
+			return ErrMissingCartesiComputeServer
+		}
+		if u, err := url.Parse(c.CartesiComputeServer); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %v", ErrInvalidCartesiComputeServer, c.CartesiComputeServer)
+		}
+		// There is no built-in game type for cartesi compute games.
+		if !slices.ContainsFunc(c.GameTypes, func(gameType GameTypeConfig) bool { return gameType.TraceType == TraceTypeCartesiCompute }) {
+			return ErrMissingCartesiComputeGameType
+		}
+	}
+	if c.TraceTypeEnabled(TraceTypeRemote) {
+		// There is no built-in game type for remote trace providers.
+		if !slices.ContainsFunc(c.GameTypes, func(gameType GameTypeConfig) bool { return gameType.TraceType == TraceTypeRemote }) {
+			return ErrMissingRemoteGameType
+		}
+		for _, gameType := range c.GameTypes {
+			if gameType.TraceType != TraceTypeRemote {
+				continue
+			}
+			traceURL, ok := c.RemoteTraceURLs[gameType.GameType]
+			if !ok {
+				return fmt.Errorf("%w for game type %v", ErrMissingRemoteTraceURL, gameType.GameType)
+			}
+			if u, err := url.Parse(traceURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%w: game type %v url %v", ErrInvalidRemoteTraceURL, gameType.GameType, traceURL)
+			}
+		}
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -22,6 +25,25 @@ var (
 	validDatadir               = "/tmp/data"
 	validCannonL2              = "http://localhost:9545"
 	validRollupRpc             = "http://localhost:8555"
+
+	validAsteriscBin             = "./bin/asterisc"
+	validAsteriscOpProgramBin    = "./bin/op-program"
+	validAsteriscNetwork         = "mainnet"
+	validAsteriscAbsolutPreState = "pre.json"
+
+	validCartesiBin         = "./bin/cartesi-machine"
+	validCartesiServer      = "./bin/op-program"
+	validCartesiNetwork     = "mainnet"
+	validCartesiSnapshotDir = "./machine"
+
+	validExternalCommand  = "./bin/trace-provider"
+	validExternalGameType = uint32(42)
+
+	validCartesiComputeServer   = "http://localhost:7000"
+	validCartesiComputeGameType = uint32(43)
+
+	validRemoteTraceURL = "http://localhost:7001"
+	validRemoteGameType = uint32(44)
 )
 
 var cannonTraceTypes = []TraceType{TraceTypeCannon, TraceTypePermissioned}
@@ -35,6 +57,34 @@ func validConfig(traceType TraceType) Config {
 		cfg.CannonL2 = validCannonL2
 		cfg.CannonNetwork = validCannonNetwork
 	}
+	if traceType == TraceTypeCartesi {
+		cfg.CartesiBin = validCartesiBin
+		cfg.CartesiServer = validCartesiServer
+		cfg.CartesiSnapshotDir = validCartesiSnapshotDir
+		cfg.CartesiNetwork = validCartesiNetwork
+		cfg.CannonL2 = validCannonL2
+	}
+	if traceType == TraceTypeAsterisc {
+		cfg.AsteriscBin = validAsteriscBin
+		cfg.AsteriscServer = validAsteriscOpProgramBin
+		cfg.AsteriscAbsolutePreState = validAsteriscAbsolutPreState
+		cfg.AsteriscNetwork = validAsteriscNetwork
+		cfg.CannonL2 = validCannonL2
+	}
+	if traceType == TraceTypeExternal {
+		cfg.ExternalCommand = validExternalCommand
+		cfg.GameTypes = []GameTypeConfig{{GameType: validExternalGameType, TraceType: TraceTypeExternal}}
+		cfg.CannonL2 = validCannonL2
+	}
+	if traceType == TraceTypeCartesiCompute {
+		cfg.CartesiComputeServer = validCartesiComputeServer
+		cfg.GameTypes = []GameTypeConfig{{GameType: validCartesiComputeGameType, TraceType: TraceTypeCartesiCompute}}
+	}
+	if traceType == TraceTypeRemote {
+		cfg.RemoteTraceURLs = map[uint32]string{validRemoteGameType: validRemoteTraceURL}
+		cfg.GameTypes = []GameTypeConfig{{GameType: validRemoteGameType, TraceType: TraceTypeRemote}}
+		cfg.CannonL2 = validCannonL2
+	}
 	cfg.RollupRpc = validRollupRpc
 	return cfg
 }
@@ -104,6 +154,13 @@ func TestCannonRequiredArgs(t *testing.T) {
 			require.ErrorIs(t, config.Check(), ErrMissingCannonAbsolutePreState)
 		})
 
+		t.Run(fmt.Sprintf("TestCannonAbsolutePreStatesReplacePreState-%v", traceType), func(t *testing.T) {
+			config := validConfig(traceType)
+			config.CannonAbsolutePreState = ""
+			config.CannonAbsolutePreStates = []string{"prestates"}
+			require.NoError(t, config.Check())
+		})
+
 		t.Run(fmt.Sprintf("TestCannonL2Required-%v", traceType), func(t *testing.T) {
 			config := validConfig(traceType)
 			config.CannonL2 = ""
@@ -166,6 +223,159 @@ func TestCannonRequiredArgs(t *testing.T) {
 	}
 }
 
+func TestAsteriscRequiredArgs(t *testing.T) {
+	t.Run("TestAsteriscBinRequired", func(t *testing.T) {
+		config := validConfig(TraceTypeAsterisc)
+		config.AsteriscBin = ""
+		require.ErrorIs(t, config.Check(), ErrMissingAsteriscBin)
+	})
+
+	t.Run("TestAsteriscServerRequired", func(t *testing.T) {
+		config := validConfig(TraceTypeAsterisc)
+		config.AsteriscServer = ""
+		require.ErrorIs(t, config.Check(), ErrMissingAsteriscServer)
+	})
+
+	t.Run("TestAsteriscAbsolutePreStateRequired", func(t *testing.T) {
+		config := validConfig(TraceTypeAsterisc)
+		config.AsteriscAbsolutePreState = ""
+		require.ErrorIs(t, config.Check(), ErrMissingAsteriscAbsolutePreState)
+	})
+
+	t.Run("TestAsteriscL2Required", func(t *testing.T) {
+		config := validConfig(TraceTypeAsterisc)
+		config.CannonL2 = ""
+		require.ErrorIs(t, config.Check(), ErrMissingCannonL2)
+	})
+
+	t.Run("TestAsteriscSnapshotFreq", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		require.Equal(t, DefaultAsteriscSnapshotFreq, cfg.AsteriscSnapshotFreq)
+		cfg.AsteriscSnapshotFreq = 0
+		require.ErrorIs(t, cfg.Check(), ErrMissingAsteriscSnapshotFreq)
+	})
+
+	t.Run("TestAsteriscInfoFreq", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		require.Equal(t, DefaultAsteriscInfoFreq, cfg.AsteriscInfoFreq)
+		cfg.AsteriscInfoFreq = 0
+		require.ErrorIs(t, cfg.Check(), ErrMissingAsteriscInfoFreq)
+	})
+
+	t.Run("TestAsteriscNetworkOrRollupConfigRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		cfg.AsteriscNetwork = ""
+		cfg.AsteriscRollupConfigPath = ""
+		cfg.AsteriscL2GenesisPath = "genesis.json"
+		require.ErrorIs(t, cfg.Check(), ErrMissingAsteriscRollupConfig)
+	})
+
+	t.Run("TestAsteriscNetworkOrL2GenesisRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		cfg.AsteriscNetwork = ""
+		cfg.AsteriscRollupConfigPath = "foo.json"
+		cfg.AsteriscL2GenesisPath = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingAsteriscL2Genesis)
+	})
+
+	t.Run("MustNotSpecifyNetworkAndRollup", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		cfg.AsteriscRollupConfigPath = "foo.json"
+		require.ErrorIs(t, cfg.Check(), ErrAsteriscNetworkAndRollupConfig)
+	})
+
+	t.Run("MustNotSpecifyNetworkAndL2Genesis", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		cfg.AsteriscL2GenesisPath = "foo.json"
+		require.ErrorIs(t, cfg.Check(), ErrAsteriscNetworkAndL2Genesis)
+	})
+
+	t.Run("TestNetworkMustBeValid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		cfg.AsteriscNetwork = "unknown"
+		require.ErrorIs(t, cfg.Check(), ErrAsteriscNetworkUnknown)
+	})
+}
+
+func TestCartesiRequiredArgs(t *testing.T) {
+	t.Run("TestCartesiBinRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiBin = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiBin)
+	})
+
+	t.Run("TestCartesiServerRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiServer = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiServer)
+	})
+
+	t.Run("TestCartesiSnapshotDirRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiSnapshotDir = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiSnapshotDir)
+	})
+
+	t.Run("TestCartesiMachineURLOptional", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiMachineURL = "http://localhost:5000"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("TestCartesiMachineURLMustBeValid", func(t *testing.T) {
+		for _, machineURL := range []string{"localhost:5000", "ws://localhost:5000", "http://"} {
+			cfg := validConfig(TraceTypeCartesi)
+			cfg.CartesiMachineURL = machineURL
+			require.ErrorIs(t, cfg.Check(), ErrInvalidCartesiMachineURL, machineURL)
+		}
+	})
+
+	t.Run("TestCartesiL2Required", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CannonL2 = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCannonL2)
+	})
+
+	t.Run("TestCartesiSnapshotFreq", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		require.Equal(t, DefaultCartesiSnapshotFreq, cfg.CartesiSnapshotFreq)
+		cfg.CartesiSnapshotFreq = 0
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiSnapshotFreq)
+	})
+
+	t.Run("TestCartesiNetworkOrRollupConfigRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiNetwork = ""
+		cfg.CartesiL2GenesisPath = "genesis.json"
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiRollupConfig)
+	})
+
+	t.Run("TestCartesiNetworkOrL2GenesisRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiNetwork = ""
+		cfg.CartesiRollupConfigPath = "foo.json"
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiL2Genesis)
+	})
+
+	t.Run("MustNotSpecifyNetworkAndRollup", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiRollupConfigPath = "foo.json"
+		require.ErrorIs(t, cfg.Check(), ErrCartesiNetworkAndRollupConfig)
+	})
+
+	t.Run("MustNotSpecifyNetworkAndL2Genesis", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiL2GenesisPath = "foo.json"
+		require.ErrorIs(t, cfg.Check(), ErrCartesiNetworkAndL2Genesis)
+	})
+
+	t.Run("TestNetworkMustBeValid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CartesiNetwork = "unknown"
+		require.ErrorIs(t, cfg.Check(), ErrCartesiNetworkUnknown)
+	})
+}
+
 func TestDatadirRequired(t *testing.T) {
 	config := validConfig(TraceTypeAlphabet)
 	config.Datadir = ""
@@ -219,3 +429,396 @@ func TestRequireConfigForMultipleTraceTypes(t *testing.T) {
 	cfg.RollupRpc = ""
 	require.ErrorIs(t, cfg.Check(), ErrMissingRollupRpc)
 }
+
+func TestL2Rpcs(t *testing.T) {
+	t.Run("FallbackToCannonL2", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAsterisc)
+		cfg.L2Rpcs = map[TraceType]string{TraceTypeCartesi: "http://cartesi-l2"}
+		require.Equal(t, validCannonL2, cfg.L2Rpc(TraceTypeAsterisc))
+		require.Equal(t, "http://cartesi-l2", cfg.L2Rpc(TraceTypeCartesi))
+	})
+
+	t.Run("SatisfiesL2Requirement", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesi)
+		cfg.CannonL2 = ""
+		cfg.L2Rpcs = map[TraceType]string{TraceTypeCartesi: "http://cartesi-l2"}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("RequiredForEachVMTraceType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.TraceTypes = []TraceType{TraceTypeCannon, TraceTypeAlphabet}
+		cfg.CannonL2 = ""
+		cfg.L2Rpcs = map[TraceType]string{TraceTypeAsterisc: "http://asterisc-l2"}
+		require.ErrorIs(t, cfg.Check(), ErrMissingCannonL2)
+	})
+
+	t.Run("InvalidTraceType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.L2Rpcs = map[TraceType]string{"foo": "http://l2"}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidL2RpcTraceType)
+	})
+}
+
+func TestGameTypes(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypes = []GameTypeConfig{
+			{GameType: 0, TraceType: TraceTypeCannon},
+			{GameType: 42, TraceType: TraceTypeCannon, AbsolutePreState: "custom.json"},
+		}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("InvalidTraceType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypes = []GameTypeConfig{{GameType: 42, TraceType: "foo"}}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidGameTypeTraceType)
+	})
+
+	t.Run("TraceTypeMustBeEnabled", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypes = []GameTypeConfig{{GameType: 42, TraceType: TraceTypeAsterisc}}
+		require.ErrorIs(t, cfg.Check(), ErrGameTypeTraceTypeNotEnabled)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypes = []GameTypeConfig{
+			{GameType: 42, TraceType: TraceTypeCannon},
+			{GameType: 42, TraceType: TraceTypeCannon, AbsolutePreState: "custom.json"},
+		}
+		require.ErrorIs(t, cfg.Check(), ErrDuplicateGameType)
+	})
+
+	t.Run("AlphabetPreStateUnsupported", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.GameTypes = []GameTypeConfig{{GameType: 42, TraceType: TraceTypeAlphabet, AbsolutePreState: "custom.json"}}
+		require.ErrorIs(t, cfg.Check(), ErrGameTypePreStateUnsupported)
+	})
+
+	t.Run("DefaultPreStateNotRequiredWhenOverridden", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.CannonAbsolutePreState = ""
+		cfg.GameTypes = []GameTypeConfig{{GameType: 42, TraceType: TraceTypeCannon, AbsolutePreState: "custom.json"}}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("DefaultPreStateRequiredWhenNotOverridden", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.CannonAbsolutePreState = ""
+		cfg.GameTypes = []GameTypeConfig{
+			{GameType: 0, TraceType: TraceTypeCannon},
+			{GameType: 42, TraceType: TraceTypeCannon, AbsolutePreState: "custom.json"},
+		}
+		require.ErrorIs(t, cfg.Check(), ErrMissingCannonAbsolutePreState)
+	})
+
+	t.Run("GameTypePreStatesReplaceDefault", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.TraceTypes = []TraceType{TraceTypeCannon, TraceTypePermissioned}
+		cfg.CannonAbsolutePreState = ""
+		cfg.GameTypePreStates = map[uint32]string{0: "cannon.json", 1: "permissioned.json"}
+		require.NoError(t, cfg.Check())
+		require.Equal(t, []GameTypeConfig{
+			{GameType: 0, TraceType: TraceTypeCannon, AbsolutePreState: "cannon.json"},
+			{GameType: 1, TraceType: TraceTypePermissioned, AbsolutePreState: "permissioned.json"},
+		}, cfg.GameTypesToRegister())
+	})
+
+	t.Run("GameTypePreStateMissingEntry", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.TraceTypes = []TraceType{TraceTypeCannon, TraceTypePermissioned}
+		cfg.CannonAbsolutePreState = ""
+		cfg.GameTypePreStates = map[uint32]string{0: "cannon.json"}
+		require.ErrorIs(t, cfg.Check(), ErrMissingCannonAbsolutePreState)
+	})
+
+	t.Run("GameTypePreStateLegacyFallback", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.TraceTypes = []TraceType{TraceTypeCannon, TraceTypePermissioned}
+		cfg.GameTypePreStates = map[uint32]string{1: "permissioned.json"}
+		require.NoError(t, cfg.Check())
+		require.Equal(t, []GameTypeConfig{
+			{GameType: 0, TraceType: TraceTypeCannon},
+			{GameType: 1, TraceType: TraceTypePermissioned, AbsolutePreState: "permissioned.json"},
+		}, cfg.GameTypesToRegister())
+	})
+
+	t.Run("GameTypePreStateAppliesToMapping", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.CannonAbsolutePreState = ""
+		cfg.GameTypes = []GameTypeConfig{{GameType: 42, TraceType: TraceTypeCannon}}
+		cfg.GameTypePreStates = map[uint32]string{42: "custom.json"}
+		require.NoError(t, cfg.Check())
+		require.Equal(t, []GameTypeConfig{{GameType: 42, TraceType: TraceTypeCannon, AbsolutePreState: "custom.json"}}, cfg.GameTypesToRegister())
+	})
+
+	t.Run("GameTypePreStateNotRegistered", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypePreStates = map[uint32]string{1: "permissioned.json"}
+		require.ErrorIs(t, cfg.Check(), ErrGameTypePreStateNotRegistered)
+	})
+
+	t.Run("GameTypePreStateDuplicate", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypes = []GameTypeConfig{{GameType: 42, TraceType: TraceTypeCannon, AbsolutePreState: "custom.json"}}
+		cfg.GameTypePreStates = map[uint32]string{42: "other.json"}
+		require.ErrorIs(t, cfg.Check(), ErrDuplicateGameTypePreState)
+	})
+
+	t.Run("GameTypePreStateUnsupported", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.GameTypePreStates = map[uint32]string{255: "custom.json"}
+		require.ErrorIs(t, cfg.Check(), ErrGameTypePreStateUnsupported)
+	})
+}
+
+func TestGameParams(t *testing.T) {
+	t.Run("DefaultForAllGameTypes", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		require.Equal(t, DefaultGameParams, cfg.ExpectedGameParams(0))
+		require.Equal(t, DefaultGameParams, cfg.ExpectedGameParams(42))
+	})
+
+	t.Run("OverriddenPerGameType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		params := GameParams{MaxGameDepth: 50, SplitDepth: 14, GameDuration: 40 * time.Minute}
+		cfg.GameTypeParams = map[uint32]GameParams{42: params}
+		require.NoError(t, cfg.Check())
+		require.Equal(t, DefaultGameParams, cfg.ExpectedGameParams(0))
+		require.Equal(t, params, cfg.ExpectedGameParams(42))
+	})
+
+	t.Run("Unchecked", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameParams = GameParams{}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("SplitDepthMustBeLessThanMaxGameDepth", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameParams.SplitDepth = cfg.GameParams.MaxGameDepth
+		require.ErrorIs(t, cfg.Check(), ErrInvalidGameParams)
+	})
+
+	t.Run("InvalidGameTypeParams", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypeParams = map[uint32]GameParams{42: {MaxGameDepth: 14, SplitDepth: 50}}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidGameParams)
+	})
+}
+
+func TestSyncThresholds(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		require.Equal(t, DefaultSyncThresholds, cfg.SyncThresholds)
+		require.NoError(t, cfg.Check())
+	})
+
+	for _, reference := range SyncReferences {
+		reference := reference
+		t.Run(string(reference), func(t *testing.T) {
+			cfg := validConfig(TraceTypeCannon)
+			cfg.SyncThresholds = SyncThresholds{Reference: reference, MaxLagBlocks: 5, MaxLagTime: time.Minute}
+			require.NoError(t, cfg.Check())
+		})
+	}
+
+	t.Run("InvalidReference", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.SyncThresholds.Reference = "foo"
+		require.ErrorIs(t, cfg.Check(), ErrInvalidSyncReference)
+	})
+}
+
+func TestL2Fallbacks(t *testing.T) {
+	cfg := validConfig(TraceTypeCannon)
+	cfg.CannonL2Fallbacks = []string{"http://l2-fallback"}
+	require.NoError(t, cfg.Check())
+	require.Equal(t, []string{"http://l2-fallback"}, cfg.L2Fallbacks(TraceTypeCannon))
+	require.Equal(t, []string{"http://l2-fallback"}, cfg.L2Fallbacks(TraceTypePermissioned))
+	require.Empty(t, cfg.L2Fallbacks(TraceTypeAsterisc))
+	require.Empty(t, cfg.L2Fallbacks(TraceTypeCartesi))
+}
+
+func TestParticipation(t *testing.T) {
+	t.Run("DefaultAct", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		require.Equal(t, ParticipationAct, cfg.ParticipationMode(0))
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("OverriddenPerGameType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.Participation = ParticipationDefendOnly
+		cfg.GameTypeParticipation = map[uint32]ParticipationMode{255: ParticipationObserve}
+		require.NoError(t, cfg.Check())
+		require.Equal(t, ParticipationDefendOnly, cfg.ParticipationMode(0))
+		require.Equal(t, ParticipationObserve, cfg.ParticipationMode(255))
+	})
+
+	t.Run("InvalidMode", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.Participation = "foo"
+		require.ErrorIs(t, cfg.Check(), ErrInvalidParticipationMode)
+	})
+
+	t.Run("InvalidGameTypeMode", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypeParticipation = map[uint32]ParticipationMode{255: "foo"}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidParticipationMode)
+	})
+}
+
+func TestGameTypeOracles(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypeOracles = map[uint32]common.Address{0: {0xaa}}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("ZeroAddress", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypeOracles = map[uint32]common.Address{0: {}}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidGameTypeOracle)
+	})
+}
+
+func TestGameTypeDelayedWETH(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypeDelayedWETH = map[uint32]common.Address{0: {0xaa}}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("ZeroAddress", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.GameTypeDelayedWETH = map[uint32]common.Address{0: {}}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidGameTypeDelayedWETH)
+	})
+}
+
+func TestLargePreimageChunkSize(t *testing.T) {
+	t.Run("DefaultMax", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		require.Equal(t, preimages.MaxChunkSize, cfg.LargePreimageChunkSize)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("SingleBlock", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.LargePreimageChunkSize = keccakTypes.BlockSize
+		require.NoError(t, cfg.Check())
+	})
+
+	for _, size := range []int{0, -keccakTypes.BlockSize, keccakTypes.BlockSize + 1, preimages.MaxChunkSize + keccakTypes.BlockSize} {
+		size := size
+		t.Run(fmt.Sprintf("Invalid-%v", size), func(t *testing.T) {
+			cfg := validConfig(TraceTypeCannon)
+			cfg.LargePreimageChunkSize = size
+			require.ErrorIs(t, cfg.Check(), ErrInvalidLargePreimageChunkSize)
+		})
+	}
+}
+
+func TestExternalRequiredConfig(t *testing.T) {
+	t.Run("MissingCommand", func(t *testing.T) {
+		cfg := validConfig(TraceTypeExternal)
+		cfg.ExternalCommand = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingExternalCommand)
+	})
+
+	t.Run("MissingGameType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeExternal)
+		cfg.GameTypes = nil
+		require.ErrorIs(t, cfg.Check(), ErrMissingExternalGameType)
+	})
+
+	t.Run("MissingL2", func(t *testing.T) {
+		cfg := validConfig(TraceTypeExternal)
+		cfg.CannonL2 = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCannonL2)
+	})
+
+	t.Run("PreStateOverrideUnsupported", func(t *testing.T) {
+		cfg := validConfig(TraceTypeExternal)
+		cfg.GameTypes[0].AbsolutePreState = "pre.json"
+		require.ErrorIs(t, cfg.Check(), ErrGameTypePreStateUnsupported)
+	})
+
+	t.Run("DefaultTimeout", func(t *testing.T) {
+		cfg := validConfig(TraceTypeExternal)
+		require.Equal(t, DefaultExternalTimeout, cfg.ExternalTimeout)
+	})
+}
+
+func TestCartesiComputeRequiredConfig(t *testing.T) {
+	t.Run("MissingServer", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesiCompute)
+		cfg.CartesiComputeServer = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiComputeServer)
+	})
+
+	for _, server := range []string{"localhost:7000", "ftp://localhost:7000", "http://"} {
+		server := server
+		t.Run("InvalidServer-"+server, func(t *testing.T) {
+			cfg := validConfig(TraceTypeCartesiCompute)
+			cfg.CartesiComputeServer = server
+			require.ErrorIs(t, cfg.Check(), ErrInvalidCartesiComputeServer)
+		})
+	}
+
+	t.Run("MissingGameType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesiCompute)
+		cfg.GameTypes = nil
+		require.ErrorIs(t, cfg.Check(), ErrMissingCartesiComputeGameType)
+	})
+
+	t.Run("L2NotRequired", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesiCompute)
+		require.Empty(t, cfg.CannonL2)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("PreStateOverrideUnsupported", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCartesiCompute)
+		cfg.GameTypes[0].AbsolutePreState = "pre.json"
+		require.ErrorIs(t, cfg.Check(), ErrGameTypePreStateUnsupported)
+	})
+}
+
+func TestRemoteRequiredConfig(t *testing.T) {
+	t.Run("MissingURL", func(t *testing.T) {
+		cfg := validConfig(TraceTypeRemote)
+		cfg.RemoteTraceURLs = map[uint32]string{validRemoteGameType + 1: validRemoteTraceURL}
+		require.ErrorIs(t, cfg.Check(), ErrMissingRemoteTraceURL)
+	})
+
+	for _, traceURL := range []string{"localhost:7001", "ftp://localhost:7001", "http://"} {
+		traceURL := traceURL
+		t.Run("InvalidURL-"+traceURL, func(t *testing.T) {
+			cfg := validConfig(TraceTypeRemote)
+			cfg.RemoteTraceURLs[validRemoteGameType] = traceURL
+			require.ErrorIs(t, cfg.Check(), ErrInvalidRemoteTraceURL)
+		})
+	}
+
+	t.Run("MissingGameType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeRemote)
+		cfg.GameTypes = nil
+		require.ErrorIs(t, cfg.Check(), ErrMissingRemoteGameType)
+	})
+
+	t.Run("L2Required", func(t *testing.T) {
+		cfg := validConfig(TraceTypeRemote)
+		cfg.CannonL2 = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCannonL2)
+	})
+
+	t.Run("PreStateOverrideUnsupported", func(t *testing.T) {
+		cfg := validConfig(TraceTypeRemote)
+		cfg.GameTypes[0].AbsolutePreState = "pre.json"
+		require.ErrorIs(t, cfg.Check(), ErrGameTypePreStateUnsupported)
+	})
+}
@@ -4,15 +4,20 @@ import (
 	"fmt"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -59,6 +64,18 @@ var (
 		EnvVars: prefixEnvVars("TRACE_TYPE"),
 		Value:   cli.NewStringSlice(config.TraceTypeCannon.String()),
 	}
+	GameTypesFlag = &cli.StringSliceFlag{
+		Name: "game-types",
+		Usage: "Game types to register and the trace type to play each with, replacing the built-in game types. " +
+			"Specified as <game-type>=<trace-type> or <game-type>=<trace-type>:<absolute-prestate>, may be repeated",
+		EnvVars: prefixEnvVars("GAME_TYPES"),
+	}
+	GameTypePreStateFlag = &cli.StringSliceFlag{
+		Name: "game-type-prestate",
+		Usage: "Absolute prestate to use for a specific game type instead of the prestate of its trace type. " +
+			"Specified as <game-type>=<absolute-prestate>, may be repeated",
+		EnvVars: prefixEnvVars("GAME_TYPE_PRESTATE"),
+	}
 	DatadirFlag = &cli.StringFlag{
 		Name:    "datadir",
 		Usage:   "Directory to store data generated as part of responding to games",
@@ -83,6 +100,12 @@ var (
 		EnvVars: prefixEnvVars("HTTP_POLL_INTERVAL"),
 		Value:   config.DefaultPollInterval,
 	}
+	PlayerCreationTimeoutFlag = &cli.DurationFlag{
+		Name:    "player-creation-timeout",
+		Usage:   "Maximum time to spend loading a game's data to create its player. Games that time out are retried when next scheduled. 0 for no limit.",
+		EnvVars: prefixEnvVars("PLAYER_CREATION_TIMEOUT"),
+		Value:   config.DefaultPlayerCreationTimeout,
+	}
 	CannonNetworkFlag = &cli.StringFlag{
 		Name: "cannon-network",
 		Usage: fmt.Sprintf(
@@ -113,14 +136,37 @@ var (
 	}
 	CannonPreStateFlag = &cli.StringFlag{
 		Name:    "cannon-prestate",
-		Usage:   "Path to absolute prestate to use when generating trace data (cannon trace type only)",
+		Usage:   "Path or http(s) URL of absolute prestate to use when generating trace data (cannon trace type only)",
 		EnvVars: prefixEnvVars("CANNON_PRESTATE"),
 	}
+	CannonPreStateDownloadTimeoutFlag = &cli.DurationFlag{
+		Name:    "cannon-prestate-download-timeout",
+		Usage:   "Maximum time to spend downloading the absolute prestate when it is an http(s) URL, 0 for no limit (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_PRESTATE_DOWNLOAD_TIMEOUT"),
+		Value:   config.DefaultCannonPreStateTimeout,
+	}
+	CannonPreStatesFlag = &cli.StringSliceFlag{
+		Name: "cannon-prestates",
+		Usage: "Paths to additional absolute prestates, or directories of them. Each game uses the prestate matching " +
+			"its on-chain absolute prestate hash (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_PRESTATES"),
+	}
 	CannonL2Flag = &cli.StringFlag{
 		Name:    "cannon-l2",
-		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (cannon trace type only)",
+		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (cannon, asterisc, cartesi and external trace types only)",
 		EnvVars: prefixEnvVars("CANNON_L2"),
 	}
+	CannonL2FallbacksFlag = &cli.StringSliceFlag{
+		Name:    "cannon-l2-fallbacks",
+		Usage:   "Additional L2 JSON-RPC endpoints to fail over to when fetching L2 block headers (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_L2_FALLBACKS"),
+	}
+	L2RpcsFlag = &cli.StringSliceFlag{
+		Name: "l2-rpcs",
+		Usage: "L2 JSON-RPC endpoints to use for specific trace types, overriding cannon-l2. " +
+			"Specified as <trace-type>=<url>, may be repeated",
+		EnvVars: prefixEnvVars("L2_RPCS"),
+	}
 	CannonSnapshotFreqFlag = &cli.UintFlag{
 		Name:    "cannon-snapshot-freq",
 		Usage:   "Frequency of cannon snapshots to generate in VM steps (cannon trace type only)",
@@ -133,6 +179,135 @@ var (
 		EnvVars: prefixEnvVars("CANNON_INFO_FREQ"),
 		Value:   config.DefaultCannonInfoFreq,
 	}
+	AsteriscNetworkFlag = &cli.StringFlag{
+		Name: "asterisc-network",
+		Usage: fmt.Sprintf(
+			"Predefined network selection. Available networks: %s (asterisc trace type only)",
+			strings.Join(chaincfg.AvailableNetworks(), ", "),
+		),
+		EnvVars: prefixEnvVars("ASTERISC_NETWORK"),
+	}
+	AsteriscRollupConfigFlag = &cli.StringFlag{
+		Name:    "asterisc-rollup-config",
+		Usage:   "Rollup chain parameters (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_ROLLUP_CONFIG"),
+	}
+	AsteriscL2GenesisFlag = &cli.StringFlag{
+		Name:    "asterisc-l2-genesis",
+		Usage:   "Path to the op-geth genesis file (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_L2_GENESIS"),
+	}
+	AsteriscBinFlag = &cli.StringFlag{
+		Name:    "asterisc-bin",
+		Usage:   "Path to asterisc executable to use when generating trace data (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_BIN"),
+	}
+	AsteriscServerFlag = &cli.StringFlag{
+		Name:    "asterisc-server",
+		Usage:   "Path to executable to use as pre-image oracle server when generating trace data (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_SERVER"),
+	}
+	AsteriscPreStateFlag = &cli.StringFlag{
+		Name:    "asterisc-prestate",
+		Usage:   "Path to absolute prestate to use when generating trace data (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_PRESTATE"),
+	}
+	AsteriscSnapshotFreqFlag = &cli.UintFlag{
+		Name:    "asterisc-snapshot-freq",
+		Usage:   "Frequency of asterisc snapshots to generate in VM steps (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_SNAPSHOT_FREQ"),
+		Value:   config.DefaultAsteriscSnapshotFreq,
+	}
+	AsteriscInfoFreqFlag = &cli.UintFlag{
+		Name:    "asterisc-info-freq",
+		Usage:   "Frequency of asterisc info log messages to generate in VM steps (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_INFO_FREQ"),
+		Value:   config.DefaultAsteriscInfoFreq,
+	}
+	CartesiNetworkFlag = &cli.StringFlag{
+		Name: "cartesi-network",
+		Usage: fmt.Sprintf(
+			"Predefined network selection. Available networks: %s (cartesi trace type only)",
+			strings.Join(chaincfg.AvailableNetworks(), ", "),
+		),
+		EnvVars: prefixEnvVars("CARTESI_NETWORK"),
+	}
+	CartesiRollupConfigFlag = &cli.StringFlag{
+		Name:    "cartesi-rollup-config",
+		Usage:   "Rollup chain parameters (cartesi trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_ROLLUP_CONFIG"),
+	}
+	CartesiL2GenesisFlag = &cli.StringFlag{
+		Name:    "cartesi-l2-genesis",
+		Usage:   "Path to the op-geth genesis file (cartesi trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_L2_GENESIS"),
+	}
+	CartesiBinFlag = &cli.StringFlag{
+		Name:    "cartesi-bin",
+		Usage:   "Path to Cartesi machine emulator executable to use when generating trace data (cartesi trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_BIN"),
+	}
+	CartesiServerFlag = &cli.StringFlag{
+		Name:    "cartesi-server",
+		Usage:   "Path to executable to use as pre-image oracle server when generating trace data (cartesi trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_SERVER"),
+	}
+	CartesiSnapshotDirFlag = &cli.StringFlag{
+		Name:    "cartesi-snapshot-dir",
+		Usage:   "Path to the stored initial machine used as the absolute prestate when generating trace data (cartesi trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_SNAPSHOT_DIR"),
+	}
+	CartesiMachineURLFlag = &cli.StringFlag{
+		Name:    "cartesi-machine-url",
+		Usage:   "Address of a machine server to run the machine on instead of loading the snapshot locally (cartesi trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_MACHINE_URL"),
+	}
+	CartesiSnapshotFreqFlag = &cli.UintFlag{
+		Name:    "cartesi-snapshot-freq",
+		Usage:   "Frequency of machine snapshots to generate in machine cycles (cartesi trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_SNAPSHOT_FREQ"),
+		Value:   config.DefaultCartesiSnapshotFreq,
+	}
+	ExternalCommandFlag = &cli.StringFlag{
+		Name: "external-command",
+		Usage: "Path to the external trace provider executable, run for each game with the game parameters appended " +
+			"to external-args (external trace type only)",
+		EnvVars: prefixEnvVars("EXTERNAL_COMMAND"),
+	}
+	ExternalArgsFlag = &cli.StringSliceFlag{
+		Name:    "external-args",
+		Usage:   "Arguments to pass to the external trace provider, may be repeated (external trace type only)",
+		EnvVars: prefixEnvVars("EXTERNAL_ARGS"),
+	}
+	ExternalTimeoutFlag = &cli.DurationFlag{
+		Name:    "external-timeout",
+		Usage:   "Maximum time to wait for a response from the external trace provider, 0 for no limit (external trace type only)",
+		EnvVars: prefixEnvVars("EXTERNAL_TIMEOUT"),
+		Value:   config.DefaultExternalTimeout,
+	}
+	CartesiComputeServerFlag = &cli.StringFlag{
+		Name:    "cartesi-compute-server",
+		Usage:   "URL of the trusted pre-image server HTTP API used to resolve claims (cartesi-compute trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_COMPUTE_SERVER"),
+	}
+	CartesiComputeTimeoutFlag = &cli.DurationFlag{
+		Name:    "cartesi-compute-timeout",
+		Usage:   "Maximum time to wait for each response from the cartesi compute pre-image server, 0 for no limit (cartesi-compute trace type only)",
+		EnvVars: prefixEnvVars("CARTESI_COMPUTE_TIMEOUT"),
+		Value:   config.DefaultCartesiComputeTimeout,
+	}
+	RemoteTraceURLFlag = &cli.StringSliceFlag{
+		Name: "remote-trace-url",
+		Usage: "URL of the remote trace service to request the trace of a game type from. " +
+			"Specified as <game-type>=<url>, may be repeated (remote trace type only)",
+		EnvVars: prefixEnvVars("REMOTE_TRACE_URL"),
+	}
+	RemoteTraceTimeoutFlag = &cli.DurationFlag{
+		Name:    "remote-trace-timeout",
+		Usage:   "Maximum time to wait for each response from a remote trace service, 0 for no limit. Timed out requests are retried (remote trace type only)",
+		EnvVars: prefixEnvVars("REMOTE_TRACE_TIMEOUT"),
+		Value:   config.DefaultRemoteTraceTimeout,
+	}
 	GameWindowFlag = &cli.DurationFlag{
 		Name: "game-window",
 		Usage: "The time window which the challenger will look for games to progress and claim bonds. " +
@@ -140,6 +315,103 @@ var (
 		EnvVars: prefixEnvVars("GAME_WINDOW"),
 		Value:   config.DefaultGameWindow,
 	}
+	GameMaxDepthFlag = &cli.Uint64Flag{
+		Name:    "game-max-depth",
+		Usage:   "Max game depth games must be deployed with to be played. 0 disables the check",
+		EnvVars: prefixEnvVars("GAME_MAX_DEPTH"),
+		Value:   config.DefaultGameParams.MaxGameDepth,
+	}
+	GameSplitDepthFlag = &cli.Uint64Flag{
+		Name:    "game-split-depth",
+		Usage:   "Split depth games must be deployed with to be played. 0 disables the check",
+		EnvVars: prefixEnvVars("GAME_SPLIT_DEPTH"),
+		Value:   config.DefaultGameParams.SplitDepth,
+	}
+	GameDurationFlag = &cli.DurationFlag{
+		Name:    "game-duration",
+		Usage:   "Game duration games must be deployed with to be played. 0 disables the check",
+		EnvVars: prefixEnvVars("GAME_DURATION"),
+		Value:   config.DefaultGameParams.GameDuration,
+	}
+	GameTypeParamsFlag = &cli.StringSliceFlag{
+		Name: "game-type-params",
+		Usage: "Parameters games of a specific game type must be deployed with, overriding the other game parameter flags. " +
+			"Specified as <game-type>=<max-depth>:<split-depth>:<duration>, may be repeated",
+		EnvVars: prefixEnvVars("GAME_TYPE_PARAMS"),
+	}
+	ParticipationFlag = &cli.StringFlag{
+		Name: "participation",
+		Usage: "Transactions to send for games. Valid options: " + openum.EnumString(config.ParticipationModes) +
+			". observe sends no transactions, only logging the actions that would be taken",
+		EnvVars: prefixEnvVars("PARTICIPATION"),
+		Value:   string(config.ParticipationAct),
+	}
+	GameTypeParticipationFlag = &cli.StringSliceFlag{
+		Name:    "game-type-participation",
+		Usage:   "Participation mode for a specific game type, overriding participation. Specified as <game-type>=<mode>, may be repeated",
+		EnvVars: prefixEnvVars("GAME_TYPE_PARTICIPATION"),
+	}
+	LargePreimageChunkSizeFlag = &cli.IntFlag{
+		Name: "large-preimage-chunk-size",
+		Usage: fmt.Sprintf("Maximum number of bytes of a large preimage to add to the oracle per transaction. "+
+			"Must be a multiple of %v up to %v", keccakTypes.BlockSize, preimages.MaxChunkSize),
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_CHUNK_SIZE"),
+		Value:   preimages.MaxChunkSize,
+	}
+	OracleVersionFlag = &cli.StringFlag{
+		Name:    "oracle-version",
+		Usage:   "Version the preimage oracle of each game type must report for the game type to be registered. Empty disables the check",
+		EnvVars: prefixEnvVars("ORACLE_VERSION"),
+	}
+	OracleChallengePeriodFlag = &cli.DurationFlag{
+		Name:    "oracle-challenge-period",
+		Usage:   "Large preimage challenge period the preimage oracle of each game type must have for the game type to be registered. 0 disables the check",
+		EnvVars: prefixEnvVars("ORACLE_CHALLENGE_PERIOD"),
+		Value:   config.DefaultOracleParams.ChallengePeriod,
+	}
+	OracleMinBondFlag = &cli.Uint64Flag{
+		Name:    "oracle-min-bond",
+		Usage:   "Minimum large preimage bond in wei the preimage oracle of each game type must have for the game type to be registered. 0 disables the check",
+		EnvVars: prefixEnvVars("ORACLE_MIN_BOND"),
+		Value:   config.DefaultOracleParams.MinBond,
+	}
+	GameTypeOracleFlag = &cli.StringSliceFlag{
+		Name: "game-type-oracle",
+		Usage: "Preimage oracle address to use for a specific game type instead of the oracle of the game type's implementation. " +
+			"Specified as <game-type>=<address>, may be repeated",
+		EnvVars: prefixEnvVars("GAME_TYPE_ORACLE"),
+	}
+	GameTypeDelayedWETHFlag = &cli.StringSliceFlag{
+		Name: "game-type-delayed-weth",
+		Usage: "DelayedWETH contract holding the bonds of games of a specific game type. " +
+			"Credit is only claimed once the withdrawal delay has elapsed. Specified as <game-type>=<address>, may be repeated",
+		EnvVars: prefixEnvVars("GAME_TYPE_DELAYED_WETH"),
+	}
+	SyncReferenceFlag = &cli.StringFlag{
+		Name: "sync-reference",
+		Usage: "Rollup node progress that must be past a game's L1 head to act on the game. Valid options: " +
+			openum.EnumString(config.SyncReferences),
+		EnvVars: prefixEnvVars("SYNC_REFERENCE"),
+		Value:   string(config.DefaultSyncThresholds.Reference),
+	}
+	SyncMaxLagBlocksFlag = &cli.Uint64Flag{
+		Name:    "sync-max-lag-blocks",
+		Usage:   "Number of L1 blocks the sync reference may be short of passing a game's L1 head when acting on the game",
+		EnvVars: prefixEnvVars("SYNC_MAX_LAG_BLOCKS"),
+		Value:   config.DefaultSyncThresholds.MaxLagBlocks,
+	}
+	SyncMaxLagTimeFlag = &cli.DurationFlag{
+		Name:    "sync-max-lag-time",
+		Usage:   "Maximum time the sync reference may be behind the L1 head when acting on games. 0 disables the check",
+		EnvVars: prefixEnvVars("SYNC_MAX_LAG_TIME"),
+		Value:   config.DefaultSyncThresholds.MaxLagTime,
+	}
+	AllowPrestateMismatchFlag = &cli.BoolFlag{
+		Name: "allow-prestate-mismatch",
+		Usage: "Register game types even if the configured absolute prestate does not match the game implementation. " +
+			"Only intended for recovery as the prestate of each game is still validated before it is played",
+		EnvVars: prefixEnvVars("ALLOW_PRESTATE_MISMATCH"),
+	}
 	UnsafeAllowInvalidPrestate = &cli.BoolFlag{
 		Name:    "unsafe-allow-invalid-prestate",
 		Usage:   "Allow responding to games where the absolute prestate is configured incorrectly. THIS IS UNSAFE!",
@@ -160,9 +432,12 @@ var requiredFlags = []cli.Flag{
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
 	TraceTypeFlag,
+	GameTypesFlag,
+	GameTypePreStateFlag,
 	MaxConcurrencyFlag,
 	MaxPendingTransactionsFlag,
 	HTTPPollInterval,
+	PlayerCreationTimeoutFlag,
 	GameAllowlistFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
@@ -170,10 +445,53 @@ var optionalFlags = []cli.Flag{
 	CannonBinFlag,
 	CannonServerFlag,
 	CannonPreStateFlag,
+	CannonPreStateDownloadTimeoutFlag,
+	CannonPreStatesFlag,
 	CannonL2Flag,
+	CannonL2FallbacksFlag,
+	L2RpcsFlag,
 	CannonSnapshotFreqFlag,
 	CannonInfoFreqFlag,
+	AsteriscNetworkFlag,
+	AsteriscRollupConfigFlag,
+	AsteriscL2GenesisFlag,
+	AsteriscBinFlag,
+	AsteriscServerFlag,
+	AsteriscPreStateFlag,
+	AsteriscSnapshotFreqFlag,
+	AsteriscInfoFreqFlag,
+	CartesiNetworkFlag,
+	CartesiRollupConfigFlag,
+	CartesiL2GenesisFlag,
+	CartesiBinFlag,
+	CartesiServerFlag,
+	CartesiSnapshotDirFlag,
+	CartesiMachineURLFlag,
+	CartesiSnapshotFreqFlag,
+	ExternalCommandFlag,
+	ExternalArgsFlag,
+	ExternalTimeoutFlag,
+	CartesiComputeServerFlag,
+	CartesiComputeTimeoutFlag,
+	RemoteTraceURLFlag,
+	RemoteTraceTimeoutFlag,
 	GameWindowFlag,
+	GameMaxDepthFlag,
+	GameSplitDepthFlag,
+	GameDurationFlag,
+	GameTypeParamsFlag,
+	ParticipationFlag,
+	GameTypeParticipationFlag,
+	LargePreimageChunkSizeFlag,
+	OracleVersionFlag,
+	OracleChallengePeriodFlag,
+	OracleMinBondFlag,
+	GameTypeOracleFlag,
+	GameTypeDelayedWETHFlag,
+	SyncReferenceFlag,
+	SyncMaxLagBlocksFlag,
+	SyncMaxLagTimeFlag,
+	AllowPrestateMismatchFlag,
 	UnsafeAllowInvalidPrestate,
 }
 
@@ -206,11 +524,45 @@ func CheckCannonFlags(ctx *cli.Context) error {
 	if !ctx.IsSet(CannonServerFlag.Name) {
 		return fmt.Errorf("flag %s is required", CannonServerFlag.Name)
 	}
-	if !ctx.IsSet(CannonPreStateFlag.Name) {
-		return fmt.Errorf("flag %s is required", CannonPreStateFlag.Name)
+	return nil
+}
+
+func CheckAsteriscFlags(ctx *cli.Context) error {
+	if !ctx.IsSet(AsteriscNetworkFlag.Name) &&
+		!(ctx.IsSet(AsteriscRollupConfigFlag.Name) && ctx.IsSet(AsteriscL2GenesisFlag.Name)) {
+		return fmt.Errorf("flag %v or %v and %v is required",
+			AsteriscNetworkFlag.Name, AsteriscRollupConfigFlag.Name, AsteriscL2GenesisFlag.Name)
+	}
+	if ctx.IsSet(AsteriscNetworkFlag.Name) &&
+		(ctx.IsSet(AsteriscRollupConfigFlag.Name) || ctx.IsSet(AsteriscL2GenesisFlag.Name)) {
+		return fmt.Errorf("flag %v can not be used with %v and %v",
+			AsteriscNetworkFlag.Name, AsteriscRollupConfigFlag.Name, AsteriscL2GenesisFlag.Name)
+	}
+	if !ctx.IsSet(AsteriscBinFlag.Name) {
+		return fmt.Errorf("flag %s is required", AsteriscBinFlag.Name)
+	}
+	if !ctx.IsSet(AsteriscServerFlag.Name) {
+		return fmt.Errorf("flag %s is required", AsteriscServerFlag.Name)
+	}
+	return nil
+}
+
+func CheckCartesiFlags(ctx *cli.Context) error {
+	if !ctx.IsSet(CartesiNetworkFlag.Name) &&
+		!(ctx.IsSet(CartesiRollupConfigFlag.Name) && ctx.IsSet(CartesiL2GenesisFlag.Name)) {
+		return fmt.Errorf("flag %v or %v and %v is required",
+			CartesiNetworkFlag.Name, CartesiRollupConfigFlag.Name, CartesiL2GenesisFlag.Name)
+	}
+	if ctx.IsSet(CartesiNetworkFlag.Name) &&
+		(ctx.IsSet(CartesiRollupConfigFlag.Name) || ctx.IsSet(CartesiL2GenesisFlag.Name)) {
+		return fmt.Errorf("flag %v can not be used with %v and %v",
+			CartesiNetworkFlag.Name, CartesiRollupConfigFlag.Name, CartesiL2GenesisFlag.Name)
+	}
+	if !ctx.IsSet(CartesiBinFlag.Name) {
+		return fmt.Errorf("flag %s is required", CartesiBinFlag.Name)
 	}
-	if !ctx.IsSet(CannonL2Flag.Name) {
-		return fmt.Errorf("flag %s is required", CannonL2Flag.Name)
+	if !ctx.IsSet(CartesiServerFlag.Name) {
+		return fmt.Errorf("flag %s is required", CartesiServerFlag.Name)
 	}
 	return nil
 }
@@ -221,16 +573,75 @@ func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
 	}
+	l2Rpcs, err := parseL2Rpcs(ctx)
+	if err != nil {
+		return err
+	}
+	gameTypes, err := parseGameTypes(ctx)
+	if err != nil {
+		return err
+	}
+	gameTypePreStates, err := parseGameTypePreStates(ctx)
+	if err != nil {
+		return err
+	}
+	gameTypes = config.Config{TraceTypes: traceTypes, GameTypes: gameTypes, GameTypePreStates: gameTypePreStates}.GameTypesToRegister()
 	for _, traceType := range traceTypes {
 		switch traceType {
 		case config.TraceTypeCannon, config.TraceTypePermissioned:
 			if err := CheckCannonFlags(ctx); err != nil {
 				return err
 			}
-		case config.TraceTypeAlphabet:
+			if err := checkPreStateFlag(ctx, CannonPreStateFlag, gameTypes, config.TraceTypeCannon, config.TraceTypePermissioned); err != nil && !ctx.IsSet(CannonPreStatesFlag.Name) {
+				return err
+			}
+		case config.TraceTypeAsterisc:
+			if err := CheckAsteriscFlags(ctx); err != nil {
+				return err
+			}
+			if err := checkPreStateFlag(ctx, AsteriscPreStateFlag, gameTypes, config.TraceTypeAsterisc); err != nil {
+				return err
+			}
+		case config.TraceTypeCartesi:
+			if err := CheckCartesiFlags(ctx); err != nil {
+				return err
+			}
+			if err := checkPreStateFlag(ctx, CartesiSnapshotDirFlag, gameTypes, config.TraceTypeCartesi); err != nil {
+				return err
+			}
+		case config.TraceTypeExternal:
+			if !ctx.IsSet(ExternalCommandFlag.Name) {
+				return fmt.Errorf("flag %s is required", ExternalCommandFlag.Name)
+			}
+		case config.TraceTypeCartesiCompute:
+			if !ctx.IsSet(CartesiComputeServerFlag.Name) {
+				return fmt.Errorf("flag %s is required", CartesiComputeServerFlag.Name)
+			}
+		case config.TraceTypeRemote:
+			if !ctx.IsSet(RemoteTraceURLFlag.Name) {
+				return fmt.Errorf("flag %s is required", RemoteTraceURLFlag.Name)
+			}
+		case config.TraceTypeAlphabet, config.TraceTypePermissionedAlphabet:
 		default:
 			return fmt.Errorf("invalid trace type. must be one of %v", config.TraceTypes)
 		}
+		if slices.Contains(config.L2TraceTypes, traceType) && l2Rpcs[traceType] == "" && !ctx.IsSet(CannonL2Flag.Name) {
+			return fmt.Errorf("flag %s is required", CannonL2Flag.Name)
+		}
+	}
+	return nil
+}
+
+// checkPreStateFlag requires the absolute pre-state flag of traceTypes unless every registered game type
+// played with them overrides the absolute pre-state.
+func checkPreStateFlag(ctx *cli.Context, flag cli.Flag, gameTypes []config.GameTypeConfig, traceTypes ...config.TraceType) error {
+	if ctx.IsSet(flag.Names()[0]) {
+		return nil
+	}
+	for _, gameType := range gameTypes {
+		if slices.Contains(traceTypes, gameType.TraceType) && gameType.AbsolutePreState == "" {
+			return fmt.Errorf("flag %s is required", flag.Names()[0])
+		}
 	}
 	return nil
 }
@@ -249,6 +660,154 @@ func parseTraceTypes(ctx *cli.Context) ([]config.TraceType, error) {
 	return traceTypes, nil
 }
 
+func parseL2Rpcs(ctx *cli.Context) (map[config.TraceType]string, error) {
+	var l2Rpcs map[config.TraceType]string
+	for _, entry := range ctx.StringSlice(L2RpcsFlag.Name) {
+		typeName, url, ok := strings.Cut(entry, "=")
+		if !ok || url == "" {
+			return nil, fmt.Errorf("invalid %v value %q, must be <trace-type>=<url>", L2RpcsFlag.Name, entry)
+		}
+		traceType := new(config.TraceType)
+		if err := traceType.Set(typeName); err != nil {
+			return nil, err
+		}
+		if l2Rpcs == nil {
+			l2Rpcs = make(map[config.TraceType]string)
+		}
+		l2Rpcs[*traceType] = url
+	}
+	return l2Rpcs, nil
+}
+
+func parseGameTypes(ctx *cli.Context) ([]config.GameTypeConfig, error) {
+	var gameTypes []config.GameTypeConfig
+	for _, entry := range ctx.StringSlice(GameTypesFlag.Name) {
+		gameTypeStr, traceTypeStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %v value %q, must be <game-type>=<trace-type>[:<absolute-prestate>]", GameTypesFlag.Name, entry)
+		}
+		gameType, err := strconv.ParseUint(gameTypeStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v game type %q: %w", GameTypesFlag.Name, gameTypeStr, err)
+		}
+		traceTypeStr, preState, _ := strings.Cut(traceTypeStr, ":")
+		traceType := new(config.TraceType)
+		if err := traceType.Set(traceTypeStr); err != nil {
+			return nil, err
+		}
+		for _, existing := range gameTypes {
+			if existing.GameType == uint32(gameType) {
+				return nil, fmt.Errorf("%w: %v", config.ErrDuplicateGameType, gameType)
+			}
+		}
+		gameTypes = append(gameTypes, config.GameTypeConfig{
+			GameType:         uint32(gameType),
+			TraceType:        *traceType,
+			AbsolutePreState: preState,
+		})
+	}
+	return gameTypes, nil
+}
+
+func parseGameTypeParticipation(ctx *cli.Context) (map[uint32]config.ParticipationMode, error) {
+	var participation map[uint32]config.ParticipationMode
+	for _, entry := range ctx.StringSlice(GameTypeParticipationFlag.Name) {
+		gameTypeStr, mode, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %v value %q, must be <game-type>=<mode>", GameTypeParticipationFlag.Name, entry)
+		}
+		gameType, err := strconv.ParseUint(gameTypeStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v game type %q: %w", GameTypeParticipationFlag.Name, gameTypeStr, err)
+		}
+		if participation == nil {
+			participation = make(map[uint32]config.ParticipationMode)
+		}
+		participation[uint32(gameType)] = config.ParticipationMode(mode)
+	}
+	return participation, nil
+}
+
+// parseGameTypePreStates parses the <game-type>=<absolute-prestate> entries of GameTypePreStateFlag.
+func parseGameTypePreStates(ctx *cli.Context) (map[uint32]string, error) {
+	var preStates map[uint32]string
+	for _, entry := range ctx.StringSlice(GameTypePreStateFlag.Name) {
+		gameTypeStr, preState, ok := strings.Cut(entry, "=")
+		if !ok || preState == "" {
+			return nil, fmt.Errorf("invalid %v value %q, must be <game-type>=<absolute-prestate>", GameTypePreStateFlag.Name, entry)
+		}
+		gameType, err := strconv.ParseUint(gameTypeStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v game type %q: %w", GameTypePreStateFlag.Name, gameTypeStr, err)
+		}
+		if preStates == nil {
+			preStates = make(map[uint32]string)
+		}
+		preStates[uint32(gameType)] = preState
+	}
+	return preStates, nil
+}
+
+// parseRemoteTraceURLs parses the <game-type>=<url> entries of RemoteTraceURLFlag.
+func parseRemoteTraceURLs(ctx *cli.Context) (map[uint32]string, error) {
+	var urls map[uint32]string
+	for _, entry := range ctx.StringSlice(RemoteTraceURLFlag.Name) {
+		gameTypeStr, url, ok := strings.Cut(entry, "=")
+		if !ok || url == "" {
+			return nil, fmt.Errorf("invalid %v value %q, must be <game-type>=<url>", RemoteTraceURLFlag.Name, entry)
+		}
+		gameType, err := strconv.ParseUint(gameTypeStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v game type %q: %w", RemoteTraceURLFlag.Name, gameTypeStr, err)
+		}
+		if urls == nil {
+			urls = make(map[uint32]string)
+		}
+		urls[uint32(gameType)] = url
+	}
+	return urls, nil
+}
+
+func parseGameTypeParams(ctx *cli.Context) (map[uint32]config.GameParams, error) {
+	var gameTypeParams map[uint32]config.GameParams
+	for _, entry := range ctx.StringSlice(GameTypeParamsFlag.Name) {
+		invalid := fmt.Errorf("invalid %v value %q, must be <game-type>=<max-depth>:<split-depth>:<duration>", GameTypeParamsFlag.Name, entry)
+		gameTypeStr, paramsStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, invalid
+		}
+		params := strings.Split(paramsStr, ":")
+		if len(params) != 3 {
+			return nil, invalid
+		}
+		gameType, err := strconv.ParseUint(gameTypeStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", invalid, err)
+		}
+		maxGameDepth, err := strconv.ParseUint(params[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", invalid, err)
+		}
+		splitDepth, err := strconv.ParseUint(params[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", invalid, err)
+		}
+		gameDuration, err := time.ParseDuration(params[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", invalid, err)
+		}
+		if gameTypeParams == nil {
+			gameTypeParams = make(map[uint32]config.GameParams)
+		}
+		gameTypeParams[uint32(gameType)] = config.GameParams{
+			MaxGameDepth: maxGameDepth,
+			SplitDepth:   splitDepth,
+			GameDuration: gameDuration,
+		}
+	}
+	return gameTypeParams, nil
+}
+
 // NewConfigFromCLI parses the Config from the provided flags or environment variables.
 func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	traceTypes, err := parseTraceTypes(ctx)
@@ -258,6 +817,53 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if err := CheckRequired(ctx, traceTypes); err != nil {
 		return nil, err
 	}
+	l2Rpcs, err := parseL2Rpcs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameTypes, err := parseGameTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameTypeParams, err := parseGameTypeParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameTypeParticipation, err := parseGameTypeParticipation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameTypePreStates, err := parseGameTypePreStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameTypeOracles, err := opflags.ParseGameTypeAddresses(ctx, GameTypeOracleFlag)
+	if err != nil {
+		return nil, err
+	}
+	gameTypeDelayedWETH, err := opflags.ParseGameTypeAddresses(ctx, GameTypeDelayedWETHFlag)
+	if err != nil {
+		return nil, err
+	}
+	remoteTraceURLs, err := parseRemoteTraceURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameParams := config.GameParams{
+		MaxGameDepth: ctx.Uint64(GameMaxDepthFlag.Name),
+		SplitDepth:   ctx.Uint64(GameSplitDepthFlag.Name),
+		GameDuration: ctx.Duration(GameDurationFlag.Name),
+	}
+	oracleParams := config.OracleParams{
+		Version:         ctx.String(OracleVersionFlag.Name),
+		ChallengePeriod: ctx.Duration(OracleChallengePeriodFlag.Name),
+		MinBond:         ctx.Uint64(OracleMinBondFlag.Name),
+	}
+	syncThresholds := config.SyncThresholds{
+		Reference:    config.SyncReference(ctx.String(SyncReferenceFlag.Name)),
+		MaxLagBlocks: ctx.Uint64(SyncMaxLagBlocksFlag.Name),
+		MaxLagTime:   ctx.Duration(SyncMaxLagTimeFlag.Name),
+	}
 	gameFactoryAddress, err := opservice.ParseAddress(ctx.String(FactoryAddressFlag.Name))
 	if err != nil {
 		return nil, err
@@ -283,29 +889,69 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	}
 	return &config.Config{
 		// Required Flags
-		L1EthRpc:               ctx.String(L1EthRpcFlag.Name),
-		L1Beacon:               ctx.String(L1BeaconFlag.Name),
-		TraceTypes:             traceTypes,
-		GameFactoryAddress:     gameFactoryAddress,
-		GameAllowlist:          allowedGames,
-		GameWindow:             ctx.Duration(GameWindowFlag.Name),
-		MaxConcurrency:         maxConcurrency,
-		MaxPendingTx:           ctx.Uint64(MaxPendingTransactionsFlag.Name),
-		PollInterval:           ctx.Duration(HTTPPollInterval.Name),
-		RollupRpc:              ctx.String(RollupRpcFlag.Name),
-		CannonNetwork:          ctx.String(CannonNetworkFlag.Name),
-		CannonRollupConfigPath: ctx.String(CannonRollupConfigFlag.Name),
-		CannonL2GenesisPath:    ctx.String(CannonL2GenesisFlag.Name),
-		CannonBin:              ctx.String(CannonBinFlag.Name),
-		CannonServer:           ctx.String(CannonServerFlag.Name),
-		CannonAbsolutePreState: ctx.String(CannonPreStateFlag.Name),
-		Datadir:                ctx.String(DatadirFlag.Name),
-		CannonL2:               ctx.String(CannonL2Flag.Name),
-		CannonSnapshotFreq:     ctx.Uint(CannonSnapshotFreqFlag.Name),
-		CannonInfoFreq:         ctx.Uint(CannonInfoFreqFlag.Name),
-		TxMgrConfig:            txMgrConfig,
-		MetricsConfig:          metricsConfig,
-		PprofConfig:            pprofConfig,
-		AllowInvalidPrestate:   ctx.Bool(UnsafeAllowInvalidPrestate.Name),
+		L1EthRpc:                 ctx.String(L1EthRpcFlag.Name),
+		L1Beacon:                 ctx.String(L1BeaconFlag.Name),
+		TraceTypes:               traceTypes,
+		GameTypes:                gameTypes,
+		GameTypePreStates:        gameTypePreStates,
+		GameFactoryAddress:       gameFactoryAddress,
+		GameAllowlist:            allowedGames,
+		GameWindow:               ctx.Duration(GameWindowFlag.Name),
+		GameParams:               gameParams,
+		GameTypeParams:           gameTypeParams,
+		SyncThresholds:           syncThresholds,
+		Participation:            config.ParticipationMode(ctx.String(ParticipationFlag.Name)),
+		GameTypeParticipation:    gameTypeParticipation,
+		LargePreimageChunkSize:   ctx.Int(LargePreimageChunkSizeFlag.Name),
+		OracleParams:             oracleParams,
+		GameTypeOracles:          gameTypeOracles,
+		GameTypeDelayedWETH:      gameTypeDelayedWETH,
+		MaxConcurrency:           maxConcurrency,
+		MaxPendingTx:             ctx.Uint64(MaxPendingTransactionsFlag.Name),
+		PollInterval:             ctx.Duration(HTTPPollInterval.Name),
+		PlayerCreationTimeout:    ctx.Duration(PlayerCreationTimeoutFlag.Name),
+		RollupRpc:                ctx.String(RollupRpcFlag.Name),
+		CannonNetwork:            ctx.String(CannonNetworkFlag.Name),
+		CannonRollupConfigPath:   ctx.String(CannonRollupConfigFlag.Name),
+		CannonL2GenesisPath:      ctx.String(CannonL2GenesisFlag.Name),
+		CannonBin:                ctx.String(CannonBinFlag.Name),
+		CannonServer:             ctx.String(CannonServerFlag.Name),
+		CannonAbsolutePreState:   ctx.String(CannonPreStateFlag.Name),
+		CannonPreStateTimeout:    ctx.Duration(CannonPreStateDownloadTimeoutFlag.Name),
+		CannonAbsolutePreStates:  ctx.StringSlice(CannonPreStatesFlag.Name),
+		Datadir:                  ctx.String(DatadirFlag.Name),
+		CannonL2:                 ctx.String(CannonL2Flag.Name),
+		CannonL2Fallbacks:        ctx.StringSlice(CannonL2FallbacksFlag.Name),
+		L2Rpcs:                   l2Rpcs,
+		CannonSnapshotFreq:       ctx.Uint(CannonSnapshotFreqFlag.Name),
+		CannonInfoFreq:           ctx.Uint(CannonInfoFreqFlag.Name),
+		AsteriscNetwork:          ctx.String(AsteriscNetworkFlag.Name),
+		AsteriscRollupConfigPath: ctx.String(AsteriscRollupConfigFlag.Name),
+		AsteriscL2GenesisPath:    ctx.String(AsteriscL2GenesisFlag.Name),
+		AsteriscBin:              ctx.String(AsteriscBinFlag.Name),
+		AsteriscServer:           ctx.String(AsteriscServerFlag.Name),
+		AsteriscAbsolutePreState: ctx.String(AsteriscPreStateFlag.Name),
+		AsteriscSnapshotFreq:     ctx.Uint(AsteriscSnapshotFreqFlag.Name),
+		AsteriscInfoFreq:         ctx.Uint(AsteriscInfoFreqFlag.Name),
+		CartesiNetwork:           ctx.String(CartesiNetworkFlag.Name),
+		CartesiRollupConfigPath:  ctx.String(CartesiRollupConfigFlag.Name),
+		CartesiL2GenesisPath:     ctx.String(CartesiL2GenesisFlag.Name),
+		CartesiBin:               ctx.String(CartesiBinFlag.Name),
+		CartesiServer:            ctx.String(CartesiServerFlag.Name),
+		CartesiSnapshotDir:       ctx.String(CartesiSnapshotDirFlag.Name),
+		CartesiMachineURL:        ctx.String(CartesiMachineURLFlag.Name),
+		CartesiSnapshotFreq:      ctx.Uint(CartesiSnapshotFreqFlag.Name),
+		ExternalCommand:          ctx.String(ExternalCommandFlag.Name),
+		ExternalArgs:             ctx.StringSlice(ExternalArgsFlag.Name),
+		ExternalTimeout:          ctx.Duration(ExternalTimeoutFlag.Name),
+		CartesiComputeServer:     ctx.String(CartesiComputeServerFlag.Name),
+		CartesiComputeTimeout:    ctx.Duration(CartesiComputeTimeoutFlag.Name),
+		RemoteTraceURLs:          remoteTraceURLs,
+		RemoteTraceTimeout:       ctx.Duration(RemoteTraceTimeoutFlag.Name),
+		TxMgrConfig:              txMgrConfig,
+		MetricsConfig:            metricsConfig,
+		PprofConfig:              pprofConfig,
+		AllowInvalidPrestate:     ctx.Bool(UnsafeAllowInvalidPrestate.Name),
+		AllowPrestateMismatch:    ctx.Bool(AllowPrestateMismatchFlag.Name),
 	}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -21,23 +22,38 @@ type BondContract interface {
 	ClaimCredit(receipient common.Address) (txmgr.TxCandidate, error)
 }
 
+// DelayedBondContract is a BondContract whose credit can only be claimed once a delay has elapsed,
+// such as games that hold bonds in a DelayedWETH contract.
+type DelayedBondContract interface {
+	BondContract
+	// GetCreditUnlockTime returns the time the credit of recipient can be claimed from.
+	// The zero time is returned if the credit has not been unlocked yet.
+	GetCreditUnlockTime(ctx context.Context, recipient common.Address) (time.Time, error)
+}
+
 type BondContractCreator func(game types.GameMetadata) (BondContract, error)
 
+type ClockReader interface {
+	Now() time.Time
+}
+
 type Claimer struct {
 	logger          log.Logger
 	metrics         BondClaimMetrics
 	contractCreator BondContractCreator
 	txSender        types.TxSender
+	clock           ClockReader
 }
 
 var _ BondClaimer = (*Claimer)(nil)
 
-func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender types.TxSender) *Claimer {
+func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender types.TxSender, cl ClockReader) *Claimer {
 	return &Claimer{
 		logger:          l,
 		metrics:         m,
 		contractCreator: contractCreator,
 		txSender:        txSender,
+		clock:           cl,
 	}
 }
 
@@ -65,6 +81,22 @@ func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata) error
 		return nil
 	}
 
+	if delayed, ok := contract.(DelayedBondContract); ok {
+		// Claiming before the delay has elapsed would revert, so wait for a later schedule.
+		unlockTime, err := delayed.GetCreditUnlockTime(ctx, c.txSender.From())
+		if err != nil {
+			return fmt.Errorf("failed to get credit unlock time: %w", err)
+		}
+		if unlockTime.IsZero() {
+			c.logger.Debug("Credit not unlocked yet", "game", game.Proxy)
+			return nil
+		}
+		if now := c.clock.Now(); now.Before(unlockTime) {
+			c.logger.Debug("Credit delay has not elapsed", "game", game.Proxy, "unlockTime", unlockTime, "remaining", unlockTime.Sub(now))
+			return nil
+		}
+	}
+
 	candidate, err := contract.ClaimCredit(c.txSender.From())
 	if err != nil {
 		return fmt.Errorf("failed to create credit claim tx: %w", err)
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -73,6 +75,58 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 	})
 }
 
+func TestClaimer_ClaimDelayedBonds(t *testing.T) {
+	gameAddr := common.HexToAddress("0x1234")
+	now := time.Unix(10_000, 0)
+	setup := func(t *testing.T, unlockTime time.Time) (*Claimer, *mockClaimMetrics, *stubDelayedBondContract, *mockTxSender) {
+		logger := testlog.Logger(t, log.LvlDebug)
+		m := &mockClaimMetrics{}
+		txSender := &mockTxSender{}
+		bondContract := &stubDelayedBondContract{stubBondContract: stubBondContract{credit: 1}, unlockTime: unlockTime}
+		contractCreator := func(game types.GameMetadata) (BondContract, error) {
+			return bondContract, nil
+		}
+		c := NewBondClaimer(logger, m, contractCreator, txSender, clock.NewDeterministicClock(now))
+		return c, m, bondContract, txSender
+	}
+
+	t.Run("NotUnlocked", func(t *testing.T) {
+		c, m, _, txSender := setup(t, time.Time{})
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Equal(t, 0, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("DelayNotElapsed", func(t *testing.T) {
+		c, m, _, txSender := setup(t, now.Add(time.Second))
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Equal(t, 0, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("DelayElapsed", func(t *testing.T) {
+		c, m, _, txSender := setup(t, now)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Equal(t, 1, txSender.sends)
+		require.Equal(t, 1, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("ZeroCreditSkipsUnlockTime", func(t *testing.T) {
+		c, _, contract, txSender := setup(t, now)
+		contract.credit = 0
+		contract.unlockErr = errors.New("should not be called")
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Equal(t, 0, txSender.sends)
+	})
+
+	t.Run("UnlockTimeFails", func(t *testing.T) {
+		c, _, contract, txSender := setup(t, now)
+		contract.unlockErr = errors.New("boom")
+		require.ErrorIs(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}), contract.unlockErr)
+		require.Equal(t, 0, txSender.sends)
+	})
+}
+
 func newTestClaimer(t *testing.T, gameAddr common.Address) (*Claimer, *mockClaimMetrics, *stubBondContract, *mockTxSender) {
 	logger := testlog.Logger(t, log.LvlDebug)
 	m := &mockClaimMetrics{}
@@ -81,7 +135,7 @@ func newTestClaimer(t *testing.T, gameAddr common.Address) (*Claimer, *mockClaim
 	contractCreator := func(game types.GameMetadata) (BondContract, error) {
 		return bondContract, nil
 	}
-	c := NewBondClaimer(logger, m, contractCreator, txSender)
+	c := NewBondClaimer(logger, m, contractCreator, txSender, clock.NewDeterministicClock(time.Unix(0, 0)))
 	return c, m, bondContract, txSender
 }
 
@@ -125,3 +179,13 @@ func (s *stubBondContract) GetCredit(_ context.Context, _ common.Address) (*big.
 func (s *stubBondContract) ClaimCredit(_ common.Address) (txmgr.TxCandidate, error) {
 	return txmgr.TxCandidate{}, nil
 }
+
+type stubDelayedBondContract struct {
+	stubBondContract
+	unlockTime time.Time
+	unlockErr  error
+}
+
+func (s *stubDelayedBondContract) GetCreditUnlockTime(_ context.Context, _ common.Address) (time.Time, error) {
+	return s.unlockTime, s.unlockErr
+}
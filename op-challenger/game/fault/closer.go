@@ -0,0 +1,57 @@
+package fault
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// resourceCloser releases the resources acquired while registering game types.
+// Cleanups run in reverse order of being added, so resources are released before the resources they depend on.
+// Close is safe to call repeatedly or concurrently and only runs each cleanup once.
+type resourceCloser struct {
+	logger log.Logger
+
+	lock     sync.Mutex
+	closed   bool
+	cleanups []namedCleanup
+}
+
+type namedCleanup struct {
+	name string
+	fn   func() error
+}
+
+func newResourceCloser(logger log.Logger) *resourceCloser {
+	return &resourceCloser{logger: logger}
+}
+
+// add registers fn to be called on Close. If the closer is already closed fn is called immediately.
+func (c *resourceCloser) add(name string, fn func() error) {
+	c.lock.Lock()
+	if !c.closed {
+		c.cleanups = append(c.cleanups, namedCleanup{name: name, fn: fn})
+		c.lock.Unlock()
+		return
+	}
+	c.lock.Unlock()
+	c.run(namedCleanup{name: name, fn: fn})
+}
+
+// Close runs every cleanup. Failed cleanups are logged and don't prevent the remaining cleanups running.
+func (c *resourceCloser) Close() {
+	c.lock.Lock()
+	cleanups := c.cleanups
+	c.cleanups = nil
+	c.closed = true
+	c.lock.Unlock()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		c.run(cleanups[i])
+	}
+}
+
+func (c *resourceCloser) run(cleanup namedCleanup) {
+	if err := cleanup.fn(); err != nil {
+		c.logger.Error("Failed to release game type resource", "resource", cleanup.name, "err", err)
+	}
+}
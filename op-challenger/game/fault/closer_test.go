@@ -0,0 +1,71 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCloser(t *testing.T) {
+	t.Run("ReverseOrder", func(t *testing.T) {
+		closer := newResourceCloser(testlog.Logger(t, log.LevelInfo))
+		var closed []string
+		for _, name := range []string{"first", "second", "third"} {
+			name := name
+			closer.add(name, func() error {
+				closed = append(closed, name)
+				return nil
+			})
+		}
+		closer.Close()
+		require.Equal(t, []string{"third", "second", "first"}, closed)
+	})
+
+	t.Run("Idempotent", func(t *testing.T) {
+		closer := newResourceCloser(testlog.Logger(t, log.LevelInfo))
+		calls := 0
+		closer.add("resource", func() error {
+			calls++
+			return nil
+		})
+		closer.Close()
+		closer.Close()
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("ContinueOnError", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		closer := newResourceCloser(logger)
+		err := errors.New("boom")
+		closedOther := false
+		closer.add("other", func() error {
+			closedOther = true
+			return nil
+		})
+		closer.add("failing", func() error {
+			return err
+		})
+		closer.Close()
+		require.True(t, closedOther, "should run remaining cleanups after a failure")
+		errLog := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("Failed to release game type resource"))
+		require.NotNil(t, errLog)
+		require.Equal(t, "failing", errLog.AttrValue("resource"))
+		require.ErrorIs(t, errLog.AttrValue("err").(error), err)
+	})
+
+	t.Run("AddAfterClose", func(t *testing.T) {
+		closer := newResourceCloser(testlog.Logger(t, log.LevelInfo))
+		closer.Close()
+		calls := 0
+		closer.add("late", func() error {
+			calls++
+			return nil
+		})
+		require.Equal(t, 1, calls, "should release resources acquired after closing immediately")
+		closer.Close()
+		require.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,125 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	methodDelay       = "delay"
+	methodWithdrawals = "withdrawals"
+)
+
+// delayedWETHABI is the subset of the DelayedWETH ABI used to check when bonds can be withdrawn.
+// op-bindings does not include DelayedWETH so the ABI is defined here.
+const delayedWETHABI = `[
+	{"type":"function","name":"delay","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"withdrawals","inputs":[{"name":"","type":"address"},{"name":"","type":"address"}],"outputs":[{"name":"amount","type":"uint256"},{"name":"timestamp","type":"uint256"}],"stateMutability":"view"}
+]`
+
+// LoadDelayedWETHABI returns the ABI used to bind DelayedWETH contracts.
+func LoadDelayedWETHABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(delayedWETHABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// WithdrawalRequest is the amount of a game's bonds unlocked for a recipient and when they were last unlocked.
+type WithdrawalRequest struct {
+	Amount    *big.Int
+	Timestamp uint64
+}
+
+// DelayedWETHContract is a binding for the DelayedWETH contract that holds the bonds of newer dispute games.
+// Bonds are unlocked when the game resolves and can only be withdrawn once the contract's delay has elapsed.
+type DelayedWETHContract struct {
+	addr        common.Address
+	multiCaller *batching.MultiCaller
+	contract    *batching.BoundContract
+
+	// delay caches the withdrawal delay in seconds once it has been loaded.
+	// 0 indicates the delay has not been loaded yet.
+	delay atomic.Uint64
+}
+
+func NewDelayedWETHContract(addr common.Address, caller *batching.MultiCaller) (*DelayedWETHContract, error) {
+	contractAbi, err := LoadDelayedWETHABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DelayedWETH ABI: %w", err)
+	}
+	return &DelayedWETHContract{
+		addr:        addr,
+		multiCaller: caller,
+		contract:    batching.NewBoundContract(contractAbi, addr),
+	}, nil
+}
+
+func (c *DelayedWETHContract) Addr() common.Address {
+	return c.addr
+}
+
+// Delay returns the time that must elapse after bonds are unlocked before they can be withdrawn.
+func (c *DelayedWETHContract) Delay(ctx context.Context) (time.Duration, error) {
+	if delay := c.delay.Load(); delay != 0 {
+		return time.Duration(delay) * time.Second, nil
+	}
+	result, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest, c.contract.Call(methodDelay))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch withdrawal delay: %w", err)
+	}
+	delay := result.GetBigInt(0).Uint64()
+	c.delay.Store(delay)
+	return time.Duration(delay) * time.Second, nil
+}
+
+// GetWithdrawal returns the bonds of game unlocked for recipient.
+func (c *DelayedWETHContract) GetWithdrawal(ctx context.Context, block batching.Block, game common.Address, recipient common.Address) (WithdrawalRequest, error) {
+	result, err := c.multiCaller.SingleCall(ctx, block, c.contract.Call(methodWithdrawals, game, recipient))
+	if err != nil {
+		return WithdrawalRequest{}, fmt.Errorf("failed to fetch withdrawal request: %w", err)
+	}
+	return WithdrawalRequest{
+		Amount:    result.GetBigInt(0),
+		Timestamp: result.GetBigInt(1).Uint64(),
+	}, nil
+}
+
+// DelayedWETHBondContract claims credit from a game that holds its bonds in a DelayedWETH contract.
+type DelayedWETHBondContract struct {
+	*FaultDisputeGameContract
+	weth *DelayedWETHContract
+}
+
+func NewDelayedWETHBondContract(game *FaultDisputeGameContract, weth *DelayedWETHContract) *DelayedWETHBondContract {
+	return &DelayedWETHBondContract{
+		FaultDisputeGameContract: game,
+		weth:                     weth,
+	}
+}
+
+// GetCreditUnlockTime returns the time the credit of recipient can be claimed from.
+// The zero time is returned if the credit has not been unlocked yet.
+func (c *DelayedWETHBondContract) GetCreditUnlockTime(ctx context.Context, recipient common.Address) (time.Time, error) {
+	withdrawal, err := c.weth.GetWithdrawal(ctx, batching.BlockLatest, c.addr, recipient)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if withdrawal.Timestamp == 0 {
+		return time.Time{}, nil
+	}
+	delay, err := c.weth.Delay(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(withdrawal.Timestamp), 0).Add(delay), nil
+}
@@ -0,0 +1,77 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var wethAddr = common.HexToAddress("0x55552842371dFC380576ebb09Ae16Cb6B6ca5555")
+
+func TestDelayedWETHContract_Delay(t *testing.T) {
+	stubRpc, weth := setupDelayedWETHTest(t)
+	stubRpc.SetResponse(wethAddr, methodDelay, batching.BlockLatest, nil, []interface{}{big.NewInt(600)})
+	delay, err := weth.Delay(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Minute, delay)
+
+	// Should cache responses
+	stubRpc.ClearResponses(methodDelay)
+	delay, err = weth.Delay(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Minute, delay)
+}
+
+func TestDelayedWETHContract_GetWithdrawal(t *testing.T) {
+	stubRpc, weth := setupDelayedWETHTest(t)
+	recipient := common.Address{0xbb}
+	block := batching.BlockByNumber(482)
+	stubRpc.SetResponse(wethAddr, methodWithdrawals, block, []interface{}{fdgAddr, recipient}, []interface{}{big.NewInt(123), big.NewInt(456)})
+	withdrawal, err := weth.GetWithdrawal(context.Background(), block, fdgAddr, recipient)
+	require.NoError(t, err)
+	require.Equal(t, WithdrawalRequest{Amount: big.NewInt(123), Timestamp: 456}, withdrawal)
+}
+
+func TestDelayedWETHBondContract_GetCreditUnlockTime(t *testing.T) {
+	recipient := common.Address{0xbb}
+	setup := func(t *testing.T, timestamp int64) *DelayedWETHBondContract {
+		stubRpc, game := setupFaultDisputeGameTest(t)
+		wethAbi, err := LoadDelayedWETHABI()
+		require.NoError(t, err)
+		stubRpc.AddContract(wethAddr, wethAbi)
+		weth, err := NewDelayedWETHContract(wethAddr, batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize))
+		require.NoError(t, err)
+		stubRpc.SetResponse(wethAddr, methodDelay, batching.BlockLatest, nil, []interface{}{big.NewInt(600)})
+		stubRpc.SetResponse(wethAddr, methodWithdrawals, batching.BlockLatest, []interface{}{fdgAddr, recipient}, []interface{}{big.NewInt(123), big.NewInt(timestamp)})
+		return NewDelayedWETHBondContract(game, weth)
+	}
+
+	t.Run("Unlocked", func(t *testing.T) {
+		contract := setup(t, 1000)
+		unlockTime, err := contract.GetCreditUnlockTime(context.Background(), recipient)
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(1600, 0), unlockTime)
+	})
+
+	t.Run("NotUnlocked", func(t *testing.T) {
+		contract := setup(t, 0)
+		unlockTime, err := contract.GetCreditUnlockTime(context.Background(), recipient)
+		require.NoError(t, err)
+		require.True(t, unlockTime.IsZero())
+	})
+}
+
+func setupDelayedWETHTest(t *testing.T) (*batchingTest.AbiBasedRpc, *DelayedWETHContract) {
+	wethAbi, err := LoadDelayedWETHABI()
+	require.NoError(t, err)
+	stubRpc := batchingTest.NewAbiBasedRpc(t, wethAddr, wethAbi)
+	weth, err := NewDelayedWETHContract(wethAddr, batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize))
+	require.NoError(t, err)
+	return stubRpc, weth
+}
@@ -40,6 +40,7 @@ var (
 )
 
 type FaultDisputeGameContract struct {
+	addr        common.Address
 	multiCaller *batching.MultiCaller
 	contract    *batching.BoundContract
 }
@@ -56,11 +57,17 @@ func NewFaultDisputeGameContract(addr common.Address, caller *batching.MultiCall
 	}
 
 	return &FaultDisputeGameContract{
+		addr:        addr,
 		multiCaller: caller,
 		contract:    batching.NewBoundContract(contractAbi, addr),
 	}, nil
 }
 
+// Addr returns the address of the game contract.
+func (c *FaultDisputeGameContract) Addr() common.Address {
+	return c.addr
+}
+
 // GetBlockRange returns the block numbers of the absolute pre-state block (typically genesis or the bedrock activation block)
 // and the post-state block (that the proposed output root is for).
 func (c *FaultDisputeGameContract) GetBlockRange(ctx context.Context) (prestateBlock uint64, poststateBlock uint64, retErr error) {
@@ -80,6 +87,42 @@ func (c *FaultDisputeGameContract) GetBlockRange(ctx context.Context) (prestateB
 	return
 }
 
+// GameSetupData is the data needed to create a player for a game. None of it changes once the game is created.
+type GameSetupData struct {
+	PrestateBlock     uint64
+	PoststateBlock    uint64
+	SplitDepth        types.Depth
+	L1Head            common.Hash
+	AbsolutePrestate  common.Hash
+	GenesisOutputRoot common.Hash
+}
+
+// GetSetupData returns the block range, split depth, L1 head, absolute prestate hash and genesis output root
+// of the game, read in a single batch.
+func (c *FaultDisputeGameContract) GetSetupData(ctx context.Context) (GameSetupData, error) {
+	results, err := c.multiCaller.Call(ctx, batching.BlockLatest,
+		c.contract.Call(methodGenesisBlockNumber),
+		c.contract.Call(methodL2BlockNumber),
+		c.contract.Call(methodSplitDepth),
+		c.contract.Call(methodL1Head),
+		c.contract.Call(methodAbsolutePrestate),
+		c.contract.Call(methodGenesisOutputRoot))
+	if err != nil {
+		return GameSetupData{}, fmt.Errorf("failed to retrieve game setup data: %w", err)
+	}
+	if len(results) != 6 {
+		return GameSetupData{}, fmt.Errorf("expected 6 results but got %v", len(results))
+	}
+	return GameSetupData{
+		PrestateBlock:     results[0].GetBigInt(0).Uint64(),
+		PoststateBlock:    results[1].GetBigInt(0).Uint64(),
+		SplitDepth:        types.Depth(results[2].GetBigInt(0).Uint64()),
+		L1Head:            results[3].GetHash(0),
+		AbsolutePrestate:  results[4].GetHash(0),
+		GenesisOutputRoot: results[5].GetHash(0),
+	}, nil
+}
+
 // GetGameMetadata returns the game's L2 block number, root claim, status, and game duration.
 func (c *FaultDisputeGameContract) GetGameMetadata(ctx context.Context) (uint64, common.Hash, gameTypes.GameStatus, uint64, error) {
 	results, err := c.multiCaller.Call(ctx, batching.BlockLatest,
@@ -237,6 +280,24 @@ func (f *FaultDisputeGameContract) GetClaimCount(ctx context.Context) (uint64, e
 	return result.GetBigInt(0).Uint64(), nil
 }
 
+// GetStatusAndClaimCount returns the game's status and number of claims, read in a single batched call.
+func (f *FaultDisputeGameContract) GetStatusAndClaimCount(ctx context.Context) (gameTypes.GameStatus, uint64, error) {
+	results, err := f.multiCaller.Call(ctx, batching.BlockLatest,
+		f.contract.Call(methodStatus),
+		f.contract.Call(methodClaimCount))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch status and claim count: %w", err)
+	}
+	if len(results) != 2 {
+		return 0, 0, fmt.Errorf("expected 2 results but got %v", len(results))
+	}
+	status, err := gameTypes.GameStatusFromUint8(results[0].GetUint8(0))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to convert game status: %w", err)
+	}
+	return status, results[1].GetBigInt(0).Uint64(), nil
+}
+
 func (f *FaultDisputeGameContract) GetClaim(ctx context.Context, idx uint64) (types.Claim, error) {
 	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodClaim, new(big.Int).SetUint64(idx)))
 	if err != nil {
@@ -316,6 +316,44 @@ func TestGetSplitDepth(t *testing.T) {
 	require.Equal(t, expectedSplitDepth, splitDepth)
 }
 
+func TestGetSetupData(t *testing.T) {
+	stubRpc, contract := setupFaultDisputeGameTest(t)
+	stubRpc.SetResponse(fdgAddr, methodGenesisBlockNumber, batching.BlockLatest, nil, []interface{}{big.NewInt(65)})
+	stubRpc.SetResponse(fdgAddr, methodL2BlockNumber, batching.BlockLatest, nil, []interface{}{big.NewInt(102)})
+	stubRpc.SetResponse(fdgAddr, methodSplitDepth, batching.BlockLatest, nil, []interface{}{big.NewInt(15)})
+	stubRpc.SetResponse(fdgAddr, methodL1Head, batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+	stubRpc.SetResponse(fdgAddr, methodAbsolutePrestate, batching.BlockLatest, nil, []interface{}{common.Hash{0xbb}})
+	stubRpc.SetResponse(fdgAddr, methodGenesisOutputRoot, batching.BlockLatest, nil, []interface{}{common.Hash{0xcc}})
+	data, err := contract.GetSetupData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, GameSetupData{
+		PrestateBlock:     65,
+		PoststateBlock:    102,
+		SplitDepth:        15,
+		L1Head:            common.Hash{0xaa},
+		AbsolutePrestate:  common.Hash{0xbb},
+		GenesisOutputRoot: common.Hash{0xcc},
+	}, data)
+
+	// Should match the individual getters
+	prestateBlock, poststateBlock, err := contract.GetBlockRange(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, prestateBlock, data.PrestateBlock)
+	require.Equal(t, poststateBlock, data.PoststateBlock)
+	splitDepth, err := contract.GetSplitDepth(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, splitDepth, data.SplitDepth)
+	l1Head, err := contract.GetL1Head(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, l1Head, data.L1Head)
+	prestate, err := contract.GetAbsolutePrestateHash(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, prestate, data.AbsolutePrestate)
+	genesisOutputRoot, err := contract.GetGenesisOutputRoot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, genesisOutputRoot, data.GenesisOutputRoot)
+}
+
 func TestGetGameMetadata(t *testing.T) {
 	stubRpc, contract := setupFaultDisputeGameTest(t)
 	expectedL2BlockNumber := uint64(123)
@@ -334,6 +372,18 @@ func TestGetGameMetadata(t *testing.T) {
 	require.Equal(t, expectedGameDuration, duration)
 }
 
+func TestGetStatusAndClaimCount(t *testing.T) {
+	stubRpc, contract := setupFaultDisputeGameTest(t)
+	expectedStatus := types.GameStatusDefenderWon
+	expectedClaimCount := uint64(7)
+	stubRpc.SetResponse(fdgAddr, methodStatus, batching.BlockLatest, nil, []interface{}{expectedStatus})
+	stubRpc.SetResponse(fdgAddr, methodClaimCount, batching.BlockLatest, nil, []interface{}{new(big.Int).SetUint64(expectedClaimCount)})
+	status, claimCount, err := contract.GetStatusAndClaimCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expectedStatus, status)
+	require.Equal(t, expectedClaimCount, claimCount)
+}
+
 func TestGetGenesisOutputRoot(t *testing.T) {
 	stubRpc, contract := setupFaultDisputeGameTest(t)
 	expectedOutputRoot := common.HexToHash("0x1234")
@@ -0,0 +1,100 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	gameTypeCacheSize = 32
+	gameCacheSize     = 1000
+)
+
+// GameDataCache caches contract reads that never change for a given game type or game.
+// Data for a game type is read from the factory's current implementation for that type, so it must be
+// invalidated with InvalidateGameType when the implementation is upgraded.
+// Data for a game is keyed by the game's proxy address and never needs to be invalidated.
+type GameDataCache struct {
+	gameFactory *DisputeGameFactoryContract
+	caller      *batching.MultiCaller
+
+	oracles   *caching.LRUCache[uint32, *PreimageOracleContract]
+	prestates *caching.LRUCache[uint32, common.Hash]
+	setupData *caching.LRUCache[common.Address, GameSetupData]
+}
+
+func NewGameDataCache(m caching.Metrics, gameFactory *DisputeGameFactoryContract, caller *batching.MultiCaller) *GameDataCache {
+	return &GameDataCache{
+		gameFactory: gameFactory,
+		caller:      caller,
+		oracles:     caching.NewLRUCache[uint32, *PreimageOracleContract](m, "game_type_oracle", gameTypeCacheSize),
+		prestates:   caching.NewLRUCache[uint32, common.Hash](m, "game_type_prestate", gameTypeCacheSize),
+		setupData:   caching.NewLRUCache[common.Address, GameSetupData](m, "game_setup_data", gameCacheSize),
+	}
+}
+
+// GetOracle returns the preimage oracle used by the factory's implementation of gameType.
+func (c *GameDataCache) GetOracle(ctx context.Context, gameType uint32) (*PreimageOracleContract, error) {
+	if oracle, ok := c.oracles.Get(gameType); ok {
+		return oracle, nil
+	}
+	implAddr, err := c.gameFactory.GetGameImpl(ctx, gameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load implementation for game type %v: %w", gameType, err)
+	}
+	impl, err := NewFaultDisputeGameContract(implAddr, c.caller)
+	if err != nil {
+		return nil, err
+	}
+	oracle, err := impl.GetOracle(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load oracle address: %w", err)
+	}
+	c.oracles.Add(gameType, oracle)
+	return oracle, nil
+}
+
+// GetAbsolutePrestateHash returns the absolute pre-state hash of the factory's implementation of gameType.
+func (c *GameDataCache) GetAbsolutePrestateHash(ctx context.Context, gameType uint32) (common.Hash, error) {
+	if prestate, ok := c.prestates.Get(gameType); ok {
+		return prestate, nil
+	}
+	implAddr, err := c.gameFactory.GetGameImpl(ctx, gameType)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load implementation for game type %v: %w", gameType, err)
+	}
+	impl, err := NewFaultDisputeGameContract(implAddr, c.caller)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	prestate, err := impl.GetAbsolutePrestateHash(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	c.prestates.Add(gameType, prestate)
+	return prestate, nil
+}
+
+// InvalidateGameType drops the cached data for gameType so it is read again from the factory's
+// implementation. It must be called when the implementation for gameType is upgraded.
+func (c *GameDataCache) InvalidateGameType(gameType uint32) {
+	c.oracles.Remove(gameType)
+	c.prestates.Remove(gameType)
+}
+
+// GetSetupData returns the data needed to create a player for game.
+func (c *GameDataCache) GetSetupData(ctx context.Context, game *FaultDisputeGameContract) (GameSetupData, error) {
+	if data, ok := c.setupData.Get(game.Addr()); ok {
+		return data, nil
+	}
+	data, err := game.GetSetupData(ctx)
+	if err != nil {
+		return GameSetupData{}, err
+	}
+	c.setupData.Add(game.Addr(), data)
+	return data, nil
+}
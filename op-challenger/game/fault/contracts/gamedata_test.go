@@ -0,0 +1,225 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	gameDataImplAddr      = common.Address{0x1a}
+	gameDataGameAddr      = common.Address{0x2a}
+	gameDataOtherGameAddr = common.Address{0x3a}
+)
+
+func TestGameDataCache_GetOracle(t *testing.T) {
+	implAddr := gameDataImplAddr
+	t.Run("CachedByGameType", func(t *testing.T) {
+		stubRpc, m, cache := setupGameDataCacheTest(t)
+		stubRpc.SetResponse(factoryAddr, methodGameImpls, batching.BlockLatest, []interface{}{faultTypes.CannonGameType}, []interface{}{implAddr})
+		stubRpc.SetResponse(implAddr, methodVM, batching.BlockLatest, nil, []interface{}{vmAddr})
+		stubRpc.SetResponse(vmAddr, methodOracle, batching.BlockLatest, nil, []interface{}{oracleAddr})
+
+		for i := 0; i < 3; i++ {
+			oracle, err := cache.GetOracle(context.Background(), faultTypes.CannonGameType)
+			require.NoError(t, err)
+			require.Equal(t, oracleAddr, oracle.Addr())
+		}
+		require.Equal(t, 3, stubRpc.calls, "should only load the implementation, vm and oracle once")
+		require.Equal(t, 2, m.hits["game_type_oracle"])
+		require.Equal(t, 1, m.misses["game_type_oracle"])
+	})
+
+	t.Run("InvalidateGameType", func(t *testing.T) {
+		upgradedOracleAddr := common.Address{0x5a}
+		stubRpc, _, cache := setupGameDataCacheTest(t)
+		stubRpc.SetResponse(factoryAddr, methodGameImpls, batching.BlockLatest, []interface{}{faultTypes.CannonGameType}, []interface{}{implAddr})
+		stubRpc.SetResponse(implAddr, methodVM, batching.BlockLatest, nil, []interface{}{vmAddr})
+		stubRpc.SetResponse(vmAddr, methodOracle, batching.BlockLatest, nil, []interface{}{oracleAddr})
+		oracle, err := cache.GetOracle(context.Background(), faultTypes.CannonGameType)
+		require.NoError(t, err)
+		require.Equal(t, oracleAddr, oracle.Addr())
+
+		stubRpc.ClearResponses(methodOracle)
+		stubRpc.SetResponse(vmAddr, methodOracle, batching.BlockLatest, nil, []interface{}{upgradedOracleAddr})
+		oracle, err = cache.GetOracle(context.Background(), faultTypes.CannonGameType)
+		require.NoError(t, err)
+		require.Equal(t, oracleAddr, oracle.Addr(), "should use cached oracle until invalidated")
+
+		cache.InvalidateGameType(faultTypes.CannonGameType)
+		oracle, err = cache.GetOracle(context.Background(), faultTypes.CannonGameType)
+		require.NoError(t, err)
+		require.Equal(t, upgradedOracleAddr, oracle.Addr())
+		require.Equal(t, 6, stubRpc.calls)
+	})
+
+	t.Run("ErrorsAreNotCached", func(t *testing.T) {
+		stubRpc, _, cache := setupGameDataCacheTest(t)
+		stubRpc.err = errors.New("unavailable")
+		_, err := cache.GetOracle(context.Background(), faultTypes.CannonGameType)
+		require.ErrorIs(t, err, stubRpc.err)
+
+		stubRpc.err = nil
+		stubRpc.SetResponse(factoryAddr, methodGameImpls, batching.BlockLatest, []interface{}{faultTypes.CannonGameType}, []interface{}{implAddr})
+		stubRpc.SetResponse(implAddr, methodVM, batching.BlockLatest, nil, []interface{}{vmAddr})
+		stubRpc.SetResponse(vmAddr, methodOracle, batching.BlockLatest, nil, []interface{}{oracleAddr})
+		oracle, err := cache.GetOracle(context.Background(), faultTypes.CannonGameType)
+		require.NoError(t, err)
+		require.Equal(t, oracleAddr, oracle.Addr())
+	})
+}
+
+func TestGameDataCache_GetAbsolutePrestateHash(t *testing.T) {
+	stubRpc, m, cache := setupGameDataCacheTest(t)
+	stubRpc.SetResponse(factoryAddr, methodGameImpls, batching.BlockLatest, []interface{}{faultTypes.CannonGameType}, []interface{}{gameDataImplAddr})
+	stubRpc.SetResponse(gameDataImplAddr, methodAbsolutePrestate, batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+
+	for i := 0; i < 3; i++ {
+		prestate, err := cache.GetAbsolutePrestateHash(context.Background(), faultTypes.CannonGameType)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0xab}, prestate)
+	}
+	require.Equal(t, 2, stubRpc.calls, "should only load the implementation and prestate once")
+	require.Equal(t, 2, m.hits["game_type_prestate"])
+	require.Equal(t, 1, m.misses["game_type_prestate"])
+
+	cache.InvalidateGameType(faultTypes.CannonGameType)
+	_, err := cache.GetAbsolutePrestateHash(context.Background(), faultTypes.CannonGameType)
+	require.NoError(t, err)
+	require.Equal(t, 4, stubRpc.calls)
+}
+
+func TestGameDataCache_PerGameData(t *testing.T) {
+	stubRpc, m, cache := setupGameDataCacheTest(t)
+	game, err := NewFaultDisputeGameContract(gameDataGameAddr, cache.caller)
+	require.NoError(t, err)
+	otherGame, err := NewFaultDisputeGameContract(gameDataOtherGameAddr, cache.caller)
+	require.NoError(t, err)
+
+	for _, addr := range []common.Address{gameDataGameAddr, gameDataOtherGameAddr} {
+		stubRpc.SetResponse(addr, methodSplitDepth, batching.BlockLatest, nil, []interface{}{big.NewInt(int64(addr[0]))})
+		stubRpc.SetResponse(addr, methodGenesisBlockNumber, batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+		stubRpc.SetResponse(addr, methodL2BlockNumber, batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+		stubRpc.SetResponse(addr, methodL1Head, batching.BlockLatest, nil, []interface{}{common.Hash{addr[0]}})
+		stubRpc.SetResponse(addr, methodAbsolutePrestate, batching.BlockLatest, nil, []interface{}{common.Hash{0xaa, addr[0]}})
+		stubRpc.SetResponse(addr, methodGenesisOutputRoot, batching.BlockLatest, nil, []interface{}{common.Hash{0xbb, addr[0]}})
+	}
+
+	for i := 0; i < 2; i++ {
+		for _, g := range []*FaultDisputeGameContract{game, otherGame} {
+			data, err := cache.GetSetupData(context.Background(), g)
+			require.NoError(t, err)
+			require.Equal(t, GameSetupData{
+				PrestateBlock:     10,
+				PoststateBlock:    20,
+				SplitDepth:        faultTypes.Depth(g.Addr()[0]),
+				L1Head:            common.Hash{g.Addr()[0]},
+				AbsolutePrestate:  common.Hash{0xaa, g.Addr()[0]},
+				GenesisOutputRoot: common.Hash{0xbb, g.Addr()[0]},
+			}, data)
+		}
+	}
+	// Each game's setup data is read once, in a single batch
+	require.Equal(t, 2, stubRpc.batches)
+	require.Equal(t, 12, stubRpc.calls)
+	require.Equal(t, 2, m.misses["game_setup_data"])
+	require.Equal(t, 2, m.hits["game_setup_data"])
+}
+
+func TestGameDataCache_SetupDataBatchesReads(t *testing.T) {
+	stubRpc, _, cache := setupGameDataCacheTest(t)
+	game, err := NewFaultDisputeGameContract(gameDataGameAddr, cache.caller)
+	require.NoError(t, err)
+	stubRpc.SetResponse(gameDataGameAddr, methodSplitDepth, batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+	stubRpc.SetResponse(gameDataGameAddr, methodGenesisBlockNumber, batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+	stubRpc.SetResponse(gameDataGameAddr, methodL2BlockNumber, batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+	stubRpc.SetResponse(gameDataGameAddr, methodL1Head, batching.BlockLatest, nil, []interface{}{common.Hash{0x11}})
+	stubRpc.SetResponse(gameDataGameAddr, methodAbsolutePrestate, batching.BlockLatest, nil, []interface{}{common.Hash{0x22}})
+	stubRpc.SetResponse(gameDataGameAddr, methodGenesisOutputRoot, batching.BlockLatest, nil, []interface{}{common.Hash{0x33}})
+
+	_, _, err = game.GetBlockRange(context.Background())
+	require.NoError(t, err)
+	_, err = game.GetSplitDepth(context.Background())
+	require.NoError(t, err)
+	_, err = game.GetL1Head(context.Background())
+	require.NoError(t, err)
+	_, err = game.GetAbsolutePrestateHash(context.Background())
+	require.NoError(t, err)
+	_, err = game.GetGenesisOutputRoot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 5, stubRpc.batches, "individual getters should need a round trip each")
+
+	stubRpc.batches = 0
+	_, err = cache.GetSetupData(context.Background(), game)
+	require.NoError(t, err)
+	require.Equal(t, 1, stubRpc.batches, "should read all setup data in one round trip")
+}
+
+func setupGameDataCacheTest(t *testing.T) (*countingRpc, *cacheMetrics, *GameDataCache) {
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	vmAbi, err := bindings.MIPSMetaData.GetAbi()
+	require.NoError(t, err)
+	stubRpc := &countingRpc{AbiBasedRpc: batchingTest.NewAbiBasedRpc(t, factoryAddr, factoryAbi)}
+	stubRpc.AddContract(gameDataImplAddr, fdgAbi)
+	stubRpc.AddContract(gameDataGameAddr, fdgAbi)
+	stubRpc.AddContract(gameDataOtherGameAddr, fdgAbi)
+	stubRpc.AddContract(vmAddr, vmAbi)
+	caller := batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize)
+	factory, err := NewDisputeGameFactoryContract(factoryAddr, caller)
+	require.NoError(t, err)
+	m := &cacheMetrics{hits: make(map[string]int), misses: make(map[string]int)}
+	return stubRpc, m, NewGameDataCache(m, factory, caller)
+}
+
+// countingRpc counts the requests and round trips made to the stubbed RPC and fails them all when err is set.
+type countingRpc struct {
+	*batchingTest.AbiBasedRpc
+	calls   int
+	batches int
+	err     error
+}
+
+func (r *countingRpc) CallContext(ctx context.Context, out interface{}, method string, args ...interface{}) error {
+	r.calls++
+	r.batches++
+	if r.err != nil {
+		return r.err
+	}
+	return r.AbiBasedRpc.CallContext(ctx, out, method, args...)
+}
+
+func (r *countingRpc) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	r.calls += len(b)
+	r.batches++
+	if r.err != nil {
+		return r.err
+	}
+	return r.AbiBasedRpc.BatchCallContext(ctx, b)
+}
+
+type cacheMetrics struct {
+	hits   map[string]int
+	misses map[string]int
+}
+
+func (m *cacheMetrics) CacheAdd(_ string, _ int, _ bool) {}
+
+func (m *cacheMetrics) CacheGet(label string, hit bool) {
+	if hit {
+		m.hits[label]++
+	} else {
+		m.misses[label]++
+	}
+}
@@ -39,6 +39,7 @@ const (
 	methodChallengePeriod                    = "challengePeriod"
 	methodGetTreeRootLPP                     = "getTreeRootLPP"
 	methodMinBondSizeLPP                     = "MIN_BOND_SIZE"
+	methodVersion                            = "version"
 )
 
 var (
@@ -52,6 +53,8 @@ type PreimageOracleContract struct {
 	addr        common.Address
 	multiCaller *batching.MultiCaller
 	contract    *batching.BoundContract
+	// semver reads the version of oracles that implement ISemver, which is not part of the IPreimageOracle interface.
+	semver *batching.BoundContract
 
 	// challengePeriod caches the challenge period from the contract once it has been loaded.
 	// 0 indicates the period has not been loaded yet.
@@ -75,11 +78,16 @@ func NewPreimageOracleContract(addr common.Address, caller *batching.MultiCaller
 	if err != nil {
 		return nil, fmt.Errorf("failed to load preimage oracle ABI: %w", err)
 	}
+	semverAbi, err := bindings.ISemverMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load semver ABI: %w", err)
+	}
 
 	return &PreimageOracleContract{
 		addr:        addr,
 		multiCaller: caller,
 		contract:    batching.NewBoundContract(oracleAbi, addr),
+		semver:      batching.NewBoundContract(semverAbi, addr),
 	}, nil
 }
 
@@ -148,6 +156,16 @@ func (c *PreimageOracleContract) ChallengePeriod(ctx context.Context) (uint64, e
 	return period, nil
 }
 
+// Version returns the semantic version reported by the oracle.
+// It fails if the oracle does not implement ISemver.
+func (c *PreimageOracleContract) Version(ctx context.Context) (string, error) {
+	result, err := c.multiCaller.SingleCall(ctx, batching.BlockLatest, c.semver.Call(methodVersion))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch oracle version: %w", err)
+	}
+	return result.GetString(0), nil
+}
+
 func (c *PreimageOracleContract) CallSqueeze(
 	ctx context.Context,
 	claimant common.Address,
@@ -3,6 +3,7 @@ package contracts
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"math/rand"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -103,6 +105,14 @@ func TestPreimageOracleContract_ChallengePeriod(t *testing.T) {
 	require.Equal(t, uint64(123), challengePeriod)
 }
 
+func TestPreimageOracleContract_Version(t *testing.T) {
+	stubRpc, oracle := setupPreimageOracleTest(t)
+	stubRpc.SetResponse(oracleAddr, methodVersion, batching.BlockLatest, nil, []interface{}{"1.2.3"})
+	version, err := oracle.Version(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", version)
+}
+
 func TestPreimageOracleContract_MinLargePreimageSize(t *testing.T) {
 	stubRpc, oracle := setupPreimageOracleTest(t)
 	stubRpc.SetResponse(oracleAddr, methodMinProposalSize, batching.BlockLatest,
@@ -353,16 +363,26 @@ func setupPreimageOracleTestWithProposals(t *testing.T, block batching.Block) (*
 }
 
 func setupPreimageOracleTest(t *testing.T) (*batchingTest.AbiBasedRpc, *PreimageOracleContract) {
-	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
-	require.NoError(t, err)
-
-	stubRpc := batchingTest.NewAbiBasedRpc(t, oracleAddr, oracleAbi)
+	stubRpc := batchingTest.NewAbiBasedRpc(t, oracleAddr, loadOracleAbiWithVersion(t))
 	oracleContract, err := NewPreimageOracleContract(oracleAddr, batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize))
 	require.NoError(t, err)
 
 	return stubRpc, oracleContract
 }
 
+// loadOracleAbiWithVersion returns the preimage oracle ABI with the ISemver version method added,
+// so the stub can respond to version calls at the oracle address.
+func loadOracleAbiWithVersion(t *testing.T) *abi.ABI {
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	semverAbi, err := bindings.ISemverMetaData.GetAbi()
+	require.NoError(t, err)
+	withVersion := *oracleAbi
+	withVersion.Methods = maps.Clone(oracleAbi.Methods)
+	withVersion.Methods[methodVersion] = semverAbi.Methods[methodVersion]
+	return &withVersion
+}
+
 func TestMetadata(t *testing.T) {
 	uint32Values := []uint32{0, 1, 2, 3252354, math.MaxUint32}
 	tests := []struct {
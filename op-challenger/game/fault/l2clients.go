@@ -0,0 +1,279 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	l2DialAttempts = 5
+	// l2FailureThreshold is the number of consecutive failures after which an L2 endpoint is considered unhealthy.
+	l2FailureThreshold = 3
+	// l2FailureCooldown is how long an unhealthy L2 endpoint is skipped before it is tried again.
+	l2FailureCooldown = 30 * time.Second
+)
+
+var (
+	ErrL2HeaderMismatch = errors.New("l2 header does not match requested block")
+	ErrL2ClientClosed   = errors.New("l2 client closed")
+)
+
+type l2Client interface {
+	cannon.L2HeaderSource
+	Close()
+}
+
+// l2Source provides the L2 headers for a game type. It is connected before the first game of the type is played.
+type l2Source interface {
+	cannon.L2HeaderSource
+	connect(ctx context.Context) (l2Client, error)
+}
+
+type l2Dialer func(ctx context.Context, url string) (l2Client, error)
+
+// l2Clients provides a single lazily dialled client for each distinct L2 RPC URL so that game types
+// configured with the same endpoint share a connection.
+type l2Clients struct {
+	logger   log.Logger
+	clock    clock.Clock
+	dialer   l2Dialer
+	strategy retry.Strategy
+	clients  map[string]*lazyL2Client
+}
+
+func newL2Clients(logger log.Logger, dialer l2Dialer) *l2Clients {
+	return &l2Clients{
+		logger:   logger,
+		clock:    clock.SystemClock,
+		dialer:   dialer,
+		strategy: retry.Exponential(),
+		clients:  make(map[string]*lazyL2Client),
+	}
+}
+
+// source returns the client for a single url, or a client that fails over between urls in order.
+func (c *l2Clients) source(urls ...string) l2Source {
+	if len(urls) == 1 {
+		return c.client(urls[0])
+	}
+	endpoints := make([]*l2Endpoint, len(urls))
+	for i, url := range urls {
+		endpoints[i] = &l2Endpoint{client: c.client(url)}
+	}
+	return &failoverL2Client{
+		logger:    c.logger,
+		clock:     c.clock,
+		endpoints: endpoints,
+	}
+}
+
+// client returns the client for url. No connection is made until the client is first used.
+func (c *l2Clients) client(url string) *lazyL2Client {
+	if client, ok := c.clients[url]; ok {
+		return client
+	}
+	c.logger.Info("Deferring L2 connection until first use", "url", url)
+	client := &lazyL2Client{
+		url:      url,
+		dialer:   c.dialer,
+		strategy: c.strategy,
+	}
+	c.clients[url] = client
+	return client
+}
+
+// Close closes every client that has been connected.
+func (c *l2Clients) Close() {
+	for _, client := range c.clients {
+		client.Close()
+	}
+}
+
+// lazyL2Client dials the L2 node on first use, retrying with backoff if the node is unavailable.
+// It is safe for concurrent use. Once closed, every request fails with ErrL2ClientClosed.
+type lazyL2Client struct {
+	url      string
+	dialer   l2Dialer
+	strategy retry.Strategy
+
+	lock   sync.Mutex
+	client l2Client
+	closed bool
+}
+
+// connect dials the L2 node if it is not already connected.
+func (c *lazyL2Client) connect(ctx context.Context) (l2Client, error) {
+	return c.dial(ctx, l2DialAttempts)
+}
+
+// dial connects to the L2 node with up to attempts dials if it is not already connected.
+// The lock isn't held while dialling so a slow or unavailable node doesn't block other callers. If concurrent
+// dials both succeed, the first connection is kept and the other is closed.
+func (c *lazyL2Client) dial(ctx context.Context, attempts int) (l2Client, error) {
+	if client, err := c.current(); client != nil || err != nil {
+		return client, err
+	}
+	client, err := retry.Do(ctx, attempts, c.strategy, func() (l2Client, error) {
+		return c.dialer(ctx, c.url)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dial l2 client %v: %w", c.url, err)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		client.Close()
+		return nil, fmt.Errorf("%w: %v", ErrL2ClientClosed, c.url)
+	}
+	if c.client != nil {
+		client.Close()
+		return c.client, nil
+	}
+	c.client = client
+	return client, nil
+}
+
+// current returns the connected client, if any, or ErrL2ClientClosed if the client has been closed.
+func (c *lazyL2Client) current() (l2Client, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return nil, fmt.Errorf("%w: %v", ErrL2ClientClosed, c.url)
+	}
+	return c.client, nil
+}
+
+func (c *lazyL2Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return c.headerByNumber(ctx, number, l2DialAttempts)
+}
+
+func (c *lazyL2Client) headerByNumber(ctx context.Context, number *big.Int, dialAttempts int) (*types.Header, error) {
+	client, err := c.dial(ctx, dialAttempts)
+	if err != nil {
+		return nil, err
+	}
+	return client.HeaderByNumber(ctx, number)
+}
+
+// Close closes the connection if it was ever opened. The client isn't dialled again once closed.
+func (c *lazyL2Client) Close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closed = true
+	if c.client != nil {
+		c.client.Close()
+		c.client = nil
+	}
+}
+
+// failoverL2Client requests headers from the first healthy endpoint, failing over to the next endpoint when
+// a request fails or returns a header for a different block. Endpoints that fail repeatedly are skipped for
+// a cooldown period. Each endpoint is dialled once per request rather than retried, so an unavailable endpoint
+// fails over immediately. The clients are owned by l2Clients, which closes them.
+type failoverL2Client struct {
+	logger    log.Logger
+	clock     clock.Clock
+	endpoints []*l2Endpoint
+}
+
+// connect connects to the first healthy endpoint that can be dialled.
+func (f *failoverL2Client) connect(ctx context.Context) (l2Client, error) {
+	var errs []error
+	for _, e := range f.orderedEndpoints() {
+		client, err := e.client.dial(ctx, 1)
+		if err == nil {
+			e.record(nil, f.clock.Now())
+			return client, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		e.record(err, f.clock.Now())
+		errs = append(errs, err)
+	}
+	return nil, fmt.Errorf("failed to connect to any l2 endpoint: %w", errors.Join(errs...))
+}
+
+func (f *failoverL2Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var errs []error
+	for _, e := range f.orderedEndpoints() {
+		header, err := e.client.headerByNumber(ctx, number, 1)
+		if err == nil && number != nil && (header.Number == nil || header.Number.Cmp(number) != 0) {
+			err = fmt.Errorf("%w: requested %v but got %v", ErrL2HeaderMismatch, number, header.Number)
+		}
+		if err == nil {
+			e.record(nil, f.clock.Now())
+			return header, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		healthy := e.record(err, f.clock.Now())
+		f.logger.Warn("L2 header request failed, failing over", "url", e.client.url, "block", number, "healthy", healthy, "err", err)
+		errs = append(errs, fmt.Errorf("%v: %w", e.client.url, err))
+	}
+	return nil, fmt.Errorf("failed to fetch l2 header from any endpoint: %w", errors.Join(errs...))
+}
+
+// orderedEndpoints returns the healthy endpoints in configured order, followed by the unhealthy ones
+// so a request is still attempted when every endpoint is unhealthy.
+func (f *failoverL2Client) orderedEndpoints() []*l2Endpoint {
+	now := f.clock.Now()
+	healthy := make([]*l2Endpoint, 0, len(f.endpoints))
+	var unhealthy []*l2Endpoint
+	for _, e := range f.endpoints {
+		if e.healthy(now) {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// l2Endpoint is an L2 client with its health state. Consecutive failures mark the endpoint unhealthy for a
+// cooldown period during which failover skips it.
+type l2Endpoint struct {
+	client *lazyL2Client
+
+	lock           sync.Mutex
+	failures       int
+	unhealthyUntil time.Time
+}
+
+func (e *l2Endpoint) healthy(now time.Time) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return !now.Before(e.unhealthyUntil)
+}
+
+// record updates the health of the endpoint with the outcome of a request and returns the resulting health.
+func (e *l2Endpoint) record(err error, now time.Time) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err == nil {
+		e.failures = 0
+		return !now.Before(e.unhealthyUntil)
+	}
+	e.failures++
+	if e.failures >= l2FailureThreshold {
+		e.unhealthyUntil = now.Add(l2FailureCooldown)
+		e.failures = 0
+	}
+	return !now.Before(e.unhealthyUntil)
+}
+
+func dialL2Client(ctx context.Context, url string) (l2Client, error) {
+	return ethclient.DialContext(ctx, url)
+}
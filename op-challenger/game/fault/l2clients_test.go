@@ -0,0 +1,287 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL2Clients(t *testing.T) {
+	newClients := func(t *testing.T, dialer *stubL2Dialer) *l2Clients {
+		clients := newL2Clients(testlog.Logger(t, log.LevelInfo), dialer.dial)
+		clients.strategy = retry.Fixed(0)
+		return clients
+	}
+
+	t.Run("NotDialledUntilUsed", func(t *testing.T) {
+		dialer := &stubL2Dialer{}
+		clients := newClients(t, dialer)
+		client := clients.client("http://l2")
+		require.Empty(t, dialer.dialed)
+
+		clients.Close()
+		require.Empty(t, dialer.dialed)
+		require.Nil(t, client.client)
+	})
+
+	t.Run("SharedURLDialledOnce", func(t *testing.T) {
+		dialer := &stubL2Dialer{}
+		clients := newClients(t, dialer)
+		first := clients.client("http://l2")
+		second := clients.client("http://l2")
+		require.Same(t, first, second)
+
+		_, err := first.HeaderByNumber(context.Background(), nil)
+		require.NoError(t, err)
+		_, err = second.HeaderByNumber(context.Background(), nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://l2"}, dialer.dialed)
+
+		connected := first.client.(*stubL2Client)
+		clients.Close()
+		require.Equal(t, 1, connected.closes)
+	})
+
+	t.Run("DistinctURLsDialledSeparately", func(t *testing.T) {
+		dialer := &stubL2Dialer{}
+		clients := newClients(t, dialer)
+		first, err := clients.client("http://l2-a").connect(context.Background())
+		require.NoError(t, err)
+		second, err := clients.client("http://l2-b").connect(context.Background())
+		require.NoError(t, err)
+		require.NotSame(t, first, second)
+		require.Equal(t, []string{"http://l2-a", "http://l2-b"}, dialer.dialed)
+
+		clients.Close()
+		require.Equal(t, 1, first.(*stubL2Client).closes)
+		require.Equal(t, 1, second.(*stubL2Client).closes)
+	})
+
+	t.Run("RetryDial", func(t *testing.T) {
+		dialer := &stubL2Dialer{err: errors.New("boom"), failures: 2}
+		clients := newClients(t, dialer)
+		_, err := clients.client("http://l2").connect(context.Background())
+		require.NoError(t, err)
+		require.Len(t, dialer.dialed, 3)
+	})
+
+	t.Run("DialError", func(t *testing.T) {
+		dialErr := errors.New("boom")
+		dialer := &stubL2Dialer{err: dialErr, failures: l2DialAttempts}
+		clients := newClients(t, dialer)
+		client := clients.client("http://l2")
+		_, err := client.connect(context.Background())
+		require.ErrorIs(t, err, dialErr)
+		require.Len(t, dialer.dialed, l2DialAttempts)
+		require.Nil(t, client.client)
+
+		// A later use tries to connect again
+		_, err = client.connect(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("NotDialledAfterClose", func(t *testing.T) {
+		dialer := &stubL2Dialer{}
+		clients := newClients(t, dialer)
+		client := clients.client("http://l2")
+		connected, err := client.connect(context.Background())
+		require.NoError(t, err)
+
+		clients.Close()
+		_, err = client.connect(context.Background())
+		require.ErrorIs(t, err, ErrL2ClientClosed)
+		_, err = client.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.ErrorIs(t, err, ErrL2ClientClosed)
+		require.Len(t, dialer.dialed, 1, "should not dial again once closed")
+		require.Equal(t, 1, connected.(*stubL2Client).closes)
+	})
+
+	t.Run("ConcurrentDialKeepsFirstConnection", func(t *testing.T) {
+		client := newClients(t, &stubL2Dialer{}).client("http://l2")
+		first := &stubL2Client{}
+		second := &stubL2Client{}
+		// Simulate another caller connecting while this dial is in progress.
+		client.dialer = func(_ context.Context, _ string) (l2Client, error) {
+			client.lock.Lock()
+			client.client = first
+			client.lock.Unlock()
+			return second, nil
+		}
+		connected, err := client.connect(context.Background())
+		require.NoError(t, err)
+		require.Same(t, first, connected)
+		require.Equal(t, 1, second.closes, "should close the duplicate connection")
+	})
+}
+
+func TestFailoverL2Client(t *testing.T) {
+	primary := "http://l2-primary"
+	fallback := "http://l2-fallback"
+	setup := func(t *testing.T, primaryClient *stubL2Client) (*l2Clients, l2Source, *stubL2Client, *clock.DeterministicClock) {
+		fallbackClient := &stubL2Client{}
+		dialer := &stubL2Dialer{clients: map[string]*stubL2Client{primary: primaryClient, fallback: fallbackClient}}
+		clients := newL2Clients(testlog.Logger(t, log.LevelInfo), dialer.dial)
+		clients.strategy = retry.Fixed(0)
+		cl := clock.NewDeterministicClock(time.Unix(0, 0))
+		clients.clock = cl
+		return clients, clients.source(primary, fallback), fallbackClient, cl
+	}
+
+	t.Run("SingleURLNotWrapped", func(t *testing.T) {
+		clients := newL2Clients(testlog.Logger(t, log.LevelInfo), (&stubL2Dialer{}).dial)
+		require.Same(t, clients.client(primary), clients.source(primary))
+	})
+
+	t.Run("UsesPrimary", func(t *testing.T) {
+		primaryClient := &stubL2Client{}
+		_, source, fallbackClient, _ := setup(t, primaryClient)
+		header, err := source.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(10), header.Number)
+		require.Equal(t, 1, primaryClient.requests)
+		require.Zero(t, fallbackClient.requests)
+	})
+
+	t.Run("FailoverOnError", func(t *testing.T) {
+		primaryClient := &stubL2Client{err: errors.New("boom")}
+		clients, source, fallbackClient, _ := setup(t, primaryClient)
+		header, err := source.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(10), header.Number)
+		require.Equal(t, 1, primaryClient.requests)
+		require.Equal(t, 1, fallbackClient.requests)
+
+		clients.Close()
+		require.Equal(t, 1, primaryClient.closes)
+		require.Equal(t, 1, fallbackClient.closes)
+	})
+
+	t.Run("FailoverOnMismatchedHeader", func(t *testing.T) {
+		primaryClient := &stubL2Client{mismatch: true}
+		_, source, fallbackClient, _ := setup(t, primaryClient)
+		header, err := source.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(10), header.Number)
+		require.Equal(t, 1, fallbackClient.requests)
+	})
+
+	t.Run("AllEndpointsFail", func(t *testing.T) {
+		primaryClient := &stubL2Client{mismatch: true}
+		_, source, fallbackClient, _ := setup(t, primaryClient)
+		fallbackErr := errors.New("fallback failed")
+		fallbackClient.err = fallbackErr
+		_, err := source.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.ErrorIs(t, err, ErrL2HeaderMismatch)
+		require.ErrorIs(t, err, fallbackErr)
+	})
+
+	t.Run("SkipUnhealthyUntilCooldown", func(t *testing.T) {
+		primaryClient := &stubL2Client{err: errors.New("boom")}
+		_, source, fallbackClient, cl := setup(t, primaryClient)
+		for i := 0; i < l2FailureThreshold; i++ {
+			_, err := source.HeaderByNumber(context.Background(), big.NewInt(10))
+			require.NoError(t, err)
+		}
+		require.Equal(t, l2FailureThreshold, primaryClient.requests)
+
+		_, err := source.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, l2FailureThreshold, primaryClient.requests, "should skip unhealthy primary")
+		require.Equal(t, l2FailureThreshold+1, fallbackClient.requests)
+
+		primaryClient.err = nil
+		cl.AdvanceTime(l2FailureCooldown)
+		_, err = source.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.NoError(t, err)
+		require.Equal(t, l2FailureThreshold+1, primaryClient.requests, "should use primary after cooldown")
+		require.Equal(t, l2FailureThreshold+1, fallbackClient.requests)
+	})
+
+	t.Run("ConnectFailsOver", func(t *testing.T) {
+		dialErr := errors.New("dial failed")
+		fallbackClient := &stubL2Client{}
+		dialer := &stubL2Dialer{
+			dialErrs: map[string]error{primary: dialErr},
+			clients:  map[string]*stubL2Client{fallback: fallbackClient},
+		}
+		clients := newL2Clients(testlog.Logger(t, log.LevelInfo), dialer.dial)
+		clients.strategy = retry.Fixed(0)
+		client, err := clients.source(primary, fallback).connect(context.Background())
+		require.NoError(t, err)
+		require.Same(t, fallbackClient, client)
+
+		clients.Close()
+		require.Equal(t, 1, fallbackClient.closes)
+	})
+
+	t.Run("DialsUnavailableEndpointOnce", func(t *testing.T) {
+		dialer := &stubL2Dialer{
+			dialErrs: map[string]error{primary: errors.New("dial failed")},
+		}
+		clients := newL2Clients(testlog.Logger(t, log.LevelInfo), dialer.dial)
+		// Retrying would wait for the backoff so a single dial is required for the test to complete promptly.
+		clients.strategy = retry.Fixed(time.Hour)
+		source := clients.source(primary, fallback)
+		_, err := source.HeaderByNumber(context.Background(), big.NewInt(10))
+		require.NoError(t, err)
+		_, err = source.connect(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{primary, fallback, primary}, dialer.dialed)
+	})
+}
+
+type stubL2Dialer struct {
+	err      error
+	failures int
+	dialed   []string
+	// dialErrs are errors returned every time specific urls are dialled
+	dialErrs map[string]error
+	// clients are the clients returned for specific urls
+	clients map[string]*stubL2Client
+}
+
+func (s *stubL2Dialer) dial(_ context.Context, url string) (l2Client, error) {
+	s.dialed = append(s.dialed, url)
+	if s.failures > 0 {
+		s.failures--
+		return nil, s.err
+	}
+	if err, ok := s.dialErrs[url]; ok {
+		return nil, err
+	}
+	if client, ok := s.clients[url]; ok {
+		return client, nil
+	}
+	return &stubL2Client{}, nil
+}
+
+type stubL2Client struct {
+	closes   int
+	err      error
+	mismatch bool
+	requests int
+}
+
+func (s *stubL2Client) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	s.requests++
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.mismatch {
+		return &types.Header{Number: new(big.Int).Add(number, big.NewInt(1))}, nil
+	}
+	return &types.Header{Number: number}, nil
+}
+
+func (s *stubL2Client) Close() {
+	s.closes++
+}
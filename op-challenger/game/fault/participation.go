@@ -0,0 +1,113 @@
+package fault
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var _ Responder = (*participationResponder)(nil)
+
+// participationResponder only passes the transactions allowed by the participation mode on to the wrapped responder.
+// Actions that are not allowed are logged instead of sent.
+type participationResponder struct {
+	logger    log.Logger
+	mode      config.ParticipationMode
+	responder Responder
+}
+
+func newParticipationResponder(logger log.Logger, mode config.ParticipationMode, responder Responder) Responder {
+	if mode == "" || mode == config.ParticipationAct {
+		return responder
+	}
+	return &participationResponder{
+		logger:    logger,
+		mode:      mode,
+		responder: responder,
+	}
+}
+
+func (p *participationResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
+	return p.responder.CallResolve(ctx)
+}
+
+func (p *participationResponder) Resolve() error {
+	if p.mode == config.ParticipationObserve {
+		p.logger.Info("Not resolving game", "participation", p.mode)
+		return nil
+	}
+	return p.responder.Resolve()
+}
+
+func (p *participationResponder) CallResolveClaim(ctx context.Context, claimIdx uint64) error {
+	return p.responder.CallResolveClaim(ctx, claimIdx)
+}
+
+func (p *participationResponder) ResolveClaim(claimIdx uint64) error {
+	if p.mode == config.ParticipationObserve {
+		p.logger.Info("Not resolving claim", "participation", p.mode, "claim", claimIdx)
+		return nil
+	}
+	return p.responder.ResolveClaim(claimIdx)
+}
+
+func (p *participationResponder) PerformAction(ctx context.Context, action types.Action) error {
+	if !participationAllowsAction(p.mode, action) {
+		p.logger.Info("Not performing action", "participation", p.mode, "action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx)
+		return nil
+	}
+	return p.responder.PerformAction(ctx, action)
+}
+
+// participationAllowsAction returns true if mode allows action to be sent.
+// Claims at even depths support the root claim, so an action supports the root claim if its parent is at an odd depth.
+func participationAllowsAction(mode config.ParticipationMode, action types.Action) bool {
+	supportsRoot := action.ParentPosition.Depth()%2 == 1
+	switch mode {
+	case config.ParticipationObserve:
+		return false
+	case config.ParticipationDefendOnly:
+		return supportsRoot
+	case config.ParticipationChallengeOnly:
+		return !supportsRoot
+	default:
+		return true
+	}
+}
+
+// observedBondContract reports no credit so that bonds are not claimed for observed games.
+type observedBondContract struct {
+	claims.BondContract
+	logger log.Logger
+}
+
+func (o *observedBondContract) GetCredit(ctx context.Context, recipient common.Address) (*big.Int, error) {
+	credit, err := o.BondContract.GetCredit(ctx, recipient)
+	if err != nil {
+		return nil, err
+	}
+	if credit.Sign() > 0 {
+		o.logger.Info("Not claiming credit", "participation", config.ParticipationObserve, "recipient", recipient, "credit", credit)
+	}
+	return big.NewInt(0), nil
+}
+
+// participationBondContractCreator wraps creator so bonds are not claimed when mode does not allow it.
+func participationBondContractCreator(logger log.Logger, mode config.ParticipationMode, creator claims.BondContractCreator) claims.BondContractCreator {
+	if mode != config.ParticipationObserve {
+		return creator
+	}
+	return func(game gameTypes.GameMetadata) (claims.BondContract, error) {
+		contract, err := creator(game)
+		if err != nil {
+			return nil, err
+		}
+		return &observedBondContract{BondContract: contract, logger: logger.New("game", game.Proxy)}, nil
+	}
+}
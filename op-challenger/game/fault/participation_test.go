@@ -0,0 +1,141 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParticipationModes(t *testing.T) {
+	// Claims at odd depths dispute the root claim, so countering them supports it.
+	// Parent indices identify the parent's depth in the transactions sent.
+	supportRoot := types.NewPosition(1, big.NewInt(0))
+	disputeRoot := types.NewPosition(2, big.NewInt(0))
+	actions := []types.Action{
+		{Type: types.ActionTypeMove, IsAttack: true, ParentPosition: supportRoot, ParentIdx: 1},
+		{Type: types.ActionTypeMove, IsAttack: false, ParentPosition: supportRoot, ParentIdx: 1},
+		{Type: types.ActionTypeMove, IsAttack: true, ParentPosition: disputeRoot, ParentIdx: 2},
+		{Type: types.ActionTypeMove, IsAttack: false, ParentPosition: disputeRoot, ParentIdx: 2},
+		{Type: types.ActionTypeStep, IsAttack: true, ParentPosition: supportRoot, ParentIdx: 1},
+		{Type: types.ActionTypeStep, IsAttack: true, ParentPosition: disputeRoot, ParentIdx: 2},
+	}
+	tests := []struct {
+		mode     config.ParticipationMode
+		expected []string
+	}{
+		{
+			mode: config.ParticipationAct,
+			expected: []string{
+				"attack-1", "defend-1", "attack-2", "defend-2", "step-1", "step-2",
+				"resolveClaim", "resolve", "claimCredit",
+			},
+		},
+		{
+			mode:     config.ParticipationDefendOnly,
+			expected: []string{"attack-1", "defend-1", "step-1", "resolveClaim", "resolve", "claimCredit"},
+		},
+		{
+			mode:     config.ParticipationChallengeOnly,
+			expected: []string{"attack-2", "defend-2", "step-2", "resolveClaim", "resolve", "claimCredit"},
+		},
+		{
+			mode:     config.ParticipationObserve,
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(string(test.mode), func(t *testing.T) {
+			logger := testlog.Logger(t, log.LevelInfo)
+			sender := &capturingTxSender{}
+			contract := &stubParticipationContract{}
+			faultResponder, err := responder.NewFaultResponder(logger, sender, contract, nil, nil)
+			require.NoError(t, err)
+			r := newParticipationResponder(logger, test.mode, faultResponder)
+
+			for _, action := range actions {
+				require.NoError(t, r.PerformAction(context.Background(), action))
+			}
+			require.NoError(t, r.ResolveClaim(0))
+			require.NoError(t, r.Resolve())
+
+			contractCreator := func(game gameTypes.GameMetadata) (claims.BondContract, error) {
+				return contract, nil
+			}
+			claimer := claims.NewBondClaimer(logger, &stubBondClaimMetrics{}, participationBondContractCreator(logger, test.mode, contractCreator), sender, clock.NewDeterministicClock(time.Unix(0, 0)))
+			require.NoError(t, claimer.ClaimBonds(context.Background(), []gameTypes.GameMetadata{{}}))
+
+			require.Equal(t, test.expected, sender.sent)
+		})
+	}
+}
+
+type capturingTxSender struct {
+	sent []string
+}
+
+func (s *capturingTxSender) From() common.Address {
+	return common.Address{0xaa}
+}
+
+func (s *capturingTxSender) SendAndWait(_ string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	for _, tx := range txs {
+		s.sent = append(s.sent, string(tx.TxData))
+	}
+	return nil, nil
+}
+
+type stubParticipationContract struct {
+	responder.GameContract
+}
+
+func (s *stubParticipationContract) ResolveTx() (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{TxData: []byte("resolve")}, nil
+}
+
+func (s *stubParticipationContract) ResolveClaimTx(_ uint64) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{TxData: []byte("resolveClaim")}, nil
+}
+
+func (s *stubParticipationContract) AttackTx(parentContractIndex uint64, _ common.Hash) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{TxData: []byte(fmt.Sprintf("attack-%v", parentContractIndex))}, nil
+}
+
+func (s *stubParticipationContract) DefendTx(parentContractIndex uint64, _ common.Hash) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{TxData: []byte(fmt.Sprintf("defend-%v", parentContractIndex))}, nil
+}
+
+func (s *stubParticipationContract) StepTx(claimIdx uint64, _ bool, _ []byte, _ []byte) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{TxData: []byte(fmt.Sprintf("step-%v", claimIdx))}, nil
+}
+
+func (s *stubParticipationContract) GetRequiredBond(_ context.Context, _ types.Position) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (s *stubParticipationContract) GetCredit(_ context.Context, _ common.Address) (*big.Int, error) {
+	return big.NewInt(10), nil
+}
+
+func (s *stubParticipationContract) ClaimCredit(_ common.Address) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{TxData: []byte("claimCredit")}, nil
+}
+
+type stubBondClaimMetrics struct{}
+
+func (s *stubBondClaimMetrics) RecordBondClaimed(_ uint64) {}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
@@ -67,6 +68,8 @@ func NewGamePlayer(
 	validators []Validator,
 	creator resourceCreator,
 	l1HeaderSource L1HeaderSource,
+	participation config.ParticipationMode,
+	preimageChunkSize int,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
 
@@ -119,14 +122,14 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to load min large preimage size: %w", err)
 	}
 	direct := preimages.NewDirectPreimageUploader(logger, txSender, loader)
-	large := preimages.NewLargePreimageUploader(logger, cl, txSender, oracle)
+	large := preimages.NewLargePreimageUploader(logger, m, cl, txSender, oracle, preimageChunkSize)
 	uploader := preimages.NewSplitPreimageUploader(direct, large, minLargePreimageSize)
 	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, loader, gameDepth, accessor, responder, logger)
+	agent := NewAgent(m, loader, gameDepth, accessor, newParticipationResponder(logger, participation, responder), logger)
 	return &GamePlayer{
 		act:                agent.Act,
 		loader:             loader,
@@ -34,31 +34,45 @@ const MaxBlocksPerChunk = 300
 // The max chunk size is roughly 0.04MB to avoid memory expansion.
 const MaxChunkSize = MaxBlocksPerChunk * keccakTypes.BlockSize
 
+// LargePreimageMetrics records the progress of large preimage uploads.
+type LargePreimageMetrics interface {
+	RecordLargePreimageInitialized()
+	RecordLargePreimageBytesUploaded(n int)
+	RecordLargePreimageSqueezed()
+}
+
 // LargePreimageUploader handles uploading large preimages by
 // streaming the merkleized preimage to the PreimageOracle contract,
 // tightly packed across multiple transactions.
 type LargePreimageUploader struct {
-	log log.Logger
+	log     log.Logger
+	metrics LargePreimageMetrics
 
-	clock    types.ClockReader
-	txSender gameTypes.TxSender
-	contract PreimageOracleContract
+	clock     types.ClockReader
+	txSender  gameTypes.TxSender
+	contract  PreimageOracleContract
+	chunkSize int
 }
 
-func NewLargePreimageUploader(logger log.Logger, cl types.ClockReader, txSender gameTypes.TxSender, contract PreimageOracleContract) *LargePreimageUploader {
-	return &LargePreimageUploader{logger, cl, txSender, contract}
+// NewLargePreimageUploader creates an uploader that adds at most chunkSize bytes of preimage data per transaction.
+// chunkSize must be a multiple of [keccakTypes.BlockSize] and no more than [MaxChunkSize].
+func NewLargePreimageUploader(logger log.Logger, m LargePreimageMetrics, cl types.ClockReader, txSender gameTypes.TxSender, contract PreimageOracleContract, chunkSize int) *LargePreimageUploader {
+	return &LargePreimageUploader{
+		log:       logger,
+		metrics:   m,
+		clock:     cl,
+		txSender:  txSender,
+		contract:  contract,
+		chunkSize: chunkSize,
+	}
 }
 
 func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
 	p.log.Debug("Upload large preimage", "key", hexutil.Bytes(data.OracleKey))
-	stateMatrix, calls, err := p.splitCalls(data)
-	if err != nil {
-		return fmt.Errorf("failed to split preimage into chunks for data with oracle offset %d: %w", data.OracleOffset, err)
-	}
-
 	uuid := NewUUID(p.txSender.From(), data)
 
 	// Fetch the current metadata for this preimage data, if it exists.
+	// The proposal's progress is stored on chain so an interrupted upload resumes from the last processed leaf.
 	ident := keccakTypes.LargePreimageIdent{Claimant: p.txSender.From(), UUID: uuid}
 	metadata, err := p.contract.GetProposalMetadata(ctx, batching.BlockLatest, ident)
 	if err != nil {
@@ -73,17 +87,21 @@ func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint6
 		}
 	}
 
-	// Filter out any chunks that have already been uploaded to the Preimage Oracle.
+	// Skip any data that has already been uploaded to the Preimage Oracle.
+	bytesProcessed := 0
 	if len(metadata) > 0 {
-		numSkip := metadata[0].BytesProcessed / MaxChunkSize
-		calls = calls[numSkip:]
-		// If the timestamp is non-zero, the preimage has been finalized.
-		if metadata[0].Timestamp != 0 {
-			calls = calls[len(calls):]
-		}
+		bytesProcessed = int(metadata[0].BytesProcessed)
+	}
+	stateMatrix, calls, err := p.splitCalls(data, bytesProcessed)
+	if err != nil {
+		return fmt.Errorf("failed to split preimage into chunks for data with oracle offset %d: %w", data.OracleOffset, err)
+	}
+	// If the timestamp is non-zero, the preimage has been finalized.
+	if len(metadata) > 0 && metadata[0].Timestamp != 0 {
+		calls = calls[len(calls):]
 	}
 
-	err = p.addLargePreimageData(uuid, calls)
+	err = p.addLargePreimageData(uuid, bytesProcessed/keccakTypes.BlockSize, calls)
 	if err != nil {
 		return fmt.Errorf("failed to add leaves to large preimage with uuid: %s: %w", uuid, err)
 	}
@@ -102,15 +120,26 @@ func NewUUID(sender common.Address, data *types.PreimageOracleData) *big.Int {
 	return hash.Big()
 }
 
-// splitChunks splits the preimage data into chunks of size [MaxChunkSize] (except the last chunk).
+// splitCalls splits the preimage data into chunks of up to the configured chunk size.
+// The first skip bytes are absorbed into the state matrix without creating calls as they have already been uploaded.
 // It also returns the state matrix and the data for the squeeze call if possible.
-func (p *LargePreimageUploader) splitCalls(data *types.PreimageOracleData) (*matrix.StateMatrix, []keccakTypes.InputData, error) {
-	// Split the preimage data into chunks of size [MaxChunkSize] (except the last chunk).
+func (p *LargePreimageUploader) splitCalls(data *types.PreimageOracleData, skip int) (*matrix.StateMatrix, []keccakTypes.InputData, error) {
 	stateMatrix := matrix.NewStateMatrix()
 	var calls []keccakTypes.InputData
 	in := bytes.NewReader(data.GetPreimageWithoutSize())
+	// Absorb the previously uploaded data in chunks that end exactly where the upload stopped.
+	// Processed bytes are always whole leaves so this works even if the chunk size changed since.
+	for skip >= keccakTypes.BlockSize {
+		call, err := stateMatrix.AbsorbUpTo(in, min(p.chunkSize, skip-skip%keccakTypes.BlockSize))
+		if errors.Is(err, io.EOF) {
+			return stateMatrix, nil, nil
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("failed to absorb data: %w", err)
+		}
+		skip -= len(call.Input)
+	}
 	for {
-		call, err := stateMatrix.AbsorbUpTo(in, MaxChunkSize)
+		call, err := stateMatrix.AbsorbUpTo(in, p.chunkSize)
 		if errors.Is(err, io.EOF) {
 			calls = append(calls, call)
 			break
@@ -154,6 +183,7 @@ func (p *LargePreimageUploader) Squeeze(ctx context.Context, uuid *big.Int, stat
 	if _, err := p.txSender.SendAndWait("squeeze large preimage", tx); err != nil {
 		return fmt.Errorf("failed to populate pre-image oracle: %w", err)
 	}
+	p.metrics.RecordLargePreimageSqueezed()
 	return nil
 }
 
@@ -173,24 +203,32 @@ func (p *LargePreimageUploader) initLargePreimage(uuid *big.Int, partOffset uint
 	if _, err := p.txSender.SendAndWait("init large preimage", candidate); err != nil {
 		return fmt.Errorf("failed to populate pre-image oracle: %w", err)
 	}
+	p.metrics.RecordLargePreimageInitialized()
 	return nil
 }
 
-// addLargePreimageData adds data to the large preimage proposal.
+// addLargePreimageData adds data to the large preimage proposal, starting at the leaf index blocksProcessed.
 // This method **must** be called after calling [initLargePreimage].
 // SAFETY: submits transactions in a [Queue] for latency while preserving submission order.
-func (p *LargePreimageUploader) addLargePreimageData(uuid *big.Int, chunks []keccakTypes.InputData) error {
+func (p *LargePreimageUploader) addLargePreimageData(uuid *big.Int, blocksProcessed int, chunks []keccakTypes.InputData) error {
+	if len(chunks) == 0 {
+		return nil
+	}
 	txs := make([]txmgr.TxCandidate, len(chunks))
-	blocksProcessed := int64(0)
+	bytesUploaded := 0
 	for i, chunk := range chunks {
-		tx, err := p.contract.AddLeaves(uuid, big.NewInt(blocksProcessed), chunk.Input, chunk.Commitments, chunk.Finalize)
+		tx, err := p.contract.AddLeaves(uuid, big.NewInt(int64(blocksProcessed)), chunk.Input, chunk.Commitments, chunk.Finalize)
 		if err != nil {
 			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
 		}
-		blocksProcessed += int64(len(chunk.Input) / keccakTypes.BlockSize)
+		blocksProcessed += len(chunk.Input) / keccakTypes.BlockSize
+		bytesUploaded += len(chunk.Input)
 		txs[i] = tx
 	}
 	p.log.Info("Adding large preimage leaves", "uuid", uuid, "blocksProcessed", blocksProcessed, "txs", len(txs))
-	_, err := p.txSender.SendAndWait("add leaf to large preimage", txs...)
-	return err
+	if _, err := p.txSender.SendAndWait("add leaf to large preimage", txs...); err != nil {
+		return err
+	}
+	p.metrics.RecordLargePreimageBytesUploaded(bytesUploaded)
+	return nil
 }
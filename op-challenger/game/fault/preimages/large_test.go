@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"testing"
@@ -250,6 +251,65 @@ func TestLargePreimageUploader_UploadPreimage_Succeeds(t *testing.T) {
 	}
 }
 
+func TestLargePreimageUploader_UploadPreimage_CallSequence(t *testing.T) {
+	fullLeaf := make([]byte, keccakTypes.BlockSize)
+	for i := 0; i < keccakTypes.BlockSize; i++ {
+		fullLeaf[i] = byte(i)
+	}
+	var input []byte
+	for i := 0; i < 5; i++ {
+		input = append(input, fullLeaf...)
+	}
+	input = append(input, byte(9))
+	data := makePreimageData(input, 0)
+
+	requireSqueezedFullPreimage := func(t *testing.T, contract *mockPreimageOracleContract) {
+		s := matrix.NewStateMatrix()
+		_, err := s.AbsorbUpTo(bytes.NewReader(input), keccakTypes.BlockSize*10000)
+		require.ErrorIs(t, err, io.EOF)
+		poststate, _ := s.PoststateWithProof()
+		require.Equal(t, poststate, contract.squeezePoststate)
+	}
+
+	t.Run("MultipleChunks", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		oracle.chunkSize = 2 * keccakTypes.BlockSize
+		m := oracle.metrics.(*stubLargePreimageMetrics)
+		require.NoError(t, oracle.UploadPreimage(context.Background(), 0, data))
+		require.Equal(t, []string{
+			"init",
+			"addLeaves(0, 272, false)",
+			"addLeaves(2, 272, false)",
+			"addLeaves(4, 137, true)",
+			"squeeze",
+		}, contract.sequence)
+		require.Equal(t, input, contract.addData)
+		requireSqueezedFullPreimage(t, contract)
+		require.Equal(t, 1, m.initialized)
+		require.Equal(t, len(input), m.bytesUploaded)
+		require.Equal(t, 1, m.squeezed)
+	})
+
+	t.Run("ResumeWithDifferentChunkSize", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		oracle.chunkSize = 3 * keccakTypes.BlockSize
+		m := oracle.metrics.(*stubLargePreimageMetrics)
+		contract.initialized = true
+		contract.claimedSize = uint32(len(input))
+		contract.bytesProcessed = 2 * keccakTypes.BlockSize
+		require.NoError(t, oracle.UploadPreimage(context.Background(), 0, data))
+		require.Equal(t, []string{
+			"addLeaves(2, 408, false)",
+			"addLeaves(5, 1, true)",
+			"squeeze",
+		}, contract.sequence)
+		require.Equal(t, input[2*keccakTypes.BlockSize:], contract.addData)
+		requireSqueezedFullPreimage(t, contract)
+		require.Equal(t, 0, m.initialized)
+		require.Equal(t, len(input)-2*keccakTypes.BlockSize, m.bytesUploaded)
+	})
+}
+
 func newTestLargePreimageUploader(t *testing.T) (*LargePreimageUploader, *clock.AdvancingClock, *mockTxSender, *mockPreimageOracleContract) {
 	logger := testlog.Logger(t, log.LevelError)
 	cl := clock.NewAdvancingClock(time.Second)
@@ -258,7 +318,25 @@ func newTestLargePreimageUploader(t *testing.T) (*LargePreimageUploader, *clock.
 	contract := &mockPreimageOracleContract{
 		addData: make([]byte, 0),
 	}
-	return NewLargePreimageUploader(logger, cl, txSender, contract), cl, txSender, contract
+	return NewLargePreimageUploader(logger, &stubLargePreimageMetrics{}, cl, txSender, contract, MaxChunkSize), cl, txSender, contract
+}
+
+type stubLargePreimageMetrics struct {
+	initialized   int
+	bytesUploaded int
+	squeezed      int
+}
+
+func (s *stubLargePreimageMetrics) RecordLargePreimageInitialized() {
+	s.initialized++
+}
+
+func (s *stubLargePreimageMetrics) RecordLargePreimageBytesUploaded(n int) {
+	s.bytesUploaded += n
+}
+
+func (s *stubLargePreimageMetrics) RecordLargePreimageSqueezed() {
+	s.squeezed++
 }
 
 type mockPreimageOracleContract struct {
@@ -277,18 +355,21 @@ type mockPreimageOracleContract struct {
 	squeezeCallClaimSize uint32
 	squeezePrestate      keccakTypes.Leaf
 	squeezePoststate     keccakTypes.Leaf
+	sequence             []string
 }
 
 func (s *mockPreimageOracleContract) InitLargePreimage(_ *big.Int, _ uint32, _ uint32) (txmgr.TxCandidate, error) {
 	s.initCalls++
+	s.sequence = append(s.sequence, "init")
 	if s.initFails {
 		return txmgr.TxCandidate{}, mockInitLPPError
 	}
 	return txmgr.TxCandidate{}, nil
 }
 
-func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, _ *big.Int, input []byte, _ []common.Hash, _ bool) (txmgr.TxCandidate, error) {
+func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, startingBlockIndex *big.Int, input []byte, _ []common.Hash, finalize bool) (txmgr.TxCandidate, error) {
 	s.addCalls++
+	s.sequence = append(s.sequence, fmt.Sprintf("addLeaves(%v, %v, %v)", startingBlockIndex, len(input), finalize))
 	s.addData = append(s.addData, input...)
 	if s.addFails {
 		return txmgr.TxCandidate{}, mockAddLeavesError
@@ -298,6 +379,7 @@ func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, _ *big.Int, input []b
 
 func (s *mockPreimageOracleContract) Squeeze(_ common.Address, _ *big.Int, _ keccakTypes.StateSnapshot, prestate keccakTypes.Leaf, _ merkle.Proof, poststate keccakTypes.Leaf, _ merkle.Proof) (txmgr.TxCandidate, error) {
 	s.squeezeCalls++
+	s.sequence = append(s.sequence, "squeeze")
 	s.squeezePrestate = prestate
 	s.squeezePoststate = poststate
 	if s.squeezeFails {
@@ -2,25 +2,46 @@ package fault
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cartesi"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/compute"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs/source"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/remote"
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+var (
+	ErrNoMatchingPrestate    = errors.New("no absolute prestate matches game")
+	ErrNoGameTypesRegistered = errors.New("no game types registered")
+	ErrPlayerCreationTimeout = errors.New("timed out creating game player")
+)
+
 type CloseFunc func()
 
 type Registry interface {
@@ -33,6 +54,60 @@ type RollupClient interface {
 	SyncStatusProvider
 }
 
+// registerDeps are the dependencies shared by the registration of every game type.
+// Resources acquired while registering a game type are added to closer so they are released on shutdown.
+type registerDeps struct {
+	registry        Registry
+	ctx             context.Context
+	cl              faultTypes.ClockReader
+	logger          log.Logger
+	m               metrics.Metricer
+	syncValidator   SyncValidator
+	rollupClient    source.OutputRollupClient
+	outputProviders *outputProviderCache
+	txSender        types.TxSender
+	gameData        *contracts.GameDataCache
+	caller          *batching.MultiCaller
+	l1HeaderSource  L1HeaderSource
+	closer          *resourceCloser
+}
+
+type vmRegisterFunc func(deps *registerDeps, gameType uint32, cfg *config.Config, l2Client l2Source) error
+
+// vmRegisterFuncs are the default register functions for trace types backed by a VM, each of which reads from its
+// own L2 endpoint. They are copied for each registration so options can replace them without affecting other callers.
+var vmRegisterFuncs = map[config.TraceType]vmRegisterFunc{
+	config.TraceTypeCannon:       cannonRegisterFunc(outputs.NewOutputCannonTraceAccessor),
+	config.TraceTypePermissioned: cannonRegisterFunc(outputs.NewOutputCannonTraceAccessor),
+	config.TraceTypeAsterisc:     registerAsterisc,
+	config.TraceTypeCartesi:      registerCartesi,
+	config.TraceTypeExternal:     registerExternal,
+	config.TraceTypeRemote:       registerRemote,
+}
+
+// vmConfig returns a copy of cfg with the L2 endpoint and absolute pre-state to use for gameType.
+func vmConfig(cfg *config.Config, gameType config.GameTypeConfig, l2Rpc string) *config.Config {
+	vmCfg := *cfg
+	// The VM executors pass CannonL2 to the pre-image server so set it to the game type's own endpoint.
+	vmCfg.CannonL2 = l2Rpc
+	if gameType.AbsolutePreState != "" {
+		switch gameType.TraceType {
+		case config.TraceTypeCannon, config.TraceTypePermissioned:
+			vmCfg.CannonAbsolutePreState = gameType.AbsolutePreState
+			vmCfg.CannonAbsolutePreStates = nil
+		case config.TraceTypeAsterisc:
+			vmCfg.AsteriscAbsolutePreState = gameType.AbsolutePreState
+		case config.TraceTypeCartesi:
+			vmCfg.CartesiSnapshotDir = gameType.AbsolutePreState
+		}
+	}
+	return &vmCfg
+}
+
+// RegisterGameTypes registers a player creator for each configured game type and returns the game types registered.
+// Game types whose preimage oracle is incompatible are skipped. It is an error for no game types to be registered.
+// The returned CloseFunc releases every resource acquired while registering. It is returned even if registration
+// fails and may be called more than once.
 func RegisterGameTypes(
 	registry Registry,
 	ctx context.Context,
@@ -42,167 +117,593 @@ func RegisterGameTypes(
 	cfg *config.Config,
 	rollupClient RollupClient,
 	txSender types.TxSender,
-	gameFactory *contracts.DisputeGameFactoryContract,
+	gameData *contracts.GameDataCache,
 	caller *batching.MultiCaller,
 	l1HeaderSource L1HeaderSource,
-) (CloseFunc, error) {
-	var closer CloseFunc
-	var l2Client *ethclient.Client
-	if cfg.TraceTypeEnabled(config.TraceTypeCannon) || cfg.TraceTypeEnabled(config.TraceTypePermissioned) {
-		l2, err := ethclient.DialContext(ctx, cfg.CannonL2)
-		if err != nil {
-			return nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
-		}
-		l2Client = l2
-		closer = l2Client.Close
-	}
-	outputSourceCreator := source.NewOutputSourceCreator(logger, rollupClient)
-	syncValidator := newSyncStatusValidator(rollupClient)
-
-	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		if err := registerCannon(faultTypes.CannonGameType, registry, ctx, cl, logger, m, cfg, syncValidator, outputSourceCreator, txSender, gameFactory, caller, l2Client, l1HeaderSource); err != nil {
-			return nil, fmt.Errorf("failed to register cannon game type: %w", err)
-		}
-	}
-	if cfg.TraceTypeEnabled(config.TraceTypePermissioned) {
-		if err := registerCannon(faultTypes.PermissionedGameType, registry, ctx, cl, logger, m, cfg, syncValidator, outputSourceCreator, txSender, gameFactory, caller, l2Client, l1HeaderSource); err != nil {
-			return nil, fmt.Errorf("failed to register permissioned cannon game type: %w", err)
-		}
-	}
-	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
-		if err := registerAlphabet(registry, ctx, cl, logger, m, syncValidator, rollupClient, txSender, gameFactory, caller, l1HeaderSource); err != nil {
-			return nil, fmt.Errorf("failed to register alphabet game type: %w", err)
-		}
-	}
-	return closer, nil
+	opts ...RegisterOption,
+) (CloseFunc, []config.GameTypeConfig, error) {
+	closer, registered, err := registerGameTypes(registry, ctx, cl, logger, m, cfg, rollupClient, txSender, gameData, caller, l1HeaderSource, newL2Clients(logger, dialL2Client), opts...)
+	return closer.Close, registered, err
 }
 
-func registerAlphabet(
+func registerGameTypes(
 	registry Registry,
 	ctx context.Context,
 	cl faultTypes.ClockReader,
 	logger log.Logger,
 	m metrics.Metricer,
-	syncValidator SyncValidator,
-	rollupClient source.OutputRollupClient,
+	cfg *config.Config,
+	rollupClient RollupClient,
 	txSender types.TxSender,
-	gameFactory *contracts.DisputeGameFactoryContract,
+	gameData *contracts.GameDataCache,
 	caller *batching.MultiCaller,
 	l1HeaderSource L1HeaderSource,
-) error {
-	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
-		if err != nil {
-			return nil, err
+	l2Clients *l2Clients,
+	opts ...RegisterOption,
+) (*resourceCloser, []config.GameTypeConfig, error) {
+	registerFuncs := maps.Clone(vmRegisterFuncs)
+	for _, opt := range opts {
+		opt(registerFuncs)
+	}
+	closer := newResourceCloser(logger)
+	closer.add("l2 clients", func() error {
+		l2Clients.Close()
+		return nil
+	})
+	fail := func(err error) (*resourceCloser, []config.GameTypeConfig, error) {
+		return closer, nil, err
+	}
+	deps := &registerDeps{
+		registry:        registry,
+		ctx:             ctx,
+		cl:              cl,
+		logger:          logger,
+		m:               m,
+		syncValidator:   newSyncStatusValidator(rollupClient, cfg.SyncThresholds),
+		rollupClient:    rollupClient,
+		outputProviders: newOutputProviderCache(m, source.NewOutputSourceCreator(logger, rollupClient)),
+		txSender:        txSender,
+		gameData:        gameData,
+		caller:          caller,
+		l1HeaderSource:  l1HeaderSource,
+		closer:          closer,
+	}
+	var registered []config.GameTypeConfig
+	seen := make(map[uint32]bool)
+	for _, gameType := range cfg.GameTypesToRegister() {
+		if seen[gameType.GameType] {
+			return fail(fmt.Errorf("%w: %v", config.ErrDuplicateGameType, gameType.GameType))
+		}
+		seen[gameType.GameType] = true
+		var err error
+		if slices.Contains(config.AlphabetTraceTypes, gameType.TraceType) {
+			// Permissioned alphabet games use the same trace as alphabet games, only who may participate differs.
+			err = registerAlphabet(deps, gameType.GameType, cfg)
+		} else if gameType.TraceType == config.TraceTypeCartesiCompute {
+			err = registerCartesiCompute(deps, gameType.GameType, cfg)
+		} else {
+			register, ok := registerFuncs[gameType.TraceType]
+			if !ok {
+				return fail(fmt.Errorf("%w: game type %v trace type %v", config.ErrInvalidGameTypeTraceType, gameType.GameType, gameType.TraceType))
+			}
+			l2Rpc := cfg.L2Rpc(gameType.TraceType)
+			l2Client := l2Clients.source(append([]string{l2Rpc}, cfg.L2Fallbacks(gameType.TraceType)...)...)
+			vmCfg := vmConfig(cfg, gameType, l2Rpc)
+			err = register(deps, gameType.GameType, vmCfg, l2Client)
 		}
-		prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+		if errors.Is(err, ErrIncompatibleOracle) {
+			// Transactions to an incompatible oracle would revert mid-game, so skip the game type but keep playing the others.
+			logger.Error("Not registering game type with incompatible preimage oracle", "gameType", gameType.GameType, "traceType", gameType.TraceType, "err", err)
+			m.RecordIncompatibleOracle(gameType.GameType)
+			continue
+		} else if err != nil {
+			return fail(fmt.Errorf("failed to register %v game type %v: %w", gameType.TraceType, gameType.GameType, err))
+		}
+		m.RecordGameTypeRegistered(gameType.GameType, string(gameType.TraceType))
+		registered = append(registered, gameType)
+	}
+	if len(registered) == 0 {
+		return fail(fmt.Errorf("%w: trace types %v", ErrNoGameTypesRegistered, cfg.TraceTypes))
+	}
+	summary := make([]string, len(registered))
+	for i, gameType := range registered {
+		summary[i] = fmt.Sprintf("%v=%v", gameType.GameType, gameType.TraceType)
+	}
+	logger.Info("Registered game types", "gameTypes", strings.Join(summary, ","))
+	return closer, registered, nil
+}
+
+func registerAlphabet(deps *registerDeps, gameType uint32, cfg *config.Config) error {
+	playerCreator := func(ctx context.Context, game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, deps.caller)
 		if err != nil {
 			return nil, err
 		}
-		splitDepth, err := contract.GetSplitDepth(ctx)
+		data, err := deps.gameData.GetSetupData(ctx, contract)
 		if err != nil {
 			return nil, err
 		}
-		outputSource := source.NewUnrestrictedOutputSource(rollupClient)
-		prestateProvider := outputs.NewPrestateProvider(outputSource, prestateBlock)
+		outputSource := source.NewUnrestrictedOutputSource(deps.rollupClient)
+		prestateProvider := outputs.NewPrestateProvider(outputSource, data.PrestateBlock)
 		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			accessor, err := outputs.NewOutputAlphabetTraceAccessor(logger, m, prestateProvider, outputSource, splitDepth, prestateBlock, poststateBlock)
+			accessor, err := outputs.NewOutputAlphabetTraceAccessor(logger, deps.m, prestateProvider, outputSource, data.SplitDepth, data.PrestateBlock, data.PoststateBlock)
 			if err != nil {
 				return nil, err
 			}
 			return accessor, nil
 		}
-		prestateValidator := NewPrestateValidator("alphabet", contract.GetAbsolutePrestateHash, alphabet.PrestateProvider)
-		genesisValidator := NewPrestateValidator("output root", contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, cl, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, genesisValidator}, creator, l1HeaderSource)
+		prestateValidator := NewPrestateValidator("alphabet", knownHash(data.AbsolutePrestate), alphabet.PrestateProvider)
+		genesisValidator := NewPrestateValidator("output root", knownHash(data.GenesisOutputRoot), prestateProvider)
+		paramsValidator := NewGameParamsValidator(deps.m, gameType, contract, cfg.ExpectedGameParams(gameType))
+		return NewGamePlayer(ctx, deps.cl, deps.logger, deps.m, dir, game.Proxy, deps.txSender, contract, deps.syncValidator, []Validator{prestateValidator, genesisValidator, paramsValidator}, creator, deps.l1HeaderSource, cfg.ParticipationMode(gameType), cfg.LargePreimageChunkSize)
+	}
+	return registerOracleAndBonds(deps, gameType, cfg, playerCreator)
+}
+
+// registerCartesiCompute registers a cartesi compute game type. Its claims are resolved by looking up the machine
+// outputs on the trusted pre-image server rather than executing the machine, so no absolute pre-state is configured.
+func registerCartesiCompute(deps *registerDeps, gameType uint32, cfg *config.Config) error {
+	httpClient := &http.Client{Timeout: cfg.CartesiComputeTimeout}
+	deps.closer.add("cartesi compute pre-image client", func() error {
+		httpClient.CloseIdleConnections()
+		return nil
+	})
+	client := compute.NewPreimageClient(cfg.CartesiComputeServer, httpClient)
+	playerCreator := func(ctx context.Context, game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, deps.caller)
+		if err != nil {
+			return nil, err
+		}
+		prestate, err := contract.GetAbsolutePrestateHash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		_, rootClaim, _, _, err := contract.GetGameMetadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+		creator := func(_ context.Context, _ log.Logger, gameDepth faultTypes.Depth, _ string) (faultTypes.TraceAccessor, error) {
+			return trace.NewSimpleTraceAccessor(compute.NewTraceProvider(client, prestate, rootClaim, gameDepth)), nil
+		}
+		paramsValidator := NewGameParamsValidator(deps.m, gameType, contract, cfg.ExpectedGameParams(gameType))
+		return NewGamePlayer(ctx, deps.cl, deps.logger, deps.m, dir, game.Proxy, deps.txSender, contract, deps.syncValidator, []Validator{paramsValidator}, creator, deps.l1HeaderSource, cfg.ParticipationMode(gameType), cfg.LargePreimageChunkSize)
 	}
-	oracle, err := createOracle(ctx, gameFactory, caller, faultTypes.AlphabetGameType)
+	return registerOracleAndBonds(deps, gameType, cfg, playerCreator)
+}
+
+// registerOracleAndBonds registers the player creator with the preimage oracle used by the game type's
+// implementation, and the bond contract creator used to claim bonds from games of that type.
+// Each player creation is limited to cfg.PlayerCreationTimeout, unless it is 0.
+// Nothing is registered if the oracle does not have the expected parameters.
+func registerOracleAndBonds(deps *registerDeps, gameType uint32, cfg *config.Config, playerCreator playerCreator) error {
+	oracle, err := loadOracle(deps.ctx, deps.logger, deps.gameData, deps.caller, gameType, cfg.GameTypeOracles[gameType])
 	if err != nil {
+		deps.m.RecordOracleLookupFailed(gameType)
 		return err
 	}
-	registry.RegisterGameType(faultTypes.AlphabetGameType, playerCreator, oracle)
-
-	contractCreator := func(game types.GameMetadata) (claims.BondContract, error) {
-		return contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+	if err := ValidateOracle(deps.ctx, deps.logger, gameType, oracle, cfg.OracleParams); err != nil {
+		return err
+	}
+	contractCreator, err := bondContractCreator(deps.logger, deps.caller, gameType, cfg.GameTypeDelayedWETH[gameType])
+	if err != nil {
+		return err
 	}
-	registry.RegisterBondContract(faultTypes.AlphabetGameType, contractCreator)
+	deps.registry.RegisterGameType(gameType, withCreationTimeout(deps.ctx, deps.m, gameType, cfg.PlayerCreationTimeout, playerCreator), oracle)
+	deps.registry.RegisterBondContract(gameType, participationBondContractCreator(deps.logger, cfg.ParticipationMode(gameType), contractCreator))
 	return nil
 }
 
-func createOracle(ctx context.Context, gameFactory *contracts.DisputeGameFactoryContract, caller *batching.MultiCaller, gameType uint32) (*contracts.PreimageOracleContract, error) {
-	implAddr, err := gameFactory.GetGameImpl(ctx, gameType)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load implementation for game type %v: %w", gameType, err)
+// playerCreator creates the player for game, making every call needed to load the game's data with ctx.
+type playerCreator func(ctx context.Context, game types.GameMetadata, dir string) (scheduler.GamePlayer, error)
+
+// withCreationTimeout limits each call to create to timeout so a stalled RPC can't block the scheduler indefinitely.
+// Timeouts are reported as ErrPlayerCreationTimeout and the scheduler creates the player again when the game is
+// next scheduled. A timeout of 0 disables the limit. Every creation and failure is recorded against gameType.
+func withCreationTimeout(ctx context.Context, m metrics.Metricer, gameType uint32, timeout time.Duration, create playerCreator) scheduler.PlayerCreator {
+	return func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		player, err := createWithTimeout(ctx, m, gameType, timeout, create, game, dir)
+		if err != nil {
+			m.RecordPlayerCreationFailed(gameType)
+			return nil, err
+		}
+		m.RecordPlayerCreated(gameType)
+		return player, nil
+	}
+}
+
+func createWithTimeout(ctx context.Context, m metrics.Metricer, gameType uint32, timeout time.Duration, create playerCreator, game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+	if timeout == 0 {
+		return create(ctx, game, dir)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	player, err := create(ctx, game, dir)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		m.RecordPlayerCreationTimeout(gameType)
+		return nil, fmt.Errorf("%w: game %v after %v: %w", ErrPlayerCreationTimeout, game.Proxy, timeout, err)
+	}
+	return player, err
+}
+
+// bondContractCreator returns the creator of contracts to claim bonds from games of gameType.
+// If delayedWETH is set the games hold their bonds in that DelayedWETH contract and credit is only claimed once
+// the withdrawal delay has elapsed.
+func bondContractCreator(logger log.Logger, caller *batching.MultiCaller, gameType uint32, delayedWETH common.Address) (claims.BondContractCreator, error) {
+	if delayedWETH == (common.Address{}) {
+		return func(game types.GameMetadata) (claims.BondContract, error) {
+			return contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+		}, nil
 	}
-	contract, err := contracts.NewFaultDisputeGameContract(implAddr, caller)
+	weth, err := contracts.NewDelayedWETHContract(delayedWETH, caller)
 	if err != nil {
 		return nil, err
 	}
-	oracle, err := contract.GetOracle(ctx)
+	logger.Info("Claiming bonds through DelayedWETH", "gameType", gameType, "weth", delayedWETH)
+	return func(game types.GameMetadata) (claims.BondContract, error) {
+		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+		if err != nil {
+			return nil, err
+		}
+		return contracts.NewDelayedWETHBondContract(contract, weth), nil
+	}, nil
+}
+
+// loadOracle returns the preimage oracle used by the factory's implementation of gameType, or the oracle at
+// override if it is set. The implementation's oracle is still loaded when overridden so that stale overrides are
+// reported, but failing to load it doesn't prevent the override being used.
+func loadOracle(
+	ctx context.Context,
+	logger log.Logger,
+	gameData *contracts.GameDataCache,
+	caller *batching.MultiCaller,
+	gameType uint32,
+	override common.Address,
+) (*contracts.PreimageOracleContract, error) {
+	if override == (common.Address{}) {
+		return gameData.GetOracle(ctx, gameType)
+	}
+	logger.Info("Using preimage oracle override", "gameType", gameType, "oracle", override)
+	if discovered, err := gameData.GetOracle(ctx, gameType); err != nil {
+		logger.Warn("Unable to load preimage oracle of game implementation to compare with override", "gameType", gameType, "err", err)
+	} else if discovered.Addr() != override {
+		logger.Warn("Preimage oracle override differs from oracle of game implementation",
+			"gameType", gameType, "override", override, "implementation", discovered.Addr())
+	}
+	return contracts.NewPreimageOracleContract(override, caller)
+}
+
+// outputProviderCacheSize is the number of distinct L1 head and prestate block pairs to cache output providers for.
+const outputProviderCacheSize = 100
+
+type outputProviderKey struct {
+	l1Head        common.Hash
+	prestateBlock uint64
+}
+
+type outputProviders struct {
+	// ready is closed once the providers are created, or creating them failed with err.
+	ready chan struct{}
+	err   error
+
+	rollupClient     *source.RestrictedOutputSource
+	prestateProvider *memoizedPrestateProvider
+}
+
+// outputProviderCache shares the output root source and output prestate provider between games with the same
+// L1 head and prestate block, so the prestate output root is only fetched from the rollup node once.
+// It is safe for concurrent use.
+type outputProviderCache struct {
+	creator *source.OutputSourceCreator
+
+	lock  sync.Mutex
+	cache *caching.LRUCache[outputProviderKey, *outputProviders]
+}
+
+func newOutputProviderCache(m caching.Metrics, creator *source.OutputSourceCreator) *outputProviderCache {
+	return &outputProviderCache{
+		creator: creator,
+		cache:   caching.NewLRUCache[outputProviderKey, *outputProviders](m, "output_providers", outputProviderCacheSize),
+	}
+}
+
+func (c *outputProviderCache) get(ctx context.Context, l1Head common.Hash, prestateBlock uint64) (*source.RestrictedOutputSource, faultTypes.PrestateProvider, error) {
+	key := outputProviderKey{l1Head: l1Head, prestateBlock: prestateBlock}
+	c.lock.Lock()
+	providers, ok := c.cache.Get(key)
+	if !ok {
+		providers = &outputProviders{ready: make(chan struct{})}
+		c.cache.Add(key, providers)
+	}
+	c.lock.Unlock()
+	if ok {
+		select {
+		case <-providers.ready:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		if providers.err != nil {
+			return nil, nil, providers.err
+		}
+		return providers.rollupClient, providers.prestateProvider, nil
+	}
+
+	// The providers are created without holding the lock so a slow rollup node doesn't block games with other keys.
+	rollupClient, err := c.creator.ForL1Head(ctx, l1Head)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load oracle address: %w", err)
+		// Failures aren't cached, the next game with the same key tries again.
+		c.lock.Lock()
+		c.cache.Remove(key)
+		c.lock.Unlock()
+		providers.err = err
+		close(providers.ready)
+		return nil, nil, err
 	}
-	return oracle, nil
+	providers.rollupClient = rollupClient
+	providers.prestateProvider = &memoizedPrestateProvider{provider: outputs.NewPrestateProvider(rollupClient, prestateBlock)}
+	close(providers.ready)
+	return providers.rollupClient, providers.prestateProvider, nil
 }
 
-func registerCannon(
-	gameType uint32,
-	registry Registry,
-	ctx context.Context,
-	cl faultTypes.ClockReader,
+// memoizedPrestateProvider remembers the commitment of the wrapped provider once it has been loaded successfully.
+type memoizedPrestateProvider struct {
+	provider faultTypes.PrestateProvider
+
+	lock       sync.Mutex
+	commitment *common.Hash
+}
+
+func (p *memoizedPrestateProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.commitment != nil {
+		return *p.commitment, nil
+	}
+	commitment, err := p.provider.AbsolutePreStateCommitment(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	p.commitment = &commitment
+	return commitment, nil
+}
+
+// RegisterOption customises how RegisterGameTypes registers game types.
+type RegisterOption func(registerFuncs map[config.TraceType]vmRegisterFunc)
+
+// WithCannonVM plays games of traceType with cannon's absolute pre-state handling and output root bisection,
+// creating the trace accessor below the split depth with newAccessor. This allows forks to play a different VM
+// without copying the cannon registration. It only applies to the RegisterGameTypes call it is passed to.
+func WithCannonVM(traceType config.TraceType, newAccessor VMAccessorFactory) RegisterOption {
+	return func(registerFuncs map[config.TraceType]vmRegisterFunc) {
+		registerFuncs[traceType] = cannonRegisterFunc(newAccessor)
+	}
+}
+
+// cannonRegisterFunc returns the register function for cannon game types, using newAccessor to create the trace
+// accessor below the split depth.
+func cannonRegisterFunc(newAccessor VMAccessorFactory) vmRegisterFunc {
+	return func(deps *registerDeps, gameType uint32, cfg *config.Config, l2Client l2Source) error {
+		return registerCannon(deps, gameType, cfg, l2Client, newAccessor)
+	}
+}
+
+func registerCannon(deps *registerDeps, gameType uint32, cfg *config.Config, l2Client l2Source, newAccessor VMAccessorFactory) error {
+	if cannon.IsPrestateURL(cfg.CannonAbsolutePreState) {
+		prestatePath, err := fetchCannonPrestate(deps, cfg, gameType)
+		if err != nil {
+			return err
+		}
+		cannonCfg := *cfg
+		cannonCfg.CannonAbsolutePreState = prestatePath
+		cfg = &cannonCfg
+	}
+	var selectPrestate vmPrestateSelector
+	if len(cfg.CannonAbsolutePreStates) > 0 {
+		var err error
+		selectPrestate, err = indexedCannonPrestates(deps.ctx, deps.logger, deps.m, gameType, cfg)
+		if err != nil {
+			return err
+		}
+	} else {
+		prestateProvider := cannon.NewPrestateProvider(cfg.CannonAbsolutePreState)
+		if err := validateImplPrestate(deps, cfg, "cannon", gameType, prestateProvider); err != nil {
+			return err
+		}
+		selectPrestate = staticPrestate(cfg, prestateProvider)
+	}
+	return registerVM(deps, "cannon", gameType, cfg, l2Client, selectPrestate, newAccessor)
+}
+
+// indexedCannonPrestates indexes every configured cannon absolute pre-state and selects the one matching
+// each game's on-chain absolute pre-state hash. Games with no matching pre-state are refused.
+func indexedCannonPrestates(ctx context.Context, logger log.Logger, m metrics.Metricer, gameType uint32, cfg *config.Config) (vmPrestateSelector, error) {
+	paths := cfg.CannonAbsolutePreStates
+	if cfg.CannonAbsolutePreState != "" {
+		paths = append([]string{cfg.CannonAbsolutePreState}, paths...)
+	}
+	index, err := cannon.NewPrestateIndex(ctx, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index cannon absolute pre-states: %w", err)
+	}
+	logger.Info("Indexed cannon absolute pre-states", "count", index.Len())
+	return func(game *contracts.FaultDisputeGameContract, hash common.Hash) (faultTypes.PrestateProvider, *config.Config, error) {
+		path, ok := index.Get(hash)
+		if !ok {
+			logger.Error("No configured cannon absolute pre-state matches game, refusing to play", "game", game.Addr(), "prestate", hash)
+			m.RecordUnmatchedPrestate(gameType)
+			return nil, nil, fmt.Errorf("%w: %v", ErrNoMatchingPrestate, hash)
+		}
+		gameCfg := *cfg
+		gameCfg.CannonAbsolutePreState = path
+		return cannon.NewPrestateProvider(path), &gameCfg, nil
+	}, nil
+}
+
+// fetchCannonPrestate downloads the absolute pre-state published at cfg.CannonAbsolutePreState into the
+// data dir and verifies it matches the absolute pre-state of the factory's implementation of gameType.
+// The download is limited to cfg.CannonPreStateTimeout, unless it is 0.
+func fetchCannonPrestate(deps *registerDeps, cfg *config.Config, gameType uint32) (string, error) {
+	ctx := deps.ctx
+	if cfg.CannonPreStateTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.CannonPreStateTimeout)
+		defer cancel()
+	}
+	expected, err := deps.gameData.GetAbsolutePrestateHash(ctx, gameType)
+	if err != nil {
+		return "", fmt.Errorf("failed to load absolute pre-state hash: %w", err)
+	}
+	httpClient := &http.Client{}
+	deps.closer.add("cannon pre-state download client", func() error {
+		httpClient.CloseIdleConnections()
+		return nil
+	})
+	downloader := cannon.NewPrestateDownloader(deps.logger, httpClient, filepath.Join(cfg.Datadir, "prestates"))
+	return downloader.Fetch(ctx, cfg.CannonAbsolutePreState, expected)
+}
+
+func registerAsterisc(deps *registerDeps, gameType uint32, cfg *config.Config, l2Client l2Source) error {
+	prestateProvider := asterisc.NewPrestateProvider(cfg.AsteriscAbsolutePreState)
+	if err := validateImplPrestate(deps, cfg, "asterisc", gameType, prestateProvider); err != nil {
+		return err
+	}
+	selectPrestate := staticPrestate(cfg, prestateProvider)
+	return registerVM(deps, "asterisc", gameType, cfg, l2Client, selectPrestate, outputs.NewOutputAsteriscTraceAccessor)
+}
+
+func registerCartesi(deps *registerDeps, gameType uint32, cfg *config.Config, l2Client l2Source) error {
+	prestateProvider := cartesi.NewPrestateProvider(cfg.CartesiSnapshotDir)
+	if err := validateImplPrestate(deps, cfg, "cartesi", gameType, prestateProvider); err != nil {
+		return err
+	}
+	selectPrestate := staticPrestate(cfg, prestateProvider)
+	return registerVM(deps, "cartesi", gameType, cfg, l2Client, selectPrestate, outputs.NewOutputCartesiTraceAccessor)
+}
+
+func registerExternal(deps *registerDeps, gameType uint32, cfg *config.Config, l2Client l2Source) error {
+	processes := external.NewProcesses()
+	deps.closer.add("external trace providers", processes.Close)
+	prestateProvider := external.NewPrestateProvider(deps.logger, cfg, processes)
+	if err := validateImplPrestate(deps, cfg, "external", gameType, prestateProvider); err != nil {
+		return err
+	}
+	selectPrestate := staticPrestate(cfg, prestateProvider)
+	newAccessor := func(logger log.Logger, m metrics.Metricer, cfg *config.Config, l2Client cannon.L2HeaderSource, contract cannon.L1HeadSource, prestateProvider faultTypes.PrestateProvider, rollupClient outputs.OutputRootProvider, dir string, splitDepth faultTypes.Depth, prestateBlock uint64, poststateBlock uint64) (*trace.Accessor, error) {
+		return outputs.NewOutputExternalTraceAccessor(logger, m, cfg, processes, l2Client, contract, prestateProvider, rollupClient, dir, splitDepth, prestateBlock, poststateBlock)
+	}
+	return registerVM(deps, "external", gameType, cfg, l2Client, selectPrestate, newAccessor)
+}
+
+func registerRemote(deps *registerDeps, gameType uint32, cfg *config.Config, l2Client l2Source) error {
+	httpClient := &http.Client{Timeout: cfg.RemoteTraceTimeout}
+	deps.closer.add("remote trace client", func() error {
+		httpClient.CloseIdleConnections()
+		return nil
+	})
+	client := remote.NewClient(cfg.RemoteTraceURLs[gameType], httpClient)
+	// The remote trace service doesn't provide its absolute pre-state so the implementation's pre-state is trusted.
+	prestateProvider := &implPrestateProvider{gameData: deps.gameData, gameType: gameType}
+	newAccessor := func(logger log.Logger, m metrics.Metricer, _ *config.Config, l2Client cannon.L2HeaderSource, contract cannon.L1HeadSource, outputPrestateProvider faultTypes.PrestateProvider, rollupClient outputs.OutputRootProvider, dir string, splitDepth faultTypes.Depth, prestateBlock uint64, poststateBlock uint64) (*trace.Accessor, error) {
+		return outputs.NewOutputRemoteTraceAccessor(logger, m, client, prestateProvider, l2Client, contract, outputPrestateProvider, rollupClient, dir, splitDepth, prestateBlock, poststateBlock)
+	}
+	return registerVM(deps, "remote", gameType, cfg, l2Client, staticPrestate(cfg, prestateProvider), newAccessor)
+}
+
+// implPrestateProvider provides the absolute pre-state of the factory's implementation of gameType.
+type implPrestateProvider struct {
+	gameData *contracts.GameDataCache
+	gameType uint32
+}
+
+func (p *implPrestateProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return p.gameData.GetAbsolutePrestateHash(ctx, p.gameType)
+}
+
+// validateImplPrestate checks the local absolute pre-state of gameType matches the absolute pre-state of the factory's
+// implementation so a misconfigured pre-state is reported at startup rather than by losing every game.
+// Mismatches are only logged if cfg.AllowPrestateMismatch is set.
+func validateImplPrestate(deps *registerDeps, cfg *config.Config, vmName string, gameType uint32, provider faultTypes.PrestateProvider) error {
+	loadImplPrestate := func(ctx context.Context) (common.Hash, error) {
+		return deps.gameData.GetAbsolutePrestateHash(ctx, gameType)
+	}
+	err := NewPrestateValidator(vmName, loadImplPrestate, provider).Validate(deps.ctx)
+	if errors.Is(err, types.ErrInvalidPrestate) && cfg.AllowPrestateMismatch {
+		deps.logger.Warn("Registering game type with mismatched absolute prestate", "gameType", gameType, "err", err)
+		return nil
+	}
+	return err
+}
+
+// vmPrestateSelector returns the provider of the VM absolute pre-state to use for game with the on-chain
+// absolute pre-state hash prestate, and the config to run the VM with for that pre-state.
+type vmPrestateSelector func(game *contracts.FaultDisputeGameContract, prestate common.Hash) (faultTypes.PrestateProvider, *config.Config, error)
+
+// staticPrestate selects the same absolute pre-state for every game.
+func staticPrestate(cfg *config.Config, provider faultTypes.PrestateProvider) vmPrestateSelector {
+	return func(_ *contracts.FaultDisputeGameContract, _ common.Hash) (faultTypes.PrestateProvider, *config.Config, error) {
+		return provider, cfg, nil
+	}
+}
+
+// VMAccessorFactory creates the trace accessor for a game that uses output roots above the split depth and
+// executes a VM below it. The output trace accessor constructors, such as outputs.NewOutputCannonTraceAccessor, are VMAccessorFactories.
+type VMAccessorFactory func(
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
-	syncValidator SyncValidator,
-	outputSourceCreator *source.OutputSourceCreator,
-	txSender types.TxSender,
-	gameFactory *contracts.DisputeGameFactoryContract,
-	caller *batching.MultiCaller,
 	l2Client cannon.L2HeaderSource,
-	l1HeaderSource L1HeaderSource,
+	contract cannon.L1HeadSource,
+	prestateProvider faultTypes.PrestateProvider,
+	rollupClient outputs.OutputRootProvider,
+	dir string,
+	splitDepth faultTypes.Depth,
+	prestateBlock uint64,
+	poststateBlock uint64,
+) (*trace.Accessor, error)
+
+// registerVM registers a game type that uses output roots above the split depth and a VM trace below it.
+// vmName identifies the VM in prestate validation errors and selectPrestate chooses its absolute prestate for each game.
+// The L2 client is connected when the first game of the type is played rather than at registration.
+func registerVM(
+	deps *registerDeps,
+	vmName string,
+	gameType uint32,
+	cfg *config.Config,
+	l2Client l2Source,
+	selectPrestate vmPrestateSelector,
+	newAccessor VMAccessorFactory,
 ) error {
-	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
-		if err != nil {
+	playerCreator := func(ctx context.Context, game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		if _, err := l2Client.connect(ctx); err != nil {
 			return nil, err
 		}
-		prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, deps.caller)
 		if err != nil {
 			return nil, err
 		}
-		splitDepth, err := contract.GetSplitDepth(ctx)
+		data, err := deps.gameData.GetSetupData(ctx, contract)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load split depth: %w", err)
+			return nil, err
 		}
-		l1Head, err := contract.GetL1Head(ctx)
+		vmPrestateProvider, vmCfg, err := selectPrestate(contract, data.AbsolutePrestate)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load L1 head: %w", err)
+			return nil, fmt.Errorf("failed to select %v absolute prestate: %w", vmName, err)
 		}
-		rollupClient, err := outputSourceCreator.ForL1Head(ctx, l1Head)
+		rollupClient, prestateProvider, err := deps.outputProviders.get(ctx, data.L1Head, data.PrestateBlock)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create output root source: %w", err)
 		}
-		prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock)
-		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			accessor, err := outputs.NewOutputCannonTraceAccessor(logger, m, cfg, l2Client, contract, prestateProvider, rollupClient, dir, splitDepth, prestateBlock, poststateBlock)
+		// The accessor logs with the registration logger rather than the game's logger passed to the creator.
+		creator := func(ctx context.Context, _ log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
+			accessor, err := newAccessor(deps.logger, deps.m, vmCfg, l2Client, contract, prestateProvider, rollupClient, dir, data.SplitDepth, data.PrestateBlock, data.PoststateBlock)
 			if err != nil {
 				return nil, err
 			}
 			return accessor, nil
 		}
-		prestateValidator := NewPrestateValidator("cannon", contract.GetAbsolutePrestateHash, cannon.NewPrestateProvider(cfg.CannonAbsolutePreState))
-		genesisValidator := NewPrestateValidator("output root", contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, cl, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, genesisValidator}, creator, l1HeaderSource)
+		prestateValidator := NewPrestateValidator(vmName, knownHash(data.AbsolutePrestate), vmPrestateProvider)
+		genesisValidator := NewPrestateValidator("output root", knownHash(data.GenesisOutputRoot), prestateProvider)
+		paramsValidator := NewGameParamsValidator(deps.m, gameType, contract, cfg.ExpectedGameParams(gameType))
+		return NewGamePlayer(ctx, deps.cl, deps.logger, deps.m, dir, game.Proxy, deps.txSender, contract, deps.syncValidator, []Validator{prestateValidator, genesisValidator, paramsValidator}, creator, deps.l1HeaderSource, cfg.ParticipationMode(gameType), cfg.LargePreimageChunkSize)
 	}
-	oracle, err := createOracle(ctx, gameFactory, caller, gameType)
-	if err != nil {
-		return err
-	}
-	registry.RegisterGameType(gameType, playerCreator, oracle)
-
-	contractCreator := func(game types.GameMetadata) (claims.BondContract, error) {
-		return contracts.NewFaultDisputeGameContract(game.Proxy, caller)
-	}
-	registry.RegisterBondContract(gameType, contractCreator)
-	return nil
+	return registerOracleAndBonds(deps, gameType, cfg, playerCreator)
 }
@@ -0,0 +1,1411 @@
+package fault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cartesi"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs/source"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	registerFactoryAddr = common.Address{0xfa}
+	registerImplAddr    = common.Address{0x1a}
+	registerVMAddr      = common.Address{0x2a}
+	registerOracleAddr  = common.Address{0x3a}
+	registerGameAddr    = common.Address{0x4a}
+)
+
+const registerOracleVersion = "1.0.0"
+
+func TestRegisterGameTypesWiresVMPrestateProvider(t *testing.T) {
+	tests := []struct {
+		traceType config.TraceType
+		gameType  uint32
+		vmName    string
+		provider  faultTypes.PrestateProvider
+	}{
+		{config.TraceTypeCannon, faultTypes.CannonGameType, "cannon", &cannon.CannonPrestateProvider{}},
+		{config.TraceTypePermissioned, faultTypes.PermissionedGameType, "cannon", &cannon.CannonPrestateProvider{}},
+		{config.TraceTypeAsterisc, faultTypes.AsteriscGameType, "asterisc", &asterisc.AsteriscPrestateProvider{}},
+		{config.TraceTypeCartesi, faultTypes.CartesiGameType, "cartesi", &cartesi.CartesiPrestateProvider{}},
+	}
+	prestates := writeRegisterPrestates(t)
+	for _, test := range tests {
+		test := test
+		t.Run(string(test.traceType), func(t *testing.T) {
+			registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+			stubRpc, gameData, caller := setupRegisterTest(t, test.gameType)
+			cfg := &config.Config{
+				TraceTypes:               []config.TraceType{test.traceType},
+				CannonL2:                 "http://localhost:1",
+				CannonAbsolutePreState:   prestates.cannon,
+				AsteriscAbsolutePreState: prestates.asterisc,
+				CartesiSnapshotDir:       prestates.cartesi,
+			}
+			logger := testlog.Logger(t, log.LevelInfo)
+			closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+				metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+			require.NoError(t, err)
+			if closer != nil {
+				t.Cleanup(closer)
+			}
+			require.Len(t, registry.creators, 1)
+			require.Contains(t, registry.creators, test.gameType)
+			require.Equal(t, registerOracleAddr, registry.oracles[test.gameType].(*contracts.PreimageOracleContract).Addr())
+			require.Contains(t, registry.bondCreators, test.gameType)
+
+			stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+			stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+			stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+			stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+			stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+			stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+			stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+			player, err := registry.creators[test.gameType](types.GameMetadata{GameType: test.gameType, Proxy: registerGameAddr}, t.TempDir())
+			require.NoError(t, err)
+
+			gamePlayer := player.(*GamePlayer)
+			require.Len(t, gamePlayer.prestateValidators, 3)
+			vmValidator := gamePlayer.prestateValidators[0].(*PrestateValidator)
+			require.Equal(t, test.vmName, vmValidator.valueName)
+			require.IsType(t, test.provider, vmValidator.provider)
+		})
+	}
+}
+
+func TestRegisterGameTypesFromMapping(t *testing.T) {
+	prestates := writeRegisterPrestates(t)
+	customPrestate, _ := writeCannonPrestate(t, t.TempDir(), "custom-prestate.json", 0)
+	newConfig := func(gameTypes ...config.GameTypeConfig) *config.Config {
+		return &config.Config{
+			TraceTypes:             []config.TraceType{config.TraceTypeCannon},
+			GameTypes:              gameTypes,
+			CannonL2:               "http://localhost:1",
+			CannonAbsolutePreState: prestates.cannon,
+		}
+	}
+	register := func(t *testing.T, cfg *config.Config, gameType uint32) (*stubRegistry, *batchingTest.AbiBasedRpc, error) {
+		registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+		stubRpc, gameData, caller := setupRegisterTest(t, gameType)
+		logger := testlog.Logger(t, log.LevelInfo)
+		closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+			metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+		if closer != nil {
+			t.Cleanup(closer)
+		}
+		return registry, stubRpc, err
+	}
+
+	t.Run("CustomGameTypeUsesCannon", func(t *testing.T) {
+		customGameType := uint32(42)
+		registry, stubRpc, err := register(t, newConfig(config.GameTypeConfig{
+			GameType:         customGameType,
+			TraceType:        config.TraceTypeCannon,
+			AbsolutePreState: customPrestate,
+		}), customGameType)
+		require.NoError(t, err)
+		require.Len(t, registry.creators, 1)
+		require.Contains(t, registry.creators, customGameType)
+		require.Equal(t, registerOracleAddr, registry.oracles[customGameType].(*contracts.PreimageOracleContract).Addr())
+		require.Contains(t, registry.bondCreators, customGameType)
+
+		stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+		stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+		stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+		stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+		stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+		stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+		stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+		player, err := registry.creators[customGameType](types.GameMetadata{GameType: customGameType, Proxy: registerGameAddr}, t.TempDir())
+		require.NoError(t, err)
+
+		gamePlayer := player.(*GamePlayer)
+		require.Len(t, gamePlayer.prestateValidators, 3)
+		vmValidator := gamePlayer.prestateValidators[0].(*PrestateValidator)
+		require.Equal(t, "cannon", vmValidator.valueName)
+		require.Equal(t, cannon.NewPrestateProvider(customPrestate), vmValidator.provider)
+	})
+
+	t.Run("DefaultsWithoutMapping", func(t *testing.T) {
+		registry, _, err := register(t, newConfig(), faultTypes.CannonGameType)
+		require.NoError(t, err)
+		require.Len(t, registry.creators, 1)
+		require.Contains(t, registry.creators, faultTypes.CannonGameType)
+	})
+
+	t.Run("UnknownTraceType", func(t *testing.T) {
+		_, _, err := register(t, newConfig(config.GameTypeConfig{GameType: 42, TraceType: "foo"}), 42)
+		require.ErrorIs(t, err, config.ErrInvalidGameTypeTraceType)
+	})
+
+	t.Run("DuplicateGameType", func(t *testing.T) {
+		_, _, err := register(t, newConfig(
+			config.GameTypeConfig{GameType: 42, TraceType: config.TraceTypeCannon},
+			config.GameTypeConfig{GameType: 42, TraceType: config.TraceTypeCannon, AbsolutePreState: customPrestate},
+		), 42)
+		require.ErrorIs(t, err, config.ErrDuplicateGameType)
+	})
+}
+
+func TestRegisterGameTypesReportsRegistered(t *testing.T) {
+	prestates := writeRegisterPrestates(t)
+	customPrestate, _ := writeCannonPrestate(t, t.TempDir(), "custom-prestate.json", 0)
+	tests := []struct {
+		name       string
+		traceTypes []config.TraceType
+		gameTypes  []config.GameTypeConfig
+		expected   []config.GameTypeConfig
+	}{
+		{
+			name:       "Cannon",
+			traceTypes: []config.TraceType{config.TraceTypeCannon},
+			expected:   []config.GameTypeConfig{{GameType: faultTypes.CannonGameType, TraceType: config.TraceTypeCannon}},
+		},
+		{
+			name:       "CannonAndAlphabet",
+			traceTypes: []config.TraceType{config.TraceTypeAlphabet, config.TraceTypeCannon},
+			expected: []config.GameTypeConfig{
+				{GameType: faultTypes.CannonGameType, TraceType: config.TraceTypeCannon},
+				{GameType: faultTypes.AlphabetGameType, TraceType: config.TraceTypeAlphabet},
+			},
+		},
+		{
+			name:       "BothAlphabets",
+			traceTypes: []config.TraceType{config.TraceTypeAlphabet, config.TraceTypePermissionedAlphabet},
+			expected: []config.GameTypeConfig{
+				{GameType: faultTypes.PermissionedAlphabetGameType, TraceType: config.TraceTypePermissionedAlphabet},
+				{GameType: faultTypes.AlphabetGameType, TraceType: config.TraceTypeAlphabet},
+			},
+		},
+		{
+			name:       "AllVMs",
+			traceTypes: []config.TraceType{config.TraceTypeCannon, config.TraceTypePermissioned, config.TraceTypeAsterisc, config.TraceTypeCartesi},
+			expected: []config.GameTypeConfig{
+				{GameType: faultTypes.CannonGameType, TraceType: config.TraceTypeCannon},
+				{GameType: faultTypes.PermissionedGameType, TraceType: config.TraceTypePermissioned},
+				{GameType: faultTypes.AsteriscGameType, TraceType: config.TraceTypeAsterisc},
+				{GameType: faultTypes.CartesiGameType, TraceType: config.TraceTypeCartesi},
+			},
+		},
+		{
+			name:       "External",
+			traceTypes: []config.TraceType{config.TraceTypeExternal},
+			gameTypes:  []config.GameTypeConfig{{GameType: 42, TraceType: config.TraceTypeExternal}},
+			expected:   []config.GameTypeConfig{{GameType: 42, TraceType: config.TraceTypeExternal}},
+		},
+		{
+			name:       "Remote",
+			traceTypes: []config.TraceType{config.TraceTypeRemote},
+			gameTypes:  []config.GameTypeConfig{{GameType: 44, TraceType: config.TraceTypeRemote}},
+			expected:   []config.GameTypeConfig{{GameType: 44, TraceType: config.TraceTypeRemote}},
+		},
+		{
+			name:       "Mapping",
+			traceTypes: []config.TraceType{config.TraceTypeCannon, config.TraceTypeAlphabet},
+			gameTypes: []config.GameTypeConfig{
+				{GameType: 42, TraceType: config.TraceTypeCannon, AbsolutePreState: customPrestate},
+				{GameType: 43, TraceType: config.TraceTypeAlphabet},
+			},
+			expected: []config.GameTypeConfig{
+				{GameType: 42, TraceType: config.TraceTypeCannon, AbsolutePreState: customPrestate},
+				{GameType: 43, TraceType: config.TraceTypeAlphabet},
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+			stubRpc, gameData, caller := setupRegisterTest(t, test.expected[0].GameType)
+			for _, gameType := range test.expected[1:] {
+				stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{gameType.GameType}, []interface{}{registerImplAddr})
+			}
+			cfg := &config.Config{
+				TraceTypes:               test.traceTypes,
+				GameTypes:                test.gameTypes,
+				Datadir:                  t.TempDir(),
+				CannonL2:                 "http://localhost:1",
+				CannonAbsolutePreState:   prestates.cannon,
+				AsteriscAbsolutePreState: prestates.asterisc,
+				CartesiSnapshotDir:       prestates.cartesi,
+				ExternalCommand:          prestates.external,
+				RemoteTraceURLs:          map[uint32]string{44: "http://localhost:2"},
+			}
+			m := &registeredGameTypesMetrics{}
+			logger := testlog.Logger(t, log.LevelInfo)
+			closer, registered, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+				m, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+			require.NoError(t, err)
+			t.Cleanup(closer)
+			require.Equal(t, test.expected, registered)
+			require.Len(t, registry.creators, len(test.expected))
+			expectedMetrics := make([]config.GameTypeConfig, len(test.expected))
+			for i, gameType := range test.expected {
+				expectedMetrics[i] = config.GameTypeConfig{GameType: gameType.GameType, TraceType: gameType.TraceType}
+			}
+			require.Equal(t, expectedMetrics, m.registered)
+		})
+	}
+
+	t.Run("NoneRegistered", func(t *testing.T) {
+		registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+		_, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+		m := &registeredGameTypesMetrics{}
+		logger := testlog.Logger(t, log.LevelInfo)
+		closer, registered, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+			m, &config.Config{}, &stubRollupClient{}, nil, gameData, caller, nil)
+		require.ErrorIs(t, err, ErrNoGameTypesRegistered)
+		require.NotNil(t, closer, "should return the closer when registration fails")
+		closer()
+		require.Empty(t, registered)
+		require.Empty(t, m.registered)
+	})
+}
+
+func TestRegisterBothAlphabetGameTypes(t *testing.T) {
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.AlphabetGameType)
+	stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{faultTypes.PermissionedAlphabetGameType}, []interface{}{registerImplAddr})
+	cfg := &config.Config{
+		TraceTypes: []config.TraceType{config.TraceTypeAlphabet, config.TraceTypePermissionedAlphabet},
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+	require.NoError(t, err)
+	t.Cleanup(closer)
+	require.Len(t, registry.creators, 2)
+	require.Len(t, registry.oracles, 2)
+	require.Len(t, registry.bondCreators, 2)
+
+	stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+	stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+	stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+	stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+	stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+	stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+	stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+	for _, gameType := range []uint32{faultTypes.AlphabetGameType, faultTypes.PermissionedAlphabetGameType} {
+		_, err = registry.creators[gameType](types.GameMetadata{GameType: gameType, Proxy: registerGameAddr}, t.TempDir())
+		require.NoError(t, err)
+	}
+}
+
+func TestRegisterCartesiCompute(t *testing.T) {
+	gameType := uint32(43)
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, gameType)
+	cfg := &config.Config{
+		TraceTypes:           []config.TraceType{config.TraceTypeCartesiCompute},
+		GameTypes:            []config.GameTypeConfig{{GameType: gameType, TraceType: config.TraceTypeCartesiCompute}},
+		CartesiComputeServer: "http://localhost:1",
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	closer, registered, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+	require.NoError(t, err)
+	t.Cleanup(closer)
+	require.Equal(t, cfg.GameTypes, registered)
+	require.Equal(t, registerOracleAddr, registry.oracles[gameType].(*contracts.PreimageOracleContract).Addr())
+	require.Contains(t, registry.bondCreators, gameType)
+
+	stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+	stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+	stubRpc.SetResponse(registerGameAddr, "rootClaim", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+	stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+	stubRpc.SetResponse(registerGameAddr, "gameDuration", batching.BlockLatest, nil, []interface{}{uint64(3600)})
+	player, err := registry.creators[gameType](types.GameMetadata{GameType: gameType, Proxy: registerGameAddr}, t.TempDir())
+	require.NoError(t, err)
+
+	// Only the game parameters are validated as the absolute pre-state is resolved by the pre-image server
+	gamePlayer := player.(*GamePlayer)
+	require.Len(t, gamePlayer.prestateValidators, 1)
+	require.IsType(t, &GameParamsValidator{}, gamePlayer.prestateValidators[0])
+}
+
+func TestRegisterGameTypesValidatesOracle(t *testing.T) {
+	otherImplAddr := common.Address{0x1b}
+	otherVMAddr := common.Address{0x2b}
+	otherOracleAddr := common.Address{0x3b}
+	period := uint64(config.DefaultOracleParams.ChallengePeriod.Seconds())
+	minBond := config.DefaultOracleParams.MinBond
+	expected := config.OracleParams{
+		Version:         registerOracleVersion,
+		ChallengePeriod: config.DefaultOracleParams.ChallengePeriod,
+		MinBond:         minBond,
+	}
+	tests := []struct {
+		name            string
+		version         string
+		challengePeriod uint64
+		minBond         uint64
+		expectedErr     string
+	}{
+		{name: "Compatible", version: registerOracleVersion, challengePeriod: period, minBond: minBond},
+		{name: "DifferentVersion", version: "2.0.0", challengePeriod: period, minBond: minBond, expectedErr: "version expected 1.0.0 but was 2.0.0"},
+		{name: "DifferentChallengePeriod", version: registerOracleVersion, challengePeriod: 120, minBond: minBond, expectedErr: "challenge period expected 24h0m0s but was 2m0s"},
+		{name: "DifferentMinBond", version: registerOracleVersion, challengePeriod: period, minBond: 1, expectedErr: "min bond expected 250000000000000000 but was 1"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+			stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+			fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+			require.NoError(t, err)
+			vmAbi, err := bindings.MIPSMetaData.GetAbi()
+			require.NoError(t, err)
+			stubRpc.AddContract(otherImplAddr, fdgAbi)
+			stubRpc.AddContract(otherVMAddr, vmAbi)
+			stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{faultTypes.AlphabetGameType}, []interface{}{otherImplAddr})
+			stubRpc.SetResponse(otherImplAddr, "vm", batching.BlockLatest, nil, []interface{}{otherVMAddr})
+			stubRpc.SetResponse(otherVMAddr, "oracle", batching.BlockLatest, nil, []interface{}{otherOracleAddr})
+			stubRegisterOracle(t, stubRpc, otherOracleAddr, test.version, test.challengePeriod, test.minBond)
+			cfg := &config.Config{
+				TraceTypes:             []config.TraceType{config.TraceTypeCannon, config.TraceTypeAlphabet},
+				CannonL2:               "http://localhost:1",
+				CannonAbsolutePreState: writeRegisterPrestates(t).cannon,
+				OracleParams:           expected,
+			}
+			m := &oracleMetrics{}
+			logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+			closer, registered, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+				m, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+			require.NoError(t, err)
+			t.Cleanup(closer)
+
+			oracleLogs := logs.FindLogs(testlog.NewLevelFilter(log.LevelInfo), testlog.NewMessageFilter("Preimage oracle parameters"))
+			require.Len(t, oracleLogs, 2, "should log the parameters of every oracle")
+			require.Equal(t, otherOracleAddr, oracleLogs[1].AttrValue("oracle"))
+			require.Equal(t, test.version, oracleLogs[1].AttrValue("version"))
+
+			require.Contains(t, registry.creators, faultTypes.CannonGameType, "should register game types with compatible oracles")
+			if test.expectedErr == "" {
+				require.Len(t, registered, 2)
+				require.Contains(t, registry.creators, faultTypes.AlphabetGameType)
+				require.Empty(t, m.incompatible)
+				return
+			}
+			require.Len(t, registered, 1)
+			require.Equal(t, []uint32{faultTypes.AlphabetGameType}, m.incompatible)
+			require.NotContains(t, registry.creators, faultTypes.AlphabetGameType)
+			require.NotContains(t, registry.bondCreators, faultTypes.AlphabetGameType)
+			errLog := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("Not registering game type with incompatible preimage oracle"))
+			require.NotNil(t, errLog)
+			require.ErrorIs(t, errLog.AttrValue("err").(error), ErrIncompatibleOracle)
+			require.ErrorContains(t, errLog.AttrValue("err").(error), test.expectedErr)
+		})
+	}
+}
+
+func TestRegisterGameTypesOracleOverride(t *testing.T) {
+	overrideAddr := common.Address{0x3c}
+	register := func(t *testing.T, oracles map[uint32]common.Address) (*stubRegistry, *testlog.CapturingHandler) {
+		registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+		stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.AlphabetGameType)
+		stubRegisterOracle(t, stubRpc, overrideAddr, registerOracleVersion, uint64(config.DefaultOracleParams.ChallengePeriod.Seconds()), config.DefaultOracleParams.MinBond)
+		cfg := &config.Config{
+			TraceTypes:      []config.TraceType{config.TraceTypeAlphabet},
+			GameTypeOracles: oracles,
+		}
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+			metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+		require.NoError(t, err)
+		t.Cleanup(closer)
+		return registry, logs
+	}
+	overrideLog := testlog.NewMessageFilter("Using preimage oracle override")
+	mismatchLog := testlog.NewMessageFilter("Preimage oracle override differs from oracle of game implementation")
+
+	t.Run("NoOverride", func(t *testing.T) {
+		registry, logs := register(t, nil)
+		require.Equal(t, registerOracleAddr, registry.oracles[faultTypes.AlphabetGameType].(*contracts.PreimageOracleContract).Addr())
+		require.Nil(t, logs.FindLog(overrideLog))
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		registry, logs := register(t, map[uint32]common.Address{faultTypes.AlphabetGameType: overrideAddr})
+		require.Equal(t, overrideAddr, registry.oracles[faultTypes.AlphabetGameType].(*contracts.PreimageOracleContract).Addr())
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelInfo), overrideLog))
+		mismatch := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), mismatchLog)
+		require.NotNil(t, mismatch, "should warn when the override differs from the implementation's oracle")
+		require.Equal(t, overrideAddr, mismatch.AttrValue("override"))
+		require.Equal(t, registerOracleAddr, mismatch.AttrValue("implementation"))
+	})
+
+	t.Run("OverrideMatchesImplementation", func(t *testing.T) {
+		registry, logs := register(t, map[uint32]common.Address{faultTypes.AlphabetGameType: registerOracleAddr})
+		require.Equal(t, registerOracleAddr, registry.oracles[faultTypes.AlphabetGameType].(*contracts.PreimageOracleContract).Addr())
+		require.NotNil(t, logs.FindLog(overrideLog))
+		require.Nil(t, logs.FindLog(mismatchLog))
+	})
+
+	t.Run("OtherGameType", func(t *testing.T) {
+		registry, logs := register(t, map[uint32]common.Address{faultTypes.CannonGameType: overrideAddr})
+		require.Equal(t, registerOracleAddr, registry.oracles[faultTypes.AlphabetGameType].(*contracts.PreimageOracleContract).Addr())
+		require.Nil(t, logs.FindLog(overrideLog))
+	})
+}
+
+func TestRegisterGameTypesBondContracts(t *testing.T) {
+	wethAddr := common.Address{0x5a}
+	sender := &capturingTxSender{}
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+	stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{faultTypes.AlphabetGameType}, []interface{}{registerImplAddr})
+	wethAbi, err := contracts.LoadDelayedWETHABI()
+	require.NoError(t, err)
+	stubRpc.AddContract(wethAddr, wethAbi)
+	cfg := &config.Config{
+		TraceTypes:             []config.TraceType{config.TraceTypeCannon, config.TraceTypeAlphabet},
+		CannonL2:               "http://localhost:1",
+		CannonAbsolutePreState: writeRegisterPrestates(t).cannon,
+		GameTypeDelayedWETH:    map[uint32]common.Address{faultTypes.AlphabetGameType: wethAddr},
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+	require.NoError(t, err)
+	t.Cleanup(closer)
+
+	stubRpc.SetResponse(registerGameAddr, "credit", batching.BlockLatest, []interface{}{sender.From()}, []interface{}{big.NewInt(10)})
+	stubRpc.SetResponse(wethAddr, "delay", batching.BlockLatest, nil, []interface{}{big.NewInt(600)})
+	stubRpc.SetResponse(wethAddr, "withdrawals", batching.BlockLatest, []interface{}{registerGameAddr, sender.From()}, []interface{}{big.NewInt(10), big.NewInt(1000)})
+	createBondContract := func(game types.GameMetadata) (claims.BondContract, error) {
+		return registry.bondCreators[game.GameType](game)
+	}
+	claimBonds := func(t *testing.T, gameType uint32, now time.Time) int {
+		sender.sent = nil
+		claimer := claims.NewBondClaimer(logger, &stubBondClaimMetrics{}, createBondContract, sender, clock.NewDeterministicClock(now))
+		require.NoError(t, claimer.ClaimBonds(context.Background(), []types.GameMetadata{{GameType: gameType, Proxy: registerGameAddr}}))
+		return len(sender.sent)
+	}
+
+	t.Run("GameContract", func(t *testing.T) {
+		contract, err := createBondContract(types.GameMetadata{GameType: faultTypes.CannonGameType, Proxy: registerGameAddr})
+		require.NoError(t, err)
+		require.IsType(t, &contracts.FaultDisputeGameContract{}, contract)
+		require.Equal(t, 1, claimBonds(t, faultTypes.CannonGameType, time.Unix(0, 0)), "should claim without delay")
+	})
+
+	t.Run("DelayedWETH", func(t *testing.T) {
+		contract, err := createBondContract(types.GameMetadata{GameType: faultTypes.AlphabetGameType, Proxy: registerGameAddr})
+		require.NoError(t, err)
+		require.Implements(t, (*claims.DelayedBondContract)(nil), contract)
+		require.Zero(t, claimBonds(t, faultTypes.AlphabetGameType, time.Unix(1599, 0)), "should not claim before the delay elapses")
+		require.Equal(t, 1, claimBonds(t, faultTypes.AlphabetGameType, time.Unix(1600, 0)), "should claim once the delay elapses")
+	})
+}
+
+func TestRegisterGameTypesDialsL2Lazily(t *testing.T) {
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+	stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{faultTypes.AlphabetGameType}, []interface{}{registerImplAddr})
+	cfg := &config.Config{
+		TraceTypes:             []config.TraceType{config.TraceTypeCannon, config.TraceTypeAlphabet},
+		CannonL2:               "http://localhost:1",
+		CannonAbsolutePreState: writeRegisterPrestates(t).cannon,
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	dialer := &stubL2Dialer{}
+	clients := newL2Clients(logger, dialer.dial)
+	closer, _, err := registerGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil, clients)
+	require.NoError(t, err)
+	require.Len(t, registry.creators, 2)
+	require.Empty(t, dialer.dialed, "should not dial at startup")
+
+	stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+	stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+	stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+	stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+	stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+	stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+	stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+
+	_, err = registry.creators[faultTypes.AlphabetGameType](types.GameMetadata{GameType: faultTypes.AlphabetGameType, Proxy: registerGameAddr}, t.TempDir())
+	require.NoError(t, err)
+	require.Empty(t, dialer.dialed, "should not dial for alphabet games")
+
+	for i := 0; i < 2; i++ {
+		_, err = registry.creators[faultTypes.CannonGameType](types.GameMetadata{GameType: faultTypes.CannonGameType, Proxy: registerGameAddr}, t.TempDir())
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{cfg.CannonL2}, dialer.dialed, "should dial once for all cannon games")
+
+	connected := clients.client(cfg.CannonL2).client.(*stubL2Client)
+	closer.Close()
+	require.Equal(t, 1, connected.closes)
+}
+
+func TestRegisterCannonSharesOutputProviders(t *testing.T) {
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+	cfg := &config.Config{
+		TraceTypes:             []config.TraceType{config.TraceTypeCannon},
+		CannonL2:               "http://localhost:1",
+		CannonAbsolutePreState: writeRegisterPrestates(t).cannon,
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	m := &cacheMetrics{}
+	rollupClient := &countingRollupClient{outputRoot: eth.Bytes32{0x11}}
+	clients := newL2Clients(logger, (&stubL2Dialer{}).dial)
+	closer, _, err := registerGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		m, cfg, rollupClient, nil, gameData, caller, nil, clients)
+	require.NoError(t, err)
+	t.Cleanup(closer.Close)
+
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	const numGames = 5
+	var players []*GamePlayer
+	for i := 0; i < numGames; i++ {
+		addr := common.Address{0x50, byte(i)}
+		stubRpc.AddContract(addr, fdgAbi)
+		stubRpc.SetResponse(addr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+		stubRpc.SetResponse(addr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+		stubRpc.SetResponse(addr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+		stubRpc.SetResponse(addr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+		stubRpc.SetResponse(addr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+		stubRpc.SetResponse(addr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+		stubRpc.SetResponse(addr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash(rollupClient.outputRoot)})
+		player, err := registry.creators[faultTypes.CannonGameType](types.GameMetadata{GameType: faultTypes.CannonGameType, Proxy: addr}, t.TempDir())
+		require.NoError(t, err)
+		players = append(players, player.(*GamePlayer))
+	}
+	for _, player := range players {
+		genesisValidator := player.prestateValidators[1].(*PrestateValidator)
+		require.Same(t, players[0].prestateValidators[1].(*PrestateValidator).provider, genesisValidator.provider)
+		require.NoError(t, genesisValidator.Validate(context.Background()))
+	}
+	require.EqualValues(t, 1, rollupClient.requests.Load(), "should fetch prestate output root once")
+	require.Equal(t, 1, m.misses["output_providers"])
+	require.Equal(t, numGames-1, m.hits["output_providers"])
+}
+
+func TestRegisterRemote(t *testing.T) {
+	gameType := uint32(44)
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, gameType)
+	cfg := &config.Config{
+		TraceTypes:      []config.TraceType{config.TraceTypeRemote},
+		GameTypes:       []config.GameTypeConfig{{GameType: gameType, TraceType: config.TraceTypeRemote}},
+		CannonL2:        "http://localhost:1",
+		RemoteTraceURLs: map[uint32]string{gameType: "http://localhost:2"},
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	closer, _, err := registerGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil, newL2Clients(logger, (&stubL2Dialer{}).dial))
+	require.NoError(t, err)
+	t.Cleanup(closer.Close)
+
+	stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+	stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+	stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+	stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+	stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+	stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+	stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+	player, err := registry.creators[gameType](types.GameMetadata{GameType: gameType, Proxy: registerGameAddr}, t.TempDir())
+	require.NoError(t, err)
+
+	// The remote trace service doesn't provide its absolute pre-state so games must match the implementation's
+	vmValidator := player.(*GamePlayer).prestateValidators[0].(*PrestateValidator)
+	require.Equal(t, &implPrestateProvider{gameData: gameData, gameType: gameType}, vmValidator.provider)
+	commitment, err := vmValidator.provider.AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, writeRegisterPrestates(t).hash, commitment)
+	require.ErrorIs(t, vmValidator.Validate(context.Background()), types.ErrInvalidPrestate)
+}
+
+func TestRegisterGameTypesClosesResources(t *testing.T) {
+	prestates := writeRegisterPrestates(t)
+	state, err := os.ReadFile(prestates.cannon)
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(state)
+	}))
+	t.Cleanup(server.Close)
+	gameTypes := []config.GameTypeConfig{
+		{GameType: faultTypes.CannonGameType, TraceType: config.TraceTypeCannon},
+		{GameType: 42, TraceType: config.TraceTypeExternal},
+		{GameType: 44, TraceType: config.TraceTypeRemote},
+		{GameType: 45, TraceType: config.TraceTypeCartesiCompute},
+	}
+	// Each register path adds the resources it acquires, while the l2 clients are shared by every game type.
+	expected := []string{
+		"l2 clients",
+		"cannon pre-state download client",
+		"external trace providers",
+		"remote trace client",
+		"cartesi compute pre-image client",
+	}
+	register := func(t *testing.T, gameTypes []config.GameTypeConfig) (*resourceCloser, []string, map[string]int, error) {
+		registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+		stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+		for _, gameType := range gameTypes[1:] {
+			stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{gameType.GameType}, []interface{}{registerImplAddr})
+		}
+		cfg := &config.Config{
+			TraceTypes:             []config.TraceType{config.TraceTypeCannon},
+			GameTypes:              gameTypes,
+			Datadir:                t.TempDir(),
+			CannonL2:               "http://localhost:1",
+			CannonAbsolutePreState: server.URL + "/prestate.json",
+			ExternalCommand:        prestates.external,
+			RemoteTraceURLs:        map[uint32]string{44: "http://localhost:2"},
+			CartesiComputeServer:   "http://localhost:3",
+		}
+		logger := testlog.Logger(t, log.LevelInfo)
+		closer, _, err := registerGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+			metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil, newL2Clients(logger, (&stubL2Dialer{}).dial))
+		// Count the calls to each cleanup added while registering.
+		var names []string
+		closes := make(map[string]int)
+		for i, cleanup := range closer.cleanups {
+			cleanup := cleanup
+			names = append(names, cleanup.name)
+			closer.cleanups[i].fn = func() error {
+				closes[cleanup.name]++
+				return cleanup.fn()
+			}
+		}
+		return closer, names, closes, err
+	}
+
+	t.Run("Registered", func(t *testing.T) {
+		closer, names, closes, err := register(t, gameTypes)
+		require.NoError(t, err)
+		require.ElementsMatch(t, expected, names)
+		closer.Close()
+		closer.Close()
+		for _, name := range names {
+			require.Equal(t, 1, closes[name], "should close %v exactly once", name)
+		}
+	})
+
+	t.Run("RegistrationFailed", func(t *testing.T) {
+		failing := append(slices.Clone(gameTypes), config.GameTypeConfig{GameType: faultTypes.CannonGameType, TraceType: config.TraceTypeCannon})
+		closer, names, closes, err := register(t, failing)
+		require.ErrorIs(t, err, config.ErrDuplicateGameType)
+		require.ElementsMatch(t, expected, names)
+		closer.Close()
+		closer.Close()
+		for _, name := range names {
+			require.Equal(t, 1, closes[name], "should close %v exactly once", name)
+		}
+	})
+}
+
+func TestRegisterCannonFailsOverL2(t *testing.T) {
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+	cfg := &config.Config{
+		TraceTypes:             []config.TraceType{config.TraceTypeCannon},
+		CannonL2:               "http://l2-primary",
+		CannonL2Fallbacks:      []string{"http://l2-fallback"},
+		CannonAbsolutePreState: writeRegisterPrestates(t).cannon,
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	fallbackClient := &stubL2Client{}
+	dialer := &stubL2Dialer{
+		dialErrs: map[string]error{cfg.CannonL2: errors.New("primary down")},
+		clients:  map[string]*stubL2Client{"http://l2-fallback": fallbackClient},
+	}
+	clients := newL2Clients(logger, dialer.dial)
+	clients.strategy = retry.Fixed(0)
+	closer, _, err := registerGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil, clients)
+	require.NoError(t, err)
+
+	stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+	stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+	stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+	stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+	stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+	stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+	stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+	_, err = registry.creators[faultTypes.CannonGameType](types.GameMetadata{GameType: faultTypes.CannonGameType, Proxy: registerGameAddr}, t.TempDir())
+	require.NoError(t, err)
+	require.Contains(t, dialer.dialed, cfg.CannonL2)
+	require.Equal(t, "http://l2-fallback", dialer.dialed[len(dialer.dialed)-1])
+
+	closer.Close()
+	require.Equal(t, 1, fallbackClient.closes)
+}
+
+func TestRegisterGameTypesValidatesPrestate(t *testing.T) {
+	prestates := writeRegisterPrestates(t)
+	register := func(t *testing.T, implPrestate common.Hash, allowMismatch bool) (*stubRegistry, *testlog.CapturingHandler, error) {
+		registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+		stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+		stubRpc.ClearResponses("absolutePrestate")
+		stubRpc.SetResponse(registerImplAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{implPrestate})
+		cfg := &config.Config{
+			TraceTypes:             []config.TraceType{config.TraceTypeCannon},
+			CannonL2:               "http://localhost:1",
+			CannonAbsolutePreState: prestates.cannon,
+			AllowPrestateMismatch:  allowMismatch,
+		}
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+			metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+		if closer != nil {
+			t.Cleanup(closer)
+		}
+		return registry, logs, err
+	}
+	mismatchLog := testlog.NewMessageFilter("Registering game type with mismatched absolute prestate")
+
+	t.Run("Match", func(t *testing.T) {
+		registry, logs, err := register(t, prestates.hash, false)
+		require.NoError(t, err)
+		require.Contains(t, registry.creators, faultTypes.CannonGameType)
+		require.Nil(t, logs.FindLog(mismatchLog))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		implPrestate := common.Hash{0xba, 0xd0}
+		registry, _, err := register(t, implPrestate, false)
+		require.ErrorIs(t, err, types.ErrInvalidPrestate)
+		require.ErrorContains(t, err, prestates.hash.Hex())
+		require.ErrorContains(t, err, implPrestate.Hex())
+		require.Empty(t, registry.creators)
+	})
+
+	t.Run("AllowMismatch", func(t *testing.T) {
+		registry, logs, err := register(t, common.Hash{0xba, 0xd0}, true)
+		require.NoError(t, err)
+		require.Contains(t, registry.creators, faultTypes.CannonGameType)
+		warning := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), mismatchLog)
+		require.NotNil(t, warning)
+		require.ErrorIs(t, warning.AttrValue("err").(error), types.ErrInvalidPrestate)
+	})
+}
+
+func TestRegisterCannonDownloadsPrestate(t *testing.T) {
+	state, err := os.ReadFile("trace/cannon/test_data/state.json")
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(state)
+	}))
+	t.Cleanup(server.Close)
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(statePath, state, 0o644))
+	prestateHash, err := cannon.NewPrestateProvider(statePath).AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+
+	register := func(t *testing.T, onChainPrestate common.Hash) (*stubRegistry, error) {
+		registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+		stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+		stubRpc.ClearResponses("absolutePrestate")
+		stubRpc.SetResponse(registerImplAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{onChainPrestate})
+		cfg := &config.Config{
+			TraceTypes:             []config.TraceType{config.TraceTypeCannon},
+			Datadir:                t.TempDir(),
+			CannonL2:               "http://localhost:1",
+			CannonAbsolutePreState: server.URL + "/prestate.json",
+		}
+		logger := testlog.Logger(t, log.LevelInfo)
+		closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+			metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+		if closer != nil {
+			t.Cleanup(closer)
+		}
+		return registry, err
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		registry, err := register(t, prestateHash)
+		require.NoError(t, err)
+		require.Contains(t, registry.creators, faultTypes.CannonGameType)
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		_, err := register(t, common.Hash{0xba, 0xd0})
+		require.ErrorIs(t, err, cannon.ErrPrestateHashMismatch)
+	})
+}
+
+func TestRegisterCannonPrestateDownloadTimeout(t *testing.T) {
+	stop := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-stop:
+		}
+	}))
+	t.Cleanup(func() {
+		close(stop)
+		server.Close()
+	})
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	_, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+	cfg := &config.Config{
+		TraceTypes:             []config.TraceType{config.TraceTypeCannon},
+		Datadir:                t.TempDir(),
+		CannonL2:               "http://localhost:1",
+		CannonAbsolutePreState: server.URL + "/prestate.json",
+		CannonPreStateTimeout:  50 * time.Millisecond,
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+	if closer != nil {
+		t.Cleanup(closer)
+	}
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRegisterCannonDownloadsPrestatePerGameType(t *testing.T) {
+	dir := t.TempDir()
+	_, cannonHash := writeCannonPrestate(t, dir, "cannon.json", 0)
+	_, permissionedHash := writeCannonPrestate(t, dir, "permissioned.json", 4)
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	t.Cleanup(server.Close)
+	permissionedImplAddr := common.Address{0x1b}
+
+	register := func(t *testing.T, permissionedPrestate common.Hash) (*stubRegistry, error) {
+		registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+		stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+		fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+		require.NoError(t, err)
+		stubRpc.AddContract(permissionedImplAddr, fdgAbi)
+		stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{faultTypes.PermissionedGameType}, []interface{}{permissionedImplAddr})
+		stubRpc.SetResponse(permissionedImplAddr, "vm", batching.BlockLatest, nil, []interface{}{registerVMAddr})
+		stubRpc.ClearResponses("absolutePrestate")
+		stubRpc.SetResponse(registerImplAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{cannonHash})
+		stubRpc.SetResponse(permissionedImplAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{permissionedPrestate})
+		cfg := &config.Config{
+			TraceTypes: []config.TraceType{config.TraceTypeCannon, config.TraceTypePermissioned},
+			Datadir:    t.TempDir(),
+			CannonL2:   "http://localhost:1",
+			GameTypePreStates: map[uint32]string{
+				faultTypes.CannonGameType:       server.URL + "/cannon.json",
+				faultTypes.PermissionedGameType: server.URL + "/permissioned.json",
+			},
+		}
+		logger := testlog.Logger(t, log.LevelInfo)
+		closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+			metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+		if closer != nil {
+			t.Cleanup(closer)
+		}
+		return registry, err
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		registry, err := register(t, permissionedHash)
+		require.NoError(t, err)
+		require.Contains(t, registry.creators, faultTypes.CannonGameType)
+		require.Contains(t, registry.creators, faultTypes.PermissionedGameType)
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		_, err := register(t, cannonHash)
+		require.ErrorIs(t, err, cannon.ErrPrestateHashMismatch)
+	})
+}
+
+func TestRegisterCannonSelectsPrestateForGame(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, oldHash := writeCannonPrestate(t, dir, "old.json", 0)
+	newPath, newHash := writeCannonPrestate(t, dir, "new.json", 4)
+	oldGameAddr := registerGameAddr
+	newGameAddr := common.Address{0x5a}
+	unknownGameAddr := common.Address{0x6a}
+
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, gameData, caller := setupRegisterTest(t, faultTypes.CannonGameType)
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	for addr, prestate := range map[common.Address]common.Hash{oldGameAddr: oldHash, newGameAddr: newHash, unknownGameAddr: {0xaa}} {
+		stubRpc.AddContract(addr, fdgAbi)
+		stubRpc.SetResponse(addr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{prestate})
+		stubRpc.SetResponse(addr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+		stubRpc.SetResponse(addr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+		stubRpc.SetResponse(addr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+		stubRpc.SetResponse(addr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+		stubRpc.SetResponse(addr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+		stubRpc.SetResponse(addr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+	}
+	cfg := &config.Config{
+		TraceTypes:              []config.TraceType{config.TraceTypeCannon},
+		CannonL2:                "http://localhost:1",
+		CannonAbsolutePreStates: []string{dir},
+	}
+	m := &unmatchedPrestateMetrics{}
+	logger := testlog.Logger(t, log.LevelInfo)
+	closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		m, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+	require.NoError(t, err)
+	t.Cleanup(closer)
+	creator := registry.creators[faultTypes.CannonGameType]
+
+	for addr, expected := range map[common.Address]string{oldGameAddr: oldPath, newGameAddr: newPath} {
+		player, err := creator(types.GameMetadata{GameType: faultTypes.CannonGameType, Proxy: addr}, t.TempDir())
+		require.NoError(t, err)
+		vmValidator := player.(*GamePlayer).prestateValidators[0].(*PrestateValidator)
+		require.Equal(t, cannon.NewPrestateProvider(expected), vmValidator.provider)
+	}
+	require.Empty(t, m.unmatched)
+
+	_, err = creator(types.GameMetadata{GameType: faultTypes.CannonGameType, Proxy: unknownGameAddr}, t.TempDir())
+	require.ErrorIs(t, err, ErrNoMatchingPrestate)
+	require.Equal(t, []uint32{faultTypes.CannonGameType}, m.unmatched)
+}
+
+func TestRegisterCannonVM(t *testing.T) {
+	for _, traceType := range []config.TraceType{config.TraceTypeCannon, config.TraceTypePermissioned} {
+		traceType := traceType
+		t.Run(string(traceType), func(t *testing.T) {
+			errRecorded := errors.New("recorded accessor args")
+			var args *vmAccessorArgs
+			option := WithCannonVM(traceType, func(logger log.Logger, m metrics.Metricer, cfg *config.Config, l2Client cannon.L2HeaderSource, contract cannon.L1HeadSource, prestateProvider faultTypes.PrestateProvider, rollupClient outputs.OutputRootProvider, dir string, splitDepth faultTypes.Depth, prestateBlock uint64, poststateBlock uint64) (*trace.Accessor, error) {
+				args = &vmAccessorArgs{cfg, l2Client, contract, prestateProvider, rollupClient, dir, splitDepth, prestateBlock, poststateBlock}
+				return nil, errRecorded
+			})
+
+			gameType := faultTypes.CannonGameType
+			if traceType == config.TraceTypePermissioned {
+				gameType = faultTypes.PermissionedGameType
+			}
+			registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+			stubRpc, gameData, caller := setupRegisterTest(t, gameType)
+			prestates := writeRegisterPrestates(t)
+			cfg := &config.Config{
+				TraceTypes:             []config.TraceType{traceType},
+				CannonL2:               "http://localhost:1",
+				CannonAbsolutePreState: prestates.cannon,
+			}
+			logger := testlog.Logger(t, log.LevelInfo)
+			closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+				metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, nil, option)
+			require.NoError(t, err)
+			t.Cleanup(closer)
+
+			stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+			stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+			stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+			stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+			stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+			stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+			stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusInProgress})
+			stubRpc.SetResponse(registerGameAddr, "maxGameDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(50)})
+			dir := t.TempDir()
+			_, err = registry.creators[gameType](types.GameMetadata{GameType: gameType, Proxy: registerGameAddr}, dir)
+			require.ErrorIs(t, err, errRecorded)
+
+			require.NotNil(t, args, "should create the accessor with the injected factory")
+			require.Equal(t, prestates.cannon, args.cfg.CannonAbsolutePreState)
+			require.Equal(t, cfg.CannonL2, args.cfg.CannonL2)
+			require.NotNil(t, args.l2Client)
+			require.Equal(t, registerGameAddr, args.contract.(*contracts.FaultDisputeGameContract).Addr())
+			require.IsType(t, &memoizedPrestateProvider{}, args.prestateProvider)
+			require.IsType(t, &source.RestrictedOutputSource{}, args.rollupClient)
+			require.Equal(t, dir, args.dir)
+			require.Equal(t, faultTypes.Depth(30), args.splitDepth)
+			require.Equal(t, uint64(10), args.prestateBlock)
+			require.Equal(t, uint64(20), args.poststateBlock)
+
+			// The option only applies to the registration it was passed to.
+			args = nil
+			defaultRegistry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+			defaultCloser, _, err := RegisterGameTypes(defaultRegistry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+				metrics.NoopMetrics, cfg, &stubRollupClient{}, nil, gameData, caller, &stubL1HeaderSource{})
+			require.NoError(t, err)
+			t.Cleanup(defaultCloser)
+			stubRpc.SetResponse(registerGameAddr, "vm", batching.BlockLatest, nil, []interface{}{registerVMAddr})
+			_, err = defaultRegistry.creators[gameType](types.GameMetadata{GameType: gameType, Proxy: registerGameAddr}, t.TempDir())
+			require.ErrorIs(t, err, errL1HeaderNotFound, "should create the accessor and continue to the L1 head")
+			require.Nil(t, args, "should create the accessor with the default factory")
+		})
+	}
+}
+
+func TestRegisterGameTypesPlayerCreationTimeout(t *testing.T) {
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, _, _ := setupRegisterTest(t, faultTypes.AlphabetGameType)
+	rpc := &stallingRpc{AbiBasedRpc: stubRpc}
+	caller := batching.NewMultiCaller(rpc, batching.DefaultBatchSize)
+	gameFactory, err := contracts.NewDisputeGameFactoryContract(registerFactoryAddr, caller)
+	require.NoError(t, err)
+	gameData := contracts.NewGameDataCache(metrics.NoopMetrics, gameFactory, caller)
+	cfg := &config.Config{
+		TraceTypes:            []config.TraceType{config.TraceTypeAlphabet},
+		PlayerCreationTimeout: 100 * time.Millisecond,
+	}
+	m := &playerCreationMetrics{}
+	logger := testlog.Logger(t, log.LevelInfo)
+	closer, _, err := RegisterGameTypes(registry, context.Background(), clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		m, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+	require.NoError(t, err)
+	t.Cleanup(closer)
+	creator := registry.creators[faultTypes.AlphabetGameType]
+	game := types.GameMetadata{GameType: faultTypes.AlphabetGameType, Proxy: registerGameAddr}
+
+	rpc.stall.Store(true)
+	start := time.Now()
+	_, err = creator(game, t.TempDir())
+	require.ErrorIs(t, err, ErrPlayerCreationTimeout)
+	require.Less(t, time.Since(start), 5*time.Second, "should give up at the deadline")
+	require.Equal(t, []uint32{faultTypes.AlphabetGameType}, m.timeouts)
+	require.Equal(t, []uint32{faultTypes.AlphabetGameType}, m.failed)
+	require.Empty(t, m.created)
+
+	// The player is created when retried once the RPC responds again
+	rpc.stall.Store(false)
+	stubRpc.SetResponse(registerGameAddr, "genesisBlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(10)})
+	stubRpc.SetResponse(registerGameAddr, "l2BlockNumber", batching.BlockLatest, nil, []interface{}{big.NewInt(20)})
+	stubRpc.SetResponse(registerGameAddr, "splitDepth", batching.BlockLatest, nil, []interface{}{big.NewInt(30)})
+	stubRpc.SetResponse(registerGameAddr, "l1Head", batching.BlockLatest, nil, []interface{}{common.Hash{0xaa}})
+	stubRpc.SetResponse(registerGameAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{common.Hash{0xab}})
+	stubRpc.SetResponse(registerGameAddr, "genesisOutputRoot", batching.BlockLatest, nil, []interface{}{common.Hash{0xcd}})
+	stubRpc.SetResponse(registerGameAddr, "status", batching.BlockLatest, nil, []interface{}{types.GameStatusDefenderWon})
+	player, err := creator(game, t.TempDir())
+	require.NoError(t, err)
+	require.NotNil(t, player)
+	require.Len(t, m.timeouts, 1)
+	require.Len(t, m.failed, 1)
+	require.Equal(t, []uint32{faultTypes.AlphabetGameType}, m.created)
+}
+
+func TestRegisterGameTypesOracleLookupFailed(t *testing.T) {
+	registry := &stubRegistry{creators: make(map[uint32]scheduler.PlayerCreator)}
+	stubRpc, _, _ := setupRegisterTest(t, faultTypes.AlphabetGameType)
+	rpc := &stallingRpc{AbiBasedRpc: stubRpc}
+	rpc.stall.Store(true)
+	caller := batching.NewMultiCaller(rpc, batching.DefaultBatchSize)
+	gameFactory, err := contracts.NewDisputeGameFactoryContract(registerFactoryAddr, caller)
+	require.NoError(t, err)
+	gameData := contracts.NewGameDataCache(metrics.NoopMetrics, gameFactory, caller)
+	cfg := &config.Config{
+		TraceTypes: []config.TraceType{config.TraceTypeAlphabet},
+	}
+	// Cancel the context so the stalled oracle lookup fails immediately
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m := &oracleMetrics{}
+	logger := testlog.Logger(t, log.LevelInfo)
+	_, _, err = RegisterGameTypes(registry, ctx, clock.NewDeterministicClock(time.Unix(0, 0)), logger,
+		m, cfg, &stubRollupClient{}, nil, gameData, caller, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []uint32{faultTypes.AlphabetGameType}, m.lookupFailures)
+	require.Empty(t, registry.creators)
+}
+
+// stallingRpc blocks every request until its context is done while stall is set.
+type stallingRpc struct {
+	*batchingTest.AbiBasedRpc
+	stall atomic.Bool
+}
+
+func (r *stallingRpc) CallContext(ctx context.Context, out interface{}, method string, args ...interface{}) error {
+	if r.stall.Load() {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return r.AbiBasedRpc.CallContext(ctx, out, method, args...)
+}
+
+func (r *stallingRpc) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	if r.stall.Load() {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return r.AbiBasedRpc.BatchCallContext(ctx, b)
+}
+
+type playerCreationMetrics struct {
+	metrics.NoopMetricsImpl
+	created  []uint32
+	failed   []uint32
+	timeouts []uint32
+}
+
+func (m *playerCreationMetrics) RecordPlayerCreated(gameType uint32) {
+	m.created = append(m.created, gameType)
+}
+
+func (m *playerCreationMetrics) RecordPlayerCreationFailed(gameType uint32) {
+	m.failed = append(m.failed, gameType)
+}
+
+func (m *playerCreationMetrics) RecordPlayerCreationTimeout(gameType uint32) {
+	m.timeouts = append(m.timeouts, gameType)
+}
+
+type oracleMetrics struct {
+	metrics.NoopMetricsImpl
+	incompatible   []uint32
+	lookupFailures []uint32
+}
+
+func (m *oracleMetrics) RecordIncompatibleOracle(gameType uint32) {
+	m.incompatible = append(m.incompatible, gameType)
+}
+
+func (m *oracleMetrics) RecordOracleLookupFailed(gameType uint32) {
+	m.lookupFailures = append(m.lookupFailures, gameType)
+}
+
+// vmAccessorArgs are the arguments a VMAccessorFactory was called with.
+type vmAccessorArgs struct {
+	cfg              *config.Config
+	l2Client         cannon.L2HeaderSource
+	contract         cannon.L1HeadSource
+	prestateProvider faultTypes.PrestateProvider
+	rollupClient     outputs.OutputRootProvider
+	dir              string
+	splitDepth       faultTypes.Depth
+	prestateBlock    uint64
+	poststateBlock   uint64
+}
+
+func writeCannonPrestate(t *testing.T, dir string, name string, pc uint32) (string, common.Hash) {
+	data, err := json.Marshal(&mipsevm.State{Memory: mipsevm.NewMemory(), PC: pc, NextPC: pc + 4})
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	hash, err := cannon.NewPrestateProvider(path).AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+	return path, hash
+}
+
+type unmatchedPrestateMetrics struct {
+	metrics.NoopMetricsImpl
+	unmatched []uint32
+}
+
+func (m *unmatchedPrestateMetrics) RecordUnmatchedPrestate(gameType uint32) {
+	m.unmatched = append(m.unmatched, gameType)
+}
+
+type cacheMetrics struct {
+	metrics.NoopMetricsImpl
+	hits   map[string]int
+	misses map[string]int
+}
+
+func (m *cacheMetrics) CacheGet(label string, hit bool) {
+	if m.hits == nil {
+		m.hits = make(map[string]int)
+		m.misses = make(map[string]int)
+	}
+	if hit {
+		m.hits[label]++
+	} else {
+		m.misses[label]++
+	}
+}
+
+type countingRollupClient struct {
+	stubRollupClient
+	outputRoot eth.Bytes32
+	requests   atomic.Int32
+}
+
+func (c *countingRollupClient) OutputAtBlock(_ context.Context, _ uint64) (*eth.OutputResponse, error) {
+	c.requests.Add(1)
+	return &eth.OutputResponse{OutputRoot: c.outputRoot}, nil
+}
+
+type registeredGameTypesMetrics struct {
+	metrics.NoopMetricsImpl
+	registered []config.GameTypeConfig
+}
+
+func (m *registeredGameTypesMetrics) RecordGameTypeRegistered(gameType uint32, traceType string) {
+	m.registered = append(m.registered, config.GameTypeConfig{GameType: gameType, TraceType: config.TraceType(traceType)})
+}
+
+func setupRegisterTest(t *testing.T, gameType uint32) (*batchingTest.AbiBasedRpc, *contracts.GameDataCache, *batching.MultiCaller) {
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	vmAbi, err := bindings.MIPSMetaData.GetAbi()
+	require.NoError(t, err)
+	stubRpc := batchingTest.NewAbiBasedRpc(t, registerFactoryAddr, factoryAbi)
+	stubRpc.AddContract(registerImplAddr, fdgAbi)
+	stubRpc.AddContract(registerGameAddr, fdgAbi)
+	stubRpc.AddContract(registerVMAddr, vmAbi)
+	stubRpc.SetResponse(registerFactoryAddr, "gameImpls", batching.BlockLatest, []interface{}{gameType}, []interface{}{registerImplAddr})
+	stubRpc.SetResponse(registerImplAddr, "vm", batching.BlockLatest, nil, []interface{}{registerVMAddr})
+	stubRpc.SetResponse(registerVMAddr, "oracle", batching.BlockLatest, nil, []interface{}{registerOracleAddr})
+	stubRpc.SetResponse(registerImplAddr, "absolutePrestate", batching.BlockLatest, nil, []interface{}{writeRegisterPrestates(t).hash})
+	stubRegisterOracle(t, stubRpc, registerOracleAddr, registerOracleVersion, uint64(config.DefaultOracleParams.ChallengePeriod.Seconds()), config.DefaultOracleParams.MinBond)
+	caller := batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize)
+	gameFactory, err := contracts.NewDisputeGameFactoryContract(registerFactoryAddr, caller)
+	require.NoError(t, err)
+	return stubRpc, contracts.NewGameDataCache(metrics.NoopMetrics, gameFactory, caller), caller
+}
+
+// registerPrestates are local absolute pre-states for each VM with the same commitment as the game implementation.
+type registerPrestates struct {
+	hash     common.Hash
+	cannon   string
+	asterisc string
+	cartesi  string
+	// external is a command acting as an external trace provider that only returns the pre-state.
+	external string
+}
+
+// writeRegisterPrestates writes local absolute pre-states for each VM matching the absolute pre-state
+// setupRegisterTest stubs for the game implementation, so game types pass pre-state validation at registration.
+func writeRegisterPrestates(t *testing.T) registerPrestates {
+	dir := t.TempDir()
+	cannonPath, hash := writeCannonPrestate(t, dir, "cannon-prestate.json", 0)
+	asteriscState, err := json.Marshal(&asterisc.VMState{StateHash: hash})
+	require.NoError(t, err)
+	asteriscPath := filepath.Join(dir, "asterisc-prestate.json")
+	require.NoError(t, os.WriteFile(asteriscPath, asteriscState, 0o644))
+	cartesiDir := filepath.Join(dir, "cartesi-machine")
+	require.NoError(t, os.Mkdir(cartesiDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cartesiDir, "hash"), hash.Bytes(), 0o644))
+	externalPath := filepath.Join(dir, "external.sh")
+	script := fmt.Sprintf("#!/bin/sh\nread -r request\necho '{\"id\":1,\"result\":{\"commitment\":\"%v\"}}'\n", hash.Hex())
+	require.NoError(t, os.WriteFile(externalPath, []byte(script), 0o755))
+	return registerPrestates{
+		hash:     hash,
+		cannon:   cannonPath,
+		asterisc: asteriscPath,
+		cartesi:  cartesiDir,
+		external: externalPath,
+	}
+}
+
+// stubRegisterOracle stubs the parameters of the preimage oracle at addr.
+func stubRegisterOracle(t *testing.T, stubRpc *batchingTest.AbiBasedRpc, addr common.Address, version string, challengePeriod uint64, minBond uint64) {
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	semverAbi, err := bindings.ISemverMetaData.GetAbi()
+	require.NoError(t, err)
+	// The stub needs a single ABI per address so add the ISemver version method to a copy of the oracle ABI.
+	withVersion := *oracleAbi
+	withVersion.Methods = maps.Clone(oracleAbi.Methods)
+	withVersion.Methods["version"] = semverAbi.Methods["version"]
+	stubRpc.AddContract(addr, &withVersion)
+	stubRpc.SetResponse(addr, "version", batching.BlockLatest, nil, []interface{}{version})
+	stubRpc.SetResponse(addr, "challengePeriod", batching.BlockLatest, nil, []interface{}{new(big.Int).SetUint64(challengePeriod)})
+	stubRpc.SetResponse(addr, "MIN_BOND_SIZE", batching.BlockLatest, nil, []interface{}{new(big.Int).SetUint64(minBond)})
+}
+
+type stubRegistry struct {
+	creators     map[uint32]scheduler.PlayerCreator
+	oracles      map[uint32]keccakTypes.LargePreimageOracle
+	bondCreators map[uint32]claims.BondContractCreator
+}
+
+func (r *stubRegistry) RegisterGameType(gameType uint32, creator scheduler.PlayerCreator, oracle keccakTypes.LargePreimageOracle) {
+	if r.oracles == nil {
+		r.oracles = make(map[uint32]keccakTypes.LargePreimageOracle)
+	}
+	r.creators[gameType] = creator
+	r.oracles[gameType] = oracle
+}
+
+func (r *stubRegistry) RegisterBondContract(gameType uint32, creator claims.BondContractCreator) {
+	if r.bondCreators == nil {
+		r.bondCreators = make(map[uint32]claims.BondContractCreator)
+	}
+	r.bondCreators[gameType] = creator
+}
+
+type stubRollupClient struct{}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, _ uint64) (*eth.OutputResponse, error) {
+	return nil, errors.New("not found")
+}
+
+func (s *stubRollupClient) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{}, nil
+}
+
+func TestOutputProviderCache(t *testing.T) {
+	rollupClient := &countingRollupClient{outputRoot: eth.Bytes32{0x11}}
+	cache := newOutputProviderCache(metrics.NoopMetrics, source.NewOutputSourceCreator(testlog.Logger(t, log.LevelInfo), rollupClient))
+
+	t.Run("SharedConcurrently", func(t *testing.T) {
+		const numGames = 20
+		var wg sync.WaitGroup
+		providers := make([]faultTypes.PrestateProvider, numGames)
+		for i := 0; i < numGames; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, provider, err := cache.get(context.Background(), common.Hash{0xaa}, 10)
+				require.NoError(t, err)
+				commitment, err := provider.AbsolutePreStateCommitment(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, common.Hash(rollupClient.outputRoot), commitment)
+				providers[i] = provider
+			}()
+		}
+		wg.Wait()
+		for _, provider := range providers {
+			require.Same(t, providers[0], provider)
+		}
+		require.EqualValues(t, 1, rollupClient.requests.Load())
+	})
+
+	t.Run("DistinctKeys", func(t *testing.T) {
+		_, sharedL1Head, err := cache.get(context.Background(), common.Hash{0xaa}, 10)
+		require.NoError(t, err)
+		_, otherL1Head, err := cache.get(context.Background(), common.Hash{0xbb}, 10)
+		require.NoError(t, err)
+		_, otherBlock, err := cache.get(context.Background(), common.Hash{0xaa}, 11)
+		require.NoError(t, err)
+		require.NotSame(t, sharedL1Head, otherL1Head)
+		require.NotSame(t, sharedL1Head, otherBlock)
+	})
+}
+
+var errL1HeaderNotFound = errors.New("l1 header not found")
+
+type stubL1HeaderSource struct{}
+
+func (s *stubL1HeaderSource) HeaderByHash(_ context.Context, _ common.Hash) (*gethTypes.Header, error) {
+	return nil, errL1HeaderNotFound
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -16,11 +18,13 @@ type SyncStatusProvider interface {
 
 type syncStatusValidator struct {
 	statusProvider SyncStatusProvider
+	thresholds     config.SyncThresholds
 }
 
-func newSyncStatusValidator(statusProvider SyncStatusProvider) *syncStatusValidator {
+func newSyncStatusValidator(statusProvider SyncStatusProvider, thresholds config.SyncThresholds) *syncStatusValidator {
 	return &syncStatusValidator{
 		statusProvider: statusProvider,
+		thresholds:     thresholds,
 	}
 }
 
@@ -29,8 +33,33 @@ func (s *syncStatusValidator) ValidateNodeSynced(ctx context.Context, gameL1Head
 	if err != nil {
 		return fmt.Errorf("failed to retrieve local node sync status: %w", err)
 	}
-	if syncStatus.CurrentL1.Number <= gameL1Head.Number {
-		return fmt.Errorf("%w require L1 block above %v but at %v", ErrNotInSync, gameL1Head.Number, syncStatus.CurrentL1.Number)
+	reference, number, timestamp := s.reference(syncStatus)
+	if number <= gameL1Head.Number {
+		// The node must be past the game's L1 head so lag is measured from the block after it.
+		lag := gameL1Head.Number + 1 - number
+		if lag > s.thresholds.MaxLagBlocks {
+			return fmt.Errorf("%w require %v L1 block above %v but at %v, lag of %v blocks exceeds max %v",
+				ErrNotInSync, reference, gameL1Head.Number, number, lag, s.thresholds.MaxLagBlocks)
+		}
+	}
+	if s.thresholds.MaxLagTime != 0 && syncStatus.HeadL1.Time > timestamp {
+		lag := time.Duration(syncStatus.HeadL1.Time-timestamp) * time.Second
+		if lag > s.thresholds.MaxLagTime {
+			return fmt.Errorf("%w %v L1 block %v is %v behind L1 head %v, exceeds max %v",
+				ErrNotInSync, reference, number, lag, syncStatus.HeadL1.Number, s.thresholds.MaxLagTime)
+		}
 	}
 	return nil
 }
+
+// reference returns the name, L1 block number and timestamp of the node progress to compare against.
+func (s *syncStatusValidator) reference(syncStatus *eth.SyncStatus) (config.SyncReference, uint64, uint64) {
+	switch s.thresholds.Reference {
+	case config.SyncReferenceSafe:
+		return config.SyncReferenceSafe, syncStatus.SafeL2.L1Origin.Number, syncStatus.SafeL2.Time
+	case config.SyncReferenceFinalized:
+		return config.SyncReferenceFinalized, syncStatus.FinalizedL2.L1Origin.Number, syncStatus.FinalizedL2.Time
+	default:
+		return config.SyncReferenceCurrentL1, syncStatus.CurrentL1.Number, syncStatus.CurrentL1.Time
+	}
+}
@@ -3,8 +3,11 @@ package fault
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/stretchr/testify/require"
 )
@@ -66,13 +69,110 @@ func TestSyncStatusProvider(t *testing.T) {
 				status: test.syncStatus,
 				err:    test.statusReqErr,
 			}
-			validator := newSyncStatusValidator(provider)
+			validator := newSyncStatusValidator(provider, config.DefaultSyncThresholds)
 			err := validator.ValidateNodeSynced(context.Background(), test.gameL1Head)
 			require.ErrorIs(t, err, test.expected)
 		})
 	}
 }
 
+func TestSyncStatusMaxLagBlocks(t *testing.T) {
+	gameL1Head := eth.BlockID{Number: 100}
+	syncStatus := func(number uint64) *eth.SyncStatus {
+		return &eth.SyncStatus{
+			CurrentL1:   eth.L1BlockRef{Number: number},
+			SafeL2:      eth.L2BlockRef{L1Origin: eth.BlockID{Number: number}},
+			FinalizedL2: eth.L2BlockRef{L1Origin: eth.BlockID{Number: number}},
+		}
+	}
+	for _, reference := range config.SyncReferences {
+		reference := reference
+		for _, maxLag := range []uint64{0, 1, 5} {
+			maxLag := maxLag
+			// Sweep from well behind the threshold to past the game's L1 head.
+			for lag := uint64(0); lag <= maxLag+2; lag++ {
+				lag := lag
+				number := gameL1Head.Number + 1 - lag
+				t.Run(fmt.Sprintf("%v-MaxLag%v-Lag%v", reference, maxLag, lag), func(t *testing.T) {
+					provider := &stubSyncStatusProvider{status: syncStatus(number)}
+					validator := newSyncStatusValidator(provider, config.SyncThresholds{Reference: reference, MaxLagBlocks: maxLag})
+					err := validator.ValidateNodeSynced(context.Background(), gameL1Head)
+					if lag > maxLag {
+						require.ErrorIs(t, err, ErrNotInSync)
+						require.ErrorContains(t, err, fmt.Sprintf("lag of %v blocks", lag))
+					} else {
+						require.NoError(t, err)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSyncStatusReference(t *testing.T) {
+	gameL1Head := eth.BlockID{Number: 100}
+	syncStatus := &eth.SyncStatus{
+		CurrentL1:   eth.L1BlockRef{Number: 105},
+		SafeL2:      eth.L2BlockRef{L1Origin: eth.BlockID{Number: 101}},
+		FinalizedL2: eth.L2BlockRef{L1Origin: eth.BlockID{Number: 90}},
+	}
+	tests := []struct {
+		reference config.SyncReference
+		expected  error
+	}{
+		{config.SyncReferenceCurrentL1, nil},
+		{config.SyncReferenceSafe, nil},
+		{config.SyncReferenceFinalized, ErrNotInSync},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(string(test.reference), func(t *testing.T) {
+			provider := &stubSyncStatusProvider{status: syncStatus}
+			validator := newSyncStatusValidator(provider, config.SyncThresholds{Reference: test.reference})
+			err := validator.ValidateNodeSynced(context.Background(), gameL1Head)
+			require.ErrorIs(t, err, test.expected)
+		})
+	}
+}
+
+func TestSyncStatusMaxLagTime(t *testing.T) {
+	gameL1Head := eth.BlockID{Number: 100}
+	headTime := uint64(10_000)
+	maxLag := time.Minute
+	for _, lag := range []time.Duration{0, 59 * time.Second, time.Minute, 61 * time.Second, time.Hour} {
+		lag := lag
+		for _, reference := range config.SyncReferences {
+			reference := reference
+			t.Run(fmt.Sprintf("%v-%v", reference, lag), func(t *testing.T) {
+				refTime := headTime - uint64(lag.Seconds())
+				provider := &stubSyncStatusProvider{status: &eth.SyncStatus{
+					HeadL1:      eth.L1BlockRef{Number: 200, Time: headTime},
+					CurrentL1:   eth.L1BlockRef{Number: 150, Time: refTime},
+					SafeL2:      eth.L2BlockRef{L1Origin: eth.BlockID{Number: 150}, Time: refTime},
+					FinalizedL2: eth.L2BlockRef{L1Origin: eth.BlockID{Number: 150}, Time: refTime},
+				}}
+				validator := newSyncStatusValidator(provider, config.SyncThresholds{Reference: reference, MaxLagTime: maxLag})
+				err := validator.ValidateNodeSynced(context.Background(), gameL1Head)
+				if lag > maxLag {
+					require.ErrorIs(t, err, ErrNotInSync)
+					require.ErrorContains(t, err, lag.String())
+				} else {
+					require.NoError(t, err)
+				}
+			})
+		}
+	}
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		provider := &stubSyncStatusProvider{status: &eth.SyncStatus{
+			HeadL1:    eth.L1BlockRef{Number: 200, Time: headTime},
+			CurrentL1: eth.L1BlockRef{Number: 150, Time: 0},
+		}}
+		validator := newSyncStatusValidator(provider, config.DefaultSyncThresholds)
+		require.NoError(t, validator.ValidateNodeSynced(context.Background(), gameL1Head))
+	})
+}
+
 type stubSyncStatusProvider struct {
 	status *eth.SyncStatus
 	err    error
@@ -0,0 +1,28 @@
+package asterisc
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsolutePreStateCommitment(t *testing.T) {
+	t.Run("StateUnavailable", func(t *testing.T) {
+		provider := NewPrestateProvider("/dir/does/not/exist/state.json")
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("ExpectedAbsolutePreState", func(t *testing.T) {
+		stateHash := common.Hash{mipsevm.VMStatusUnfinished, 0xaa}
+		path := writeState(t, t.TempDir(), VMState{StateHash: stateHash})
+		provider := NewPrestateProvider(path)
+		actual, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, stateHash, actual)
+	})
+}
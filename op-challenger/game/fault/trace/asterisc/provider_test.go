@@ -0,0 +1,148 @@
+package asterisc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("ExistingProof", func(t *testing.T) {
+		provider, generator := setupProvider(t)
+		expected := common.Hash{0xaa}
+		writeProof(t, provider.dir, 0, &cannon.ProofData{ClaimValue: expected})
+		value, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, common.Big0))
+		require.NoError(t, err)
+		require.Equal(t, expected, value)
+		require.Empty(t, generator.generated)
+	})
+
+	t.Run("ErrorsTraceIndexOutOfBounds", func(t *testing.T) {
+		provider, generator := setupProvider(t)
+		largePosition := types.NewPosition(provider.gameDepth, new(big.Int).Mul(new(big.Int).SetUint64(math.MaxUint64), big.NewInt(2)))
+		_, err := provider.Get(context.Background(), largePosition)
+		require.ErrorContains(t, err, "trace index out of bounds")
+		require.Empty(t, generator.generated)
+	})
+
+	t.Run("GeneratesMissingProof", func(t *testing.T) {
+		provider, generator := setupProvider(t)
+		generator.proof = &cannon.ProofData{ClaimValue: common.Hash{0xbb}}
+		value, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7)))
+		require.NoError(t, err)
+		require.Equal(t, []int{7}, generator.generated)
+		require.Equal(t, common.Hash{0xbb}, value)
+	})
+
+	t.Run("ProofAfterEndOfTrace", func(t *testing.T) {
+		provider, generator := setupProvider(t)
+		generator.finalState = &VMState{
+			Step:      10,
+			Exited:    true,
+			Witness:   []byte{0xcc},
+			StateHash: common.Hash{mipsevm.VMStatusValid, 0xcc},
+		}
+		value, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7000)))
+		require.NoError(t, err)
+		require.Contains(t, generator.generated, 7000, "should have tried to generate the proof")
+		require.Equal(t, generator.finalState.StateHash, value)
+		require.Equal(t, uint64(9), provider.lastStep)
+	})
+
+	t.Run("MissingPostHash", func(t *testing.T) {
+		provider, _ := setupProvider(t)
+		writeProof(t, provider.dir, 1, &cannon.ProofData{StateData: []byte{0x01}})
+		_, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(1)))
+		require.ErrorContains(t, err, "missing post hash")
+	})
+}
+
+func TestGetStepData(t *testing.T) {
+	t.Run("ExistingProof", func(t *testing.T) {
+		provider, generator := setupProvider(t)
+		writeProof(t, provider.dir, 0, &cannon.ProofData{
+			ClaimValue: common.Hash{0xaa},
+			StateData:  []byte{0xbb},
+			ProofData:  []byte{0xcc},
+		})
+		value, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(provider.gameDepth, common.Big0))
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xbb}, value)
+		require.Equal(t, []byte{0xcc}, proof)
+		require.Nil(t, data)
+		require.Empty(t, generator.generated)
+	})
+
+	t.Run("ProofAfterEndOfTrace", func(t *testing.T) {
+		provider, generator := setupProvider(t)
+		generator.finalState = &VMState{
+			Step:      10,
+			Exited:    true,
+			Witness:   []byte{0xdd},
+			StateHash: common.Hash{mipsevm.VMStatusValid, 0xdd},
+		}
+		value, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7000)))
+		require.NoError(t, err)
+		require.Contains(t, generator.generated, 7000, "should have tried to generate the proof")
+		require.Equal(t, []byte{0xdd}, value)
+		require.Empty(t, proof)
+		require.Nil(t, data)
+	})
+
+	t.Run("MissingStateData", func(t *testing.T) {
+		provider, _ := setupProvider(t)
+		writeProof(t, provider.dir, 1, &cannon.ProofData{ClaimValue: common.Hash{0xaa}})
+		_, _, _, err := provider.GetStepData(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(1)))
+		require.ErrorContains(t, err, "missing state data")
+	})
+}
+
+func setupProvider(t *testing.T) (*AsteriscTraceProvider, *stubGenerator) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, proofsDir), 0o777))
+	generator := &stubGenerator{}
+	return &AsteriscTraceProvider{
+		logger:         testlog.Logger(t, log.LevelInfo),
+		dir:            dir,
+		generator:      generator,
+		prestate:       filepath.Join(dir, "state.json"),
+		gameDepth:      63,
+		preimageLoader: cannon.NewPreimageLoader(kvstore.NewMemKV().Get),
+	}, generator
+}
+
+func writeProof(t *testing.T, dir string, i uint64, proof *cannon.ProofData) {
+	require.NoError(t, ioutil.WriteCompressedJson(filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json.gz", i)), proof))
+}
+
+type stubGenerator struct {
+	generated  []int // Using int makes assertions easier
+	finalState *VMState
+	proof      *cannon.ProofData
+}
+
+func (e *stubGenerator) GenerateProof(ctx context.Context, dir string, i uint64) error {
+	e.generated = append(e.generated, int(i))
+	if e.finalState != nil && e.finalState.Step <= i {
+		// Requesting a trace index past the end of the trace
+		return ioutil.WriteCompressedJson(filepath.Join(dir, finalState), e.finalState)
+	}
+	if e.proof != nil {
+		return ioutil.WriteCompressedJson(filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json.gz", i)), e.proof)
+	}
+	return nil
+}
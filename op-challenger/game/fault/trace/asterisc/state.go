@@ -0,0 +1,44 @@
+package asterisc
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// VMState is the subset of the asterisc VM state needed by the challenger.
+// The state hash is computed by asterisc itself and included in its state output.
+type VMState struct {
+	PC        uint64        `json:"pc"`
+	Exited    bool          `json:"exited"`
+	Step      uint64        `json:"step"`
+	Witness   hexutil.Bytes `json:"witness"`
+	StateHash common.Hash   `json:"stateHash"`
+}
+
+// validateStateHash checks the VM status encoded in the first byte of the state hash
+// is consistent with the exited flag.
+func (state *VMState) validateStateHash() error {
+	exitCode := state.StateHash[0]
+	if exitCode >= 4 {
+		return fmt.Errorf("invalid stateHash: unknown exitCode %d", exitCode)
+	}
+	if (state.Exited && exitCode == mipsevm.VMStatusUnfinished) || (!state.Exited && exitCode != mipsevm.VMStatusUnfinished) {
+		return fmt.Errorf("invalid stateHash: invalid exitCode %d", exitCode)
+	}
+	return nil
+}
+
+func parseState(path string) (*VMState, error) {
+	state, err := cannon.ParseState[VMState](path, "asterisc VM state")
+	if err != nil {
+		return nil, err
+	}
+	if err := state.validateStateHash(); err != nil {
+		return nil, fmt.Errorf("invalid asterisc VM state (%v): %w", path, err)
+	}
+	return state, nil
+}
@@ -0,0 +1,60 @@
+package asterisc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadState(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		expected := VMState{
+			PC:        4,
+			Step:      12,
+			Witness:   []byte{0xaa, 0xbb},
+			StateHash: common.Hash{mipsevm.VMStatusUnfinished, 0x01},
+		}
+		path := writeState(t, t.TempDir(), expected)
+		state, err := parseState(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, *state)
+	})
+
+	t.Run("ExitedWithUnfinishedStatus", func(t *testing.T) {
+		path := writeState(t, t.TempDir(), VMState{Exited: true, StateHash: common.Hash{mipsevm.VMStatusUnfinished}})
+		_, err := parseState(path)
+		require.ErrorContains(t, err, "invalid exitCode 3")
+	})
+
+	t.Run("NotExitedWithFinalStatus", func(t *testing.T) {
+		path := writeState(t, t.TempDir(), VMState{StateHash: common.Hash{mipsevm.VMStatusValid}})
+		_, err := parseState(path)
+		require.ErrorContains(t, err, "invalid exitCode 0")
+	})
+
+	t.Run("UnknownStatus", func(t *testing.T) {
+		path := writeState(t, t.TempDir(), VMState{Exited: true, StateHash: common.Hash{0x04}})
+		_, err := parseState(path)
+		require.ErrorContains(t, err, "unknown exitCode 4")
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+		_, err := parseState(path)
+		require.ErrorContains(t, err, "invalid asterisc VM state")
+	})
+}
+
+func writeState(t *testing.T, dir string, state VMState) string {
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "state.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
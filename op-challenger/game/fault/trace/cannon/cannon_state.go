@@ -9,15 +9,21 @@ import (
 )
 
 func parseState(path string) (*mipsevm.State, error) {
+	return ParseState[mipsevm.State](path, "mipsevm state")
+}
+
+// ParseState reads a VM state of type T from the, optionally compressed, JSON file at path.
+// The kind of state is included in decoding errors.
+func ParseState[T any](path string, kind string) (*T, error) {
 	file, err := ioutil.OpenDecompressed(path)
 	if err != nil {
 		return nil, fmt.Errorf("cannot open state file (%v): %w", path, err)
 	}
 	defer file.Close()
-	var state mipsevm.State
+	var state T
 	err = json.NewDecoder(file).Decode(&state)
 	if err != nil {
-		return nil, fmt.Errorf("invalid mipsevm state (%v): %w", path, err)
+		return nil, fmt.Errorf("invalid %v (%v): %w", kind, path, err)
 	}
 	return &state, nil
 }
@@ -27,19 +27,22 @@ var (
 	ErrInvalidBlobKeyPreimage = errors.New("invalid blob key preimage")
 )
 
-type preimageSource func(key common.Hash) ([]byte, error)
+// PreimageSource returns the preimage of the key.
+type PreimageSource func(key common.Hash) ([]byte, error)
 
-type preimageLoader struct {
-	getPreimage preimageSource
+// PreimageLoader loads the preimage oracle data of a proof, as the oracle expects it.
+// It is shared by the VMs using the same proof format, like asterisc.
+type PreimageLoader struct {
+	getPreimage PreimageSource
 }
 
-func newPreimageLoader(getPreimage preimageSource) *preimageLoader {
-	return &preimageLoader{
+func NewPreimageLoader(getPreimage PreimageSource) *PreimageLoader {
+	return &PreimageLoader{
 		getPreimage: getPreimage,
 	}
 }
 
-func (l *preimageLoader) LoadPreimage(proof *proofData) (*types.PreimageOracleData, error) {
+func (l *PreimageLoader) LoadPreimage(proof *ProofData) (*types.PreimageOracleData, error) {
 	if len(proof.OracleKey) == 0 {
 		return nil, nil
 	}
@@ -53,7 +56,7 @@ func (l *preimageLoader) LoadPreimage(proof *proofData) (*types.PreimageOracleDa
 	}
 }
 
-func (l *preimageLoader) loadBlobPreimage(proof *proofData) (*types.PreimageOracleData, error) {
+func (l *PreimageLoader) loadBlobPreimage(proof *ProofData) (*types.PreimageOracleData, error) {
 	// The key for a blob field element is a keccak hash of commitment++fieldElementIndex.
 	// First retrieve the preimage of the key as a keccak hash so we have the commitment and required field element
 	inputsKey := preimage.Keccak256Key(proof.OracleKey).PreimageKey()
@@ -102,7 +105,7 @@ func (l *preimageLoader) loadBlobPreimage(proof *proofData) (*types.PreimageOrac
 	return types.NewPreimageOracleBlobData(proof.OracleKey, claimWithLength, proof.OracleOffset, requiredFieldElement, commitment, kzgProof[:]), nil
 }
 
-func (l *preimageLoader) loadKZGPointEvaluationPreimage(proof *proofData) (*types.PreimageOracleData, error) {
+func (l *PreimageLoader) loadKZGPointEvaluationPreimage(proof *ProofData) (*types.PreimageOracleData, error) {
 	inputKey := preimage.Keccak256Key(proof.OracleKey).PreimageKey()
 	input, err := l.getPreimage(inputKey)
 	if err != nil {
@@ -20,15 +20,15 @@ import (
 )
 
 func TestPreimageLoader_NoPreimage(t *testing.T) {
-	loader := newPreimageLoader(kvstore.NewMemKV().Get)
-	actual, err := loader.LoadPreimage(&proofData{})
+	loader := NewPreimageLoader(kvstore.NewMemKV().Get)
+	actual, err := loader.LoadPreimage(&ProofData{})
 	require.NoError(t, err)
 	require.Nil(t, actual)
 }
 
 func TestPreimageLoader_LocalPreimage(t *testing.T) {
-	loader := newPreimageLoader(kvstore.NewMemKV().Get)
-	proof := &proofData{
+	loader := NewPreimageLoader(kvstore.NewMemKV().Get)
+	proof := &ProofData{
 		OracleKey:    common.Hash{byte(preimage.LocalKeyType), 0xaa, 0xbb}.Bytes(),
 		OracleValue:  nil,
 		OracleOffset: 4,
@@ -48,8 +48,8 @@ func TestPreimageLoader_SimpleTypes(t *testing.T) {
 	for _, keyType := range tests {
 		keyType := keyType
 		t.Run(fmt.Sprintf("type-%v", keyType), func(t *testing.T) {
-			loader := newPreimageLoader(kvstore.NewMemKV().Get)
-			proof := &proofData{
+			loader := NewPreimageLoader(kvstore.NewMemKV().Get)
+			proof := &ProofData{
 				OracleKey:    common.Hash{byte(keyType), 0xaa, 0xbb}.Bytes(),
 				OracleValue:  []byte{1, 2, 3, 4, 5, 6},
 				OracleOffset: 3,
@@ -82,7 +82,7 @@ func TestPreimageLoader_BlobPreimage(t *testing.T) {
 	binary.BigEndian.PutUint64(keyBuf[72:], fieldIndex)
 	key := preimage.BlobKey(crypto.Keccak256Hash(keyBuf)).PreimageKey()
 
-	proof := &proofData{
+	proof := &ProofData{
 		OracleKey:    key[:],
 		OracleValue:  elementDataWithLengthPrefix,
 		OracleOffset: 4,
@@ -90,8 +90,8 @@ func TestPreimageLoader_BlobPreimage(t *testing.T) {
 
 	t.Run("NoKeyPreimage", func(t *testing.T) {
 		kv := kvstore.NewMemKV()
-		loader := newPreimageLoader(kv.Get)
-		proof := &proofData{
+		loader := NewPreimageLoader(kv.Get)
+		proof := &ProofData{
 			OracleKey:    common.Hash{byte(preimage.BlobKeyType), 0xaf}.Bytes(),
 			OracleValue:  proof.OracleValue,
 			OracleOffset: proof.OracleOffset,
@@ -102,8 +102,8 @@ func TestPreimageLoader_BlobPreimage(t *testing.T) {
 
 	t.Run("InvalidKeyPreimage", func(t *testing.T) {
 		kv := kvstore.NewMemKV()
-		loader := newPreimageLoader(kv.Get)
-		proof := &proofData{
+		loader := NewPreimageLoader(kv.Get)
+		proof := &ProofData{
 			OracleKey:    common.Hash{byte(preimage.BlobKeyType), 0xad}.Bytes(),
 			OracleValue:  proof.OracleValue,
 			OracleOffset: proof.OracleOffset,
@@ -115,8 +115,8 @@ func TestPreimageLoader_BlobPreimage(t *testing.T) {
 
 	t.Run("MissingBlobs", func(t *testing.T) {
 		kv := kvstore.NewMemKV()
-		loader := newPreimageLoader(kv.Get)
-		proof := &proofData{
+		loader := NewPreimageLoader(kv.Get)
+		proof := &ProofData{
 			OracleKey:    common.Hash{byte(preimage.BlobKeyType), 0xae}.Bytes(),
 			OracleValue:  proof.OracleValue,
 			OracleOffset: proof.OracleOffset,
@@ -128,7 +128,7 @@ func TestPreimageLoader_BlobPreimage(t *testing.T) {
 
 	t.Run("Valid", func(t *testing.T) {
 		kv := kvstore.NewMemKV()
-		loader := newPreimageLoader(kv.Get)
+		loader := NewPreimageLoader(kv.Get)
 		storeBlob(t, kv, gokzg4844.KZGCommitment(commitment), blob)
 		actual, err := loader.LoadPreimage(proof)
 		require.NoError(t, err)
@@ -155,19 +155,19 @@ func TestPreimageLoader_BlobPreimage(t *testing.T) {
 func TestPreimageLoader_KZGPointEvaluationPreimage(t *testing.T) {
 	input := []byte("test input")
 	key := preimage.KZGPointEvaluationKey(crypto.Keccak256Hash(input)).PreimageKey()
-	proof := &proofData{
+	proof := &ProofData{
 		OracleKey: key[:],
 	}
 
 	t.Run("NoInputPreimage", func(t *testing.T) {
 		kv := kvstore.NewMemKV()
-		loader := newPreimageLoader(kv.Get)
+		loader := NewPreimageLoader(kv.Get)
 		_, err := loader.LoadPreimage(proof)
 		require.ErrorIs(t, err, kvstore.ErrNotFound)
 	})
 	t.Run("Valid", func(t *testing.T) {
 		kv := kvstore.NewMemKV()
-		loader := newPreimageLoader(kv.Get)
+		loader := NewPreimageLoader(kv.Get)
 		require.NoError(t, kv.Put(preimage.Keccak256Key(proof.OracleKey).PreimageKey(), input))
 		actual, err := loader.LoadPreimage(proof)
 		require.NoError(t, err)
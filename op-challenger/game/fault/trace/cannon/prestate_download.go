@@ -0,0 +1,132 @@
+package cannon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrPrestateHashMismatch = errors.New("absolute pre-state hash does not match")
+
+// IsPrestateURL returns true if prestate refers to an http(s) URL rather than a local file.
+func IsPrestateURL(prestate string) bool {
+	u, err := url.Parse(prestate)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// PrestateDownloader downloads absolute pre-states published at a URL into a local cache directory.
+// Cached files are verified against the expected absolute pre-state hash every time they are used.
+type PrestateDownloader struct {
+	logger   log.Logger
+	client   *http.Client
+	cacheDir string
+}
+
+func NewPrestateDownloader(logger log.Logger, client *http.Client, cacheDir string) *PrestateDownloader {
+	return &PrestateDownloader{
+		logger:   logger,
+		client:   client,
+		cacheDir: cacheDir,
+	}
+}
+
+// Fetch returns the path to a local copy of the pre-state at prestateURL, downloading it if it is not already cached.
+// The pre-state is only returned if its hash matches expected.
+func (d *PrestateDownloader) Fetch(ctx context.Context, prestateURL string, expected common.Hash) (string, error) {
+	path, err := d.cachePath(prestateURL)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil {
+		err := verifyPrestate(ctx, path, expected)
+		if err == nil {
+			d.logger.Debug("Using cached absolute pre-state", "url", prestateURL, "path", path)
+			return path, nil
+		}
+		d.logger.Warn("Cached absolute pre-state is invalid, downloading again", "url", prestateURL, "path", path, "err", err)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to check cached pre-state %v: %w", path, err)
+	}
+
+	if err := os.MkdirAll(d.cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create pre-state cache dir %v: %w", d.cacheDir, err)
+	}
+	tmpPath, err := d.download(ctx, prestateURL, filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpPath) // No-op once the file has been renamed
+	if err := verifyPrestate(ctx, tmpPath, expected); err != nil {
+		return "", fmt.Errorf("invalid pre-state downloaded from %v: %w", prestateURL, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", fmt.Errorf("failed to move downloaded pre-state to %v: %w", path, err)
+	}
+	d.logger.Info("Downloaded absolute pre-state", "url", prestateURL, "path", path)
+	return path, nil
+}
+
+// cachePath returns the path prestateURL is cached at. The gzip extension is kept so the file is decompressed when read.
+func (d *PrestateDownloader) cachePath(prestateURL string) (string, error) {
+	u, err := url.Parse(prestateURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid pre-state url %v: %w", prestateURL, err)
+	}
+	name := crypto.Keccak256Hash([]byte(prestateURL)).Hex() + ".json"
+	if strings.HasSuffix(u.Path, ".gz") {
+		name += ".gz"
+	}
+	return filepath.Join(d.cacheDir, name), nil
+}
+
+// download writes the content at prestateURL to a temporary file in the cache dir and returns its path.
+// The temporary file keeps the suffix of name so it can be verified before being renamed into place.
+func (d *PrestateDownloader) download(ctx context.Context, prestateURL string, name string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, prestateURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pre-state request: %w", err)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download pre-state from %v: %w", prestateURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download pre-state from %v: status %v", prestateURL, resp.Status)
+	}
+	file, err := os.CreateTemp(d.cacheDir, "download-*-"+name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file for pre-state: %w", err)
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to download pre-state from %v: %w", prestateURL, err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write pre-state: %w", err)
+	}
+	return file.Name(), nil
+}
+
+func verifyPrestate(ctx context.Context, path string, expected common.Hash) error {
+	actual, err := NewPrestateProvider(path).AbsolutePreStateCommitment(ctx)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: expected %v but was %v", ErrPrestateHashMismatch, expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package cannon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPrestateURL(t *testing.T) {
+	require.True(t, IsPrestateURL("http://example.com/prestate.json"))
+	require.True(t, IsPrestateURL("https://example.com/prestate.json.gz"))
+	require.False(t, IsPrestateURL("/prestates/prestate.json"))
+	require.False(t, IsPrestateURL("prestate.json"))
+	require.False(t, IsPrestateURL("file:///prestates/prestate.json"))
+}
+
+func TestPrestateDownloader(t *testing.T) {
+	validState, err := testData.ReadFile("test_data/state.json")
+	require.NoError(t, err)
+	invalidState, err := testData.ReadFile("test_data/invalid.json")
+	require.NoError(t, err)
+	expected := expectedPrestateHash(t, validState)
+
+	setup := func(t *testing.T, content []byte) (*PrestateDownloader, *prestateServer, string) {
+		server := &prestateServer{content: content}
+		httpServer := httptest.NewServer(server)
+		t.Cleanup(httpServer.Close)
+		cacheDir := filepath.Join(t.TempDir(), "prestates")
+		downloader := NewPrestateDownloader(testlog.Logger(t, log.LevelInfo), httpServer.Client(), cacheDir)
+		return downloader, server, httpServer.URL + "/prestate.json"
+	}
+
+	t.Run("DownloadAndCache", func(t *testing.T) {
+		downloader, server, url := setup(t, validState)
+		path, err := downloader.Fetch(context.Background(), url, expected)
+		require.NoError(t, err)
+		actual, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, validState, actual)
+		require.EqualValues(t, 1, server.requests.Load())
+
+		cachedPath, err := downloader.Fetch(context.Background(), url, expected)
+		require.NoError(t, err)
+		require.Equal(t, path, cachedPath)
+		require.EqualValues(t, 1, server.requests.Load(), "should use cached pre-state")
+		requireNoTempFiles(t, downloader.cacheDir)
+	})
+
+	t.Run("CacheReusedAcrossRestarts", func(t *testing.T) {
+		downloader, server, url := setup(t, validState)
+		path, err := downloader.Fetch(context.Background(), url, expected)
+		require.NoError(t, err)
+
+		restarted := NewPrestateDownloader(downloader.logger, downloader.client, downloader.cacheDir)
+		cachedPath, err := restarted.Fetch(context.Background(), url, expected)
+		require.NoError(t, err)
+		require.Equal(t, path, cachedPath)
+		require.EqualValues(t, 1, server.requests.Load())
+	})
+
+	t.Run("InvalidCacheDownloadedAgain", func(t *testing.T) {
+		downloader, server, url := setup(t, validState)
+		path, err := downloader.Fetch(context.Background(), url, expected)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, invalidState, 0o644))
+
+		cachedPath, err := downloader.Fetch(context.Background(), url, expected)
+		require.NoError(t, err)
+		require.Equal(t, path, cachedPath)
+		require.EqualValues(t, 2, server.requests.Load())
+		actual, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, validState, actual)
+	})
+
+	t.Run("CorruptedDownload", func(t *testing.T) {
+		downloader, _, url := setup(t, invalidState)
+		_, err := downloader.Fetch(context.Background(), url, expected)
+		require.ErrorContains(t, err, "invalid mipsevm state")
+		requireEmptyDir(t, downloader.cacheDir)
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		downloader, _, url := setup(t, validState)
+		_, err := downloader.Fetch(context.Background(), url, common.Hash{0xba, 0xd0})
+		require.ErrorIs(t, err, ErrPrestateHashMismatch)
+		requireEmptyDir(t, downloader.cacheDir)
+	})
+
+	t.Run("RequestFailed", func(t *testing.T) {
+		downloader, server, url := setup(t, validState)
+		server.status = http.StatusNotFound
+		_, err := downloader.Fetch(context.Background(), url, expected)
+		require.ErrorContains(t, err, "404")
+		requireEmptyDir(t, downloader.cacheDir)
+	})
+}
+
+func expectedPrestateHash(t *testing.T, state []byte) common.Hash {
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(path, state, 0o644))
+	hash, err := NewPrestateProvider(path).AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+	return hash
+}
+
+func requireNoTempFiles(t *testing.T, dir string) {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "should only contain the cached pre-state")
+}
+
+func requireEmptyDir(t *testing.T, dir string) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return
+	}
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+type prestateServer struct {
+	content  []byte
+	status   int
+	requests atomic.Int32
+}
+
+func (s *prestateServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.requests.Add(1)
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+	_, _ = w.Write(s.content)
+}
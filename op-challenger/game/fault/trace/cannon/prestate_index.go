@@ -0,0 +1,69 @@
+package cannon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PrestateIndex indexes absolute pre-state files by their commitment so the pre-state matching a game can be selected.
+type PrestateIndex struct {
+	prestates map[common.Hash]string
+}
+
+// NewPrestateIndex loads each of paths and indexes them by their commitment.
+// A path may be a pre-state file or a directory, in which case every .json and .json.gz file in it is loaded.
+func NewPrestateIndex(ctx context.Context, paths ...string) (*PrestateIndex, error) {
+	index := &PrestateIndex{prestates: make(map[common.Hash]string)}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load absolute pre-state %v: %w", path, err)
+		}
+		if !info.IsDir() {
+			if err := index.add(ctx, path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read absolute pre-state dir %v: %w", path, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !(strings.HasSuffix(entry.Name(), ".json") || strings.HasSuffix(entry.Name(), ".json.gz")) {
+				continue
+			}
+			if err := index.add(ctx, filepath.Join(path, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return index, nil
+}
+
+func (i *PrestateIndex) add(ctx context.Context, path string) error {
+	hash, err := NewPrestateProvider(path).AbsolutePreStateCommitment(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot index absolute pre-state %v: %w", path, err)
+	}
+	if _, ok := i.prestates[hash]; !ok {
+		i.prestates[hash] = path
+	}
+	return nil
+}
+
+// Get returns the path of the pre-state with the given commitment.
+func (i *PrestateIndex) Get(hash common.Hash) (string, bool) {
+	path, ok := i.prestates[hash]
+	return path, ok
+}
+
+// Len returns the number of distinct pre-states in the index.
+func (i *PrestateIndex) Len() int {
+	return len(i.prestates)
+}
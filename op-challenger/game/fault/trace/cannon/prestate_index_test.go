@@ -0,0 +1,77 @@
+package cannon
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrestateIndex(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, oldHash := writeTestPrestate(t, dir, "old.json", 0)
+	newPath, newHash := writeTestPrestate(t, dir, "new.json", 4)
+	require.NotEqual(t, oldHash, newHash)
+
+	t.Run("Files", func(t *testing.T) {
+		index, err := NewPrestateIndex(context.Background(), oldPath, newPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, index.Len())
+		path, ok := index.Get(oldHash)
+		require.True(t, ok)
+		require.Equal(t, oldPath, path)
+		path, ok = index.Get(newHash)
+		require.True(t, ok)
+		require.Equal(t, newPath, path)
+		_, ok = index.Get(common.Hash{0xaa})
+		require.False(t, ok)
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a prestate"), 0o644))
+		index, err := NewPrestateIndex(context.Background(), dir)
+		require.NoError(t, err)
+		require.Equal(t, 2, index.Len())
+		path, ok := index.Get(newHash)
+		require.True(t, ok)
+		require.Equal(t, newPath, path)
+	})
+
+	t.Run("FirstPathWinsForDuplicates", func(t *testing.T) {
+		copyPath, _ := writeTestPrestate(t, t.TempDir(), "copy.json", 0)
+		index, err := NewPrestateIndex(context.Background(), copyPath, oldPath)
+		require.NoError(t, err)
+		require.Equal(t, 1, index.Len())
+		path, ok := index.Get(oldHash)
+		require.True(t, ok)
+		require.Equal(t, copyPath, path)
+	})
+
+	t.Run("MissingPath", func(t *testing.T) {
+		_, err := NewPrestateIndex(context.Background(), filepath.Join(dir, "missing.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		invalidDir := t.TempDir()
+		setupPreState(t, invalidDir, "invalid.json")
+		_, err := NewPrestateIndex(context.Background(), invalidDir)
+		require.ErrorContains(t, err, "invalid mipsevm state")
+	})
+}
+
+func writeTestPrestate(t *testing.T, dir string, name string, pc uint32) (string, common.Hash) {
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), PC: pc, NextPC: pc + 4}
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	hash, err := NewPrestateProvider(path).AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+	return path, hash
+}
@@ -0,0 +1,168 @@
+package cartesi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	snapsDir   = "snapshots"
+	finalState = "final.json.gz"
+)
+
+type snapshotSelect func(logger log.Logger, dir string, initialMachine string, i uint64) (string, error)
+type cmdExecutor func(ctx context.Context, l log.Logger, binary string, args ...string) error
+
+type Executor struct {
+	logger         log.Logger
+	metrics        CartesiMetricer
+	l1             string
+	l1Beacon       string
+	l2             string
+	inputs         cannon.LocalGameInputs
+	emulator       string
+	server         string
+	network        string
+	rollupConfig   string
+	l2Genesis      string
+	snapshotDir    string
+	machineURL     string
+	snapshotFreq   uint
+	selectSnapshot snapshotSelect
+	cmdExecutor    cmdExecutor
+}
+
+func NewExecutor(logger log.Logger, m CartesiMetricer, cfg *config.Config, inputs cannon.LocalGameInputs) *Executor {
+	return &Executor{
+		logger:         logger,
+		metrics:        m,
+		l1:             cfg.L1EthRpc,
+		l1Beacon:       cfg.L1Beacon,
+		l2:             cfg.CannonL2,
+		inputs:         inputs,
+		emulator:       cfg.CartesiBin,
+		server:         cfg.CartesiServer,
+		network:        cfg.CartesiNetwork,
+		rollupConfig:   cfg.CartesiRollupConfigPath,
+		l2Genesis:      cfg.CartesiL2GenesisPath,
+		snapshotDir:    cfg.CartesiSnapshotDir,
+		machineURL:     cfg.CartesiMachineURL,
+		snapshotFreq:   cfg.CartesiSnapshotFreq,
+		selectSnapshot: findStartingSnapshot,
+		cmdExecutor:    cannon.RunCmd,
+	}
+}
+
+// GenerateProof runs the Cartesi machine to generate a proof at the specified trace index.
+// The proof is stored at the specified directory.
+func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) error {
+	snapshotDir := filepath.Join(dir, snapsDir)
+	start, err := e.selectSnapshot(e.logger, snapshotDir, e.snapshotDir, i)
+	if err != nil {
+		return fmt.Errorf("find starting snapshot: %w", err)
+	}
+	proofDir := filepath.Join(dir, proofsDir)
+	dataDir := cannon.PreimageDir(dir)
+	lastGeneratedState := filepath.Join(dir, finalState)
+	args := []string{
+		"run",
+		"--load", start,
+		"--output", lastGeneratedState,
+		"--proof-at", "=" + strconv.FormatUint(i, 10),
+		"--proof-fmt", filepath.Join(proofDir, "%d.json.gz"),
+		"--snapshot-at", "%" + strconv.FormatUint(uint64(e.snapshotFreq), 10),
+		"--snapshot-fmt", filepath.Join(snapshotDir, "%d"),
+	}
+	if i < math.MaxUint64 {
+		args = append(args, "--stop-at", "="+strconv.FormatUint(i+1, 10))
+	}
+	if e.machineURL != "" {
+		args = append(args, "--remote-address", e.machineURL)
+	}
+	args = append(args,
+		"--",
+		e.server, "--server",
+		"--l1", e.l1,
+		"--l1.beacon", e.l1Beacon,
+		"--l2", e.l2,
+		"--datadir", dataDir,
+		"--l1.head", e.inputs.L1Head.Hex(),
+		"--l2.head", e.inputs.L2Head.Hex(),
+		"--l2.outputroot", e.inputs.L2OutputRoot.Hex(),
+		"--l2.claim", e.inputs.L2Claim.Hex(),
+		"--l2.blocknumber", e.inputs.L2BlockNumber.Text(10),
+	)
+	// The pre-image server serves machine pages from the same source the machine is run from.
+	if e.machineURL != "" {
+		args = append(args, "--cartesi.machine-url", e.machineURL)
+	} else {
+		args = append(args, "--cartesi.snapshot-dir", e.snapshotDir)
+	}
+	if e.network != "" {
+		args = append(args, "--network", e.network)
+	}
+	if e.rollupConfig != "" {
+		args = append(args, "--rollup.config", e.rollupConfig)
+	}
+	if e.l2Genesis != "" {
+		args = append(args, "--l2.genesis", e.l2Genesis)
+	}
+
+	if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+		return fmt.Errorf("could not create snapshot directory %v: %w", snapshotDir, err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("could not create preimage cache directory %v: %w", dataDir, err)
+	}
+	if err := os.MkdirAll(proofDir, 0755); err != nil {
+		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
+	}
+	e.logger.Info("Generating trace", "proof", i, "cmd", e.emulator, "args", strings.Join(args, ", "))
+	execStart := time.Now()
+	err = e.cmdExecutor(ctx, e.logger.New("proof", i), e.emulator, args...)
+	e.metrics.RecordCartesiExecutionTime(time.Since(execStart).Seconds())
+	return err
+}
+
+// findStartingSnapshot finds the closest machine snapshot before the specified traceIndex in snapDir.
+// Machine snapshots are directories named by the step they were taken at.
+// If no suitable snapshot can be found it returns initialMachine.
+func findStartingSnapshot(logger log.Logger, snapDir string, initialMachine string, traceIndex uint64) (string, error) {
+	entries, err := os.ReadDir(snapDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return initialMachine, nil
+		}
+		return "", fmt.Errorf("list snapshots in %v: %w", snapDir, err)
+	}
+	bestSnap := uint64(0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			logger.Warn("Unexpected file in snapshots dir", "parent", snapDir, "child", entry.Name())
+			continue
+		}
+		index, err := strconv.ParseUint(entry.Name(), 10, 64)
+		if err != nil {
+			logger.Warn("Unexpected directory in snapshots dir", "parent", snapDir, "child", entry.Name())
+			continue
+		}
+		if index > bestSnap && index < traceIndex {
+			bestSnap = index
+		}
+	}
+	if bestSnap == 0 {
+		return initialMachine, nil
+	}
+	return filepath.Join(snapDir, strconv.FormatUint(bestSnap, 10)), nil
+}
@@ -0,0 +1,137 @@
+package cartesi
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateProof(t *testing.T) {
+	tempDir := t.TempDir()
+	dir := filepath.Join(tempDir, "gameDir")
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", "http://localhost:9000", tempDir, config.TraceTypeCartesi)
+	cfg.CartesiBin = "./bin/cartesi-machine"
+	cfg.CartesiServer = "./bin/op-program"
+	cfg.CartesiSnapshotDir = "/machines/initial"
+	cfg.CartesiNetwork = "mainnet"
+	cfg.CartesiSnapshotFreq = 500
+	cfg.CannonL2 = "http://localhost:9999"
+	inputs := cannon.LocalGameInputs{
+		L1Head:        common.Hash{0x11},
+		L2Head:        common.Hash{0x22},
+		L2OutputRoot:  common.Hash{0x33},
+		L2Claim:       common.Hash{0x44},
+		L2BlockNumber: big.NewInt(3333),
+	}
+	captureExec := func(t *testing.T, cfg config.Config) (string, map[string]string, map[string]string) {
+		m := &cartesiDurationMetrics{}
+		executor := NewExecutor(testlog.Logger(t, log.LevelInfo), m, &cfg, inputs)
+		var binary string
+		emulatorArgs := make(map[string]string)
+		serverArgs := make(map[string]string)
+		executor.cmdExecutor = func(ctx context.Context, l log.Logger, b string, a ...string) error {
+			binary = b
+			args := emulatorArgs
+			for i := 1; i < len(a); {
+				if a[i] == "--" {
+					// Switch to the server program, skipping its path and --server
+					args = serverArgs
+					i += 3
+					continue
+				}
+				args[a[i]] = a[i+1]
+				i += 2
+			}
+			return nil
+		}
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 150))
+		require.Equal(t, 1, m.executionTimeRecordCount, "Should record cartesi execution time")
+		return binary, emulatorArgs, serverArgs
+	}
+
+	t.Run("SnapshotDir", func(t *testing.T) {
+		binary, emulatorArgs, serverArgs := captureExec(t, cfg)
+		require.Equal(t, cfg.CartesiBin, binary)
+		require.Equal(t, cfg.CartesiSnapshotDir, emulatorArgs["--load"])
+		require.Equal(t, filepath.Join(dir, finalState), emulatorArgs["--output"])
+		require.Equal(t, "=150", emulatorArgs["--proof-at"])
+		require.Equal(t, "=151", emulatorArgs["--stop-at"])
+		require.Equal(t, "%500", emulatorArgs["--snapshot-at"])
+		require.Equal(t, filepath.Join(dir, snapsDir, "%d"), emulatorArgs["--snapshot-fmt"])
+		require.NotContains(t, emulatorArgs, "--remote-address")
+
+		require.Equal(t, cfg.L1EthRpc, serverArgs["--l1"])
+		require.Equal(t, cfg.L1Beacon, serverArgs["--l1.beacon"])
+		require.Equal(t, cfg.CannonL2, serverArgs["--l2"])
+		require.Equal(t, cannon.PreimageDir(dir), serverArgs["--datadir"])
+		require.Equal(t, cfg.CartesiSnapshotDir, serverArgs["--cartesi.snapshot-dir"])
+		require.NotContains(t, serverArgs, "--cartesi.machine-url")
+		require.Equal(t, cfg.CartesiNetwork, serverArgs["--network"])
+		require.Equal(t, inputs.L1Head.Hex(), serverArgs["--l1.head"])
+		require.Equal(t, inputs.L2Claim.Hex(), serverArgs["--l2.claim"])
+		require.Equal(t, "3333", serverArgs["--l2.blocknumber"])
+	})
+
+	t.Run("MachineURL", func(t *testing.T) {
+		cfg := cfg
+		cfg.CartesiMachineURL = "http://localhost:5000"
+		_, emulatorArgs, serverArgs := captureExec(t, cfg)
+		require.Equal(t, cfg.CartesiMachineURL, emulatorArgs["--remote-address"])
+		require.Equal(t, cfg.CartesiMachineURL, serverArgs["--cartesi.machine-url"])
+		require.NotContains(t, serverArgs, "--cartesi.snapshot-dir")
+	})
+}
+
+func TestFindStartingSnapshot(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	initialMachine := "/machines/initial"
+
+	t.Run("UseInitialMachineIfSnapshotDirDoesNotExist", func(t *testing.T) {
+		snapshot, err := findStartingSnapshot(logger, filepath.Join(t.TempDir(), "missing"), initialMachine, 1200)
+		require.NoError(t, err)
+		require.Equal(t, initialMachine, snapshot)
+	})
+
+	t.Run("UseClosestSnapshotBeforeTraceIndex", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"100", "200", "300"} {
+			require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0o755))
+		}
+		snapshot, err := findStartingSnapshot(logger, dir, initialMachine, 250)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "200"), snapshot)
+
+		snapshot, err = findStartingSnapshot(logger, dir, initialMachine, 100)
+		require.NoError(t, err)
+		require.Equal(t, initialMachine, snapshot)
+	})
+
+	t.Run("IgnoreInvalidEntries", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "foo"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "150"), []byte{}, 0o644))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "100"), 0o755))
+		snapshot, err := findStartingSnapshot(logger, dir, initialMachine, 200)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "100"), snapshot)
+	})
+}
+
+type cartesiDurationMetrics struct {
+	metrics.NoopMetricsImpl
+	executionTimeRecordCount int
+}
+
+func (c *cartesiDurationMetrics) RecordCartesiExecutionTime(_ float64) {
+	c.executionTimeRecordCount++
+}
@@ -0,0 +1,43 @@
+package cartesi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// machineHashFile is the file in a stored machine that holds its root hash.
+const machineHashFile = "hash"
+
+var _ types.PrestateProvider = (*CartesiPrestateProvider)(nil)
+
+type CartesiPrestateProvider struct {
+	snapshotDir string
+}
+
+func NewPrestateProvider(snapshotDir string) *CartesiPrestateProvider {
+	return &CartesiPrestateProvider{snapshotDir}
+}
+
+// AbsolutePreStateCommitment returns the root hash of the initial machine.
+func (p *CartesiPrestateProvider) AbsolutePreStateCommitment(_ context.Context) (common.Hash, error) {
+	return readRootHash(p.snapshotDir)
+}
+
+// readRootHash reads the root hash of the machine stored in dir.
+func readRootHash(dir string) (common.Hash, error) {
+	path := filepath.Join(dir, machineHashFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("cannot load absolute pre-state: %w", err)
+	}
+	if len(data) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("cannot load absolute pre-state: invalid root hash length %d in %v", len(data), path)
+	}
+	return common.BytesToHash(data), nil
+}
@@ -0,0 +1,189 @@
+package cartesi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("GeneratesProof", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 100)
+		value, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7)))
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(8), value)
+		require.Equal(t, []uint64{7}, emulator.runs)
+	})
+
+	t.Run("ExistingProof", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 100)
+		_, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7)))
+		require.NoError(t, err)
+		value, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7)))
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(8), value)
+		require.Len(t, emulator.runs, 1, "should reuse the generated proof")
+	})
+
+	t.Run("ErrorsTraceIndexOutOfBounds", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 100)
+		largePosition := types.NewPosition(provider.gameDepth, new(big.Int).Mul(new(big.Int).SetUint64(math.MaxUint64), big.NewInt(2)))
+		_, err := provider.Get(context.Background(), largePosition)
+		require.ErrorContains(t, err, "trace index out of bounds")
+		require.Empty(t, emulator.runs)
+	})
+
+	t.Run("ProofAfterMachineHalted", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 10)
+		value, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7000)))
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(10), value)
+		require.Equal(t, uint64(9), provider.lastStep)
+
+		// Later indices are extended from the cached final proof without running the machine again
+		value, err = provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(8000)))
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(10), value)
+		require.Len(t, emulator.runs, 1)
+	})
+
+	t.Run("EmulatorFails", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 100)
+		emulator.err = fmt.Errorf("boom")
+		_, err := provider.Get(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7)))
+		require.ErrorIs(t, err, emulator.err)
+	})
+}
+
+func TestGetStepData(t *testing.T) {
+	t.Run("GeneratesProof", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 100)
+		state, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7)))
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(7).Bytes(), state)
+		require.Equal(t, emulator.accessLog(7), proof)
+		require.Nil(t, data)
+	})
+
+	t.Run("ProofAfterMachineHalted", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 10)
+		state, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(7000)))
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(10).Bytes(), state)
+		require.Empty(t, proof)
+		require.Nil(t, data)
+	})
+
+	t.Run("MissingStateData", func(t *testing.T) {
+		provider, _ := setupWithFakeEmulator(t, 100)
+		require.NoError(t, os.MkdirAll(filepath.Join(provider.dir, proofsDir), 0o755))
+		proofFile := filepath.Join(provider.dir, proofsDir, "3.json.gz")
+		require.NoError(t, ioutil.WriteCompressedJson(proofFile, &cannon.ProofData{ClaimValue: common.Hash{0xaa}}))
+		_, _, _, err := provider.GetStepData(context.Background(), types.NewPosition(provider.gameDepth, big.NewInt(3)))
+		require.ErrorContains(t, err, "missing state data")
+	})
+}
+
+func TestAbsolutePreStateCommitment(t *testing.T) {
+	t.Run("InitialRootHash", func(t *testing.T) {
+		provider, emulator := setupWithFakeEmulator(t, 100)
+		actual, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(0), actual)
+
+		actual, err = NewPrestateProvider(provider.snapshotDir).AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, emulator.rootHash(0), actual)
+	})
+
+	t.Run("SnapshotUnavailable", func(t *testing.T) {
+		provider := NewPrestateProvider("/dir/does/not/exist")
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("InvalidRootHash", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, machineHashFile), []byte{0x01, 0x02}, 0o644))
+		_, err := NewPrestateProvider(dir).AbsolutePreStateCommitment(context.Background())
+		require.ErrorContains(t, err, "invalid root hash length 2")
+	})
+}
+
+func setupWithFakeEmulator(t *testing.T, haltStep uint64) (*CartesiTraceProvider, *fakeEmulator) {
+	emulator := &fakeEmulator{t: t, haltStep: haltStep}
+	snapshotDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(snapshotDir, machineHashFile), emulator.rootHash(0).Bytes(), 0o644))
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", "http://localhost:9000", t.TempDir(), config.TraceTypeCartesi)
+	cfg.CartesiBin = "./bin/cartesi-machine"
+	cfg.CartesiServer = "./bin/op-program"
+	cfg.CartesiSnapshotDir = snapshotDir
+	cfg.CannonL2 = "http://localhost:9999"
+	inputs := cannon.LocalGameInputs{L2BlockNumber: big.NewInt(1)}
+	provider := NewTraceProvider(testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, &cfg, inputs, t.TempDir(), 63)
+	executor := provider.generator.(*Executor)
+	executor.cmdExecutor = emulator.run
+	emulator.initialMachine = snapshotDir
+	return provider, emulator
+}
+
+// fakeEmulator stands in for the Cartesi machine emulator, running a machine that halts at haltStep.
+type fakeEmulator struct {
+	t              *testing.T
+	haltStep       uint64
+	initialMachine string
+	runs           []uint64
+	err            error
+}
+
+func (e *fakeEmulator) rootHash(step uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte("root"), new(big.Int).SetUint64(step).Bytes())
+}
+
+func (e *fakeEmulator) accessLog(step uint64) []byte {
+	return crypto.Keccak256([]byte("access log"), new(big.Int).SetUint64(step).Bytes())
+}
+
+func (e *fakeEmulator) run(_ context.Context, _ log.Logger, _ string, args ...string) error {
+	require.Equal(e.t, "run", args[0])
+	flags := make(map[string]string)
+	for i := 1; i < len(args) && args[i] != "--"; i += 2 {
+		flags[args[i]] = args[i+1]
+	}
+	require.Equal(e.t, e.initialMachine, flags["--load"], "should start from the initial machine")
+	proofAt, err := strconv.ParseUint(strings.TrimPrefix(flags["--proof-at"], "="), 10, 64)
+	require.NoError(e.t, err)
+	e.runs = append(e.runs, proofAt)
+	if e.err != nil {
+		return e.err
+	}
+	if proofAt >= e.haltStep {
+		return ioutil.WriteCompressedJson(flags["--output"], &MachineState{Step: e.haltStep, Halted: true, RootHash: e.rootHash(e.haltStep)})
+	}
+	proof := &cannon.ProofData{
+		ClaimValue: e.rootHash(proofAt + 1),
+		StateData:  e.rootHash(proofAt).Bytes(),
+		ProofData:  e.accessLog(proofAt),
+	}
+	if err := ioutil.WriteCompressedJson(fmt.Sprintf(flags["--proof-fmt"], proofAt), proof); err != nil {
+		return err
+	}
+	return ioutil.WriteCompressedJson(flags["--output"], &MachineState{Step: proofAt + 1, RootHash: e.rootHash(proofAt + 1)})
+}
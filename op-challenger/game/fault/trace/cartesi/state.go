@@ -0,0 +1,10 @@
+package cartesi
+
+import "github.com/ethereum/go-ethereum/common"
+
+// MachineState is the state of the Cartesi machine reported by the emulator when it stops.
+type MachineState struct {
+	Step     uint64      `json:"step"`
+	Halted   bool        `json:"halted"`
+	RootHash common.Hash `json:"root-hash"`
+}
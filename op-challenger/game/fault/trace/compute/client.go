@@ -0,0 +1,62 @@
+package compute
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrPreimageNotFound = errors.New("pre-image not found")
+	ErrPreimageMismatch = errors.New("pre-image does not match hash")
+)
+
+// PreimageClient fetches keccak256 pre-images from the /dehash/ endpoint of a pre-image server's HTTP API,
+// such as the op-program host.
+type PreimageClient struct {
+	url    string
+	client *http.Client
+}
+
+func NewPreimageClient(url string, client *http.Client) *PreimageClient {
+	return &PreimageClient{
+		url:    strings.TrimSuffix(url, "/"),
+		client: client,
+	}
+}
+
+// Get returns the pre-image of hash. It returns ErrPreimageNotFound if the server doesn't know the pre-image and
+// ErrPreimageMismatch if the server returns data that doesn't hash to hash.
+func (c *PreimageClient) Get(ctx context.Context, hash common.Hash) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/dehash/"+hex.EncodeToString(hash[:]), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pre-image request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request pre-image %v: %w", hash, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %v", ErrPreimageNotFound, hash)
+	default:
+		return nil, fmt.Errorf("failed to request pre-image %v: status %v", hash, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pre-image %v: %w", hash, err)
+	}
+	if actual := crypto.Keccak256Hash(data); actual != hash {
+		return nil, fmt.Errorf("%w: requested %v but got pre-image of %v", ErrPreimageMismatch, hash, actual)
+	}
+	return data, nil
+}
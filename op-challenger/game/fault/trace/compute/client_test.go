@@ -0,0 +1,66 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	hostConfig "github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreimageClient(t *testing.T) {
+	known := []byte("cartesi machine output")
+	client, kv := newHostPreimageClient(t, known)
+
+	t.Run("Known", func(t *testing.T) {
+		data, err := client.Get(context.Background(), crypto.Keccak256Hash(known))
+		require.NoError(t, err)
+		require.Equal(t, known, data)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := client.Get(context.Background(), crypto.Keccak256Hash([]byte("unknown")))
+		require.ErrorIs(t, err, ErrPreimageNotFound)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		hash := crypto.Keccak256Hash([]byte("expected"))
+		require.NoError(t, kv.Put(preimage.Keccak256Key(hash).PreimageKey(), []byte("tampered")))
+		_, err := client.Get(context.Background(), hash)
+		require.ErrorIs(t, err, ErrPreimageMismatch)
+	})
+
+	t.Run("ServerError", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+		_, err := NewPreimageClient(srv.URL, srv.Client()).Get(context.Background(), crypto.Keccak256Hash(known))
+		require.ErrorContains(t, err, "500")
+		require.NotErrorIs(t, err, ErrPreimageNotFound)
+	})
+}
+
+// newHostPreimageClient serves preimages from the op-program host HTTP API and returns a client for it.
+// The returned key-value store backs the server.
+func newHostPreimageClient(t *testing.T, preimages ...[]byte) (*PreimageClient, kvstore.KV) {
+	kv := kvstore.NewMemKV()
+	for _, data := range preimages {
+		require.NoError(t, kv.Put(preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey(), data))
+	}
+	cfg := hostConfig.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	srv := httptest.NewServer(host.NewHTTPHandler(testlog.Logger(t, log.LevelInfo), cfg, kv.Get, func(string) error { return nil }))
+	t.Cleanup(srv.Close)
+	return NewPreimageClient(srv.URL, srv.Client()), kv
+}
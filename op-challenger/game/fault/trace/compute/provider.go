@@ -0,0 +1,129 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrCannotResolve is returned when the pre-image server doesn't know a state of the trace.
+	// The claim may still be valid, the server just can't say what the honest trace is.
+	ErrCannotResolve = errors.New("cannot resolve claim")
+	ErrInvalidState  = errors.New("invalid compute state")
+	ErrTraceTooLong  = errors.New("compute trace is longer than the game")
+)
+
+var _ types.TraceProvider = (*ComputeTraceProvider)(nil)
+
+// ComputeTraceProvider provides the trace of a cartesi compute game by looking up the outputs of the machine on a
+// trusted pre-image server rather than executing the machine.
+// The keccak256 pre-image of each state in the trace is the hash of the previous state followed by the machine
+// output of the step, so the trace is resolved by following the states back from the game's root claim to its
+// absolute pre-state. The trace repeats the final state once the machine has halted.
+type ComputeTraceProvider struct {
+	client    *PreimageClient
+	prestate  common.Hash
+	rootClaim common.Hash
+	gameDepth types.Depth
+
+	lock   sync.Mutex
+	states []common.Hash // Loaded on first use, states[i] is the state at trace index i
+}
+
+func NewTraceProvider(client *PreimageClient, prestate common.Hash, rootClaim common.Hash, gameDepth types.Depth) *ComputeTraceProvider {
+	return &ComputeTraceProvider{
+		client:    client,
+		prestate:  prestate,
+		rootClaim: rootClaim,
+		gameDepth: gameDepth,
+	}
+}
+
+func (p *ComputeTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	states, err := p.loadStates(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return states[stateIndex(pos.TraceIndex(p.gameDepth), len(states))], nil
+}
+
+// GetStepData returns the pre-image of the state before the step at pos as the pre-state. No proof data or
+// pre-image oracle data is required to step.
+func (p *ComputeTraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	traceIndex := pos.TraceIndex(p.gameDepth)
+	prestate := p.prestate
+	if traceIndex.Sign() > 0 {
+		states, err := p.loadStates(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		prestate = states[stateIndex(new(big.Int).Sub(traceIndex, big.NewInt(1)), len(states))]
+	}
+	data, err := p.dehash(ctx, prestate)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return data, []byte{}, nil, nil
+}
+
+// AbsolutePreStateCommitment returns the absolute pre-state of the game the provider was created for.
+func (p *ComputeTraceProvider) AbsolutePreStateCommitment(_ context.Context) (common.Hash, error) {
+	return p.prestate, nil
+}
+
+// loadStates follows the states back from the root claim to the absolute pre-state.
+// The states are only kept once the whole trace is resolved so unknown states are looked up again on the next call.
+func (p *ComputeTraceProvider) loadStates(ctx context.Context) ([]common.Hash, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.states != nil {
+		return p.states, nil
+	}
+	if p.rootClaim == p.prestate {
+		return nil, fmt.Errorf("%w: root claim is the absolute pre-state %v", ErrCannotResolve, p.prestate)
+	}
+	var states []common.Hash
+	for state := p.rootClaim; state != p.prestate; {
+		if p.gameDepth < 64 && uint64(len(states)) >= 1<<p.gameDepth {
+			return nil, fmt.Errorf("%w: more than %v states at depth %v", ErrTraceTooLong, len(states), p.gameDepth)
+		}
+		data, err := p.dehash(ctx, state)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) < common.HashLength {
+			return nil, fmt.Errorf("%w: state %v has %v bytes", ErrInvalidState, state, len(data))
+		}
+		states = append(states, state)
+		state = common.BytesToHash(data[:common.HashLength])
+	}
+	slices.Reverse(states)
+	p.states = states
+	return states, nil
+}
+
+// dehash returns the pre-image of state, reporting states the server doesn't know as ErrCannotResolve.
+func (p *ComputeTraceProvider) dehash(ctx context.Context, state common.Hash) ([]byte, error) {
+	data, err := p.client.Get(ctx, state)
+	if errors.Is(err, ErrPreimageNotFound) {
+		return nil, fmt.Errorf("%w: %w", ErrCannotResolve, err)
+	} else if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// stateIndex returns the index of the state at traceIndex in a trace of count states.
+func stateIndex(traceIndex *big.Int, count int) int {
+	if !traceIndex.IsUint64() || traceIndex.Uint64() >= uint64(count) {
+		return count - 1
+	}
+	return int(traceIndex.Uint64())
+}
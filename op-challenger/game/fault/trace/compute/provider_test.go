@@ -0,0 +1,98 @@
+package compute
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTraceProvider(t *testing.T) {
+	prestateData := []byte("initial machine")
+	prestate := crypto.Keccak256Hash(prestateData)
+	state1Data := append(prestate.Bytes(), []byte("output 1")...)
+	state1 := crypto.Keccak256Hash(state1Data)
+	state2Data := append(state1.Bytes(), []byte("output 2")...)
+	state2 := crypto.Keccak256Hash(state2Data)
+	gameDepth := types.Depth(2)
+
+	t.Run("Get", func(t *testing.T) {
+		client, _ := newHostPreimageClient(t, prestateData, state1Data, state2Data)
+		provider := NewTraceProvider(client, prestate, state2, gameDepth)
+		for i, expected := range []common.Hash{state1, state2, state2, state2} {
+			actual, err := provider.Get(context.Background(), types.NewPosition(gameDepth, big.NewInt(int64(i))))
+			require.NoError(t, err)
+			require.Equal(t, expected, actual, "trace index %v", i)
+		}
+		// Claims above the leaves commit to the state at their trace index
+		actual, err := provider.Get(context.Background(), types.NewPosition(1, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, state2, actual)
+	})
+
+	t.Run("GetStepData", func(t *testing.T) {
+		client, _ := newHostPreimageClient(t, prestateData, state1Data, state2Data)
+		provider := NewTraceProvider(client, prestate, state2, gameDepth)
+		for i, expected := range [][]byte{prestateData, state1Data, state2Data, state2Data} {
+			stateData, proof, oracleData, err := provider.GetStepData(context.Background(), types.NewPosition(gameDepth, big.NewInt(int64(i))))
+			require.NoError(t, err)
+			require.Equal(t, expected, stateData, "trace index %v", i)
+			require.Empty(t, proof)
+			require.Nil(t, oracleData)
+		}
+	})
+
+	t.Run("AbsolutePreStateCommitment", func(t *testing.T) {
+		client, _ := newHostPreimageClient(t)
+		provider := NewTraceProvider(client, prestate, state2, gameDepth)
+		actual, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, prestate, actual)
+	})
+
+	t.Run("UnknownRootClaim", func(t *testing.T) {
+		client, _ := newHostPreimageClient(t, prestateData, state1Data, state2Data)
+		provider := NewTraceProvider(client, prestate, crypto.Keccak256Hash([]byte("invalid output")), gameDepth)
+		_, err := provider.Get(context.Background(), types.NewPosition(gameDepth, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrCannotResolve)
+	})
+
+	t.Run("RootClaimIsPrestate", func(t *testing.T) {
+		client, _ := newHostPreimageClient(t, prestateData)
+		provider := NewTraceProvider(client, prestate, prestate, gameDepth)
+		_, err := provider.Get(context.Background(), types.NewPosition(gameDepth, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrCannotResolve)
+	})
+
+	t.Run("ResolvesOnceStatesAreKnown", func(t *testing.T) {
+		client, kv := newHostPreimageClient(t, prestateData, state2Data)
+		provider := NewTraceProvider(client, prestate, state2, gameDepth)
+		_, err := provider.Get(context.Background(), types.NewPosition(gameDepth, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrCannotResolve)
+
+		require.NoError(t, kv.Put(preimage.Keccak256Key(state1).PreimageKey(), state1Data))
+		actual, err := provider.Get(context.Background(), types.NewPosition(gameDepth, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, state1, actual)
+	})
+
+	t.Run("TraceTooLong", func(t *testing.T) {
+		client, _ := newHostPreimageClient(t, prestateData, state1Data, state2Data)
+		provider := NewTraceProvider(client, prestate, state2, 0)
+		_, err := provider.Get(context.Background(), types.NewPosition(0, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrTraceTooLong)
+	})
+
+	t.Run("InvalidState", func(t *testing.T) {
+		invalidData := []byte("too short")
+		client, _ := newHostPreimageClient(t, invalidData)
+		provider := NewTraceProvider(client, prestate, crypto.Keccak256Hash(invalidData), gameDepth)
+		_, err := provider.Get(context.Background(), types.NewPosition(gameDepth, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrInvalidState)
+	})
+}
@@ -0,0 +1,119 @@
+package external
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fixtureModeEnv makes the test binary act as an external trace provider instead of running the tests.
+const fixtureModeEnv = "OP_CHALLENGER_EXTERNAL_FIXTURE"
+
+const (
+	fixtureValid   = "valid"
+	fixtureHang    = "hang"
+	fixtureGarbage = "garbage"
+	fixtureWrongID = "wrong-id"
+	fixtureExit    = "exit"
+)
+
+var (
+	fixturePrestate = common.Hash{0xaa}
+	// fixtureOracleIndex is the only trace index the fixture returns pre-image oracle data for.
+	fixtureOracleIndex = uint64(3)
+	// fixtureFailIndex is a trace index the fixture returns an error for.
+	fixtureFailIndex = uint64(99)
+)
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fixtureModeEnv); mode != "" {
+		os.Exit(runFixture(mode))
+	}
+	os.Exit(m.Run())
+}
+
+// runFixture serves requests from stdin, recording its args and each start in its working directory.
+func runFixture(mode string) int {
+	if err := os.WriteFile("args", []byte(strings.Join(os.Args[1:], " ")), 0o644); err != nil {
+		return 1
+	}
+	starts, err := os.OpenFile("starts", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 1
+	}
+	_, _ = starts.WriteString("started\n")
+	_ = starts.Close()
+
+	in := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+	for in.Scan() {
+		var req Request
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil {
+			return 1
+		}
+		switch mode {
+		case fixtureHang:
+			select {}
+		case fixtureGarbage:
+			fmt.Println("not json")
+			continue
+		case fixtureWrongID:
+			_ = out.Encode(Response{ID: req.ID + 1, Result: json.RawMessage("{}")})
+			continue
+		case fixtureExit:
+			return 0
+		}
+		if req.TraceIndex == fixtureFailIndex {
+			_ = out.Encode(Response{ID: req.ID, Error: "no trace"})
+			continue
+		}
+		var result any
+		switch req.Method {
+		case MethodAbsolutePrestate:
+			result = PrestateResult{Commitment: fixturePrestate}
+		case MethodGet:
+			result = GetResult{Claim: fixtureClaim(req.TraceIndex)}
+		case MethodGetStepData:
+			step := StepDataResult{
+				StateData: fixtureState(req.TraceIndex),
+				ProofData: []byte{0xbb},
+			}
+			if req.TraceIndex == fixtureOracleIndex {
+				step.OracleKey = fixtureOracleKey
+				step.OracleValue = fixtureOracleValue
+				step.OracleOffset = 4
+			}
+			result = step
+		default:
+			_ = out.Encode(Response{ID: req.ID, Error: "unknown method " + req.Method})
+			continue
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return 1
+		}
+		_ = out.Encode(Response{ID: req.ID, Result: data})
+	}
+	return 0
+}
+
+var (
+	fixtureOracleKey = common.Hash{0x02, 0xcc}.Bytes()
+	// fixtureOracleValue is the pre-image 0x010203 with its length prefix.
+	fixtureOracleValue = append(binary.BigEndian.AppendUint64(nil, 3), 1, 2, 3)
+)
+
+func fixtureState(traceIndex uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, traceIndex)
+}
+
+func fixtureClaim(traceIndex uint64) common.Hash {
+	return crypto.Keccak256Hash(fixtureState(traceIndex + 1))
+}
@@ -0,0 +1,60 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// prestateDir is the directory in the data dir the external trace provider is run in to load the absolute pre-state.
+const prestateDir = "external-prestate"
+
+var _ types.PrestateProvider = (*ExternalPrestateProvider)(nil)
+
+// ExternalPrestateProvider loads the absolute pre-state from the external trace provider run without game parameters.
+// The provider is only run until the pre-state is loaded.
+type ExternalPrestateProvider struct {
+	process *Process
+
+	mu         sync.Mutex
+	commitment *common.Hash
+}
+
+func NewPrestateProvider(logger log.Logger, cfg *config.Config, processes *Processes) *ExternalPrestateProvider {
+	dir := filepath.Join(cfg.Datadir, prestateDir)
+	return &ExternalPrestateProvider{
+		process: NewProcess(logger, processes, cfg.ExternalCommand, cfg.ExternalArgs, dir, cfg.ExternalTimeout),
+	}
+}
+
+func (p *ExternalPrestateProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.commitment != nil {
+		return *p.commitment, nil
+	}
+	defer p.process.Close()
+	commitment, err := requestPrestate(ctx, p.process)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	p.commitment = &commitment
+	return commitment, nil
+}
+
+func requestPrestate(ctx context.Context, process *Process) (common.Hash, error) {
+	var result PrestateResult
+	if err := process.Request(ctx, MethodAbsolutePrestate, 0, &result); err != nil {
+		return common.Hash{}, fmt.Errorf("cannot load absolute pre-state: %w", err)
+	}
+	if result.Commitment == (common.Hash{}) {
+		return common.Hash{}, fmt.Errorf("cannot load absolute pre-state: %w: missing commitment", ErrProtocol)
+	}
+	return result.Commitment, nil
+}
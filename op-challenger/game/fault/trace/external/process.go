@@ -0,0 +1,292 @@
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrProtocol       = errors.New("external trace provider protocol error")
+	ErrTimeout        = errors.New("external trace provider timed out")
+	ErrProviderFailed = errors.New("external trace provider failed")
+	ErrClosed         = errors.New("external trace providers closed")
+)
+
+// dirCheckInterval is how often a running provider checks that its directory still exists.
+const dirCheckInterval = time.Minute
+
+// Processes tracks the running external trace providers started by its Process instances so they can all be
+// killed on shutdown. Once closed, no further providers are started.
+type Processes struct {
+	mu      sync.Mutex
+	closed  bool
+	running map[*runningProcess]struct{}
+}
+
+func NewProcesses() *Processes {
+	return &Processes{running: make(map[*runningProcess]struct{})}
+}
+
+// Close kills every running provider and prevents new providers being started.
+func (g *Processes) Close() error {
+	g.mu.Lock()
+	g.closed = true
+	running := make([]*runningProcess, 0, len(g.running))
+	for r := range g.running {
+		running = append(running, r)
+	}
+	g.mu.Unlock()
+	for _, r := range running {
+		r.stop()
+	}
+	return nil
+}
+
+// add tracks r until it is stopped, failing with ErrClosed if the providers have been closed.
+func (g *Processes) add(r *runningProcess) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return ErrClosed
+	}
+	g.running[r] = struct{}{}
+	r.onStop = func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		delete(g.running, r)
+	}
+	return nil
+}
+
+// Process runs an external trace provider and sends it requests one at a time.
+// The provider is started by the first request and restarted by the next request if it exits, times out or
+// violates the protocol. It is killed once its directory is removed, which happens when the game is complete,
+// or when processes is closed.
+type Process struct {
+	logger        log.Logger
+	processes     *Processes
+	command       string
+	args          []string
+	dir           string
+	timeout       time.Duration
+	checkInterval time.Duration
+
+	mu      sync.Mutex
+	nextID  uint64
+	running *runningProcess
+}
+
+// NewProcess creates a Process that runs command with args in dir, tracking the running provider in processes.
+// Requests fail with ErrTimeout if no response is received within timeout. A timeout of 0 disables the limit.
+func NewProcess(logger log.Logger, processes *Processes, command string, args []string, dir string, timeout time.Duration) *Process {
+	return &Process{
+		logger:        logger,
+		processes:     processes,
+		command:       command,
+		args:          args,
+		dir:           dir,
+		timeout:       timeout,
+		checkInterval: dirCheckInterval,
+	}
+}
+
+// Request sends a request for method at traceIndex and decodes the result into result.
+func (p *Process) Request(ctx context.Context, method string, traceIndex uint64, result any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	running, err := p.start()
+	if err != nil {
+		return err
+	}
+	p.nextID++
+	id := p.nextID
+	req, err := json.Marshal(Request{ID: id, Method: method, TraceIndex: traceIndex})
+	if err != nil {
+		return fmt.Errorf("failed to encode %v request: %w", method, err)
+	}
+	if _, err := running.stdin.Write(append(req, '\n')); err != nil {
+		p.stop()
+		return fmt.Errorf("failed to send %v request: %w", method, err)
+	}
+
+	var timeout <-chan time.Time
+	if p.timeout > 0 {
+		timer := time.NewTimer(p.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var line []byte
+	select {
+	case l, ok := <-running.lines:
+		if !ok {
+			p.stop()
+			return fmt.Errorf("%w: exited before responding to %v request", ErrProtocol, method)
+		}
+		line = l
+	case <-timeout:
+		// The response may still arrive so the process must be restarted to keep responses in order.
+		p.stop()
+		return fmt.Errorf("%w: no response to %v request after %v", ErrTimeout, method, p.timeout)
+	case <-ctx.Done():
+		p.stop()
+		return ctx.Err()
+	}
+
+	var resp Response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		p.stop()
+		return fmt.Errorf("%w: invalid response to %v request: %w", ErrProtocol, method, err)
+	}
+	if resp.ID != id {
+		p.stop()
+		return fmt.Errorf("%w: response id %v does not match request id %v", ErrProtocol, resp.ID, id)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("%w: %v request: %v", ErrProviderFailed, method, resp.Error)
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		p.stop()
+		return fmt.Errorf("%w: invalid %v result: %w", ErrProtocol, method, err)
+	}
+	return nil
+}
+
+// Close kills the provider if it is running.
+func (p *Process) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stop()
+	return nil
+}
+
+func (p *Process) start() (*runningProcess, error) {
+	if p.running != nil && !p.running.stopped() {
+		return p.running, nil
+	}
+	p.running = nil
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create external trace provider directory %v: %w", p.dir, err)
+	}
+	cmd := exec.Command(p.command, p.args...)
+	cmd.Dir = p.dir
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open external trace provider stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open external trace provider stdout: %w", err)
+	}
+	stderr := oplog.NewWriter(p.logger, log.LevelInfo)
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		_ = stderr.Close()
+		return nil, fmt.Errorf("failed to start external trace provider %v: %w", p.command, err)
+	}
+	p.logger.Info("Started external trace provider", "cmd", p.command, "args", strings.Join(p.args, ", "), "pid", cmd.Process.Pid)
+	running := &runningProcess{
+		cmd:    cmd,
+		stdin:  stdin,
+		stderr: stderr,
+		lines:  make(chan []byte),
+		done:   make(chan struct{}),
+	}
+	if err := p.processes.add(running); err != nil {
+		running.stop()
+		return nil, err
+	}
+	go running.readLines(stdout)
+	go p.stopWhenDirRemoved(running)
+	p.running = running
+	return running, nil
+}
+
+func (p *Process) stop() {
+	if p.running == nil {
+		return
+	}
+	p.running.stop()
+	p.running = nil
+}
+
+// stopWhenDirRemoved kills running once the provider's directory has been removed.
+// Directories are removed when their game is complete so the provider is no longer required.
+func (p *Process) stopWhenDirRemoved(running *runningProcess) {
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-running.done:
+			return
+		case <-ticker.C:
+			if _, err := os.Stat(p.dir); errors.Is(err, os.ErrNotExist) {
+				p.logger.Info("Directory removed, stopping external trace provider", "dir", p.dir, "pid", running.cmd.Process.Pid)
+				running.stop()
+				return
+			}
+		}
+	}
+}
+
+type runningProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr io.Closer
+	lines  chan []byte
+	done   chan struct{}
+	once   sync.Once
+
+	// onStop is called once the process has been killed.
+	onStop func()
+}
+
+// readLines sends each line the provider writes to stdout to lines, closing lines once stdout is closed.
+func (r *runningProcess) readLines(stdout io.Reader) {
+	defer close(r.lines)
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		select {
+		case r.lines <- line:
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *runningProcess) stopped() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *runningProcess) stop() {
+	r.once.Do(func() {
+		close(r.done)
+		_ = r.stdin.Close()
+		_ = r.cmd.Process.Kill()
+		_ = r.cmd.Wait()
+		_ = r.stderr.Close()
+		if r.onStop != nil {
+			r.onStop()
+		}
+	})
+}
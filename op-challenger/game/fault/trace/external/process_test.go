@@ -0,0 +1,154 @@
+package external
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	t.Run("Request", func(t *testing.T) {
+		p, dir := newFixtureProcess(t, fixtureValid, time.Minute)
+		for i := uint64(0); i < 3; i++ {
+			var result GetResult
+			require.NoError(t, p.Request(context.Background(), MethodGet, i, &result))
+			require.Equal(t, fixtureClaim(i), result.Claim)
+		}
+		require.Equal(t, 1, fixtureStarts(t, dir), "should reuse the running process")
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		p, dir := newFixtureProcess(t, fixtureValid, time.Minute)
+		var result GetResult
+		err := p.Request(context.Background(), MethodGet, fixtureFailIndex, &result)
+		require.ErrorIs(t, err, ErrProviderFailed)
+		require.ErrorContains(t, err, "no trace")
+
+		require.NoError(t, p.Request(context.Background(), MethodGet, 1, &result))
+		require.Equal(t, 1, fixtureStarts(t, dir), "should not restart after the provider reports an error")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		p, _ := newFixtureProcess(t, fixtureHang, 100*time.Millisecond)
+		var result GetResult
+		require.ErrorIs(t, p.Request(context.Background(), MethodGet, 1, &result), ErrTimeout)
+		require.Nil(t, p.running, "should kill the process")
+	})
+
+	t.Run("ContextDone", func(t *testing.T) {
+		p, _ := newFixtureProcess(t, fixtureHang, 0)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		var result GetResult
+		require.ErrorIs(t, p.Request(ctx, MethodGet, 1, &result), context.DeadlineExceeded)
+		require.Nil(t, p.running, "should kill the process")
+	})
+
+	t.Run("InvalidResponse", func(t *testing.T) {
+		p, _ := newFixtureProcess(t, fixtureGarbage, time.Minute)
+		var result GetResult
+		require.ErrorIs(t, p.Request(context.Background(), MethodGet, 1, &result), ErrProtocol)
+		require.Nil(t, p.running, "should kill the process")
+	})
+
+	t.Run("MismatchedID", func(t *testing.T) {
+		p, _ := newFixtureProcess(t, fixtureWrongID, time.Minute)
+		var result GetResult
+		err := p.Request(context.Background(), MethodGet, 1, &result)
+		require.ErrorIs(t, err, ErrProtocol)
+		require.ErrorContains(t, err, "does not match request id")
+	})
+
+	t.Run("ExitedRestarts", func(t *testing.T) {
+		p, dir := newFixtureProcess(t, fixtureExit, time.Minute)
+		var result GetResult
+		require.ErrorIs(t, p.Request(context.Background(), MethodGet, 1, &result), ErrProtocol)
+		require.ErrorIs(t, p.Request(context.Background(), MethodGet, 1, &result), ErrProtocol)
+		require.Equal(t, 2, fixtureStarts(t, dir))
+	})
+
+	t.Run("MissingCommand", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "provider")
+		p := NewProcess(testlog.Logger(t, log.LevelInfo), NewProcesses(), filepath.Join(dir, "missing"), nil, dir, time.Minute)
+		var result GetResult
+		require.ErrorContains(t, p.Request(context.Background(), MethodGet, 1, &result), "failed to start external trace provider")
+	})
+
+	t.Run("StopsWhenDirRemoved", func(t *testing.T) {
+		p, dir := newFixtureProcess(t, fixtureValid, time.Minute)
+		p.checkInterval = 10 * time.Millisecond
+		var result GetResult
+		require.NoError(t, p.Request(context.Background(), MethodGet, 1, &result))
+		running := p.running
+		require.NoError(t, os.RemoveAll(dir))
+		require.Eventually(t, func() bool {
+			return running.cmd.Process.Signal(syscall.Signal(0)) != nil
+		}, 10*time.Second, 10*time.Millisecond, "should kill the process")
+	})
+
+	t.Run("StopsWhenClosed", func(t *testing.T) {
+		processes := NewProcesses()
+		p1, _ := newGroupedFixtureProcess(t, processes, fixtureValid, time.Minute)
+		p2, _ := newGroupedFixtureProcess(t, processes, fixtureValid, time.Minute)
+		var result GetResult
+		require.NoError(t, p1.Request(context.Background(), MethodGet, 1, &result))
+		require.NoError(t, p2.Request(context.Background(), MethodGet, 1, &result))
+		running := []*runningProcess{p1.running, p2.running}
+		require.Len(t, processes.running, 2)
+
+		require.NoError(t, processes.Close())
+		for _, r := range running {
+			require.True(t, r.stopped(), "should kill the process")
+		}
+		require.Empty(t, processes.running)
+		require.ErrorIs(t, p1.Request(context.Background(), MethodGet, 1, &result), ErrClosed)
+	})
+
+	t.Run("UntracksStopped", func(t *testing.T) {
+		processes := NewProcesses()
+		p, _ := newGroupedFixtureProcess(t, processes, fixtureValid, time.Minute)
+		var result GetResult
+		require.NoError(t, p.Request(context.Background(), MethodGet, 1, &result))
+		require.Len(t, processes.running, 1)
+		require.NoError(t, p.Close())
+		require.Empty(t, processes.running)
+	})
+}
+
+// newFixtureProcess creates a Process running the test binary as an external trace provider in mode.
+func newFixtureProcess(t *testing.T, mode string, timeout time.Duration) (*Process, string) {
+	return newGroupedFixtureProcess(t, NewProcesses(), mode, timeout)
+}
+
+// newGroupedFixtureProcess creates a Process tracked by processes running the test binary as an external trace
+// provider in mode.
+func newGroupedFixtureProcess(t *testing.T, processes *Processes, mode string, timeout time.Duration) (*Process, string) {
+	t.Setenv(fixtureModeEnv, mode)
+	dir := filepath.Join(t.TempDir(), "provider")
+	p := NewProcess(testlog.Logger(t, log.LevelInfo), processes, fixtureCommand(t), nil, dir, timeout)
+	t.Cleanup(func() {
+		require.NoError(t, p.Close())
+	})
+	return p, dir
+}
+
+func fixtureCommand(t *testing.T) string {
+	cmd, err := os.Executable()
+	require.NoError(t, err)
+	return cmd
+}
+
+// fixtureStarts returns the number of times the fixture was started in dir.
+func fixtureStarts(t *testing.T, dir string) int {
+	data, err := os.ReadFile(filepath.Join(dir, "starts"))
+	require.NoError(t, err)
+	return strings.Count(string(data), "started\n")
+}
@@ -0,0 +1,58 @@
+package external
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// The external trace provider protocol is line delimited JSON.
+// The challenger writes one Request per line to the provider's stdin and the provider must write exactly one
+// Response per line to its stdout, in order. Anything the provider writes to stderr is logged.
+// The provider is started with the configured args, followed by the game parameters as flags,
+// with the directory it may store data in as its working directory.
+// It should exit when its stdin is closed.
+
+const (
+	// MethodAbsolutePrestate requests the commitment to the absolute pre-state. The result is a PrestateResult.
+	MethodAbsolutePrestate = "absolutePrestate"
+	// MethodGet requests the claim at a trace index. The result is a GetResult.
+	MethodGet = "get"
+	// MethodGetStepData requests the data required to step from a trace index. The result is a StepDataResult.
+	MethodGetStepData = "getStepData"
+)
+
+// Request is sent to the external trace provider.
+type Request struct {
+	ID         uint64 `json:"id"`
+	Method     string `json:"method"`
+	TraceIndex uint64 `json:"traceIndex"`
+}
+
+// Response is returned by the external trace provider.
+// The provider sets Error if it is unable to handle the request, otherwise Result holds the result of the method.
+type Response struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type PrestateResult struct {
+	Commitment common.Hash `json:"commitment"`
+}
+
+type GetResult struct {
+	Claim common.Hash `json:"claim"`
+}
+
+// StepDataResult is the pre-state and proof data for a step.
+// If the step reads from the pre-image oracle, OracleKey is set and OracleValue is the pre-image with its
+// 8 byte big endian length prefix.
+type StepDataResult struct {
+	StateData    hexutil.Bytes `json:"stateData"`
+	ProofData    hexutil.Bytes `json:"proofData"`
+	OracleKey    hexutil.Bytes `json:"oracleKey,omitempty"`
+	OracleValue  hexutil.Bytes `json:"oracleValue,omitempty"`
+	OracleOffset uint32        `json:"oracleOffset,omitempty"`
+}
@@ -0,0 +1,89 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var _ types.TraceProvider = (*ExternalTraceProvider)(nil)
+
+// ExternalTraceProvider provides the trace of a game from an external trace provider process.
+type ExternalTraceProvider struct {
+	logger    log.Logger
+	process   *Process
+	gameDepth types.Depth
+}
+
+func NewTraceProvider(logger log.Logger, cfg *config.Config, processes *Processes, localInputs cannon.LocalGameInputs, dir string, gameDepth types.Depth) *ExternalTraceProvider {
+	args := append(slices.Clone(cfg.ExternalArgs),
+		"--l1-head", localInputs.L1Head.Hex(),
+		"--l2-head", localInputs.L2Head.Hex(),
+		"--l2-output-root", localInputs.L2OutputRoot.Hex(),
+		"--l2-claim", localInputs.L2Claim.Hex(),
+		"--l2-block-number", localInputs.L2BlockNumber.Text(10),
+		"--trace-depth", strconv.FormatUint(uint64(gameDepth), 10),
+	)
+	return &ExternalTraceProvider{
+		logger:    logger,
+		process:   NewProcess(logger, processes, cfg.ExternalCommand, args, dir, cfg.ExternalTimeout),
+		gameDepth: gameDepth,
+	}
+}
+
+func (p *ExternalTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	traceIndex := pos.TraceIndex(p.gameDepth)
+	if !traceIndex.IsUint64() {
+		return common.Hash{}, errors.New("trace index out of bounds")
+	}
+	var result GetResult
+	if err := p.process.Request(ctx, MethodGet, traceIndex.Uint64(), &result); err != nil {
+		return common.Hash{}, err
+	}
+	if result.Claim == (common.Hash{}) {
+		return common.Hash{}, fmt.Errorf("%w: missing claim at trace index %v", ErrProtocol, traceIndex)
+	}
+	return result.Claim, nil
+}
+
+func (p *ExternalTraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	traceIndex := pos.TraceIndex(p.gameDepth)
+	if !traceIndex.IsUint64() {
+		return nil, nil, nil, errors.New("trace index out of bounds")
+	}
+	var result StepDataResult
+	if err := p.process.Request(ctx, MethodGetStepData, traceIndex.Uint64(), &result); err != nil {
+		return nil, nil, nil, err
+	}
+	if len(result.StateData) == 0 {
+		return nil, nil, nil, fmt.Errorf("%w: missing state data at trace index %v", ErrProtocol, traceIndex)
+	}
+	if result.ProofData == nil {
+		return nil, nil, nil, fmt.Errorf("%w: missing proof data at trace index %v", ErrProtocol, traceIndex)
+	}
+	var oracleData *types.PreimageOracleData
+	if len(result.OracleKey) > 0 {
+		if len(result.OracleValue) < 8 {
+			return nil, nil, nil, fmt.Errorf("%w: oracle value at trace index %v missing length prefix", ErrProtocol, traceIndex)
+		}
+		oracleData = types.NewPreimageOracleData(result.OracleKey, result.OracleValue, result.OracleOffset)
+	}
+	return result.StateData, result.ProofData, oracleData, nil
+}
+
+func (p *ExternalTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return requestPrestate(ctx, p.process)
+}
+
+// Close kills the external trace provider process if it is running.
+func (p *ExternalTraceProvider) Close() error {
+	return p.process.Close()
+}
@@ -0,0 +1,123 @@
+package external
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testGameDepth = types.Depth(10)
+
+func TestExternalTraceProvider(t *testing.T) {
+	t.Run("GameParameters", func(t *testing.T) {
+		provider, dir := setupProvider(t, fixtureValid)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(0)))
+		require.NoError(t, err)
+		args, err := os.ReadFile(filepath.Join(dir, "args"))
+		require.NoError(t, err)
+		require.Equal(t, strings.Join([]string{
+			"--network", "custom",
+			"--l1-head", common.Hash{0x11}.Hex(),
+			"--l2-head", common.Hash{0x22}.Hex(),
+			"--l2-output-root", common.Hash{0x33}.Hex(),
+			"--l2-claim", common.Hash{0x44}.Hex(),
+			"--l2-block-number", "3333",
+			"--trace-depth", "10",
+		}, " "), string(args))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		provider, _ := setupProvider(t, fixtureValid)
+		value, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(5)))
+		require.NoError(t, err)
+		require.Equal(t, fixtureClaim(5), value)
+	})
+
+	t.Run("GetStepData", func(t *testing.T) {
+		provider, _ := setupProvider(t, fixtureValid)
+		prestate, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, big.NewInt(2)))
+		require.NoError(t, err)
+		require.Equal(t, fixtureState(2), prestate)
+		require.Equal(t, []byte{0xbb}, proof)
+		require.Nil(t, data)
+	})
+
+	t.Run("GetStepDataWithPreimage", func(t *testing.T) {
+		provider, _ := setupProvider(t, fixtureValid)
+		_, _, data, err := provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, new(big.Int).SetUint64(fixtureOracleIndex)))
+		require.NoError(t, err)
+		require.NotNil(t, data)
+		require.Equal(t, fixtureOracleKey, data.OracleKey)
+		require.Equal(t, []byte{1, 2, 3}, data.GetPreimageWithoutSize())
+		require.Equal(t, uint32(4), data.OracleOffset)
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		provider, _ := setupProvider(t, fixtureValid)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, new(big.Int).SetUint64(fixtureFailIndex)))
+		require.ErrorIs(t, err, ErrProviderFailed)
+		_, _, _, err = provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, new(big.Int).SetUint64(fixtureFailIndex)))
+		require.ErrorIs(t, err, ErrProviderFailed)
+	})
+
+	t.Run("MissingResult", func(t *testing.T) {
+		provider, _ := setupProvider(t, fixtureWrongID)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(1)))
+		require.ErrorIs(t, err, ErrProtocol)
+	})
+
+	t.Run("AbsolutePreStateCommitment", func(t *testing.T) {
+		provider, _ := setupProvider(t, fixtureValid)
+		commitment, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, fixturePrestate, commitment)
+	})
+}
+
+func TestExternalPrestateProvider(t *testing.T) {
+	t.Setenv(fixtureModeEnv, fixtureValid)
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8545", "http://localhost:9000", t.TempDir(), config.TraceTypeExternal)
+	cfg.ExternalCommand = fixtureCommand(t)
+	provider := NewPrestateProvider(testlog.Logger(t, log.LevelInfo), &cfg, NewProcesses())
+
+	for i := 0; i < 2; i++ {
+		commitment, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, fixturePrestate, commitment)
+		require.Nil(t, provider.process.running, "should stop the provider once the pre-state is loaded")
+	}
+	require.Equal(t, 1, fixtureStarts(t, filepath.Join(cfg.Datadir, prestateDir)), "should cache the pre-state")
+}
+
+func setupProvider(t *testing.T, mode string) (*ExternalTraceProvider, string) {
+	t.Setenv(fixtureModeEnv, mode)
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8545", "http://localhost:9000", t.TempDir(), config.TraceTypeExternal)
+	cfg.ExternalCommand = fixtureCommand(t)
+	cfg.ExternalArgs = []string{"--network", "custom"}
+	cfg.ExternalTimeout = time.Minute
+	inputs := cannon.LocalGameInputs{
+		L1Head:        common.Hash{0x11},
+		L2Head:        common.Hash{0x22},
+		L2OutputRoot:  common.Hash{0x33},
+		L2Claim:       common.Hash{0x44},
+		L2BlockNumber: big.NewInt(3333),
+	}
+	dir := filepath.Join(cfg.Datadir, "provider")
+	provider := NewTraceProvider(testlog.Logger(t, log.LevelInfo), &cfg, NewProcesses(), inputs, dir, testGameDepth)
+	t.Cleanup(func() {
+		require.NoError(t, provider.Close())
+	})
+	return provider, dir
+}
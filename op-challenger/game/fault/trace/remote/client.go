@@ -0,0 +1,101 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// maxAttempts is the number of times a request that fails with a transient error is made before giving up.
+const maxAttempts = 5
+
+var (
+	ErrRequestFailed = errors.New("remote trace request failed")
+	ErrProtocol      = errors.New("invalid remote trace response")
+)
+
+// Client requests trace data from a remote trace service.
+type Client struct {
+	url      string
+	client   *http.Client
+	strategy retry.Strategy
+}
+
+func NewClient(url string, client *http.Client) *Client {
+	return &Client{
+		url:      strings.TrimSuffix(url, "/"),
+		client:   client,
+		strategy: retry.Exponential(),
+	}
+}
+
+// Claim returns the claim at traceIndex of the sub-game identified by query.
+func (c *Client) Claim(ctx context.Context, traceIndex uint64, query url.Values) (ClaimResponse, error) {
+	var resp ClaimResponse
+	err := c.get(ctx, "claim", traceIndex, query, &resp)
+	return resp, err
+}
+
+// Step returns the data required to step from traceIndex of the sub-game identified by query.
+func (c *Client) Step(ctx context.Context, traceIndex uint64, query url.Values) (StepResponse, error) {
+	var resp StepResponse
+	err := c.get(ctx, "step", traceIndex, query, &resp)
+	return resp, err
+}
+
+// get requests <url>/<method>/<traceIndex> and decodes the response into result, retrying transient failures.
+func (c *Client) get(ctx context.Context, method string, traceIndex uint64, query url.Values, result any) error {
+	reqURL := c.url + "/" + method + "/" + strconv.FormatUint(traceIndex, 10) + "?" + query.Encode()
+	var body []byte
+	for attempt := 0; ; attempt++ {
+		var transient bool
+		var err error
+		body, transient, err = c.request(ctx, reqURL)
+		if err == nil {
+			break
+		}
+		if !transient || attempt == maxAttempts-1 {
+			return fmt.Errorf("%v request for trace index %v: %w", method, traceIndex, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.strategy.Duration(attempt)):
+		}
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("%w: %v response for trace index %v: %w", ErrProtocol, method, traceIndex, err)
+	}
+	return nil
+}
+
+// request makes a single request, reporting whether a failure is transient and may be retried.
+func (c *Client) request(ctx context.Context, reqURL string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		transient := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return nil, transient, fmt.Errorf("%w: status %v", ErrRequestFailed, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: failed to read response: %w", ErrRequestFailed, err)
+	}
+	return body, false, nil
+}
@@ -0,0 +1,35 @@
+package remote
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// The remote trace service serves the trace below the split depth of output root games over HTTP.
+// The challenger requests GET <url>/claim/<trace-index> and GET <url>/step/<trace-index>, identifying the
+// sub-game with the query parameters below, and the service must respond with a ClaimResponse or StepResponse
+// as JSON. Server errors and rate limiting are retried, any other non-200 status fails the request.
+
+const (
+	QueryL1Head        = "l1Head"
+	QueryL2Head        = "l2Head"
+	QueryL2OutputRoot  = "l2OutputRoot"
+	QueryL2Claim       = "l2Claim"
+	QueryL2BlockNumber = "l2BlockNumber"
+	QueryTraceDepth    = "traceDepth"
+)
+
+type ClaimResponse struct {
+	Claim common.Hash `json:"claim"`
+}
+
+// StepResponse is the pre-state and proof data for a step.
+// If the step reads from the pre-image oracle, OracleKey is set and OracleValue is the pre-image with its
+// 8 byte big endian length prefix.
+type StepResponse struct {
+	Prestate     hexutil.Bytes `json:"prestate"`
+	ProofData    hexutil.Bytes `json:"proofData"`
+	OracleKey    hexutil.Bytes `json:"oracleKey,omitempty"`
+	OracleValue  hexutil.Bytes `json:"oracleValue,omitempty"`
+	OracleOffset uint32        `json:"oracleOffset,omitempty"`
+}
@@ -0,0 +1,138 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// claimsFile is the file in the provider's directory that fetched claims are cached in.
+const claimsFile = "claims.json"
+
+var _ types.TraceProvider = (*RemoteTraceProvider)(nil)
+
+// RemoteTraceProvider provides the trace of a game from a remote trace service.
+// Claims are cached in the provider's directory so they are only fetched once, even across restarts.
+type RemoteTraceProvider struct {
+	logger    log.Logger
+	client    *Client
+	prestate  types.PrestateProvider
+	query     url.Values
+	dir       string
+	gameDepth types.Depth
+
+	lock   sync.Mutex
+	claims map[uint64]common.Hash // Loaded from the cache file on first use
+}
+
+func NewTraceProvider(logger log.Logger, client *Client, prestate types.PrestateProvider, localInputs cannon.LocalGameInputs, dir string, gameDepth types.Depth) *RemoteTraceProvider {
+	query := url.Values{}
+	query.Set(QueryL1Head, localInputs.L1Head.Hex())
+	query.Set(QueryL2Head, localInputs.L2Head.Hex())
+	query.Set(QueryL2OutputRoot, localInputs.L2OutputRoot.Hex())
+	query.Set(QueryL2Claim, localInputs.L2Claim.Hex())
+	query.Set(QueryL2BlockNumber, localInputs.L2BlockNumber.Text(10))
+	query.Set(QueryTraceDepth, strconv.FormatUint(uint64(gameDepth), 10))
+	return &RemoteTraceProvider{
+		logger:    logger,
+		client:    client,
+		prestate:  prestate,
+		query:     query,
+		dir:       dir,
+		gameDepth: gameDepth,
+	}
+}
+
+func (p *RemoteTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	traceIndex := pos.TraceIndex(p.gameDepth)
+	if !traceIndex.IsUint64() {
+		return common.Hash{}, errors.New("trace index out of bounds")
+	}
+	idx := traceIndex.Uint64()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := p.loadClaims(); err != nil {
+		return common.Hash{}, err
+	}
+	if claim, ok := p.claims[idx]; ok {
+		return claim, nil
+	}
+	resp, err := p.client.Claim(ctx, idx, p.query)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if resp.Claim == (common.Hash{}) {
+		return common.Hash{}, fmt.Errorf("%w: missing claim at trace index %v", ErrProtocol, idx)
+	}
+	p.claims[idx] = resp.Claim
+	if err := jsonutil.WriteJSON(filepath.Join(p.dir, claimsFile), p.claims, 0o644); err != nil {
+		// The claim is still valid, it will just be fetched again after a restart.
+		p.logger.Warn("Failed to cache remote trace claim", "traceIndex", idx, "err", err)
+	}
+	return resp.Claim, nil
+}
+
+func (p *RemoteTraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	traceIndex := pos.TraceIndex(p.gameDepth)
+	if !traceIndex.IsUint64() {
+		return nil, nil, nil, errors.New("trace index out of bounds")
+	}
+	idx := traceIndex.Uint64()
+	resp, err := p.client.Step(ctx, idx, p.query)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(resp.Prestate) == 0 {
+		return nil, nil, nil, fmt.Errorf("%w: missing prestate at trace index %v", ErrProtocol, idx)
+	}
+	if resp.ProofData == nil {
+		return nil, nil, nil, fmt.Errorf("%w: missing proof data at trace index %v", ErrProtocol, idx)
+	}
+	var oracleData *types.PreimageOracleData
+	if len(resp.OracleKey) > 0 {
+		if len(resp.OracleValue) < 8 {
+			return nil, nil, nil, fmt.Errorf("%w: oracle value at trace index %v missing length prefix", ErrProtocol, idx)
+		}
+		oracleData = types.NewPreimageOracleData(resp.OracleKey, resp.OracleValue, resp.OracleOffset)
+	}
+	return resp.Prestate, resp.ProofData, oracleData, nil
+}
+
+func (p *RemoteTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return p.prestate.AbsolutePreStateCommitment(ctx)
+}
+
+// loadClaims loads the cached claims if they haven't been loaded yet. The caller must hold the lock.
+func (p *RemoteTraceProvider) loadClaims() error {
+	if p.claims != nil {
+		return nil
+	}
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return fmt.Errorf("could not create remote trace directory %v: %w", p.dir, err)
+	}
+	path := filepath.Join(p.dir, claimsFile)
+	p.claims = make(map[uint64]common.Hash)
+	claims, err := jsonutil.LoadJSON[map[uint64]common.Hash](path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		// Claims can always be fetched again so discard an unreadable cache rather than failing the game.
+		p.logger.Warn("Discarding unreadable remote trace claim cache", "path", path, "err", err)
+		return nil
+	}
+	for idx, claim := range *claims {
+		p.claims[idx] = claim
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGameDepth = types.Depth(10)
+
+	// Trace indices the canned trace service responds to specially
+	oracleIndex       = 7
+	missingIndex      = 8
+	malformedIndex    = 9
+	notFoundIndex     = 10
+	unavailableIndex  = 11
+	flakyIndex        = 12
+	flakyFailures     = 2
+	missingProofIndex = 13
+)
+
+var testPrestate = common.Hash{0xaa}
+
+func TestRemoteTraceProvider(t *testing.T) {
+	t.Run("GameParameters", func(t *testing.T) {
+		provider, server, _ := setupProvider(t)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, url.Values{
+			QueryL1Head:        []string{common.Hash{0x11}.Hex()},
+			QueryL2Head:        []string{common.Hash{0x22}.Hex()},
+			QueryL2OutputRoot:  []string{common.Hash{0x33}.Hex()},
+			QueryL2Claim:       []string{common.Hash{0x44}.Hex()},
+			QueryL2BlockNumber: []string{"3333"},
+			QueryTraceDepth:    []string{"10"},
+		}, server.lastQuery())
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		provider, _, _ := setupProvider(t)
+		claim, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(5)))
+		require.NoError(t, err)
+		require.Equal(t, cannedClaim(5), claim)
+	})
+
+	t.Run("GetCachesClaims", func(t *testing.T) {
+		provider, server, dir := setupProvider(t)
+		for i := 0; i < 2; i++ {
+			claim, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(5)))
+			require.NoError(t, err)
+			require.Equal(t, cannedClaim(5), claim)
+		}
+		require.Equal(t, 1, server.requestCount("/claim/5"), "should only fetch the claim once")
+
+		// A new provider for the same game loads the claim from the cache
+		reloaded := NewTraceProvider(testlog.Logger(t, log.LevelInfo), provider.client, provider.prestate, testInputs, dir, testGameDepth)
+		claim, err := reloaded.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(5)))
+		require.NoError(t, err)
+		require.Equal(t, cannedClaim(5), claim)
+		require.Equal(t, 1, server.requestCount("/claim/5"), "should load the claim from the cache")
+	})
+
+	t.Run("IgnoreCorruptCache", func(t *testing.T) {
+		provider, server, dir := setupProvider(t)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, claimsFile), []byte("not json"), 0o644))
+		claim, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(5)))
+		require.NoError(t, err)
+		require.Equal(t, cannedClaim(5), claim)
+		require.Equal(t, 1, server.requestCount("/claim/5"))
+	})
+
+	t.Run("GetStepData", func(t *testing.T) {
+		provider, _, _ := setupProvider(t)
+		prestate, proof, data, err := provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, big.NewInt(2)))
+		require.NoError(t, err)
+		require.Equal(t, cannedState(2), prestate)
+		require.Equal(t, []byte{0xbb}, proof)
+		require.Nil(t, data)
+	})
+
+	t.Run("GetStepDataWithPreimage", func(t *testing.T) {
+		provider, _, _ := setupProvider(t)
+		_, _, data, err := provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, big.NewInt(oracleIndex)))
+		require.NoError(t, err)
+		require.NotNil(t, data)
+		require.Equal(t, []byte{0x02, 0x01}, data.OracleKey)
+		require.Equal(t, []byte{1, 2, 3}, data.GetPreimageWithoutSize())
+		require.Equal(t, uint32(4), data.OracleOffset)
+	})
+
+	t.Run("MissingClaim", func(t *testing.T) {
+		provider, _, _ := setupProvider(t)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(missingIndex)))
+		require.ErrorIs(t, err, ErrProtocol)
+	})
+
+	t.Run("MissingStepData", func(t *testing.T) {
+		provider, _, _ := setupProvider(t)
+		_, _, _, err := provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, big.NewInt(missingIndex)))
+		require.ErrorIs(t, err, ErrProtocol)
+		_, _, _, err = provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, big.NewInt(missingProofIndex)))
+		require.ErrorIs(t, err, ErrProtocol)
+	})
+
+	t.Run("MalformedResponse", func(t *testing.T) {
+		provider, server, _ := setupProvider(t)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(malformedIndex)))
+		require.ErrorIs(t, err, ErrProtocol)
+		_, _, _, err = provider.GetStepData(context.Background(), types.NewPosition(testGameDepth, big.NewInt(malformedIndex)))
+		require.ErrorIs(t, err, ErrProtocol)
+		require.Equal(t, 1, server.requestCount("/claim/"+strconv.Itoa(malformedIndex)), "should not retry malformed responses")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		provider, server, _ := setupProvider(t)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(notFoundIndex)))
+		require.ErrorIs(t, err, ErrRequestFailed)
+		require.ErrorContains(t, err, "404")
+		require.Equal(t, 1, server.requestCount("/claim/"+strconv.Itoa(notFoundIndex)), "should not retry client errors")
+	})
+
+	t.Run("RetryTransientFailures", func(t *testing.T) {
+		provider, server, _ := setupProvider(t)
+		claim, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(flakyIndex)))
+		require.NoError(t, err)
+		require.Equal(t, cannedClaim(flakyIndex), claim)
+		require.Equal(t, flakyFailures+1, server.requestCount("/claim/"+strconv.Itoa(flakyIndex)))
+	})
+
+	t.Run("GiveUpAfterMaxAttempts", func(t *testing.T) {
+		provider, server, _ := setupProvider(t)
+		_, err := provider.Get(context.Background(), types.NewPosition(testGameDepth, big.NewInt(unavailableIndex)))
+		require.ErrorIs(t, err, ErrRequestFailed)
+		require.Equal(t, maxAttempts, server.requestCount("/claim/"+strconv.Itoa(unavailableIndex)))
+	})
+
+	t.Run("AbsolutePreStateCommitment", func(t *testing.T) {
+		provider, _, _ := setupProvider(t)
+		commitment, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, testPrestate, commitment)
+	})
+}
+
+var testInputs = cannon.LocalGameInputs{
+	L1Head:        common.Hash{0x11},
+	L2Head:        common.Hash{0x22},
+	L2OutputRoot:  common.Hash{0x33},
+	L2Claim:       common.Hash{0x44},
+	L2BlockNumber: big.NewInt(3333),
+}
+
+func setupProvider(t *testing.T) (*RemoteTraceProvider, *cannedTraceServer, string) {
+	server := &cannedTraceServer{requests: make(map[string]int)}
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.URL, srv.Client())
+	client.strategy = retry.Fixed(0)
+	dir := filepath.Join(t.TempDir(), "provider")
+	provider := NewTraceProvider(testlog.Logger(t, log.LevelInfo), client, &stubPrestateProvider{testPrestate}, testInputs, dir, testGameDepth)
+	return provider, server, dir
+}
+
+func cannedClaim(i uint64) common.Hash {
+	return common.Hash{0xcc, byte(i)}
+}
+
+func cannedState(i uint64) []byte {
+	return []byte{0xdd, byte(i)}
+}
+
+// cannedTraceServer serves a canned trace, with special responses for some trace indices.
+type cannedTraceServer struct {
+	lock     sync.Mutex
+	requests map[string]int
+	query    url.Values
+}
+
+func (s *cannedTraceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	s.requests[r.URL.Path]++
+	count := s.requests[r.URL.Path]
+	s.query = r.URL.Query()
+	s.lock.Unlock()
+
+	method, idxStr, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	idx, err := strconv.ParseUint(idxStr, 10, 64)
+	if !ok || err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch idx {
+	case malformedIndex:
+		_, _ = w.Write([]byte(`{"claim": "0x12`))
+		return
+	case notFoundIndex:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case unavailableIndex:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	case flakyIndex:
+		if count <= flakyFailures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+	var resp any
+	switch {
+	case method == "claim" && idx == missingIndex:
+		resp = ClaimResponse{}
+	case method == "claim":
+		resp = ClaimResponse{Claim: cannedClaim(idx)}
+	case method == "step" && idx == missingIndex:
+		resp = StepResponse{ProofData: []byte{0xbb}}
+	case method == "step" && idx == missingProofIndex:
+		// Empty proof data is valid so omit the field entirely
+		resp = map[string]any{"prestate": hexutil.Bytes(cannedState(idx))}
+	case method == "step" && idx == oracleIndex:
+		resp = StepResponse{
+			Prestate:     cannedState(idx),
+			ProofData:    []byte{0xbb},
+			OracleKey:    []byte{0x02, 0x01},
+			OracleValue:  []byte{0, 0, 0, 0, 0, 0, 0, 3, 1, 2, 3},
+			OracleOffset: 4,
+		}
+	case method == "step":
+		resp = StepResponse{Prestate: cannedState(idx), ProofData: []byte{0xbb}}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *cannedTraceServer) requestCount(path string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests[path]
+}
+
+func (s *cannedTraceServer) lastQuery() url.Values {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.query
+}
+
+type stubPrestateProvider struct {
+	commitment common.Hash
+}
+
+func (s *stubPrestateProvider) AbsolutePreStateCommitment(_ context.Context) (common.Hash, error) {
+	return s.commitment, nil
+}
@@ -19,9 +19,12 @@ var (
 )
 
 const (
-	CannonGameType       uint32 = 0
-	PermissionedGameType uint32 = 1
-	AlphabetGameType     uint32 = 255
+	CannonGameType               uint32 = 0
+	PermissionedGameType         uint32 = 1
+	AsteriscGameType             uint32 = 2
+	CartesiGameType              uint32 = 3
+	PermissionedAlphabetGameType uint32 = 254
+	AlphabetGameType             uint32 = 255
 )
 
 type ClockReader interface {
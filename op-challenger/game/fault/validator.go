@@ -3,16 +3,33 @@ package fault
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 )
 
+var (
+	ErrUnexpectedGameParams = errors.New("unexpected game parameters")
+	ErrIncompatibleOracle   = errors.New("incompatible preimage oracle")
+)
+
 type PrestateLoader = func(ctx context.Context) (common.Hash, error)
 
+// knownHash returns a PrestateLoader for a hash that has already been read from the game contract.
+func knownHash(hash common.Hash) PrestateLoader {
+	return func(context.Context) (common.Hash, error) {
+		return hash, nil
+	}
+}
+
 type Validator interface {
 	Validate(ctx context.Context) error
 }
@@ -48,3 +65,116 @@ func (v *PrestateValidator) Validate(ctx context.Context) error {
 	}
 	return nil
 }
+
+type GameParamsMetricer interface {
+	RecordUnexpectedGameParams(gameType uint32)
+}
+
+// GameParamsContract provides the parameters a game was deployed with.
+type GameParamsContract interface {
+	GetMaxGameDepth(ctx context.Context) (types.Depth, error)
+	GetSplitDepth(ctx context.Context) (types.Depth, error)
+	GetGameDuration(ctx context.Context) (uint64, error)
+}
+
+var _ Validator = (*GameParamsValidator)(nil)
+
+// GameParamsValidator refuses games deployed with parameters other than the expected ones,
+// so that games with unplayable parameters don't consume bonds.
+type GameParamsValidator struct {
+	m        GameParamsMetricer
+	gameType uint32
+	contract GameParamsContract
+	expected config.GameParams
+}
+
+func NewGameParamsValidator(m GameParamsMetricer, gameType uint32, contract GameParamsContract, expected config.GameParams) *GameParamsValidator {
+	return &GameParamsValidator{
+		m:        m,
+		gameType: gameType,
+		contract: contract,
+		expected: expected,
+	}
+}
+
+func (v *GameParamsValidator) Validate(ctx context.Context) error {
+	if v.expected.MaxGameDepth != 0 {
+		maxGameDepth, err := v.contract.GetMaxGameDepth(ctx)
+		if err != nil {
+			return err
+		}
+		if uint64(maxGameDepth) != v.expected.MaxGameDepth {
+			return v.unexpected("max game depth", v.expected.MaxGameDepth, maxGameDepth)
+		}
+	}
+	if v.expected.SplitDepth != 0 {
+		splitDepth, err := v.contract.GetSplitDepth(ctx)
+		if err != nil {
+			return err
+		}
+		if uint64(splitDepth) != v.expected.SplitDepth {
+			return v.unexpected("split depth", v.expected.SplitDepth, splitDepth)
+		}
+	}
+	if v.expected.GameDuration != 0 {
+		gameDuration, err := v.contract.GetGameDuration(ctx)
+		if err != nil {
+			return err
+		}
+		if actual := time.Duration(gameDuration) * time.Second; actual != v.expected.GameDuration {
+			return v.unexpected("game duration", v.expected.GameDuration, actual)
+		}
+	}
+	return nil
+}
+
+func (v *GameParamsValidator) unexpected(param string, expected any, actual any) error {
+	v.m.RecordUnexpectedGameParams(v.gameType)
+	return fmt.Errorf("%w: %v expected %v but was %v", ErrUnexpectedGameParams, param, expected, actual)
+}
+
+// OracleParamsContract provides the parameters of a preimage oracle.
+type OracleParamsContract interface {
+	Addr() common.Address
+	Version(ctx context.Context) (string, error)
+	ChallengePeriod(ctx context.Context) (uint64, error)
+	GetMinBondLPP(ctx context.Context) (*big.Int, error)
+}
+
+// ValidateOracle checks the preimage oracle used by gameType has the expected parameters, returning
+// ErrIncompatibleOracle if it does not. The oracle's parameters are logged whether or not they match.
+// Oracles that don't report a version are only rejected if a version is expected.
+func ValidateOracle(ctx context.Context, logger log.Logger, gameType uint32, oracle OracleParamsContract, expected config.OracleParams) error {
+	challengePeriod, err := oracle.ChallengePeriod(ctx)
+	if err != nil {
+		return err
+	}
+	minBond, err := oracle.GetMinBondLPP(ctx)
+	if err != nil {
+		return err
+	}
+	version, versionErr := oracle.Version(ctx)
+	loggedVersion := version
+	if versionErr != nil {
+		loggedVersion = "unknown"
+	}
+	period := time.Duration(challengePeriod) * time.Second
+	logger.Info("Preimage oracle parameters", "gameType", gameType, "oracle", oracle.Addr(),
+		"version", loggedVersion, "challengePeriod", period, "minBond", minBond)
+
+	if expected.Version != "" {
+		if versionErr != nil {
+			return fmt.Errorf("%w: version expected %v but could not be read: %w", ErrIncompatibleOracle, expected.Version, versionErr)
+		}
+		if version != expected.Version {
+			return fmt.Errorf("%w: version expected %v but was %v", ErrIncompatibleOracle, expected.Version, version)
+		}
+	}
+	if expected.ChallengePeriod != 0 && period != expected.ChallengePeriod {
+		return fmt.Errorf("%w: challenge period expected %v but was %v", ErrIncompatibleOracle, expected.ChallengePeriod, period)
+	}
+	if expected.MinBond != 0 && minBond.Cmp(new(big.Int).SetUint64(expected.MinBond)) != 0 {
+		return fmt.Errorf("%w: min bond expected %v but was %v", ErrIncompatibleOracle, expected.MinBond, minBond)
+	}
+	return nil
+}
@@ -3,13 +3,18 @@ package fault
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,6 +64,177 @@ func TestValidate(t *testing.T) {
 	})
 }
 
+func TestValidateGameParams(t *testing.T) {
+	expected := config.GameParams{
+		MaxGameDepth: 73,
+		SplitDepth:   32,
+		GameDuration: 7 * 24 * time.Hour,
+	}
+	matching := func() *stubGameParamsContract {
+		return &stubGameParamsContract{
+			maxGameDepth: 73,
+			splitDepth:   32,
+			gameDuration: uint64((7 * 24 * time.Hour).Seconds()),
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		m := &stubGameParamsMetrics{}
+		validator := NewGameParamsValidator(m, types.CannonGameType, matching(), expected)
+		require.NoError(t, validator.Validate(context.Background()))
+		require.Empty(t, m.unexpected)
+	})
+
+	tests := []struct {
+		name   string
+		modify func(c *stubGameParamsContract)
+	}{
+		{"MaxGameDepth", func(c *stubGameParamsContract) { c.maxGameDepth = 50 }},
+		{"SplitDepth", func(c *stubGameParamsContract) { c.splitDepth = 14 }},
+		{"GameDuration", func(c *stubGameParamsContract) { c.gameDuration = 60 }},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run("Unexpected"+test.name, func(t *testing.T) {
+			m := &stubGameParamsMetrics{}
+			contract := matching()
+			test.modify(contract)
+			validator := NewGameParamsValidator(m, types.CannonGameType, contract, expected)
+			require.ErrorIs(t, validator.Validate(context.Background()), ErrUnexpectedGameParams)
+			require.Equal(t, []uint32{types.CannonGameType}, m.unexpected)
+		})
+
+		t.Run("Unchecked"+test.name, func(t *testing.T) {
+			m := &stubGameParamsMetrics{}
+			contract := matching()
+			test.modify(contract)
+			validator := NewGameParamsValidator(m, types.CannonGameType, contract, config.GameParams{})
+			require.NoError(t, validator.Validate(context.Background()))
+			require.Empty(t, m.unexpected)
+		})
+	}
+
+	t.Run("ContractErrors", func(t *testing.T) {
+		m := &stubGameParamsMetrics{}
+		contract := matching()
+		contract.err = mockLoaderError
+		validator := NewGameParamsValidator(m, types.CannonGameType, contract, expected)
+		require.ErrorIs(t, validator.Validate(context.Background()), mockLoaderError)
+		require.Empty(t, m.unexpected)
+	})
+}
+
+type stubGameParamsMetrics struct {
+	unexpected []uint32
+}
+
+func (s *stubGameParamsMetrics) RecordUnexpectedGameParams(gameType uint32) {
+	s.unexpected = append(s.unexpected, gameType)
+}
+
+type stubGameParamsContract struct {
+	err          error
+	maxGameDepth types.Depth
+	splitDepth   types.Depth
+	gameDuration uint64
+}
+
+func (s *stubGameParamsContract) GetMaxGameDepth(_ context.Context) (types.Depth, error) {
+	return s.maxGameDepth, s.err
+}
+
+func (s *stubGameParamsContract) GetSplitDepth(_ context.Context) (types.Depth, error) {
+	return s.splitDepth, s.err
+}
+
+func (s *stubGameParamsContract) GetGameDuration(_ context.Context) (uint64, error) {
+	return s.gameDuration, s.err
+}
+
+func TestValidateOracle(t *testing.T) {
+	expected := config.OracleParams{
+		Version:         "1.0.0",
+		ChallengePeriod: 24 * time.Hour,
+		MinBond:         1000,
+	}
+	matching := func() *stubOracleParamsContract {
+		return &stubOracleParamsContract{
+			version:         "1.0.0",
+			challengePeriod: uint64((24 * time.Hour).Seconds()),
+			minBond:         1000,
+		}
+	}
+	validate := func(t *testing.T, contract *stubOracleParamsContract, expected config.OracleParams) error {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		err := ValidateOracle(context.Background(), logger, 4, contract, expected)
+		if contract.err == nil {
+			require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelInfo), testlog.NewMessageFilter("Preimage oracle parameters")),
+				"should log the oracle parameters")
+		}
+		return err
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, validate(t, matching(), expected))
+	})
+
+	tests := []struct {
+		name   string
+		modify func(c *stubOracleParamsContract)
+	}{
+		{"Version", func(c *stubOracleParamsContract) { c.version = "2.0.0" }},
+		{"VersionUnavailable", func(c *stubOracleParamsContract) { c.versionErr = mockLoaderError }},
+		{"ChallengePeriod", func(c *stubOracleParamsContract) { c.challengePeriod = 120 }},
+		{"MinBond", func(c *stubOracleParamsContract) { c.minBond = 1 }},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run("Incompatible"+test.name, func(t *testing.T) {
+			contract := matching()
+			test.modify(contract)
+			require.ErrorIs(t, validate(t, contract, expected), ErrIncompatibleOracle)
+		})
+
+		t.Run("Unchecked"+test.name, func(t *testing.T) {
+			contract := matching()
+			test.modify(contract)
+			require.NoError(t, validate(t, contract, config.OracleParams{}))
+		})
+	}
+
+	t.Run("ContractErrors", func(t *testing.T) {
+		contract := matching()
+		contract.err = mockLoaderError
+		err := validate(t, contract, expected)
+		require.ErrorIs(t, err, mockLoaderError)
+		require.NotErrorIs(t, err, ErrIncompatibleOracle)
+	})
+}
+
+type stubOracleParamsContract struct {
+	err             error
+	versionErr      error
+	version         string
+	challengePeriod uint64
+	minBond         uint64
+}
+
+func (s *stubOracleParamsContract) Addr() common.Address {
+	return common.Address{0xaa}
+}
+
+func (s *stubOracleParamsContract) Version(_ context.Context) (string, error) {
+	return s.version, s.versionErr
+}
+
+func (s *stubOracleParamsContract) ChallengePeriod(_ context.Context) (uint64, error) {
+	return s.challengePeriod, s.err
+}
+
+func (s *stubOracleParamsContract) GetMinBondLPP(_ context.Context) (*big.Int, error) {
+	return new(big.Int).SetUint64(s.minBond), s.err
+}
+
 var _ types.PrestateProvider = (*mockPrestateProvider)(nil)
 
 type mockPrestateProvider struct {
@@ -39,6 +39,7 @@ type Service struct {
 	sched   *scheduler.Scheduler
 
 	faultGamesCloser fault.CloseFunc
+	registerOpts     []fault.RegisterOption
 
 	preimages *keccak.LargePreimageScheduler
 
@@ -50,6 +51,7 @@ type Service struct {
 	claimer *claims.BondClaimScheduler
 
 	factoryContract *contracts.DisputeGameFactoryContract
+	gameData        *contracts.GameDataCache
 	registry        *registry.GameTypeRegistry
 	rollupClient    *sources.RollupClient
 
@@ -64,12 +66,13 @@ type Service struct {
 	stopped atomic.Bool
 }
 
-// NewService creates a new Service.
-func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
+// NewService creates a new Service. The options are applied when registering the game types to play.
+func NewService(ctx context.Context, logger log.Logger, cfg *config.Config, opts ...fault.RegisterOption) (*Service, error) {
 	s := &Service{
-		cl:      clock.NewSimpleClock(),
-		logger:  logger,
-		metrics: metrics.NewMetrics(),
+		cl:           clock.NewSimpleClock(),
+		logger:       logger,
+		metrics:      metrics.NewMetrics(),
+		registerOpts: opts,
 	}
 
 	if err := s.initFromConfig(ctx, cfg); err != nil {
@@ -197,7 +200,7 @@ func (s *Service) initFactoryContract(cfg *config.Config) error {
 }
 
 func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender)
+	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.cl)
 	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
 	return nil
 }
@@ -217,11 +220,13 @@ func (s *Service) initRollupClient(ctx context.Context, cfg *config.Config) erro
 func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) error {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.cl, s.logger, s.metrics, cfg, s.rollupClient, s.txSender, s.factoryContract, caller, s.l1Client)
+	s.gameData = contracts.NewGameDataCache(s.metrics, s.factoryContract, caller)
+	closer, _, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.cl, s.logger, s.metrics, cfg, s.rollupClient, s.txSender, s.gameData, caller, s.l1Client, s.registerOpts...)
+	// Keep the closer even if registration failed so Stop releases anything acquired before the failure.
+	s.faultGamesCloser = closer
 	if err != nil {
 		return err
 	}
-	s.faultGamesCloser = closer
 	s.registry = gameTypeRegistry
 	return nil
 }
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -51,3 +52,8 @@ type TxSender interface {
 	From() common.Address
 	SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error)
 }
+
+// GameTypeLabel returns the value of the game_type metrics label for gameType.
+func GameTypeLabel(gameType uint32) string {
+	return strconv.FormatUint(uint64(gameType), 10)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
@@ -33,10 +34,16 @@ type Metricer interface {
 	RecordGameStep()
 	RecordGameMove()
 	RecordCannonExecutionTime(t float64)
+	RecordAsteriscExecutionTime(t float64)
+	RecordCartesiExecutionTime(t float64)
 
 	RecordPreimageChallenged()
 	RecordPreimageChallengeFailed()
 
+	RecordLargePreimageInitialized()
+	RecordLargePreimageBytesUploaded(n int)
+	RecordLargePreimageSqueezed()
+
 	RecordBondClaimFailed()
 	RecordBondClaimed(amount uint64)
 
@@ -45,6 +52,17 @@ type Metricer interface {
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
 
+	RecordGameTypeRegistered(gameType uint32, traceType string)
+	RecordIncompatibleOracle(gameType uint32)
+	RecordOracleLookupFailed(gameType uint32)
+
+	RecordPlayerCreated(gameType uint32)
+	RecordPlayerCreationFailed(gameType uint32)
+	RecordPlayerCreationTimeout(gameType uint32)
+
+	RecordUnmatchedPrestate(gameType uint32)
+	RecordUnexpectedGameParams(gameType uint32)
+
 	IncActiveExecutors()
 	DecActiveExecutors()
 	IncIdleExecutors()
@@ -74,15 +92,31 @@ type Metrics struct {
 	preimageChallenged      prometheus.Counter
 	preimageChallengeFailed prometheus.Counter
 
+	largePreimagesInitialized  prometheus.Counter
+	largePreimageBytesUploaded prometheus.Counter
+	largePreimagesSqueezed     prometheus.Counter
+
 	highestActedL1Block prometheus.Gauge
 
 	moves prometheus.Counter
 	steps prometheus.Counter
 
-	cannonExecutionTime prometheus.Histogram
+	unmatchedPrestates   prometheus.CounterVec
+	unexpectedGameParams prometheus.CounterVec
+
+	cannonExecutionTime   prometheus.Histogram
+	asteriscExecutionTime prometheus.Histogram
+	cartesiExecutionTime  prometheus.Histogram
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+
+	registeredGameTypes    prometheus.GaugeVec
+	incompatibleOracles    prometheus.CounterVec
+	oracleLookupFailures   prometheus.CounterVec
+	playerCreations        prometheus.CounterVec
+	playerCreationFailures prometheus.CounterVec
+	playerCreationTimeouts prometheus.CounterVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -141,6 +175,36 @@ func NewMetrics() *Metrics {
 				[]float64{1.0, 10.0},
 				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
 		}),
+		asteriscExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "asterisc_execution_time",
+			Help:      "Time (in seconds) to execute asterisc",
+			Buckets: append(
+				[]float64{1.0, 10.0},
+				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
+		}),
+		cartesiExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "cartesi_execution_time",
+			Help:      "Time (in seconds) to run the cartesi machine",
+			Buckets: append(
+				[]float64{1.0, 10.0},
+				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
+		}),
+		unmatchedPrestates: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "unmatched_prestates",
+			Help:      "Number of times a game was not played because no configured absolute prestate matched it",
+		}, []string{
+			"game_type",
+		}),
+		unexpectedGameParams: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "unexpected_game_params",
+			Help:      "Number of times a game was not played because its parameters did not match the expected values",
+		}, []string{
+			"game_type",
+		}),
 		bondClaimFailures: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "claim_failures",
@@ -156,6 +220,21 @@ func NewMetrics() *Metrics {
 			Name:      "preimage_challenged",
 			Help:      "Number of preimages challenged by the challenger",
 		}),
+		largePreimagesInitialized: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "large_preimages_initialized",
+			Help:      "Number of large preimage proposals initialized by the challenger",
+		}),
+		largePreimageBytesUploaded: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_bytes_uploaded",
+			Help:      "Number of bytes of large preimages added to proposals by the challenger",
+		}),
+		largePreimagesSqueezed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "large_preimages_squeezed",
+			Help:      "Number of large preimage proposals squeezed by the challenger",
+		}),
 		preimageChallengeFailed: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "preimage_challenge_failed",
@@ -178,6 +257,49 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		registeredGameTypes: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "registered_game_types",
+			Help:      "Game types the challenger has registered and will play, with the trace type used to play them",
+		}, []string{
+			"game_type",
+			"trace_type",
+		}),
+		incompatibleOracles: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "incompatible_oracles",
+			Help:      "Number of times a game type was not registered because its preimage oracle had unexpected parameters",
+		}, []string{
+			"game_type",
+		}),
+		oracleLookupFailures: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "oracle_lookup_failures",
+			Help:      "Number of times the preimage oracle of a game type's implementation could not be loaded",
+		}, []string{
+			"game_type",
+		}),
+		playerCreations: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "player_creations",
+			Help:      "Number of game players created",
+		}, []string{
+			"game_type",
+		}),
+		playerCreationFailures: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "player_creation_failures",
+			Help:      "Number of times creating a game player failed, including timeouts",
+		}, []string{
+			"game_type",
+		}),
+		playerCreationTimeouts: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "player_creation_timeouts",
+			Help:      "Number of times loading a game's data to create its player timed out",
+		}, []string{
+			"game_type",
+		}),
 	}
 }
 
@@ -225,6 +347,18 @@ func (m *Metrics) RecordPreimageChallengeFailed() {
 	m.preimageChallengeFailed.Add(1)
 }
 
+func (m *Metrics) RecordLargePreimageInitialized() {
+	m.largePreimagesInitialized.Inc()
+}
+
+func (m *Metrics) RecordLargePreimageBytesUploaded(n int) {
+	m.largePreimageBytesUploaded.Add(float64(n))
+}
+
+func (m *Metrics) RecordLargePreimageSqueezed() {
+	m.largePreimagesSqueezed.Inc()
+}
+
 func (m *Metrics) RecordBondClaimFailed() {
 	m.bondClaimFailures.Add(1)
 }
@@ -237,6 +371,14 @@ func (m *Metrics) RecordCannonExecutionTime(t float64) {
 	m.cannonExecutionTime.Observe(t)
 }
 
+func (m *Metrics) RecordAsteriscExecutionTime(t float64) {
+	m.asteriscExecutionTime.Observe(t)
+}
+
+func (m *Metrics) RecordCartesiExecutionTime(t float64) {
+	m.cartesiExecutionTime.Observe(t)
+}
+
 func (m *Metrics) IncActiveExecutors() {
 	m.executors.WithLabelValues("active").Inc()
 }
@@ -270,3 +412,35 @@ func (m *Metrics) RecordGameUpdateScheduled() {
 func (m *Metrics) RecordGameUpdateCompleted() {
 	m.inflightGames.Sub(1)
 }
+
+func (m *Metrics) RecordGameTypeRegistered(gameType uint32, traceType string) {
+	m.registeredGameTypes.WithLabelValues(types.GameTypeLabel(gameType), traceType).Set(1)
+}
+
+func (m *Metrics) RecordIncompatibleOracle(gameType uint32) {
+	m.incompatibleOracles.WithLabelValues(types.GameTypeLabel(gameType)).Inc()
+}
+
+func (m *Metrics) RecordOracleLookupFailed(gameType uint32) {
+	m.oracleLookupFailures.WithLabelValues(types.GameTypeLabel(gameType)).Inc()
+}
+
+func (m *Metrics) RecordPlayerCreated(gameType uint32) {
+	m.playerCreations.WithLabelValues(types.GameTypeLabel(gameType)).Inc()
+}
+
+func (m *Metrics) RecordPlayerCreationFailed(gameType uint32) {
+	m.playerCreationFailures.WithLabelValues(types.GameTypeLabel(gameType)).Inc()
+}
+
+func (m *Metrics) RecordPlayerCreationTimeout(gameType uint32) {
+	m.playerCreationTimeouts.WithLabelValues(types.GameTypeLabel(gameType)).Inc()
+}
+
+func (m *Metrics) RecordUnmatchedPrestate(gameType uint32) {
+	m.unmatchedPrestates.WithLabelValues(types.GameTypeLabel(gameType)).Inc()
+}
+
+func (m *Metrics) RecordUnexpectedGameParams(gameType uint32) {
+	m.unexpectedGameParams.WithLabelValues(types.GameTypeLabel(gameType)).Inc()
+}
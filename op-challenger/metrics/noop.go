@@ -31,16 +31,33 @@ func (*NoopMetricsImpl) RecordActedL1Block(_ uint64) {}
 func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
 func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
 
+func (*NoopMetricsImpl) RecordLargePreimageInitialized()        {}
+func (*NoopMetricsImpl) RecordLargePreimageBytesUploaded(_ int) {}
+func (*NoopMetricsImpl) RecordLargePreimageSqueezed()           {}
+
 func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
 func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
 
-func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64) {}
+func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64)   {}
+func (*NoopMetricsImpl) RecordAsteriscExecutionTime(t float64) {}
+func (*NoopMetricsImpl) RecordCartesiExecutionTime(t float64)  {}
 
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
 
+func (*NoopMetricsImpl) RecordGameTypeRegistered(gameType uint32, traceType string) {}
+func (*NoopMetricsImpl) RecordIncompatibleOracle(gameType uint32)                   {}
+func (*NoopMetricsImpl) RecordOracleLookupFailed(gameType uint32)                   {}
+
+func (*NoopMetricsImpl) RecordPlayerCreated(gameType uint32)         {}
+func (*NoopMetricsImpl) RecordPlayerCreationFailed(gameType uint32)  {}
+func (*NoopMetricsImpl) RecordPlayerCreationTimeout(gameType uint32) {}
+
+func (*NoopMetricsImpl) RecordUnmatchedPrestate(gameType uint32)    {}
+func (*NoopMetricsImpl) RecordUnexpectedGameParams(gameType uint32) {}
+
 func (*NoopMetricsImpl) IncActiveExecutors() {}
 func (*NoopMetricsImpl) DecActiveExecutors() {}
 func (*NoopMetricsImpl) IncIdleExecutors()   {}
@@ -3,18 +3,45 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"net/url"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 var (
 	ErrMissingL1EthRPC           = errors.New("missing l1 eth rpc url")
 	ErrMissingGameFactoryAddress = errors.New("missing game factory address")
 	ErrMissingRollupRpc          = errors.New("missing rollup rpc url")
+	ErrInvalidBlockTag           = errors.New("invalid block tag")
+	ErrMaxConcurrencyZero        = errors.New("max concurrency must not be 0")
+	ErrCycleTimeoutZero          = errors.New("cycle timeout must not be 0")
+	ErrCycleTimeoutTooLarge      = errors.New("cycle timeout must not exceed the monitor interval")
+	ErrIntervalJitterTooLarge    = errors.New("interval jitter must be less than the monitor interval")
+	ErrResolverMaxTxsZero        = errors.New("resolver max transactions must not be 0")
+	ErrInvalidAPIPort            = errors.New("invalid api port")
+	ErrInvalidGameTypeWETH       = errors.New("invalid DelayedWETH address for game type")
+	ErrInvalidCreditThreshold    = errors.New("invalid credit warning threshold")
+	ErrInvalidMinBalance         = errors.New("invalid honest actor minimum balance")
+	ErrInvalidGameMinBond        = errors.New("invalid game minimum bond")
+	ErrHealthStaleIntervalsZero  = errors.New("health stale intervals must not be 0")
+	ErrMissingChainName          = errors.New("missing chain name")
+	ErrDuplicateChainName        = errors.New("duplicate chain name")
+	ErrChainsWithGameFactory     = errors.New("game factory address must not be set when monitoring multiple chains")
+	ErrInvalidAlertWebhookURL    = errors.New("invalid alert webhook url")
+	ErrAlertWebhookTimeoutZero   = errors.New("alert webhook timeout must not be 0")
+	ErrGameDumpRetentionZero     = errors.New("game dump retention must not be 0")
+	ErrInvalidForecastClock      = errors.New("forecast critical clock must not exceed the warning clock")
+	ErrInvalidForecastBond       = errors.New("invalid forecast bond threshold")
+	ErrResolverL1Mismatch        = errors.New("resolver requires every chain to use the l1 eth rpc url")
 )
 
 const (
@@ -25,44 +52,363 @@ const (
 	// DefaultMonitorInterval is the default interval at which the dispute
 	// monitor will check for new games to monitor.
 	DefaultMonitorInterval = time.Second * 30
+	// DefaultCycleTimeout is the default maximum time a single monitoring
+	// cycle may take. It is below the default monitor interval so a stuck
+	// cycle is abandoned before the next one is due.
+	DefaultCycleTimeout = time.Second * 25
+	// DefaultIntervalJitter is the default maximum random delay added to each
+	// scheduled monitoring cycle so replicas don't query the RPC in sync.
+	DefaultIntervalJitter = time.Second
+	// DefaultBlockTag is the default L1 block tag that games are monitored at.
+	DefaultBlockTag = eth.Unsafe
+	// DefaultMaxConcurrency is the default number of games that are loaded concurrently.
+	DefaultMaxConcurrency = uint(5)
+	// DefaultResolvedGameRefresh is the default time resolved games are served
+	// from the cache for before being loaded again.
+	DefaultResolvedGameRefresh = time.Minute * 10
+	// DefaultResolverMaxTransactions is the default maximum number of
+	// resolution transactions sent per monitoring cycle.
+	DefaultResolverMaxTransactions = uint(10)
+	// DefaultResolverBackoff is the default time a game is skipped for
+	// after its resolution fails. It doubles for each consecutive failure.
+	DefaultResolverBackoff = time.Minute * 5
+	// DefaultCreditWarnAfter is the default time credit may be claimable
+	// for before a warning is logged.
+	DefaultCreditWarnAfter = time.Hour * 24 * 7
+	// DefaultPreimageExpiringWindow is the default time before the end of a large
+	// preimage proposal's challenge period that it is warned about if unchallenged.
+	DefaultPreimageExpiringWindow = time.Hour
+	// DefaultEventDebounce is the default time to wait for a burst of game events to
+	// end before running the monitoring cycle they trigger.
+	DefaultEventDebounce = time.Second * 2
+	// DefaultHealthMaxFailures is the default number of consecutive monitoring
+	// cycles that may fail before the monitor is reported as unhealthy.
+	DefaultHealthMaxFailures = 3
+	// DefaultHealthStaleIntervals is the default number of monitor intervals without
+	// a successful cycle before the monitor is reported as unhealthy.
+	DefaultHealthStaleIntervals = 5
+	// DefaultAlertWebhookTimeout is the default maximum time a single attempt to
+	// deliver an alert to the webhook may take.
+	DefaultAlertWebhookTimeout = time.Second * 10
+	// DefaultAlertCooldown is the default minimum time between alerts for the
+	// same game and category.
+	DefaultAlertCooldown = time.Hour
+	// DefaultClaimantMetricsLimit is the default number of claimants other
+	// than honest actors whose unresolved claims are labelled in metrics.
+	DefaultClaimantMetricsLimit = uint(20)
+	// DefaultGameDumpRetention is the default number of game dumps kept,
+	// including the most recent one.
+	DefaultGameDumpRetention = uint(1)
+	// DefaultForecastWarningClock is the default time left to counter a game's
+	// claims at or below which an unexpected forecast is a warning.
+	DefaultForecastWarningClock = time.Hour * 24
+	// DefaultForecastCriticalClock is the default time left to counter a game's
+	// claims at or below which an unexpected forecast is critical.
+	DefaultForecastCriticalClock = time.Hour * 6
+	// DefaultAPIListenAddr is the default address the monitoring API listens on.
+	DefaultAPIListenAddr = "0.0.0.0"
+	// DefaultAPIListenPort is the default port the monitoring API listens on.
+	DefaultAPIListenPort = 7310
 )
 
+// ChainConfig identifies one of multiple chains monitored by a single process.
+type ChainConfig struct {
+	Name               string         `json:"name"`               // Name of the chain, used to label metrics.
+	GameFactoryAddress common.Address `json:"gameFactoryAddress"` // Address of the chain's dispute game factory.
+	L1EthRpc           string         `json:"l1EthRpc"`           // L1 RPC Url used to monitor the chain.
+	RollupRpc          string         `json:"rollupRpc"`          // The chain's rollup node RPC URL.
+	// Optional rollup node RPC URL that output roots disagreeing with a game's root claim are cross-checked against.
+	SecondaryRollupRpc string `json:"secondaryRollupRpc,omitempty"`
+}
+
+func (c ChainConfig) Check() error {
+	if c.Name == "" {
+		return ErrMissingChainName
+	}
+	if c.L1EthRpc == "" {
+		return ErrMissingL1EthRPC
+	}
+	if c.RollupRpc == "" {
+		return ErrMissingRollupRpc
+	}
+	if c.GameFactoryAddress == (common.Address{}) {
+		return ErrMissingGameFactoryAddress
+	}
+	return nil
+}
+
 // Config is a well typed config that is parsed from the CLI params.
 // It also contains config options for auxiliary services.
 type Config struct {
 	L1EthRpc           string         // L1 RPC Url
 	GameFactoryAddress common.Address // Address of the dispute game factory
 	RollupRpc          string         // The rollup node RPC URL.
+	// Optional rollup node RPC URL that output roots disagreeing with a game's root claim are cross-checked against,
+	// so a faulty rollup node isn't mistaken for invalid games.
+	SecondaryRollupRpc string
+
+	// Chains to monitor instead of the single chain identified by GameFactoryAddress and RollupRpc.
+	// L1EthRpc is then only used to send resolution transactions, so every chain must use it if the resolver is enabled.
+	Chains []ChainConfig
+
+	MonitorInterval  time.Duration // Frequency to check for new games to monitor.
+	CreationWindow   time.Duration // Maximum age of games to forecast the outcome of.
+	ResolutionWindow time.Duration // Maximum age of games to track the resolution, bonds and credit of.
+	CycleTimeout     time.Duration // Maximum time a single monitoring cycle may take.
+
+	StartupJitter  time.Duration // Maximum random delay before the first monitoring cycle.
+	IntervalJitter time.Duration // Maximum random delay added to each scheduled monitoring cycle.
+
+	BlockTag       eth.BlockLabel // L1 block tag to monitor games at.
+	MaxConcurrency uint           // Maximum number of games to load concurrently.
 
-	MonitorInterval time.Duration // Frequency to check for new games to monitor.
-	GameWindow      time.Duration // Maximum window to look for games to monitor.
+	// Time resolved games are served from the cache for before being loaded again.
+	// In-progress games are loaded again as soon as their status or number of claims changes.
+	ResolvedGameRefresh time.Duration
 
+	ResolverEnabled         bool          // Whether to resolve claims and games once they are resolvable.
+	ResolverDryRun          bool          // Log resolution transactions instead of sending them.
+	ResolverMaxTransactions uint          // Maximum number of resolution transactions to send per cycle.
+	ResolverBackoff         time.Duration // Time to skip a game for after its resolution fails.
+
+	// DelayedWETH contracts holding the bonds of games of specific game types.
+	// Bonds of other game types are held by the game contract itself.
+	GameTypeDelayedWETH map[uint32]common.Address
+
+	HonestActors        []common.Address // Addresses whose balance, bonds and credit are tracked.
+	CreditWarnThreshold *big.Int         // Claimable credit in wei a recipient may hold before it is warned about.
+	CreditWarnAfter     time.Duration    // Time credit may exceed the threshold for before a warning is logged.
+
+	HonestActorMinBalance *big.Int // Balance in wei below which an honest actor may be unable to post bonds.
+
+	// Number of claimants other than honest actors whose unresolved claims are labelled in metrics.
+	// Claimants with fewer bonds posted are aggregated under a single label.
+	ClaimantMetricsLimit uint
+
+	GameIgnoreList []common.Address // Games excluded from monitoring. Takes precedence over the allow list.
+	GameAllowList  []common.Address // Games to monitor, excluding all others. All games are monitored if empty.
+	GameMinBond    *big.Int         // Minimum root claim bond in wei of games to monitor.
+	// Path to a JSON file of further games to ignore or allow and a minimum bond, read every cycle so the
+	// filter can be changed without restarting the monitor.
+	GameFilterFile string
+
+	// Thresholds on the time left to counter a game's claims and the bonds in wei at stake in its unresolved claims
+	// at which an unexpected forecast is a warning or critical. A bond threshold of 0 is disabled.
+	ForecastWarningClock  time.Duration
+	ForecastCriticalClock time.Duration
+	ForecastWarningBond   *big.Int
+	ForecastCriticalBond  *big.Int
+
+	// Time before the end of a large preimage proposal's challenge period that it is warned about if unchallenged.
+	PreimageExpiringWindow time.Duration
+
+	EventPollInterval time.Duration // Frequency to poll for game events that trigger a cycle. 0 disables event triggers.
+	EventDebounce     time.Duration // Time without further events before a triggered cycle is run.
+	EventGameEvents   bool          // Whether moves and resolutions in in-progress games also trigger a cycle.
+
+	HealthMaxFailures    uint // Consecutive cycles that may fail before the monitor is unhealthy.
+	HealthStaleIntervals uint // Monitor intervals without a successful cycle before the monitor is unhealthy.
+
+	AlertWebhookURL     string        // URL alerts are posted to. Alerting is disabled if empty.
+	AlertWebhookAuth    string        // Value of the Authorization header sent with alerts. Omitted if empty.
+	AlertWebhookTimeout time.Duration // Maximum time a single attempt to deliver an alert may take.
+	AlertCooldown       time.Duration // Minimum time between alerts for the same game and category.
+
+	// Path to write the state of every game loaded in each successful cycle to as JSON lines. Disabled if empty.
+	GameDumpPath      string
+	GameDumpRetention uint // Number of game dumps to keep, including the most recent one.
+
+	APIEnabled    bool   // Whether to serve the latest monitoring snapshot over HTTP.
+	APIListenAddr string // Address the monitoring API listens on.
+	APIListenPort int    // Port the monitoring API listens on.
+
+	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
 }
 
+var (
+	// DefaultForecastWarningBond is the default bond in wei at stake in a game's
+	// unresolved claims at or above which an unexpected forecast is a warning.
+	DefaultForecastWarningBond = big.NewInt(params.Ether)
+	// DefaultForecastCriticalBond is the default bond in wei at stake in a game's
+	// unresolved claims at or above which an unexpected forecast is critical.
+	DefaultForecastCriticalBond = new(big.Int).Mul(big.NewInt(10), big.NewInt(params.Ether))
+)
+
 func NewConfig(gameFactoryAddress common.Address, l1EthRpc string) Config {
 	return Config{
 		L1EthRpc:           l1EthRpc,
 		GameFactoryAddress: gameFactoryAddress,
 
-		MonitorInterval: DefaultMonitorInterval,
-		GameWindow:      DefaultGameWindow,
+		MonitorInterval:  DefaultMonitorInterval,
+		CreationWindow:   DefaultGameWindow,
+		ResolutionWindow: DefaultGameWindow,
+		CycleTimeout:     DefaultCycleTimeout,
+
+		IntervalJitter: DefaultIntervalJitter,
+
+		BlockTag:       DefaultBlockTag,
+		MaxConcurrency: DefaultMaxConcurrency,
+
+		ResolvedGameRefresh: DefaultResolvedGameRefresh,
 
+		ResolverMaxTransactions: DefaultResolverMaxTransactions,
+		ResolverBackoff:         DefaultResolverBackoff,
+
+		CreditWarnThreshold: big.NewInt(0),
+		CreditWarnAfter:     DefaultCreditWarnAfter,
+
+		HonestActorMinBalance: big.NewInt(0),
+
+		ClaimantMetricsLimit: DefaultClaimantMetricsLimit,
+
+		GameMinBond: big.NewInt(0),
+
+		ForecastWarningClock:  DefaultForecastWarningClock,
+		ForecastCriticalClock: DefaultForecastCriticalClock,
+		ForecastWarningBond:   new(big.Int).Set(DefaultForecastWarningBond),
+		ForecastCriticalBond:  new(big.Int).Set(DefaultForecastCriticalBond),
+
+		PreimageExpiringWindow: DefaultPreimageExpiringWindow,
+
+		EventDebounce: DefaultEventDebounce,
+
+		HealthMaxFailures:    DefaultHealthMaxFailures,
+		HealthStaleIntervals: DefaultHealthStaleIntervals,
+
+		AlertWebhookTimeout: DefaultAlertWebhookTimeout,
+		AlertCooldown:       DefaultAlertCooldown,
+
+		GameDumpRetention: DefaultGameDumpRetention,
+
+		APIListenAddr: DefaultAPIListenAddr,
+		APIListenPort: DefaultAPIListenPort,
+
+		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
 	}
 }
 
+// MonitoredChains returns the chains to monitor. If Chains is empty, the single chain identified by L1EthRpc,
+// GameFactoryAddress and RollupRpc is monitored and has an empty name.
+func (c Config) MonitoredChains() []ChainConfig {
+	if len(c.Chains) > 0 {
+		return c.Chains
+	}
+	return []ChainConfig{{
+		GameFactoryAddress: c.GameFactoryAddress,
+		L1EthRpc:           c.L1EthRpc,
+		RollupRpc:          c.RollupRpc,
+		SecondaryRollupRpc: c.SecondaryRollupRpc,
+	}}
+}
+
 func (c Config) Check() error {
-	if c.L1EthRpc == "" {
-		return ErrMissingL1EthRPC
+	if len(c.Chains) == 0 {
+		if c.L1EthRpc == "" {
+			return ErrMissingL1EthRPC
+		}
+		if c.RollupRpc == "" {
+			return ErrMissingRollupRpc
+		}
+		if c.GameFactoryAddress == (common.Address{}) {
+			return ErrMissingGameFactoryAddress
+		}
+	} else {
+		if c.GameFactoryAddress != (common.Address{}) {
+			return ErrChainsWithGameFactory
+		}
+		names := make(map[string]bool)
+		for i, chain := range c.Chains {
+			if err := chain.Check(); err != nil {
+				return fmt.Errorf("chain %v (%q): %w", i, chain.Name, err)
+			}
+			if names[chain.Name] {
+				return fmt.Errorf("%w: %v", ErrDuplicateChainName, chain.Name)
+			}
+			names[chain.Name] = true
+		}
 	}
-	if c.RollupRpc == "" {
-		return ErrMissingRollupRpc
+	switch c.BlockTag {
+	case eth.Unsafe, eth.Safe, eth.Finalized:
+	default:
+		return fmt.Errorf("%w: %v", ErrInvalidBlockTag, c.BlockTag)
 	}
-	if c.GameFactoryAddress == (common.Address{}) {
-		return ErrMissingGameFactoryAddress
+	if c.CycleTimeout == 0 {
+		return ErrCycleTimeoutZero
+	}
+	if c.CycleTimeout > c.MonitorInterval {
+		return fmt.Errorf("%w: %v > %v", ErrCycleTimeoutTooLarge, c.CycleTimeout, c.MonitorInterval)
+	}
+	if c.IntervalJitter != 0 && c.IntervalJitter >= c.MonitorInterval {
+		return fmt.Errorf("%w: %v >= %v", ErrIntervalJitterTooLarge, c.IntervalJitter, c.MonitorInterval)
+	}
+	if c.MaxConcurrency == 0 {
+		return ErrMaxConcurrencyZero
+	}
+	if c.ResolverEnabled {
+		if c.ResolverMaxTransactions == 0 {
+			return ErrResolverMaxTxsZero
+		}
+		// Resolution transactions for every chain are sent to the same L1
+		for _, chain := range c.Chains {
+			if chain.L1EthRpc != c.L1EthRpc {
+				return fmt.Errorf("%w: chain %q uses %v", ErrResolverL1Mismatch, chain.Name, chain.L1EthRpc)
+			}
+		}
+		// Transactions are only sent when not in dry-run mode
+		if !c.ResolverDryRun {
+			if err := c.TxMgrConfig.Check(); err != nil {
+				return fmt.Errorf("tx manager config: %w", err)
+			}
+		}
+	}
+	for gameType, weth := range c.GameTypeDelayedWETH {
+		if weth == (common.Address{}) {
+			return fmt.Errorf("%w: %v", ErrInvalidGameTypeWETH, gameType)
+		}
+	}
+	if c.CreditWarnThreshold == nil || c.CreditWarnThreshold.Sign() < 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidCreditThreshold, c.CreditWarnThreshold)
+	}
+	if c.HonestActorMinBalance == nil || c.HonestActorMinBalance.Sign() < 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidMinBalance, c.HonestActorMinBalance)
+	}
+	if c.GameMinBond == nil || c.GameMinBond.Sign() < 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidGameMinBond, c.GameMinBond)
+	}
+	if c.ForecastCriticalClock > c.ForecastWarningClock {
+		return fmt.Errorf("%w: %v > %v", ErrInvalidForecastClock, c.ForecastCriticalClock, c.ForecastWarningClock)
+	}
+	if c.ForecastWarningBond == nil || c.ForecastWarningBond.Sign() < 0 {
+		return fmt.Errorf("%w: warning %v", ErrInvalidForecastBond, c.ForecastWarningBond)
+	}
+	if c.ForecastCriticalBond == nil || c.ForecastCriticalBond.Sign() < 0 {
+		return fmt.Errorf("%w: critical %v", ErrInvalidForecastBond, c.ForecastCriticalBond)
+	}
+	// A disabled threshold can't be out of order with the other.
+	if c.ForecastWarningBond.Sign() > 0 && c.ForecastCriticalBond.Sign() > 0 && c.ForecastCriticalBond.Cmp(c.ForecastWarningBond) < 0 {
+		return fmt.Errorf("%w: critical %v < warning %v", ErrInvalidForecastBond, c.ForecastCriticalBond, c.ForecastWarningBond)
+	}
+	if c.HealthStaleIntervals == 0 {
+		return ErrHealthStaleIntervalsZero
+	}
+	if c.AlertWebhookURL != "" {
+		if u, err := url.Parse(c.AlertWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			// The URL isn't included in the error as webhook URLs often contain secrets
+			return ErrInvalidAlertWebhookURL
+		}
+		if c.AlertWebhookTimeout == 0 {
+			return ErrAlertWebhookTimeoutZero
+		}
+	}
+	if c.GameDumpPath != "" && c.GameDumpRetention == 0 {
+		return ErrGameDumpRetentionZero
+	}
+	if c.APIEnabled && (c.APIListenPort < 0 || c.APIListenPort > math.MaxUint16) {
+		return fmt.Errorf("%w: %v", ErrInvalidAPIPort, c.APIListenPort)
 	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return fmt.Errorf("metrics config: %w", err)
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -36,6 +40,353 @@ func TestGameFactoryAddressRequired(t *testing.T) {
 	require.ErrorIs(t, config.Check(), ErrMissingGameFactoryAddress)
 }
 
+func TestChains(t *testing.T) {
+	chainsConfig := func() Config {
+		config := NewConfig(common.Address{}, "")
+		config.Chains = []ChainConfig{
+			{Name: "chain-a", GameFactoryAddress: common.Address{0xaa}, L1EthRpc: validL1EthRpc, RollupRpc: "http://localhost:9545"},
+			{Name: "chain-b", GameFactoryAddress: common.Address{0xbb}, L1EthRpc: validL1EthRpc, RollupRpc: "http://localhost:9546"},
+		}
+		return config
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		config := chainsConfig()
+		require.NoError(t, config.Check())
+		require.Equal(t, config.Chains, config.MonitoredChains())
+	})
+
+	t.Run("SingleChain", func(t *testing.T) {
+		config := validConfig()
+		require.Equal(t, []ChainConfig{{
+			GameFactoryAddress: validGameFactoryAddress,
+			L1EthRpc:           validL1EthRpc,
+			RollupRpc:          validRollupRpc,
+		}}, config.MonitoredChains())
+	})
+
+	t.Run("SingleChainSecondaryRollupRpc", func(t *testing.T) {
+		config := validConfig()
+		config.SecondaryRollupRpc = "http://localhost:9546"
+		require.NoError(t, config.Check())
+		require.Equal(t, "http://localhost:9546", config.MonitoredChains()[0].SecondaryRollupRpc)
+	})
+
+	t.Run("GameFactoryAddressNotAllowed", func(t *testing.T) {
+		config := chainsConfig()
+		config.GameFactoryAddress = validGameFactoryAddress
+		require.ErrorIs(t, config.Check(), ErrChainsWithGameFactory)
+	})
+
+	t.Run("NameRequired", func(t *testing.T) {
+		config := chainsConfig()
+		config.Chains[1].Name = ""
+		require.ErrorIs(t, config.Check(), ErrMissingChainName)
+	})
+
+	t.Run("UniqueNames", func(t *testing.T) {
+		config := chainsConfig()
+		config.Chains[1].Name = config.Chains[0].Name
+		require.ErrorIs(t, config.Check(), ErrDuplicateChainName)
+	})
+
+	t.Run("L1EthRpcRequired", func(t *testing.T) {
+		config := chainsConfig()
+		config.Chains[0].L1EthRpc = ""
+		require.ErrorIs(t, config.Check(), ErrMissingL1EthRPC)
+	})
+
+	t.Run("RollupRpcRequired", func(t *testing.T) {
+		config := chainsConfig()
+		config.Chains[0].RollupRpc = ""
+		require.ErrorIs(t, config.Check(), ErrMissingRollupRpc)
+	})
+
+	t.Run("GameFactoryAddressRequired", func(t *testing.T) {
+		config := chainsConfig()
+		config.Chains[1].GameFactoryAddress = common.Address{}
+		require.ErrorIs(t, config.Check(), ErrMissingGameFactoryAddress)
+	})
+}
+
+func TestBlockTag(t *testing.T) {
+	for _, tag := range []eth.BlockLabel{eth.Unsafe, eth.Safe, eth.Finalized} {
+		tag := tag
+		t.Run(string(tag), func(t *testing.T) {
+			config := validConfig()
+			config.BlockTag = tag
+			require.NoError(t, config.Check())
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		config := validConfig()
+		config.BlockTag = "pending"
+		require.ErrorIs(t, config.Check(), ErrInvalidBlockTag)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		config := validConfig()
+		config.BlockTag = ""
+		require.ErrorIs(t, config.Check(), ErrInvalidBlockTag)
+	})
+}
+
+func TestCycleTimeout(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		config := validConfig()
+		config.CycleTimeout = 0
+		require.ErrorIs(t, config.Check(), ErrCycleTimeoutZero)
+	})
+
+	t.Run("EqualToInterval", func(t *testing.T) {
+		config := validConfig()
+		config.CycleTimeout = config.MonitorInterval
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("ExceedsInterval", func(t *testing.T) {
+		config := validConfig()
+		config.CycleTimeout = config.MonitorInterval + time.Second
+		require.ErrorIs(t, config.Check(), ErrCycleTimeoutTooLarge)
+	})
+}
+
+func TestIntervalJitter(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig()
+		require.Equal(t, DefaultIntervalJitter, config.IntervalJitter)
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		config := validConfig()
+		config.IntervalJitter = 0
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("NotLessThanInterval", func(t *testing.T) {
+		config := validConfig()
+		config.IntervalJitter = config.MonitorInterval
+		require.ErrorIs(t, config.Check(), ErrIntervalJitterTooLarge)
+	})
+}
+
+func TestMaxConcurrencyRequired(t *testing.T) {
+	config := validConfig()
+	config.MaxConcurrency = 0
+	require.ErrorIs(t, config.Check(), ErrMaxConcurrencyZero)
+}
+
+func TestResolverConfig(t *testing.T) {
+	t.Run("DisabledIgnoresTxMgrConfig", func(t *testing.T) {
+		config := validConfig()
+		config.TxMgrConfig.NumConfirmations = 0
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		config := validConfig()
+		config.ResolverEnabled = true
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("MaxTransactionsRequired", func(t *testing.T) {
+		config := validConfig()
+		config.ResolverEnabled = true
+		config.ResolverMaxTransactions = 0
+		require.ErrorIs(t, config.Check(), ErrResolverMaxTxsZero)
+	})
+
+	t.Run("ValidatesTxMgrConfig", func(t *testing.T) {
+		config := validConfig()
+		config.ResolverEnabled = true
+		config.TxMgrConfig.NumConfirmations = 0
+		require.ErrorContains(t, config.Check(), "tx manager config")
+	})
+
+	t.Run("ChainsUseL1EthRpc", func(t *testing.T) {
+		config := validConfig()
+		config.GameFactoryAddress = common.Address{}
+		config.ResolverEnabled = true
+		config.Chains = []ChainConfig{
+			{Name: "chain-a", GameFactoryAddress: common.Address{0xaa}, L1EthRpc: validL1EthRpc, RollupRpc: "http://localhost:9545"},
+			{Name: "chain-b", GameFactoryAddress: common.Address{0xbb}, L1EthRpc: validL1EthRpc, RollupRpc: "http://localhost:9546"},
+		}
+		require.NoError(t, config.Check())
+
+		config.Chains[1].L1EthRpc = "http://localhost:9999"
+		require.ErrorIs(t, config.Check(), ErrResolverL1Mismatch)
+
+		config.ResolverEnabled = false
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("DryRunIgnoresTxMgrConfig", func(t *testing.T) {
+		config := validConfig()
+		config.ResolverEnabled = true
+		config.ResolverDryRun = true
+		config.TxMgrConfig.NumConfirmations = 0
+		require.NoError(t, config.Check())
+	})
+}
+
+func TestGameTypeDelayedWETH(t *testing.T) {
+	config := validConfig()
+	config.GameTypeDelayedWETH = map[uint32]common.Address{0: {0xaa}}
+	require.NoError(t, config.Check())
+
+	config.GameTypeDelayedWETH[1] = common.Address{}
+	require.ErrorIs(t, config.Check(), ErrInvalidGameTypeWETH)
+}
+
+func TestCreditWarnThreshold(t *testing.T) {
+	config := validConfig()
+	config.CreditWarnThreshold = big.NewInt(1)
+	require.NoError(t, config.Check())
+
+	config.CreditWarnThreshold = nil
+	require.ErrorIs(t, config.Check(), ErrInvalidCreditThreshold)
+
+	config.CreditWarnThreshold = big.NewInt(-1)
+	require.ErrorIs(t, config.Check(), ErrInvalidCreditThreshold)
+}
+
+func TestHonestActorMinBalance(t *testing.T) {
+	config := validConfig()
+	config.HonestActorMinBalance = big.NewInt(1)
+	require.NoError(t, config.Check())
+
+	config.HonestActorMinBalance = nil
+	require.ErrorIs(t, config.Check(), ErrInvalidMinBalance)
+
+	config.HonestActorMinBalance = big.NewInt(-1)
+	require.ErrorIs(t, config.Check(), ErrInvalidMinBalance)
+}
+
+func TestGameMinBond(t *testing.T) {
+	config := validConfig()
+	config.GameMinBond = big.NewInt(1)
+	require.NoError(t, config.Check())
+
+	config.GameMinBond = nil
+	require.ErrorIs(t, config.Check(), ErrInvalidGameMinBond)
+
+	config.GameMinBond = big.NewInt(-1)
+	require.ErrorIs(t, config.Check(), ErrInvalidGameMinBond)
+}
+
+func TestForecastThresholds(t *testing.T) {
+	t.Run("Clock", func(t *testing.T) {
+		config := validConfig()
+		config.ForecastCriticalClock = config.ForecastWarningClock
+		require.NoError(t, config.Check())
+
+		config.ForecastCriticalClock = config.ForecastWarningClock + 1
+		require.ErrorIs(t, config.Check(), ErrInvalidForecastClock)
+	})
+
+	t.Run("Bond", func(t *testing.T) {
+		config := validConfig()
+		config.ForecastWarningBond = nil
+		require.ErrorIs(t, config.Check(), ErrInvalidForecastBond)
+
+		config = validConfig()
+		config.ForecastCriticalBond = big.NewInt(-1)
+		require.ErrorIs(t, config.Check(), ErrInvalidForecastBond)
+
+		config = validConfig()
+		config.ForecastCriticalBond = new(big.Int).Sub(config.ForecastWarningBond, big.NewInt(1))
+		require.ErrorIs(t, config.Check(), ErrInvalidForecastBond)
+
+		config.ForecastCriticalBond = new(big.Int).Set(config.ForecastWarningBond)
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("DisabledBond", func(t *testing.T) {
+		config := validConfig()
+		config.ForecastCriticalBond = big.NewInt(0)
+		require.NoError(t, config.Check())
+
+		config = validConfig()
+		config.ForecastWarningBond = big.NewInt(0)
+		require.NoError(t, config.Check())
+	})
+}
+
+func TestGameDumpRetention(t *testing.T) {
+	config := validConfig()
+	config.GameDumpRetention = 0
+	require.NoError(t, config.Check())
+
+	config.GameDumpPath = "games.jsonl"
+	require.ErrorIs(t, config.Check(), ErrGameDumpRetentionZero)
+
+	config.GameDumpRetention = 3
+	require.NoError(t, config.Check())
+}
+
+func TestHealthStaleIntervals(t *testing.T) {
+	config := validConfig()
+	config.HealthStaleIntervals = 1
+	require.NoError(t, config.Check())
+
+	config.HealthStaleIntervals = 0
+	require.ErrorIs(t, config.Check(), ErrHealthStaleIntervalsZero)
+}
+
+func TestAlertConfig(t *testing.T) {
+	t.Run("DisabledIgnoresTimeout", func(t *testing.T) {
+		config := validConfig()
+		config.AlertWebhookTimeout = 0
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		config := validConfig()
+		config.AlertWebhookURL = "https://alerts.example.com/hook"
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		for _, u := range []string{"alerts.example.com", "ftp://alerts.example.com", "https://", "://bad"} {
+			config := validConfig()
+			config.AlertWebhookURL = u
+			require.ErrorIs(t, config.Check(), ErrInvalidAlertWebhookURL, u)
+		}
+	})
+
+	t.Run("TimeoutZero", func(t *testing.T) {
+		config := validConfig()
+		config.AlertWebhookURL = "https://alerts.example.com/hook"
+		config.AlertWebhookTimeout = 0
+		require.ErrorIs(t, config.Check(), ErrAlertWebhookTimeoutZero)
+	})
+}
+
+func TestAPIConfig(t *testing.T) {
+	t.Run("DisabledIgnoresPort", func(t *testing.T) {
+		config := validConfig()
+		config.APIListenPort = -1
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		config := validConfig()
+		config.APIEnabled = true
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("InvalidPort", func(t *testing.T) {
+		for _, port := range []int{-1, 65536} {
+			config := validConfig()
+			config.APIEnabled = true
+			config.APIListenPort = port
+			require.ErrorIs(t, config.Check(), ErrInvalidAPIPort)
+		}
+	})
+}
+
 func TestRollupRpcRequired(t *testing.T) {
 	config := validConfig()
 	config.RollupRpc = ""
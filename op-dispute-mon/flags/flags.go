@@ -1,15 +1,23 @@
 package flags
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"os"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 const (
@@ -38,6 +46,18 @@ var (
 		Usage:   "HTTP provider URL for the rollup node",
 		EnvVars: prefixEnvVars("ROLLUP_RPC"),
 	}
+	SecondaryRollupRpcFlag = &cli.StringFlag{
+		Name: "secondary-rollup-rpc",
+		Usage: "HTTP provider URL for a second rollup node that output roots disagreeing with a game's root claim are cross-checked against. " +
+			"Different output roots from the two rollup nodes are alerted on instead of the game.",
+		EnvVars: prefixEnvVars("SECONDARY_ROLLUP_RPC"),
+	}
+	ChainsConfigFlag = &cli.PathFlag{
+		Name: "chains-config",
+		Usage: "Path to a JSON file listing the chains to monitor, each with a name, gameFactoryAddress, l1EthRpc, rollupRpc and optional secondaryRollupRpc. " +
+			"Replaces --game-factory-address, --rollup-rpc and --secondary-rollup-rpc. --l1-eth-rpc is then only used to send resolution transactions.",
+		EnvVars: prefixEnvVars("CHAINS_CONFIG"),
+	}
 	MonitorIntervalFlag = &cli.DurationFlag{
 		Name:    "monitor-interval",
 		Usage:   "The interval at which the dispute monitor will check for new games to monitor.",
@@ -51,6 +71,243 @@ var (
 		EnvVars: prefixEnvVars("GAME_WINDOW"),
 		Value:   config.DefaultGameWindow,
 	}
+	CreationWindowFlag = &cli.DurationFlag{
+		Name: "creation-window",
+		Usage: "The time window in which the monitor forecasts the outcome of newly created games. " +
+			"Defaults to the game window.",
+		EnvVars: prefixEnvVars("CREATION_WINDOW"),
+	}
+	ResolutionWindowFlag = &cli.DurationFlag{
+		Name: "resolution-window",
+		Usage: "The time window in which the monitor tracks the resolution, bonds and credit of games. " +
+			"Defaults to the game window.",
+		EnvVars: prefixEnvVars("RESOLUTION_WINDOW"),
+	}
+	CycleTimeoutFlag = &cli.DurationFlag{
+		Name:    "cycle-timeout",
+		Usage:   "The maximum time a single monitoring cycle may take before it is abandoned. Must not exceed the monitor interval.",
+		EnvVars: prefixEnvVars("CYCLE_TIMEOUT"),
+		Value:   config.DefaultCycleTimeout,
+	}
+	StartupJitterFlag = &cli.DurationFlag{
+		Name:    "startup-jitter",
+		Usage:   "Maximum random delay before the first monitoring cycle, so monitors started together don't query the RPC in sync.",
+		EnvVars: prefixEnvVars("STARTUP_JITTER"),
+	}
+	IntervalJitterFlag = &cli.DurationFlag{
+		Name:    "interval-jitter",
+		Usage:   "Maximum random delay added to each scheduled monitoring cycle. Must be less than the monitor interval.",
+		EnvVars: prefixEnvVars("INTERVAL_JITTER"),
+		Value:   config.DefaultIntervalJitter,
+	}
+	BlockTagFlag = &cli.StringFlag{
+		Name:    "block-tag",
+		Usage:   "The L1 block tag to monitor games at. Valid values: latest, safe, finalized",
+		EnvVars: prefixEnvVars("BLOCK_TAG"),
+		Value:   config.DefaultBlockTag,
+	}
+	ResolverEnabledFlag = &cli.BoolFlag{
+		Name:    "resolver-enabled",
+		Usage:   "Resolve claims and games once they are resolvable to unlock bonds. Requires the tx manager flags unless running in dry-run mode.",
+		EnvVars: prefixEnvVars("RESOLVER_ENABLED"),
+	}
+	ResolverDryRunFlag = &cli.BoolFlag{
+		Name:    "resolver-dry-run",
+		Usage:   "Log the resolution transactions the resolver would send instead of sending them.",
+		EnvVars: prefixEnvVars("RESOLVER_DRY_RUN"),
+	}
+	ResolverMaxTransactionsFlag = &cli.UintFlag{
+		Name:    "resolver-max-transactions",
+		Usage:   "Maximum number of resolution transactions to send per monitoring cycle.",
+		EnvVars: prefixEnvVars("RESOLVER_MAX_TRANSACTIONS"),
+		Value:   config.DefaultResolverMaxTransactions,
+	}
+	ResolverBackoffFlag = &cli.DurationFlag{
+		Name:    "resolver-backoff",
+		Usage:   "Time to skip a game for after its resolution fails. Doubles for each consecutive failure.",
+		EnvVars: prefixEnvVars("RESOLVER_BACKOFF"),
+		Value:   config.DefaultResolverBackoff,
+	}
+	GameTypeDelayedWETHFlag = &cli.StringSliceFlag{
+		Name: "game-type-delayed-weth",
+		Usage: "DelayedWETH contract holding the bonds of games of a specific game type. " +
+			"Credit is only claimable once the withdrawal delay has elapsed. Specified as <game-type>=<address>, may be repeated",
+		EnvVars: prefixEnvVars("GAME_TYPE_DELAYED_WETH"),
+	}
+	HonestActorsFlag = &cli.StringSliceFlag{
+		Name:    "honest-actors",
+		Usage:   "Addresses of honest actors whose balance, posted bonds and unclaimed credit are tracked. May be repeated",
+		EnvVars: prefixEnvVars("HONEST_ACTORS"),
+	}
+	CreditWarnThresholdFlag = &cli.StringFlag{
+		Name:    "credit-warn-threshold",
+		Usage:   "Claimable credit in wei a recipient may hold before it is warned about.",
+		EnvVars: prefixEnvVars("CREDIT_WARN_THRESHOLD"),
+		Value:   "0",
+	}
+	CreditWarnAfterFlag = &cli.DurationFlag{
+		Name:    "credit-warn-after",
+		Usage:   "Time a recipient's claimable credit may exceed the warning threshold for before a warning is logged.",
+		EnvVars: prefixEnvVars("CREDIT_WARN_AFTER"),
+		Value:   config.DefaultCreditWarnAfter,
+	}
+	HonestActorMinBalanceFlag = &cli.StringFlag{
+		Name:    "honest-actor-min-balance",
+		Usage:   "Balance in wei below which an honest actor is warned about as it may be unable to post bonds.",
+		EnvVars: prefixEnvVars("HONEST_ACTOR_MIN_BALANCE"),
+		Value:   "0",
+	}
+	ClaimantMetricsLimitFlag = &cli.UintFlag{
+		Name:    "claimant-metrics-limit",
+		Usage:   "Number of claimants, in addition to honest actors, whose unresolved claims and bonds are labelled in metrics. Claimants with fewer bonds posted are reported as \"other\".",
+		EnvVars: prefixEnvVars("CLAIMANT_METRICS_LIMIT"),
+		Value:   config.DefaultClaimantMetricsLimit,
+	}
+	GameIgnoreListFlag = &cli.StringSliceFlag{
+		Name:    "game-ignore-list",
+		Usage:   "Addresses of games to exclude from monitoring. Takes precedence over the allow list. May be repeated",
+		EnvVars: prefixEnvVars("GAME_IGNORE_LIST"),
+	}
+	GameAllowListFlag = &cli.StringSliceFlag{
+		Name:    "game-allow-list",
+		Usage:   "Addresses of games to monitor, excluding all other games. All games are monitored if not set. May be repeated",
+		EnvVars: prefixEnvVars("GAME_ALLOW_LIST"),
+	}
+	GameMinBondFlag = &cli.StringFlag{
+		Name:    "game-min-bond",
+		Usage:   "Minimum root claim bond in wei of games to monitor. Games with a smaller bond are excluded from monitoring.",
+		EnvVars: prefixEnvVars("GAME_MIN_BOND"),
+		Value:   "0",
+	}
+	GameFilterFileFlag = &cli.PathFlag{
+		Name: "game-filter-file",
+		Usage: "Path to a JSON file with further games to exclude (ignore) or monitor (allow) and a minimum bond (minBond). " +
+			"The file is read every monitoring cycle so the filter can be changed without restarting.",
+		EnvVars: prefixEnvVars("GAME_FILTER_FILE"),
+	}
+	ForecastWarningClockFlag = &cli.DurationFlag{
+		Name:    "forecast-warning-clock",
+		Usage:   "Time left to counter a game's claims at or below which a forecast of an unexpected game result is a warning.",
+		EnvVars: prefixEnvVars("FORECAST_WARNING_CLOCK"),
+		Value:   config.DefaultForecastWarningClock,
+	}
+	ForecastCriticalClockFlag = &cli.DurationFlag{
+		Name:    "forecast-critical-clock",
+		Usage:   "Time left to counter a game's claims at or below which a forecast of an unexpected game result is critical.",
+		EnvVars: prefixEnvVars("FORECAST_CRITICAL_CLOCK"),
+		Value:   config.DefaultForecastCriticalClock,
+	}
+	ForecastWarningBondFlag = &cli.StringFlag{
+		Name:    "forecast-warning-bond",
+		Usage:   "Bonds in wei at stake in a game's unresolved claims at or above which a forecast of an unexpected game result is a warning. 0 disables the threshold.",
+		EnvVars: prefixEnvVars("FORECAST_WARNING_BOND"),
+		Value:   config.DefaultForecastWarningBond.String(),
+	}
+	ForecastCriticalBondFlag = &cli.StringFlag{
+		Name:    "forecast-critical-bond",
+		Usage:   "Bonds in wei at stake in a game's unresolved claims at or above which a forecast of an unexpected game result is critical. 0 disables the threshold.",
+		EnvVars: prefixEnvVars("FORECAST_CRITICAL_BOND"),
+		Value:   config.DefaultForecastCriticalBond.String(),
+	}
+	PreimageExpiringWindowFlag = &cli.DurationFlag{
+		Name:    "preimage-expiring-window",
+		Usage:   "Time before the end of a large preimage proposal's challenge period that it is warned about if unchallenged.",
+		EnvVars: prefixEnvVars("PREIMAGE_EXPIRING_WINDOW"),
+		Value:   config.DefaultPreimageExpiringWindow,
+	}
+	EventPollIntervalFlag = &cli.DurationFlag{
+		Name: "event-poll-interval",
+		Usage: "The interval at which to poll L1 for newly created games, triggering an immediate monitoring cycle when found. " +
+			"Cycles are still run every monitor interval. 0 disables event triggers.",
+		EnvVars: prefixEnvVars("EVENT_POLL_INTERVAL"),
+	}
+	EventDebounceFlag = &cli.DurationFlag{
+		Name:    "event-debounce",
+		Usage:   "The time to wait for further game events before running a triggered monitoring cycle.",
+		EnvVars: prefixEnvVars("EVENT_DEBOUNCE"),
+		Value:   config.DefaultEventDebounce,
+	}
+	EventGameEventsFlag = &cli.BoolFlag{
+		Name:    "event-game-events",
+		Usage:   "Also trigger a monitoring cycle when claims are made or games are resolved in in-progress games.",
+		EnvVars: prefixEnvVars("EVENT_GAME_EVENTS"),
+	}
+	HealthMaxFailuresFlag = &cli.UintFlag{
+		Name:    "health.max-failures",
+		Usage:   "Number of consecutive monitoring cycles that may fail before the monitor is reported as unhealthy.",
+		EnvVars: prefixEnvVars("HEALTH_MAX_FAILURES"),
+		Value:   config.DefaultHealthMaxFailures,
+	}
+	HealthStaleIntervalsFlag = &cli.UintFlag{
+		Name:    "health.stale-intervals",
+		Usage:   "Number of monitor intervals without a successful monitoring cycle before the monitor is reported as unhealthy.",
+		EnvVars: prefixEnvVars("HEALTH_STALE_INTERVALS"),
+		Value:   config.DefaultHealthStaleIntervals,
+	}
+	AlertWebhookURLFlag = &cli.StringFlag{
+		Name:    "alert.webhook-url",
+		Usage:   "URL to post alerts about games that need attention to as JSON. Alerting is disabled if not set.",
+		EnvVars: prefixEnvVars("ALERT_WEBHOOK_URL"),
+	}
+	AlertWebhookAuthFlag = &cli.StringFlag{
+		Name:    "alert.webhook-auth",
+		Usage:   "Value of the Authorization header sent with each alert, e.g. \"Bearer <token>\".",
+		EnvVars: prefixEnvVars("ALERT_WEBHOOK_AUTH"),
+	}
+	AlertWebhookTimeoutFlag = &cli.DurationFlag{
+		Name:    "alert.webhook-timeout",
+		Usage:   "Maximum time a single attempt to deliver an alert may take. Failed deliveries are retried with backoff.",
+		EnvVars: prefixEnvVars("ALERT_WEBHOOK_TIMEOUT"),
+		Value:   config.DefaultAlertWebhookTimeout,
+	}
+	AlertCooldownFlag = &cli.DurationFlag{
+		Name:    "alert.cooldown",
+		Usage:   "Minimum time between alerts for the same game and category, so a persistent condition isn't alerted every cycle.",
+		EnvVars: prefixEnvVars("ALERT_COOLDOWN"),
+		Value:   config.DefaultAlertCooldown,
+	}
+	GameDumpPathFlag = &cli.PathFlag{
+		Name: "game-dump-path",
+		Usage: "Path to write the state of every game loaded in each successful monitoring cycle to as JSON lines. " +
+			"The file is replaced atomically. When monitoring multiple chains, the chain name is added to the file name.",
+		EnvVars: prefixEnvVars("GAME_DUMP_PATH"),
+	}
+	GameDumpRetentionFlag = &cli.UintFlag{
+		Name:    "game-dump-retention",
+		Usage:   "Number of game dumps to keep, including the most recent one. Older dumps are kept with a numbered suffix.",
+		EnvVars: prefixEnvVars("GAME_DUMP_RETENTION"),
+		Value:   config.DefaultGameDumpRetention,
+	}
+	APIEnabledFlag = &cli.BoolFlag{
+		Name:    "api.enabled",
+		Usage:   "Serve the latest monitoring snapshot and health check over an HTTP JSON API.",
+		EnvVars: prefixEnvVars("API_ENABLED"),
+	}
+	APIListenAddrFlag = &cli.StringFlag{
+		Name:    "api.addr",
+		Usage:   "Address the monitoring API listens on.",
+		EnvVars: prefixEnvVars("API_ADDR"),
+		Value:   config.DefaultAPIListenAddr,
+	}
+	APIListenPortFlag = &cli.IntFlag{
+		Name:    "api.port",
+		Usage:   "Port the monitoring API listens on.",
+		EnvVars: prefixEnvVars("API_PORT"),
+		Value:   config.DefaultAPIListenPort,
+	}
+	MaxConcurrencyFlag = &cli.UintFlag{
+		Name:    "max-concurrency",
+		Usage:   "Maximum number of games to load concurrently.",
+		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
+		Value:   config.DefaultMaxConcurrency,
+	}
+	ResolvedGameRefreshFlag = &cli.DurationFlag{
+		Name: "resolved-game-refresh",
+		Usage: "The time resolved games are served from the cache for before their credits are loaded again. " +
+			"In-progress games are loaded again as soon as their status or number of claims changes.",
+		EnvVars: prefixEnvVars("RESOLVED_GAME_REFRESH"),
+		Value:   config.DefaultResolvedGameRefresh,
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -62,12 +319,56 @@ var requiredFlags = []cli.Flag{
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
 	RollupRpcFlag,
+	SecondaryRollupRpcFlag,
+	ChainsConfigFlag,
 	MonitorIntervalFlag,
 	GameWindowFlag,
+	CreationWindowFlag,
+	ResolutionWindowFlag,
+	CycleTimeoutFlag,
+	StartupJitterFlag,
+	IntervalJitterFlag,
+	BlockTagFlag,
+	MaxConcurrencyFlag,
+	ResolvedGameRefreshFlag,
+	ResolverEnabledFlag,
+	ResolverDryRunFlag,
+	ResolverMaxTransactionsFlag,
+	ResolverBackoffFlag,
+	GameTypeDelayedWETHFlag,
+	HonestActorsFlag,
+	CreditWarnThresholdFlag,
+	CreditWarnAfterFlag,
+	HonestActorMinBalanceFlag,
+	ClaimantMetricsLimitFlag,
+	GameIgnoreListFlag,
+	GameAllowListFlag,
+	GameMinBondFlag,
+	GameFilterFileFlag,
+	ForecastWarningClockFlag,
+	ForecastCriticalClockFlag,
+	ForecastWarningBondFlag,
+	ForecastCriticalBondFlag,
+	PreimageExpiringWindowFlag,
+	EventPollIntervalFlag,
+	EventDebounceFlag,
+	EventGameEventsFlag,
+	HealthMaxFailuresFlag,
+	HealthStaleIntervalsFlag,
+	AlertWebhookURLFlag,
+	AlertWebhookAuthFlag,
+	AlertWebhookTimeoutFlag,
+	AlertCooldownFlag,
+	GameDumpPathFlag,
+	GameDumpRetentionFlag,
+	APIEnabledFlag,
+	APIListenAddrFlag,
+	APIListenPortFlag,
 }
 
 func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(envVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
 
@@ -78,6 +379,10 @@ func init() {
 var Flags []cli.Flag
 
 func CheckRequired(ctx *cli.Context) error {
+	// The chains config replaces the flags identifying a single chain
+	if ctx.IsSet(ChainsConfigFlag.Name) {
+		return nil
+	}
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
@@ -91,23 +396,164 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if err := CheckRequired(ctx); err != nil {
 		return nil, err
 	}
-	gameFactoryAddress, err := opservice.ParseAddress(ctx.String(FactoryAddressFlag.Name))
+	var gameFactoryAddress common.Address
+	if ctx.IsSet(FactoryAddressFlag.Name) {
+		addr, err := opservice.ParseAddress(ctx.String(FactoryAddressFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		gameFactoryAddress = addr
+	}
+	chains, err := loadChains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gameTypeDelayedWETH, err := opflags.ParseGameTypeAddresses(ctx, GameTypeDelayedWETHFlag)
+	if err != nil {
+		return nil, err
+	}
+	honestActors, err := parseAddresses(ctx, HonestActorsFlag)
+	if err != nil {
+		return nil, err
+	}
+	creditWarnThreshold, ok := new(big.Int).SetString(ctx.String(CreditWarnThresholdFlag.Name), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v value %q", CreditWarnThresholdFlag.Name, ctx.String(CreditWarnThresholdFlag.Name))
+	}
+	honestActorMinBalance, ok := new(big.Int).SetString(ctx.String(HonestActorMinBalanceFlag.Name), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v value %q", HonestActorMinBalanceFlag.Name, ctx.String(HonestActorMinBalanceFlag.Name))
+	}
+	gameIgnoreList, err := parseAddresses(ctx, GameIgnoreListFlag)
 	if err != nil {
 		return nil, err
 	}
+	gameAllowList, err := parseAddresses(ctx, GameAllowListFlag)
+	if err != nil {
+		return nil, err
+	}
+	gameMinBond, ok := new(big.Int).SetString(ctx.String(GameMinBondFlag.Name), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v value %q", GameMinBondFlag.Name, ctx.String(GameMinBondFlag.Name))
+	}
+	forecastWarningBond, ok := new(big.Int).SetString(ctx.String(ForecastWarningBondFlag.Name), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v value %q", ForecastWarningBondFlag.Name, ctx.String(ForecastWarningBondFlag.Name))
+	}
+	forecastCriticalBond, ok := new(big.Int).SetString(ctx.String(ForecastCriticalBondFlag.Name), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v value %q", ForecastCriticalBondFlag.Name, ctx.String(ForecastCriticalBondFlag.Name))
+	}
 
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
+	txMgrConfig := txmgr.ReadCLIConfig(ctx)
 
 	return &config.Config{
 		L1EthRpc:           ctx.String(L1EthRpcFlag.Name),
 		GameFactoryAddress: gameFactoryAddress,
 
-		RollupRpc:       ctx.String(RollupRpcFlag.Name),
-		MonitorInterval: ctx.Duration(MonitorIntervalFlag.Name),
-		GameWindow:      ctx.Duration(GameWindowFlag.Name),
+		RollupRpc:        ctx.String(RollupRpcFlag.Name),
+		Chains:           chains,
+		MonitorInterval:  ctx.Duration(MonitorIntervalFlag.Name),
+		CreationWindow:   windowOrDefault(ctx, CreationWindowFlag),
+		ResolutionWindow: windowOrDefault(ctx, ResolutionWindowFlag),
+		CycleTimeout:     ctx.Duration(CycleTimeoutFlag.Name),
+		BlockTag:         eth.BlockLabel(ctx.String(BlockTagFlag.Name)),
+		MaxConcurrency:   ctx.Uint(MaxConcurrencyFlag.Name),
+
+		SecondaryRollupRpc: ctx.String(SecondaryRollupRpcFlag.Name),
+
+		StartupJitter:  ctx.Duration(StartupJitterFlag.Name),
+		IntervalJitter: ctx.Duration(IntervalJitterFlag.Name),
+
+		ResolvedGameRefresh: ctx.Duration(ResolvedGameRefreshFlag.Name),
+
+		ResolverEnabled:         ctx.Bool(ResolverEnabledFlag.Name),
+		ResolverDryRun:          ctx.Bool(ResolverDryRunFlag.Name),
+		ResolverMaxTransactions: ctx.Uint(ResolverMaxTransactionsFlag.Name),
+		ResolverBackoff:         ctx.Duration(ResolverBackoffFlag.Name),
+
+		GameTypeDelayedWETH: gameTypeDelayedWETH,
+		HonestActors:        honestActors,
+		CreditWarnThreshold: creditWarnThreshold,
+		CreditWarnAfter:     ctx.Duration(CreditWarnAfterFlag.Name),
 
+		HonestActorMinBalance: honestActorMinBalance,
+
+		ClaimantMetricsLimit: ctx.Uint(ClaimantMetricsLimitFlag.Name),
+
+		GameIgnoreList: gameIgnoreList,
+		GameAllowList:  gameAllowList,
+		GameMinBond:    gameMinBond,
+		GameFilterFile: ctx.Path(GameFilterFileFlag.Name),
+
+		ForecastWarningClock:  ctx.Duration(ForecastWarningClockFlag.Name),
+		ForecastCriticalClock: ctx.Duration(ForecastCriticalClockFlag.Name),
+		ForecastWarningBond:   forecastWarningBond,
+		ForecastCriticalBond:  forecastCriticalBond,
+
+		PreimageExpiringWindow: ctx.Duration(PreimageExpiringWindowFlag.Name),
+
+		EventPollInterval: ctx.Duration(EventPollIntervalFlag.Name),
+		EventDebounce:     ctx.Duration(EventDebounceFlag.Name),
+		EventGameEvents:   ctx.Bool(EventGameEventsFlag.Name),
+
+		HealthMaxFailures:    ctx.Uint(HealthMaxFailuresFlag.Name),
+		HealthStaleIntervals: ctx.Uint(HealthStaleIntervalsFlag.Name),
+
+		AlertWebhookURL:     ctx.String(AlertWebhookURLFlag.Name),
+		AlertWebhookAuth:    ctx.String(AlertWebhookAuthFlag.Name),
+		AlertWebhookTimeout: ctx.Duration(AlertWebhookTimeoutFlag.Name),
+		AlertCooldown:       ctx.Duration(AlertCooldownFlag.Name),
+
+		GameDumpPath:      ctx.Path(GameDumpPathFlag.Name),
+		GameDumpRetention: ctx.Uint(GameDumpRetentionFlag.Name),
+
+		APIEnabled:    ctx.Bool(APIEnabledFlag.Name),
+		APIListenAddr: ctx.String(APIListenAddrFlag.Name),
+		APIListenPort: ctx.Int(APIListenPortFlag.Name),
+
+		TxMgrConfig:   txMgrConfig,
 		MetricsConfig: metricsConfig,
 		PprofConfig:   pprofConfig,
 	}, nil
 }
+
+// loadChains reads the chains to monitor from the file specified by the chains config flag, if set.
+func loadChains(ctx *cli.Context) ([]config.ChainConfig, error) {
+	path := ctx.Path(ChainsConfigFlag.Name)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chains config: %w", err)
+	}
+	var chains []config.ChainConfig
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("failed to parse chains config %v: %w", path, err)
+	}
+	return chains, nil
+}
+
+// windowOrDefault returns the value of flag if it is set, or the game window otherwise.
+func windowOrDefault(ctx *cli.Context, flag *cli.DurationFlag) time.Duration {
+	if ctx.IsSet(flag.Name) {
+		return ctx.Duration(flag.Name)
+	}
+	return ctx.Duration(GameWindowFlag.Name)
+}
+
+func parseAddresses(ctx *cli.Context, flag *cli.StringSliceFlag) ([]common.Address, error) {
+	var addresses []common.Address
+	for _, entry := range ctx.StringSlice(flag.Name) {
+		addr, err := opservice.ParseAddress(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v address %q: %w", flag.Name, entry, err)
+		}
+		addresses = append(addresses, addr)
+	}
+	return addresses, nil
+}
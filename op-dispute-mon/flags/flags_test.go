@@ -2,12 +2,18 @@ package flags
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
 )
@@ -68,7 +74,16 @@ func TestEnvVarFormat(t *testing.T) {
 		flag := flag
 		flagName := flag.Names()[0]
 
+		skippedFlags := []string{
+			txmgr.FeeLimitMultiplierFlagName,
+			txmgr.TxSendTimeoutFlagName,
+			txmgr.TxNotInMempoolTimeoutFlagName,
+		}
+
 		t.Run(flagName, func(t *testing.T) {
+			if slices.Contains(skippedFlags, flagName) {
+				t.Skipf("Skipping flag %v which is known to not have a standard flag name <-> env var conversion", flagName)
+			}
 			envFlagGetter, ok := flag.(interface {
 				GetEnvVars() []string
 			})
@@ -80,3 +95,65 @@ func TestEnvVarFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestChainsConfig(t *testing.T) {
+	t.Run("LoadsChains", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "chains.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[
+			{"name": "chain-a", "gameFactoryAddress": "0x00000000000000000000000000000000000000aa", "l1EthRpc": "http://l1", "rollupRpc": "http://rollup-a"},
+			{"name": "chain-b", "gameFactoryAddress": "0x00000000000000000000000000000000000000bb", "l1EthRpc": "http://l1", "rollupRpc": "http://rollup-b", "secondaryRollupRpc": "http://rollup-b2"}
+		]`), 0o644))
+		cfg, err := configForArgs(t, "--chains-config", path)
+		require.NoError(t, err)
+		require.Equal(t, []config.ChainConfig{
+			{Name: "chain-a", GameFactoryAddress: common.HexToAddress("0xaa"), L1EthRpc: "http://l1", RollupRpc: "http://rollup-a"},
+			{Name: "chain-b", GameFactoryAddress: common.HexToAddress("0xbb"), L1EthRpc: "http://l1", RollupRpc: "http://rollup-b", SecondaryRollupRpc: "http://rollup-b2"},
+		}, cfg.Chains)
+		require.Equal(t, common.Address{}, cfg.GameFactoryAddress)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("SingleChain", func(t *testing.T) {
+		cfg, err := configForArgs(t, "--l1-eth-rpc", "http://l1", "--game-factory-address", "0x00000000000000000000000000000000000000aa")
+		require.NoError(t, err)
+		require.Empty(t, cfg.Chains)
+		require.Equal(t, common.HexToAddress("0xaa"), cfg.GameFactoryAddress)
+	})
+
+	t.Run("SingleChainSecondaryRollupRpc", func(t *testing.T) {
+		cfg, err := configForArgs(t, "--l1-eth-rpc", "http://l1", "--game-factory-address", "0x00000000000000000000000000000000000000aa",
+			"--secondary-rollup-rpc", "http://rollup-2")
+		require.NoError(t, err)
+		require.Equal(t, "http://rollup-2", cfg.MonitoredChains()[0].SecondaryRollupRpc)
+	})
+
+	t.Run("SingleChainFlagsRequiredWithoutChains", func(t *testing.T) {
+		_, err := configForArgs(t, "--l1-eth-rpc", "http://l1")
+		require.ErrorContains(t, err, "game-factory-address is required")
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := configForArgs(t, "--chains-config", filepath.Join(t.TempDir(), "missing.json"))
+		require.ErrorContains(t, err, "failed to read chains config")
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "chains.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"name": "chain-a"}`), 0o644))
+		_, err := configForArgs(t, "--chains-config", path)
+		require.ErrorContains(t, err, "failed to parse chains config")
+	})
+}
+
+func configForArgs(t *testing.T, args ...string) (*config.Config, error) {
+	var cfg *config.Config
+	app := cli.NewApp()
+	app.Flags = Flags
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		cfg, err = NewConfigFromCLI(ctx)
+		return err
+	}
+	err := app.Run(append([]string{"op-dispute-mon"}, args...))
+	return cfg, err
+}
@@ -3,19 +3,41 @@ package metrics
 import (
 	"fmt"
 	"io"
+	"math/big"
+	"strconv"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
 const Namespace = "op_dispute_mon"
 
+// claimResolutionDelayBuckets are the histogram buckets for claim resolution delays, ranging from a minute to a week.
+var claimResolutionDelayBuckets = []float64{
+	60, 5 * 60, 15 * 60, 60 * 60, 3 * 60 * 60, 6 * 60 * 60, 12 * 60 * 60, 24 * 60 * 60, 3 * 24 * 60 * 60, 7 * 24 * 60 * 60,
+}
+
+// cyclePhaseDurationBuckets are the histogram buckets for the duration of monitoring cycle phases, ranging from 10ms
+// to about 80 seconds.
+var cyclePhaseDurationBuckets = prometheus.ExponentialBuckets(0.01, 2, 14)
+
+// gameEnrichmentDurationBuckets are the histogram buckets for the time taken to enrich a single game, ranging from
+// 1ms to about 16 seconds.
+var gameEnrichmentDurationBuckets = prometheus.ExponentialBuckets(0.001, 2, 15)
+
+// ChainLabel is the label identifying the chain a metric belongs to when monitoring multiple chains.
+const ChainLabel = "chain"
+
 type GameAgreementStatus uint8
 
 const (
@@ -32,16 +54,67 @@ const (
 	DisagreeChallengerWins
 )
 
+// Labels for the recipients and state of unclaimed credit.
+const (
+	CreditRecipientHonest = "honest"
+	CreditRecipientOther  = "other"
+
+	CreditStateClaimable = "claimable"
+	CreditStatePending   = "pending"
+)
+
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
 
 	RecordClaimResolutionDelayMax(delay float64)
+	RecordClaimResolutionDelay(gameType uint32, delay float64)
+	RecordClaimResolutionDelayStats(gameType uint32, maxDelay, p95Delay float64)
+	RecordClaimsPastClock(gameType uint32, count int)
+
+	RecordMonitoredBlock(number uint64)
+	RecordCycleTimeout(phase string)
+	RecordCycleFailure(phase string)
+	RecordConsecutiveCycleFailures(count int)
+	RecordLastSuccessfulCycle(timestamp time.Time)
+	RecordCycleDuration(duration time.Duration)
+	RecordSkippedCycle()
+	RecordCyclePhaseDuration(phase string, duration time.Duration)
+	RecordCycleGames(count int)
+
+	RecordFailedGames(count int)
+	RecordGameEnrichments(cacheHits int, fullEnrichments int)
+	RecordGameEnrichmentDuration(cached bool, duration time.Duration)
+	RecordGameEnrichmentFailure(class string)
+
+	RecordReorgedGames(count int)
+
+	RecordFilteredGames(reason string, count int)
+
+	RecordAlert(result string)
+
+	RecordResolution(method string, result string)
+
+	RecordUnclaimedCredit(recipients string, state string, amount *big.Int)
+
+	RecordHonestActorBalance(actor common.Address, balance *big.Int)
+	RecordHonestActorBonded(actor common.Address, bonded *big.Int)
+	RecordHonestActorCredit(actor common.Address, credit *big.Int)
+
+	RecordClaimantExposure(claimant string, honest bool, bonded *big.Int, pastClock int, maxDelay uint64, oldestClaimAge uint64)
+	RecordClaimantClaims(claimant string, honest bool, age string, count int)
+	RemoveClaimant(claimant string)
+
+	RecordLargePreimageProposals(state string, count int)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	RecordGameAgreement(status GameAgreementStatus, count int)
 
+	RecordForecastSeverity(severity string, count int)
+	RecordOutputCrossCheck(result string)
+
 	caching.Metrics
+	txmetrics.TxMetricer
 }
 
 // Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
@@ -53,14 +126,63 @@ type Metrics struct {
 	factory  opmetrics.Factory
 
 	*opmetrics.CacheMetrics
+	txmetrics.TxMetricer
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
 
 	claimResolutionDelayMax prometheus.Gauge
 
+	claimResolutionDelays      prometheus.HistogramVec
+	claimResolutionDelayByType prometheus.GaugeVec
+	claimResolutionDelayP95    prometheus.GaugeVec
+	claimsPastClock            prometheus.GaugeVec
+
+	monitoredBlock prometheus.Gauge
+	cycleTimeouts  prometheus.CounterVec
+
+	cycleFailures            prometheus.CounterVec
+	consecutiveCycleFailures prometheus.Gauge
+	lastSuccessfulCycle      prometheus.Gauge
+	cycleDuration            prometheus.Gauge
+	skippedCycles            prometheus.Counter
+	cyclePhaseDurations      prometheus.HistogramVec
+	cycleGames               prometheus.Gauge
+
+	failedGames prometheus.Counter
+
+	gameEnrichments prometheus.CounterVec
+
+	gameEnrichmentDurations prometheus.HistogramVec
+	gameEnrichmentFailures  prometheus.CounterVec
+
+	reorgedGames prometheus.Gauge
+
+	filteredGames prometheus.GaugeVec
+
+	alerts prometheus.CounterVec
+
+	resolutions prometheus.CounterVec
+
+	unclaimedCredit prometheus.GaugeVec
+
+	honestActorBalance prometheus.GaugeVec
+	honestActorBonded  prometheus.GaugeVec
+	honestActorCredit  prometheus.GaugeVec
+
+	claimantClaims         prometheus.GaugeVec
+	claimantBonded         prometheus.GaugeVec
+	claimantPastClock      prometheus.GaugeVec
+	claimantMaxDelay       prometheus.GaugeVec
+	claimantOldestClaimAge prometheus.GaugeVec
+
+	largePreimageProposals prometheus.GaugeVec
+
 	trackedGames   prometheus.GaugeVec
 	gamesAgreement prometheus.GaugeVec
+
+	forecastSeverity  prometheus.GaugeVec
+	outputCrossChecks prometheus.CounterVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -72,13 +194,33 @@ var _ Metricer = (*Metrics)(nil)
 func NewMetrics() *Metrics {
 	registry := opmetrics.NewRegistry()
 	factory := opmetrics.With(registry)
+	txMetrics := txmetrics.MakeTxMetrics(Namespace, factory)
+	return newMetrics(registry, factory, &txMetrics)
+}
+
+// NewTxMetrics creates transaction metrics registered with registry. When monitoring multiple chains a single
+// transaction manager is shared by all chains, so its metrics are not labelled with a chain.
+func NewTxMetrics(registry *prometheus.Registry) txmetrics.TxMetricer {
+	txMetrics := txmetrics.MakeTxMetrics(Namespace, opmetrics.With(registry))
+	return &txMetrics
+}
+
+// NewChainMetrics creates the metrics for one of multiple chains monitored by the same process. Metrics are
+// registered with registry and labelled with the chain name, except for transaction metrics which are recorded
+// to txMetrics.
+func NewChainMetrics(registry *prometheus.Registry, chain string, txMetrics txmetrics.TxMetricer) *Metrics {
+	factory := opmetrics.With(prometheus.WrapRegistererWith(prometheus.Labels{ChainLabel: chain}, registry))
+	return newMetrics(registry, factory, txMetrics)
+}
 
+func newMetrics(registry *prometheus.Registry, factory opmetrics.Factory, txMetrics txmetrics.TxMetricer) *Metrics {
 	return &Metrics{
 		ns:       Namespace,
 		registry: registry,
 		factory:  factory,
 
 		CacheMetrics: opmetrics.NewCacheMetrics(factory, Namespace, "provider_cache", "Provider cache"),
+		TxMetricer:   txMetrics,
 
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
@@ -97,6 +239,218 @@ func NewMetrics() *Metrics {
 			Name:      "claim_resolution_delay_max",
 			Help:      "Maximum claim resolution delay in seconds",
 		}),
+		claimResolutionDelays: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "claim_resolution_delay_seconds",
+			Help:      "Time in seconds claims were resolvable for before they were resolved, observed once per claim and labelled by game type",
+			Buckets:   claimResolutionDelayBuckets,
+		}, []string{
+			"game_type",
+		}),
+		claimResolutionDelayByType: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_type_claim_resolution_delay_max",
+			Help:      "Maximum time in seconds an unresolved claim has been resolvable for, labelled by game type",
+		}, []string{
+			"game_type",
+		}),
+		claimResolutionDelayP95: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_type_claim_resolution_delay_p95",
+			Help:      "95th percentile of the time in seconds unresolved claims have been resolvable for, labelled by game type",
+		}, []string{
+			"game_type",
+		}),
+		claimsPastClock: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claims_past_clock",
+			Help:      "Number of unresolved claims whose chess clock has expired, labelled by game type",
+		}, []string{
+			"game_type",
+		}),
+		monitoredBlock: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "monitored_block",
+			Help:      "Number of the L1 block games were last monitored at",
+		}),
+		cycleTimeouts: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cycle_timeouts",
+			Help:      "Number of monitoring cycles that timed out, labelled by the phase in progress",
+		}, []string{
+			"phase",
+		}),
+		cycleFailures: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cycle_failures",
+			Help:      "Number of monitoring cycles that failed, labelled by the phase that failed",
+		}, []string{
+			"phase",
+		}),
+		consecutiveCycleFailures: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "consecutive_cycle_failures",
+			Help:      "Number of monitoring cycles that have failed since the last successful cycle",
+		}),
+		lastSuccessfulCycle: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "last_successful_cycle",
+			Help:      "Unix timestamp of the start of the last successful monitoring cycle",
+		}),
+		cycleDuration: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "cycle_duration_seconds",
+			Help:      "Time in seconds the last monitoring cycle took",
+		}),
+		skippedCycles: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "skipped_cycles",
+			Help:      "Number of scheduled monitoring cycles skipped because the previous cycle was still running",
+		}),
+		cyclePhaseDurations: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "cycle_phase_duration_seconds",
+			Help:      "Time in seconds each phase of a monitoring cycle took, labelled by phase",
+			Buckets:   cyclePhaseDurationBuckets,
+		}, []string{
+			"phase",
+		}),
+		cycleGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "cycle_games",
+			Help:      "Number of games extracted in the last monitoring cycle",
+		}),
+		failedGames: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "failed_games",
+			Help:      "Number of times a game was excluded from monitoring because its data could not be loaded",
+		}),
+		gameEnrichments: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_enrichments",
+			Help:      "Number of games extracted, labelled by whether they were served from the cache or fully enriched from the contract",
+		}, []string{
+			"source",
+		}),
+		gameEnrichmentDurations: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "game_enrichment_duration_seconds",
+			Help:      "Time in seconds taken to enrich a single game, labelled by whether it was served from the cache or the contract",
+			Buckets:   gameEnrichmentDurationBuckets,
+		}, []string{
+			"source",
+		}),
+		gameEnrichmentFailures: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_enrichment_failures",
+			Help:      "Number of games that failed to load, labelled by the class of error",
+		}, []string{
+			"class",
+		}),
+		reorgedGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "reorged_games",
+			Help:      "Number of monitored games created against an L1 head that is no longer canonical",
+		}),
+		filteredGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "filtered_games",
+			Help:      "Number of games excluded from monitoring by the game filter, labelled by the reason",
+		}, []string{
+			"reason",
+		}),
+		alerts: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "alerts",
+			Help:      "Number of alerts handled, labelled by whether they were delivered, failed, dropped or suppressed as duplicates",
+		}, []string{
+			"result",
+		}),
+		resolutions: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "resolutions",
+			Help:      "Number of claim and game resolutions attempted by the resolver, labelled by method and result",
+		}, []string{
+			"method",
+			"result",
+		}),
+		unclaimedCredit: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "unclaimed_credit",
+			Help:      "Credit in ether held by resolved games that hasn't been claimed, labelled by recipient and whether it can be claimed yet",
+		}, []string{
+			"recipients",
+			"state",
+		}),
+		honestActorBalance: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "honest_actor_balance",
+			Help:      "ETH balance in ether of each honest actor",
+		}, []string{
+			"actor",
+		}),
+		honestActorBonded: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "honest_actor_bonded",
+			Help:      "Bonds in ether posted by each honest actor with claims that are yet to be resolved",
+		}, []string{
+			"actor",
+		}),
+		honestActorCredit: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "honest_actor_credit",
+			Help:      "Credit in ether held for each honest actor by resolved games that hasn't been claimed",
+		}, []string{
+			"actor",
+		}),
+		claimantClaims: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claimant_unresolved_claims",
+			Help:      "Number of unresolved claims posted by each claimant, labelled by the upper bound of the claim age in seconds",
+		}, []string{
+			"claimant",
+			"honest",
+			"age",
+		}),
+		claimantBonded: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claimant_bonded",
+			Help:      "Bonds in ether posted by each claimant with claims that are yet to be resolved",
+		}, []string{
+			"claimant",
+			"honest",
+		}),
+		claimantPastClock: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claimant_claims_past_clock",
+			Help:      "Number of unresolved claims posted by each claimant whose chess clock has expired",
+		}, []string{
+			"claimant",
+			"honest",
+		}),
+		claimantMaxDelay: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claimant_resolution_delay_max",
+			Help:      "Longest time in seconds any unresolved claim posted by each claimant has been resolvable for",
+		}, []string{
+			"claimant",
+			"honest",
+		}),
+		claimantOldestClaimAge: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claimant_oldest_claim_age",
+			Help:      "Age in seconds of the oldest unresolved claim posted by each claimant",
+		}, []string{
+			"claimant",
+			"honest",
+		}),
+		largePreimageProposals: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_proposals",
+			Help:      "Number of large preimage proposals in the preimage oracles used by monitored games, labelled by state",
+		}, []string{
+			"state",
+		}),
 		trackedGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "tracked_games",
@@ -114,6 +468,20 @@ func NewMetrics() *Metrics {
 			"result_correctness",
 			"root_agreement",
 		}),
+		forecastSeverity: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "forecast_severity",
+			Help:      "Number of games forecast to resolve with an unexpected result, labelled by the severity of the forecast",
+		}, []string{
+			"severity",
+		}),
+		outputCrossChecks: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "output_cross_checks_total",
+			Help:      "Number of root claims the primary rollup node disagrees with that were cross-checked against the secondary rollup node, labelled by the outcome",
+		}, []string{
+			"result",
+		}),
 	}
 }
 
@@ -145,6 +513,134 @@ func (m *Metrics) RecordClaimResolutionDelayMax(delay float64) {
 	m.claimResolutionDelayMax.Set(delay)
 }
 
+func (m *Metrics) RecordClaimResolutionDelay(gameType uint32, delay float64) {
+	m.claimResolutionDelays.WithLabelValues(types.GameTypeLabel(gameType)).Observe(delay)
+}
+
+func (m *Metrics) RecordClaimResolutionDelayStats(gameType uint32, maxDelay, p95Delay float64) {
+	m.claimResolutionDelayByType.WithLabelValues(types.GameTypeLabel(gameType)).Set(maxDelay)
+	m.claimResolutionDelayP95.WithLabelValues(types.GameTypeLabel(gameType)).Set(p95Delay)
+}
+
+func (m *Metrics) RecordClaimsPastClock(gameType uint32, count int) {
+	m.claimsPastClock.WithLabelValues(types.GameTypeLabel(gameType)).Set(float64(count))
+}
+
+func (m *Metrics) RecordMonitoredBlock(number uint64) {
+	m.monitoredBlock.Set(float64(number))
+}
+
+func (m *Metrics) RecordCycleTimeout(phase string) {
+	m.cycleTimeouts.WithLabelValues(phase).Inc()
+}
+
+func (m *Metrics) RecordCycleFailure(phase string) {
+	m.cycleFailures.WithLabelValues(phase).Inc()
+}
+
+func (m *Metrics) RecordConsecutiveCycleFailures(count int) {
+	m.consecutiveCycleFailures.Set(float64(count))
+}
+
+func (m *Metrics) RecordLastSuccessfulCycle(timestamp time.Time) {
+	m.lastSuccessfulCycle.Set(float64(timestamp.Unix()))
+}
+
+func (m *Metrics) RecordCycleDuration(duration time.Duration) {
+	m.cycleDuration.Set(duration.Seconds())
+}
+
+func (m *Metrics) RecordSkippedCycle() {
+	m.skippedCycles.Inc()
+}
+
+func (m *Metrics) RecordCyclePhaseDuration(phase string, duration time.Duration) {
+	m.cyclePhaseDurations.WithLabelValues(phase).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordCycleGames(count int) {
+	m.cycleGames.Set(float64(count))
+}
+
+func (m *Metrics) RecordFailedGames(count int) {
+	m.failedGames.Add(float64(count))
+}
+
+func (m *Metrics) RecordGameEnrichments(cacheHits int, fullEnrichments int) {
+	m.gameEnrichments.WithLabelValues("cache").Add(float64(cacheHits))
+	m.gameEnrichments.WithLabelValues("contract").Add(float64(fullEnrichments))
+}
+
+func (m *Metrics) RecordGameEnrichmentDuration(cached bool, duration time.Duration) {
+	source := "contract"
+	if cached {
+		source = "cache"
+	}
+	m.gameEnrichmentDurations.WithLabelValues(source).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordGameEnrichmentFailure(class string) {
+	m.gameEnrichmentFailures.WithLabelValues(class).Inc()
+}
+
+func (m *Metrics) RecordReorgedGames(count int) {
+	m.reorgedGames.Set(float64(count))
+}
+
+func (m *Metrics) RecordFilteredGames(reason string, count int) {
+	m.filteredGames.WithLabelValues(reason).Set(float64(count))
+}
+
+func (m *Metrics) RecordAlert(result string) {
+	m.alerts.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) RecordResolution(method string, result string) {
+	m.resolutions.WithLabelValues(method, result).Inc()
+}
+
+func (m *Metrics) RecordUnclaimedCredit(recipients string, state string, amount *big.Int) {
+	m.unclaimedCredit.WithLabelValues(recipients, state).Set(weiToEther(amount))
+}
+
+func (m *Metrics) RecordHonestActorBalance(actor common.Address, balance *big.Int) {
+	m.honestActorBalance.WithLabelValues(actor.Hex()).Set(weiToEther(balance))
+}
+
+func (m *Metrics) RecordHonestActorBonded(actor common.Address, bonded *big.Int) {
+	m.honestActorBonded.WithLabelValues(actor.Hex()).Set(weiToEther(bonded))
+}
+
+func (m *Metrics) RecordHonestActorCredit(actor common.Address, credit *big.Int) {
+	m.honestActorCredit.WithLabelValues(actor.Hex()).Set(weiToEther(credit))
+}
+
+func (m *Metrics) RecordClaimantExposure(claimant string, honest bool, bonded *big.Int, pastClock int, maxDelay uint64, oldestClaimAge uint64) {
+	honestLabel := strconv.FormatBool(honest)
+	m.claimantBonded.WithLabelValues(claimant, honestLabel).Set(weiToEther(bonded))
+	m.claimantPastClock.WithLabelValues(claimant, honestLabel).Set(float64(pastClock))
+	m.claimantMaxDelay.WithLabelValues(claimant, honestLabel).Set(float64(maxDelay))
+	m.claimantOldestClaimAge.WithLabelValues(claimant, honestLabel).Set(float64(oldestClaimAge))
+}
+
+func (m *Metrics) RecordClaimantClaims(claimant string, honest bool, age string, count int) {
+	m.claimantClaims.WithLabelValues(claimant, strconv.FormatBool(honest), age).Set(float64(count))
+}
+
+// RemoveClaimant removes the metrics of claimant, so claimants that are no longer labelled don't leave stale series.
+func (m *Metrics) RemoveClaimant(claimant string) {
+	labels := prometheus.Labels{"claimant": claimant}
+	m.claimantClaims.DeletePartialMatch(labels)
+	m.claimantBonded.DeletePartialMatch(labels)
+	m.claimantPastClock.DeletePartialMatch(labels)
+	m.claimantMaxDelay.DeletePartialMatch(labels)
+	m.claimantOldestClaimAge.DeletePartialMatch(labels)
+}
+
+func (m *Metrics) RecordLargePreimageProposals(state string, count int) {
+	m.largePreimageProposals.WithLabelValues(state).Set(float64(count))
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -159,6 +655,23 @@ func (m *Metrics) RecordGameAgreement(status GameAgreementStatus, count int) {
 	m.gamesAgreement.WithLabelValues(labelValuesFor(status)...).Set(float64(count))
 }
 
+func (m *Metrics) RecordForecastSeverity(severity string, count int) {
+	m.forecastSeverity.WithLabelValues(severity).Set(float64(count))
+}
+
+func (m *Metrics) RecordOutputCrossCheck(result string) {
+	m.outputCrossChecks.WithLabelValues(result).Inc()
+}
+
+// weiToEther divides the wei value by 10^18 to get a number in ether as a float64
+func weiToEther(wei *big.Int) float64 {
+	num := new(big.Rat).SetInt(wei)
+	denom := big.NewRat(params.Ether, 1)
+	num = num.Quo(num, denom)
+	f, _ := num.Float64()
+	return f
+}
+
 const (
 	inProgress = true
 	correct    = true
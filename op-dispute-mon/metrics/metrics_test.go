@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	txMetrics := NewTxMetrics(registry)
+	chainA := NewChainMetrics(registry, "chain-a", txMetrics)
+	chainB := NewChainMetrics(registry, "chain-b", txMetrics)
+
+	chainA.RecordMonitoredBlock(100)
+	chainB.RecordMonitoredBlock(200)
+	chainA.RecordCycleFailure("extract")
+	chainA.RecordCycleFailure("extract")
+	chainB.RecordGamesStatus(1, 2, 3)
+	chainA.RecordNonce(5)
+
+	expected := `
+# HELP op_dispute_mon_monitored_block Number of the L1 block games were last monitored at
+# TYPE op_dispute_mon_monitored_block gauge
+op_dispute_mon_monitored_block{chain="chain-a"} 100
+op_dispute_mon_monitored_block{chain="chain-b"} 200
+# HELP op_dispute_mon_cycle_failures Number of monitoring cycles that failed, labelled by the phase that failed
+# TYPE op_dispute_mon_cycle_failures counter
+op_dispute_mon_cycle_failures{chain="chain-a",phase="extract"} 2
+# HELP op_dispute_mon_tracked_games Number of games being tracked by the challenger
+# TYPE op_dispute_mon_tracked_games gauge
+op_dispute_mon_tracked_games{chain="chain-b",status="challenger_won"} 3
+op_dispute_mon_tracked_games{chain="chain-b",status="defender_won"} 2
+op_dispute_mon_tracked_games{chain="chain-b",status="in_progress"} 1
+# HELP op_dispute_mon_txmgr_current_nonce Current nonce of the from address
+# TYPE op_dispute_mon_txmgr_current_nonce gauge
+op_dispute_mon_txmgr_current_nonce 5
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"op_dispute_mon_monitored_block",
+		"op_dispute_mon_cycle_failures",
+		"op_dispute_mon_tracked_games",
+		"op_dispute_mon_txmgr_current_nonce",
+	))
+}
+
+func TestChainMetrics_DuplicateChain(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	txMetrics := NewTxMetrics(registry)
+	NewChainMetrics(registry, "chain-a", txMetrics)
+	require.Panics(t, func() {
+		NewChainMetrics(registry, "chain-a", txMetrics)
+	})
+}
+
+func TestChainMetrics_RemoveClaimant(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	txMetrics := NewTxMetrics(registry)
+	chainA := NewChainMetrics(registry, "chain-a", txMetrics)
+	chainB := NewChainMetrics(registry, "chain-b", txMetrics)
+
+	for _, m := range []*Metrics{chainA, chainB} {
+		m.RecordClaimantClaims("0xaa", true, "3600", 2)
+		m.RecordClaimantClaims("0xaa", true, "+Inf", 1)
+		m.RecordClaimantClaims("other", false, "3600", 4)
+	}
+	chainA.RemoveClaimant("0xaa")
+
+	expected := `
+# HELP op_dispute_mon_claimant_unresolved_claims Number of unresolved claims posted by each claimant, labelled by the upper bound of the claim age in seconds
+# TYPE op_dispute_mon_claimant_unresolved_claims gauge
+op_dispute_mon_claimant_unresolved_claims{age="3600",chain="chain-a",claimant="other",honest="false"} 4
+op_dispute_mon_claimant_unresolved_claims{age="+Inf",chain="chain-b",claimant="0xaa",honest="true"} 1
+op_dispute_mon_claimant_unresolved_claims{age="3600",chain="chain-b",claimant="0xaa",honest="true"} 2
+op_dispute_mon_claimant_unresolved_claims{age="3600",chain="chain-b",claimant="other",honest="false"} 4
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"op_dispute_mon_claimant_unresolved_claims",
+	))
+}
@@ -1,6 +1,17 @@
 package metrics
 
-type NoopMetricsImpl struct{}
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+type NoopMetricsImpl struct {
+	txmetrics.NoopTxMetrics
+}
 
 var NoopMetrics Metricer = new(NoopMetricsImpl)
 
@@ -10,7 +21,52 @@ func (*NoopMetricsImpl) RecordUp()                 {}
 func (*NoopMetricsImpl) CacheAdd(_ string, _ int, _ bool) {}
 func (*NoopMetricsImpl) CacheGet(_ string, _ bool)        {}
 
-func (*NoopMetricsImpl) RecordClaimResolutionDelayMax(delay float64) {}
+func (*NoopMetricsImpl) RecordClaimResolutionDelayMax(delay float64)               {}
+func (*NoopMetricsImpl) RecordClaimResolutionDelay(gameType uint32, delay float64) {}
+func (*NoopMetricsImpl) RecordClaimResolutionDelayStats(_ uint32, _, _ float64)    {}
+func (*NoopMetricsImpl) RecordClaimsPastClock(gameType uint32, count int)          {}
+
+func (*NoopMetricsImpl) RecordMonitoredBlock(number uint64) {}
+func (*NoopMetricsImpl) RecordCycleTimeout(phase string)    {}
+
+func (*NoopMetricsImpl) RecordCycleFailure(phase string)               {}
+func (*NoopMetricsImpl) RecordConsecutiveCycleFailures(count int)      {}
+func (*NoopMetricsImpl) RecordLastSuccessfulCycle(timestamp time.Time) {}
+func (*NoopMetricsImpl) RecordCycleDuration(duration time.Duration)    {}
+func (*NoopMetricsImpl) RecordSkippedCycle()                           {}
+
+func (*NoopMetricsImpl) RecordCyclePhaseDuration(phase string, duration time.Duration) {}
+func (*NoopMetricsImpl) RecordCycleGames(count int)                                    {}
+
+func (*NoopMetricsImpl) RecordFailedGames(count int)                              {}
+func (*NoopMetricsImpl) RecordGameEnrichments(cacheHits int, fullEnrichments int) {}
+
+func (*NoopMetricsImpl) RecordGameEnrichmentDuration(cached bool, duration time.Duration) {}
+func (*NoopMetricsImpl) RecordGameEnrichmentFailure(class string)                         {}
+
+func (*NoopMetricsImpl) RecordReorgedGames(count int) {}
+
+func (*NoopMetricsImpl) RecordFilteredGames(reason string, count int) {}
+
+func (*NoopMetricsImpl) RecordAlert(result string) {}
+
+func (*NoopMetricsImpl) RecordResolution(method string, result string) {}
+
+func (*NoopMetricsImpl) RecordUnclaimedCredit(recipients string, state string, amount *big.Int) {}
+
+func (*NoopMetricsImpl) RecordHonestActorBalance(actor common.Address, balance *big.Int) {}
+func (*NoopMetricsImpl) RecordHonestActorBonded(actor common.Address, bonded *big.Int)   {}
+func (*NoopMetricsImpl) RecordHonestActorCredit(actor common.Address, credit *big.Int)   {}
+
+func (*NoopMetricsImpl) RecordClaimantExposure(claimant string, honest bool, bonded *big.Int, pastClock int, maxDelay uint64, oldestClaimAge uint64) {
+}
+func (*NoopMetricsImpl) RecordClaimantClaims(claimant string, honest bool, age string, count int) {}
+func (*NoopMetricsImpl) RemoveClaimant(claimant string)                                           {}
+
+func (*NoopMetricsImpl) RecordLargePreimageProposals(state string, count int) {}
 
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 func (*NoopMetricsImpl) RecordGameAgreement(status GameAgreementStatus, count int)    {}
+
+func (*NoopMetricsImpl) RecordForecastSeverity(severity string, count int) {}
+func (*NoopMetricsImpl) RecordOutputCrossCheck(result string)              {}
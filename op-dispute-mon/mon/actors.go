@@ -0,0 +1,97 @@
+package mon
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ActorMetrics interface {
+	RecordHonestActorBalance(actor common.Address, balance *big.Int)
+	RecordHonestActorBonded(actor common.Address, bonded *big.Int)
+	RecordHonestActorCredit(actor common.Address, credit *big.Int)
+}
+
+// actorExposure is the funds an honest actor has committed to games.
+type actorExposure struct {
+	games  int
+	claims int
+	bonded *big.Int
+	credit *big.Int
+}
+
+type actorMonitor struct {
+	logger       log.Logger
+	metrics      ActorMetrics
+	fetchBalance BalanceFetcher
+	actors       []common.Address
+	minBalance   *big.Int
+}
+
+func newActorMonitor(logger log.Logger, metrics ActorMetrics, fetchBalance BalanceFetcher, actors []common.Address, minBalance *big.Int) *actorMonitor {
+	seen := make(map[common.Address]bool, len(actors))
+	var unique []common.Address
+	for _, actor := range actors {
+		if !seen[actor] {
+			seen[actor] = true
+			unique = append(unique, actor)
+		}
+	}
+	return &actorMonitor{
+		logger:       logger,
+		metrics:      metrics,
+		fetchBalance: fetchBalance,
+		actors:       unique,
+		minBalance:   minBalance,
+	}
+}
+
+// Detect records the balance of each honest actor as of block along with the bonds it has at risk in unresolved
+// claims and the credit awaiting claim, warning if its balance has fallen below the minimum.
+func (a *actorMonitor) Detect(ctx context.Context, block eth.BlockID, games []*types.EnrichedGameData) {
+	if len(a.actors) == 0 {
+		return
+	}
+	exposures := make(map[common.Address]*actorExposure, len(a.actors))
+	for _, actor := range a.actors {
+		exposures[actor] = &actorExposure{bonded: big.NewInt(0), credit: big.NewInt(0)}
+	}
+	for _, game := range games {
+		for actor, stake := range game.HonestStakes {
+			exposure, ok := exposures[actor]
+			if !ok {
+				continue
+			}
+			exposure.games++
+			exposure.claims += stake.Claims
+			exposure.bonded.Add(exposure.bonded, stake.Bonded)
+		}
+		for _, credit := range game.Credits {
+			if exposure, ok := exposures[credit.Recipient]; ok {
+				exposure.credit.Add(exposure.credit, credit.Amount)
+			}
+		}
+	}
+	for _, actor := range a.actors {
+		exposure := exposures[actor]
+		a.metrics.RecordHonestActorBonded(actor, exposure.bonded)
+		a.metrics.RecordHonestActorCredit(actor, exposure.credit)
+		balance, err := a.fetchBalance(ctx, block, actor)
+		if err != nil {
+			a.logger.Error("Failed to fetch honest actor balance", "actor", actor, "err", err)
+			continue
+		}
+		a.metrics.RecordHonestActorBalance(actor, balance)
+		a.logger.Debug("Honest actor exposure", "actor", actor, "balance", balance, "games", exposure.games,
+			"claims", exposure.claims, "bonded", exposure.bonded, "credit", exposure.credit)
+		if balance.Cmp(a.minBalance) < 0 {
+			a.logger.Warn("Honest actor balance below minimum, may be unable to post bonds",
+				"actor", actor, "balance", balance, "min", a.minBalance, "bonded", exposure.bonded, "credit", exposure.credit)
+		}
+	}
+}
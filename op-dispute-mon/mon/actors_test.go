@@ -0,0 +1,199 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	secondHonestActor = common.Address{0x03}
+	actorBlock        = eth.BlockID{Hash: common.Hash{0xbb}, Number: 42}
+	lowBalanceLog     = "Honest actor balance below minimum, may be unable to post bonds"
+)
+
+func TestActorMonitor_Exposure(t *testing.T) {
+	t.Run("NoActors", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlDebug)
+		m := newMockActorMetrics()
+		balances := &stubBalances{}
+		actors := newActorMonitor(logger, m, balances.FetchBalance, nil, big.NewInt(0))
+		actors.Detect(context.Background(), actorBlock, []*monTypes.EnrichedGameData{actorGame(types.GameStatusInProgress)})
+		require.Zero(t, balances.calls)
+		require.Empty(t, m.balances)
+		require.Empty(t, m.bonded)
+		require.Empty(t, m.credits)
+	})
+
+	t.Run("NoGames", func(t *testing.T) {
+		actors, m, _, _ := setupActorMonitorTest(t)
+		actors.Detect(context.Background(), actorBlock, nil)
+		require.Equal(t, big.NewInt(0), m.bonded[honestActor])
+		require.Equal(t, big.NewInt(0), m.credits[honestActor])
+		require.Equal(t, big.NewInt(1000), m.balances[honestActor])
+	})
+
+	t.Run("ClaimsAcrossGames", func(t *testing.T) {
+		actors, m, balances, _ := setupActorMonitorTest(t)
+		game1 := actorGame(types.GameStatusInProgress)
+		game1.HonestStakes = map[common.Address]monTypes.ActorStake{
+			honestActor: {Claims: 2, Bonded: big.NewInt(30)},
+		}
+		game2 := actorGame(types.GameStatusInProgress)
+		game2.HonestStakes = map[common.Address]monTypes.ActorStake{
+			honestActor:       {Claims: 1, Bonded: big.NewInt(5)},
+			secondHonestActor: {Claims: 1, Bonded: big.NewInt(7)},
+		}
+		game3 := actorGame(types.GameStatusDefenderWon,
+			monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(11)},
+			monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(100)},
+		)
+		game4 := actorGame(types.GameStatusChallengerWon,
+			monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(13), Delayed: true},
+			monTypes.Credit{Recipient: secondHonestActor, Amount: big.NewInt(17)},
+		)
+		// Stakes of actors that aren't monitored are ignored
+		game4.HonestStakes = map[common.Address]monTypes.ActorStake{
+			otherActor: {Claims: 1, Bonded: big.NewInt(1000)},
+		}
+		actors.Detect(context.Background(), actorBlock, []*monTypes.EnrichedGameData{game1, game2, game3, game4})
+
+		require.Equal(t, big.NewInt(35), m.bonded[honestActor])
+		require.Equal(t, big.NewInt(24), m.credits[honestActor])
+		require.Equal(t, big.NewInt(1000), m.balances[honestActor])
+
+		require.Equal(t, big.NewInt(7), m.bonded[secondHonestActor])
+		require.Equal(t, big.NewInt(17), m.credits[secondHonestActor])
+		require.Equal(t, big.NewInt(50), m.balances[secondHonestActor])
+
+		require.NotContains(t, m.bonded, otherActor)
+		require.NotContains(t, m.credits, otherActor)
+		require.NotContains(t, m.balances, otherActor)
+		require.Equal(t, []eth.BlockID{actorBlock, actorBlock}, balances.blocks)
+	})
+
+	t.Run("DuplicateActorsRecordedOnce", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlDebug)
+		m := newMockActorMetrics()
+		balances := &stubBalances{balances: map[common.Address]*big.Int{honestActor: big.NewInt(1)}}
+		actors := newActorMonitor(logger, m, balances.FetchBalance, []common.Address{honestActor, honestActor}, big.NewInt(0))
+		game := actorGame(types.GameStatusInProgress)
+		game.HonestStakes = map[common.Address]monTypes.ActorStake{honestActor: {Claims: 1, Bonded: big.NewInt(3)}}
+		actors.Detect(context.Background(), actorBlock, []*monTypes.EnrichedGameData{game})
+		require.Equal(t, big.NewInt(3), m.bonded[honestActor])
+		require.Equal(t, 1, balances.calls)
+	})
+
+	t.Run("BalanceFetchError", func(t *testing.T) {
+		actors, m, balances, logs := setupActorMonitorTest(t)
+		balances.err = errors.New("boom")
+		game := actorGame(types.GameStatusInProgress)
+		game.HonestStakes = map[common.Address]monTypes.ActorStake{honestActor: {Claims: 1, Bonded: big.NewInt(3)}}
+		actors.Detect(context.Background(), actorBlock, []*monTypes.EnrichedGameData{game})
+		require.Equal(t, big.NewInt(3), m.bonded[honestActor])
+		require.Empty(t, m.balances)
+		levelFilter := testlog.NewLevelFilter(log.LevelError)
+		messageFilter := testlog.NewMessageFilter("Failed to fetch honest actor balance")
+		require.Len(t, logs.FindLogs(levelFilter, messageFilter), 2)
+		requireLowBalanceWarnings(t, logs)
+	})
+}
+
+func TestActorMonitor_LowBalance(t *testing.T) {
+	t.Run("WarnsBelowMinimum", func(t *testing.T) {
+		actors, _, _, logs := setupActorMonitorTest(t)
+		game := actorGame(types.GameStatusInProgress)
+		game.HonestStakes = map[common.Address]monTypes.ActorStake{secondHonestActor: {Claims: 1, Bonded: big.NewInt(8)}}
+		actors.Detect(context.Background(), actorBlock, []*monTypes.EnrichedGameData{game})
+		requireLowBalanceWarnings(t, logs, secondHonestActor)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter(lowBalanceLog))
+		require.Equal(t, big.NewInt(50), l.AttrValue("balance"))
+		require.Equal(t, big.NewInt(8), l.AttrValue("bonded"))
+	})
+
+	t.Run("NoWarningAtMinimum", func(t *testing.T) {
+		actors, _, balances, logs := setupActorMonitorTest(t)
+		balances.balances[secondHonestActor] = big.NewInt(100)
+		actors.Detect(context.Background(), actorBlock, nil)
+		requireLowBalanceWarnings(t, logs)
+	})
+}
+
+func setupActorMonitorTest(t *testing.T) (*actorMonitor, *mockActorMetrics, *stubBalances, *testlog.CapturingHandler) {
+	logger, logs := testlog.CaptureLogger(t, log.LvlDebug)
+	m := newMockActorMetrics()
+	balances := &stubBalances{balances: map[common.Address]*big.Int{
+		honestActor:       big.NewInt(1000),
+		secondHonestActor: big.NewInt(50),
+	}}
+	actors := newActorMonitor(logger, m, balances.FetchBalance, []common.Address{honestActor, secondHonestActor}, big.NewInt(100))
+	return actors, m, balances, logs
+}
+
+func actorGame(status types.GameStatus, credits ...monTypes.Credit) *monTypes.EnrichedGameData {
+	return &monTypes.EnrichedGameData{
+		Status:  status,
+		Credits: credits,
+	}
+}
+
+func requireLowBalanceWarnings(t *testing.T, logs *testlog.CapturingHandler, actors ...common.Address) {
+	levelFilter := testlog.NewLevelFilter(log.LevelWarn)
+	messageFilter := testlog.NewMessageFilter(lowBalanceLog)
+	warnings := logs.FindLogs(levelFilter, messageFilter)
+	require.Len(t, warnings, len(actors))
+	for i, actor := range actors {
+		require.Equal(t, actor, warnings[i].AttrValue("actor"))
+	}
+}
+
+type stubBalances struct {
+	calls    int
+	blocks   []eth.BlockID
+	balances map[common.Address]*big.Int
+	err      error
+}
+
+func (s *stubBalances) FetchBalance(_ context.Context, block eth.BlockID, account common.Address) (*big.Int, error) {
+	s.calls++
+	s.blocks = append(s.blocks, block)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.balances[account], nil
+}
+
+type mockActorMetrics struct {
+	balances map[common.Address]*big.Int
+	bonded   map[common.Address]*big.Int
+	credits  map[common.Address]*big.Int
+}
+
+func newMockActorMetrics() *mockActorMetrics {
+	return &mockActorMetrics{
+		balances: make(map[common.Address]*big.Int),
+		bonded:   make(map[common.Address]*big.Int),
+		credits:  make(map[common.Address]*big.Int),
+	}
+}
+
+func (m *mockActorMetrics) RecordHonestActorBalance(actor common.Address, balance *big.Int) {
+	m.balances[actor] = balance
+}
+
+func (m *mockActorMetrics) RecordHonestActorBonded(actor common.Address, bonded *big.Int) {
+	m.bonded[actor] = bonded
+}
+
+func (m *mockActorMetrics) RecordHonestActorCredit(actor common.Address, credit *big.Int) {
+	m.credits[actor] = credit
+}
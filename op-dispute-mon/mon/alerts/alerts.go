@@ -0,0 +1,117 @@
+package alerts
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+type Category string
+
+const (
+	// CategoryUnexpectedForecast is a game in progress that is forecast to resolve incorrectly.
+	CategoryUnexpectedForecast Category = "unexpected_forecast"
+	// CategoryUnexpectedResult is a game that resolved incorrectly.
+	CategoryUnexpectedResult Category = "unexpected_result"
+	// CategoryReorgedL1Head is a game created against an L1 head that is no longer canonical.
+	CategoryReorgedL1Head Category = "reorged_l1_head"
+	// CategoryRollupDivergence is the primary and secondary rollup nodes reporting different output roots for a
+	// game's L2 block. It is critical if neither is the game's root claim, and a warning if the secondary's is.
+	CategoryRollupDivergence Category = "rollup_divergence"
+)
+
+// Results of handling an alert, reported in metrics.
+const (
+	ResultDelivered     = "delivered"
+	ResultAttemptFailed = "attempt_failed"
+	ResultFailed        = "failed"
+	ResultDropped       = "dropped"
+	ResultSuppressed    = "suppressed"
+)
+
+type Metrics interface {
+	RecordAlert(result string)
+}
+
+// Alert is a finding about a game that needs attention.
+type Alert struct {
+	Chain    string         `json:"chain,omitempty"`
+	Game     common.Address `json:"game"`
+	Severity Severity       `json:"severity"`
+	Category Category       `json:"category"`
+	Message  string         `json:"message"`
+	Time     time.Time      `json:"time"`
+}
+
+// Sink receives alerts. Emit must not block, as alerts are emitted during monitoring cycles.
+type Sink interface {
+	Emit(alert Alert)
+}
+
+// NoopSink discards all alerts.
+type NoopSink struct{}
+
+func (NoopSink) Emit(Alert) {}
+
+type alertKey struct {
+	game     common.Address
+	category Category
+	severity Severity
+}
+
+// Deduplicator forwards alerts to a sink at most once per cooldown for each game and category, so a persistent
+// condition isn't reported every monitoring cycle.
+type Deduplicator struct {
+	clock    clock.Clock
+	metrics  Metrics
+	sink     Sink
+	cooldown time.Duration
+
+	lock sync.Mutex
+	sent map[alertKey]time.Time
+}
+
+func NewDeduplicator(cl clock.Clock, m Metrics, cooldown time.Duration, sink Sink) *Deduplicator {
+	return &Deduplicator{
+		clock:    cl,
+		metrics:  m,
+		sink:     sink,
+		cooldown: cooldown,
+		sent:     make(map[alertKey]time.Time),
+	}
+}
+
+// Emit forwards alert unless an alert for the same game, category and severity was forwarded within the cooldown.
+// A change in severity is forwarded immediately so an escalating condition isn't held back by the cooldown.
+func (d *Deduplicator) Emit(alert Alert) {
+	now := d.clock.Now()
+	key := alertKey{game: alert.Game, category: alert.Category, severity: alert.Severity}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if sent, ok := d.sent[key]; ok && now.Sub(sent) < d.cooldown {
+		d.metrics.RecordAlert(ResultSuppressed)
+		return
+	}
+	// Forget alerts whose cooldown has passed so the map doesn't grow with every game ever alerted on
+	for k, sent := range d.sent {
+		if now.Sub(sent) >= d.cooldown {
+			delete(d.sent, k)
+		}
+	}
+	d.sent[key] = now
+	if alert.Time.IsZero() {
+		alert.Time = now
+	}
+	d.sink.Emit(alert)
+}
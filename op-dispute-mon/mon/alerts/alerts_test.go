@@ -0,0 +1,87 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const testCooldown = time.Hour
+
+func TestDeduplicator(t *testing.T) {
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+
+	t.Run("SuppressesRepeatsWithinCooldown", func(t *testing.T) {
+		dedup, cl, m, sink := setupDeduplicatorTest()
+		for i := 0; i < 3; i++ {
+			dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast})
+			cl.AdvanceTime(testCooldown / 4)
+		}
+		require.Len(t, sink.alerts, 1)
+		require.Equal(t, 2, m.results[ResultSuppressed])
+	})
+
+	t.Run("SendsAgainAfterCooldown", func(t *testing.T) {
+		dedup, cl, _, sink := setupDeduplicatorTest()
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast})
+		cl.AdvanceTime(testCooldown)
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast})
+		require.Len(t, sink.alerts, 2)
+	})
+
+	t.Run("DistinctGamesAndCategories", func(t *testing.T) {
+		dedup, _, m, sink := setupDeduplicatorTest()
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast})
+		dedup.Emit(Alert{Game: gameA, Category: CategoryReorgedL1Head})
+		dedup.Emit(Alert{Game: gameB, Category: CategoryUnexpectedForecast})
+		require.Len(t, sink.alerts, 3)
+		require.Zero(t, m.results[ResultSuppressed])
+	})
+
+	t.Run("SendsSeverityChangesWithinCooldown", func(t *testing.T) {
+		dedup, _, m, sink := setupDeduplicatorTest()
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast, Severity: SeverityWarning})
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast, Severity: SeverityCritical})
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast, Severity: SeverityCritical})
+		require.Len(t, sink.alerts, 2)
+		require.Equal(t, SeverityCritical, sink.alerts[1].Severity)
+		require.Equal(t, 1, m.results[ResultSuppressed])
+	})
+
+	t.Run("SetsTime", func(t *testing.T) {
+		dedup, cl, _, sink := setupDeduplicatorTest()
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast})
+		explicit := time.Unix(500, 0)
+		dedup.Emit(Alert{Game: gameB, Category: CategoryUnexpectedForecast, Time: explicit})
+		require.Equal(t, cl.Now(), sink.alerts[0].Time)
+		require.Equal(t, explicit, sink.alerts[1].Time)
+	})
+
+	t.Run("PrunesExpiredEntries", func(t *testing.T) {
+		dedup, cl, _, _ := setupDeduplicatorTest()
+		dedup.Emit(Alert{Game: gameA, Category: CategoryUnexpectedForecast})
+		cl.AdvanceTime(testCooldown)
+		dedup.Emit(Alert{Game: gameB, Category: CategoryUnexpectedForecast})
+		require.Len(t, dedup.sent, 1)
+	})
+}
+
+func setupDeduplicatorTest() (*Deduplicator, *clock.DeterministicClock, *stubMetrics, *recordingSink) {
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	m := &stubMetrics{}
+	sink := &recordingSink{}
+	return NewDeduplicator(cl, m, testCooldown, sink), cl, m, sink
+}
+
+type recordingSink struct {
+	alerts []Alert
+}
+
+func (s *recordingSink) Emit(alert Alert) {
+	s.alerts = append(s.alerts, alert)
+}
@@ -0,0 +1,164 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+const (
+	// queueSize is the maximum number of alerts waiting to be delivered. Further alerts are dropped.
+	queueSize = 100
+	// maxAttempts is the number of times delivery of an alert is attempted before it is dropped.
+	maxAttempts = 5
+)
+
+type WebhookConfig struct {
+	URL        string        // URL alerts are posted to.
+	AuthHeader string        // Value of the Authorization header sent with each alert. Omitted if empty.
+	Timeout    time.Duration // Maximum time a single delivery attempt may take.
+}
+
+// WebhookSink posts alerts as JSON to a webhook. Alerts are queued and delivered in the background so emitting an
+// alert never blocks the monitoring cycle. Failed deliveries are retried with exponential backoff.
+type WebhookSink struct {
+	logger   log.Logger
+	metrics  Metrics
+	chain    string
+	cfg      WebhookConfig
+	client   *http.Client
+	strategy retry.Strategy
+
+	queue chan Alert
+
+	// ctx is the parent context of each delivery run.
+	ctx context.Context
+
+	lock   sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWebhookSink creates a sink posting alerts to the webhook in cfg. Alerts are labelled with chain if it is set.
+func NewWebhookSink(ctx context.Context, logger log.Logger, m Metrics, chain string, cfg WebhookConfig) *WebhookSink {
+	return &WebhookSink{
+		ctx:      ctx,
+		logger:   logger,
+		metrics:  m,
+		chain:    chain,
+		cfg:      cfg,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		strategy: retry.Exponential(),
+		queue:    make(chan Alert, queueSize),
+	}
+}
+
+// Emit queues alert for delivery, dropping it if the queue is full.
+func (w *WebhookSink) Emit(alert Alert) {
+	alert.Chain = w.chain
+	select {
+	case w.queue <- alert:
+	default:
+		w.logger.Warn("Alert queue full, dropping alert", "game", alert.Game, "category", alert.Category)
+		w.metrics.RecordAlert(ResultDropped)
+	}
+}
+
+func (w *WebhookSink) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	for {
+		select {
+		case alert := <-w.queue:
+			w.deliver(ctx, alert)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver posts alert, retrying failed attempts with backoff. Unlike retry.Do, the backoff is aborted as soon as
+// ctx is done so stopping the sink isn't delayed by a failing webhook.
+func (w *WebhookSink) deliver(ctx context.Context, alert Alert) {
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(w.strategy.Duration(attempt - 1)):
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err = w.post(ctx, alert); err == nil {
+			w.metrics.RecordAlert(ResultDelivered)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		w.logger.Debug("Failed to deliver alert", "game", alert.Game, "category", alert.Category, "attempt", attempt+1, "err", err)
+		w.metrics.RecordAlert(ResultAttemptFailed)
+	}
+	w.logger.Error("Failed to deliver alert", "game", alert.Game, "category", alert.Category, "attempts", maxAttempts, "err", err)
+	w.metrics.RecordAlert(ResultFailed)
+}
+
+func (w *WebhookSink) post(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.cfg.AuthHeader != "" {
+		req.Header.Set("Authorization", w.cfg.AuthHeader)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %v", resp.StatusCode)
+	}
+	return nil
+}
+
+// Start starts delivering queued alerts. It is a no-op if the sink is already running.
+func (w *WebhookSink) Start() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(w.ctx)
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go w.loop(ctx, w.done)
+}
+
+// Stop stops delivering alerts, abandoning any alert currently being delivered. Alerts still queued are
+// delivered if the sink is started again.
+func (w *WebhookSink) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+	w.cancel = nil
+	w.done = nil
+}
@@ -0,0 +1,226 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestWebhookSink_Deliver(t *testing.T) {
+	t.Run("PostsAlert", func(t *testing.T) {
+		sink, receiver, m := setupWebhookTest(t, "Bearer secret")
+		sink.Start()
+		defer sink.Stop()
+		alert := Alert{
+			Game:     common.Address{0xaa},
+			Severity: SeverityCritical,
+			Category: CategoryUnexpectedResult,
+			Message:  "game resolved incorrectly",
+			Time:     time.Unix(1_000_000, 0).UTC(),
+		}
+		sink.Emit(alert)
+
+		req := receiver.next(t)
+		require.Equal(t, "application/json", req.contentType)
+		require.Equal(t, "Bearer secret", req.auth)
+		alert.Chain = "op-mainnet"
+		require.Equal(t, alert, req.alert)
+		require.Eventually(t, func() bool { return m.count(ResultDelivered) == 1 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("OmitsEmptyAuthHeader", func(t *testing.T) {
+		sink, receiver, _ := setupWebhookTest(t, "")
+		sink.Start()
+		defer sink.Stop()
+		sink.Emit(Alert{Game: common.Address{0xaa}})
+		require.Empty(t, receiver.next(t).auth)
+	})
+
+	t.Run("RetriesFailedAttempts", func(t *testing.T) {
+		sink, receiver, m := setupWebhookTest(t, "")
+		receiver.setStatuses(http.StatusInternalServerError, http.StatusBadGateway)
+		sink.Start()
+		defer sink.Stop()
+		sink.Emit(Alert{Game: common.Address{0xaa}})
+		for i := 0; i < 3; i++ {
+			require.Equal(t, common.Address{0xaa}, receiver.next(t).alert.Game)
+		}
+		require.Eventually(t, func() bool { return m.count(ResultDelivered) == 1 }, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, 2, m.count(ResultAttemptFailed))
+		require.Zero(t, m.count(ResultFailed))
+	})
+
+	t.Run("FailsAfterMaxAttempts", func(t *testing.T) {
+		sink, receiver, m := setupWebhookTest(t, "")
+		receiver.setStatuses(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError,
+			http.StatusInternalServerError, http.StatusInternalServerError)
+		sink.Start()
+		defer sink.Stop()
+		sink.Emit(Alert{Game: common.Address{0xaa}})
+		require.Eventually(t, func() bool { return m.count(ResultFailed) == 1 }, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, maxAttempts, m.count(ResultAttemptFailed))
+		require.Zero(t, m.count(ResultDelivered))
+		require.Equal(t, maxAttempts, receiver.received())
+	})
+
+	t.Run("DropsWhenQueueFull", func(t *testing.T) {
+		sink, _, m := setupWebhookTest(t, "")
+		// Not started so nothing is taken from the queue
+		for i := 0; i < queueSize+2; i++ {
+			sink.Emit(Alert{Game: common.Address{byte(i)}})
+		}
+		require.Equal(t, 2, m.count(ResultDropped))
+	})
+
+	t.Run("StopAbortsBackoff", func(t *testing.T) {
+		sink, receiver, _ := setupWebhookTest(t, "")
+		sink.strategy = retry.Fixed(time.Hour)
+		receiver.setStatuses(http.StatusInternalServerError)
+		sink.Start()
+		sink.Emit(Alert{Game: common.Address{0xaa}})
+		receiver.next(t)
+		stopped := make(chan struct{})
+		go func() {
+			sink.Stop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Stop did not abort backoff")
+		}
+	})
+}
+
+func TestWebhookSink_DeduplicatesAcrossCycles(t *testing.T) {
+	sink, receiver, m := setupWebhookTest(t, "")
+	sink.Start()
+	defer sink.Stop()
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	dedup := NewDeduplicator(cl, m, testCooldown, sink)
+	interval := 10 * time.Minute
+
+	// Each monitoring cycle reports the same persistent conditions
+	cycle := func() {
+		dedup.Emit(Alert{Game: common.Address{0xaa}, Category: CategoryUnexpectedForecast})
+		dedup.Emit(Alert{Game: common.Address{0xbb}, Category: CategoryReorgedL1Head})
+		cl.AdvanceTime(interval)
+	}
+	for i := 0; i < 5; i++ {
+		cycle()
+	}
+	require.Eventually(t, func() bool { return m.count(ResultDelivered) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 8, m.count(ResultSuppressed))
+	require.Equal(t, 2, receiver.received())
+
+	// Once the cooldown passes, the conditions are reported again
+	cl.AdvanceTime(testCooldown)
+	cycle()
+	require.Eventually(t, func() bool { return m.count(ResultDelivered) == 4 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 4, receiver.received())
+}
+
+func setupWebhookTest(t *testing.T, auth string) (*WebhookSink, *webhookReceiver, *stubMetrics) {
+	receiver := &webhookReceiver{requests: make(chan receivedAlert, 100)}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+	logger := testlog.Logger(t, log.LvlDebug)
+	m := &stubMetrics{}
+	sink := NewWebhookSink(context.Background(), logger, m, "op-mainnet", WebhookConfig{
+		URL:        server.URL,
+		AuthHeader: auth,
+		Timeout:    5 * time.Second,
+	})
+	sink.strategy = retry.Fixed(time.Millisecond)
+	return sink, receiver, m
+}
+
+type receivedAlert struct {
+	contentType string
+	auth        string
+	alert       Alert
+}
+
+// webhookReceiver records the alerts posted to it, responding with the configured statuses in order and then 200 OK.
+type webhookReceiver struct {
+	lock     sync.Mutex
+	statuses []int
+	count    int
+	requests chan receivedAlert
+}
+
+func (r *webhookReceiver) setStatuses(statuses ...int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.statuses = statuses
+}
+
+func (r *webhookReceiver) received() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.count
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var alert Alert
+	if err := json.NewDecoder(req.Body).Decode(&alert); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.lock.Lock()
+	r.count++
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status = r.statuses[0]
+		r.statuses = r.statuses[1:]
+	}
+	r.lock.Unlock()
+	r.requests <- receivedAlert{
+		contentType: req.Header.Get("Content-Type"),
+		auth:        req.Header.Get("Authorization"),
+		alert:       alert,
+	}
+	w.WriteHeader(status)
+}
+
+func (r *webhookReceiver) next(t *testing.T) receivedAlert {
+	select {
+	case req := <-r.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for alert")
+		return receivedAlert{}
+	}
+}
+
+type stubMetrics struct {
+	lock    sync.Mutex
+	results map[string]int
+}
+
+func (m *stubMetrics) RecordAlert(result string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.results == nil {
+		m.results = make(map[string]int)
+	}
+	m.results[result]++
+}
+
+func (m *stubMetrics) count(result string) int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.results[result]
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DumpHeader is the first line of a game dump, identifying the cycle the games were loaded in.
+// Each following line is the GameDetail of one game, as served by the game detail endpoint.
+type DumpHeader struct {
+	CycleStart time.Time   `json:"cycleStart"`
+	Block      eth.BlockID `json:"block"`
+	Games      int         `json:"games"`
+}
+
+// Dumper writes the state of every game loaded in a cycle to a file as JSON lines, for analysis after an incident.
+// Games are encoded one at a time so the dump of a large number of games is never held in memory as a whole.
+type Dumper struct {
+	path   string
+	retain uint
+}
+
+// NewDumper creates a dumper writing to path and keeping the last retain dumps.
+// Older dumps are kept alongside path with the suffix .1 for the previous dump, .2 for the one before it and so on.
+func NewDumper(path string, retain uint) *Dumper {
+	return &Dumper{path: path, retain: max(retain, 1)}
+}
+
+// Dump writes the games loaded in cycle. Failed cycles are skipped as they have no games.
+// The dump is written to a temporary file and renamed over the previous dump, so path always holds a complete dump.
+func (d *Dumper) Dump(cycle Cycle) error {
+	if cycle.Err != nil {
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(d.path), filepath.Base(d.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create game dump: %w", err)
+	}
+	if err := writeDump(tmp, cycle); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to close game dump: %w", err)
+	}
+	if err := d.rotate(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), d.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace game dump: %w", err)
+	}
+	return nil
+}
+
+func writeDump(f *os.File, cycle Cycle) error {
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	header := DumpHeader{CycleStart: cycle.Start, Block: cycle.Block, Games: len(cycle.Games)}
+	if err := enc.Encode(header); err != nil {
+		return fmt.Errorf("failed to write game dump header: %w", err)
+	}
+	for _, game := range cycle.Games {
+		if err := enc.Encode(newGameDetail(game, cycle.forecast(game.Proxy))); err != nil {
+			return fmt.Errorf("failed to write game %v to dump: %w", game.Proxy, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write game dump: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync game dump: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts the retained dumps along by one, discarding the oldest.
+// The current dump is hard linked rather than renamed to the .1 suffix so path is never missing.
+func (d *Dumper) rotate() error {
+	if d.retain == 1 {
+		return nil
+	}
+	for i := d.retain - 1; i > 1; i-- {
+		if err := os.Rename(d.retainedPath(i-1), d.retainedPath(i)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate game dump: %w", err)
+		}
+	}
+	// Only left in place when two dumps are retained, as otherwise it was renamed above.
+	if err := os.Remove(d.retainedPath(1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rotate game dump: %w", err)
+	}
+	if err := os.Link(d.path, d.retainedPath(1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rotate game dump: %w", err)
+	}
+	return nil
+}
+
+func (d *Dumper) retainedPath(i uint) string {
+	return fmt.Sprintf("%v.%d", d.path, i)
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumper_Dump(t *testing.T) {
+	t.Run("CompleteForLargeGameSet", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "games.jsonl")
+		cycle := newLargeTestCycle(2000)
+		require.NoError(t, NewDumper(path, 1).Dump(cycle))
+
+		header, games := readDump(t, path)
+		require.Equal(t, DumpHeader{CycleStart: cycleStart, Block: testBlock, Games: len(cycle.Games)}, header)
+		require.Len(t, games, len(cycle.Games))
+		store := NewStore()
+		store.Publish(cycle)
+		for i, game := range games {
+			require.Equal(t, cycle.Games[i].Proxy, game.Address)
+			expected, ok := store.Snapshot().Game(game.Address)
+			require.True(t, ok)
+			require.Equal(t, expected, game)
+		}
+		require.NotNil(t, games[0].Forecast)
+		require.Nil(t, games[1].Forecast)
+	})
+
+	t.Run("MatchesDetailEndpoint", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "games.jsonl")
+		cycle := newTestCycle()
+		require.NoError(t, NewDumper(path, 1).Dump(cycle))
+
+		store := NewStore()
+		store.Publish(cycle)
+		handler := NewHandler(testlog.Logger(t, log.LvlInfo), store, &stubHealth{})
+		lines := readLines(t, path)
+		require.Len(t, lines, 3)
+		require.Equal(t, get(t, handler, "/games/"+gameAddr1.Hex()).Body.String(), lines[1]+"\n")
+		require.Equal(t, get(t, handler, "/games/"+gameAddr2.Hex()).Body.String(), lines[2]+"\n")
+	})
+
+	t.Run("NoGames", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "games.jsonl")
+		require.NoError(t, NewDumper(path, 1).Dump(Cycle{Start: cycleStart, Block: testBlock}))
+		header, games := readDump(t, path)
+		require.Zero(t, header.Games)
+		require.Empty(t, games)
+	})
+
+	t.Run("ReplacesPreviousDump", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "games.jsonl")
+		dumper := NewDumper(path, 1)
+		require.NoError(t, dumper.Dump(newLargeTestCycle(5)))
+		require.NoError(t, dumper.Dump(newLargeTestCycle(2)))
+		_, games := readDump(t, path)
+		require.Len(t, games, 2)
+		requireFiles(t, dir, "games.jsonl")
+	})
+
+	t.Run("SkipsFailedCycle", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "games.jsonl")
+		dumper := NewDumper(path, 3)
+		require.NoError(t, dumper.Dump(newLargeTestCycle(5)))
+		require.NoError(t, dumper.Dump(Cycle{Start: cycleStart, Err: errors.New("boom")}))
+		_, games := readDump(t, path)
+		require.Len(t, games, 5)
+		requireFiles(t, dir, "games.jsonl")
+	})
+
+	t.Run("RetainsPreviousDumps", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "games.jsonl")
+		dumper := NewDumper(path, 3)
+		for i := 1; i <= 5; i++ {
+			require.NoError(t, dumper.Dump(newLargeTestCycle(i)))
+		}
+		requireFiles(t, dir, "games.jsonl", "games.jsonl.1", "games.jsonl.2")
+		for i, name := range []string{"games.jsonl", "games.jsonl.1", "games.jsonl.2"} {
+			header, games := readDump(t, filepath.Join(dir, name))
+			require.Equal(t, 5-i, header.Games)
+			require.Len(t, games, 5-i)
+		}
+	})
+
+	t.Run("RetainsTwoDumps", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "games.jsonl")
+		dumper := NewDumper(path, 2)
+		for i := 1; i <= 3; i++ {
+			require.NoError(t, dumper.Dump(newLargeTestCycle(i)))
+		}
+		requireFiles(t, dir, "games.jsonl", "games.jsonl.1")
+		_, games := readDump(t, path)
+		require.Len(t, games, 3)
+		_, games = readDump(t, path+".1")
+		require.Len(t, games, 2)
+	})
+
+	t.Run("MissingDirectory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "games.jsonl")
+		require.ErrorIs(t, NewDumper(path, 1).Dump(newTestCycle()), os.ErrNotExist)
+	})
+}
+
+// readDump reads the dump at path, failing the test if any line is not valid.
+func readDump(t *testing.T, path string) (DumpHeader, []GameDetail) {
+	lines := readLines(t, path)
+	require.NotEmpty(t, lines, "missing header")
+	var header DumpHeader
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	games := make([]GameDetail, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		var game GameDetail
+		require.NoError(t, json.Unmarshal([]byte(line), &game))
+		games = append(games, game)
+	}
+	return header, games
+}
+
+func readLines(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func requireFiles(t *testing.T, dir string, expected ...string) {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.ElementsMatch(t, expected, names)
+}
+
+// newLargeTestCycle returns a cycle of count games with claims, clocks and bonds. Every other game is forecast.
+func newLargeTestCycle(count int) Cycle {
+	cycle := Cycle{
+		Start:     cycleStart,
+		Duration:  time.Second,
+		Block:     testBlock,
+		Forecasts: make(map[common.Address]monTypes.GameForecast),
+	}
+	for i := 0; i < count; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+		game := &monTypes.EnrichedGameData{
+			GameMetadata:  gameTypes.GameMetadata{Proxy: addr, GameType: uint32(i % 3), Timestamp: uint64(1000 + i)},
+			L2BlockNumber: uint64(i),
+			RootClaim:     common.BigToHash(big.NewInt(int64(i))),
+			Status:        gameTypes.GameStatus(i % 3),
+			Duration:      3600,
+		}
+		for j := 0; j < i%5+1; j++ {
+			parent := j - 1
+			if j == 0 {
+				parent = math.MaxUint32
+			}
+			game.Claims = append(game.Claims, faultTypes.Claim{
+				ClaimData: faultTypes.ClaimData{
+					Value:    common.BigToHash(big.NewInt(int64(j))),
+					Bond:     big.NewInt(int64(1000 * (j + 1))),
+					Position: faultTypes.NewPosition(faultTypes.Depth(j), big.NewInt(0)),
+				},
+				Clock:               faultTypes.NewClock(uint64(60*j), uint64(1000+j)),
+				Claimant:            common.Address{byte(j)},
+				ContractIndex:       j,
+				ParentContractIndex: parent,
+			})
+		}
+		if i%2 == 0 {
+			cycle.Forecasts[addr] = monTypes.GameForecast{Status: gameTypes.GameStatusDefenderWon, AgreeRoot: true, ExpectedRoot: game.RootClaim}
+		}
+		cycle.Games = append(cycle.Games, game)
+	}
+	return cycle
+}
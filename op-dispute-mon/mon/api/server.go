@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SnapshotSource provides the snapshot served by the API.
+type SnapshotSource interface {
+	Snapshot() *Snapshot
+}
+
+// HealthChecker reports whether the monitor is healthy.
+type HealthChecker interface {
+	// CheckHealth returns an error describing why the monitor is unhealthy, or nil if it is healthy.
+	CheckHealth() error
+}
+
+// Health is the response of the health check endpoint.
+type Health struct {
+	Healthy bool   `json:"healthy"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// NewHandler creates the HTTP handler serving the latest monitoring snapshot from source and the health
+// reported by health.
+func NewHandler(logger log.Logger, source SnapshotSource, health HealthChecker) http.Handler {
+	h := &handler{logger: logger, source: source, health: health}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/games", h.handleGames)
+	mux.HandleFunc("/games/", h.handleGame)
+	mux.HandleFunc("/claimants", h.handleClaimants)
+	mux.HandleFunc("/status", h.handleStatus)
+	mux.HandleFunc("/healthz", h.handleHealth)
+	return mux
+}
+
+// Chain is the snapshot source and health of one of multiple monitored chains.
+type Chain struct {
+	Name   string
+	Source SnapshotSource
+	Health HealthChecker
+}
+
+// NewChainsHandler creates the HTTP handler serving the API of each of chains under /chains/<name>/.
+// The health check endpoint at /healthz reports the combined health of all chains.
+func NewChainsHandler(logger log.Logger, chains []Chain) http.Handler {
+	mux := http.NewServeMux()
+	for _, chain := range chains {
+		prefix := "/chains/" + chain.Name
+		mux.Handle(prefix+"/", http.StripPrefix(prefix, NewHandler(logger, chain.Source, chain.Health)))
+	}
+	h := &handler{logger: logger, health: chainsHealth(chains)}
+	mux.HandleFunc("/healthz", h.handleHealth)
+	return mux
+}
+
+// chainsHealth is unhealthy if any of the chains is unhealthy.
+type chainsHealth []Chain
+
+func (c chainsHealth) CheckHealth() error {
+	var result error
+	for _, chain := range c {
+		if err := chain.Health.CheckHealth(); err != nil {
+			result = errors.Join(result, fmt.Errorf("%v: %w", chain.Name, err))
+		}
+	}
+	return result
+}
+
+type handler struct {
+	logger log.Logger
+	source SnapshotSource
+	health HealthChecker
+}
+
+func (h *handler) handleGames(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	games := h.source.Snapshot().Games
+	summaries := make([]GameSummary, 0, len(games))
+	for _, game := range games {
+		summaries = append(summaries, game.GameSummary)
+	}
+	h.writeJSON(w, summaries)
+}
+
+func (h *handler) handleGame(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	addr := strings.TrimPrefix(r.URL.Path, "/games/")
+	if !common.IsHexAddress(addr) {
+		http.Error(w, "invalid game address", http.StatusBadRequest)
+		return
+	}
+	game, ok := h.source.Snapshot().Game(common.HexToAddress(addr))
+	if !ok {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	h.writeJSON(w, game)
+}
+
+func (h *handler) handleClaimants(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	claimants := h.source.Snapshot().Claimants
+	if claimants == nil {
+		claimants = []Claimant{}
+	}
+	h.writeJSON(w, claimants)
+}
+
+func (h *handler) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	h.writeJSON(w, h.source.Snapshot().Status)
+}
+
+func (h *handler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if !requireGet(w, r) {
+		return
+	}
+	if err := h.health.CheckHealth(); err != nil {
+		h.writeJSONStatus(w, http.StatusServiceUnavailable, Health{Reason: err.Error()})
+		return
+	}
+	h.writeJSON(w, Health{Healthy: true})
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, value any) {
+	h.writeJSONStatus(w, http.StatusOK, value)
+}
+
+func (h *handler) writeJSONStatus(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		h.logger.Warn("Failed to write API response", "err", err)
+	}
+}
+
+func requireGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	store := NewStore()
+	store.Publish(newTestCycle())
+	health := &stubHealth{}
+	handler := NewHandler(testlog.Logger(t, log.LvlInfo), store, health)
+
+	t.Run("Games", func(t *testing.T) {
+		rec := get(t, handler, "/games")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var games []GameSummary
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &games))
+		expected := store.Snapshot().Games
+		require.Equal(t, []GameSummary{expected[0].GameSummary, expected[1].GameSummary}, games)
+		require.NotContains(t, rec.Body.String(), "claimList", "should not include claims in summaries")
+	})
+
+	t.Run("NoGames", func(t *testing.T) {
+		rec := get(t, NewHandler(testlog.Logger(t, log.LvlInfo), NewStore(), &stubHealth{}), "/games")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "[]\n", rec.Body.String())
+	})
+
+	t.Run("Game", func(t *testing.T) {
+		rec := get(t, handler, "/games/"+gameAddr1.Hex())
+		require.Equal(t, http.StatusOK, rec.Code)
+		var game GameDetail
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &game))
+		expected, _ := store.Snapshot().Game(gameAddr1)
+		require.Equal(t, expected, game)
+	})
+
+	t.Run("GameLowerCaseAddress", func(t *testing.T) {
+		rec := get(t, handler, "/games/"+strings.ToLower(gameAddr2.Hex()))
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("GameNotFound", func(t *testing.T) {
+		rec := get(t, handler, "/games/0x1234567890123456789012345678901234567890")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		rec := get(t, handler, "/games/0x1234")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Claimants", func(t *testing.T) {
+		rec := get(t, handler, "/claimants")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var claimants []Claimant
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &claimants))
+		require.Equal(t, store.Snapshot().Claimants, claimants)
+		require.Contains(t, rec.Body.String(), `"honest":true`)
+	})
+
+	t.Run("NoClaimants", func(t *testing.T) {
+		rec := get(t, NewHandler(testlog.Logger(t, log.LvlInfo), NewStore(), &stubHealth{}), "/claimants")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "[]\n", rec.Body.String())
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		rec := get(t, handler, "/status")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var status Status
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		require.Equal(t, store.Snapshot().Status, status)
+	})
+
+	t.Run("Healthy", func(t *testing.T) {
+		health.err = nil
+		rec := get(t, handler, "/healthz")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var result Health
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.Equal(t, Health{Healthy: true}, result)
+	})
+
+	t.Run("Unhealthy", func(t *testing.T) {
+		health.err = errors.New("boom")
+		rec := get(t, handler, "/healthz")
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var result Health
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.Equal(t, Health{Healthy: false, Reason: "boom"}, result)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		for _, path := range []string{"/games", "/games/" + gameAddr1.Hex(), "/claimants", "/status", "/healthz"} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+			require.Equal(t, http.StatusMethodNotAllowed, rec.Code, path)
+		}
+	})
+
+	t.Run("UnknownPath", func(t *testing.T) {
+		rec := get(t, handler, "/unknown")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestChainsHandler(t *testing.T) {
+	storeA := NewStore()
+	storeA.Publish(newTestCycle())
+	storeB := NewStore()
+	healthA := &stubHealth{}
+	healthB := &stubHealth{}
+	handler := NewChainsHandler(testlog.Logger(t, log.LvlInfo), []Chain{
+		{Name: "chain-a", Source: storeA, Health: healthA},
+		{Name: "chain-b", Source: storeB, Health: healthB},
+	})
+
+	t.Run("ServesEachChain", func(t *testing.T) {
+		rec := get(t, handler, "/chains/chain-a/games")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var games []GameSummary
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &games))
+		require.Len(t, games, 2)
+
+		rec = get(t, handler, "/chains/chain-b/games")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "[]\n", rec.Body.String())
+
+		rec = get(t, handler, "/chains/chain-a/games/"+gameAddr1.Hex())
+		require.Equal(t, http.StatusOK, rec.Code)
+		rec = get(t, handler, "/chains/chain-b/games/"+gameAddr1.Hex())
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("UnknownChain", func(t *testing.T) {
+		rec := get(t, handler, "/chains/chain-c/games")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("ChainHealth", func(t *testing.T) {
+		healthB.err = errors.New("boom")
+		t.Cleanup(func() { healthB.err = nil })
+		rec := get(t, handler, "/chains/chain-a/healthz")
+		require.Equal(t, http.StatusOK, rec.Code)
+		rec = get(t, handler, "/chains/chain-b/healthz")
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("Healthy", func(t *testing.T) {
+		rec := get(t, handler, "/healthz")
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("UnhealthyIfAnyChainUnhealthy", func(t *testing.T) {
+		healthA.err = errors.New("stale")
+		healthB.err = errors.New("boom")
+		t.Cleanup(func() {
+			healthA.err = nil
+			healthB.err = nil
+		})
+		rec := get(t, handler, "/healthz")
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var result Health
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.False(t, result.Healthy)
+		require.Contains(t, result.Reason, "chain-a: stale")
+		require.Contains(t, result.Reason, "chain-b: boom")
+	})
+}
+
+type stubHealth struct {
+	err error
+}
+
+func (s *stubHealth) CheckHealth() error {
+	return s.err
+}
+
+func get(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
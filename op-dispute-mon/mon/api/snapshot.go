@@ -0,0 +1,260 @@
+package api
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Cycle is the outcome of a single monitoring cycle. Games and Forecasts are only set if the cycle succeeded.
+type Cycle struct {
+	Start     time.Time
+	Duration  time.Duration
+	Block     eth.BlockID
+	Err       error
+	Games     []*monTypes.EnrichedGameData
+	Forecasts map[common.Address]monTypes.GameForecast
+	Claimants []monTypes.ClaimantExposure
+}
+
+// forecast returns the forecast of the game at addr, or nil if it wasn't forecast.
+func (c Cycle) forecast(addr common.Address) *monTypes.GameForecast {
+	f, ok := c.Forecasts[addr]
+	if !ok {
+		return nil
+	}
+	return &f
+}
+
+// Status reports the outcome of the most recent monitoring cycles.
+type Status struct {
+	LastCycleStart      time.Time   `json:"lastCycleStart"`
+	LastCycleDuration   float64     `json:"lastCycleDurationSeconds"`
+	LastCycleError      string      `json:"lastCycleError,omitempty"`
+	ConsecutiveFailures int         `json:"consecutiveFailures"`
+	LastSuccess         time.Time   `json:"lastSuccess"`
+	Block               eth.BlockID `json:"block"`
+	Games               int         `json:"games"`
+}
+
+// Forecast is the forecast resolution of an in progress game.
+type Forecast struct {
+	Status       string      `json:"status"`
+	AgreeRoot    bool        `json:"agreeRoot"`
+	ExpectedRoot common.Hash `json:"expectedRoot"`
+	Expected     bool        `json:"expected"`
+	// Severity of an unexpected forecast. Omitted if the forecast is expected.
+	Severity string `json:"severity,omitempty"`
+	// Outcome of cross-checking the root claim with the secondary rollup node. Omitted if it wasn't cross-checked.
+	CrossCheck string `json:"crossCheck,omitempty"`
+}
+
+// GameSummary is the summary of a game returned by the game list.
+type GameSummary struct {
+	Address        common.Address `json:"address"`
+	GameType       uint32         `json:"gameType"`
+	Timestamp      uint64         `json:"timestamp"`
+	L2BlockNumber  uint64         `json:"l2BlockNumber"`
+	RootClaim      common.Hash    `json:"rootClaim"`
+	Status         string         `json:"status"`
+	Claims         int            `json:"claims"`
+	ResolvedClaims int            `json:"resolvedClaims"`
+	Forecast       *Forecast      `json:"forecast,omitempty"`
+}
+
+// Claim is a single claim in a game.
+type Claim struct {
+	Index          int            `json:"index"`
+	ParentIndex    *int           `json:"parentIndex,omitempty"`
+	Value          common.Hash    `json:"value"`
+	Depth          uint64         `json:"depth"`
+	IndexAtDepth   string         `json:"indexAtDepth"`
+	Bond           string         `json:"bond"`
+	Resolved       bool           `json:"resolved"`
+	Claimant       common.Address `json:"claimant"`
+	CounteredBy    common.Address `json:"counteredBy"`
+	ClockDuration  uint64         `json:"clockDuration"`
+	ClockTimestamp uint64         `json:"clockTimestamp"`
+}
+
+// GameDetail is the full state of a game.
+type GameDetail struct {
+	GameSummary
+	Duration  uint64  `json:"duration"`
+	ClaimList []Claim `json:"claimList"`
+}
+
+// Claimant is the claims a claimant has posted across all monitored games that are yet to be resolved.
+type Claimant struct {
+	Address        common.Address `json:"address"`
+	Honest         bool           `json:"honest"`
+	Claims         int            `json:"claims"`
+	Bonded         string         `json:"bonded"`
+	ClaimsByAge    []ClaimAge     `json:"claimsByAge"`
+	OldestClaimAge uint64         `json:"oldestClaimAgeSeconds"`
+	PastClock      int            `json:"claimsPastClock"`
+	MaxDelay       uint64         `json:"resolutionDelayMaxSeconds"`
+}
+
+// ClaimAge is the number of a claimant's unresolved claims in a range of claim ages.
+type ClaimAge struct {
+	// MaxAge is the upper bound of the range in seconds, or "+Inf" for claims older than all other ranges.
+	MaxAge string `json:"maxAge"`
+	Claims int    `json:"claims"`
+}
+
+// Snapshot is the state reported by the API. It is never modified once published.
+type Snapshot struct {
+	Status Status
+	Games  []GameDetail
+	index  map[common.Address]int
+
+	// Claimants are ordered by the bonds posted with their unresolved claims, largest first.
+	Claimants []Claimant
+}
+
+// Game returns the detail of the game at addr, if it was loaded in the last successful cycle.
+func (s *Snapshot) Game(addr common.Address) (GameDetail, bool) {
+	idx, ok := s.index[addr]
+	if !ok {
+		return GameDetail{}, false
+	}
+	return s.Games[idx], true
+}
+
+// Store holds the most recently published snapshot.
+// Each cycle is copied into a new snapshot when published so readers never see data a later cycle is modifying.
+type Store struct {
+	snapshot atomic.Pointer[Snapshot]
+}
+
+func NewStore() *Store {
+	s := &Store{}
+	s.snapshot.Store(&Snapshot{})
+	return s
+}
+
+// Snapshot returns the most recently published snapshot.
+func (s *Store) Snapshot() *Snapshot {
+	return s.snapshot.Load()
+}
+
+// Publish replaces the current snapshot with one built from cycle.
+// Games from the last successful cycle are retained if cycle failed. Publish must not be called concurrently.
+func (s *Store) Publish(cycle Cycle) {
+	prev := s.snapshot.Load()
+	next := &Snapshot{
+		Status: Status{
+			LastCycleStart:    cycle.Start,
+			LastCycleDuration: cycle.Duration.Seconds(),
+			LastSuccess:       prev.Status.LastSuccess,
+			Block:             prev.Status.Block,
+			Games:             prev.Status.Games,
+		},
+		Games: prev.Games,
+		index: prev.index,
+
+		Claimants: prev.Claimants,
+	}
+	if cycle.Err != nil {
+		next.Status.LastCycleError = cycle.Err.Error()
+		next.Status.ConsecutiveFailures = prev.Status.ConsecutiveFailures + 1
+	} else {
+		next.Status.LastSuccess = cycle.Start
+		next.Status.Block = cycle.Block
+		next.Status.Games = len(cycle.Games)
+		next.Games = make([]GameDetail, 0, len(cycle.Games))
+		next.index = make(map[common.Address]int, len(cycle.Games))
+		for _, game := range cycle.Games {
+			next.index[game.Proxy] = len(next.Games)
+			next.Games = append(next.Games, newGameDetail(game, cycle.forecast(game.Proxy)))
+		}
+		next.Claimants = make([]Claimant, 0, len(cycle.Claimants))
+		for _, exposure := range cycle.Claimants {
+			next.Claimants = append(next.Claimants, newClaimant(exposure))
+		}
+	}
+	s.snapshot.Store(next)
+}
+
+func newGameDetail(game *monTypes.EnrichedGameData, forecast *monTypes.GameForecast) GameDetail {
+	detail := GameDetail{
+		GameSummary: GameSummary{
+			Address:       game.Proxy,
+			GameType:      game.GameType,
+			Timestamp:     game.Timestamp,
+			L2BlockNumber: game.L2BlockNumber,
+			RootClaim:     game.RootClaim,
+			Status:        game.Status.String(),
+			Claims:        len(game.Claims),
+		},
+		Duration:  game.Duration,
+		ClaimList: make([]Claim, 0, len(game.Claims)),
+	}
+	if forecast != nil {
+		detail.Forecast = &Forecast{
+			Status:       forecast.Status.String(),
+			AgreeRoot:    forecast.AgreeRoot,
+			ExpectedRoot: forecast.ExpectedRoot,
+			Expected:     forecast.Expected(),
+			Severity:     string(forecast.Severity),
+			CrossCheck:   string(forecast.CrossCheck),
+		}
+	}
+	for i := range game.Claims {
+		claim := newClaim(&game.Claims[i])
+		if claim.Resolved {
+			detail.ResolvedClaims++
+		}
+		detail.ClaimList = append(detail.ClaimList, claim)
+	}
+	return detail
+}
+
+func newClaimant(exposure monTypes.ClaimantExposure) Claimant {
+	claimant := Claimant{
+		Address:        exposure.Claimant,
+		Honest:         exposure.Honest,
+		Claims:         exposure.Claims,
+		Bonded:         exposure.Bonded.String(),
+		ClaimsByAge:    make([]ClaimAge, 0, len(exposure.ClaimsByAge)),
+		OldestClaimAge: exposure.OldestClaimAge,
+		PastClock:      exposure.PastClock,
+		MaxDelay:       exposure.MaxDelay,
+	}
+	for i, count := range exposure.ClaimsByAge {
+		claimant.ClaimsByAge = append(claimant.ClaimsByAge, ClaimAge{MaxAge: monTypes.ClaimAgeLabel(i), Claims: count})
+	}
+	return claimant
+}
+
+func newClaim(claim *faultTypes.Claim) Claim {
+	result := Claim{
+		Index:        claim.ContractIndex,
+		Value:        claim.Value,
+		Depth:        uint64(claim.Position.Depth()),
+		IndexAtDepth: claim.Position.IndexAtDepth().String(),
+		Bond:         "0",
+		Claimant:     claim.Claimant,
+		CounteredBy:  claim.CounteredBy,
+	}
+	// The root claim's parent index is a sentinel value rather than a real claim.
+	if claim.ParentContractIndex >= 0 && claim.ParentContractIndex < math.MaxUint32 {
+		parent := claim.ParentContractIndex
+		result.ParentIndex = &parent
+	}
+	if claim.Bond != nil {
+		result.Bond = claim.Bond.String()
+		result.Resolved = claim.Bond.Cmp(monTypes.ResolvedBondAmount) == 0
+	}
+	if claim.Clock != nil {
+		result.ClockDuration = claim.Clock.Duration
+		result.ClockTimestamp = claim.Clock.Timestamp
+	}
+	return result
+}
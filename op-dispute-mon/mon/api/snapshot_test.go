@@ -0,0 +1,229 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	gameAddr1  = common.Address{0xaa}
+	gameAddr2  = common.Address{0xbb}
+	cycleStart = time.Unix(1_000_000, 0).UTC()
+	testBlock  = eth.BlockID{Hash: common.Hash{0x01}, Number: 42}
+)
+
+func TestStore_Publish(t *testing.T) {
+	t.Run("EmptyBeforePublish", func(t *testing.T) {
+		store := NewStore()
+		snapshot := store.Snapshot()
+		require.Equal(t, Status{}, snapshot.Status)
+		require.Empty(t, snapshot.Games)
+		require.Empty(t, snapshot.Claimants)
+		_, ok := snapshot.Game(gameAddr1)
+		require.False(t, ok)
+	})
+
+	t.Run("SuccessfulCycle", func(t *testing.T) {
+		store := NewStore()
+		store.Publish(newTestCycle())
+		snapshot := store.Snapshot()
+		require.Equal(t, Status{
+			LastCycleStart:    cycleStart,
+			LastCycleDuration: 2,
+			LastSuccess:       cycleStart,
+			Block:             testBlock,
+			Games:             2,
+		}, snapshot.Status)
+		require.Len(t, snapshot.Games, 2)
+
+		game, ok := snapshot.Game(gameAddr1)
+		require.True(t, ok)
+		require.Equal(t, gameAddr1, game.Address)
+		require.Equal(t, "In Progress", game.Status)
+		require.Equal(t, 3, game.Claims)
+		require.Equal(t, 1, game.ResolvedClaims)
+		require.Equal(t, &Forecast{
+			Status:       "Challenger Won",
+			AgreeRoot:    false,
+			ExpectedRoot: common.Hash{0xee},
+			Expected:     true,
+		}, game.Forecast)
+		require.Len(t, game.ClaimList, 3)
+		require.Nil(t, game.ClaimList[0].ParentIndex)
+		require.Equal(t, monTypes.ResolvedBondAmount.String(), game.ClaimList[0].Bond)
+		require.True(t, game.ClaimList[0].Resolved)
+		require.Equal(t, 0, *game.ClaimList[1].ParentIndex)
+		require.Equal(t, "1", game.ClaimList[1].IndexAtDepth)
+		require.Equal(t, uint64(1), game.ClaimList[1].Depth)
+		require.Equal(t, uint64(30), game.ClaimList[1].ClockDuration)
+		require.Equal(t, "0", game.ClaimList[2].Bond)
+
+		game, ok = snapshot.Game(gameAddr2)
+		require.True(t, ok)
+		require.Equal(t, "Defender Won", game.Status)
+		require.Nil(t, game.Forecast)
+
+		require.Equal(t, []Claimant{{
+			Address:        common.Address{0x11},
+			Honest:         true,
+			Claims:         1,
+			Bonded:         "1",
+			ClaimsByAge:    []ClaimAge{{"3600", 1}, {"86400", 0}, {"302400", 0}, {"604800", 0}, {"+Inf", 0}},
+			OldestClaimAge: 120,
+			PastClock:      1,
+			MaxDelay:       60,
+		}}, snapshot.Claimants)
+	})
+
+	t.Run("UnexpectedForecastSeverityAndCrossCheck", func(t *testing.T) {
+		store := NewStore()
+		cycle := newTestCycle()
+		cycle.Forecasts[gameAddr1] = monTypes.GameForecast{
+			Status:       gameTypes.GameStatusDefenderWon,
+			AgreeRoot:    false,
+			ExpectedRoot: common.Hash{0xee},
+			Severity:     alerts.SeverityWarning,
+			CrossCheck:   monTypes.CrossCheckAgreeInvalid,
+		}
+		store.Publish(cycle)
+		game, ok := store.Snapshot().Game(gameAddr1)
+		require.True(t, ok)
+		require.Equal(t, &Forecast{
+			Status:       "Defender Won",
+			AgreeRoot:    false,
+			ExpectedRoot: common.Hash{0xee},
+			Expected:     false,
+			Severity:     "warning",
+			CrossCheck:   "agree_invalid",
+		}, game.Forecast)
+	})
+
+	t.Run("FailedCycleRetainsGames", func(t *testing.T) {
+		store := NewStore()
+		store.Publish(newTestCycle())
+		failedStart := cycleStart.Add(time.Minute)
+		for i := 1; i <= 2; i++ {
+			store.Publish(Cycle{Start: failedStart, Duration: time.Second, Err: errors.New("boom")})
+			snapshot := store.Snapshot()
+			require.Equal(t, Status{
+				LastCycleStart:      failedStart,
+				LastCycleDuration:   1,
+				LastCycleError:      "boom",
+				ConsecutiveFailures: i,
+				LastSuccess:         cycleStart,
+				Block:               testBlock,
+				Games:               2,
+			}, snapshot.Status)
+			_, ok := snapshot.Game(gameAddr1)
+			require.True(t, ok)
+			require.Len(t, snapshot.Claimants, 1)
+		}
+
+		store.Publish(Cycle{Start: failedStart, Block: testBlock})
+		snapshot := store.Snapshot()
+		require.Zero(t, snapshot.Status.ConsecutiveFailures)
+		require.Empty(t, snapshot.Status.LastCycleError)
+		require.Empty(t, snapshot.Games)
+		_, ok := snapshot.Game(gameAddr1)
+		require.False(t, ok)
+	})
+
+	t.Run("SnapshotIsCopy", func(t *testing.T) {
+		store := NewStore()
+		cycle := newTestCycle()
+		store.Publish(cycle)
+		before, _ := store.Snapshot().Game(gameAddr1)
+
+		// The next cycle may reuse and modify the same game data
+		game := cycle.Games[0]
+		game.Status = gameTypes.GameStatusChallengerWon
+		game.Claims[1].Bond.Set(monTypes.ResolvedBondAmount)
+		game.Claims[1].Clock.Duration = 500
+		game.Claims = append(game.Claims, faultTypes.Claim{})
+		cycle.Forecasts[gameAddr1] = monTypes.GameForecast{Status: gameTypes.GameStatusDefenderWon}
+
+		after, _ := store.Snapshot().Game(gameAddr1)
+		require.Equal(t, before, after)
+		require.Equal(t, "In Progress", after.Status)
+		require.Equal(t, "1", after.ClaimList[1].Bond)
+		require.Equal(t, uint64(30), after.ClaimList[1].ClockDuration)
+		require.Len(t, after.ClaimList, 3)
+		require.Equal(t, "Challenger Won", after.Forecast.Status)
+	})
+
+	t.Run("PreviousSnapshotUnchanged", func(t *testing.T) {
+		store := NewStore()
+		store.Publish(newTestCycle())
+		prev := store.Snapshot()
+		prevStatus := prev.Status
+		store.Publish(Cycle{Start: cycleStart.Add(time.Minute), Err: errors.New("boom")})
+		require.Equal(t, prevStatus, prev.Status)
+		require.NotSame(t, prev, store.Snapshot())
+	})
+}
+
+func newTestCycle() Cycle {
+	return Cycle{
+		Start:    cycleStart,
+		Duration: 2 * time.Second,
+		Block:    testBlock,
+		Games: []*monTypes.EnrichedGameData{
+			{
+				GameMetadata:  gameTypes.GameMetadata{Proxy: gameAddr1, GameType: 1, Timestamp: 999},
+				L2BlockNumber: 1234,
+				RootClaim:     common.Hash{0xcc},
+				Status:        gameTypes.GameStatusInProgress,
+				Duration:      3600,
+				Claims: []faultTypes.Claim{
+					{
+						ClaimData:           faultTypes.ClaimData{Value: common.Hash{0xcc}, Bond: new(big.Int).Set(monTypes.ResolvedBondAmount), Position: faultTypes.NewPositionFromGIndex(big.NewInt(1))},
+						Clock:               faultTypes.NewClock(0, 999),
+						ContractIndex:       0,
+						ParentContractIndex: math.MaxUint32,
+					},
+					{
+						ClaimData:           faultTypes.ClaimData{Value: common.Hash{0xdd}, Bond: big.NewInt(1), Position: faultTypes.NewPosition(1, big.NewInt(1))},
+						Clock:               faultTypes.NewClock(30, 1000),
+						Claimant:            common.Address{0x11},
+						ContractIndex:       1,
+						ParentContractIndex: 0,
+					},
+					{
+						ContractIndex:       2,
+						ParentContractIndex: 1,
+					},
+				},
+			},
+			{
+				GameMetadata: gameTypes.GameMetadata{Proxy: gameAddr2},
+				Status:       gameTypes.GameStatusDefenderWon,
+			},
+		},
+		Forecasts: map[common.Address]monTypes.GameForecast{
+			gameAddr1: {Status: gameTypes.GameStatusChallengerWon, AgreeRoot: false, ExpectedRoot: common.Hash{0xee}},
+		},
+		Claimants: []monTypes.ClaimantExposure{
+			{
+				Claimant:       common.Address{0x11},
+				Honest:         true,
+				Claims:         1,
+				Bonded:         big.NewInt(1),
+				ClaimsByAge:    []int{1, 0, 0, 0, 0},
+				OldestClaimAge: 120,
+				PastClock:      1,
+				MaxDelay:       60,
+			},
+		},
+	}
+}
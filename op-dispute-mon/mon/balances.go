@@ -0,0 +1,27 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type BalanceSource interface {
+	BalanceAtHash(ctx context.Context, account common.Address, blockHash common.Hash) (*big.Int, error)
+}
+
+// newBalanceFetcher creates a BalanceFetcher that reads balances as of the block being monitored so they are
+// consistent with the game data loaded from the same block.
+func newBalanceFetcher(source BalanceSource) BalanceFetcher {
+	return func(ctx context.Context, block eth.BlockID, account common.Address) (*big.Int, error) {
+		balance, err := source.BalanceAtHash(ctx, account, block.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch balance of %v at block %v: %w", account, block, err)
+		}
+		return balance, nil
+	}
+}
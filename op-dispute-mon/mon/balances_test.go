@@ -0,0 +1,49 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestBalanceFetcher(t *testing.T) {
+	account := common.Address{0xaa}
+	block := eth.BlockID{Hash: common.Hash{0xbb}, Number: 42}
+
+	t.Run("FetchesAtBlock", func(t *testing.T) {
+		source := &stubBalanceSource{balance: big.NewInt(1234)}
+		balance, err := newBalanceFetcher(source)(context.Background(), block, account)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1234), balance)
+		require.Equal(t, account, source.account)
+		require.Equal(t, block.Hash, source.blockHash)
+	})
+
+	t.Run("FetchError", func(t *testing.T) {
+		source := &stubBalanceSource{err: errors.New("boom")}
+		_, err := newBalanceFetcher(source)(context.Background(), block, account)
+		require.ErrorIs(t, err, source.err)
+	})
+}
+
+type stubBalanceSource struct {
+	account   common.Address
+	blockHash common.Hash
+	balance   *big.Int
+	err       error
+}
+
+func (s *stubBalanceSource) BalanceAtHash(_ context.Context, account common.Address, blockHash common.Hash) (*big.Int, error) {
+	s.account = account
+	s.blockHash = blockHash
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.balance, nil
+}
@@ -0,0 +1,40 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// newBlockFetcher creates a BlockFetcher that resolves the L1 block identified by tag.
+// The number and hash are taken from a single header so they always refer to the same block,
+// even if the chain reorgs between monitoring cycles.
+func newBlockFetcher(source HeaderSource, tag eth.BlockLabel) (BlockFetcher, error) {
+	var number rpc.BlockNumber
+	switch tag {
+	case eth.Unsafe:
+		number = rpc.LatestBlockNumber
+	case eth.Safe:
+		number = rpc.SafeBlockNumber
+	case eth.Finalized:
+		number = rpc.FinalizedBlockNumber
+	default:
+		return nil, fmt.Errorf("unsupported block tag: %v", tag)
+	}
+	return func(ctx context.Context) (eth.BlockID, error) {
+		header, err := source.HeaderByNumber(ctx, big.NewInt(number.Int64()))
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to fetch %v block: %w", tag, err)
+		}
+		return eth.HeaderBlockID(header), nil
+	}, nil
+}
@@ -0,0 +1,82 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestBlockFetcher(t *testing.T) {
+	tests := []struct {
+		tag      eth.BlockLabel
+		expected rpc.BlockNumber
+	}{
+		{eth.Unsafe, rpc.LatestBlockNumber},
+		{eth.Safe, rpc.SafeBlockNumber},
+		{eth.Finalized, rpc.FinalizedBlockNumber},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(string(test.tag), func(t *testing.T) {
+			header := &types.Header{Number: big.NewInt(1234), Time: 5678}
+			source := &stubHeaderSource{header: header}
+			fetch, err := newBlockFetcher(source, test.tag)
+			require.NoError(t, err)
+
+			block, err := fetch(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, eth.BlockID{Hash: header.Hash(), Number: 1234}, block)
+			require.Equal(t, big.NewInt(test.expected.Int64()), source.requested)
+			require.Equal(t, 1, source.calls, "should resolve the block with a single request")
+		})
+	}
+
+	t.Run("UnsupportedTag", func(t *testing.T) {
+		_, err := newBlockFetcher(&stubHeaderSource{}, "pending")
+		require.ErrorContains(t, err, "unsupported block tag")
+	})
+
+	t.Run("FetchError", func(t *testing.T) {
+		source := &stubHeaderSource{err: errors.New("boom")}
+		fetch, err := newBlockFetcher(source, eth.Finalized)
+		require.NoError(t, err)
+		_, err = fetch(context.Background())
+		require.ErrorIs(t, err, source.err)
+	})
+
+	t.Run("UsesContext", func(t *testing.T) {
+		source := &stubHeaderSource{header: &types.Header{Number: big.NewInt(1)}}
+		fetch, err := newBlockFetcher(source, eth.Finalized)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = fetch(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+type stubHeaderSource struct {
+	calls     int
+	requested *big.Int
+	header    *types.Header
+	err       error
+}
+
+func (s *stubHeaderSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	s.calls++
+	s.requested = number
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.header, nil
+}
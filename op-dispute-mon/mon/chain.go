@@ -0,0 +1,429 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/api"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/resolution"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/resolver"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+)
+
+// chainMonitor monitors the games of a single dispute game factory. Each chain has its own monitor so
+// monitoring cycles for different chains run independently of each other.
+type chainMonitor struct {
+	name    string
+	logger  log.Logger
+	metrics metrics.Metricer
+	monitor *gameMonitor
+
+	factoryContract *contracts.DisputeGameFactoryContract
+
+	cl clock.Clock
+
+	delays       *resolution.DelayCalculator
+	claimants    *resolution.ClaimantTracker
+	extractor    *extract.Extractor
+	filter       *gameFilter
+	lppExtractor *extract.PreimageExtractor
+	forecast     *forecast
+	game         *extract.GameCallerCreator
+	rollupClient *sources.RollupClient
+	// secondaryRollupClient is the client of the secondary rollup node, if any.
+	secondaryRollupClient *sources.RollupClient
+	detector              *detector
+	credits               *creditDetector
+	actors                *actorMonitor
+	l1Heads               *l1HeadDetector
+	preimages             *preimageDetector
+	validator             *outputValidator
+	secondary             *outputValidator
+	resolver              *resolver.Resolver
+
+	l1Client *ethclient.Client
+
+	webhook *alerts.WebhookSink
+	alerts  alerts.Sink
+
+	health    *healthMonitor
+	snapshots *api.Store
+	dumper    *api.Dumper
+	events    *eventWatcher
+}
+
+// newChainMonitor creates the monitor for chain. Resolution transactions are sent via txSender, or are only
+// logged if txSender is nil.
+func newChainMonitor(
+	ctx context.Context,
+	logger log.Logger,
+	m metrics.Metricer,
+	cl clock.Clock,
+	cfg *config.Config,
+	chain config.ChainConfig,
+	txSender resolver.TxSender,
+) (*chainMonitor, error) {
+	if chain.Name != "" {
+		logger = logger.New("chain", chain.Name)
+	}
+	c := &chainMonitor{
+		name:    chain.Name,
+		logger:  logger,
+		metrics: m,
+		cl:      cl,
+	}
+	if err := c.initFromConfig(ctx, cfg, chain, txSender); err != nil {
+		c.stop()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *chainMonitor) initFromConfig(ctx context.Context, cfg *config.Config, chain config.ChainConfig, txSender resolver.TxSender) error {
+	if err := c.initL1Client(ctx, chain); err != nil {
+		return fmt.Errorf("failed to init l1 client: %w", err)
+	}
+	if err := c.initFactoryContract(chain); err != nil {
+		return fmt.Errorf("failed to create factory contract bindings: %w", err)
+	}
+	if err := c.initOutputRollupClient(ctx, chain); err != nil {
+		return fmt.Errorf("failed to init rollup client: %w", err)
+	}
+	if err := c.initSecondaryOutputValidator(ctx, chain); err != nil {
+		return fmt.Errorf("failed to init secondary output validator: %w", err)
+	}
+
+	c.initOutputValidator() // Must be called before initForecast
+	// Must be called before initForecast
+	if err := c.initGameCallerCreator(cfg); err != nil {
+		return fmt.Errorf("failed to init game caller creator: %w", err)
+	}
+
+	c.initAlerts(ctx, cfg)
+	c.initDelayCalculator()
+	c.initClaimantTracker(cfg)
+	c.initExtractor(cfg)
+	c.initGameFilter(cfg)
+	c.initPreimageExtractor()
+
+	c.initForecast(cfg)
+	c.initDetector()
+	c.initCreditDetector(cfg)
+	c.initActorMonitor(cfg)
+	c.initL1HeadDetector()
+	c.initPreimageDetector(cfg)
+	c.initResolver(ctx, cfg, txSender)
+
+	c.initHealthMonitor(cfg)
+	c.initSnapshots(cfg)
+	c.initGameDump(cfg)
+	if err := c.initEventWatcher(ctx, cfg, chain); err != nil {
+		return fmt.Errorf("failed to init event watcher: %w", err)
+	}
+
+	if err := c.initMonitor(ctx, cfg); err != nil { // Monitor must be initialized last
+		return fmt.Errorf("failed to init monitor: %w", err)
+	}
+	return nil
+}
+
+func (c *chainMonitor) initOutputValidator() {
+	c.validator = newOutputValidator(c.rollupClient)
+}
+
+// initSecondaryOutputValidator creates the validator root claims are cross-checked with if chain has a secondary
+// rollup node.
+func (c *chainMonitor) initSecondaryOutputValidator(ctx context.Context, chain config.ChainConfig) error {
+	if chain.SecondaryRollupRpc == "" {
+		return nil
+	}
+	client, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, c.logger, chain.SecondaryRollupRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial secondary rollup client: %w", err)
+	}
+	c.secondaryRollupClient = client
+	c.secondary = newOutputValidator(client)
+	return nil
+}
+
+func (c *chainMonitor) initGameCallerCreator(cfg *config.Config) error {
+	game, err := extract.NewGameCallerCreator(c.metrics, batching.NewMultiCaller(c.l1Client.Client(), batching.DefaultBatchSize), cfg.GameTypeDelayedWETH)
+	if err != nil {
+		return err
+	}
+	c.game = game
+	return nil
+}
+
+func (c *chainMonitor) initAlerts(ctx context.Context, cfg *config.Config) {
+	if cfg.AlertWebhookURL == "" {
+		c.alerts = alerts.NoopSink{}
+		return
+	}
+	c.webhook = alerts.NewWebhookSink(ctx, c.logger, c.metrics, c.name, alerts.WebhookConfig{
+		URL:        cfg.AlertWebhookURL,
+		AuthHeader: cfg.AlertWebhookAuth,
+		Timeout:    cfg.AlertWebhookTimeout,
+	})
+	c.alerts = alerts.NewDeduplicator(c.cl, c.metrics, cfg.AlertCooldown, c.webhook)
+}
+
+func (c *chainMonitor) initDelayCalculator() {
+	c.delays = resolution.NewDelayCalculator(c.metrics, c.cl)
+}
+
+func (c *chainMonitor) initClaimantTracker(cfg *config.Config) {
+	c.claimants = resolution.NewClaimantTracker(c.metrics, c.cl, cfg.HonestActors, cfg.ClaimantMetricsLimit)
+}
+
+func (c *chainMonitor) initExtractor(cfg *config.Config) {
+	c.extractor = extract.NewExtractor(c.logger, c.cl, c.metrics, c.game.CreateContract, c.factoryContract.GetGamesAtOrAfter, c.canonicalBlockHash,
+		cfg.HonestActors, cfg.MaxConcurrency, cfg.ResolvedGameRefresh)
+}
+
+// canonicalBlockHash returns the hash of the canonical L1 block at number.
+func (c *chainMonitor) canonicalBlockHash(ctx context.Context, number uint64) (common.Hash, error) {
+	header, err := c.l1Client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Hash(), nil
+}
+
+func (c *chainMonitor) initGameFilter(cfg *config.Config) {
+	c.filter = newGameFilter(c.logger, c.metrics, cfg.GameIgnoreList, cfg.GameAllowList, cfg.GameMinBond, cfg.GameFilterFile)
+}
+
+func (c *chainMonitor) initPreimageExtractor() {
+	gameData := contracts.NewGameDataCache(c.metrics, c.factoryContract, batching.NewMultiCaller(c.l1Client.Client(), batching.DefaultBatchSize))
+	c.lppExtractor = extract.NewPreimageExtractor(c.logger, func(ctx context.Context, gameType uint32) (extract.PreimageOracle, error) {
+		return gameData.GetOracle(ctx, gameType)
+	})
+}
+
+func (c *chainMonitor) initForecast(cfg *config.Config) {
+	thresholds := forecastThresholds{
+		warningClock:  cfg.ForecastWarningClock,
+		criticalClock: cfg.ForecastCriticalClock,
+		warningBond:   cfg.ForecastWarningBond,
+		criticalBond:  cfg.ForecastCriticalBond,
+	}
+	// Avoid passing a nil *outputValidator as a non-nil OutputValidator.
+	var secondary OutputValidator
+	if c.secondary != nil {
+		secondary = c.secondary
+	}
+	c.forecast = newForecast(c.logger, c.metrics, c.validator, secondary, c.alerts, c.cl, cfg.HonestActors, thresholds)
+}
+
+func (c *chainMonitor) initDetector() {
+	c.detector = newDetector(c.logger, c.metrics, c.validator, c.alerts)
+}
+
+func (c *chainMonitor) initCreditDetector(cfg *config.Config) {
+	c.credits = newCreditDetector(c.logger, c.metrics, c.cl, cfg.HonestActors, cfg.CreditWarnThreshold, cfg.CreditWarnAfter)
+}
+
+func (c *chainMonitor) initActorMonitor(cfg *config.Config) {
+	c.actors = newActorMonitor(c.logger, c.metrics, newBalanceFetcher(c.l1Client), cfg.HonestActors, cfg.HonestActorMinBalance)
+}
+
+func (c *chainMonitor) initL1HeadDetector() {
+	c.l1Heads = newL1HeadDetector(c.logger, c.metrics, c.l1Client, c.canonicalBlockHash, c.alerts)
+}
+
+func (c *chainMonitor) initPreimageDetector(cfg *config.Config) {
+	c.preimages = newPreimageDetector(c.logger, c.metrics, c.cl, cfg.PreimageExpiringWindow)
+}
+
+func (c *chainMonitor) initResolver(ctx context.Context, cfg *config.Config, txSender resolver.TxSender) {
+	if !cfg.ResolverEnabled {
+		return
+	}
+	creator := resolver.NewGameContractCreator(batching.NewMultiCaller(c.l1Client.Client(), batching.DefaultBatchSize))
+	c.resolver = resolver.NewResolver(ctx, c.logger, c.metrics, c.cl, creator.CreateContract, txSender, cfg.ResolverMaxTransactions, cfg.ResolverBackoff)
+}
+
+func (c *chainMonitor) initOutputRollupClient(ctx context.Context, chain config.ChainConfig) error {
+	outputRollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, c.logger, chain.RollupRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial rollup client: %w", err)
+	}
+	c.rollupClient = outputRollupClient
+	return nil
+}
+
+func (c *chainMonitor) initL1Client(ctx context.Context, chain config.ChainConfig) error {
+	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, c.logger, chain.L1EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	c.l1Client = l1Client
+	return nil
+}
+
+func (c *chainMonitor) initHealthMonitor(cfg *config.Config) {
+	c.health = newHealthMonitor(c.metrics, c.cl, cfg.HealthMaxFailures, cfg.MonitorInterval*time.Duration(cfg.HealthStaleIntervals))
+}
+
+func (c *chainMonitor) initSnapshots(cfg *config.Config) {
+	if cfg.APIEnabled {
+		c.snapshots = api.NewStore()
+	}
+}
+
+func (c *chainMonitor) initGameDump(cfg *config.Config) {
+	if cfg.GameDumpPath != "" {
+		c.dumper = api.NewDumper(chainDumpPath(cfg.GameDumpPath, c.name), cfg.GameDumpRetention)
+	}
+}
+
+// chainDumpPath returns the path the games of the chain called name are dumped to, so each of multiple
+// monitored chains writes to its own file. The name is added before the extension, if any.
+func chainDumpPath(path string, name string) string {
+	if name == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + name + ext
+}
+
+func (c *chainMonitor) initEventWatcher(ctx context.Context, cfg *config.Config, chain config.ChainConfig) error {
+	if cfg.EventPollInterval == 0 {
+		return nil
+	}
+	blockFetcher, err := newBlockFetcher(c.l1Client, cfg.BlockTag)
+	if err != nil {
+		return err
+	}
+	// The monitor is created after the watcher but the watcher is only started once the monitor exists
+	trigger := func() { c.monitor.Trigger() }
+	events, err := newEventWatcher(ctx, c.logger, c.cl, c.l1Client, blockFetcher, trigger, cfg.EventPollInterval, chain.GameFactoryAddress, cfg.EventGameEvents)
+	if err != nil {
+		return err
+	}
+	c.events = events
+	return nil
+}
+
+func (c *chainMonitor) initFactoryContract(chain config.ChainConfig) error {
+	factoryContract, err := contracts.NewDisputeGameFactoryContract(chain.GameFactoryAddress,
+		batching.NewMultiCaller(c.l1Client.Client(), batching.DefaultBatchSize))
+	if err != nil {
+		return fmt.Errorf("failed to bind the fault dispute game factory contract: %w", err)
+	}
+	c.factoryContract = factoryContract
+	return nil
+}
+
+func (c *chainMonitor) initMonitor(ctx context.Context, cfg *config.Config) error {
+	blockFetcher, err := newBlockFetcher(c.l1Client, cfg.BlockTag)
+	if err != nil {
+		return err
+	}
+	resolve := func(ctx context.Context, games []*monTypes.EnrichedGameData) {}
+	if c.resolver != nil {
+		resolve = c.resolver.Resolve
+	}
+	publish := func(cycle api.Cycle) {
+		c.health.RecordCycle(cycle)
+		if c.snapshots != nil {
+			c.snapshots.Publish(cycle)
+		}
+		if c.dumper != nil {
+			if err := c.dumper.Dump(cycle); err != nil {
+				c.logger.Error("Failed to dump games", "err", err)
+			}
+		}
+		if c.events != nil && cycle.Err == nil {
+			c.events.SetGames(cycle.Games)
+		}
+	}
+	c.monitor = newGameMonitor(ctx, c.logger, c.cl, gameMonitorDeps{
+		creationWindow:   cfg.CreationWindow,
+		resolutionWindow: cfg.ResolutionWindow,
+		monitorInterval:  cfg.MonitorInterval,
+		cycleTimeout:     cfg.CycleTimeout,
+		startupJitter:    cfg.StartupJitter,
+		intervalJitter:   cfg.IntervalJitter,
+		triggerDebounce:  cfg.EventDebounce,
+		delays:           c.delays.RecordClaimResolutionDelays,
+		claimants:        c.claimants.RecordClaimants,
+		detect:           c.detector.Detect,
+		credits:          c.credits.Detect,
+		actors:           c.actors.Detect,
+		l1Heads:          c.l1Heads.Detect,
+		forecast:         c.forecast.Forecast,
+		resolve:          resolve,
+		extract:          c.extractor.Extract,
+		filter:           c.filter.Filter,
+		fetchBlock:       blockFetcher,
+		recordBlock:      c.metrics.RecordMonitoredBlock,
+		extractPreimages: c.lppExtractor.Extract,
+		detectPreimages:  c.preimages.Detect,
+		recordTimeout:    c.metrics.RecordCycleTimeout,
+		recordSkipped:    c.metrics.RecordSkippedCycle,
+		recordPhase:      c.metrics.RecordCyclePhaseDuration,
+		recordGames:      c.metrics.RecordCycleGames,
+		publish:          publish,
+	})
+	return nil
+}
+
+// apiChain returns the snapshots and health of the chain served by the monitoring API.
+func (c *chainMonitor) apiChain() api.Chain {
+	return api.Chain{Name: c.name, Source: c.snapshots, Health: c.health}
+}
+
+func (c *chainMonitor) start() {
+	if c.webhook != nil {
+		c.webhook.Start()
+	}
+	c.monitor.StartMonitoring()
+	if c.events != nil {
+		c.events.Start()
+	}
+}
+
+func (c *chainMonitor) stop() {
+	if c.events != nil {
+		c.events.Stop()
+	}
+	if c.monitor != nil {
+		c.monitor.StopMonitoring()
+	}
+	if c.resolver != nil {
+		c.resolver.Close()
+	}
+	if c.webhook != nil {
+		c.webhook.Stop()
+	}
+	// The clients are closed last as the components stopped above use them.
+	if c.secondaryRollupClient != nil {
+		c.secondaryRollupClient.Close()
+	}
+	if c.rollupClient != nil {
+		c.rollupClient.Close()
+	}
+	if c.l1Client != nil {
+		c.l1Client.Close()
+	}
+}
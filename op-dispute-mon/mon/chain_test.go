@@ -0,0 +1,102 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/api"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestChainMonitors_Independent(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	txMetrics := metrics.NewTxMetrics(registry)
+
+	// The slow chain's RPC hangs until released, then fails
+	release := make(chan struct{})
+	slow := setupTestChain(t, metrics.NewChainMetrics(registry, "slow", txMetrics), 100,
+		func(ctx context.Context, _ eth.BlockID, _ uint64) ([]*monTypes.EnrichedGameData, error) {
+			<-release
+			return nil, errors.New("rpc timeout")
+		})
+	fast := setupTestChain(t, metrics.NewChainMetrics(registry, "fast", txMetrics), 200,
+		func(ctx context.Context, _ eth.BlockID, _ uint64) ([]*monTypes.EnrichedGameData, error) {
+			return []*monTypes.EnrichedGameData{{}}, nil
+		})
+	slow.monitor.StartMonitoring()
+	defer slow.monitor.StopMonitoring()
+	fast.monitor.StartMonitoring()
+	defer fast.monitor.StopMonitoring()
+
+	require.Eventually(t, func() bool {
+		return fast.cycles.Load() >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Zero(t, slow.cycles.Load(), "slow chain should still be stuck in its first cycle")
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return slow.cycles.Load() >= 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.ErrorIs(t, slow.health.CheckHealth(), errTooManyFailures)
+	require.NoError(t, fast.health.CheckHealth())
+
+	// Stop the slow chain so it doesn't record further failures. Every one of its cycles failed.
+	slow.monitor.StopMonitoring()
+	expected := fmt.Sprintf(`
+# HELP op_dispute_mon_cycle_failures Number of monitoring cycles that failed, labelled by the phase that failed
+# TYPE op_dispute_mon_cycle_failures counter
+op_dispute_mon_cycle_failures{chain="slow",phase="extract"} %d
+# HELP op_dispute_mon_monitored_block Number of the L1 block games were last monitored at
+# TYPE op_dispute_mon_monitored_block gauge
+op_dispute_mon_monitored_block{chain="fast"} 200
+op_dispute_mon_monitored_block{chain="slow"} 0
+`, slow.cycles.Load())
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"op_dispute_mon_cycle_failures",
+		"op_dispute_mon_monitored_block",
+	))
+}
+
+type testChain struct {
+	monitor *gameMonitor
+	health  *healthMonitor
+	cycles  atomic.Int32
+}
+
+// setupTestChain creates a monitor for a fake chain whose games are loaded by extract at block number.
+func setupTestChain(t *testing.T, m *metrics.Metrics, number uint64, extract Extract) *testChain {
+	monitor, _, _, _, _ := setupMonitorTest(t)
+	chain := &testChain{
+		monitor: monitor,
+		health:  newHealthMonitor(m, monitor.clock, 0, time.Hour),
+	}
+	monitor.cycleTimeout = time.Hour
+	monitor.extract = extract
+	monitor.fetchBlock = func(ctx context.Context) (eth.BlockID, error) {
+		return eth.BlockID{Number: number}, nil
+	}
+	monitor.recordBlock = m.RecordMonitoredBlock
+	monitor.recordTimeout = m.RecordCycleTimeout
+	monitor.publish = func(cycle api.Cycle) {
+		chain.health.RecordCycle(cycle)
+		chain.cycles.Add(1)
+	}
+	return chain
+}
+
+func TestChainDumpPath(t *testing.T) {
+	require.Equal(t, "/data/games.jsonl", chainDumpPath("/data/games.jsonl", ""))
+	require.Equal(t, "/data/games-mainnet.jsonl", chainDumpPath("/data/games.jsonl", "mainnet"))
+	require.Equal(t, "/data/games-mainnet", chainDumpPath("/data/games", "mainnet"))
+}
@@ -0,0 +1,138 @@
+package mon
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type CreditMetrics interface {
+	RecordUnclaimedCredit(recipients string, state string, amount *big.Int)
+}
+
+// creditBatch sums unclaimed credit by recipient group and whether it can be claimed yet.
+type creditBatch struct {
+	honestClaimable *big.Int
+	honestPending   *big.Int
+	otherClaimable  *big.Int
+	otherPending    *big.Int
+}
+
+type creditDetector struct {
+	logger       log.Logger
+	metrics      CreditMetrics
+	clock        clock.Clock
+	honestActors map[common.Address]bool
+
+	warnThreshold *big.Int
+	warnAfter     time.Duration
+
+	// exceededSince is when each recipient's claimable credit started exceeding warnThreshold.
+	// Only accessed from Detect, which the monitor never calls concurrently.
+	exceededSince map[common.Address]time.Time
+}
+
+func newCreditDetector(
+	logger log.Logger,
+	metrics CreditMetrics,
+	cl clock.Clock,
+	honestActors []common.Address,
+	warnThreshold *big.Int,
+	warnAfter time.Duration,
+) *creditDetector {
+	honest := make(map[common.Address]bool, len(honestActors))
+	for _, actor := range honestActors {
+		honest[actor] = true
+	}
+	return &creditDetector{
+		logger:        logger,
+		metrics:       metrics,
+		clock:         cl,
+		honestActors:  honest,
+		warnThreshold: warnThreshold,
+		warnAfter:     warnAfter,
+		exceededSince: make(map[common.Address]time.Time),
+	}
+}
+
+// Detect records the unclaimed credit held by resolved games and warns about recipients whose claimable credit
+// has exceeded the warning threshold for longer than allowed.
+func (c *creditDetector) Detect(_ context.Context, games []*monTypes.EnrichedGameData) {
+	now := c.clock.Now()
+	batch := creditBatch{
+		honestClaimable: big.NewInt(0),
+		honestPending:   big.NewInt(0),
+		otherClaimable:  big.NewInt(0),
+		otherPending:    big.NewInt(0),
+	}
+	claimable := make(map[common.Address]*big.Int)
+	for _, game := range games {
+		if game.Status == types.GameStatusInProgress {
+			continue
+		}
+		for _, credit := range game.Credits {
+			honest := c.honestActors[credit.Recipient]
+			if !credit.Claimable(now) {
+				if honest {
+					batch.honestPending.Add(batch.honestPending, credit.Amount)
+				} else {
+					batch.otherPending.Add(batch.otherPending, credit.Amount)
+				}
+				continue
+			}
+			if honest {
+				batch.honestClaimable.Add(batch.honestClaimable, credit.Amount)
+			} else {
+				batch.otherClaimable.Add(batch.otherClaimable, credit.Amount)
+			}
+			total, ok := claimable[credit.Recipient]
+			if !ok {
+				total = big.NewInt(0)
+				claimable[credit.Recipient] = total
+			}
+			total.Add(total, credit.Amount)
+		}
+	}
+	c.recordBatch(batch)
+	c.checkThreshold(now, claimable)
+}
+
+func (c *creditDetector) recordBatch(batch creditBatch) {
+	c.metrics.RecordUnclaimedCredit(metrics.CreditRecipientHonest, metrics.CreditStateClaimable, batch.honestClaimable)
+	c.metrics.RecordUnclaimedCredit(metrics.CreditRecipientHonest, metrics.CreditStatePending, batch.honestPending)
+	c.metrics.RecordUnclaimedCredit(metrics.CreditRecipientOther, metrics.CreditStateClaimable, batch.otherClaimable)
+	c.metrics.RecordUnclaimedCredit(metrics.CreditRecipientOther, metrics.CreditStatePending, batch.otherPending)
+}
+
+// checkThreshold warns about recipients whose claimable credit has exceeded the warning threshold for at
+// least warnAfter, tracking when each recipient first exceeded it.
+func (c *creditDetector) checkThreshold(now time.Time, claimable map[common.Address]*big.Int) {
+	for recipient := range c.exceededSince {
+		if amount, ok := claimable[recipient]; !ok || amount.Cmp(c.warnThreshold) <= 0 {
+			delete(c.exceededSince, recipient)
+		}
+	}
+	for recipient, amount := range claimable {
+		if amount.Cmp(c.warnThreshold) <= 0 {
+			continue
+		}
+		since, ok := c.exceededSince[recipient]
+		if !ok {
+			since = now
+			c.exceededSince[recipient] = since
+		}
+		if elapsed := now.Sub(since); elapsed >= c.warnAfter {
+			c.logger.Warn("Claimable credit has not been claimed",
+				"recipient", recipient, "honest", c.honestActors[recipient],
+				"amount", amount, "threshold", c.warnThreshold, "duration", elapsed)
+		}
+	}
+}
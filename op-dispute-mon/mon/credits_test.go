@@ -0,0 +1,183 @@
+package mon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	honestActor     = common.Address{0x01}
+	otherActor      = common.Address{0x02}
+	creditWarnAfter = time.Hour
+	unclaimedLog    = "Claimable credit has not been claimed"
+)
+
+func TestCreditDetector_Classification(t *testing.T) {
+	t.Run("NoGames", func(t *testing.T) {
+		detector, m, _, _ := setupCreditDetectorTest(t)
+		detector.Detect(context.Background(), nil)
+		m.requireCredits(t, 0, 0, 0, 0)
+	})
+
+	t.Run("IgnoresInProgressGames", func(t *testing.T) {
+		detector, m, _, _ := setupCreditDetectorTest(t)
+		game := creditGame(monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(10)})
+		game.Status = types.GameStatusInProgress
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{game})
+		m.requireCredits(t, 0, 0, 0, 0)
+	})
+
+	t.Run("UndelayedCreditIsClaimable", func(t *testing.T) {
+		detector, m, _, _ := setupCreditDetectorTest(t)
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{
+			creditGame(
+				monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(10)},
+				monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(20)},
+			),
+		})
+		m.requireCredits(t, 10, 0, 20, 0)
+	})
+
+	t.Run("DelayPendingVsClaimable", func(t *testing.T) {
+		detector, m, cl, _ := setupCreditDetectorTest(t)
+		now := cl.Now()
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{
+			creditGame(
+				// Not unlocked yet
+				monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(1), Delayed: true},
+				// Delay still in progress
+				monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(2), Delayed: true, UnlockTime: now.Add(time.Second)},
+			),
+			creditGame(
+				// Delay ends now
+				monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(4), Delayed: true, UnlockTime: now},
+				// Delay long elapsed
+				monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(8), Delayed: true, UnlockTime: now.Add(-time.Hour)},
+				monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(16), Delayed: true, UnlockTime: now.Add(time.Hour)},
+			),
+		})
+		m.requireCredits(t, 4, 17, 8, 2)
+	})
+
+	t.Run("PendingBecomesClaimable", func(t *testing.T) {
+		detector, m, cl, _ := setupCreditDetectorTest(t)
+		games := []*monTypes.EnrichedGameData{
+			creditGame(monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(3), Delayed: true, UnlockTime: cl.Now().Add(time.Minute)}),
+		}
+		detector.Detect(context.Background(), games)
+		m.requireCredits(t, 0, 0, 0, 3)
+		cl.AdvanceTime(time.Minute)
+		detector.Detect(context.Background(), games)
+		m.requireCredits(t, 0, 0, 3, 0)
+	})
+}
+
+func TestCreditDetector_Warnings(t *testing.T) {
+	t.Run("WarnsAfterDuration", func(t *testing.T) {
+		detector, _, cl, logs := setupCreditDetectorTest(t)
+		games := []*monTypes.EnrichedGameData{
+			creditGame(monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(60)}),
+			creditGame(monTypes.Credit{Recipient: honestActor, Amount: big.NewInt(50)}),
+		}
+		detector.Detect(context.Background(), games)
+		requireUnclaimedWarnings(t, logs, 0)
+
+		cl.AdvanceTime(creditWarnAfter - time.Second)
+		detector.Detect(context.Background(), games)
+		requireUnclaimedWarnings(t, logs, 0)
+
+		cl.AdvanceTime(time.Second)
+		detector.Detect(context.Background(), games)
+		requireUnclaimedWarnings(t, logs, 1)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter(unclaimedLog))
+		require.Equal(t, honestActor, l.AttrValue("recipient"))
+		require.Equal(t, true, l.AttrValue("honest"))
+		require.Equal(t, big.NewInt(110), l.AttrValue("amount"))
+	})
+
+	t.Run("NoWarningAtThreshold", func(t *testing.T) {
+		detector, _, cl, logs := setupCreditDetectorTest(t)
+		games := []*monTypes.EnrichedGameData{
+			creditGame(monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(100)}),
+		}
+		detector.Detect(context.Background(), games)
+		cl.AdvanceTime(2 * creditWarnAfter)
+		detector.Detect(context.Background(), games)
+		requireUnclaimedWarnings(t, logs, 0)
+	})
+
+	t.Run("PendingCreditNotWarned", func(t *testing.T) {
+		detector, _, cl, logs := setupCreditDetectorTest(t)
+		games := []*monTypes.EnrichedGameData{
+			creditGame(monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(500), Delayed: true}),
+		}
+		detector.Detect(context.Background(), games)
+		cl.AdvanceTime(2 * creditWarnAfter)
+		detector.Detect(context.Background(), games)
+		requireUnclaimedWarnings(t, logs, 0)
+	})
+
+	t.Run("ResetsWhenClaimed", func(t *testing.T) {
+		detector, _, cl, logs := setupCreditDetectorTest(t)
+		games := []*monTypes.EnrichedGameData{
+			creditGame(monTypes.Credit{Recipient: otherActor, Amount: big.NewInt(500)}),
+		}
+		detector.Detect(context.Background(), games)
+		cl.AdvanceTime(creditWarnAfter / 2)
+		// Credit is claimed
+		detector.Detect(context.Background(), nil)
+		cl.AdvanceTime(creditWarnAfter / 2)
+		detector.Detect(context.Background(), games)
+		requireUnclaimedWarnings(t, logs, 0)
+		cl.AdvanceTime(creditWarnAfter)
+		detector.Detect(context.Background(), games)
+		requireUnclaimedWarnings(t, logs, 1)
+	})
+}
+
+func setupCreditDetectorTest(t *testing.T) (*creditDetector, *mockCreditMetrics, *clock.DeterministicClock, *testlog.CapturingHandler) {
+	logger, logs := testlog.CaptureLogger(t, log.LvlDebug)
+	m := &mockCreditMetrics{credits: make(map[string]*big.Int)}
+	cl := clock.NewDeterministicClock(time.Unix(100_000, 0))
+	detector := newCreditDetector(logger, m, cl, []common.Address{honestActor}, big.NewInt(100), creditWarnAfter)
+	return detector, m, cl, logs
+}
+
+func creditGame(credits ...monTypes.Credit) *monTypes.EnrichedGameData {
+	return &monTypes.EnrichedGameData{
+		Status:  types.GameStatusDefenderWon,
+		Credits: credits,
+	}
+}
+
+func requireUnclaimedWarnings(t *testing.T, logs *testlog.CapturingHandler, count int) {
+	levelFilter := testlog.NewLevelFilter(log.LevelWarn)
+	messageFilter := testlog.NewMessageFilter(unclaimedLog)
+	require.Len(t, logs.FindLogs(levelFilter, messageFilter), count)
+}
+
+type mockCreditMetrics struct {
+	credits map[string]*big.Int
+}
+
+func (m *mockCreditMetrics) RecordUnclaimedCredit(recipients string, state string, amount *big.Int) {
+	m.credits[recipients+"/"+state] = amount
+}
+
+func (m *mockCreditMetrics) requireCredits(t *testing.T, honestClaimable, honestPending, otherClaimable, otherPending int64) {
+	require.Equal(t, big.NewInt(honestClaimable), m.credits[metrics.CreditRecipientHonest+"/"+metrics.CreditStateClaimable])
+	require.Equal(t, big.NewInt(honestPending), m.credits[metrics.CreditRecipientHonest+"/"+metrics.CreditStatePending])
+	require.Equal(t, big.NewInt(otherClaimable), m.credits[metrics.CreditRecipientOther+"/"+metrics.CreditStateClaimable])
+	require.Equal(t, big.NewInt(otherPending), m.credits[metrics.CreditRecipientOther+"/"+metrics.CreditStatePending])
+}
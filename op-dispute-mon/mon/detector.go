@@ -2,9 +2,11 @@ package mon
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 
@@ -29,13 +31,15 @@ type detector struct {
 	logger    log.Logger
 	metrics   DetectorMetrics
 	validator OutputValidator
+	alerts    alerts.Sink
 }
 
-func newDetector(logger log.Logger, metrics DetectorMetrics, validator OutputValidator) *detector {
+func newDetector(logger log.Logger, metrics DetectorMetrics, validator OutputValidator, sink alerts.Sink) *detector {
 	return &detector{
 		logger:    logger,
 		metrics:   metrics,
 		validator: validator,
+		alerts:    sink,
 	}
 }
 
@@ -80,6 +84,13 @@ func (d *detector) checkAgreement(ctx context.Context, addr common.Address, bloc
 				"gameAddr", addr, "blockNum", blockNum,
 				"expectedResult", expectedResult, "actualResult", status,
 				"rootClaim", rootClaim, "correctClaim", expectedClaim)
+			d.alerts.Emit(alerts.Alert{
+				Game:     addr,
+				Severity: alerts.SeverityCritical,
+				Category: alerts.CategoryUnexpectedResult,
+				Message: fmt.Sprintf("Game for L2 block %v with root claim %v resolved as %v but should have resolved as %v",
+					blockNum, rootClaim, status, expectedResult),
+			})
 		}
 	}
 	return batch, nil
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
@@ -200,7 +201,7 @@ func setupDetectorTest(t *testing.T) (*detector, *mockDetectorMetricer, *stubOut
 	logger, capturedLogs := testlog.CaptureLogger(t, log.LvlDebug)
 	metrics := &mockDetectorMetricer{}
 	validator := &stubOutputValidator{}
-	detector := newDetector(logger, metrics, validator)
+	detector := newDetector(logger, metrics, validator, alerts.NoopSink{})
 	return detector, metrics, validator, capturedLogs
 }
 
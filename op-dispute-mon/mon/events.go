@@ -0,0 +1,211 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// maxLogRange is the maximum number of blocks to load logs for in a single request.
+const maxLogRange = 1000
+
+type LogFilterer interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethTypes.Log, error)
+}
+
+// eventWatcher polls L1 for events that may need an immediate response, triggering a monitoring cycle when they
+// are found rather than waiting for the next monitor interval.
+// Each poll loads the logs of every block since the last successful poll, so events emitted while the RPC is
+// unavailable are found once it recovers.
+type eventWatcher struct {
+	logger       log.Logger
+	clock        clock.Clock
+	client       LogFilterer
+	fetchBlock   BlockFetcher
+	trigger      func()
+	pollInterval time.Duration
+
+	factory      common.Address
+	createdTopic common.Hash
+	// gameTopics are the topics of game events that trigger a cycle, or nil if game events are ignored.
+	gameTopics []common.Hash
+
+	// ctx is the parent context of each watcher run.
+	ctx context.Context
+
+	// next is the first block that hasn't been checked for events, or 0 before the first poll.
+	next uint64
+
+	gamesLock sync.Mutex
+	games     []common.Address
+
+	lock   sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newEventWatcher(
+	ctx context.Context,
+	logger log.Logger,
+	cl clock.Clock,
+	client LogFilterer,
+	fetchBlock BlockFetcher,
+	trigger func(),
+	pollInterval time.Duration,
+	factory common.Address,
+	gameEvents bool,
+) (*eventWatcher, error) {
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dispute game factory ABI: %w", err)
+	}
+	w := &eventWatcher{
+		ctx:          ctx,
+		logger:       logger,
+		clock:        cl,
+		client:       client,
+		fetchBlock:   fetchBlock,
+		trigger:      trigger,
+		pollInterval: pollInterval,
+		factory:      factory,
+		createdTopic: factoryAbi.Events["DisputeGameCreated"].ID,
+	}
+	if gameEvents {
+		gameAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load fault dispute game ABI: %w", err)
+		}
+		w.gameTopics = []common.Hash{gameAbi.Events["Move"].ID, gameAbi.Events["Resolved"].ID}
+	}
+	return w, nil
+}
+
+// SetGames records the games whose events trigger a cycle from the latest successful monitoring cycle.
+// Only in-progress games are watched, as completed games have no further moves.
+func (w *eventWatcher) SetGames(games []*types.EnrichedGameData) {
+	var inProgress []common.Address
+	for _, game := range games {
+		if game.Status == gameTypes.GameStatusInProgress {
+			inProgress = append(inProgress, game.Proxy)
+		}
+	}
+	w.gamesLock.Lock()
+	defer w.gamesLock.Unlock()
+	w.games = inProgress
+}
+
+func (w *eventWatcher) watchedGames() []common.Address {
+	w.gamesLock.Lock()
+	defer w.gamesLock.Unlock()
+	return w.games
+}
+
+// poll checks the blocks since the last poll for events, triggering a single cycle if any are found.
+// Blocks are only marked as checked once their logs are loaded, so they are retried by the next poll on failure.
+func (w *eventWatcher) poll(ctx context.Context) error {
+	head, err := w.fetchBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch head block: %w", err)
+	}
+	if w.next == 0 {
+		// Games created before the watcher started are found by the regular monitoring cycle
+		w.next = head.Number + 1
+		return nil
+	}
+	found := 0
+	for w.next <= head.Number && err == nil {
+		to := min(head.Number, w.next+maxLogRange-1)
+		var count int
+		count, err = w.countEvents(ctx, w.next, to)
+		if err == nil {
+			found += count
+			w.next = to + 1
+		}
+	}
+	if found > 0 {
+		w.logger.Info("Found game events, triggering monitoring cycle", "events", found, "head", head)
+		w.trigger()
+	}
+	return err
+}
+
+// countEvents returns the number of events that trigger a cycle emitted in blocks from to to, inclusive.
+func (w *eventWatcher) countEvents(ctx context.Context, from uint64, to uint64) (int, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{w.factory},
+		Topics:    [][]common.Hash{{w.createdTopic}},
+	}
+	logs, err := w.client.FilterLogs(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load game creation logs from block %v to %v: %w", from, to, err)
+	}
+	count := len(logs)
+	games := w.watchedGames()
+	if len(w.gameTopics) == 0 || len(games) == 0 {
+		return count, nil
+	}
+	query.Addresses = games
+	query.Topics = [][]common.Hash{w.gameTopics}
+	logs, err = w.client.FilterLogs(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load game logs from block %v to %v: %w", from, to, err)
+	}
+	return count + len(logs), nil
+}
+
+func (w *eventWatcher) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := w.clock.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.Ch():
+			if err := w.poll(ctx); err != nil {
+				w.logger.Warn("Failed to poll for game events", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Start starts polling for events. It is a no-op if the watcher is already running.
+func (w *eventWatcher) Start() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.cancel != nil {
+		return
+	}
+	w.logger.Info("Starting game event watcher", "interval", w.pollInterval)
+	ctx, cancel := context.WithCancel(w.ctx)
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go w.loop(ctx, w.done)
+}
+
+// Stop stops polling for events and waits for the current poll to complete.
+func (w *eventWatcher) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+	w.cancel = nil
+	w.done = nil
+}
@@ -0,0 +1,286 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var watchedFactory = common.Address{0xfa}
+
+func TestEventWatcher_Poll(t *testing.T) {
+	t.Run("FirstPollStartsAtHead", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		stubs.head = 100
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Empty(t, stubs.queries)
+		require.Zero(t, stubs.triggers)
+		require.Equal(t, uint64(101), watcher.next)
+	})
+
+	t.Run("NoNewBlocks", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		startWatcherAt(t, watcher, stubs, 100)
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Empty(t, stubs.queries)
+	})
+
+	t.Run("NoEvents", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 105
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Len(t, stubs.queries, 1)
+		query := stubs.queries[0]
+		require.Equal(t, big.NewInt(101), query.FromBlock)
+		require.Equal(t, big.NewInt(105), query.ToBlock)
+		require.Equal(t, []common.Address{watchedFactory}, query.Addresses)
+		require.Equal(t, [][]common.Hash{{gameCreatedTopic(t)}}, query.Topics)
+		require.Zero(t, stubs.triggers)
+		require.Equal(t, uint64(106), watcher.next)
+	})
+
+	t.Run("TriggersOncePerBurst", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 105
+		stubs.logs = map[common.Address]int{watchedFactory: 3}
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Equal(t, 1, stubs.triggers)
+
+		// Events are only reported once
+		stubs.logs = nil
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Equal(t, 1, stubs.triggers)
+	})
+
+	t.Run("SplitsLargeRanges", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 100 + 2*maxLogRange + 500
+		stubs.logs = map[common.Address]int{watchedFactory: 1}
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Equal(t, [][2]uint64{
+			{101, 100 + maxLogRange},
+			{101 + maxLogRange, 100 + 2*maxLogRange},
+			{101 + 2*maxLogRange, 100 + 2*maxLogRange + 500},
+		}, queryRanges(stubs.queries))
+		require.Equal(t, 1, stubs.triggers, "should trigger once for events across all ranges")
+	})
+
+	t.Run("FetchBlockError", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.headErr = errors.New("boom")
+		require.ErrorIs(t, watcher.poll(context.Background()), stubs.headErr)
+		require.Equal(t, uint64(101), watcher.next)
+	})
+
+	t.Run("BackfillsAfterError", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 105
+		stubs.logs = map[common.Address]int{watchedFactory: 1}
+		stubs.logsErr = errors.New("connection dropped")
+		require.ErrorIs(t, watcher.poll(context.Background()), stubs.logsErr)
+		require.Zero(t, stubs.triggers)
+		require.Equal(t, uint64(101), watcher.next)
+
+		stubs.logsErr = nil
+		stubs.head = 110
+		stubs.queries = nil
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Equal(t, [][2]uint64{{101, 110}}, queryRanges(stubs.queries))
+		require.Equal(t, 1, stubs.triggers)
+	})
+
+	t.Run("TriggersForEventsFoundBeforeError", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 100 + 2*maxLogRange
+		stubs.logs = map[common.Address]int{watchedFactory: 1}
+		stubs.logsErrAfter = 1
+		stubs.logsErr = errors.New("boom")
+		require.ErrorIs(t, watcher.poll(context.Background()), stubs.logsErr)
+		require.Equal(t, 1, stubs.triggers)
+		require.Equal(t, uint64(101+maxLogRange), watcher.next)
+	})
+
+	t.Run("IgnoresGameEventsWhenDisabled", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, false)
+		watcher.SetGames([]*monTypes.EnrichedGameData{eventGame(common.Address{0x01}, gameTypes.GameStatusInProgress)})
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 105
+		stubs.logs = map[common.Address]int{{0x01}: 1}
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Len(t, stubs.queries, 1)
+		require.Zero(t, stubs.triggers)
+	})
+
+	t.Run("NoGamesToWatch", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, true)
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 105
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Len(t, stubs.queries, 1)
+	})
+
+	t.Run("TriggersOnGameEvents", func(t *testing.T) {
+		watcher, stubs := setupEventWatcherTest(t, true)
+		watcher.SetGames([]*monTypes.EnrichedGameData{
+			eventGame(common.Address{0x01}, gameTypes.GameStatusInProgress),
+			eventGame(common.Address{0x02}, gameTypes.GameStatusDefenderWon),
+			eventGame(common.Address{0x03}, gameTypes.GameStatusInProgress),
+		})
+		startWatcherAt(t, watcher, stubs, 100)
+		stubs.head = 105
+		stubs.logs = map[common.Address]int{{0x03}: 2}
+		require.NoError(t, watcher.poll(context.Background()))
+		require.Len(t, stubs.queries, 2)
+		query := stubs.queries[1]
+		require.Equal(t, []common.Address{{0x01}, {0x03}}, query.Addresses, "should only watch in progress games")
+		require.Equal(t, [][]common.Hash{gameEventTopics(t)}, query.Topics)
+		require.Equal(t, big.NewInt(101), query.FromBlock)
+		require.Equal(t, big.NewInt(105), query.ToBlock)
+		require.Equal(t, 1, stubs.triggers)
+	})
+}
+
+func TestEventWatcher_Lifecycle(t *testing.T) {
+	watcher, stubs := setupEventWatcherTest(t, false)
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	watcher.clock = cl
+	stubs.head = 100
+	triggered := make(chan struct{}, 10)
+	watcher.trigger = func() {
+		triggered <- struct{}{}
+	}
+	watcher.Start()
+	defer watcher.Stop()
+
+	// First poll finds the current head
+	require.Eventually(t, func() bool {
+		cl.AdvanceTime(watcher.pollInterval)
+		return stubs.fetchCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stubs.setHead(105, map[common.Address]int{watchedFactory: 1})
+	require.Eventually(t, func() bool {
+		cl.AdvanceTime(watcher.pollInterval)
+		return len(triggered) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	watcher.Stop()
+	require.Len(t, triggered, 1)
+}
+
+func setupEventWatcherTest(t *testing.T, gameEvents bool) (*eventWatcher, *stubEventSource) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	stubs := &stubEventSource{}
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	trigger := func() {
+		stubs.triggers++
+	}
+	watcher, err := newEventWatcher(context.Background(), logger, cl, stubs, stubs.FetchBlock, trigger, 12*time.Second, watchedFactory, gameEvents)
+	require.NoError(t, err)
+	return watcher, stubs
+}
+
+func startWatcherAt(t *testing.T, watcher *eventWatcher, stubs *stubEventSource, head uint64) {
+	stubs.head = head
+	require.NoError(t, watcher.poll(context.Background()))
+}
+
+func eventGame(addr common.Address, status gameTypes.GameStatus) *monTypes.EnrichedGameData {
+	return &monTypes.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{Proxy: addr}, Status: status}
+}
+
+func gameCreatedTopic(t *testing.T) common.Hash {
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	return factoryAbi.Events["DisputeGameCreated"].ID
+}
+
+func gameEventTopics(t *testing.T) []common.Hash {
+	gameAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	return []common.Hash{gameAbi.Events["Move"].ID, gameAbi.Events["Resolved"].ID}
+}
+
+func queryRanges(queries []ethereum.FilterQuery) [][2]uint64 {
+	var ranges [][2]uint64
+	for _, query := range queries {
+		ranges = append(ranges, [2]uint64{query.FromBlock.Uint64(), query.ToBlock.Uint64()})
+	}
+	return ranges
+}
+
+type stubEventSource struct {
+	lock    sync.Mutex
+	head    uint64
+	headErr error
+	fetches int
+
+	// logs is the number of logs each address emits in each queried range.
+	logs         map[common.Address]int
+	logsErr      error
+	logsErrAfter int
+	queries      []ethereum.FilterQuery
+
+	triggers int
+}
+
+func (s *stubEventSource) setHead(head uint64, logs map[common.Address]int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.head = head
+	s.logs = logs
+}
+
+func (s *stubEventSource) fetchCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.fetches
+}
+
+func (s *stubEventSource) FetchBlock(_ context.Context) (eth.BlockID, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.fetches++
+	if s.headErr != nil {
+		return eth.BlockID{}, s.headErr
+	}
+	return eth.BlockID{Number: s.head}, nil
+}
+
+func (s *stubEventSource) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]gethTypes.Log, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.logsErr != nil && len(s.queries) >= s.logsErrAfter {
+		return nil, s.logsErr
+	}
+	s.queries = append(s.queries, q)
+	var logs []gethTypes.Log
+	for _, addr := range q.Addresses {
+		for i := 0; i < s.logs[addr]; i++ {
+			logs = append(logs, gethTypes.Log{Address: addr})
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,96 @@
+package extract
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BlockHashFetcher returns the hash of the canonical L1 block at number.
+type BlockHashFetcher func(ctx context.Context, number uint64) (common.Hash, error)
+
+type cachedGame struct {
+	game       *monTypes.EnrichedGameData
+	enrichedAt time.Time
+}
+
+// gameCache holds the last enriched data of each game so games that haven't changed since the last cycle don't
+// need their claims and credits reloaded.
+type gameCache struct {
+	lock  sync.Mutex
+	games map[common.Address]cachedGame
+
+	// anchor is the block games were last extracted at. The cache is cleared if it is reorged out.
+	anchor eth.BlockID
+}
+
+func newGameCache() *gameCache {
+	return &gameCache{
+		games: make(map[common.Address]cachedGame),
+	}
+}
+
+// get returns a copy of the cached data for game so callers can set its fields without modifying the cache.
+// The claims, credits and honest stakes are shared with the cache and must not be modified.
+func (c *gameCache) get(game common.Address) (*monTypes.EnrichedGameData, time.Time, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.games[game]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	enriched := *entry.game
+	return &enriched, entry.enrichedAt, true
+}
+
+func (c *gameCache) add(enriched *monTypes.EnrichedGameData, enrichedAt time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached := *enriched
+	c.games[enriched.Proxy] = cachedGame{game: &cached, enrichedAt: enrichedAt}
+}
+
+// retain removes every game not in games from the cache.
+func (c *gameCache) retain(games []gameTypes.GameMetadata) {
+	keep := make(map[common.Address]bool, len(games))
+	for _, game := range games {
+		keep[game.Proxy] = true
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for addr := range c.games {
+		if !keep[addr] {
+			delete(c.games, addr)
+		}
+	}
+}
+
+// updateAnchor records block as the block games are extracted at, returning true and clearing the cache if the
+// previous anchor block is no longer canonical.
+func (c *gameCache) updateAnchor(ctx context.Context, block eth.BlockID, blockHash BlockHashFetcher) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	prev := c.anchor
+	c.anchor = block
+	if prev == (eth.BlockID{}) || prev == block {
+		return false, nil
+	}
+	reorged := block.Number <= prev.Number
+	var err error
+	if !reorged {
+		var hash common.Hash
+		hash, err = blockHash(ctx, prev.Number)
+		// The cache can't be trusted if the previous anchor's canonical hash is unknown
+		reorged = err != nil || hash != prev.Hash
+	}
+	if reorged {
+		c.games = make(map[common.Address]cachedGame)
+	}
+	return reorged, err
+}
@@ -0,0 +1,67 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestGameCache_UpdateAnchor(t *testing.T) {
+	anchor := eth.BlockID{Hash: common.Hash{0xaa}, Number: 100}
+	canonical := func(ctx context.Context, number uint64) (common.Hash, error) {
+		return anchor.Hash, nil
+	}
+	reorgedOut := func(ctx context.Context, number uint64) (common.Hash, error) {
+		return common.Hash{0xbb}, nil
+	}
+	unexpected := func(ctx context.Context, number uint64) (common.Hash, error) {
+		t.Fatal("unexpected block hash request")
+		return common.Hash{}, nil
+	}
+
+	tests := []struct {
+		name      string
+		block     eth.BlockID
+		blockHash BlockHashFetcher
+		reorged   bool
+		err       error
+	}{
+		{"SameBlock", anchor, unexpected, false, nil},
+		{"CanonicalDescendant", eth.BlockID{Hash: common.Hash{0xcc}, Number: 105}, canonical, false, nil},
+		{"AnchorReorgedOut", eth.BlockID{Hash: common.Hash{0xcc}, Number: 105}, reorgedOut, true, nil},
+		{"SameHeightDifferentHash", eth.BlockID{Hash: common.Hash{0xcc}, Number: 100}, unexpected, true, nil},
+		{"LowerHeight", eth.BlockID{Hash: common.Hash{0xcc}, Number: 99}, unexpected, true, nil},
+		{"HashFetchError", eth.BlockID{Hash: common.Hash{0xcc}, Number: 105}, func(ctx context.Context, number uint64) (common.Hash, error) {
+			return common.Hash{}, errors.New("boom")
+		}, true, errors.New("boom")},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cache := newGameCache()
+			reorged, err := cache.updateAnchor(context.Background(), anchor, unexpected)
+			require.NoError(t, err)
+			require.False(t, reorged, "should not report a reorg for the first block")
+			cache.add(&monTypes.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x01}}}, time.Unix(1000, 0))
+
+			reorged, err = cache.updateAnchor(context.Background(), test.block, test.blockHash)
+			if test.err != nil {
+				require.ErrorContains(t, err, test.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.reorged, reorged)
+			_, _, ok := cache.get(common.Address{0x01})
+			require.Equal(t, !test.reorged, ok)
+			require.Equal(t, test.block, cache.anchor)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package extract
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -17,19 +19,41 @@ const metricsLabel = "game_caller_creator"
 
 type GameCaller interface {
 	GetGameMetadata(context.Context) (uint64, common.Hash, types.GameStatus, uint64, error)
+	GetL1Head(context.Context) (common.Hash, error)
 	GetAllClaims(context.Context) ([]faultTypes.Claim, error)
+	GetStatusAndClaimCount(context.Context) (types.GameStatus, uint64, error)
+	GetCredits(ctx context.Context, block batching.Block, recipients ...common.Address) ([]*big.Int, error)
+}
+
+// DelayedCreditCaller is a GameCaller for a game that holds its bonds in a DelayedWETH contract.
+type DelayedCreditCaller interface {
+	// GetCreditUnlockTime returns the time the credit of recipient can be claimed from.
+	// The zero time is returned if the credit has not been unlocked yet.
+	GetCreditUnlockTime(ctx context.Context, recipient common.Address) (time.Time, error)
 }
 
 type GameCallerCreator struct {
-	cache  *caching.LRUCache[common.Address, *contracts.FaultDisputeGameContract]
+	cache  *caching.LRUCache[common.Address, GameCaller]
 	caller *batching.MultiCaller
+
+	// delayedWETH holds the DelayedWETH contracts of game types that don't hold bonds in the game contract.
+	delayedWETH map[uint32]*contracts.DelayedWETHContract
 }
 
-func NewGameCallerCreator(m caching.Metrics, caller *batching.MultiCaller) *GameCallerCreator {
-	return &GameCallerCreator{
-		caller: caller,
-		cache:  caching.NewLRUCache[common.Address, *contracts.FaultDisputeGameContract](m, metricsLabel, 100),
+func NewGameCallerCreator(m caching.Metrics, caller *batching.MultiCaller, gameTypeDelayedWETH map[uint32]common.Address) (*GameCallerCreator, error) {
+	delayedWETH := make(map[uint32]*contracts.DelayedWETHContract, len(gameTypeDelayedWETH))
+	for gameType, addr := range gameTypeDelayedWETH {
+		weth, err := contracts.NewDelayedWETHContract(addr, caller)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DelayedWETH contract for game type %v: %w", gameType, err)
+		}
+		delayedWETH[gameType] = weth
 	}
+	return &GameCallerCreator{
+		caller:      caller,
+		cache:       caching.NewLRUCache[common.Address, GameCaller](m, metricsLabel, 100),
+		delayedWETH: delayedWETH,
+	}, nil
 }
 
 func (g *GameCallerCreator) CreateContract(game types.GameMetadata) (GameCaller, error) {
@@ -42,8 +66,12 @@ func (g *GameCallerCreator) CreateContract(game types.GameMetadata) (GameCaller,
 		if err != nil {
 			return nil, fmt.Errorf("failed to create FaultDisputeGameContract: %w", err)
 		}
-		g.cache.Add(game.Proxy, fdg)
-		return fdg, nil
+		var gameCaller GameCaller = fdg
+		if weth, ok := g.delayedWETH[game.GameType]; ok {
+			gameCaller = contracts.NewDelayedWETHBondContract(fdg, weth)
+		}
+		g.cache.Add(game.Proxy, gameCaller)
+		return gameCaller, nil
 	default:
 		return nil, fmt.Errorf("unsupported game type: %d", game.GameType)
 	}
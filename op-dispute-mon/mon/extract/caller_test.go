@@ -43,8 +43,9 @@ func TestMetadataCreator_CreateContract(t *testing.T) {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			caller, metrics := setupMetadataLoaderTest(t)
-			creator := NewGameCallerCreator(metrics, caller)
-			_, err := creator.CreateContract(test.game)
+			creator, err := NewGameCallerCreator(metrics, caller, nil)
+			require.NoError(t, err)
+			_, err = creator.CreateContract(test.game)
 			require.Equal(t, test.expectedErr, err)
 			if test.expectedErr == nil {
 				require.Equal(t, 1, metrics.cacheAddCalls)
@@ -60,6 +61,23 @@ func TestMetadataCreator_CreateContract(t *testing.T) {
 	}
 }
 
+func TestMetadataCreator_DelayedWETH(t *testing.T) {
+	caller, metrics := setupMetadataLoaderTest(t)
+	creator, err := NewGameCallerCreator(metrics, caller, map[uint32]common.Address{
+		faultTypes.CannonGameType: {0xaa},
+	})
+	require.NoError(t, err)
+
+	cannon, err := creator.CreateContract(types.GameMetadata{GameType: faultTypes.CannonGameType, Proxy: fdgAddr})
+	require.NoError(t, err)
+	require.Implements(t, (*DelayedCreditCaller)(nil), cannon)
+
+	alphabet, err := creator.CreateContract(types.GameMetadata{GameType: faultTypes.AlphabetGameType, Proxy: common.Address{0xbb}})
+	require.NoError(t, err)
+	_, ok := alphabet.(DelayedCreditCaller)
+	require.False(t, ok, "should not use DelayedWETH for other game types")
+}
+
 func setupMetadataLoaderTest(t *testing.T) (*batching.MultiCaller, *mockCacheMetrics) {
 	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
 	require.NoError(t, err)
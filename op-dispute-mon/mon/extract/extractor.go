@@ -2,66 +2,335 @@ package extract
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 )
 
 type CreateGameCaller func(game gameTypes.GameMetadata) (GameCaller, error)
 type FactoryGameFetcher func(ctx context.Context, blockHash common.Hash, earliestTimestamp uint64) ([]gameTypes.GameMetadata, error)
 
+// Classes of error that caused a game to fail to load, reported in metrics.
+const (
+	failureCreateCaller = "create_caller"
+	failureTimeout      = "timeout"
+	failureCanceled     = "canceled"
+	failureRPC          = "rpc"
+	failureHTTP         = "http"
+	failureOther        = "other"
+)
+
+type ExtractorMetrics interface {
+	RecordFailedGames(count int)
+	RecordGameEnrichments(cacheHits int, fullEnrichments int)
+	RecordGameEnrichmentDuration(cached bool, duration time.Duration)
+	RecordGameEnrichmentFailure(class string)
+}
+
 type Extractor struct {
 	logger         log.Logger
+	clock          clock.Clock
+	metrics        ExtractorMetrics
 	createContract CreateGameCaller
 	fetchGames     FactoryGameFetcher
+	blockHash      BlockHashFetcher
+	honestActors   map[common.Address]bool
+	maxConcurrency int
+
+	cache *gameCache
+	// resolvedRefresh is the time resolved games are served from the cache for before being enriched again.
+	resolvedRefresh time.Duration
 }
 
-func NewExtractor(logger log.Logger, creator CreateGameCaller, fetchGames FactoryGameFetcher) *Extractor {
+func NewExtractor(
+	logger log.Logger,
+	cl clock.Clock,
+	m ExtractorMetrics,
+	creator CreateGameCaller,
+	fetchGames FactoryGameFetcher,
+	blockHash BlockHashFetcher,
+	honestActors []common.Address,
+	maxConcurrency uint,
+	resolvedRefresh time.Duration,
+) *Extractor {
+	honest := make(map[common.Address]bool, len(honestActors))
+	for _, actor := range honestActors {
+		honest[actor] = true
+	}
 	return &Extractor{
-		logger:         logger,
-		createContract: creator,
-		fetchGames:     fetchGames,
+		logger:          logger,
+		clock:           cl,
+		metrics:         m,
+		createContract:  creator,
+		fetchGames:      fetchGames,
+		blockHash:       blockHash,
+		honestActors:    honest,
+		maxConcurrency:  int(maxConcurrency),
+		cache:           newGameCache(),
+		resolvedRefresh: resolvedRefresh,
 	}
 }
 
-func (e *Extractor) Extract(ctx context.Context, blockHash common.Hash, minTimestamp uint64) ([]*monTypes.EnrichedGameData, error) {
-	games, err := e.fetchGames(ctx, blockHash, minTimestamp)
+// Extract loads the games created at or after minTimestamp as of block and enriches them with their current state.
+// Games that fail to load are logged, counted and excluded. The remaining games are returned in the order the
+// factory returned them.
+//
+// Enriched games are cached. In-progress games are only enriched again once their status or number of claims
+// changes, and resolved games once they have been cached for the resolved refresh interval. The cache is cleared
+// if the block games were last extracted at is reorged out.
+func (e *Extractor) Extract(ctx context.Context, block eth.BlockID, minTimestamp uint64) ([]*monTypes.EnrichedGameData, error) {
+	if reorged, err := e.cache.updateAnchor(ctx, block, e.blockHash); err != nil {
+		e.logger.Warn("Failed to check for reorg, cleared game cache", "block", block, "err", err)
+	} else if reorged {
+		e.logger.Warn("Previous block reorged out, cleared game cache", "block", block)
+	}
+	games, err := e.fetchGames(ctx, block.Hash, minTimestamp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load games: %w", err)
 	}
-	return e.enrichGames(ctx, games), nil
+	e.logger.Debug("Loaded games", "block", block, "count", len(games))
+	e.cache.retain(games)
+	return e.enrichGames(ctx, block, games), nil
+}
+
+// enrichGames enriches games using up to maxConcurrency workers.
+func (e *Extractor) enrichGames(ctx context.Context, block eth.BlockID, games []gameTypes.GameMetadata) []*monTypes.EnrichedGameData {
+	// Each worker writes to the slot for its game's index so the fan-in preserves the factory's ordering.
+	results := make([]*monTypes.EnrichedGameData, len(games))
+	cached := make([]bool, len(games))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(e.maxConcurrency, len(games)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				start := e.clock.Now()
+				results[idx], cached[idx] = e.enrichGame(ctx, block, games[idx])
+				if results[idx] != nil {
+					e.metrics.RecordGameEnrichmentDuration(cached[idx], e.clock.Since(start))
+				}
+			}
+		}()
+	}
+	for i := range games {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	enrichedGames := make([]*monTypes.EnrichedGameData, 0, len(games))
+	cacheHits := 0
+	for i, game := range results {
+		if game != nil {
+			enrichedGames = append(enrichedGames, game)
+		}
+		if cached[i] {
+			cacheHits++
+		}
+	}
+	e.metrics.RecordGameEnrichments(cacheHits, len(enrichedGames)-cacheHits)
+	if failed := len(games) - len(enrichedGames); failed > 0 {
+		e.logger.Warn("Excluded games that failed to load", "failed", failed, "total", len(games))
+		e.metrics.RecordFailedGames(failed)
+	}
+	return enrichedGames
 }
 
-func (e *Extractor) enrichGames(ctx context.Context, games []gameTypes.GameMetadata) []*monTypes.EnrichedGameData {
-	var enrichedGames []*monTypes.EnrichedGameData
-	for _, game := range games {
-		caller, err := e.createContract(game)
+// enrichGame returns the current state of game and whether it was served from the cache.
+// Errors are logged and counted by class, and nil is returned if the game can't be loaded.
+func (e *Extractor) enrichGame(ctx context.Context, block eth.BlockID, game gameTypes.GameMetadata) (*monTypes.EnrichedGameData, bool) {
+	caller, err := e.createContract(game)
+	if err != nil {
+		e.logger.Error("failed to create game caller", "game", game.Proxy, "err", err)
+		e.metrics.RecordGameEnrichmentFailure(failureCreateCaller)
+		return nil, false
+	}
+	if cached, enrichedAt, ok := e.cache.get(game.Proxy); ok {
+		unchanged, err := e.unchanged(ctx, caller, cached, enrichedAt)
 		if err != nil {
-			e.logger.Error("failed to create game caller", "err", err)
+			e.gameFailed("failed to fetch game status", game, err)
+			return nil, false
+		}
+		if unchanged {
+			return cached, true
+		}
+	}
+	enriched := e.loadGame(ctx, caller, block, game)
+	if enriched != nil {
+		e.cache.add(enriched, e.clock.Now())
+	}
+	return enriched, false
+}
+
+// unchanged returns true if the cached data of a game is still current.
+// Resolved games can't change status or gain claims so are refreshed on a timer to pick up credit changes instead.
+// Resolving a claim doesn't change the claim count, so in progress games with claims that may have been resolved
+// are always reloaded.
+func (e *Extractor) unchanged(ctx context.Context, caller GameCaller, cached *monTypes.EnrichedGameData, enrichedAt time.Time) (bool, error) {
+	if cached.Status != gameTypes.GameStatusInProgress {
+		return e.clock.Since(enrichedAt) < e.resolvedRefresh, nil
+	}
+	if e.hasResolvableClaims(cached) {
+		return false, nil
+	}
+	status, claimCount, err := caller.GetStatusAndClaimCount(ctx)
+	if err != nil {
+		return false, err
+	}
+	return status == cached.Status && claimCount == uint64(len(cached.Claims)), nil
+}
+
+// hasResolvableClaims returns true if game has unresolved claims whose clock has expired. Only those claims can
+// be resolved.
+func (e *Extractor) hasResolvableClaims(game *monTypes.EnrichedGameData) bool {
+	now := e.clock.Now()
+	for _, claim := range game.Claims {
+		if claim.Clock == nil || claim.Bond == nil || monTypes.ResolvedBondAmount.Cmp(claim.Bond) == 0 {
 			continue
 		}
-		l2BlockNum, rootClaim, status, duration, err := caller.GetGameMetadata(ctx)
+		// ChessTime counts seconds despite its type.
+		if uint64(claim.ChessTime(now)) >= game.Duration/2 {
+			return true
+		}
+	}
+	return false
+}
+
+// loadGame loads the full state of game, logging and returning nil if it can't be loaded.
+func (e *Extractor) loadGame(ctx context.Context, caller GameCaller, block eth.BlockID, game gameTypes.GameMetadata) *monTypes.EnrichedGameData {
+	l2BlockNum, rootClaim, status, duration, err := caller.GetGameMetadata(ctx)
+	if err != nil {
+		e.gameFailed("failed to fetch game metadata", game, err)
+		return nil
+	}
+	l1Head, err := caller.GetL1Head(ctx)
+	if err != nil {
+		e.gameFailed("failed to fetch game l1 head", game, err)
+		return nil
+	}
+	claims, err := caller.GetAllClaims(ctx)
+	if err != nil {
+		e.gameFailed("failed to fetch game claims", game, err)
+		return nil
+	}
+	var credits []monTypes.Credit
+	// Credit is only allocated as claims are resolved, so there is none to load until the game resolves.
+	if status != gameTypes.GameStatusInProgress {
+		credits, err = e.loadCredits(ctx, caller, block, claims)
 		if err != nil {
-			e.logger.Error("failed to fetch game metadata", "err", err)
+			e.gameFailed("failed to fetch game credits", game, err)
+			return nil
+		}
+	}
+	return &monTypes.EnrichedGameData{
+		GameMetadata:  game,
+		L1Head:        l1Head,
+		L2BlockNumber: l2BlockNum,
+		RootClaim:     rootClaim,
+		Status:        status,
+		Duration:      duration,
+		Claims:        claims,
+		Credits:       credits,
+		HonestStakes:  e.honestStakes(claims),
+	}
+}
+
+// gameFailed logs that game failed to load with err and counts the failure by the class of err.
+func (e *Extractor) gameFailed(msg string, game gameTypes.GameMetadata, err error) {
+	e.logger.Error(msg, "game", game.Proxy, "err", err)
+	e.metrics.RecordGameEnrichmentFailure(failureClass(err))
+}
+
+// failureClass returns the class of err for metrics, distinguishing timeouts and errors returned by the RPC
+// server from other failures.
+func failureClass(err error) string {
+	var rpcErr rpc.Error
+	var httpErr rpc.HTTPError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return failureTimeout
+	case errors.Is(err, context.Canceled):
+		return failureCanceled
+	case errors.As(err, &rpcErr):
+		return failureRPC
+	case errors.As(err, &httpErr):
+		return failureHTTP
+	default:
+		return failureOther
+	}
+}
+
+// honestStakes attributes the unresolved claims in a game, and the bonds posted with them, to honest actors.
+func (e *Extractor) honestStakes(claims []faultTypes.Claim) map[common.Address]monTypes.ActorStake {
+	var stakes map[common.Address]monTypes.ActorStake
+	for _, claim := range claims {
+		if !e.honestActors[claim.Claimant] || claim.Bond == nil || claim.Bond.Cmp(monTypes.ResolvedBondAmount) == 0 {
 			continue
 		}
-		claims, err := caller.GetAllClaims(ctx)
-		if err != nil {
-			e.logger.Error("failed to fetch game claims", "err", err)
+		if stakes == nil {
+			stakes = make(map[common.Address]monTypes.ActorStake)
+		}
+		stake, ok := stakes[claim.Claimant]
+		if !ok {
+			stake.Bonded = big.NewInt(0)
+		}
+		stake.Claims++
+		stake.Bonded.Add(stake.Bonded, claim.Bond)
+		stakes[claim.Claimant] = stake
+	}
+	return stakes
+}
+
+// loadCredits returns the unclaimed credit of every address that posted or countered a claim in the game.
+func (e *Extractor) loadCredits(ctx context.Context, caller GameCaller, block eth.BlockID, claims []faultTypes.Claim) ([]monTypes.Credit, error) {
+	var recipients []common.Address
+	seen := make(map[common.Address]bool)
+	for _, claim := range claims {
+		for _, addr := range []common.Address{claim.Claimant, claim.CounteredBy} {
+			if addr != (common.Address{}) && !seen[addr] {
+				seen[addr] = true
+				recipients = append(recipients, addr)
+			}
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+	amounts, err := caller.GetCredits(ctx, batching.BlockByHash(block.Hash), recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if len(amounts) != len(recipients) {
+		return nil, fmt.Errorf("expected %v credits but got %v", len(recipients), len(amounts))
+	}
+	delayed, isDelayed := caller.(DelayedCreditCaller)
+	var credits []monTypes.Credit
+	for i, amount := range amounts {
+		if amount.Sign() == 0 {
 			continue
 		}
-		enrichedGames = append(enrichedGames, &monTypes.EnrichedGameData{
-			GameMetadata:  game,
-			L2BlockNumber: l2BlockNum,
-			RootClaim:     rootClaim,
-			Status:        status,
-			Duration:      duration,
-			Claims:        claims,
-		})
+		credit := monTypes.Credit{Recipient: recipients[i], Amount: amount, Delayed: isDelayed}
+		if isDelayed {
+			credit.UnlockTime, err = delayed.GetCreditUnlockTime(ctx, recipients[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch credit unlock time: %w", err)
+			}
+		}
+		credits = append(credits, credit)
 	}
-	return enrichedGames
+	return credits, nil
 }
@@ -3,34 +3,55 @@ package extract
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
-var mockRootClaim = common.HexToHash("0x1234")
+var (
+	mockRootClaim = common.HexToHash("0x1234")
+	honestActor   = common.Address{0xaa}
+)
 
 func TestExtractor_Extract(t *testing.T) {
 	t.Run("FetchGamesError", func(t *testing.T) {
-		extractor, _, games, _ := setupExtractorTest(t)
+		extractor, _, games, _, _ := setupExtractorTest(t)
 		games.err = errors.New("boom")
-		_, err := extractor.Extract(context.Background(), common.Hash{}, 0)
+		_, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
 		require.ErrorIs(t, err, games.err)
 		require.Equal(t, 1, games.calls)
 	})
 
+	t.Run("FetchGamesAtBlock", func(t *testing.T) {
+		extractor, _, games, _, _ := setupExtractorTest(t)
+		block := eth.BlockID{Hash: common.Hash{0xaa}, Number: 42}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Equal(t, block.Hash, games.blockHash)
+	})
+
 	t.Run("CreateGameErrorLog", func(t *testing.T) {
-		extractor, creator, games, logs := setupExtractorTest(t)
+		extractor, creator, games, logs, _ := setupExtractorTest(t)
 		games.games = []gameTypes.GameMetadata{{}}
 		creator.err = errors.New("boom")
-		enriched, err := extractor.Extract(context.Background(), common.Hash{}, 0)
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
 		require.NoError(t, err)
 		require.Len(t, enriched, 0)
 		require.Equal(t, 1, games.calls)
@@ -41,10 +62,10 @@ func TestExtractor_Extract(t *testing.T) {
 	})
 
 	t.Run("MetadataFetchErrorLog", func(t *testing.T) {
-		extractor, creator, games, logs := setupExtractorTest(t)
+		extractor, creator, games, logs, _ := setupExtractorTest(t)
 		games.games = []gameTypes.GameMetadata{{}}
 		creator.caller.metadataErr = errors.New("boom")
-		enriched, err := extractor.Extract(context.Background(), common.Hash{}, 0)
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
 		require.NoError(t, err)
 		require.Len(t, enriched, 0)
 		require.Equal(t, 1, games.calls)
@@ -54,11 +75,34 @@ func TestExtractor_Extract(t *testing.T) {
 		verifyLogs(t, logs, 0, 1, 0, 0)
 	})
 
+	t.Run("L1HeadFetchError", func(t *testing.T) {
+		extractor, creator, games, logs, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.caller.l1HeadErr = errors.New("boom")
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 0)
+		require.Equal(t, 1, metrics.failedGames)
+		require.Equal(t, 0, creator.caller.claimsCalls)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("failed to fetch game l1 head"))
+		require.NotNil(t, l)
+	})
+
+	t.Run("FailedGamesCounted", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}, {}, {}}
+		creator.caller.claimsErr = errors.New("boom")
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 0)
+		require.Equal(t, 3, metrics.failedGames)
+	})
+
 	t.Run("ClaimsFetchErrorLog", func(t *testing.T) {
-		extractor, creator, games, logs := setupExtractorTest(t)
+		extractor, creator, games, logs, _ := setupExtractorTest(t)
 		games.games = []gameTypes.GameMetadata{{}}
 		creator.caller.claimsErr = errors.New("boom")
-		enriched, err := extractor.Extract(context.Background(), common.Hash{}, 0)
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
 		require.NoError(t, err)
 		require.Len(t, enriched, 0)
 		require.Equal(t, 1, games.calls)
@@ -69,18 +113,423 @@ func TestExtractor_Extract(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		extractor, creator, games, _ := setupExtractorTest(t)
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
 		games.games = []gameTypes.GameMetadata{{}}
-		enriched, err := extractor.Extract(context.Background(), common.Hash{}, 0)
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
 		require.NoError(t, err)
 		require.Len(t, enriched, 1)
 		require.Equal(t, 1, games.calls)
 		require.Equal(t, 1, creator.calls)
 		require.Equal(t, 1, creator.caller.metadataCalls)
 		require.Equal(t, 1, creator.caller.claimsCalls)
+		require.Equal(t, 0, metrics.failedGames)
+		require.Equal(t, creator.caller.l1Head, enriched[0].L1Head)
+	})
+
+	t.Run("NoCreditsForInProgressGames", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.caller.claims = []faultTypes.Claim{{Claimant: common.Address{0x01}}}
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Equal(t, 0, creator.caller.creditCalls)
+		require.Nil(t, enriched[0].Credits)
+	})
+
+	t.Run("AttributesHonestStakes", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.caller.claims = []faultTypes.Claim{
+			{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(10)}, Claimant: honestActor},
+			{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(20)}, Claimant: common.Address{0x01}},
+			{ClaimData: faultTypes.ClaimData{Bond: monTypes.ResolvedBondAmount}, Claimant: honestActor},
+			{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(5)}, Claimant: honestActor},
+		}
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Equal(t, map[common.Address]monTypes.ActorStake{
+			honestActor: {Claims: 2, Bonded: big.NewInt(15)},
+		}, enriched[0].HonestStakes)
+	})
+
+	t.Run("NoStakesWithoutHonestClaims", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.caller.claims = []faultTypes.Claim{
+			{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(20)}, Claimant: common.Address{0x01}},
+			{ClaimData: faultTypes.ClaimData{Bond: monTypes.ResolvedBondAmount}, Claimant: honestActor},
+		}
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Nil(t, enriched[0].HonestStakes)
+	})
+
+	t.Run("LoadsCreditsForResolvedGames", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		alice, bob, carol := common.Address{0x01}, common.Address{0x02}, common.Address{0x03}
+		creator.caller.status = types.GameStatusChallengerWon
+		creator.caller.claims = []faultTypes.Claim{
+			{Claimant: alice, CounteredBy: bob},
+			{Claimant: bob},
+			{Claimant: alice, CounteredBy: carol},
+		}
+		creator.caller.credits = map[common.Address]*big.Int{alice: big.NewInt(5), carol: big.NewInt(7)}
+		block := eth.BlockID{Hash: common.Hash{0xaa}, Number: 42}
+		enriched, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Equal(t, []common.Address{alice, bob, carol}, creator.caller.recipients)
+		require.Equal(t, batching.BlockByHash(block.Hash), creator.caller.creditBlock)
+		require.Equal(t, []monTypes.Credit{
+			{Recipient: alice, Amount: big.NewInt(5)},
+			{Recipient: carol, Amount: big.NewInt(7)},
+		}, enriched[0].Credits, "should exclude recipients with no credit")
+	})
+
+	t.Run("LoadsDelayedCreditUnlockTimes", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		alice, bob := common.Address{0x01}, common.Address{0x02}
+		unlockTime := time.Unix(5000, 0)
+		creator.caller.status = types.GameStatusDefenderWon
+		creator.caller.claims = []faultTypes.Claim{{Claimant: alice}, {Claimant: bob}}
+		creator.caller.credits = map[common.Address]*big.Int{alice: big.NewInt(5), bob: big.NewInt(7)}
+		creator.delayed = &mockDelayedGameCaller{
+			mockGameCaller: creator.caller,
+			unlockTimes:    map[common.Address]time.Time{alice: unlockTime},
+		}
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Equal(t, []monTypes.Credit{
+			{Recipient: alice, Amount: big.NewInt(5), Delayed: true, UnlockTime: unlockTime},
+			{Recipient: bob, Amount: big.NewInt(7), Delayed: true},
+		}, enriched[0].Credits)
+	})
+
+	t.Run("CreditsFetchErrorLog", func(t *testing.T) {
+		extractor, creator, games, logs, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.caller.status = types.GameStatusDefenderWon
+		creator.caller.claims = []faultTypes.Claim{{Claimant: common.Address{0x01}}}
+		creator.caller.creditsErr = errors.New("boom")
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 0)
+		require.Equal(t, 1, metrics.failedGames)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("failed to fetch game credits"))
+		require.NotNil(t, l)
+	})
+
+	t.Run("UnlockTimeFetchError", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.caller.status = types.GameStatusDefenderWon
+		creator.caller.claims = []faultTypes.Claim{{Claimant: common.Address{0x01}}}
+		creator.caller.credits = map[common.Address]*big.Int{{0x01}: big.NewInt(5)}
+		creator.delayed = &mockDelayedGameCaller{mockGameCaller: creator.caller, unlockErr: errors.New("boom")}
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 0)
+		require.Equal(t, 1, metrics.failedGames)
+	})
+
+	t.Run("PreservesOrder", func(t *testing.T) {
+		games := make([]gameTypes.GameMetadata, 50)
+		for i := range games {
+			games[i] = gameTypes.GameMetadata{Proxy: common.Address{byte(i)}, Timestamp: uint64(i)}
+		}
+		failing := map[common.Address]bool{games[3].Proxy: true, games[17].Proxy: true}
+		for i := 0; i < 5; i++ {
+			loader := &delayedGameLoader{maxDelay: time.Millisecond, failing: failing}
+			metrics := &mockExtractorMetrics{}
+			extractor := NewExtractor(testlog.Logger(t, log.LvlInfo), clock.NewDeterministicClock(time.Unix(0, 0)), metrics, loader.CreateGameCaller, (&mockGameFetcher{games: games}).FetchGames, nil, nil, 8, 0)
+			enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+			require.NoError(t, err)
+			require.Len(t, enriched, len(games)-len(failing))
+			require.Equal(t, len(failing), metrics.failedGames)
+			expected := make([]gameTypes.GameMetadata, 0, len(games))
+			for _, game := range games {
+				if !failing[game.Proxy] {
+					expected = append(expected, game)
+				}
+			}
+			for j, game := range enriched {
+				require.Equal(t, expected[j], game.GameMetadata)
+				require.Equal(t, game.Timestamp, game.L2BlockNumber, "should enrich with the game's own data")
+			}
+		}
+	})
+
+	t.Run("LimitsConcurrency", func(t *testing.T) {
+		games := make([]gameTypes.GameMetadata, 20)
+		loader := &delayedGameLoader{maxDelay: time.Millisecond}
+		extractor := NewExtractor(testlog.Logger(t, log.LvlInfo), clock.NewDeterministicClock(time.Unix(0, 0)), &mockExtractorMetrics{}, loader.CreateGameCaller, (&mockGameFetcher{games: games}).FetchGames, nil, nil, 3, 0)
+		enriched, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, len(games))
+		require.LessOrEqual(t, loader.maxActive, 3)
 	})
 }
 
+func TestExtractor_Cache(t *testing.T) {
+	game := gameTypes.GameMetadata{Proxy: common.Address{0x01}}
+	block := eth.BlockID{Hash: common.Hash{0xaa}, Number: 42}
+
+	t.Run("QuietGameServedFromCache", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		creator.caller.claims = []faultTypes.Claim{{Claimant: honestActor, ClaimData: faultTypes.ClaimData{Bond: big.NewInt(5)}}}
+		first, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		second, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+		require.Equal(t, 1, creator.caller.metadataCalls)
+		require.Equal(t, 1, creator.caller.claimsCalls)
+		require.Equal(t, 1, creator.caller.statusCalls)
+		require.Equal(t, 1, metrics.cacheHits)
+		require.Equal(t, 1, metrics.fullEnrichments)
+	})
+
+	t.Run("ChangedGameRefreshed", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		creator.caller.claims = []faultTypes.Claim{{Claimant: common.Address{0x02}}}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+
+		creator.caller.claims = append(creator.caller.claims, faultTypes.Claim{Claimant: common.Address{0x03}})
+		enriched, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Len(t, enriched[0].Claims, 2)
+		require.Equal(t, 2, creator.caller.claimsCalls)
+		require.Equal(t, 0, metrics.cacheHits)
+		require.Equal(t, 2, metrics.fullEnrichments)
+	})
+
+	t.Run("StatusChangeRefreshed", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+
+		creator.caller.status = types.GameStatusDefenderWon
+		enriched, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Equal(t, types.GameStatusDefenderWon, enriched[0].Status)
+		require.Equal(t, 2, creator.caller.metadataCalls)
+	})
+
+	t.Run("StatusFetchError", func(t *testing.T) {
+		extractor, creator, games, logs, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+
+		creator.caller.statusErr = errors.New("boom")
+		enriched, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Empty(t, enriched)
+		require.Equal(t, 1, metrics.failedGames)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("failed to fetch game status"))
+		require.NotNil(t, l)
+	})
+
+	t.Run("ResolvedGameRefreshedAfterInterval", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		cl := extractor.clock.(*clock.DeterministicClock)
+		games.games = []gameTypes.GameMetadata{game}
+		creator.caller.status = types.GameStatusChallengerWon
+		creator.caller.claims = []faultTypes.Claim{{Claimant: common.Address{0x02}}}
+		creator.caller.credits = map[common.Address]*big.Int{{0x02}: big.NewInt(5)}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+
+		// Credit is claimed but the cached game is still used
+		creator.caller.credits = nil
+		cl.AdvanceTime(extractor.resolvedRefresh - time.Second)
+		enriched, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched[0].Credits, 1)
+		require.Equal(t, 0, creator.caller.statusCalls, "should not check resolved games for changes")
+		require.Equal(t, 1, creator.caller.creditCalls)
+
+		cl.AdvanceTime(time.Second)
+		enriched, err = extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Empty(t, enriched[0].Credits)
+		require.Equal(t, 2, creator.caller.creditCalls)
+	})
+
+	t.Run("ResolvableClaimRefreshed", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		cl := extractor.clock.(*clock.DeterministicClock)
+		games.games = []gameTypes.GameMetadata{game}
+		creator.caller.duration = 960
+		expired := faultTypes.NewClock(480, uint64(cl.Now().Add(-time.Minute).Unix()))
+		inProgress := faultTypes.NewClock(0, uint64(cl.Now().Unix()))
+		creator.caller.claims = []faultTypes.Claim{
+			{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(5)}, Clock: expired},
+			{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(5)}, Clock: inProgress},
+		}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+
+		// The claim is resolved without changing the claim count
+		creator.caller.claims = []faultTypes.Claim{
+			{ClaimData: faultTypes.ClaimData{Bond: monTypes.ResolvedBondAmount}, Clock: expired},
+			{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(5)}, Clock: inProgress},
+		}
+		enriched, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Equal(t, monTypes.ResolvedBondAmount, enriched[0].Claims[0].Bond)
+		require.Equal(t, 2, creator.caller.claimsCalls)
+		require.Equal(t, 0, creator.caller.statusCalls, "should reload without checking the claim count")
+
+		// No claims can be resolved until the remaining claim's clock expires
+		enriched, err = extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Len(t, enriched, 1)
+		require.Equal(t, 2, creator.caller.claimsCalls)
+		require.Equal(t, 1, metrics.cacheHits)
+	})
+
+	t.Run("CachedDataNotModifiedByCaller", func(t *testing.T) {
+		extractor, _, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		first, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		first[0].InCreationWindow = true
+		second, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.False(t, second[0].InCreationWindow)
+	})
+
+	t.Run("EvictsGamesOutsideWindow", func(t *testing.T) {
+		extractor, creator, games, _, _ := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		games.games = nil
+		_, err = extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		games.games = []gameTypes.GameMetadata{game}
+		_, err = extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, creator.caller.metadataCalls)
+	})
+
+	t.Run("ReorgClearsCache", func(t *testing.T) {
+		extractor, creator, games, logs, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+
+		extractor.blockHash = func(ctx context.Context, number uint64) (common.Hash, error) {
+			require.Equal(t, block.Number, number)
+			return common.Hash{0xbb}, nil
+		}
+		_, err = extractor.Extract(context.Background(), eth.BlockID{Hash: common.Hash{0xcc}, Number: 43}, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, creator.caller.metadataCalls)
+		require.Equal(t, 0, metrics.cacheHits)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("Previous block reorged out, cleared game cache"))
+		require.NotNil(t, l)
+	})
+
+	t.Run("CanonicalBlockKeepsCache", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{game}
+		_, err := extractor.Extract(context.Background(), block, 0)
+		require.NoError(t, err)
+
+		extractor.blockHash = func(ctx context.Context, number uint64) (common.Hash, error) {
+			return block.Hash, nil
+		}
+		_, err = extractor.Extract(context.Background(), eth.BlockID{Hash: common.Hash{0xcc}, Number: 43}, 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, creator.caller.metadataCalls)
+		require.Equal(t, 1, metrics.cacheHits)
+	})
+}
+
+func BenchmarkExtract(b *testing.B) {
+	games := make([]gameTypes.GameMetadata, 100)
+	for _, workers := range []uint{1, 4, 16} {
+		workers := workers
+		b.Run(fmt.Sprintf("Workers%d", workers), func(b *testing.B) {
+			loader := &delayedGameLoader{delay: time.Millisecond}
+			extractor := NewExtractor(log.NewLogger(log.DiscardHandler()), clock.SystemClock, &mockExtractorMetrics{}, loader.CreateGameCaller, (&mockGameFetcher{games: games}).FetchGames, nil, nil, workers, 0)
+			for i := 0; i < b.N; i++ {
+				_, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func TestExtractor_EnrichmentMetrics(t *testing.T) {
+	t.Run("RecordsDurationBySource", func(t *testing.T) {
+		extractor, _, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{Proxy: common.Address{0x01}}, {Proxy: common.Address{0x02}}}
+		_, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, map[bool]int{false: 2}, metrics.durations)
+
+		_, err = extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, map[bool]int{false: 2, true: 2}, metrics.durations)
+	})
+
+	t.Run("NoDurationForFailedGames", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.caller.claimsErr = errors.New("boom")
+		_, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Empty(t, metrics.durations)
+	})
+
+	t.Run("CreateCallerFailure", func(t *testing.T) {
+		extractor, creator, games, _, metrics := setupExtractorTest(t)
+		games.games = []gameTypes.GameMetadata{{}}
+		creator.err = errors.New("boom")
+		_, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{failureCreateCaller: 1}, metrics.failures)
+	})
+
+	tests := []struct {
+		name  string
+		err   error
+		class string
+	}{
+		{name: "Timeout", err: fmt.Errorf("load: %w", context.DeadlineExceeded), class: failureTimeout},
+		{name: "Canceled", err: context.Canceled, class: failureCanceled},
+		{name: "RPC", err: fmt.Errorf("call: %w", &stubRPCError{code: -32000}), class: failureRPC},
+		{name: "HTTP", err: rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, class: failureHTTP},
+		{name: "Other", err: errors.New("boom"), class: failureOther},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			extractor, creator, games, _, metrics := setupExtractorTest(t)
+			games.games = []gameTypes.GameMetadata{{Proxy: common.Address{0x01}}, {Proxy: common.Address{0x02}}}
+			creator.caller.metadataErr = test.err
+			_, err := extractor.Extract(context.Background(), eth.BlockID{}, 0)
+			require.NoError(t, err)
+			require.Equal(t, map[string]int{test.class: 2}, metrics.failures)
+		})
+	}
+}
+
 func verifyLogs(t *testing.T, logs *testlog.CapturingHandler, createErr int, metadataErr int, claimsErr int, durationErr int) {
 	errorLevelFilter := testlog.NewLevelFilter(log.LevelError)
 	createMessageFilter := testlog.NewMessageFilter("failed to create game caller")
@@ -97,29 +546,92 @@ func verifyLogs(t *testing.T, logs *testlog.CapturingHandler, createErr int, met
 	require.Len(t, l, durationErr)
 }
 
-func setupExtractorTest(t *testing.T) (*Extractor, *mockGameCallerCreator, *mockGameFetcher, *testlog.CapturingHandler) {
+func setupExtractorTest(t *testing.T) (*Extractor, *mockGameCallerCreator, *mockGameFetcher, *testlog.CapturingHandler, *mockExtractorMetrics) {
 	logger, capturedLogs := testlog.CaptureLogger(t, log.LvlDebug)
 	games := &mockGameFetcher{}
-	caller := &mockGameCaller{rootClaim: mockRootClaim}
+	caller := &mockGameCaller{rootClaim: mockRootClaim, l1Head: common.Hash{0x11}}
 	creator := &mockGameCallerCreator{caller: caller}
+	metrics := &mockExtractorMetrics{}
 	return NewExtractor(
 			logger,
+			clock.NewDeterministicClock(time.Unix(1_000_000, 0)),
+			metrics,
 			creator.CreateGameCaller,
 			games.FetchGames,
+			func(ctx context.Context, number uint64) (common.Hash, error) {
+				return common.Hash{}, errors.New("unexpected block hash request")
+			},
+			[]common.Address{honestActor},
+			4,
+			time.Hour,
 		),
 		creator,
 		games,
-		capturedLogs
+		capturedLogs,
+		metrics
+}
+
+type stubRPCError struct {
+	code int
+}
+
+func (e *stubRPCError) Error() string {
+	return fmt.Sprintf("rpc error %d", e.code)
+}
+
+func (e *stubRPCError) ErrorCode() int {
+	return e.code
+}
+
+type mockExtractorMetrics struct {
+	failedGames     int
+	cacheHits       int
+	fullEnrichments int
+
+	lock        sync.Mutex
+	durations   map[bool]int
+	failures    map[string]int
+	maxDuration time.Duration
+}
+
+func (m *mockExtractorMetrics) RecordFailedGames(count int) {
+	m.failedGames += count
+}
+
+func (m *mockExtractorMetrics) RecordGameEnrichments(cacheHits int, fullEnrichments int) {
+	m.cacheHits += cacheHits
+	m.fullEnrichments += fullEnrichments
+}
+
+func (m *mockExtractorMetrics) RecordGameEnrichmentDuration(cached bool, duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.durations == nil {
+		m.durations = make(map[bool]int)
+	}
+	m.durations[cached]++
+	m.maxDuration = max(m.maxDuration, duration)
+}
+
+func (m *mockExtractorMetrics) RecordGameEnrichmentFailure(class string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.failures == nil {
+		m.failures = make(map[string]int)
+	}
+	m.failures[class]++
 }
 
 type mockGameFetcher struct {
-	calls int
-	err   error
-	games []gameTypes.GameMetadata
+	calls     int
+	err       error
+	games     []gameTypes.GameMetadata
+	blockHash common.Hash
 }
 
-func (m *mockGameFetcher) FetchGames(_ context.Context, _ common.Hash, _ uint64) ([]gameTypes.GameMetadata, error) {
+func (m *mockGameFetcher) FetchGames(_ context.Context, blockHash common.Hash, _ uint64) ([]gameTypes.GameMetadata, error) {
 	m.calls++
+	m.blockHash = blockHash
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -127,40 +639,179 @@ func (m *mockGameFetcher) FetchGames(_ context.Context, _ common.Hash, _ uint64)
 }
 
 type mockGameCallerCreator struct {
+	lock   sync.Mutex
 	calls  int
 	err    error
 	caller *mockGameCaller
+	// delayed is returned instead of caller if set
+	delayed *mockDelayedGameCaller
 }
 
 func (m *mockGameCallerCreator) CreateGameCaller(_ gameTypes.GameMetadata) (GameCaller, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	m.calls++
 	if m.err != nil {
 		return nil, m.err
 	}
+	if m.delayed != nil {
+		return m.delayed, nil
+	}
 	return m.caller, nil
 }
 
 type mockGameCaller struct {
+	lock          sync.Mutex
 	metadataCalls int
 	metadataErr   error
 	claimsCalls   int
 	claimsErr     error
+	creditCalls   int
+	creditsErr    error
+	statusCalls   int
+	statusErr     error
+	l1HeadCalls   int
+	l1HeadErr     error
+	l1Head        common.Hash
 	rootClaim     common.Hash
+	status        types.GameStatus
+	duration      uint64
 	claims        []faultTypes.Claim
+	credits       map[common.Address]*big.Int
+	creditBlock   batching.Block
+	recipients    []common.Address
 }
 
 func (m *mockGameCaller) GetGameMetadata(_ context.Context) (uint64, common.Hash, types.GameStatus, uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	m.metadataCalls++
 	if m.metadataErr != nil {
 		return 0, common.Hash{}, 0, 0, m.metadataErr
 	}
-	return 0, mockRootClaim, 0, 0, nil
+	return 0, mockRootClaim, m.status, m.duration, nil
+}
+
+func (m *mockGameCaller) GetL1Head(_ context.Context) (common.Hash, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.l1HeadCalls++
+	if m.l1HeadErr != nil {
+		return common.Hash{}, m.l1HeadErr
+	}
+	return m.l1Head, nil
 }
 
 func (m *mockGameCaller) GetAllClaims(ctx context.Context) ([]faultTypes.Claim, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	m.claimsCalls++
 	if m.claimsErr != nil {
 		return nil, m.claimsErr
 	}
 	return m.claims, nil
 }
+
+func (m *mockGameCaller) GetStatusAndClaimCount(_ context.Context) (types.GameStatus, uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.statusCalls++
+	if m.statusErr != nil {
+		return 0, 0, m.statusErr
+	}
+	return m.status, uint64(len(m.claims)), nil
+}
+
+func (m *mockGameCaller) GetCredits(_ context.Context, block batching.Block, recipients ...common.Address) ([]*big.Int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.creditCalls++
+	m.creditBlock = block
+	m.recipients = recipients
+	if m.creditsErr != nil {
+		return nil, m.creditsErr
+	}
+	credits := make([]*big.Int, 0, len(recipients))
+	for _, recipient := range recipients {
+		credit, ok := m.credits[recipient]
+		if !ok {
+			credit = big.NewInt(0)
+		}
+		credits = append(credits, credit)
+	}
+	return credits, nil
+}
+
+// mockDelayedGameCaller is a mockGameCaller for a game that holds its bonds in DelayedWETH.
+type mockDelayedGameCaller struct {
+	*mockGameCaller
+	unlockTimes map[common.Address]time.Time
+	unlockErr   error
+}
+
+func (m *mockDelayedGameCaller) GetCreditUnlockTime(_ context.Context, recipient common.Address) (time.Time, error) {
+	if m.unlockErr != nil {
+		return time.Time{}, m.unlockErr
+	}
+	return m.unlockTimes[recipient], nil
+}
+
+// delayedGameLoader creates game callers that take time to respond, so games complete out of order.
+type delayedGameLoader struct {
+	delay    time.Duration
+	maxDelay time.Duration
+	failing  map[common.Address]bool
+
+	lock      sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (l *delayedGameLoader) CreateGameCaller(game gameTypes.GameMetadata) (GameCaller, error) {
+	return &delayedGameCaller{loader: l, game: game}, nil
+}
+
+func (l *delayedGameLoader) wait() {
+	l.lock.Lock()
+	l.active++
+	l.maxActive = max(l.maxActive, l.active)
+	delay := l.delay
+	if l.maxDelay > 0 {
+		delay = time.Duration(rand.Int63n(int64(l.maxDelay)))
+	}
+	l.lock.Unlock()
+	time.Sleep(delay)
+	l.lock.Lock()
+	l.active--
+	l.lock.Unlock()
+}
+
+type delayedGameCaller struct {
+	loader *delayedGameLoader
+	game   gameTypes.GameMetadata
+}
+
+func (c *delayedGameCaller) GetGameMetadata(_ context.Context) (uint64, common.Hash, types.GameStatus, uint64, error) {
+	c.loader.wait()
+	if c.loader.failing[c.game.Proxy] {
+		return 0, common.Hash{}, 0, 0, errors.New("boom")
+	}
+	return c.game.Timestamp, mockRootClaim, 0, 0, nil
+}
+
+func (c *delayedGameCaller) GetL1Head(_ context.Context) (common.Hash, error) {
+	return common.Hash{}, nil
+}
+
+func (c *delayedGameCaller) GetAllClaims(_ context.Context) ([]faultTypes.Claim, error) {
+	c.loader.wait()
+	return nil, nil
+}
+
+func (c *delayedGameCaller) GetStatusAndClaimCount(_ context.Context) (types.GameStatus, uint64, error) {
+	return 0, 0, nil
+}
+
+func (c *delayedGameCaller) GetCredits(_ context.Context, _ batching.Block, _ ...common.Address) ([]*big.Int, error) {
+	return nil, nil
+}
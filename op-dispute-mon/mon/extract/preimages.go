@@ -0,0 +1,87 @@
+package extract
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type PreimageOracle interface {
+	Addr() common.Address
+	GetActivePreimages(ctx context.Context, blockHash common.Hash) ([]keccakTypes.LargePreimageMetaData, error)
+	ChallengePeriod(ctx context.Context) (uint64, error)
+}
+
+// OracleLoader returns the preimage oracle used by games of gameType.
+type OracleLoader func(ctx context.Context, gameType uint32) (PreimageOracle, error)
+
+type PreimageExtractor struct {
+	logger     log.Logger
+	loadOracle OracleLoader
+}
+
+func NewPreimageExtractor(logger log.Logger, loadOracle OracleLoader) *PreimageExtractor {
+	return &PreimageExtractor{
+		logger:     logger,
+		loadOracle: loadOracle,
+	}
+}
+
+// Extract loads the large preimage proposals as of block from the preimage oracles used by the game types of games.
+// Each oracle is only read once, even if it is used by multiple game types.
+func (e *PreimageExtractor) Extract(ctx context.Context, block eth.BlockID, games []*monTypes.EnrichedGameData) ([]monTypes.LargePreimageProposal, error) {
+	oracles, err := e.loadOracles(ctx, games)
+	if err != nil {
+		return nil, err
+	}
+	var proposals []monTypes.LargePreimageProposal
+	for _, oracle := range oracles {
+		period, err := oracle.ChallengePeriod(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load challenge period of oracle %v: %w", oracle.Addr(), err)
+		}
+		preimages, err := oracle.GetActivePreimages(ctx, block.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load large preimages of oracle %v: %w", oracle.Addr(), err)
+		}
+		e.logger.Debug("Loaded large preimage proposals", "oracle", oracle.Addr(), "block", block, "count", len(preimages))
+		for _, preimage := range preimages {
+			proposals = append(proposals, monTypes.LargePreimageProposal{
+				LargePreimageMetaData: preimage,
+				Oracle:                oracle.Addr(),
+				ChallengePeriod:       time.Duration(period) * time.Second,
+			})
+		}
+	}
+	return proposals, nil
+}
+
+// loadOracles returns the distinct oracles used by the game types of games, in the order the game types first appear.
+func (e *PreimageExtractor) loadOracles(ctx context.Context, games []*monTypes.EnrichedGameData) ([]PreimageOracle, error) {
+	seenTypes := make(map[uint32]bool)
+	seenOracles := make(map[common.Address]bool)
+	var oracles []PreimageOracle
+	for _, game := range games {
+		if seenTypes[game.GameType] {
+			continue
+		}
+		seenTypes[game.GameType] = true
+		oracle, err := e.loadOracle(ctx, game.GameType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load oracle for game type %v: %w", game.GameType, err)
+		}
+		if seenOracles[oracle.Addr()] {
+			continue
+		}
+		seenOracles[oracle.Addr()] = true
+		oracles = append(oracles, oracle)
+	}
+	return oracles, nil
+}
@@ -0,0 +1,164 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestPreimageExtractor_Extract(t *testing.T) {
+	block := eth.BlockID{Hash: common.Hash{0xaa}, Number: 42}
+	proposal1 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0x01}, UUID: big.NewInt(1)},
+		Timestamp:          1000,
+		ClaimedSize:        500,
+	}
+	proposal2 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0x02}, UUID: big.NewInt(2)},
+		Countered:          true,
+	}
+	proposal3 := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0x03}, UUID: big.NewInt(3)},
+	}
+
+	t.Run("NoGames", func(t *testing.T) {
+		extractor, oracles := setupPreimageExtractorTest(t)
+		proposals, err := extractor.Extract(context.Background(), block, nil)
+		require.NoError(t, err)
+		require.Empty(t, proposals)
+		require.Zero(t, oracles.calls)
+	})
+
+	t.Run("LoadsProposalsFromEachOracle", func(t *testing.T) {
+		extractor, oracles := setupPreimageExtractorTest(t)
+		oracleA := &stubPreimageOracle{addr: common.Address{0xa0}, period: 60, proposals: []keccakTypes.LargePreimageMetaData{proposal1, proposal2}}
+		oracleB := &stubPreimageOracle{addr: common.Address{0xb0}, period: 120, proposals: []keccakTypes.LargePreimageMetaData{proposal3}}
+		oracles.oracles = map[uint32]*stubPreimageOracle{0: oracleA, 1: oracleB}
+		proposals, err := extractor.Extract(context.Background(), block, preimageGames(0, 1))
+		require.NoError(t, err)
+		require.Equal(t, []monTypes.LargePreimageProposal{
+			{LargePreimageMetaData: proposal1, Oracle: oracleA.addr, ChallengePeriod: time.Minute},
+			{LargePreimageMetaData: proposal2, Oracle: oracleA.addr, ChallengePeriod: time.Minute},
+			{LargePreimageMetaData: proposal3, Oracle: oracleB.addr, ChallengePeriod: 2 * time.Minute},
+		}, proposals)
+		require.Equal(t, block.Hash, oracleA.blockHash)
+		require.Equal(t, block.Hash, oracleB.blockHash)
+	})
+
+	t.Run("LoadsEachGameTypeOnce", func(t *testing.T) {
+		extractor, oracles := setupPreimageExtractorTest(t)
+		oracle := &stubPreimageOracle{addr: common.Address{0xa0}, proposals: []keccakTypes.LargePreimageMetaData{proposal1}}
+		oracles.oracles = map[uint32]*stubPreimageOracle{0: oracle}
+		proposals, err := extractor.Extract(context.Background(), block, preimageGames(0, 0, 0))
+		require.NoError(t, err)
+		require.Len(t, proposals, 1)
+		require.Equal(t, 1, oracles.calls)
+	})
+
+	t.Run("ReadsSharedOracleOnce", func(t *testing.T) {
+		extractor, oracles := setupPreimageExtractorTest(t)
+		oracle := &stubPreimageOracle{addr: common.Address{0xa0}, proposals: []keccakTypes.LargePreimageMetaData{proposal1}}
+		oracles.oracles = map[uint32]*stubPreimageOracle{0: oracle, 1: oracle}
+		proposals, err := extractor.Extract(context.Background(), block, preimageGames(0, 1))
+		require.NoError(t, err)
+		require.Len(t, proposals, 1)
+		require.Equal(t, 2, oracles.calls)
+		require.Equal(t, 1, oracle.calls)
+	})
+
+	t.Run("LoadOracleError", func(t *testing.T) {
+		extractor, oracles := setupPreimageExtractorTest(t)
+		oracles.err = errors.New("boom")
+		_, err := extractor.Extract(context.Background(), block, preimageGames(0))
+		require.ErrorIs(t, err, oracles.err)
+	})
+
+	t.Run("ChallengePeriodError", func(t *testing.T) {
+		extractor, oracles := setupPreimageExtractorTest(t)
+		oracle := &stubPreimageOracle{addr: common.Address{0xa0}, periodErr: errors.New("boom")}
+		oracles.oracles = map[uint32]*stubPreimageOracle{0: oracle}
+		_, err := extractor.Extract(context.Background(), block, preimageGames(0))
+		require.ErrorIs(t, err, oracle.periodErr)
+	})
+
+	t.Run("ProposalsError", func(t *testing.T) {
+		extractor, oracles := setupPreimageExtractorTest(t)
+		oracle := &stubPreimageOracle{addr: common.Address{0xa0}, proposalsErr: errors.New("boom")}
+		oracles.oracles = map[uint32]*stubPreimageOracle{0: oracle}
+		_, err := extractor.Extract(context.Background(), block, preimageGames(0))
+		require.ErrorIs(t, err, oracle.proposalsErr)
+	})
+}
+
+func setupPreimageExtractorTest(t *testing.T) (*PreimageExtractor, *stubOracleLoader) {
+	oracles := &stubOracleLoader{}
+	return NewPreimageExtractor(testlog.Logger(t, log.LvlDebug), oracles.LoadOracle), oracles
+}
+
+func preimageGames(types ...uint32) []*monTypes.EnrichedGameData {
+	games := make([]*monTypes.EnrichedGameData, 0, len(types))
+	for _, gameType := range types {
+		games = append(games, &monTypes.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{GameType: gameType}})
+	}
+	return games
+}
+
+type stubOracleLoader struct {
+	calls   int
+	err     error
+	oracles map[uint32]*stubPreimageOracle
+}
+
+func (s *stubOracleLoader) LoadOracle(_ context.Context, gameType uint32) (PreimageOracle, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	oracle, ok := s.oracles[gameType]
+	if !ok {
+		return nil, errors.New("unknown game type")
+	}
+	return oracle, nil
+}
+
+type stubPreimageOracle struct {
+	addr         common.Address
+	calls        int
+	blockHash    common.Hash
+	period       uint64
+	periodErr    error
+	proposals    []keccakTypes.LargePreimageMetaData
+	proposalsErr error
+}
+
+func (s *stubPreimageOracle) Addr() common.Address {
+	return s.addr
+}
+
+func (s *stubPreimageOracle) GetActivePreimages(_ context.Context, blockHash common.Hash) ([]keccakTypes.LargePreimageMetaData, error) {
+	s.calls++
+	s.blockHash = blockHash
+	if s.proposalsErr != nil {
+		return nil, s.proposalsErr
+	}
+	return s.proposals, nil
+}
+
+func (s *stubPreimageOracle) ChallengePeriod(_ context.Context) (uint64, error) {
+	if s.periodErr != nil {
+		return 0, s.periodErr
+	}
+	return s.period, nil
+}
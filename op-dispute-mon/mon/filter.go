@@ -0,0 +1,159 @@
+package mon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+)
+
+// Reasons a game is excluded by the game filter, reported in metrics.
+const (
+	filterReasonIgnored    = "ignored"
+	filterReasonNotAllowed = "not_allowed"
+	filterReasonMinBond    = "min_bond"
+)
+
+var errNegativeMinBond = errors.New("minimum bond must not be negative")
+
+type FilterMetrics interface {
+	RecordFilteredGames(reason string, count int)
+}
+
+// gameFilterRules are the games to exclude from or restrict monitoring to.
+type gameFilterRules struct {
+	Ignore  []common.Address `json:"ignore"`
+	Allow   []common.Address `json:"allow"`
+	MinBond *big.Int         `json:"minBond"`
+}
+
+// gameFilter excludes games from monitoring, such as a cohort of spam games that would otherwise drown out the
+// games that matter. The rules from the filter file are added to those configured at startup, and the file is
+// read again every cycle so the filter can be changed during an incident without restarting the monitor.
+type gameFilter struct {
+	logger   log.Logger
+	metrics  FilterMetrics
+	static   gameFilterRules
+	path     string
+	readFile func(path string) ([]byte, error)
+
+	// raw is the content of the filter file when it was last loaded successfully and file is the rules it contains.
+	// They are kept if the file can't be read or parsed so a bad edit doesn't drop the filter.
+	raw  []byte
+	file gameFilterRules
+}
+
+func newGameFilter(logger log.Logger, metrics FilterMetrics, ignore []common.Address, allow []common.Address, minBond *big.Int, path string) *gameFilter {
+	return &gameFilter{
+		logger:   logger,
+		metrics:  metrics,
+		static:   gameFilterRules{Ignore: ignore, Allow: allow, MinBond: minBond},
+		path:     path,
+		readFile: os.ReadFile,
+	}
+}
+
+// Filter returns the games that pass the filter and records the number excluded for each reason.
+// Ignored games are excluded even if they are also allowed. If any games are allowed, all others are excluded.
+// Games whose root claim bond is below the minimum bond are excluded, but games whose bond is unknown because it
+// has been paid out or the root claim isn't loaded are kept.
+func (f *gameFilter) Filter(games []*types.EnrichedGameData) []*types.EnrichedGameData {
+	f.reload()
+	ignore := addressSet(f.static.Ignore, f.file.Ignore)
+	allow := addressSet(f.static.Allow, f.file.Allow)
+	minBond := maxBond(f.static.MinBond, f.file.MinBond)
+
+	filtered := map[string]int{filterReasonIgnored: 0, filterReasonNotAllowed: 0, filterReasonMinBond: 0}
+	kept := make([]*types.EnrichedGameData, 0, len(games))
+	for _, game := range games {
+		switch {
+		case ignore[game.Proxy]:
+			filtered[filterReasonIgnored]++
+		case len(allow) > 0 && !allow[game.Proxy]:
+			filtered[filterReasonNotAllowed]++
+		case belowMinBond(game, minBond):
+			filtered[filterReasonMinBond]++
+		default:
+			kept = append(kept, game)
+		}
+	}
+	for reason, count := range filtered {
+		f.metrics.RecordFilteredGames(reason, count)
+	}
+	if len(kept) < len(games) {
+		f.logger.Debug("Excluded games from monitoring", "ignored", filtered[filterReasonIgnored],
+			"notAllowed", filtered[filterReasonNotAllowed], "belowMinBond", filtered[filterReasonMinBond])
+	}
+	return kept
+}
+
+// reload loads the filter file if it has changed since it was last loaded.
+func (f *gameFilter) reload() {
+	if f.path == "" {
+		return
+	}
+	data, err := f.readFile(f.path)
+	if err != nil {
+		f.logger.Error("Failed to read game filter file, using previous filter", "path", f.path, "err", err)
+		return
+	}
+	if f.raw != nil && bytes.Equal(data, f.raw) {
+		return
+	}
+	rules, err := parseGameFilterRules(data)
+	if err != nil {
+		f.logger.Error("Failed to parse game filter file, using previous filter", "path", f.path, "err", err)
+		return
+	}
+	f.raw = data
+	f.file = rules
+	f.logger.Info("Loaded game filter file", "path", f.path,
+		"ignore", len(rules.Ignore), "allow", len(rules.Allow), "minBond", rules.MinBond)
+}
+
+func parseGameFilterRules(data []byte) (gameFilterRules, error) {
+	var rules gameFilterRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return gameFilterRules{}, err
+	}
+	if rules.MinBond != nil && rules.MinBond.Sign() < 0 {
+		return gameFilterRules{}, fmt.Errorf("%w: %v", errNegativeMinBond, rules.MinBond)
+	}
+	return rules, nil
+}
+
+func belowMinBond(game *types.EnrichedGameData, minBond *big.Int) bool {
+	if minBond == nil || minBond.Sign() == 0 || len(game.Claims) == 0 {
+		return false
+	}
+	bond := game.Claims[0].Bond
+	if bond == nil || bond.Cmp(types.ResolvedBondAmount) == 0 {
+		return false
+	}
+	return bond.Cmp(minBond) < 0
+}
+
+func addressSet(lists ...[]common.Address) map[common.Address]bool {
+	set := make(map[common.Address]bool)
+	for _, list := range lists {
+		for _, addr := range list {
+			set[addr] = true
+		}
+	}
+	return set
+}
+
+// maxBond returns the larger of a and b, treating nil as no minimum.
+func maxBond(a *big.Int, b *big.Int) *big.Int {
+	if a == nil || (b != nil && b.Cmp(a) > 0) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,193 @@
+package mon
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	filterGameA = common.Address{0xaa}
+	filterGameB = common.Address{0xbb}
+	filterGameC = common.Address{0xcc}
+)
+
+func TestGameFilter_Filter(t *testing.T) {
+	games := []*monTypes.EnrichedGameData{
+		bondedGame(filterGameA, big.NewInt(100)),
+		bondedGame(filterGameB, big.NewInt(200)),
+		bondedGame(filterGameC, big.NewInt(300)),
+	}
+
+	t.Run("NoRules", func(t *testing.T) {
+		filter, m, _ := setupGameFilterTest(t, nil, nil, big.NewInt(0), "")
+		require.Equal(t, games, filter.Filter(games))
+		require.Equal(t, map[string]int{filterReasonIgnored: 0, filterReasonNotAllowed: 0, filterReasonMinBond: 0}, m.filtered)
+	})
+
+	t.Run("Ignore", func(t *testing.T) {
+		filter, m, _ := setupGameFilterTest(t, []common.Address{filterGameB}, nil, big.NewInt(0), "")
+		require.Equal(t, []common.Address{filterGameA, filterGameC}, proxies(filter.Filter(games)))
+		require.Equal(t, 1, m.filtered[filterReasonIgnored])
+	})
+
+	t.Run("Allow", func(t *testing.T) {
+		filter, m, _ := setupGameFilterTest(t, nil, []common.Address{filterGameA, filterGameC}, big.NewInt(0), "")
+		require.Equal(t, []common.Address{filterGameA, filterGameC}, proxies(filter.Filter(games)))
+		require.Equal(t, 1, m.filtered[filterReasonNotAllowed])
+	})
+
+	t.Run("IgnoreWinsOverAllow", func(t *testing.T) {
+		filter, m, _ := setupGameFilterTest(t, []common.Address{filterGameA}, []common.Address{filterGameA, filterGameB}, big.NewInt(0), "")
+		require.Equal(t, []common.Address{filterGameB}, proxies(filter.Filter(games)))
+		require.Equal(t, 1, m.filtered[filterReasonIgnored])
+		require.Equal(t, 1, m.filtered[filterReasonNotAllowed])
+	})
+
+	t.Run("MinBond", func(t *testing.T) {
+		filter, m, _ := setupGameFilterTest(t, nil, nil, big.NewInt(200), "")
+		// Games with a bond equal to the minimum are kept
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+		require.Equal(t, 1, m.filtered[filterReasonMinBond])
+	})
+
+	t.Run("MinBondKeepsUnknownBonds", func(t *testing.T) {
+		filter, m, _ := setupGameFilterTest(t, nil, nil, big.NewInt(200), "")
+		unknown := []*monTypes.EnrichedGameData{
+			{GameMetadata: gameTypes.GameMetadata{Proxy: filterGameA}},
+			bondedGame(filterGameB, monTypes.ResolvedBondAmount),
+		}
+		require.Equal(t, unknown, filter.Filter(unknown))
+		require.Zero(t, m.filtered[filterReasonMinBond])
+	})
+
+	t.Run("EachGameCountedOnce", func(t *testing.T) {
+		filter, m, _ := setupGameFilterTest(t, []common.Address{filterGameA}, []common.Address{filterGameC}, big.NewInt(1000), "")
+		require.Empty(t, filter.Filter(games))
+		require.Equal(t, map[string]int{filterReasonIgnored: 1, filterReasonNotAllowed: 1, filterReasonMinBond: 1}, m.filtered)
+	})
+}
+
+func TestGameFilter_File(t *testing.T) {
+	games := []*monTypes.EnrichedGameData{
+		bondedGame(filterGameA, big.NewInt(100)),
+		bondedGame(filterGameB, big.NewInt(200)),
+		bondedGame(filterGameC, big.NewInt(300)),
+	}
+
+	t.Run("AddsToConfiguredRules", func(t *testing.T) {
+		path := writeFilterFile(t, `{"ignore": ["0xbb00000000000000000000000000000000000000"]}`)
+		filter, m, _ := setupGameFilterTest(t, []common.Address{filterGameA}, nil, big.NewInt(0), path)
+		require.Equal(t, []common.Address{filterGameC}, proxies(filter.Filter(games)))
+		require.Equal(t, 2, m.filtered[filterReasonIgnored])
+	})
+
+	t.Run("LargerMinBondApplies", func(t *testing.T) {
+		path := writeFilterFile(t, `{"minBond": 250}`)
+		filter, _, _ := setupGameFilterTest(t, nil, nil, big.NewInt(150), path)
+		require.Equal(t, []common.Address{filterGameC}, proxies(filter.Filter(games)))
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"minBond": 50}`), 0o644))
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+	})
+
+	t.Run("IgnoreInFileWinsOverConfiguredAllow", func(t *testing.T) {
+		path := writeFilterFile(t, `{"ignore": ["0xaa00000000000000000000000000000000000000"]}`)
+		filter, _, _ := setupGameFilterTest(t, nil, []common.Address{filterGameA, filterGameB}, big.NewInt(0), path)
+		require.Equal(t, []common.Address{filterGameB}, proxies(filter.Filter(games)))
+	})
+
+	t.Run("ReloadedEachCycle", func(t *testing.T) {
+		path := writeFilterFile(t, `{"allow": ["0xaa00000000000000000000000000000000000000"]}`)
+		filter, _, logs := setupGameFilterTest(t, nil, nil, big.NewInt(0), path)
+		require.Equal(t, []common.Address{filterGameA}, proxies(filter.Filter(games)))
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"allow": ["0xcc00000000000000000000000000000000000000"]}`), 0o644))
+		require.Equal(t, []common.Address{filterGameC}, proxies(filter.Filter(games)))
+
+		// Unchanged files are not reported as loaded again
+		filter.Filter(games)
+		require.Len(t, logs.FindLogs(testlog.NewMessageFilter("Loaded game filter file")), 2)
+	})
+
+	t.Run("KeepsPreviousRulesOnInvalidFile", func(t *testing.T) {
+		path := writeFilterFile(t, `{"ignore": ["0xaa00000000000000000000000000000000000000"]}`)
+		filter, _, logs := setupGameFilterTest(t, nil, nil, big.NewInt(0), path)
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"ignore": [`), 0o644))
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageContainsFilter("Failed to parse game filter file")))
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"minBond": -1}`), 0o644))
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+	})
+
+	t.Run("KeepsPreviousRulesOnReadError", func(t *testing.T) {
+		path := writeFilterFile(t, `{"ignore": ["0xaa00000000000000000000000000000000000000"]}`)
+		filter, _, logs := setupGameFilterTest(t, nil, nil, big.NewInt(0), path)
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+
+		readErr := errors.New("boom")
+		filter.readFile = func(path string) ([]byte, error) {
+			return nil, readErr
+		}
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageContainsFilter("Failed to read game filter file"))
+		require.NotNil(t, l)
+		require.ErrorIs(t, l.AttrValue("err").(error), readErr)
+	})
+
+	t.Run("MissingFileAtStartup", func(t *testing.T) {
+		filter, _, _ := setupGameFilterTest(t, []common.Address{filterGameA}, nil, big.NewInt(0), filepath.Join(t.TempDir(), "missing.json"))
+		require.Equal(t, []common.Address{filterGameB, filterGameC}, proxies(filter.Filter(games)))
+	})
+}
+
+func setupGameFilterTest(t *testing.T, ignore []common.Address, allow []common.Address, minBond *big.Int, path string) (*gameFilter, *mockFilterMetrics, *testlog.CapturingHandler) {
+	logger, logs := testlog.CaptureLogger(t, log.LvlDebug)
+	m := &mockFilterMetrics{filtered: make(map[string]int)}
+	return newGameFilter(logger, m, ignore, allow, minBond, path), m, logs
+}
+
+func writeFilterFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "filter.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func bondedGame(proxy common.Address, bond *big.Int) *monTypes.EnrichedGameData {
+	return &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: proxy},
+		Claims: []faultTypes.Claim{
+			{ClaimData: faultTypes.ClaimData{Bond: bond}},
+		},
+	}
+}
+
+func proxies(games []*monTypes.EnrichedGameData) []common.Address {
+	var addrs []common.Address
+	for _, game := range games {
+		addrs = append(addrs, game.Proxy)
+	}
+	return addrs
+}
+
+type mockFilterMetrics struct {
+	filtered map[string]int
+}
+
+func (m *mockFilterMetrics) RecordFilteredGames(reason string, count int) {
+	m.filtered[reason] = count
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/resolution"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/transform"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -24,30 +29,85 @@ var (
 type ForecastMetrics interface {
 	RecordClaimResolutionDelayMax(delay float64)
 	RecordGameAgreement(status metrics.GameAgreementStatus, count int)
+	RecordForecastSeverity(severity string, count int)
+	RecordOutputCrossCheck(result string)
+}
+
+// severityLevels are the severities of unexpected forecasts, from least to most urgent.
+var severityLevels = []alerts.Severity{alerts.SeverityInfo, alerts.SeverityWarning, alerts.SeverityCritical}
+
+// forecastThresholds determine the severity of unexpected forecasts.
+type forecastThresholds struct {
+	// warningClock and criticalClock are the time left to counter the game's claims at or below which an unexpected
+	// forecast is at least a warning or critical.
+	warningClock  time.Duration
+	criticalClock time.Duration
+	// warningBond and criticalBond are the bonds in wei at stake in the game's unresolved claims at or above which
+	// an unexpected forecast is at least a warning or critical. A threshold of zero is disabled.
+	warningBond  *big.Int
+	criticalBond *big.Int
 }
 
 type forecast struct {
-	logger    log.Logger
-	metrics   ForecastMetrics
-	validator OutputValidator
+	logger       log.Logger
+	metrics      ForecastMetrics
+	validator    OutputValidator
+	secondary    OutputValidator
+	alerts       alerts.Sink
+	clock        clock.Clock
+	honestActors map[common.Address]bool
+	thresholds   forecastThresholds
 }
 
-func newForecast(logger log.Logger, metrics ForecastMetrics, validator OutputValidator) *forecast {
+// newForecast creates a forecast checking root claims against validator. Root claims validator disagrees with are
+// cross-checked against secondary, unless it is nil.
+func newForecast(logger log.Logger, metrics ForecastMetrics, validator OutputValidator, secondary OutputValidator, sink alerts.Sink, cl clock.Clock, honestActors []common.Address, thresholds forecastThresholds) *forecast {
+	honest := make(map[common.Address]bool, len(honestActors))
+	for _, actor := range honestActors {
+		honest[actor] = true
+	}
 	return &forecast{
-		logger:    logger,
-		metrics:   metrics,
-		validator: validator,
+		logger:       logger,
+		metrics:      metrics,
+		validator:    validator,
+		secondary:    secondary,
+		alerts:       sink,
+		clock:        cl,
+		honestActors: honest,
+		thresholds:   thresholds,
 	}
 }
 
-func (f *forecast) Forecast(ctx context.Context, games []*monTypes.EnrichedGameData) {
+// Forecast records the forecast outcome of the in progress games and returns the forecast for each of them,
+// keyed by game address. Games that aren't in progress or fail to forecast are omitted.
+func (f *forecast) Forecast(ctx context.Context, games []*monTypes.EnrichedGameData) map[common.Address]monTypes.GameForecast {
 	batch := monTypes.ForecastBatch{}
+	forecasts := make(map[common.Address]monTypes.GameForecast)
 	for _, game := range games {
-		if err := f.forecastGame(ctx, game, &batch); err != nil {
+		result, err := f.forecastGame(ctx, game, &batch)
+		if err != nil {
 			f.logger.Error("Failed to forecast game", "err", err)
+			continue
+		}
+		if result != nil {
+			forecasts[game.Proxy] = *result
 		}
 	}
 	f.recordBatch(batch)
+	f.recordSeverities(forecasts)
+	return forecasts
+}
+
+func (f *forecast) recordSeverities(forecasts map[common.Address]monTypes.GameForecast) {
+	counts := make(map[alerts.Severity]int, len(severityLevels))
+	for _, forecast := range forecasts {
+		if forecast.Severity != "" {
+			counts[forecast.Severity]++
+		}
+	}
+	for _, severity := range severityLevels {
+		f.metrics.RecordForecastSeverity(string(severity), counts[severity])
+	}
 }
 
 func (f *forecast) recordBatch(batch monTypes.ForecastBatch) {
@@ -57,10 +117,10 @@ func (f *forecast) recordBatch(batch monTypes.ForecastBatch) {
 	f.metrics.RecordGameAgreement(metrics.DisagreeDefenderAhead, batch.DisagreeDefenderAhead)
 }
 
-func (f *forecast) forecastGame(ctx context.Context, game *monTypes.EnrichedGameData, metrics *monTypes.ForecastBatch) error {
+func (f *forecast) forecastGame(ctx context.Context, game *monTypes.EnrichedGameData, metrics *monTypes.ForecastBatch) (*monTypes.GameForecast, error) {
 	if game.Status != types.GameStatusInProgress {
 		f.logger.Debug("Game is not in progress, skipping forecast", "game", game.Proxy, "status", game.Status)
-		return nil
+		return nil, nil
 	}
 
 	// Create the bidirectional tree of claims.
@@ -72,16 +132,26 @@ func (f *forecast) forecastGame(ctx context.Context, game *monTypes.EnrichedGame
 	// Check the root agreement.
 	agreement, expected, err := f.validator.CheckRootAgreement(ctx, game.L2BlockNumber, game.RootClaim)
 	if err != nil {
-		return fmt.Errorf("%w: %w", ErrRootAgreement, err)
+		return nil, fmt.Errorf("%w: %w", ErrRootAgreement, err)
+	}
+	var crossCheck monTypes.CrossCheck
+	if !agreement && f.secondary != nil {
+		crossCheck = f.crossCheck(ctx, game, expected)
+		if crossCheck == monTypes.CrossCheckAgreeValid {
+			agreement, expected = true, game.RootClaim
+		}
 	}
 
+	var severity alerts.Severity
 	if agreement {
 		// If we agree with the output root proposal, the Defender should win, defending that claim.
 		if status == types.GameStatusChallengerWon {
 			metrics.AgreeChallengerAhead++
+			severity = f.severity(game, status)
 			f.logger.Warn("Forecasting unexpected game result", "status", status,
 				"game", game.Proxy, "blockNum", game.L2BlockNumber,
-				"rootClaim", game.RootClaim, "expected", expected)
+				"rootClaim", game.RootClaim, "expected", expected, "severity", severity)
+			f.alertUnexpected(game, status, types.GameStatusDefenderWon, severity)
 		} else {
 			metrics.AgreeDefenderAhead++
 			f.logger.Debug("Forecasting expected game result", "status", status,
@@ -92,9 +162,15 @@ func (f *forecast) forecastGame(ctx context.Context, game *monTypes.EnrichedGame
 		// If we disagree with the output root proposal, the Challenger should win, challenging that claim.
 		if status == types.GameStatusDefenderWon {
 			metrics.DisagreeDefenderAhead++
+			severity = f.severity(game, status)
 			f.logger.Warn("Forecasting unexpected game result", "status", status,
 				"game", game.Proxy, "blockNum", game.L2BlockNumber,
-				"rootClaim", game.RootClaim, "expected", expected)
+				"rootClaim", game.RootClaim, "expected", expected, "severity", severity, "crossCheck", crossCheck)
+			// The rollup nodes can't be relied on to say how the game should resolve if they diverge, which is
+			// alerted on instead.
+			if crossCheck != monTypes.CrossCheckDiverge {
+				f.alertUnexpected(game, status, types.GameStatusChallengerWon, severity)
+			}
 		} else {
 			metrics.DisagreeChallengerAhead++
 			f.logger.Debug("Forecasting expected game result", "status", status,
@@ -103,5 +179,140 @@ func (f *forecast) forecastGame(ctx context.Context, game *monTypes.EnrichedGame
 		}
 	}
 
-	return nil
+	return &monTypes.GameForecast{Status: status, AgreeRoot: agreement, ExpectedRoot: expected, Severity: severity, CrossCheck: crossCheck}, nil
+}
+
+// crossCheck checks the root claim of game against the secondary rollup node, after the primary disagreed with it by
+// reporting expected as the output root. This distinguishes invalid root claims from a faulty primary rollup node.
+func (f *forecast) crossCheck(ctx context.Context, game *monTypes.EnrichedGameData, expected common.Hash) monTypes.CrossCheck {
+	agree, secondary, err := f.secondary.CheckRootAgreement(ctx, game.L2BlockNumber, game.RootClaim)
+	var result monTypes.CrossCheck
+	switch {
+	case err != nil:
+		f.logger.Warn("Failed to cross-check root claim with secondary rollup node", "game", game.Proxy,
+			"blockNum", game.L2BlockNumber, "err", err)
+		result = monTypes.CrossCheckFailed
+	case agree:
+		f.logger.Warn("Secondary rollup node agrees with root claim the primary disagrees with", "game", game.Proxy,
+			"blockNum", game.L2BlockNumber, "rootClaim", game.RootClaim, "primary", expected)
+		// The game is forecast against the root claim, but the primary rollup node is faulty or lagging.
+		f.alerts.Emit(alerts.Alert{
+			Game:     game.Proxy,
+			Severity: alerts.SeverityWarning,
+			Category: alerts.CategoryRollupDivergence,
+			Message: fmt.Sprintf("Rollup nodes report different output roots for L2 block %v: primary %v, secondary agrees with root claim %v",
+				game.L2BlockNumber, expected, game.RootClaim),
+		})
+		result = monTypes.CrossCheckAgreeValid
+	case secondary == expected:
+		result = monTypes.CrossCheckAgreeInvalid
+	default:
+		f.logger.Error("Rollup nodes report different output roots", "game", game.Proxy,
+			"blockNum", game.L2BlockNumber, "rootClaim", game.RootClaim, "primary", expected, "secondary", secondary)
+		f.alerts.Emit(alerts.Alert{
+			Game:     game.Proxy,
+			Severity: alerts.SeverityCritical,
+			Category: alerts.CategoryRollupDivergence,
+			Message: fmt.Sprintf("Rollup nodes report different output roots for L2 block %v: primary %v, secondary %v, root claim %v",
+				game.L2BlockNumber, expected, secondary, game.RootClaim),
+		})
+		result = monTypes.CrossCheckDiverge
+	}
+	f.metrics.RecordOutputCrossCheck(string(result))
+	return result
+}
+
+// severity classifies the forecast that game will unexpectedly resolve as status. Forecasts are info by default,
+// at least a warning or critical if the time left to counter the game's claims or the bonds at stake reach the
+// thresholds, and raised a further level if an honest actor has claims on the side forecast to lose.
+func (f *forecast) severity(game *monTypes.EnrichedGameData, status types.GameStatus) alerts.Severity {
+	level := 0
+	if remaining, ok := remainingClock(f.clock.Now(), game); ok {
+		if remaining <= f.thresholds.criticalClock {
+			level = 2
+		} else if remaining <= f.thresholds.warningClock {
+			level = 1
+		}
+	}
+	bonded := unresolvedBonds(game)
+	if reachesThreshold(bonded, f.thresholds.criticalBond) {
+		level = 2
+	} else if reachesThreshold(bonded, f.thresholds.warningBond) {
+		level = max(level, 1)
+	}
+	if f.honestActorLosing(game, status) {
+		level++
+	}
+	return severityLevels[min(level, len(severityLevels)-1)]
+}
+
+// honestActorLosing returns true if an honest actor posted an unresolved claim on the side of game forecast to lose
+// if it resolves as status. Claims at even depths support the root claim so lose if the challenger wins, while
+// claims at odd depths lose if the defender wins.
+func (f *forecast) honestActorLosing(game *monTypes.EnrichedGameData, status types.GameStatus) bool {
+	for _, claim := range game.Claims {
+		if !f.honestActors[claim.Claimant] || isResolved(claim.Bond) {
+			continue
+		}
+		defender := claim.Position.Depth()%2 == 0
+		if defender == (status == types.GameStatusChallengerWon) {
+			return true
+		}
+	}
+	return false
+}
+
+// remainingClock returns the least time left at now to counter any of the uncountered claims in game, after which
+// the claim can be resolved. Returns false if none of the uncountered claims have a clock.
+func remainingClock(now time.Time, game *monTypes.EnrichedGameData) (time.Duration, bool) {
+	countered := make(map[int]bool, len(game.Claims))
+	for _, claim := range game.Claims {
+		countered[claim.ParentContractIndex] = true
+	}
+	maxChessTime := time.Duration(game.Duration/2) * time.Second
+	var remaining time.Duration
+	found := false
+	for i := range game.Claims {
+		claim := &game.Claims[i]
+		if countered[claim.ContractIndex] || claim.Clock == nil {
+			continue
+		}
+		// ChessTime counts seconds despite its type.
+		left := max(maxChessTime-claim.ChessTime(now)*time.Second, 0)
+		if !found || left < remaining {
+			remaining = left
+			found = true
+		}
+	}
+	return remaining, found
+}
+
+// unresolvedBonds returns the total bonds posted with the unresolved claims in game.
+func unresolvedBonds(game *monTypes.EnrichedGameData) *big.Int {
+	total := big.NewInt(0)
+	for _, claim := range game.Claims {
+		if claim.Bond != nil && !isResolved(claim.Bond) {
+			total.Add(total, claim.Bond)
+		}
+	}
+	return total
+}
+
+func isResolved(bond *big.Int) bool {
+	return bond != nil && bond.Cmp(monTypes.ResolvedBondAmount) == 0
+}
+
+// reachesThreshold returns true if value is at or above threshold, unless threshold is unset or zero.
+func reachesThreshold(value *big.Int, threshold *big.Int) bool {
+	return threshold != nil && threshold.Sign() > 0 && value.Cmp(threshold) >= 0
+}
+
+func (f *forecast) alertUnexpected(game *monTypes.EnrichedGameData, status types.GameStatus, expected types.GameStatus, severity alerts.Severity) {
+	f.alerts.Emit(alerts.Alert{
+		Game:     game.Proxy,
+		Severity: severity,
+		Category: alerts.CategoryUnexpectedForecast,
+		Message: fmt.Sprintf("Game for L2 block %v with root claim %v is forecast to resolve as %v but should resolve as %v",
+			game.L2BlockNumber, game.RootClaim, status, expected),
+	})
 }
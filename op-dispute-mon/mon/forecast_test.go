@@ -7,18 +7,27 @@ import (
 	"math"
 	"math/big"
 	"testing"
+	"time"
 
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
+const forecastGameDuration = uint64(7 * 24 * 60 * 60)
+
 var (
+	forecastNow         = time.Unix(1_700_000_000, 0)
+	forecastHonestActor = common.Address{0xee}
+
 	failedForecastLog     = "Failed to forecast game"
 	expectedInProgressLog = "Game is not in progress, skipping forecast"
 	unexpectedResultLog   = "Forecasting unexpected game result"
@@ -257,11 +266,427 @@ func TestForecast_Forecast_MultipleGames(t *testing.T) {
 	require.Len(t, logs.FindLogs(levelFilter, messageFilter), 5)
 }
 
+func TestForecast_Forecast_Results(t *testing.T) {
+	forecast, _, rollup, _ := setupForecastTest(t)
+	agreeAddr := common.Address{0xaa}
+	disagreeAddr := common.Address{0xbb}
+	resolvedAddr := common.Address{0xcc}
+	games := []*monTypes.EnrichedGameData{
+		{
+			GameMetadata: types.GameMetadata{Proxy: agreeAddr},
+			Status:       types.GameStatusInProgress,
+			RootClaim:    mockRootClaim,
+			Claims:       createDeepClaimList()[:2],
+		},
+		{
+			GameMetadata: types.GameMetadata{Proxy: disagreeAddr},
+			Status:       types.GameStatusInProgress,
+			Claims:       createDeepClaimList()[:1],
+		},
+		{
+			GameMetadata: types.GameMetadata{Proxy: resolvedAddr},
+			Status:       types.GameStatusDefenderWon,
+		},
+	}
+	forecasts := forecast.Forecast(context.Background(), games)
+	require.Equal(t, 2, rollup.calls)
+	require.Equal(t, map[common.Address]monTypes.GameForecast{
+		agreeAddr: {
+			Status:       types.GameStatusChallengerWon,
+			AgreeRoot:    true,
+			ExpectedRoot: mockRootClaim,
+			Severity:     alerts.SeverityInfo,
+		},
+		disagreeAddr: {
+			Status:       types.GameStatusDefenderWon,
+			AgreeRoot:    false,
+			ExpectedRoot: mockRootClaim,
+			Severity:     alerts.SeverityInfo,
+		},
+	}, forecasts)
+}
+
+func TestForecast_Forecast_Alerts(t *testing.T) {
+	forecast, _, _, _ := setupForecastTest(t)
+	sink := &recordingAlertSink{}
+	forecast.alerts = sink
+	unexpectedAddr := common.Address{0xaa}
+	unexpected := unexpectedGame(false)
+	unexpected.Proxy = unexpectedAddr
+	withRemainingClock(&unexpected.Claims[1], time.Hour)
+	forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{
+		unexpected,
+		{
+			GameMetadata: types.GameMetadata{Proxy: common.Address{0xbb}},
+			Status:       types.GameStatusInProgress,
+			RootClaim:    mockRootClaim,
+			Claims:       createDeepClaimList()[:1],
+		},
+	})
+	require.Len(t, sink.alerts, 1)
+	alert := sink.alerts[0]
+	require.Equal(t, unexpectedAddr, alert.Game)
+	require.Equal(t, alerts.SeverityCritical, alert.Severity)
+	require.Equal(t, alerts.CategoryUnexpectedForecast, alert.Category)
+	require.Contains(t, alert.Message, mockRootClaim.Hex())
+}
+
+func TestForecast_Forecast_Severity(t *testing.T) {
+	tests := []struct {
+		name string
+		// disagree forecasts the game to unexpectedly resolve as defender won rather than challenger won.
+		disagree bool
+		setup    func(claims []faultTypes.Claim)
+		expected alerts.Severity
+	}{
+		{
+			name:     "NoClockOrBond",
+			setup:    func(claims []faultTypes.Claim) {},
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name:     "ClockAboveWarning",
+			setup:    func(claims []faultTypes.Claim) { withRemainingClock(&claims[1], 24*time.Hour+time.Second) },
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name:     "ClockBelowWarning",
+			setup:    func(claims []faultTypes.Claim) { withRemainingClock(&claims[1], 24*time.Hour-time.Second) },
+			expected: alerts.SeverityWarning,
+		},
+		{
+			name:     "ClockAboveCritical",
+			setup:    func(claims []faultTypes.Claim) { withRemainingClock(&claims[1], 6*time.Hour+time.Second) },
+			expected: alerts.SeverityWarning,
+		},
+		{
+			name:     "ClockBelowCritical",
+			setup:    func(claims []faultTypes.Claim) { withRemainingClock(&claims[1], 6*time.Hour-time.Second) },
+			expected: alerts.SeverityCritical,
+		},
+		{
+			name: "ClockExpired",
+			setup: func(claims []faultTypes.Claim) {
+				claims[1].Clock = faultTypes.NewClock(forecastGameDuration, uint64(forecastNow.Unix()))
+			},
+			expected: alerts.SeverityCritical,
+		},
+		{
+			name: "CounteredClaimClockIgnored",
+			setup: func(claims []faultTypes.Claim) {
+				withRemainingClock(&claims[0], 0)
+				withRemainingClock(&claims[1], 48*time.Hour)
+			},
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name:     "BondBelowWarning",
+			setup:    func(claims []faultTypes.Claim) { withBonds(claims, 50, 49) },
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name:     "BondAboveWarning",
+			setup:    func(claims []faultTypes.Claim) { withBonds(claims, 50, 51) },
+			expected: alerts.SeverityWarning,
+		},
+		{
+			name:     "BondBelowCritical",
+			setup:    func(claims []faultTypes.Claim) { withBonds(claims, 500, 499) },
+			expected: alerts.SeverityWarning,
+		},
+		{
+			name:     "BondAboveCritical",
+			setup:    func(claims []faultTypes.Claim) { withBonds(claims, 500, 501) },
+			expected: alerts.SeverityCritical,
+		},
+		{
+			name: "ResolvedBondsExcluded",
+			setup: func(claims []faultTypes.Claim) {
+				withBonds(claims, 0, 99)
+				claims[0].Bond = monTypes.ResolvedBondAmount
+			},
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name: "ClockCriticalBondWarning",
+			setup: func(claims []faultTypes.Claim) {
+				withBonds(claims, 50, 51)
+				withRemainingClock(&claims[1], time.Hour)
+			},
+			expected: alerts.SeverityCritical,
+		},
+		{
+			name:     "HonestActorLosingDefence",
+			setup:    func(claims []faultTypes.Claim) { claims[0].Claimant = forecastHonestActor },
+			expected: alerts.SeverityWarning,
+		},
+		{
+			name:     "HonestActorWinningChallenge",
+			setup:    func(claims []faultTypes.Claim) { claims[1].Claimant = forecastHonestActor },
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name:     "HonestActorLosingChallenge",
+			disagree: true,
+			setup:    func(claims []faultTypes.Claim) { claims[1].Claimant = forecastHonestActor },
+			expected: alerts.SeverityWarning,
+		},
+		{
+			name:     "HonestActorWinningDefence",
+			disagree: true,
+			setup:    func(claims []faultTypes.Claim) { claims[2].Claimant = forecastHonestActor },
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name: "HonestActorResolvedClaimIgnored",
+			setup: func(claims []faultTypes.Claim) {
+				claims[0].Claimant = forecastHonestActor
+				claims[0].Bond = monTypes.ResolvedBondAmount
+			},
+			expected: alerts.SeverityInfo,
+		},
+		{
+			name: "HonestActorLosingRaisesWarning",
+			setup: func(claims []faultTypes.Claim) {
+				claims[0].Claimant = forecastHonestActor
+				withRemainingClock(&claims[1], 24*time.Hour-time.Second)
+			},
+			expected: alerts.SeverityCritical,
+		},
+		{
+			name: "HonestActorLosingAlreadyCritical",
+			setup: func(claims []faultTypes.Claim) {
+				claims[0].Claimant = forecastHonestActor
+				withBonds(claims, 500, 501)
+			},
+			expected: alerts.SeverityCritical,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			forecast, metrics, _, _ := setupForecastTest(t)
+			sink := &recordingAlertSink{}
+			forecast.alerts = sink
+			game := unexpectedGame(test.disagree)
+			test.setup(game.Claims)
+
+			forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+			require.False(t, forecasts[game.Proxy].Expected())
+			require.Equal(t, test.expected, forecasts[game.Proxy].Severity)
+			require.Len(t, sink.alerts, 1)
+			require.Equal(t, test.expected, sink.alerts[0].Severity)
+			expectedCounts := map[string]int{"info": 0, "warning": 0, "critical": 0}
+			expectedCounts[string(test.expected)] = 1
+			require.Equal(t, expectedCounts, metrics.severities)
+		})
+	}
+
+	t.Run("DisabledBondThresholds", func(t *testing.T) {
+		forecast, _, _, _ := setupForecastTest(t)
+		forecast.thresholds.warningBond = big.NewInt(0)
+		forecast.thresholds.criticalBond = big.NewInt(0)
+		game := unexpectedGame(false)
+		withBonds(game.Claims, 5000, 5000)
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, alerts.SeverityInfo, forecasts[game.Proxy].Severity)
+	})
+
+	t.Run("ExpectedForecastHasNoSeverity", func(t *testing.T) {
+		forecast, metrics, _, _ := setupForecastTest(t)
+		game := unexpectedGame(false)
+		game.Claims = game.Claims[:1]
+		withRemainingClock(&game.Claims[0], 0)
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.True(t, forecasts[game.Proxy].Expected())
+		require.Empty(t, forecasts[game.Proxy].Severity)
+		require.Equal(t, map[string]int{"info": 0, "warning": 0, "critical": 0}, metrics.severities)
+	})
+}
+
+// unexpectedGame returns an in progress game forecast to resolve with an unexpected result. If disagree is false the
+// root claim is agreed with and countered by the second claim. Otherwise it is disagreed with and defended by the
+// third claim.
+func unexpectedGame(disagree bool) *monTypes.EnrichedGameData {
+	game := &monTypes.EnrichedGameData{
+		GameMetadata: types.GameMetadata{Proxy: common.Address{0xaa}},
+		Status:       types.GameStatusInProgress,
+		Duration:     forecastGameDuration,
+		RootClaim:    mockRootClaim,
+		Claims:       createDeepClaimList()[:2],
+	}
+	if disagree {
+		game.RootClaim = common.Hash{0xdd}
+		game.Claims = createDeepClaimList()
+	}
+	return game
+}
+
+// withRemainingClock sets the clock of claim so it has remaining time left at forecastNow in a forecastGameDuration game.
+func withRemainingClock(claim *faultTypes.Claim, remaining time.Duration) {
+	elapsed := forecastGameDuration/2 - uint64(remaining.Seconds())
+	claim.Clock = faultTypes.NewClock(elapsed, uint64(forecastNow.Unix()))
+}
+
+func withBonds(claims []faultTypes.Claim, bonds ...int64) {
+	for i, bond := range bonds {
+		claims[i].Bond = big.NewInt(bond)
+	}
+}
+
+func TestForecast_Forecast_CrossCheck(t *testing.T) {
+	disputedRoot := common.Hash{0xdd}
+	setup := func(t *testing.T) (*forecast, *mockForecastMetrics, *stubOutputRollupClient, *recordingAlertSink) {
+		forecast, metrics, _, _ := setupForecastTest(t)
+		sink := &recordingAlertSink{}
+		forecast.alerts = sink
+		secondary := &stubOutputRollupClient{output: mockRootClaim}
+		forecast.secondary = newOutputValidator(secondary)
+		return forecast, metrics, secondary, sink
+	}
+	// disputedGame returns a game forecast to resolve as defender won, with a root claim the primary disagrees with.
+	disputedGame := func() *monTypes.EnrichedGameData {
+		return &monTypes.EnrichedGameData{
+			GameMetadata:  types.GameMetadata{Proxy: common.Address{0xaa}},
+			Status:        types.GameStatusInProgress,
+			L2BlockNumber: 42,
+			RootClaim:     disputedRoot,
+			Claims:        createDeepClaimList()[:1],
+		}
+	}
+
+	t.Run("NotCrossCheckedWhenPrimaryAgrees", func(t *testing.T) {
+		forecast, metrics, secondary, _ := setup(t)
+		game := disputedGame()
+		game.RootClaim = mockRootClaim
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Zero(t, secondary.calls)
+		require.Empty(t, forecasts[game.Proxy].CrossCheck)
+		require.Empty(t, metrics.crossChecks)
+	})
+
+	t.Run("NoSecondary", func(t *testing.T) {
+		forecast, _, _, _ := setupForecastTest(t)
+		game := disputedGame()
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Empty(t, forecasts[game.Proxy].CrossCheck)
+		require.False(t, forecasts[game.Proxy].AgreeRoot)
+	})
+
+	t.Run("AgreeInvalid", func(t *testing.T) {
+		forecast, metrics, secondary, sink := setup(t)
+		game := disputedGame()
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, 1, secondary.calls)
+		require.Equal(t, game.L2BlockNumber, secondary.blockNum)
+		require.Equal(t, monTypes.GameForecast{
+			Status:       types.GameStatusDefenderWon,
+			AgreeRoot:    false,
+			ExpectedRoot: mockRootClaim,
+			Severity:     alerts.SeverityInfo,
+			CrossCheck:   monTypes.CrossCheckAgreeInvalid,
+		}, forecasts[game.Proxy])
+		require.Len(t, sink.alerts, 1)
+		require.Equal(t, alerts.CategoryUnexpectedForecast, sink.alerts[0].Category)
+		require.Equal(t, map[string]int{"agree_invalid": 1}, metrics.crossChecks)
+		require.Equal(t, 1, metrics.disagreeDefenderAhead)
+	})
+
+	t.Run("AgreeValid", func(t *testing.T) {
+		forecast, metrics, secondary, sink := setup(t)
+		secondary.output = disputedRoot
+		game := disputedGame()
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, monTypes.GameForecast{
+			Status:       types.GameStatusDefenderWon,
+			AgreeRoot:    true,
+			ExpectedRoot: disputedRoot,
+			CrossCheck:   monTypes.CrossCheckAgreeValid,
+		}, forecasts[game.Proxy])
+		// the game is expected to resolve as forecast, but the rollup nodes diverge
+		require.Len(t, sink.alerts, 1)
+		alert := sink.alerts[0]
+		require.Equal(t, game.Proxy, alert.Game)
+		require.Equal(t, alerts.CategoryRollupDivergence, alert.Category)
+		require.Equal(t, alerts.SeverityWarning, alert.Severity)
+		require.Contains(t, alert.Message, mockRootClaim.Hex())
+		require.Contains(t, alert.Message, disputedRoot.Hex())
+		require.Equal(t, map[string]int{"agree_valid": 1}, metrics.crossChecks)
+		require.Equal(t, 1, metrics.agreeDefenderAhead)
+		require.Zero(t, metrics.disagreeDefenderAhead)
+	})
+
+	t.Run("EndpointsDiverge", func(t *testing.T) {
+		forecast, metrics, secondary, sink := setup(t)
+		secondary.output = common.Hash{0xee}
+		game := disputedGame()
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, monTypes.CrossCheckDiverge, forecasts[game.Proxy].CrossCheck)
+		require.False(t, forecasts[game.Proxy].AgreeRoot)
+		require.Equal(t, mockRootClaim, forecasts[game.Proxy].ExpectedRoot)
+
+		// Only the divergence is alerted on, not the game
+		require.Len(t, sink.alerts, 1)
+		alert := sink.alerts[0]
+		require.Equal(t, game.Proxy, alert.Game)
+		require.Equal(t, alerts.CategoryRollupDivergence, alert.Category)
+		require.Equal(t, alerts.SeverityCritical, alert.Severity)
+		require.Contains(t, alert.Message, mockRootClaim.Hex())
+		require.Contains(t, alert.Message, common.Hash{0xee}.Hex())
+		require.Equal(t, map[string]int{"endpoints_diverge": 1}, metrics.crossChecks)
+	})
+
+	t.Run("SecondaryFails", func(t *testing.T) {
+		forecast, metrics, secondary, sink := setup(t)
+		secondary.err = errors.New("boom")
+		game := disputedGame()
+		forecasts := forecast.Forecast(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, monTypes.CrossCheckFailed, forecasts[game.Proxy].CrossCheck)
+		require.False(t, forecasts[game.Proxy].AgreeRoot)
+		require.Len(t, sink.alerts, 1)
+		require.Equal(t, alerts.CategoryUnexpectedForecast, sink.alerts[0].Category)
+		require.Equal(t, map[string]int{"failed": 1}, metrics.crossChecks)
+	})
+}
+
+// stubOutputRollupClient reports output as the output root at every block.
+type stubOutputRollupClient struct {
+	output   common.Hash
+	err      error
+	calls    int
+	blockNum uint64
+}
+
+func (s *stubOutputRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	s.calls++
+	s.blockNum = blockNum
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(s.output)}, nil
+}
+
+type recordingAlertSink struct {
+	alerts []alerts.Alert
+}
+
+func (s *recordingAlertSink) Emit(alert alerts.Alert) {
+	s.alerts = append(s.alerts, alert)
+}
+
 func setupForecastTest(t *testing.T) (*forecast, *mockForecastMetrics, *stubOutputValidator, *testlog.CapturingHandler) {
 	logger, capturedLogs := testlog.CaptureLogger(t, log.LvlDebug)
 	validator := &stubOutputValidator{}
 	metrics := &mockForecastMetrics{}
-	return newForecast(logger, metrics, validator), metrics, validator, capturedLogs
+	thresholds := forecastThresholds{
+		warningClock:  24 * time.Hour,
+		criticalClock: 6 * time.Hour,
+		warningBond:   big.NewInt(100),
+		criticalBond:  big.NewInt(1000),
+	}
+	cl := clock.NewDeterministicClock(forecastNow)
+	forecast := newForecast(logger, metrics, validator, nil, alerts.NoopSink{}, cl, []common.Address{forecastHonestActor}, thresholds)
+	return forecast, metrics, validator, capturedLogs
 }
 
 type mockForecastMetrics struct {
@@ -270,6 +695,8 @@ type mockForecastMetrics struct {
 	agreeChallengerAhead    int
 	disagreeChallengerAhead int
 	claimResolutionDelayMax float64
+	severities              map[string]int
+	crossChecks             map[string]int
 }
 
 func (m *mockForecastMetrics) RecordGameAgreement(status metrics.GameAgreementStatus, count int) {
@@ -285,6 +712,20 @@ func (m *mockForecastMetrics) RecordGameAgreement(status metrics.GameAgreementSt
 	}
 }
 
+func (m *mockForecastMetrics) RecordForecastSeverity(severity string, count int) {
+	if m.severities == nil {
+		m.severities = make(map[string]int)
+	}
+	m.severities[severity] = count
+}
+
+func (m *mockForecastMetrics) RecordOutputCrossCheck(result string) {
+	if m.crossChecks == nil {
+		m.crossChecks = make(map[string]int)
+	}
+	m.crossChecks[result]++
+}
+
 func (m *mockForecastMetrics) RecordClaimResolutionDelayMax(delay float64) {
 	m.claimResolutionDelayMax = delay
 }
@@ -0,0 +1,82 @@
+package mon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/api"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+var (
+	errTooManyFailures = errors.New("too many consecutive monitoring cycle failures")
+	errStale           = errors.New("no recent successful monitoring cycle")
+)
+
+type HealthMetrics interface {
+	RecordCycleFailure(phase string)
+	RecordConsecutiveCycleFailures(count int)
+	RecordLastSuccessfulCycle(timestamp time.Time)
+	RecordCycleDuration(duration time.Duration)
+}
+
+// healthMonitor tracks the outcome of monitoring cycles so a monitor that keeps failing is reported as unhealthy,
+// rather than only being noticed when its metrics go stale.
+// It is safe for concurrent use.
+type healthMonitor struct {
+	metrics      HealthMetrics
+	clock        clock.Clock
+	maxFailures  int
+	maxStaleness time.Duration
+
+	lock sync.Mutex
+	// lastSuccess is the start of the last successful cycle, or the time the monitor was created if no cycle has
+	// succeeded yet so a monitor that never succeeds still becomes stale.
+	lastSuccess time.Time
+	failures    int
+	lastErr     error
+}
+
+func newHealthMonitor(metrics HealthMetrics, cl clock.Clock, maxFailures uint, maxStaleness time.Duration) *healthMonitor {
+	return &healthMonitor{
+		metrics:      metrics,
+		clock:        cl,
+		maxFailures:  int(maxFailures),
+		maxStaleness: maxStaleness,
+		lastSuccess:  cl.Now(),
+	}
+}
+
+// RecordCycle records the outcome of a monitoring cycle.
+func (h *healthMonitor) RecordCycle(cycle api.Cycle) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if cycle.Err != nil {
+		h.failures++
+		h.lastErr = cycle.Err
+		h.metrics.RecordCycleFailure(failedPhase(cycle.Err))
+	} else {
+		h.failures = 0
+		h.lastErr = nil
+		h.lastSuccess = cycle.Start
+		h.metrics.RecordLastSuccessfulCycle(cycle.Start)
+	}
+	h.metrics.RecordConsecutiveCycleFailures(h.failures)
+	h.metrics.RecordCycleDuration(cycle.Duration)
+}
+
+// CheckHealth returns an error if more than the maximum number of consecutive cycles have failed, or if there hasn't
+// been a successful cycle within the maximum staleness.
+func (h *healthMonitor) CheckHealth() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.failures > h.maxFailures {
+		return fmt.Errorf("%w: %v failed in %v, last error: %v", errTooManyFailures, h.failures, failedPhase(h.lastErr), h.lastErr)
+	}
+	if staleness := h.clock.Since(h.lastSuccess); staleness > h.maxStaleness {
+		return fmt.Errorf("%w: last success %v ago", errStale, staleness)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package mon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/api"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	healthInterval    = time.Minute
+	healthMaxFailures = 2
+	healthMaxStale    = 5 * healthInterval
+)
+
+func TestHealthMonitor(t *testing.T) {
+	t.Run("HealthyAtStartup", func(t *testing.T) {
+		health, _, _ := setupHealthMonitorTest(t)
+		require.NoError(t, health.CheckHealth())
+	})
+
+	t.Run("StaleWithoutSuccessAfterStartup", func(t *testing.T) {
+		health, _, cl := setupHealthMonitorTest(t)
+		cl.AdvanceTime(healthMaxStale)
+		require.NoError(t, health.CheckHealth())
+		cl.AdvanceTime(time.Second)
+		require.ErrorIs(t, health.CheckHealth(), errStale)
+	})
+
+	t.Run("SuccessRecorded", func(t *testing.T) {
+		health, m, cl := setupHealthMonitorTest(t)
+		start := cl.Now()
+		health.RecordCycle(api.Cycle{Start: start, Duration: 3 * time.Second})
+		require.Equal(t, start, m.lastSuccess)
+		require.Equal(t, 3*time.Second, m.lastDuration)
+		require.Equal(t, 0, m.consecutiveFailures)
+		require.Empty(t, m.failures)
+		require.NoError(t, health.CheckHealth())
+	})
+
+	t.Run("FailuresUpToMaximumHealthy", func(t *testing.T) {
+		health, m, cl := setupHealthMonitorTest(t)
+		for i := 1; i <= healthMaxFailures; i++ {
+			cl.AdvanceTime(healthInterval)
+			health.RecordCycle(api.Cycle{Start: cl.Now(), Err: &cycleError{phase: phaseExtract, err: errors.New("boom")}})
+			require.Equal(t, i, m.consecutiveFailures)
+			require.NoError(t, health.CheckHealth())
+		}
+		require.Equal(t, map[string]int{phaseExtract: healthMaxFailures}, m.failures)
+	})
+
+	t.Run("UnhealthyAfterTooManyFailures", func(t *testing.T) {
+		health, m, cl := setupHealthMonitorTest(t)
+		phases := []string{phaseFetchBlock, phaseExtract, phaseDetect}
+		for _, phase := range phases {
+			cl.AdvanceTime(healthInterval)
+			health.RecordCycle(api.Cycle{Start: cl.Now(), Err: &cycleError{phase: phase, err: errors.New("boom")}})
+		}
+		require.Equal(t, 3, m.consecutiveFailures)
+		require.Equal(t, map[string]int{phaseFetchBlock: 1, phaseExtract: 1, phaseDetect: 1}, m.failures)
+		err := health.CheckHealth()
+		require.ErrorIs(t, err, errTooManyFailures)
+		require.ErrorContains(t, err, phaseDetect)
+		require.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("SuccessResetsFailures", func(t *testing.T) {
+		health, m, cl := setupHealthMonitorTest(t)
+		for i := 0; i < healthMaxFailures+1; i++ {
+			cl.AdvanceTime(healthInterval)
+			health.RecordCycle(api.Cycle{Start: cl.Now(), Err: &cycleError{phase: phaseFetchBlock, err: errors.New("boom")}})
+		}
+		require.ErrorIs(t, health.CheckHealth(), errTooManyFailures)
+
+		cl.AdvanceTime(healthInterval)
+		health.RecordCycle(api.Cycle{Start: cl.Now()})
+		require.Equal(t, 0, m.consecutiveFailures)
+		require.Equal(t, cl.Now(), m.lastSuccess)
+		require.NoError(t, health.CheckHealth())
+	})
+
+	t.Run("StaleAfterLastSuccess", func(t *testing.T) {
+		health, _, cl := setupHealthMonitorTest(t)
+		cl.AdvanceTime(10 * healthInterval)
+		health.RecordCycle(api.Cycle{Start: cl.Now()})
+		require.NoError(t, health.CheckHealth())
+
+		// A cycle that never completes doesn't record a failure but the monitor still becomes stale
+		cl.AdvanceTime(healthMaxStale + time.Second)
+		err := health.CheckHealth()
+		require.ErrorIs(t, err, errStale)
+		require.NotErrorIs(t, err, errTooManyFailures)
+	})
+
+	t.Run("UnclassifiedFailure", func(t *testing.T) {
+		health, m, cl := setupHealthMonitorTest(t)
+		health.RecordCycle(api.Cycle{Start: cl.Now(), Err: errors.New("boom")})
+		require.Equal(t, map[string]int{phaseUnknown: 1}, m.failures)
+	})
+}
+
+func TestFailedPhase(t *testing.T) {
+	require.Equal(t, phaseUnknown, failedPhase(errors.New("boom")))
+	require.Equal(t, phaseExtract, failedPhase(&cycleError{phase: phaseExtract, err: errors.New("boom")}))
+	wrapped := fmt.Errorf("wrapped: %w", &cycleError{phase: phaseDetect, err: errors.New("boom")})
+	require.Equal(t, phaseDetect, failedPhase(wrapped))
+}
+
+func setupHealthMonitorTest(t *testing.T) (*healthMonitor, *mockHealthMetrics, *clock.DeterministicClock) {
+	m := &mockHealthMetrics{failures: make(map[string]int)}
+	cl := clock.NewDeterministicClock(time.Unix(100_000, 0))
+	return newHealthMonitor(m, cl, healthMaxFailures, healthMaxStale), m, cl
+}
+
+type mockHealthMetrics struct {
+	failures            map[string]int
+	consecutiveFailures int
+	lastSuccess         time.Time
+	lastDuration        time.Duration
+}
+
+func (m *mockHealthMetrics) RecordCycleFailure(phase string) {
+	m.failures[phase]++
+}
+
+func (m *mockHealthMetrics) RecordConsecutiveCycleFailures(count int) {
+	m.consecutiveFailures = count
+}
+
+func (m *mockHealthMetrics) RecordLastSuccessfulCycle(timestamp time.Time) {
+	m.lastSuccess = timestamp
+}
+
+func (m *mockHealthMetrics) RecordCycleDuration(duration time.Duration) {
+	m.lastDuration = duration
+}
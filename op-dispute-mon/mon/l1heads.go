@@ -0,0 +1,135 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+)
+
+// maxReorgDepth is the maximum number of blocks walked back from a reorged L1 head to find where it diverged
+// from the canonical chain.
+const maxReorgDepth = 64
+
+type L1HeadMetrics interface {
+	RecordReorgedGames(count int)
+}
+
+type HeaderByHashSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*gethTypes.Header, error)
+}
+
+// l1HeadStatus is the result of checking whether a game's L1 head is canonical.
+type l1HeadStatus struct {
+	reorged bool
+	// depth is the number of blocks between the L1 head and the last canonical block it descends from,
+	// or 0 if it couldn't be determined.
+	depth uint64
+	err   error
+}
+
+// l1HeadDetector detects games created against an L1 head that is no longer canonical. The output proposal a
+// game disputes may not exist on the canonical chain, so the game can behave unexpectedly.
+type l1HeadDetector struct {
+	logger    log.Logger
+	metrics   L1HeadMetrics
+	headers   HeaderByHashSource
+	blockHash extract.BlockHashFetcher
+	alerts    alerts.Sink
+}
+
+func newL1HeadDetector(logger log.Logger, metrics L1HeadMetrics, headers HeaderByHashSource, blockHash extract.BlockHashFetcher, sink alerts.Sink) *l1HeadDetector {
+	return &l1HeadDetector{
+		logger:    logger,
+		metrics:   metrics,
+		headers:   headers,
+		blockHash: blockHash,
+		alerts:    sink,
+	}
+}
+
+// Detect warns about and counts the games whose L1 head is no longer canonical.
+// Each L1 head is only checked once per call, as many games are typically created against the same head.
+func (d *l1HeadDetector) Detect(ctx context.Context, games []*types.EnrichedGameData) {
+	checked := make(map[common.Hash]l1HeadStatus)
+	reorged := 0
+	for _, game := range games {
+		status, ok := checked[game.L1Head]
+		if !ok {
+			status = d.check(ctx, game.L1Head)
+			checked[game.L1Head] = status
+		}
+		if status.err != nil {
+			d.logger.Warn("Failed to check if game L1 head is canonical", "game", game.Proxy, "l1Head", game.L1Head, "err", status.err)
+			continue
+		}
+		if !status.reorged {
+			continue
+		}
+		reorged++
+		depth := "unknown"
+		if status.depth != 0 {
+			depth = fmt.Sprint(status.depth)
+		}
+		d.logger.Warn("Game L1 head is no longer canonical", "game", game.Proxy, "l1Head", game.L1Head, "depth", depth)
+		d.alerts.Emit(alerts.Alert{
+			Game:     game.Proxy,
+			Severity: alerts.SeverityWarning,
+			Category: alerts.CategoryReorgedL1Head,
+			Message:  fmt.Sprintf("Game L1 head %v is no longer canonical, reorg depth %v", game.L1Head, depth),
+		})
+	}
+	d.metrics.RecordReorgedGames(reorged)
+}
+
+// check returns whether head is still canonical. If it isn't, the blocks it descends from are walked back until
+// one is canonical to determine the depth of the reorg.
+func (d *l1HeadDetector) check(ctx context.Context, head common.Hash) l1HeadStatus {
+	header, err := d.headers.HeaderByHash(ctx, head)
+	if errors.Is(err, ethereum.NotFound) {
+		// The node has discarded the block so the depth of the reorg can't be determined
+		return l1HeadStatus{reorged: true}
+	} else if err != nil {
+		return l1HeadStatus{err: fmt.Errorf("failed to fetch L1 head %v: %w", head, err)}
+	}
+	number := header.Number.Uint64()
+	canonical, err := d.blockHash(ctx, number)
+	if err != nil {
+		return l1HeadStatus{err: fmt.Errorf("failed to fetch canonical block %v: %w", number, err)}
+	}
+	if canonical == head {
+		return l1HeadStatus{}
+	}
+	return l1HeadStatus{reorged: true, depth: d.divergenceDepth(ctx, header)}
+}
+
+// divergenceDepth returns the number of blocks from the non-canonical header back to the last canonical block it
+// descends from, or 0 if it can't be determined.
+func (d *l1HeadDetector) divergenceDepth(ctx context.Context, header *gethTypes.Header) uint64 {
+	for depth := uint64(1); depth <= maxReorgDepth && header.Number.Uint64() > 0; depth++ {
+		parentNumber := header.Number.Uint64() - 1
+		canonical, err := d.blockHash(ctx, parentNumber)
+		if err != nil {
+			d.logger.Debug("Failed to fetch canonical block", "number", parentNumber, "err", err)
+			return 0
+		}
+		if canonical == header.ParentHash {
+			return depth
+		}
+		parentHash := header.ParentHash
+		header, err = d.headers.HeaderByHash(ctx, parentHash)
+		if err != nil {
+			d.logger.Debug("Failed to fetch reorged block", "hash", parentHash, "err", err)
+			return 0
+		}
+	}
+	return 0
+}
@@ -0,0 +1,185 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+const reorgedHeadLog = "Game L1 head is no longer canonical"
+
+func TestL1HeadDetector_Detect(t *testing.T) {
+	t.Run("NoGames", func(t *testing.T) {
+		detector, m, _, _ := setupL1HeadDetectorTest(t)
+		detector.Detect(context.Background(), nil)
+		require.Equal(t, 0, m.reorged)
+	})
+
+	t.Run("CanonicalHead", func(t *testing.T) {
+		detector, m, chain, logs := setupL1HeadDetectorTest(t)
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{l1HeadGame(0x01, chain.canonical[10])})
+		require.Equal(t, 0, m.reorged)
+		require.Nil(t, logs.FindLog(testlog.NewMessageFilter(reorgedHeadLog)))
+	})
+
+	t.Run("ReorgedHead", func(t *testing.T) {
+		detector, m, chain, logs := setupL1HeadDetectorTest(t)
+		sink := &recordingAlertSink{}
+		detector.alerts = sink
+		// Fork from block 7 so blocks 8 to 10 are reorged out
+		head := chain.fork(7, 3)
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{
+			l1HeadGame(0x01, chain.canonical[10]),
+			l1HeadGame(0x02, head),
+		})
+		require.Equal(t, 1, m.reorged)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter(reorgedHeadLog))
+		require.NotNil(t, l)
+		require.Equal(t, common.Address{0x02}, l.AttrValue("game"))
+		require.Equal(t, head, l.AttrValue("l1Head"))
+		require.Equal(t, "3", l.AttrValue("depth"))
+		require.Len(t, sink.alerts, 1)
+		require.Equal(t, common.Address{0x02}, sink.alerts[0].Game)
+		require.Equal(t, alerts.SeverityWarning, sink.alerts[0].Severity)
+		require.Equal(t, alerts.CategoryReorgedL1Head, sink.alerts[0].Category)
+	})
+
+	t.Run("DiscardedHead", func(t *testing.T) {
+		detector, m, _, logs := setupL1HeadDetectorTest(t)
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{l1HeadGame(0x01, common.Hash{0xde, 0xad})})
+		require.Equal(t, 1, m.reorged)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter(reorgedHeadLog))
+		require.NotNil(t, l)
+		require.Equal(t, "unknown", l.AttrValue("depth"))
+	})
+
+	t.Run("DepthUnknownWhenAncestorMissing", func(t *testing.T) {
+		detector, m, chain, logs := setupL1HeadDetectorTest(t)
+		head := chain.fork(5, 3)
+		// The node no longer has the first block of the fork
+		delete(chain.headers, chain.headers[chain.headers[head].ParentHash].ParentHash)
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{l1HeadGame(0x01, head)})
+		require.Equal(t, 1, m.reorged)
+		l := logs.FindLog(testlog.NewMessageFilter(reorgedHeadLog))
+		require.NotNil(t, l)
+		require.Equal(t, "unknown", l.AttrValue("depth"))
+	})
+
+	t.Run("ChecksEachHeadOnce", func(t *testing.T) {
+		detector, m, chain, _ := setupL1HeadDetectorTest(t)
+		reorged := chain.fork(9, 1)
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{
+			l1HeadGame(0x01, chain.canonical[10]),
+			l1HeadGame(0x02, chain.canonical[10]),
+			l1HeadGame(0x03, reorged),
+			l1HeadGame(0x04, chain.canonical[10]),
+			l1HeadGame(0x05, reorged),
+		})
+		require.Equal(t, 2, m.reorged)
+		require.Equal(t, 2, chain.headerRequests, "should fetch each head once")
+		// One check for each head and one to find the reorg depth
+		require.Equal(t, 3, chain.hashRequests)
+	})
+
+	t.Run("FetchErrorNotCounted", func(t *testing.T) {
+		detector, m, chain, logs := setupL1HeadDetectorTest(t)
+		chain.err = errors.New("boom")
+		detector.Detect(context.Background(), []*monTypes.EnrichedGameData{l1HeadGame(0x01, chain.canonical[10])})
+		require.Equal(t, 0, m.reorged)
+		l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("Failed to check if game L1 head is canonical"))
+		require.NotNil(t, l)
+		require.ErrorIs(t, l.AttrValue("err").(error), chain.err)
+	})
+}
+
+func setupL1HeadDetectorTest(t *testing.T) (*l1HeadDetector, *mockL1HeadMetrics, *stubL1Chain, *testlog.CapturingHandler) {
+	logger, logs := testlog.CaptureLogger(t, log.LvlDebug)
+	m := &mockL1HeadMetrics{}
+	chain := newStubL1Chain(10)
+	return newL1HeadDetector(logger, m, chain, chain.BlockHash, alerts.NoopSink{}), m, chain, logs
+}
+
+func l1HeadGame(addr byte, l1Head common.Hash) *monTypes.EnrichedGameData {
+	return &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{addr}},
+		L1Head:       l1Head,
+	}
+}
+
+type mockL1HeadMetrics struct {
+	reorged int
+}
+
+func (m *mockL1HeadMetrics) RecordReorgedGames(count int) {
+	m.reorged = count
+}
+
+// stubL1Chain is a canonical chain of blocks plus any forks created from it.
+type stubL1Chain struct {
+	canonical []common.Hash
+	headers   map[common.Hash]*gethTypes.Header
+	err       error
+
+	headerRequests int
+	hashRequests   int
+}
+
+func newStubL1Chain(head uint64) *stubL1Chain {
+	chain := &stubL1Chain{headers: make(map[common.Hash]*gethTypes.Header)}
+	parent := common.Hash{}
+	for i := uint64(0); i <= head; i++ {
+		parent = chain.add(i, parent, 0)
+		chain.canonical = append(chain.canonical, parent)
+	}
+	return chain
+}
+
+func (c *stubL1Chain) add(number uint64, parent common.Hash, fork byte) common.Hash {
+	header := &gethTypes.Header{Number: new(big.Int).SetUint64(number), ParentHash: parent, Extra: []byte{fork}}
+	c.headers[header.Hash()] = header
+	return header.Hash()
+}
+
+// fork creates length non-canonical blocks descending from the canonical block at number, returning the last.
+func (c *stubL1Chain) fork(number uint64, length uint64) common.Hash {
+	hash := c.canonical[number]
+	for i := uint64(1); i <= length; i++ {
+		hash = c.add(number+i, hash, 1)
+	}
+	return hash
+}
+
+func (c *stubL1Chain) HeaderByHash(_ context.Context, hash common.Hash) (*gethTypes.Header, error) {
+	c.headerRequests++
+	if c.err != nil {
+		return nil, c.err
+	}
+	header, ok := c.headers[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (c *stubL1Chain) BlockHash(_ context.Context, number uint64) (common.Hash, error) {
+	c.hashRequests++
+	if c.err != nil {
+		return common.Hash{}, c.err
+	}
+	if number >= uint64(len(c.canonical)) {
+		return common.Hash{}, ethereum.NotFound
+	}
+	return c.canonical[number], nil
+}
@@ -2,138 +2,405 @@ package mon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/api"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 type Detect func(ctx context.Context, games []*types.EnrichedGameData)
-type Forecast func(ctx context.Context, games []*types.EnrichedGameData)
-type BlockHashFetcher func(ctx context.Context, number *big.Int) (common.Hash, error)
-type BlockNumberFetcher func(ctx context.Context) (uint64, error)
-type Extract func(ctx context.Context, blockHash common.Hash, minTimestamp uint64) ([]*types.EnrichedGameData, error)
-type RecordClaimResolutionDelayMax func([]*types.EnrichedGameData)
+type DetectAtBlock func(ctx context.Context, block eth.BlockID, games []*types.EnrichedGameData)
+type Forecast func(ctx context.Context, games []*types.EnrichedGameData) map[common.Address]types.GameForecast
+type Resolve func(ctx context.Context, games []*types.EnrichedGameData)
+type BlockFetcher func(ctx context.Context) (eth.BlockID, error)
+type BalanceFetcher func(ctx context.Context, block eth.BlockID, account common.Address) (*big.Int, error)
+type Extract func(ctx context.Context, block eth.BlockID, minTimestamp uint64) ([]*types.EnrichedGameData, error)
+type FilterGames func(games []*types.EnrichedGameData) []*types.EnrichedGameData
+type ExtractPreimages func(ctx context.Context, block eth.BlockID, games []*types.EnrichedGameData) ([]types.LargePreimageProposal, error)
+type DetectPreimages func(ctx context.Context, proposals []types.LargePreimageProposal)
+type RecordClaimResolutionDelays func([]*types.EnrichedGameData)
+type RecordClaimants func([]*types.EnrichedGameData) []types.ClaimantExposure
+type RecordMonitoredBlock func(number uint64)
+type RecordCycleTimeout func(phase string)
+type RecordSkippedCycle func()
+type RecordCyclePhase func(phase string, duration time.Duration)
+type RecordCycleGames func(count int)
+type PublishCycle func(cycle api.Cycle)
+
+// Phases of a monitoring cycle, reported with the time each phase takes and when a cycle times out or fails.
+const (
+	phaseFetchBlock = "fetch_block"
+	phaseExtract    = "extract"
+	phaseDelays     = "delays"
+	phaseDetect     = "detect"
+	phaseForecast   = "forecast"
+	phaseResolve    = "resolve"
+	phaseUnknown    = "unknown"
+)
 
 type gameMonitor struct {
 	logger log.Logger
 	clock  clock.Clock
 
-	done   chan struct{}
-	ctx    context.Context
+	// ctx is the parent context of each monitoring run.
+	ctx context.Context
+
+	// lock serialises StartMonitoring and StopMonitoring. While the monitor is running, cancel stops the
+	// current loop and done is closed once it has exited. Both are nil while the monitor is stopped.
+	lock   sync.Mutex
 	cancel context.CancelFunc
+	done   chan struct{}
+
+	gameMonitorDeps
+
+	// jitter returns a random duration in [0, limit).
+	jitter func(limit time.Duration) time.Duration
+
+	// triggers holds a pending request for an immediate cycle. It is buffered so requests made while a cycle is
+	// pending are coalesced.
+	triggers chan struct{}
+}
+
+// gameMonitorDeps are the settings of a gameMonitor and the functions it calls in each monitoring cycle.
+type gameMonitorDeps struct {
+	creationWindow   time.Duration
+	resolutionWindow time.Duration
+	monitorInterval  time.Duration
+	cycleTimeout     time.Duration
 
-	gameWindow      time.Duration
-	monitorInterval time.Duration
-
-	delays           RecordClaimResolutionDelayMax
-	detect           Detect
-	forecast         Forecast
-	extract          Extract
-	fetchBlockHash   BlockHashFetcher
-	fetchBlockNumber BlockNumberFetcher
-}
-
-func newGameMonitor(
-	ctx context.Context,
-	logger log.Logger,
-	cl clock.Clock,
-	monitorInterval time.Duration,
-	gameWindow time.Duration,
-	delays RecordClaimResolutionDelayMax,
-	detect Detect,
-	forecast Forecast,
-	extract Extract,
-	fetchBlockNumber BlockNumberFetcher,
-	fetchBlockHash BlockHashFetcher,
-) *gameMonitor {
+	// startupJitter and intervalJitter are the maximum random delays before the first tick and before each
+	// scheduled cycle, so replicas started together don't all query the RPC at the same time.
+	startupJitter  time.Duration
+	intervalJitter time.Duration
+
+	// triggerDebounce is how long to wait after the last trigger before running the triggered cycle.
+	triggerDebounce time.Duration
+
+	delays      RecordClaimResolutionDelays
+	claimants   RecordClaimants
+	detect      Detect
+	credits     Detect
+	actors      DetectAtBlock
+	l1Heads     Detect
+	forecast    Forecast
+	resolve     Resolve
+	extract     Extract
+	filter      FilterGames
+	fetchBlock  BlockFetcher
+	recordBlock RecordMonitoredBlock
+
+	extractPreimages ExtractPreimages
+	detectPreimages  DetectPreimages
+
+	recordTimeout RecordCycleTimeout
+	recordSkipped RecordSkippedCycle
+	recordPhase   RecordCyclePhase
+	recordGames   RecordCycleGames
+	publish       PublishCycle
+}
+
+func newGameMonitor(ctx context.Context, logger log.Logger, cl clock.Clock, deps gameMonitorDeps) *gameMonitor {
 	return &gameMonitor{
-		logger:           logger,
-		clock:            cl,
-		ctx:              ctx,
-		done:             make(chan struct{}),
-		monitorInterval:  monitorInterval,
-		gameWindow:       gameWindow,
-		delays:           delays,
-		detect:           detect,
-		forecast:         forecast,
-		extract:          extract,
-		fetchBlockNumber: fetchBlockNumber,
-		fetchBlockHash:   fetchBlockHash,
+		logger:          logger,
+		clock:           cl,
+		ctx:             ctx,
+		gameMonitorDeps: deps,
+		jitter:          randomDuration,
+		triggers:        make(chan struct{}, 1),
 	}
 }
 
+// minGameTimestamp returns the creation timestamp of the oldest game in any monitoring window.
 func (m *gameMonitor) minGameTimestamp() uint64 {
-	if m.gameWindow.Seconds() == 0 {
+	return min(m.minTimestamp(m.creationWindow), m.minTimestamp(m.resolutionWindow))
+}
+
+// minTimestamp returns the creation timestamp of the oldest game in window. A zero window includes all games.
+func (m *gameMonitor) minTimestamp(window time.Duration) uint64 {
+	if window.Seconds() == 0 {
 		return 0
 	}
 	// time: "To compute t-d for a duration d, use t.Add(-d)."
 	// https://pkg.go.dev/time#Time.Sub
-	if m.clock.Now().Unix() > int64(m.gameWindow.Seconds()) {
-		return uint64(m.clock.Now().Add(-m.gameWindow).Unix())
+	if m.clock.Now().Unix() > int64(window.Seconds()) {
+		return uint64(m.clock.Now().Add(-window).Unix())
 	}
 	return 0
 }
 
-func (m *gameMonitor) monitorGames() error {
-	blockNumber, err := m.fetchBlockNumber(m.ctx)
-	if err != nil {
-		return fmt.Errorf("Failed to fetch block number: %w", err)
+// tagWindows marks which monitoring windows each game falls in, returning the games in the creation and resolution
+// windows respectively.
+func (m *gameMonitor) tagWindows(games []*types.EnrichedGameData) (creation []*types.EnrichedGameData, resolution []*types.EnrichedGameData) {
+	minCreation := m.minTimestamp(m.creationWindow)
+	minResolution := m.minTimestamp(m.resolutionWindow)
+	for _, game := range games {
+		game.InCreationWindow = game.Timestamp >= minCreation
+		game.InResolutionWindow = game.Timestamp >= minResolution
+		if game.InCreationWindow {
+			creation = append(creation, game)
+		}
+		if game.InResolutionWindow {
+			resolution = append(resolution, game)
+		}
+	}
+	return creation, resolution
+}
+
+// monitorGames runs a single monitoring cycle, aborting it if it takes longer than the cycle timeout.
+// The outcome of the cycle is published whether or not it succeeds.
+func (m *gameMonitor) monitorGames(ctx context.Context) error {
+	cycle := api.Cycle{Start: m.clock.Now()}
+	err := m.runCycle(ctx, &cycle)
+	cycle.Duration = m.clock.Since(cycle.Start)
+	cycle.Err = err
+	m.publish(cycle)
+	return err
+}
+
+func (m *gameMonitor) runCycle(ctx context.Context, cycle *api.Cycle) error {
+	ctx, cancel := context.WithTimeout(ctx, m.cycleTimeout)
+	defer cancel()
+	start := m.clock.Now()
+	block, err := m.fetchBlock(ctx)
+	start = m.endPhase(phaseFetchBlock, start)
+	if err := m.checkCycle(ctx, phaseFetchBlock); err != nil {
+		return err
 	}
-	m.logger.Debug("Fetched block number", "blockNumber", blockNumber)
-	blockHash, err := m.fetchBlockHash(context.Background(), new(big.Int).SetUint64(blockNumber))
 	if err != nil {
-		return fmt.Errorf("Failed to fetch block hash: %w", err)
+		return &cycleError{phase: phaseFetchBlock, err: fmt.Errorf("failed to fetch block: %w", err)}
+	}
+	m.logger.Debug("Monitoring games", "block", block)
+	enrichedGames, err := m.extract(ctx, block, m.minGameTimestamp())
+	start = m.endPhase(phaseExtract, start)
+	// Games that were still loading when the cycle was aborted are missing, so discard the partial results.
+	if err := m.checkCycle(ctx, phaseExtract); err != nil {
+		return err
 	}
-	enrichedGames, err := m.extract(m.ctx, blockHash, m.minGameTimestamp())
 	if err != nil {
-		return fmt.Errorf("failed to load games: %w", err)
+		return &cycleError{phase: phaseExtract, err: fmt.Errorf("failed to load games at block %v: %w", block, err)}
+	}
+	m.recordGames(len(enrichedGames))
+	enrichedGames = m.filter(enrichedGames)
+	creationGames, resolutionGames := m.tagWindows(enrichedGames)
+	m.delays(resolutionGames)
+	claimants := m.claimants(resolutionGames)
+	start = m.endPhase(phaseDelays, start)
+	m.detect(ctx, resolutionGames)
+	m.credits(ctx, resolutionGames)
+	m.actors(ctx, block, resolutionGames)
+	m.l1Heads(ctx, enrichedGames)
+	m.checkPreimages(ctx, block, enrichedGames)
+	start = m.endPhase(phaseDetect, start)
+	if err := m.checkCycle(ctx, phaseDetect); err != nil {
+		return err
 	}
-	m.delays(enrichedGames)
-	m.detect(m.ctx, enrichedGames)
-	m.forecast(m.ctx, enrichedGames)
+	forecasts := m.forecast(ctx, creationGames)
+	start = m.endPhase(phaseForecast, start)
+	if err := m.checkCycle(ctx, phaseForecast); err != nil {
+		return err
+	}
+	m.resolve(ctx, resolutionGames)
+	m.endPhase(phaseResolve, start)
+	m.recordBlock(block.Number)
+	m.logger.Info("Monitored games", "block", block, "games", len(enrichedGames))
+	cycle.Block = block
+	cycle.Games = enrichedGames
+	cycle.Forecasts = forecasts
+	cycle.Claimants = claimants
 	return nil
 }
 
-func (m *gameMonitor) loop() {
+// endPhase records the time taken by phase, which started at start, and returns the time the next phase starts.
+// Phases are timed whether or not they succeed so slow failures are visible too.
+func (m *gameMonitor) endPhase(phase string, start time.Time) time.Time {
+	end := m.clock.Now()
+	m.recordPhase(phase, end.Sub(start))
+	return end
+}
+
+// checkPreimages checks the large preimage proposals in the oracles used by games. Failing to load them is logged
+// rather than failing the cycle so the games themselves are still monitored.
+func (m *gameMonitor) checkPreimages(ctx context.Context, block eth.BlockID, games []*types.EnrichedGameData) {
+	proposals, err := m.extractPreimages(ctx, block, games)
+	if err != nil {
+		m.logger.Error("Failed to load large preimage proposals", "block", block, "err", err)
+		return
+	}
+	m.detectPreimages(ctx, proposals)
+}
+
+// checkCycle returns an error if the cycle was aborted during phase, recording the timeout if it ran out of time.
+func (m *gameMonitor) checkCycle(ctx context.Context, phase string) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		m.logger.Warn("Monitoring cycle timed out", "phase", phase, "timeout", m.cycleTimeout)
+		m.recordTimeout(phase)
+	}
+	return &cycleError{phase: phase, err: fmt.Errorf("monitoring cycle aborted during %v: %w", phase, err)}
+}
+
+// cycleError is an error that caused a monitoring cycle to fail during phase.
+type cycleError struct {
+	phase string
+	err   error
+}
+
+func (e *cycleError) Error() string {
+	return e.err.Error()
+}
+
+func (e *cycleError) Unwrap() error {
+	return e.err
+}
+
+// failedPhase returns the phase of the monitoring cycle that failed with err.
+func failedPhase(err error) string {
+	var cycleErr *cycleError
+	if errors.As(err, &cycleErr) {
+		return cycleErr.phase
+	}
+	return phaseUnknown
+}
+
+// Trigger requests a monitoring cycle in addition to those run every monitor interval. The cycle is run once no
+// further triggers have been received for the trigger debounce, so a burst of triggers results in a single cycle.
+// Cycles are never run concurrently: a trigger received while a cycle is in progress runs another cycle after it.
+func (m *gameMonitor) Trigger() {
+	select {
+	case m.triggers <- struct{}{}:
+	default:
+		// A cycle is already pending
+	}
+}
+
+// loop runs a monitoring cycle every monitor interval and when triggered. Cycles run in the background so the loop
+// keeps track of ticks while a cycle is in progress: a tick that fires while a cycle is still running is skipped
+// rather than starting an overlapping or back-to-back cycle.
+func (m *gameMonitor) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	if !m.wait(ctx, m.jitter(m.startupJitter)) {
+		m.logger.Info("Game monitor stopped")
+		return
+	}
 	ticker := m.clock.NewTicker(m.monitorInterval)
 	defer ticker.Stop()
+	// running is closed once the in-progress cycle completes, or is nil if no cycle is running.
+	var running chan struct{}
+	start := func(delay time.Duration) {
+		running = make(chan struct{})
+		go func(running chan struct{}) {
+			defer close(running)
+			if m.wait(ctx, delay) {
+				m.runLoggedCycle(ctx)
+			}
+		}(running)
+	}
+	// debounced fires once a burst of triggers is over, or is nil if no trigger has been received.
+	var debounced <-chan time.Time
+	// pending is set when a triggered cycle is due while another cycle is running.
+	pending := false
 	for {
 		select {
 		case <-ticker.Ch():
-			if err := m.monitorGames(); err != nil {
-				m.logger.Error("Failed to monitor games", "err", err)
+			if running != nil {
+				m.logger.Warn("Previous monitoring cycle still running, skipping cycle", "interval", m.monitorInterval)
+				m.recordSkipped()
+				continue
+			}
+			start(m.jitter(m.intervalJitter))
+		case <-m.triggers:
+			// Restart the wait so the cycle runs once the burst is over
+			debounced = m.clock.After(m.triggerDebounce)
+		case <-debounced:
+			debounced = nil
+			if running != nil {
+				pending = true
+				continue
+			}
+			m.logger.Debug("Running triggered monitoring cycle")
+			start(0)
+		case <-running:
+			running = nil
+			if pending {
+				pending = false
+				m.logger.Debug("Running triggered monitoring cycle")
+				start(0)
 			}
-		case <-m.done:
-			m.logger.Info("Stopping game monitor")
+		case <-ctx.Done():
+			if running != nil {
+				<-running
+			}
+			m.logger.Info("Game monitor stopped")
 			return
 		}
 	}
 }
 
+// wait waits for d, returning false if ctx is done first.
+func (m *gameMonitor) wait(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-m.clock.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// randomDuration returns a random duration in [0, limit), or 0 if limit isn't positive.
+func randomDuration(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(limit)))
+}
+
+func (m *gameMonitor) runLoggedCycle(ctx context.Context) {
+	if err := m.monitorGames(ctx); err != nil {
+		m.logger.Error("Failed to monitor games", "err", err)
+	}
+}
+
+// StartMonitoring starts the monitoring loop. It is a no-op if the monitor is already running and
+// may be called again after StopMonitoring to restart the monitor.
 func (m *gameMonitor) StartMonitoring() {
-	// Setup the cancellation only if it's not already set.
-	// This prevents overwriting the context and cancel function
-	// if, for example, this function is called multiple times.
-	if m.cancel == nil {
-		ctx, cancel := context.WithCancel(m.ctx)
-		m.ctx = ctx
-		m.cancel = cancel
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.cancel != nil {
+		return
 	}
 	m.logger.Info("Starting game monitor")
-	go m.loop()
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go m.loop(ctx, m.done)
 }
 
+// StopMonitoring stops the monitoring loop and waits for it to exit. It is a no-op if the monitor isn't running.
 func (m *gameMonitor) StopMonitoring() {
-	m.logger.Info("Stopping game monitor")
-	if m.cancel != nil {
-		m.cancel()
-		m.cancel = nil
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.cancel == nil {
+		return
 	}
-	close(m.done)
+	m.logger.Info("Stopping game monitor")
+	m.cancel()
+	<-m.done
+	m.cancel = nil
+	m.done = nil
 }
@@ -3,13 +3,16 @@ package mon
 import (
 	"context"
 	"errors"
-	"math/big"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/api"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -25,54 +28,199 @@ func TestMonitor_MinGameTimestamp(t *testing.T) {
 
 	t.Run("ZeroGameWindow", func(t *testing.T) {
 		monitor, _, _, _, _ := setupMonitorTest(t)
-		monitor.gameWindow = time.Duration(0)
+		monitor.creationWindow = time.Duration(0)
+		monitor.resolutionWindow = time.Duration(0)
 		require.Equal(t, monitor.minGameTimestamp(), uint64(0))
 	})
 
 	t.Run("ZeroClock", func(t *testing.T) {
 		monitor, _, _, _, _ := setupMonitorTest(t)
-		monitor.gameWindow = time.Minute
+		monitor.creationWindow = time.Minute
+		monitor.resolutionWindow = time.Minute
 		monitor.clock = clock.NewDeterministicClock(time.Unix(0, 0))
 		require.Equal(t, uint64(0), monitor.minGameTimestamp())
 	})
 
 	t.Run("ValidArithmetic", func(t *testing.T) {
 		monitor, _, _, _, _ := setupMonitorTest(t)
-		monitor.gameWindow = time.Minute
+		monitor.creationWindow = time.Minute
+		monitor.resolutionWindow = time.Minute
 		frozen := time.Unix(int64(time.Hour.Seconds()), 0)
 		monitor.clock = clock.NewDeterministicClock(frozen)
 		expected := uint64(frozen.Add(-time.Minute).Unix())
 		require.Equal(t, monitor.minGameTimestamp(), expected)
 	})
+
+	t.Run("UsesWiderWindow", func(t *testing.T) {
+		monitor, _, _, _, _ := setupMonitorTest(t)
+		frozen := time.Unix(int64(time.Hour.Seconds()), 0)
+		monitor.clock = clock.NewDeterministicClock(frozen)
+		monitor.creationWindow = time.Minute
+		monitor.resolutionWindow = 10 * time.Minute
+		require.Equal(t, uint64(frozen.Add(-10*time.Minute).Unix()), monitor.minGameTimestamp())
+
+		monitor.creationWindow = 20 * time.Minute
+		require.Equal(t, uint64(frozen.Add(-20*time.Minute).Unix()), monitor.minGameTimestamp())
+
+		monitor.resolutionWindow = 0
+		require.Equal(t, uint64(0), monitor.minGameTimestamp())
+	})
+}
+
+func TestMonitor_Windows(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(100_000_000, 0)
+	creationWindow := 3 * 24 * time.Hour
+	resolutionWindow := 30 * 24 * time.Hour
+	minCreation := uint64(now.Add(-creationWindow).Unix())
+	minResolution := uint64(now.Add(-resolutionWindow).Unix())
+
+	setup := func(t *testing.T) (*gameMonitor, *mockExtractor, *mockDetector, *mockForecast) {
+		monitor, factory, detector, forecast, _ := setupMonitorTest(t)
+		monitor.clock = clock.NewDeterministicClock(now)
+		monitor.creationWindow = creationWindow
+		monitor.resolutionWindow = resolutionWindow
+		factory.games = []*monTypes.EnrichedGameData{
+			newEnrichedGameData(common.Address{0x01}, uint64(now.Unix())),
+			newEnrichedGameData(common.Address{0x02}, minCreation),
+			newEnrichedGameData(common.Address{0x03}, minCreation-1),
+			newEnrichedGameData(common.Address{0x04}, minResolution),
+		}
+		return monitor, factory, detector, forecast
+	}
+
+	t.Run("ExtractsWiderWindow", func(t *testing.T) {
+		monitor, factory, _, _ := setup(t)
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, minResolution, factory.minTimestamp)
+	})
+
+	t.Run("TagsGamesAtBoundaries", func(t *testing.T) {
+		monitor, factory, _, _ := setup(t)
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		games := factory.games
+		require.True(t, games[0].InCreationWindow)
+		require.True(t, games[0].InResolutionWindow)
+		require.True(t, games[1].InCreationWindow, "game created at the start of the window is included")
+		require.True(t, games[1].InResolutionWindow)
+		require.False(t, games[2].InCreationWindow, "game created before the start of the window is excluded")
+		require.True(t, games[2].InResolutionWindow)
+		require.False(t, games[3].InCreationWindow)
+		require.True(t, games[3].InResolutionWindow)
+	})
+
+	t.Run("ForecastsCreationWindow", func(t *testing.T) {
+		monitor, factory, detector, forecast := setup(t)
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, factory.games[:2], forecast.games)
+		require.Equal(t, factory.games, detector.games)
+	})
+
+	t.Run("DetectsResolutionWindow", func(t *testing.T) {
+		monitor, factory, detector, forecast := setup(t)
+		monitor.creationWindow = resolutionWindow
+		monitor.resolutionWindow = creationWindow
+		var resolved []*monTypes.EnrichedGameData
+		monitor.resolve = func(ctx context.Context, games []*monTypes.EnrichedGameData) {
+			resolved = games
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, factory.games[:2], detector.games)
+		require.Equal(t, factory.games[:2], resolved)
+		require.Equal(t, factory.games, forecast.games)
+	})
+
+	t.Run("TracksClaimantsInResolutionWindow", func(t *testing.T) {
+		monitor, factory, _, _ := setup(t)
+		monitor.resolutionWindow = creationWindow
+		claimants := []monTypes.ClaimantExposure{{Claimant: common.Address{0xaa}, Claims: 1}}
+		var tracked []*monTypes.EnrichedGameData
+		monitor.claimants = func(games []*monTypes.EnrichedGameData) []monTypes.ClaimantExposure {
+			tracked = games
+			return claimants
+		}
+		var published api.Cycle
+		monitor.publish = func(cycle api.Cycle) {
+			published = cycle
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, factory.games[:2], tracked)
+		require.Equal(t, claimants, published.Claimants)
+	})
+
+	t.Run("ChecksL1HeadsOfAllGames", func(t *testing.T) {
+		monitor, factory, _, _ := setup(t)
+		monitor.resolutionWindow = creationWindow
+		var checked []*monTypes.EnrichedGameData
+		monitor.l1Heads = func(ctx context.Context, games []*monTypes.EnrichedGameData) {
+			checked = games
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, factory.games, checked)
+	})
+
+	t.Run("SingleWindowIncludesAllGames", func(t *testing.T) {
+		monitor, factory, detector, forecast := setup(t)
+		monitor.creationWindow = resolutionWindow
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, factory.games, forecast.games)
+		require.Equal(t, factory.games, detector.games)
+		for _, game := range factory.games {
+			require.True(t, game.InCreationWindow)
+			require.True(t, game.InResolutionWindow)
+		}
+	})
 }
 
 func TestMonitor_MonitorGames(t *testing.T) {
 	t.Parallel()
 
-	t.Run("FailedFetchBlocknumber", func(t *testing.T) {
-		monitor, _, _, _, _ := setupMonitorTest(t)
+	t.Run("FailedFetchBlock", func(t *testing.T) {
+		monitor, factory, detector, _, _ := setupMonitorTest(t)
 		boom := errors.New("boom")
-		monitor.fetchBlockNumber = func(ctx context.Context) (uint64, error) {
-			return 0, boom
+		monitor.fetchBlock = func(ctx context.Context) (eth.BlockID, error) {
+			return eth.BlockID{}, boom
 		}
-		err := monitor.monitorGames()
+		err := monitor.monitorGames(context.Background())
 		require.ErrorIs(t, err, boom)
+		require.Equal(t, phaseFetchBlock, failedPhase(err))
+		require.Equal(t, 0, factory.calls)
+		require.Equal(t, 0, detector.calls)
 	})
 
-	t.Run("FailedFetchBlockHash", func(t *testing.T) {
-		monitor, _, _, _, _ := setupMonitorTest(t)
-		boom := errors.New("boom")
-		monitor.fetchBlockHash = func(ctx context.Context, number *big.Int) (common.Hash, error) {
-			return common.Hash{}, boom
+	t.Run("ExtractsAtFetchedBlock", func(t *testing.T) {
+		monitor, factory, _, _, _ := setupMonitorTest(t)
+		block := eth.BlockID{Hash: common.Hash{0xaa}, Number: 42}
+		monitor.fetchBlock = func(ctx context.Context) (eth.BlockID, error) {
+			return block, nil
 		}
-		err := monitor.monitorGames()
-		require.ErrorIs(t, err, boom)
+		var recorded uint64
+		monitor.recordBlock = func(number uint64) {
+			recorded = number
+		}
+		err := monitor.monitorGames(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, block, factory.block)
+		require.Equal(t, block.Number, recorded)
+	})
+
+	t.Run("NoRecordWhenExtractFails", func(t *testing.T) {
+		monitor, factory, _, _, _ := setupMonitorTest(t)
+		factory.fetchErr = errors.New("boom")
+		recorded := false
+		monitor.recordBlock = func(number uint64) {
+			recorded = true
+		}
+		err := monitor.monitorGames(context.Background())
+		require.ErrorIs(t, err, factory.fetchErr)
+		require.Equal(t, phaseExtract, failedPhase(err))
+		require.False(t, recorded)
 	})
 
 	t.Run("DetectsWithNoGames", func(t *testing.T) {
 		monitor, factory, detector, forecast, delays := setupMonitorTest(t)
 		factory.games = []*monTypes.EnrichedGameData{}
-		err := monitor.monitorGames()
+		err := monitor.monitorGames(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 1, detector.calls)
 		require.Equal(t, 1, forecast.calls)
@@ -82,12 +230,153 @@ func TestMonitor_MonitorGames(t *testing.T) {
 	t.Run("DetectsMultipleGames", func(t *testing.T) {
 		monitor, factory, detector, forecast, delays := setupMonitorTest(t)
 		factory.games = []*monTypes.EnrichedGameData{{}, {}, {}}
-		err := monitor.monitorGames()
+		err := monitor.monitorGames(context.Background())
 		require.NoError(t, err)
 		require.Equal(t, 1, detector.calls)
 		require.Equal(t, 1, forecast.calls)
 		require.Equal(t, 1, delays.calls)
 	})
+
+	t.Run("FiltersGamesBeforeDetection", func(t *testing.T) {
+		monitor, factory, detector, forecast, _ := setupMonitorTest(t)
+		monitor.creationWindow = 0
+		monitor.resolutionWindow = 0
+		factory.games = []*monTypes.EnrichedGameData{
+			newEnrichedGameData(common.Address{0x01}, 9999),
+			newEnrichedGameData(common.Address{0x02}, 9999),
+		}
+		monitor.filter = func(games []*monTypes.EnrichedGameData) []*monTypes.EnrichedGameData {
+			return games[1:]
+		}
+		var published api.Cycle
+		monitor.publish = func(cycle api.Cycle) {
+			published = cycle
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, factory.games[1:], detector.games)
+		require.Equal(t, factory.games[1:], forecast.games)
+		require.Equal(t, factory.games[1:], published.Games)
+	})
+
+	t.Run("DetectsPreimages", func(t *testing.T) {
+		monitor, factory, _, _, _ := setupMonitorTest(t)
+		factory.games = []*monTypes.EnrichedGameData{{}, {}}
+		proposals := []monTypes.LargePreimageProposal{{Oracle: common.Address{0xaa}}}
+		var extractedGames []*monTypes.EnrichedGameData
+		monitor.extractPreimages = func(ctx context.Context, block eth.BlockID, games []*monTypes.EnrichedGameData) ([]monTypes.LargePreimageProposal, error) {
+			extractedGames = games
+			return proposals, nil
+		}
+		var detected []monTypes.LargePreimageProposal
+		monitor.detectPreimages = func(ctx context.Context, proposals []monTypes.LargePreimageProposal) {
+			detected = proposals
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, factory.games, extractedGames)
+		require.Equal(t, proposals, detected)
+	})
+
+	t.Run("PreimageErrorDoesNotFailCycle", func(t *testing.T) {
+		monitor, _, _, forecast, _ := setupMonitorTest(t)
+		monitor.extractPreimages = func(ctx context.Context, block eth.BlockID, games []*monTypes.EnrichedGameData) ([]monTypes.LargePreimageProposal, error) {
+			return nil, errors.New("boom")
+		}
+		detected := false
+		monitor.detectPreimages = func(ctx context.Context, proposals []monTypes.LargePreimageProposal) {
+			detected = true
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.False(t, detected)
+		require.Equal(t, 1, forecast.calls)
+	})
+
+	t.Run("RecordsPhaseDurations", func(t *testing.T) {
+		monitor, factory, _, _, _ := setupMonitorTest(t)
+		cl := clock.NewDeterministicClock(time.Unix(10_000, 0))
+		monitor.clock = cl
+		factory.games = []*monTypes.EnrichedGameData{{}, {}, {}}
+		extract := monitor.extract
+		monitor.extract = func(ctx context.Context, block eth.BlockID, minTimestamp uint64) ([]*monTypes.EnrichedGameData, error) {
+			cl.AdvanceTime(3 * time.Second)
+			return extract(ctx, block, minTimestamp)
+		}
+		monitor.filter = func(games []*monTypes.EnrichedGameData) []*monTypes.EnrichedGameData {
+			return games[:1]
+		}
+		phases := make(map[string]time.Duration)
+		monitor.recordPhase = func(phase string, duration time.Duration) {
+			phases[phase] = duration
+		}
+		recordedGames := -1
+		monitor.recordGames = func(count int) {
+			recordedGames = count
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, map[string]time.Duration{
+			phaseFetchBlock: 0,
+			phaseExtract:    3 * time.Second,
+			phaseDelays:     0,
+			phaseDetect:     0,
+			phaseForecast:   0,
+			phaseResolve:    0,
+		}, phases)
+		// Games are counted before they are filtered
+		require.Equal(t, 3, recordedGames)
+	})
+
+	t.Run("RecordsPhasesOfFailedCycle", func(t *testing.T) {
+		monitor, factory, _, _, _ := setupMonitorTest(t)
+		factory.fetchErr = errors.New("boom")
+		var phases []string
+		monitor.recordPhase = func(phase string, duration time.Duration) {
+			phases = append(phases, phase)
+		}
+		recordedGames := false
+		monitor.recordGames = func(count int) {
+			recordedGames = true
+		}
+		require.ErrorIs(t, monitor.monitorGames(context.Background()), factory.fetchErr)
+		require.Equal(t, []string{phaseFetchBlock, phaseExtract}, phases)
+		require.False(t, recordedGames)
+	})
+
+	t.Run("PublishesCycle", func(t *testing.T) {
+		monitor, factory, _, forecast, _ := setupMonitorTest(t)
+		block := eth.BlockID{Hash: common.Hash{0xaa}, Number: 42}
+		monitor.fetchBlock = func(ctx context.Context) (eth.BlockID, error) {
+			return block, nil
+		}
+		addr := common.Address{0xbb}
+		factory.games = []*monTypes.EnrichedGameData{newEnrichedGameData(addr, 9999)}
+		forecast.forecasts = map[common.Address]monTypes.GameForecast{
+			addr: {Status: types.GameStatusDefenderWon, AgreeRoot: true},
+		}
+		var published []api.Cycle
+		monitor.publish = func(cycle api.Cycle) {
+			published = append(published, cycle)
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Len(t, published, 1)
+		require.NoError(t, published[0].Err)
+		require.Equal(t, block, published[0].Block)
+		require.Equal(t, factory.games, published[0].Games)
+		require.Equal(t, forecast.forecasts, published[0].Forecasts)
+		require.False(t, published[0].Start.IsZero())
+	})
+
+	t.Run("PublishesFailedCycle", func(t *testing.T) {
+		monitor, factory, _, _, _ := setupMonitorTest(t)
+		factory.fetchErr = errors.New("boom")
+		var published []api.Cycle
+		monitor.publish = func(cycle api.Cycle) {
+			published = append(published, cycle)
+		}
+		err := monitor.monitorGames(context.Background())
+		require.ErrorIs(t, err, factory.fetchErr)
+		require.Len(t, published, 1)
+		require.Equal(t, err, published[0].Err)
+		require.Nil(t, published[0].Games)
+	})
 }
 
 func TestMonitor_StartMonitoring(t *testing.T) {
@@ -100,7 +389,7 @@ func TestMonitor_StartMonitoring(t *testing.T) {
 
 		monitor.StartMonitoring()
 		require.Eventually(t, func() bool {
-			return detector.calls >= 2
+			return detector.Calls() >= 2
 		}, time.Second, 50*time.Millisecond)
 		monitor.StopMonitoring()
 		require.Equal(t, len(factory.games), detector.calls) // Each game's status is recorded twice
@@ -112,13 +401,438 @@ func TestMonitor_StartMonitoring(t *testing.T) {
 
 		monitor.StartMonitoring()
 		require.Eventually(t, func() bool {
-			return factory.calls > 0
+			return factory.Calls() > 0
 		}, time.Second, 50*time.Millisecond)
 		monitor.StopMonitoring()
 		require.Equal(t, 0, detector.calls)
 	})
 }
 
+func TestMonitor_CycleTimeout(t *testing.T) {
+	// slowExtract blocks until the cycle is aborted on its first call, then responds immediately.
+	slowExtract := func(games []*monTypes.EnrichedGameData) Extract {
+		var calls atomic.Int32
+		return func(ctx context.Context, _ eth.BlockID, _ uint64) ([]*monTypes.EnrichedGameData, error) {
+			if calls.Add(1) == 1 {
+				<-ctx.Done()
+				// Return the games loaded so far without an error, like the extractor does when games fail to load
+				return games[:1], nil
+			}
+			return games, nil
+		}
+	}
+
+	t.Run("AbortsAndRecovers", func(t *testing.T) {
+		monitor, _, detector, forecast, _ := setupMonitorTest(t)
+		monitor.cycleTimeout = 50 * time.Millisecond
+		var timeouts []string
+		monitor.recordTimeout = func(phase string) {
+			timeouts = append(timeouts, phase)
+		}
+		monitor.extract = slowExtract([]*monTypes.EnrichedGameData{{}, {}})
+
+		err := monitor.monitorGames(context.Background())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, phaseExtract)
+		require.Equal(t, phaseExtract, failedPhase(err))
+		require.Equal(t, []string{phaseExtract}, timeouts)
+		require.Equal(t, 0, detector.calls, "should discard partial results")
+		require.Equal(t, 0, forecast.calls)
+
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, 1, detector.calls)
+		require.Equal(t, 1, forecast.calls)
+		require.Equal(t, []string{phaseExtract}, timeouts)
+	})
+
+	t.Run("SlowDetect", func(t *testing.T) {
+		monitor, _, _, forecast, _ := setupMonitorTest(t)
+		monitor.cycleTimeout = 50 * time.Millisecond
+		var timeouts []string
+		monitor.recordTimeout = func(phase string) {
+			timeouts = append(timeouts, phase)
+		}
+		monitor.detect = func(ctx context.Context, _ []*monTypes.EnrichedGameData) {
+			<-ctx.Done()
+		}
+		err := monitor.monitorGames(context.Background())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, []string{phaseDetect}, timeouts)
+		require.Equal(t, 0, forecast.calls)
+	})
+
+	t.Run("ParentCancelledIsNotTimeout", func(t *testing.T) {
+		monitor, _, _, _, _ := setupMonitorTest(t)
+		monitor.recordTimeout = func(phase string) {
+			t.Fatalf("unexpected timeout during %v", phase)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := monitor.monitorGames(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("NextCycleProceeds", func(t *testing.T) {
+		monitor, _, detector, _, _ := setupMonitorTest(t)
+		monitor.cycleTimeout = 50 * time.Millisecond
+		monitor.extract = slowExtract([]*monTypes.EnrichedGameData{{}})
+		monitor.StartMonitoring()
+		require.Eventually(t, func() bool {
+			return detector.Calls() >= 1
+		}, 5*time.Second, 10*time.Millisecond)
+		monitor.StopMonitoring()
+	})
+}
+
+func TestMonitor_Trigger(t *testing.T) {
+	debounce := time.Second
+	setup := func(t *testing.T) (*gameMonitor, *mockExtractor, *clock.DeterministicClock) {
+		monitor, extractor, _, _, _ := setupMonitorTest(t)
+		cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+		monitor.clock = cl
+		monitor.monitorInterval = time.Hour
+		monitor.triggerDebounce = debounce
+		return monitor, extractor, cl
+	}
+	// awaitCycles advances the clock past the debounce until the expected number of cycles have run.
+	awaitCycles := func(t *testing.T, cl *clock.DeterministicClock, extractor *mockExtractor, expected int) {
+		require.Eventually(t, func() bool {
+			cl.AdvanceTime(debounce)
+			return extractor.Calls() >= expected
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, expected, extractor.Calls())
+	}
+
+	t.Run("OneCyclePerBurst", func(t *testing.T) {
+		monitor, extractor, cl := setup(t)
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+
+		for i := 0; i < 5; i++ {
+			monitor.Trigger()
+		}
+		awaitCycles(t, cl, extractor, 1)
+
+		// No further cycles are run for the same burst
+		cl.AdvanceTime(10 * debounce)
+		time.Sleep(50 * time.Millisecond)
+		require.Equal(t, 1, extractor.Calls())
+
+		for i := 0; i < 5; i++ {
+			monitor.Trigger()
+		}
+		awaitCycles(t, cl, extractor, 2)
+	})
+
+	t.Run("WaitsForDebounce", func(t *testing.T) {
+		monitor, extractor, cl := setup(t)
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+
+		monitor.Trigger()
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		cl.AdvanceTime(debounce - time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		require.Zero(t, extractor.Calls())
+		awaitCycles(t, cl, extractor, 1)
+	})
+
+	t.Run("TriggerDuringCycleRunsOneMoreCycle", func(t *testing.T) {
+		monitor, extractor, cl := setup(t)
+		started := make(chan struct{}, 1)
+		release := make(chan struct{})
+		monitor.extract = func(ctx context.Context, block eth.BlockID, minTimestamp uint64) ([]*monTypes.EnrichedGameData, error) {
+			started <- struct{}{}
+			<-release
+			return extractor.Extract(ctx, block, minTimestamp)
+		}
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+
+		monitor.Trigger()
+		require.Eventually(t, func() bool {
+			cl.AdvanceTime(debounce)
+			return len(started) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		<-started
+		for i := 0; i < 5; i++ {
+			monitor.Trigger()
+		}
+		close(release)
+		awaitCycles(t, cl, extractor, 2)
+		<-started
+		cl.AdvanceTime(10 * debounce)
+		time.Sleep(50 * time.Millisecond)
+		require.Equal(t, 2, extractor.Calls())
+	})
+
+	t.Run("StopWhileDebouncing", func(t *testing.T) {
+		monitor, extractor, cl := setup(t)
+		monitor.StartMonitoring()
+		monitor.Trigger()
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		monitor.StopMonitoring()
+		require.Zero(t, extractor.Calls())
+	})
+}
+
+func TestMonitor_Scheduling(t *testing.T) {
+	interval := time.Minute
+	setup := func(t *testing.T) (*gameMonitor, *mockExtractor, *clock.DeterministicClock, *atomic.Int32) {
+		monitor, extractor, _, _, _ := setupMonitorTest(t)
+		cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+		monitor.clock = cl
+		monitor.monitorInterval = interval
+		var skipped atomic.Int32
+		monitor.recordSkipped = func() {
+			skipped.Add(1)
+		}
+		// Use half the maximum jitter so the delay is predictable
+		monitor.jitter = func(limit time.Duration) time.Duration {
+			return limit / 2
+		}
+		return monitor, extractor, cl, &skipped
+	}
+
+	t.Run("SkipsTicksWhileCycleRunning", func(t *testing.T) {
+		monitor, extractor, cl, skipped := setup(t)
+		var active atomic.Int32
+		var concurrent atomic.Bool
+		started := make(chan struct{}, 10)
+		release := make(chan struct{})
+		monitor.extract = func(ctx context.Context, block eth.BlockID, minTimestamp uint64) ([]*monTypes.EnrichedGameData, error) {
+			if active.Add(1) > 1 {
+				concurrent.Store(true)
+			}
+			defer active.Add(-1)
+			started <- struct{}{}
+			<-release
+			return extractor.Extract(ctx, block, minTimestamp)
+		}
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		cl.AdvanceTime(interval)
+		<-started
+
+		// Each tick while the cycle is slow is skipped rather than starting another cycle
+		require.Eventually(t, func() bool {
+			cl.AdvanceTime(interval)
+			return skipped.Load() >= 3
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Empty(t, started)
+
+		// Once the slow cycle completes, the next tick runs a cycle
+		close(release)
+		require.Eventually(t, func() bool {
+			return extractor.Calls() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		skippedBefore := skipped.Load()
+		require.Eventually(t, func() bool {
+			cl.AdvanceTime(interval)
+			return extractor.Calls() >= 2
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, skippedBefore, skipped.Load())
+		require.False(t, concurrent.Load(), "should never run concurrent cycles")
+	})
+
+	t.Run("TickDuringTriggeredCycleSkipped", func(t *testing.T) {
+		monitor, extractor, cl, skipped := setup(t)
+		monitor.triggerDebounce = time.Second
+		started := make(chan struct{}, 10)
+		release := make(chan struct{})
+		monitor.extract = func(ctx context.Context, block eth.BlockID, minTimestamp uint64) ([]*monTypes.EnrichedGameData, error) {
+			started <- struct{}{}
+			<-release
+			return extractor.Extract(ctx, block, minTimestamp)
+		}
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+
+		monitor.Trigger()
+		require.Eventually(t, func() bool {
+			cl.AdvanceTime(time.Second)
+			return len(started) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Eventually(t, func() bool {
+			cl.AdvanceTime(interval)
+			return skipped.Load() >= 1
+		}, 5*time.Second, 10*time.Millisecond)
+		close(release)
+		require.Eventually(t, func() bool {
+			return extractor.Calls() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Len(t, started, 1)
+	})
+
+	t.Run("StartupJitter", func(t *testing.T) {
+		monitor, extractor, cl, _ := setup(t)
+		monitor.startupJitter = 20 * time.Second
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+
+		// The ticker is only started after the startup jitter
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		cl.AdvanceTime(10 * time.Second)
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		cl.AdvanceTime(interval - time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		require.Zero(t, extractor.Calls())
+		cl.AdvanceTime(time.Millisecond)
+		require.Eventually(t, func() bool {
+			return extractor.Calls() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("IntervalJitter", func(t *testing.T) {
+		monitor, extractor, cl, _ := setup(t)
+		monitor.intervalJitter = 10 * time.Second
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		cl.AdvanceTime(interval)
+		// The cycle is delayed by the jitter
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		cl.AdvanceTime(5*time.Second - time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		require.Zero(t, extractor.Calls())
+		cl.AdvanceTime(time.Millisecond)
+		require.Eventually(t, func() bool {
+			return extractor.Calls() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("StopDuringJitter", func(t *testing.T) {
+		monitor, extractor, cl, _ := setup(t)
+		monitor.intervalJitter = 10 * time.Second
+		monitor.StartMonitoring()
+		done := monitor.done
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		cl.AdvanceTime(interval)
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(5*time.Second))
+		monitor.StopMonitoring()
+		requireLoopExited(t, done)
+		require.Zero(t, extractor.Calls())
+	})
+}
+
+func TestRandomDuration(t *testing.T) {
+	require.Zero(t, randomDuration(0))
+	require.Zero(t, randomDuration(-time.Second))
+	for i := 0; i < 100; i++ {
+		d := randomDuration(time.Second)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.Less(t, d, time.Second)
+	}
+}
+
+func TestMonitor_Lifecycle(t *testing.T) {
+	t.Run("RestartAfterStop", func(t *testing.T) {
+		monitor, _, detector, _, _ := setupMonitorTest(t)
+		for i := 1; i <= 3; i++ {
+			monitor.StartMonitoring()
+			done := monitor.done
+			require.Eventually(t, func() bool {
+				return detector.Calls() >= i
+			}, time.Second, 10*time.Millisecond)
+			monitor.StopMonitoring()
+			requireLoopExited(t, done)
+			require.Nil(t, monitor.cancel)
+		}
+	})
+
+	t.Run("StopWithoutStart", func(t *testing.T) {
+		monitor, _, _, _, _ := setupMonitorTest(t)
+		monitor.StopMonitoring()
+		require.Nil(t, monitor.cancel)
+	})
+
+	t.Run("DoubleStop", func(t *testing.T) {
+		monitor, _, _, _, _ := setupMonitorTest(t)
+		monitor.StartMonitoring()
+		done := monitor.done
+		monitor.StopMonitoring()
+		monitor.StopMonitoring()
+		requireLoopExited(t, done)
+	})
+
+	t.Run("DoubleStart", func(t *testing.T) {
+		monitor, _, _, _, _ := setupMonitorTest(t)
+		monitor.StartMonitoring()
+		done := monitor.done
+		monitor.StartMonitoring()
+		require.Equal(t, done, monitor.done, "should not start a second loop")
+		monitor.StopMonitoring()
+		requireLoopExited(t, done)
+	})
+
+	t.Run("ConcurrentStartStop", func(t *testing.T) {
+		monitor, _, _, _, _ := setupMonitorTest(t)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				monitor.StartMonitoring()
+			}()
+			go func() {
+				defer wg.Done()
+				monitor.StopMonitoring()
+			}()
+		}
+		wg.Wait()
+		monitor.StartMonitoring()
+		done := monitor.done
+		monitor.StopMonitoring()
+		requireLoopExited(t, done)
+	})
+
+	t.Run("StopCancelsInFlightCycle", func(t *testing.T) {
+		monitor, _, detector, _, _ := setupMonitorTest(t)
+		started := make(chan struct{})
+		var once sync.Once
+		var extractErr error
+		monitor.extract = func(ctx context.Context, _ eth.BlockID, _ uint64) ([]*monTypes.EnrichedGameData, error) {
+			once.Do(func() { close(started) })
+			<-ctx.Done()
+			extractErr = ctx.Err()
+			return nil, extractErr
+		}
+		monitor.StartMonitoring()
+		done := monitor.done
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("monitor did not start extracting games")
+		}
+		monitor.StopMonitoring()
+		requireLoopExited(t, done)
+		require.ErrorIs(t, extractErr, context.Canceled)
+		require.Equal(t, 0, detector.calls)
+	})
+
+	t.Run("ParentContextCancelled", func(t *testing.T) {
+		monitor, _, _, _, _ := setupMonitorTest(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		monitor.ctx = ctx
+		monitor.StartMonitoring()
+		done := monitor.done
+		cancel()
+		requireLoopExited(t, done)
+		monitor.StopMonitoring()
+	})
+}
+
+func requireLoopExited(t *testing.T, done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("monitor loop did not exit")
+	}
+}
+
 func newEnrichedGameData(proxy common.Address, timestamp uint64) *monTypes.EnrichedGameData {
 	return &monTypes.EnrichedGameData{
 		GameMetadata: types.GameMetadata{
@@ -131,12 +845,11 @@ func newEnrichedGameData(proxy common.Address, timestamp uint64) *monTypes.Enric
 
 func setupMonitorTest(t *testing.T) (*gameMonitor, *mockExtractor, *mockDetector, *mockForecast, *mockDelayCalculator) {
 	logger := testlog.Logger(t, log.LvlDebug)
-	fetchBlockNum := func(ctx context.Context) (uint64, error) {
-		return 1, nil
-	}
-	fetchBlockHash := func(ctx context.Context, number *big.Int) (common.Hash, error) {
-		return common.Hash{}, nil
+	fetchBlock := func(ctx context.Context) (eth.BlockID, error) {
+		return eth.BlockID{Number: 1}, nil
 	}
+	recordBlock := func(number uint64) {}
+	recordTimeout := func(phase string) {}
 	monitorInterval := time.Duration(100 * time.Millisecond)
 	cl := clock.NewAdvancingClock(10 * time.Millisecond)
 	cl.Start()
@@ -144,19 +857,33 @@ func setupMonitorTest(t *testing.T) (*gameMonitor, *mockExtractor, *mockDetector
 	detect := &mockDetector{}
 	forecast := &mockForecast{}
 	delays := &mockDelayCalculator{}
-	monitor := newGameMonitor(
-		context.Background(),
-		logger,
-		cl,
-		monitorInterval,
-		time.Duration(10*time.Second),
-		delays.RecordClaimResolutionDelayMax,
-		detect.Detect,
-		forecast.Forecast,
-		extractor.Extract,
-		fetchBlockNum,
-		fetchBlockHash,
-	)
+	monitor := newGameMonitor(context.Background(), logger, cl, gameMonitorDeps{
+		creationWindow:   10 * time.Second,
+		resolutionWindow: 10 * time.Second,
+		monitorInterval:  monitorInterval,
+		cycleTimeout:     time.Second,
+		delays:           delays.RecordClaimResolutionDelays,
+		claimants:        func(games []*monTypes.EnrichedGameData) []monTypes.ClaimantExposure { return nil },
+		detect:           detect.Detect,
+		credits:          func(ctx context.Context, games []*monTypes.EnrichedGameData) {},
+		actors:           func(ctx context.Context, block eth.BlockID, games []*monTypes.EnrichedGameData) {},
+		l1Heads:          func(ctx context.Context, games []*monTypes.EnrichedGameData) {},
+		forecast:         forecast.Forecast,
+		resolve:          func(ctx context.Context, games []*monTypes.EnrichedGameData) {},
+		extract:          extractor.Extract,
+		filter:           func(games []*monTypes.EnrichedGameData) []*monTypes.EnrichedGameData { return games },
+		fetchBlock:       fetchBlock,
+		recordBlock:      recordBlock,
+		extractPreimages: func(ctx context.Context, block eth.BlockID, games []*monTypes.EnrichedGameData) ([]monTypes.LargePreimageProposal, error) {
+			return nil, nil
+		},
+		detectPreimages: func(ctx context.Context, proposals []monTypes.LargePreimageProposal) {},
+		recordTimeout:   recordTimeout,
+		recordSkipped:   func() {},
+		recordPhase:     func(phase string, duration time.Duration) {},
+		recordGames:     func(count int) {},
+		publish:         func(cycle api.Cycle) {},
+	})
 	return monitor, extractor, detect, forecast, delays
 }
 
@@ -164,39 +891,61 @@ type mockDelayCalculator struct {
 	calls int
 }
 
-func (m *mockDelayCalculator) RecordClaimResolutionDelayMax(games []*monTypes.EnrichedGameData) {
+func (m *mockDelayCalculator) RecordClaimResolutionDelays(games []*monTypes.EnrichedGameData) {
 	m.calls++
 }
 
 type mockForecast struct {
-	calls int
+	calls     int
+	games     []*monTypes.EnrichedGameData
+	forecasts map[common.Address]monTypes.GameForecast
 }
 
-func (m *mockForecast) Forecast(ctx context.Context, games []*monTypes.EnrichedGameData) {
+func (m *mockForecast) Forecast(ctx context.Context, games []*monTypes.EnrichedGameData) map[common.Address]monTypes.GameForecast {
 	m.calls++
+	m.games = games
+	return m.forecasts
 }
 
 type mockDetector struct {
+	lock  sync.Mutex
 	calls int
+	games []*monTypes.EnrichedGameData
 }
 
 func (m *mockDetector) Detect(ctx context.Context, games []*monTypes.EnrichedGameData) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	m.calls++
+	m.games = games
+}
+
+func (m *mockDetector) Calls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.calls
 }
 
 type mockExtractor struct {
-	fetchErr   error
-	calls      int
-	maxSuccess int
-	games      []*monTypes.EnrichedGameData
+	lock         sync.Mutex
+	fetchErr     error
+	calls        int
+	maxSuccess   int
+	games        []*monTypes.EnrichedGameData
+	block        eth.BlockID
+	minTimestamp uint64
 }
 
 func (m *mockExtractor) Extract(
 	_ context.Context,
-	_ common.Hash,
-	_ uint64,
+	block eth.BlockID,
+	minTimestamp uint64,
 ) ([]*monTypes.EnrichedGameData, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	m.calls++
+	m.block = block
+	m.minTimestamp = minTimestamp
 	if m.fetchErr != nil {
 		return nil, m.fetchErr
 	}
@@ -205,3 +954,9 @@ func (m *mockExtractor) Extract(
 	}
 	return m.games, nil
 }
+
+func (m *mockExtractor) Calls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.calls
+}
@@ -0,0 +1,51 @@
+package mon
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type PreimageMetrics interface {
+	RecordLargePreimageProposals(state string, count int)
+}
+
+type preimageDetector struct {
+	logger         log.Logger
+	metrics        PreimageMetrics
+	clock          clock.Clock
+	expiringWindow time.Duration
+}
+
+func newPreimageDetector(logger log.Logger, metrics PreimageMetrics, cl clock.Clock, expiringWindow time.Duration) *preimageDetector {
+	return &preimageDetector{
+		logger:         logger,
+		metrics:        metrics,
+		clock:          cl,
+		expiringWindow: expiringWindow,
+	}
+}
+
+// Detect records the number of large preimage proposals in each state and warns about unchallenged proposals whose
+// challenge period is about to end. Once it ends, an invalid proposal can be squeezed into the oracle and used to
+// win games.
+func (d *preimageDetector) Detect(_ context.Context, proposals []types.LargePreimageProposal) {
+	now := d.clock.Now()
+	counts := make(map[types.LargePreimageState]int)
+	for _, proposal := range proposals {
+		state := proposal.State(now, d.expiringWindow)
+		counts[state]++
+		if state == types.LargePreimageExpiring {
+			d.logger.Warn("Unchallenged large preimage proposal nearing challenge deadline",
+				"oracle", proposal.Oracle, "claimant", proposal.Claimant, "uuid", proposal.UUID,
+				"size", proposal.ClaimedSize, "deadline", proposal.ChallengeDeadline())
+		}
+	}
+	for _, state := range types.LargePreimageStates {
+		d.metrics.RecordLargePreimageProposals(string(state), counts[state])
+	}
+}
@@ -0,0 +1,128 @@
+package mon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	preimageOracle          = common.Address{0xaa}
+	preimageChallengePeriod = 24 * time.Hour
+	preimageExpiringWindow  = time.Hour
+	expiringPreimageLog     = "Unchallenged large preimage proposal nearing challenge deadline"
+)
+
+func TestPreimageDetector_Detect(t *testing.T) {
+	t.Run("NoProposals", func(t *testing.T) {
+		detector, m, _, logs := setupPreimageDetectorTest(t)
+		detector.Detect(context.Background(), nil)
+		require.Equal(t, map[string]int{
+			"uploading": 0,
+			"active":    0,
+			"expiring":  0,
+			"countered": 0,
+			"finalized": 0,
+		}, m.proposals)
+		requireExpiringWarnings(t, logs)
+	})
+
+	t.Run("ClassifiesEachState", func(t *testing.T) {
+		detector, m, cl, logs := setupPreimageDetectorTest(t)
+		now := uint64(cl.Now().Unix())
+		period := uint64(preimageChallengePeriod.Seconds())
+		detector.Detect(context.Background(), []monTypes.LargePreimageProposal{
+			// Uploading
+			preimageProposal(1, 0, false),
+			preimageProposal(2, 0, false),
+			// Active
+			preimageProposal(3, now, false),
+			preimageProposal(4, now-period+uint64(preimageExpiringWindow.Seconds())+1, false),
+			// Expiring
+			preimageProposal(5, now-period+uint64(preimageExpiringWindow.Seconds()), false),
+			preimageProposal(6, now-period+1, false),
+			// Countered
+			preimageProposal(7, now-period+1, true),
+			preimageProposal(8, 0, true),
+			preimageProposal(9, now-2*period, true),
+			// Finalized
+			preimageProposal(10, now-period, false),
+		})
+		require.Equal(t, map[string]int{
+			"uploading": 2,
+			"active":    2,
+			"expiring":  2,
+			"countered": 3,
+			"finalized": 1,
+		}, m.proposals)
+		requireExpiringWarnings(t, logs, 5, 6)
+	})
+
+	t.Run("ExpiresAsTimePasses", func(t *testing.T) {
+		detector, m, cl, logs := setupPreimageDetectorTest(t)
+		proposals := []monTypes.LargePreimageProposal{preimageProposal(1, uint64(cl.Now().Unix()), false)}
+		detector.Detect(context.Background(), proposals)
+		require.Equal(t, 1, m.proposals["active"])
+		requireExpiringWarnings(t, logs)
+
+		cl.AdvanceTime(preimageChallengePeriod - preimageExpiringWindow)
+		detector.Detect(context.Background(), proposals)
+		require.Equal(t, 0, m.proposals["active"])
+		require.Equal(t, 1, m.proposals["expiring"])
+		requireExpiringWarnings(t, logs, 1)
+
+		cl.AdvanceTime(preimageExpiringWindow)
+		detector.Detect(context.Background(), proposals)
+		require.Equal(t, 0, m.proposals["expiring"])
+		require.Equal(t, 1, m.proposals["finalized"])
+		requireExpiringWarnings(t, logs, 1)
+	})
+}
+
+func setupPreimageDetectorTest(t *testing.T) (*preimageDetector, *mockPreimageMetrics, *clock.DeterministicClock, *testlog.CapturingHandler) {
+	logger, logs := testlog.CaptureLogger(t, log.LvlDebug)
+	m := &mockPreimageMetrics{proposals: make(map[string]int)}
+	cl := clock.NewDeterministicClock(time.Unix(10_000_000, 0))
+	detector := newPreimageDetector(logger, m, cl, preimageExpiringWindow)
+	return detector, m, cl, logs
+}
+
+func preimageProposal(uuid int64, timestamp uint64, countered bool) monTypes.LargePreimageProposal {
+	return monTypes.LargePreimageProposal{
+		LargePreimageMetaData: keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0x01}, UUID: big.NewInt(uuid)},
+			Timestamp:          timestamp,
+			Countered:          countered,
+		},
+		Oracle:          preimageOracle,
+		ChallengePeriod: preimageChallengePeriod,
+	}
+}
+
+func requireExpiringWarnings(t *testing.T, logs *testlog.CapturingHandler, uuids ...int64) {
+	levelFilter := testlog.NewLevelFilter(log.LevelWarn)
+	messageFilter := testlog.NewMessageFilter(expiringPreimageLog)
+	warnings := logs.FindLogs(levelFilter, messageFilter)
+	require.Len(t, warnings, len(uuids))
+	for i, uuid := range uuids {
+		require.Equal(t, big.NewInt(uuid), warnings[i].AttrValue("uuid"))
+		require.Equal(t, preimageOracle, warnings[i].AttrValue("oracle"))
+	}
+}
+
+type mockPreimageMetrics struct {
+	proposals map[string]int
+}
+
+func (m *mockPreimageMetrics) RecordLargePreimageProposals(state string, count int) {
+	m.proposals[state] = count
+}
@@ -0,0 +1,178 @@
+package resolution
+
+import (
+	"bytes"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OtherClaimants is the claimant label metrics of claimants outside the labelled set are aggregated under.
+const OtherClaimants = "other"
+
+type ClaimantMetrics interface {
+	RecordClaimantExposure(claimant string, honest bool, bonded *big.Int, pastClock int, maxDelay uint64, oldestClaimAge uint64)
+	RecordClaimantClaims(claimant string, honest bool, age string, count int)
+	RemoveClaimant(claimant string)
+}
+
+// ClaimantTracker aggregates the unresolved claims of each claimant across all games, showing which actors have the
+// most value locked in games and which are slow to resolve their claims.
+type ClaimantTracker struct {
+	metrics ClaimantMetrics
+	clock   clock.Clock
+	honest  map[common.Address]bool
+	limit   int
+
+	// labelled are the claimant labels metrics were recorded for in the last cycle, so the metrics of claimants that
+	// no longer have unresolved claims or drop out of the labelled set can be removed.
+	// Only accessed from the monitoring loop.
+	labelled map[string]bool
+}
+
+// NewClaimantTracker creates a tracker labelling the metrics of each of honestActors and at most limit other claimants.
+func NewClaimantTracker(metrics ClaimantMetrics, clock clock.Clock, honestActors []common.Address, limit uint) *ClaimantTracker {
+	honest := make(map[common.Address]bool, len(honestActors))
+	for _, actor := range honestActors {
+		honest[actor] = true
+	}
+	return &ClaimantTracker{
+		metrics:  metrics,
+		clock:    clock,
+		honest:   honest,
+		limit:    int(limit),
+		labelled: make(map[string]bool),
+	}
+}
+
+// RecordClaimants returns the unresolved claims of every claimant in games, ordered by the bonds posted with them,
+// largest first. Metrics are labelled with the claimant for honest actors and the claimants with the most bonds
+// posted, up to the limit. All other claimants are aggregated under OtherClaimants to bound the number of series.
+func (c *ClaimantTracker) RecordClaimants(games []*monTypes.EnrichedGameData) []monTypes.ClaimantExposure {
+	now := c.clock.Now()
+	byClaimant := make(map[common.Address]*monTypes.ClaimantExposure)
+	for _, game := range games {
+		for i := range game.Claims {
+			claim := &game.Claims[i]
+			// Claims with unknown bonds can't be identified as unresolved.
+			if claim.Bond == nil || claim.Bond.Cmp(monTypes.ResolvedBondAmount) == 0 {
+				continue
+			}
+			exposure, ok := byClaimant[claim.Claimant]
+			if !ok {
+				exposure = newClaimantExposure(claim.Claimant, c.honest[claim.Claimant])
+				byClaimant[claim.Claimant] = exposure
+			}
+			age := claimAge(now, claim)
+			exposure.Claims++
+			exposure.Bonded.Add(exposure.Bonded, claim.Bond)
+			exposure.ClaimsByAge[claimAgeBucket(age)]++
+			exposure.OldestClaimAge = max(exposure.OldestClaimAge, age)
+			if claim.Clock == nil {
+				continue
+			}
+			if delay, pastClock := overflowTime(now, game.Duration, claim); pastClock {
+				exposure.PastClock++
+				exposure.MaxDelay = max(exposure.MaxDelay, delay)
+			}
+		}
+	}
+	exposures := make([]monTypes.ClaimantExposure, 0, len(byClaimant))
+	for _, exposure := range byClaimant {
+		exposures = append(exposures, *exposure)
+	}
+	slices.SortFunc(exposures, func(a, b monTypes.ClaimantExposure) int {
+		if cmp := b.Bonded.Cmp(a.Bonded); cmp != 0 {
+			return cmp
+		}
+		if a.Claims != b.Claims {
+			return b.Claims - a.Claims
+		}
+		return bytes.Compare(a.Claimant[:], b.Claimant[:])
+	})
+	c.recordMetrics(exposures)
+	return exposures
+}
+
+func (c *ClaimantTracker) recordMetrics(exposures []monTypes.ClaimantExposure) {
+	labelled := make(map[string]bool)
+	other := newClaimantExposure(common.Address{}, false)
+	top := 0
+	for _, exposure := range exposures {
+		if !exposure.Honest {
+			if top >= c.limit {
+				mergeExposure(other, exposure)
+				continue
+			}
+			top++
+		}
+		claimant := exposure.Claimant.Hex()
+		c.record(claimant, exposure)
+		labelled[claimant] = true
+	}
+	if other.Claims > 0 {
+		c.record(OtherClaimants, *other)
+		labelled[OtherClaimants] = true
+	}
+	for claimant := range c.labelled {
+		if !labelled[claimant] {
+			c.metrics.RemoveClaimant(claimant)
+		}
+	}
+	c.labelled = labelled
+}
+
+func (c *ClaimantTracker) record(claimant string, exposure monTypes.ClaimantExposure) {
+	c.metrics.RecordClaimantExposure(claimant, exposure.Honest, exposure.Bonded, exposure.PastClock, exposure.MaxDelay, exposure.OldestClaimAge)
+	for i, count := range exposure.ClaimsByAge {
+		c.metrics.RecordClaimantClaims(claimant, exposure.Honest, monTypes.ClaimAgeLabel(i), count)
+	}
+}
+
+func newClaimantExposure(claimant common.Address, honest bool) *monTypes.ClaimantExposure {
+	return &monTypes.ClaimantExposure{
+		Claimant:    claimant,
+		Honest:      honest,
+		Bonded:      big.NewInt(0),
+		ClaimsByAge: make([]int, len(monTypes.ClaimAgeBuckets)+1),
+	}
+}
+
+// mergeExposure adds the claims of exposure to total.
+func mergeExposure(total *monTypes.ClaimantExposure, exposure monTypes.ClaimantExposure) {
+	total.Claims += exposure.Claims
+	total.Bonded.Add(total.Bonded, exposure.Bonded)
+	for i, count := range exposure.ClaimsByAge {
+		total.ClaimsByAge[i] += count
+	}
+	total.OldestClaimAge = max(total.OldestClaimAge, exposure.OldestClaimAge)
+	total.PastClock += exposure.PastClock
+	total.MaxDelay = max(total.MaxDelay, exposure.MaxDelay)
+}
+
+// claimAge returns the time in seconds since claim was posted at now.
+func claimAge(now time.Time, claim *types.Claim) uint64 {
+	if claim.Clock == nil {
+		return 0
+	}
+	posted := time.Unix(int64(claim.Clock.Timestamp), 0)
+	if now.Before(posted) {
+		return 0
+	}
+	return uint64(now.Sub(posted).Seconds())
+}
+
+// claimAgeBucket returns the index of the range of monTypes.ClaimAgeBuckets age falls in.
+func claimAgeBucket(age uint64) int {
+	for i, bound := range monTypes.ClaimAgeBuckets {
+		if age <= bound {
+			return i
+		}
+	}
+	return len(monTypes.ClaimAgeBuckets)
+}
@@ -0,0 +1,223 @@
+package resolution
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	honestClaimant = common.Address{0xaa}
+	claimantB      = common.Address{0xbb}
+	claimantC      = common.Address{0xcc}
+	claimantD      = common.Address{0xdd}
+	claimantE      = common.Address{0xee}
+)
+
+func TestClaimantTracker_RecordClaimants(t *testing.T) {
+	t.Run("NoGames", func(t *testing.T) {
+		tracker, metrics, _ := setupClaimantTrackerTest(t, 10)
+		require.Empty(t, tracker.RecordClaimants(nil))
+		require.Empty(t, metrics.exposures)
+	})
+
+	t.Run("AggregatesAcrossGames", func(t *testing.T) {
+		tracker, metrics, _ := setupClaimantTrackerTest(t, 10)
+		resolved := postedClaim(claimantB, 1000, 2*time.Hour)
+		resolved.Bond = monTypes.ResolvedBondAmount
+		unknownBond := postedClaim(claimantB, 0, time.Minute)
+		unknownBond.Bond = nil
+		noClock := postedClaim(claimantB, 7, 0)
+		noClock.Clock = nil
+		games := []*monTypes.EnrichedGameData{
+			timelineGame(0, postedClaim(claimantB, 10, 30*time.Minute), postedClaim(honestClaimant, 5, 2*time.Hour), resolved),
+			timelineGame(0, postedClaim(claimantB, 20, 50*time.Hour), unknownBond, noClock),
+			timelineGame(0, postedClaim(claimantB, 30, 10*24*time.Hour)),
+		}
+		exposures := tracker.RecordClaimants(games)
+		require.Len(t, exposures, 2)
+
+		// Claims are past their clock after half the game duration of 960 seconds
+		expected := monTypes.ClaimantExposure{
+			Claimant:       claimantB,
+			Claims:         4,
+			Bonded:         big.NewInt(67),
+			ClaimsByAge:    []int{2, 0, 1, 0, 1},
+			OldestClaimAge: uint64((10 * 24 * time.Hour).Seconds()),
+			PastClock:      3,
+			MaxDelay:       uint64((10 * 24 * time.Hour).Seconds()) - maxGameDuration/2,
+		}
+		require.Equal(t, expected, exposures[0])
+		require.Equal(t, monTypes.ClaimantExposure{
+			Claimant:       honestClaimant,
+			Honest:         true,
+			Claims:         1,
+			Bonded:         big.NewInt(5),
+			ClaimsByAge:    []int{0, 1, 0, 0, 0},
+			OldestClaimAge: uint64((2 * time.Hour).Seconds()),
+			PastClock:      1,
+			MaxDelay:       uint64((2 * time.Hour).Seconds()) - maxGameDuration/2,
+		}, exposures[1])
+
+		require.Equal(t, recordedExposure{
+			honest:    false,
+			bonded:    big.NewInt(67),
+			pastClock: 3,
+			maxDelay:  expected.MaxDelay,
+			oldest:    expected.OldestClaimAge,
+			claims:    map[string]int{"3600": 2, "86400": 0, "302400": 1, "604800": 0, "+Inf": 1},
+		}, metrics.exposures[claimantB.Hex()])
+		require.True(t, metrics.exposures[honestClaimant.Hex()].honest)
+	})
+
+	t.Run("OrderedByBondsThenClaims", func(t *testing.T) {
+		tracker, _, _ := setupClaimantTrackerTest(t, 10)
+		games := []*monTypes.EnrichedGameData{
+			timelineGame(0,
+				postedClaim(claimantB, 5, time.Minute),
+				postedClaim(claimantC, 5, time.Minute), postedClaim(claimantC, 5, time.Minute),
+				postedClaim(claimantD, 50, time.Minute),
+				postedClaim(claimantE, 5, time.Minute),
+			),
+		}
+		exposures := tracker.RecordClaimants(games)
+		require.Equal(t, []common.Address{claimantD, claimantC, claimantB, claimantE}, claimants(exposures))
+	})
+
+	t.Run("CapsLabelledClaimants", func(t *testing.T) {
+		tracker, metrics, _ := setupClaimantTrackerTest(t, 2)
+		games := []*monTypes.EnrichedGameData{
+			timelineGame(0,
+				postedClaim(honestClaimant, 1, time.Minute),
+				postedClaim(claimantB, 40, time.Minute),
+				postedClaim(claimantC, 30, 2*time.Hour),
+				postedClaim(claimantD, 20, 2*time.Hour),
+				postedClaim(claimantE, 10, 10*24*time.Hour),
+			),
+		}
+		exposures := tracker.RecordClaimants(games)
+		// The full breakdown is returned regardless of the cap
+		require.Len(t, exposures, 5)
+
+		// Honest actors are always labelled, even with the smallest bonds
+		require.ElementsMatch(t, []string{honestClaimant.Hex(), claimantB.Hex(), claimantC.Hex(), OtherClaimants}, metrics.labels())
+		other := metrics.exposures[OtherClaimants]
+		require.False(t, other.honest)
+		require.Equal(t, big.NewInt(30), other.bonded)
+		require.Equal(t, map[string]int{"3600": 0, "86400": 1, "302400": 0, "604800": 0, "+Inf": 1}, other.claims)
+		require.Equal(t, uint64((10 * 24 * time.Hour).Seconds()), other.oldest)
+		require.Equal(t, 2, other.pastClock)
+	})
+
+	t.Run("NoOtherWithinCap", func(t *testing.T) {
+		tracker, metrics, _ := setupClaimantTrackerTest(t, 2)
+		tracker.RecordClaimants([]*monTypes.EnrichedGameData{
+			timelineGame(0, postedClaim(claimantB, 40, time.Minute), postedClaim(claimantC, 30, time.Minute)),
+		})
+		require.ElementsMatch(t, []string{claimantB.Hex(), claimantC.Hex()}, metrics.labels())
+	})
+
+	t.Run("ZeroLimitOnlyLabelsHonestActors", func(t *testing.T) {
+		tracker, metrics, _ := setupClaimantTrackerTest(t, 0)
+		tracker.RecordClaimants([]*monTypes.EnrichedGameData{
+			timelineGame(0, postedClaim(honestClaimant, 1, time.Minute), postedClaim(claimantB, 40, time.Minute)),
+		})
+		require.ElementsMatch(t, []string{honestClaimant.Hex(), OtherClaimants}, metrics.labels())
+	})
+
+	t.Run("RemovesClaimantsNoLongerLabelled", func(t *testing.T) {
+		tracker, metrics, _ := setupClaimantTrackerTest(t, 1)
+		tracker.RecordClaimants([]*monTypes.EnrichedGameData{
+			timelineGame(0, postedClaim(claimantB, 40, time.Minute), postedClaim(claimantC, 30, time.Minute)),
+		})
+		require.ElementsMatch(t, []string{claimantB.Hex(), OtherClaimants}, metrics.labels())
+
+		// C overtakes B, then all claims are resolved
+		tracker.RecordClaimants([]*monTypes.EnrichedGameData{
+			timelineGame(0, postedClaim(claimantC, 50, time.Minute)),
+		})
+		require.ElementsMatch(t, []string{claimantC.Hex()}, metrics.labels())
+		require.ElementsMatch(t, []string{claimantB.Hex(), OtherClaimants}, metrics.removed)
+
+		tracker.RecordClaimants(nil)
+		require.Empty(t, metrics.labels())
+	})
+}
+
+func setupClaimantTrackerTest(t *testing.T, limit uint) (*ClaimantTracker, *mockClaimantMetrics, *clock.DeterministicClock) {
+	metrics := &mockClaimantMetrics{exposures: make(map[string]recordedExposure)}
+	cl := clock.NewDeterministicClock(frozen.Add(30 * 24 * time.Hour))
+	return NewClaimantTracker(metrics, cl, []common.Address{honestClaimant}, limit), metrics, cl
+}
+
+// postedClaim creates an unresolved claim posted by claimant age ago, whose clock has been running since it was posted.
+func postedClaim(claimant common.Address, bond int64, age time.Duration) types.Claim {
+	now := frozen.Add(30 * 24 * time.Hour)
+	return types.Claim{
+		ClaimData: types.ClaimData{
+			Bond: big.NewInt(bond),
+		},
+		Claimant: claimant,
+		Clock:    types.NewClock(0, uint64(now.Add(-age).Unix())),
+	}
+}
+
+func claimants(exposures []monTypes.ClaimantExposure) []common.Address {
+	var addrs []common.Address
+	for _, exposure := range exposures {
+		addrs = append(addrs, exposure.Claimant)
+	}
+	return addrs
+}
+
+type recordedExposure struct {
+	honest    bool
+	bonded    *big.Int
+	pastClock int
+	maxDelay  uint64
+	oldest    uint64
+	claims    map[string]int
+}
+
+type mockClaimantMetrics struct {
+	exposures map[string]recordedExposure
+	removed   []string
+}
+
+func (m *mockClaimantMetrics) RecordClaimantExposure(claimant string, honest bool, bonded *big.Int, pastClock int, maxDelay uint64, oldestClaimAge uint64) {
+	exposure := m.exposures[claimant]
+	exposure.honest = honest
+	exposure.bonded = bonded
+	exposure.pastClock = pastClock
+	exposure.maxDelay = maxDelay
+	exposure.oldest = oldestClaimAge
+	m.exposures[claimant] = exposure
+}
+
+func (m *mockClaimantMetrics) RecordClaimantClaims(claimant string, honest bool, age string, count int) {
+	exposure := m.exposures[claimant]
+	if exposure.claims == nil {
+		exposure.claims = make(map[string]int)
+	}
+	exposure.claims[age] = count
+	m.exposures[claimant] = exposure
+}
+
+func (m *mockClaimantMetrics) RemoveClaimant(claimant string) {
+	delete(m.exposures, claimant)
+	m.removed = append(m.removed, claimant)
+}
+
+func (m *mockClaimantMetrics) labels() []string {
+	var labels []string
+	for label := range m.exposures {
+		labels = append(labels, label)
+	}
+	return labels
+}
@@ -1,52 +1,119 @@
 package resolution
 
 import (
+	"slices"
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type DelayMetrics interface {
 	RecordClaimResolutionDelayMax(delay float64)
+	RecordClaimResolutionDelay(gameType uint32, delay float64)
+	RecordClaimResolutionDelayStats(gameType uint32, maxDelay, p95Delay float64)
+	RecordClaimsPastClock(gameType uint32, count int)
 }
 
 type DelayCalculator struct {
 	metrics DelayMetrics
 	clock   clock.Clock
+
+	// gameTypes are the game types metrics have been recorded for, so they can be reset once no games of that
+	// type are monitored. Only accessed from the monitoring loop.
+	gameTypes map[uint32]bool
+	// pending is the delay of each claim last seen unresolved past its clock, observed once the claim is resolved.
+	// Only accessed from the monitoring loop.
+	pending map[claimKey]pendingDelay
+}
+
+type claimKey struct {
+	game  common.Address
+	index int
+}
+
+type pendingDelay struct {
+	gameType uint32
+	delay    uint64
 }
 
 func NewDelayCalculator(metrics DelayMetrics, clock clock.Clock) *DelayCalculator {
 	return &DelayCalculator{
-		metrics: metrics,
-		clock:   clock,
+		metrics:   metrics,
+		clock:     clock,
+		gameTypes: make(map[uint32]bool),
+		pending:   make(map[claimKey]pendingDelay),
 	}
 }
 
-func (d *DelayCalculator) RecordClaimResolutionDelayMax(games []*monTypes.EnrichedGameData) {
+// RecordClaimResolutionDelays records how long unresolved claims have been resolvable for, as the max and 95th
+// percentile delay and number of claims past their clock for each game type.
+// The delay of each claim is observed once, when the claim is first seen resolved, using the delay it had in the
+// last cycle it was unresolved. Claims no longer monitored before they are seen resolved are not observed.
+func (d *DelayCalculator) RecordClaimResolutionDelays(games []*monTypes.EnrichedGameData) {
 	var maxDelay uint64 = 0
+	delaysByType := make(map[uint32][]uint64)
+	resolvedByType := make(map[uint32][]uint64)
+	for gameType := range d.gameTypes {
+		delaysByType[gameType] = nil
+	}
+	pending := make(map[claimKey]pendingDelay)
 	for _, game := range games {
-		maxDelay = max(d.getMaxResolutionDelay(game), maxDelay)
+		delays := delaysByType[game.GameType]
+		for _, claim := range game.Claims {
+			key := claimKey{game: game.Proxy, index: claim.ContractIndex}
+			if delay, pastClock := d.getOverflowTime(game.Duration, &claim); pastClock {
+				delays = append(delays, delay)
+				maxDelay = max(delay, maxDelay)
+				pending[key] = pendingDelay{gameType: game.GameType, delay: delay}
+			} else if p, ok := d.pending[key]; ok && monTypes.ResolvedBondAmount.Cmp(claim.Bond) == 0 {
+				resolvedByType[p.gameType] = append(resolvedByType[p.gameType], p.delay)
+			}
+		}
+		delaysByType[game.GameType] = delays
 	}
+	d.pending = pending
 	d.metrics.RecordClaimResolutionDelayMax(float64(maxDelay))
+	for gameType, delays := range resolvedByType {
+		slices.Sort(delays)
+		for _, delay := range delays {
+			d.metrics.RecordClaimResolutionDelay(gameType, float64(delay))
+		}
+	}
+	for gameType, delays := range delaysByType {
+		d.gameTypes[gameType] = true
+		slices.Sort(delays)
+		d.metrics.RecordClaimsPastClock(gameType, len(delays))
+		d.metrics.RecordClaimResolutionDelayStats(gameType, float64(percentile(delays, 100)), float64(percentile(delays, 95)))
+	}
 }
 
-func (d *DelayCalculator) getMaxResolutionDelay(game *monTypes.EnrichedGameData) uint64 {
-	var maxDelay uint64 = 0
-	for _, claim := range game.Claims {
-		maxDelay = max(d.getOverflowTime(game.Duration, &claim), maxDelay)
-	}
-	return maxDelay
+// getOverflowTime returns how long claim has been past its clock and whether it is unresolved and past its clock.
+func (d *DelayCalculator) getOverflowTime(maxGameDuration uint64, claim *types.Claim) (uint64, bool) {
+	return overflowTime(d.clock.Now(), maxGameDuration, claim)
 }
 
-func (d *DelayCalculator) getOverflowTime(maxGameDuration uint64, claim *types.Claim) uint64 {
+// overflowTime returns how long claim has been past its clock at now and whether it is unresolved and past its clock.
+func overflowTime(now time.Time, maxGameDuration uint64, claim *types.Claim) (uint64, bool) {
 	// If the bond amount is the max uint128 value, the claim is resolved.
 	if monTypes.ResolvedBondAmount.Cmp(claim.ClaimData.Bond) == 0 {
-		return 0
+		return 0, false
 	}
 	maxChessTime := maxGameDuration / 2
-	accumulatedTime := uint64(claim.ChessTime(d.clock.Now()))
+	accumulatedTime := uint64(claim.ChessTime(now))
 	if accumulatedTime < maxChessTime {
+		return 0, false
+	}
+	return accumulatedTime - maxChessTime, true
+}
+
+// percentile returns the nearest-rank percentile p of the sorted delays, or 0 if there are no delays.
+func percentile(sorted []uint64, p int) uint64 {
+	if len(sorted) == 0 {
 		return 0
 	}
-	return accumulatedTime - maxChessTime
+	rank := (len(sorted)*p + 99) / 100
+	return sorted[max(rank, 1)-1]
 }
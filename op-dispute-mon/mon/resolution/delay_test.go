@@ -2,12 +2,17 @@ package resolution
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,8 +29,9 @@ func TestDelayCalculator_getOverflowTime(t *testing.T) {
 				Bond: monTypes.ResolvedBondAmount,
 			},
 		}
-		delay := d.getOverflowTime(maxGameDuration, claim)
+		delay, pastClock := d.getOverflowTime(maxGameDuration, claim)
 		require.Equal(t, uint64(0), delay)
+		require.False(t, pastClock)
 		require.Equal(t, 0, metrics.calls)
 	})
 
@@ -39,8 +45,9 @@ func TestDelayCalculator_getOverflowTime(t *testing.T) {
 			},
 			Clock: types.NewClock(duration, timestamp),
 		}
-		delay := d.getOverflowTime(maxGameDuration, claim)
+		delay, pastClock := d.getOverflowTime(maxGameDuration, claim)
 		require.Equal(t, uint64(0), delay)
+		require.False(t, pastClock)
 		require.Equal(t, 0, metrics.calls)
 	})
 
@@ -54,40 +61,29 @@ func TestDelayCalculator_getOverflowTime(t *testing.T) {
 			},
 			Clock: types.NewClock(duration, timestamp),
 		}
-		delay := d.getOverflowTime(maxGameDuration, claim)
+		delay, pastClock := d.getOverflowTime(maxGameDuration, claim)
 		require.Equal(t, uint64(240), delay)
+		require.True(t, pastClock)
 		require.Equal(t, 0, metrics.calls)
 	})
-}
-
-func TestDelayCalculator_getMaxResolutionDelay(t *testing.T) {
-	tests := []struct {
-		name   string
-		claims []types.Claim
-		want   uint64
-	}{
-		{"NoClaims", []types.Claim{}, 0},
-		{"SingleClaim", createClaimList()[:1], 180},
-		{"MultipleClaims", createClaimList()[:2], 300},
-		{"ClaimsWithMaxUint128", createClaimList(), 300},
-	}
 
-	for _, test := range tests {
-		test := test
-		t.Run(test.name, func(t *testing.T) {
-			d, metrics, _ := setupDelayCalculatorTest(t)
-			game := &monTypes.EnrichedGameData{
-				Claims:   test.claims,
-				Duration: maxGameDuration,
-			}
-			got := d.getMaxResolutionDelay(game)
-			require.Equal(t, 0, metrics.calls)
-			require.Equal(t, test.want, got)
-		})
-	}
+	t.Run("ClockJustExpired", func(t *testing.T) {
+		d, metrics, cl := setupDelayCalculatorTest(t)
+		timestamp := uint64(cl.Now().Add(-time.Duration(maxGameDuration/2) * time.Second).Unix())
+		claim := &types.Claim{
+			ClaimData: types.ClaimData{
+				Bond: big.NewInt(5),
+			},
+			Clock: types.NewClock(0, timestamp),
+		}
+		delay, pastClock := d.getOverflowTime(maxGameDuration, claim)
+		require.Equal(t, uint64(0), delay)
+		require.True(t, pastClock)
+		require.Equal(t, 0, metrics.calls)
+	})
 }
 
-func TestDelayCalculator_RecordClaimResolutionDelayMax(t *testing.T) {
+func TestDelayCalculator_RecordClaimResolutionDelays(t *testing.T) {
 	tests := []struct {
 		name  string
 		games []*monTypes.EnrichedGameData
@@ -103,19 +99,191 @@ func TestDelayCalculator_RecordClaimResolutionDelayMax(t *testing.T) {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			d, metrics, _ := setupDelayCalculatorTest(t)
-			d.RecordClaimResolutionDelayMax(test.games)
+			d.RecordClaimResolutionDelays(test.games)
 			require.Equal(t, 1, metrics.calls)
 			require.Equal(t, test.want, metrics.maxDelay)
 		})
 	}
 }
 
+func TestDelayCalculator_GameTypes(t *testing.T) {
+	t.Run("RecordsDelaysPerGameType", func(t *testing.T) {
+		d, metrics, _ := setupDelayCalculatorTest(t)
+		d.RecordClaimResolutionDelays(createTimelines())
+		require.Empty(t, metrics.delays, "should not observe unresolved claims")
+		require.Equal(t, map[uint32]int{0: 5, 1: 1, 2: 0}, metrics.pastClock)
+		require.Equal(t, map[uint32][2]float64{
+			0: {360, 360},
+			1: {600, 600},
+			2: {0, 0},
+		}, metrics.stats)
+		require.Equal(t, float64(600), metrics.maxDelay)
+
+		metrics.reset()
+		d.RecordClaimResolutionDelays(resolveClaims(createTimelines()))
+		require.Equal(t, map[uint32][]float64{
+			0: {120, 180, 240, 300, 360},
+			1: {600},
+		}, metrics.delays)
+		require.Equal(t, map[uint32]int{0: 0, 1: 0, 2: 0}, metrics.pastClock)
+	})
+
+	t.Run("ObservesEachClaimOnce", func(t *testing.T) {
+		d, metrics, cl := setupDelayCalculatorTest(t)
+		game := timelineGameAt(common.Address{0xaa}, 0, expiredClaim(time.Minute), expiredClaim(2*time.Minute))
+		d.RecordClaimResolutionDelays([]*monTypes.EnrichedGameData{game})
+		cl.AdvanceTime(time.Minute)
+		d.RecordClaimResolutionDelays([]*monTypes.EnrichedGameData{game})
+		require.Empty(t, metrics.delays)
+
+		game.Claims[0].Bond = monTypes.ResolvedBondAmount
+		cl.AdvanceTime(time.Minute)
+		d.RecordClaimResolutionDelays([]*monTypes.EnrichedGameData{game})
+		require.Equal(t, map[uint32][]float64{0: {120}}, metrics.delays, "should observe the delay when last unresolved")
+		require.Equal(t, 1, metrics.pastClock[0])
+
+		cl.AdvanceTime(time.Minute)
+		d.RecordClaimResolutionDelays([]*monTypes.EnrichedGameData{game})
+		require.Equal(t, map[uint32][]float64{0: {120}}, metrics.delays, "should not observe a claim again")
+	})
+
+	t.Run("DropsClaimsNoLongerMonitored", func(t *testing.T) {
+		d, metrics, _ := setupDelayCalculatorTest(t)
+		d.RecordClaimResolutionDelays(createTimelines())
+		d.RecordClaimResolutionDelays(nil)
+		require.Empty(t, d.pending)
+		d.RecordClaimResolutionDelays(resolveClaims(createTimelines()))
+		require.Empty(t, metrics.delays)
+	})
+
+	t.Run("P95", func(t *testing.T) {
+		d, metrics, _ := setupDelayCalculatorTest(t)
+		var claims []types.Claim
+		// 40 claims with delays of 1 to 40 minutes
+		for i := 1; i <= 40; i++ {
+			claims = append(claims, expiredClaim(time.Duration(i)*time.Minute))
+		}
+		d.RecordClaimResolutionDelays([]*monTypes.EnrichedGameData{timelineGameAt(common.Address{0xaa}, 0, claims...)})
+		require.Equal(t, [2]float64{40 * 60, 38 * 60}, metrics.stats[0])
+		require.Equal(t, 40, metrics.pastClock[0])
+	})
+
+	t.Run("ResetsMissingGameTypes", func(t *testing.T) {
+		d, metrics, _ := setupDelayCalculatorTest(t)
+		d.RecordClaimResolutionDelays(createTimelines())
+		metrics.reset()
+		d.RecordClaimResolutionDelays(nil)
+		require.Empty(t, metrics.delays)
+		require.Equal(t, map[uint32]int{0: 0, 1: 0, 2: 0}, metrics.pastClock)
+		require.Equal(t, map[uint32][2]float64{0: {0, 0}, 1: {0, 0}, 2: {0, 0}}, metrics.stats)
+	})
+
+	t.Run("HistogramBuckets", func(t *testing.T) {
+		m := metrics.NewMetrics()
+		cl := clock.NewDeterministicClock(frozen)
+		d := NewDelayCalculator(m, cl)
+		d.RecordClaimResolutionDelays(createTimelines())
+		d.RecordClaimResolutionDelays(resolveClaims(createTimelines()))
+		expected := `
+# HELP op_dispute_mon_claim_resolution_delay_seconds Time in seconds claims were resolvable for before they were resolved, observed once per claim and labelled by game type
+# TYPE op_dispute_mon_claim_resolution_delay_seconds histogram
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="60"} 0
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="300"} 4
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="900"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="3600"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="10800"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="21600"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="43200"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="86400"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="259200"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="604800"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="0",le="+Inf"} 5
+op_dispute_mon_claim_resolution_delay_seconds_sum{game_type="0"} 1200
+op_dispute_mon_claim_resolution_delay_seconds_count{game_type="0"} 5
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="60"} 0
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="300"} 0
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="900"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="3600"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="10800"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="21600"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="43200"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="86400"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="259200"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="604800"} 1
+op_dispute_mon_claim_resolution_delay_seconds_bucket{game_type="1",le="+Inf"} 1
+op_dispute_mon_claim_resolution_delay_seconds_sum{game_type="1"} 600
+op_dispute_mon_claim_resolution_delay_seconds_count{game_type="1"} 1
+# HELP op_dispute_mon_claims_past_clock Number of unresolved claims whose chess clock has expired, labelled by game type
+# TYPE op_dispute_mon_claims_past_clock gauge
+op_dispute_mon_claims_past_clock{game_type="0"} 0
+op_dispute_mon_claims_past_clock{game_type="1"} 0
+op_dispute_mon_claims_past_clock{game_type="2"} 0
+`
+		require.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+			"op_dispute_mon_claim_resolution_delay_seconds",
+			"op_dispute_mon_claims_past_clock",
+		))
+	})
+}
+
 func setupDelayCalculatorTest(t *testing.T) (*DelayCalculator, *mockDelayMetrics, *clock.DeterministicClock) {
 	metrics := &mockDelayMetrics{}
 	cl := clock.NewDeterministicClock(frozen)
 	return NewDelayCalculator(metrics, cl), metrics, cl
 }
 
+// createTimelines creates games of three game types. Game type 0 has claims past their clock in two games,
+// game type 1 has a single claim past its clock and game type 2 only has claims that are resolved or in progress.
+func createTimelines() []*monTypes.EnrichedGameData {
+	resolved := expiredClaim(time.Hour)
+	resolved.Bond = monTypes.ResolvedBondAmount
+	inProgress := expiredClaim(0)
+	inProgress.Clock = types.NewClock(0, uint64(frozen.Add(-time.Minute).Unix()))
+	return []*monTypes.EnrichedGameData{
+		timelineGameAt(common.Address{0xaa}, 0, expiredClaim(2*time.Minute), expiredClaim(5*time.Minute), resolved),
+		timelineGameAt(common.Address{0xbb}, 1, expiredClaim(10*time.Minute), inProgress),
+		timelineGameAt(common.Address{0xcc}, 0, expiredClaim(3*time.Minute), expiredClaim(4*time.Minute), expiredClaim(6*time.Minute)),
+		timelineGameAt(common.Address{0xdd}, 2, resolved, inProgress),
+	}
+}
+
+// resolveClaims resolves every claim of games.
+func resolveClaims(games []*monTypes.EnrichedGameData) []*monTypes.EnrichedGameData {
+	for _, game := range games {
+		for i := range game.Claims {
+			game.Claims[i].Bond = monTypes.ResolvedBondAmount
+		}
+	}
+	return games
+}
+
+func timelineGame(gameType uint32, claims ...types.Claim) *monTypes.EnrichedGameData {
+	return timelineGameAt(common.Address{}, gameType, claims...)
+}
+
+// timelineGameAt creates a game at proxy with claims indexed in order.
+func timelineGameAt(proxy common.Address, gameType uint32, claims ...types.Claim) *monTypes.EnrichedGameData {
+	for i := range claims {
+		claims[i].ContractIndex = i
+	}
+	return &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{GameType: gameType, Proxy: proxy},
+		Claims:       claims,
+		Duration:     maxGameDuration,
+	}
+}
+
+// expiredClaim creates an unresolved claim whose clock expired delay ago.
+func expiredClaim(delay time.Duration) types.Claim {
+	timestamp := uint64(frozen.Add(-delay).Unix())
+	return types.Claim{
+		ClaimData: types.ClaimData{
+			Bond: big.NewInt(5),
+		},
+		Clock: types.NewClock(maxGameDuration/2, timestamp),
+	}
+}
+
 func createGameWithClaimsList() []*monTypes.EnrichedGameData {
 	return []*monTypes.EnrichedGameData{
 		{
@@ -171,6 +339,37 @@ func createClaimList() []types.Claim {
 type mockDelayMetrics struct {
 	calls    int
 	maxDelay float64
+
+	delays    map[uint32][]float64
+	stats     map[uint32][2]float64
+	pastClock map[uint32]int
+}
+
+func (m *mockDelayMetrics) reset() {
+	m.delays = nil
+	m.stats = nil
+	m.pastClock = nil
+}
+
+func (m *mockDelayMetrics) RecordClaimResolutionDelay(gameType uint32, delay float64) {
+	if m.delays == nil {
+		m.delays = make(map[uint32][]float64)
+	}
+	m.delays[gameType] = append(m.delays[gameType], delay)
+}
+
+func (m *mockDelayMetrics) RecordClaimResolutionDelayStats(gameType uint32, maxDelay, p95Delay float64) {
+	if m.stats == nil {
+		m.stats = make(map[uint32][2]float64)
+	}
+	m.stats[gameType] = [2]float64{maxDelay, p95Delay}
+}
+
+func (m *mockDelayMetrics) RecordClaimsPastClock(gameType uint32, count int) {
+	if m.pastClock == nil {
+		m.pastClock = make(map[uint32]int)
+	}
+	m.pastClock[gameType] = count
 }
 
 func (m *mockDelayMetrics) RecordClaimResolutionDelayMax(delay float64) {
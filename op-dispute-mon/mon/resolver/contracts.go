@@ -0,0 +1,31 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+)
+
+type GameContractCreator struct {
+	caller *batching.MultiCaller
+}
+
+func NewGameContractCreator(caller *batching.MultiCaller) *GameContractCreator {
+	return &GameContractCreator{caller: caller}
+}
+
+func (g *GameContractCreator) CreateContract(game types.GameMetadata) (GameContract, error) {
+	switch game.GameType {
+	case faultTypes.CannonGameType, faultTypes.AlphabetGameType:
+		fdg, err := contracts.NewFaultDisputeGameContract(game.Proxy, g.caller)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create FaultDisputeGameContract: %w", err)
+		}
+		return fdg, nil
+	default:
+		return nil, fmt.Errorf("unsupported game type: %d", game.GameType)
+	}
+}
@@ -0,0 +1,39 @@
+package resolver
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// alreadyResolvedSelectors are the FaultDisputeGame errors that mean the claim or game being resolved has
+// already been resolved, typically by a challenger.
+var alreadyResolvedSelectors = [][]byte{
+	crypto.Keccak256([]byte("ClaimAlreadyResolved()"))[:4],
+	crypto.Keccak256([]byte("GameNotInProgress()"))[:4],
+}
+
+// isAlreadyResolved returns true if err is a revert because the claim or game has already been resolved.
+func isAlreadyResolved(err error) bool {
+	var dataErr rpc.DataError
+	if err == nil || !errors.As(err, &dataErr) {
+		return false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return false
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil || len(data) < 4 {
+		return false
+	}
+	for _, selector := range alreadyResolvedSelectors {
+		if bytes.Equal(data[:4], selector) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,324 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// maxBackoff is the longest a game is skipped for after repeated resolution failures.
+const maxBackoff = time.Hour
+
+const (
+	MethodResolveClaim = "resolve_claim"
+	MethodResolve      = "resolve"
+
+	ResultSuccess         = "success"
+	ResultAlreadyResolved = "already_resolved"
+	ResultFailed          = "failed"
+	ResultDryRun          = "dry_run"
+)
+
+var errResolutionFailed = errors.New("resolution transaction failed")
+
+type ResolverMetrics interface {
+	RecordResolution(method string, result string)
+}
+
+type GameContract interface {
+	CallResolveClaim(ctx context.Context, claimIdx uint64) error
+	ResolveClaimTx(claimIdx uint64) (txmgr.TxCandidate, error)
+	CallResolve(ctx context.Context) (gameTypes.GameStatus, error)
+	ResolveTx() (txmgr.TxCandidate, error)
+}
+
+type CreateGameContract func(game gameTypes.GameMetadata) (GameContract, error)
+
+type TxSender interface {
+	SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*ethTypes.Receipt, error)
+}
+
+// gameBackoff tracks consecutive resolution failures for a game.
+type gameBackoff struct {
+	failures int
+	until    time.Time
+}
+
+// Resolver resolves claims whose clocks have expired and games whose root claim has been resolved,
+// so that bonds are unlocked without waiting for a challenger to do it.
+// Resolution runs in the background so slow transactions don't delay monitoring.
+// If no TxSender is provided, the resolver runs in dry-run mode and only logs the transactions it would send.
+type Resolver struct {
+	logger         log.Logger
+	metrics        ResolverMetrics
+	clock          clock.Clock
+	createContract CreateGameContract
+	sender         TxSender
+	maxTxs         int
+	backoff        time.Duration
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running atomic.Bool
+
+	lock     sync.Mutex
+	backoffs map[common.Address]*gameBackoff
+}
+
+func NewResolver(
+	ctx context.Context,
+	logger log.Logger,
+	metrics ResolverMetrics,
+	cl clock.Clock,
+	createContract CreateGameContract,
+	sender TxSender,
+	maxTxs uint,
+	backoff time.Duration,
+) *Resolver {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Resolver{
+		logger:         logger,
+		metrics:        metrics,
+		clock:          cl,
+		createContract: createContract,
+		sender:         sender,
+		maxTxs:         int(maxTxs),
+		backoff:        backoff,
+		ctx:            ctx,
+		cancel:         cancel,
+		backoffs:       make(map[common.Address]*gameBackoff),
+	}
+}
+
+// Resolve starts resolving games in the background. It is a no-op if the previous run is still in progress.
+func (r *Resolver) Resolve(_ context.Context, games []*monTypes.EnrichedGameData) {
+	if r.ctx.Err() != nil {
+		return
+	}
+	if !r.running.CompareAndSwap(false, true) {
+		r.logger.Debug("Skipping resolution, previous run still in progress")
+		return
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer r.running.Store(false)
+		r.resolveGames(r.ctx, games)
+	}()
+}
+
+// Close stops any in progress resolution and waits for it to exit.
+func (r *Resolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// resolveGames resolves the claims and games that can be resolved, sending at most maxTxs transactions.
+func (r *Resolver) resolveGames(ctx context.Context, games []*monTypes.EnrichedGameData) {
+	budget := r.maxTxs
+	for _, game := range games {
+		if budget == 0 {
+			r.logger.Info("Reached resolution transaction limit for this cycle", "limit", r.maxTxs)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if game.Status != gameTypes.GameStatusInProgress || r.backingOff(game.Proxy) {
+			continue
+		}
+		claims, rootResolvable := r.resolvableClaims(game)
+		if len(claims) == 0 && !rootResolvable {
+			continue
+		}
+		contract, err := r.createContract(game.GameMetadata)
+		if err != nil {
+			r.logger.Error("Failed to create game contract", "game", game.Proxy, "err", err)
+			continue
+		}
+		if err := r.resolveGame(ctx, game, contract, claims, rootResolvable, &budget); err != nil {
+			r.logger.Error("Failed to resolve game", "game", game.Proxy, "err", err)
+			r.recordFailure(game.Proxy)
+			continue
+		}
+		r.clearFailures(game.Proxy)
+	}
+}
+
+// resolvableClaims returns the indices of the claims in game that can be resolved, in the order they must be
+// resolved, and whether the game can be resolved once they are.
+func (r *Resolver) resolvableClaims(game *monTypes.EnrichedGameData) ([]uint64, bool) {
+	if len(game.Claims) == 0 {
+		return nil, false
+	}
+	children := make([][]int, len(game.Claims))
+	for i := 1; i < len(game.Claims); i++ {
+		parent := game.Claims[i].ParentContractIndex
+		if parent >= 0 && parent < len(game.Claims) {
+			children[parent] = append(children[parent], i)
+		}
+	}
+	now := r.clock.Now()
+	resolved := make([]bool, len(game.Claims))
+	var claims []uint64
+	// Child claims always have a higher index than their parent, so walking backwards resolves subgames
+	// before the subgames that contain them.
+	for i := len(game.Claims) - 1; i >= 0; i-- {
+		claim := &game.Claims[i]
+		if isResolved(claim) {
+			resolved[i] = true
+			continue
+		}
+		if uint64(claim.ChessTime(now)) <= game.Duration/2 {
+			continue
+		}
+		// The contract only requires that child subgames don't contain unresolved claims.
+		subgamesResolved := true
+		for _, child := range children[i] {
+			if !resolved[child] && len(children[child]) != 0 {
+				subgamesResolved = false
+				break
+			}
+		}
+		if subgamesResolved {
+			resolved[i] = true
+			claims = append(claims, uint64(i))
+		}
+	}
+	return claims, resolved[0]
+}
+
+// resolveGame resolves claims in order then the game itself, stopping at the first failure since later
+// resolutions depend on earlier ones.
+func (r *Resolver) resolveGame(ctx context.Context, game *monTypes.EnrichedGameData, contract GameContract, claims []uint64, rootResolvable bool, budget *int) error {
+	for _, claimIdx := range claims {
+		if *budget == 0 || ctx.Err() != nil {
+			return nil
+		}
+		claimIdx := claimIdx
+		err := r.send(ctx, game.Proxy, MethodResolveClaim, budget,
+			func(ctx context.Context) error { return contract.CallResolveClaim(ctx, claimIdx) },
+			func() (txmgr.TxCandidate, error) { return contract.ResolveClaimTx(claimIdx) },
+			"claimIdx", claimIdx)
+		if err != nil {
+			return fmt.Errorf("failed to resolve claim %v: %w", claimIdx, err)
+		}
+	}
+	if !rootResolvable || *budget == 0 || ctx.Err() != nil {
+		return nil
+	}
+	err := r.send(ctx, game.Proxy, MethodResolve, budget,
+		func(ctx context.Context) error {
+			_, err := contract.CallResolve(ctx)
+			return err
+		},
+		contract.ResolveTx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve game: %w", err)
+	}
+	return nil
+}
+
+// send simulates a resolution transaction and, if it would succeed, sends it.
+// Resolutions that fail because another party already resolved the claim or game are treated as successful.
+func (r *Resolver) send(
+	ctx context.Context,
+	game common.Address,
+	method string,
+	budget *int,
+	simulate func(ctx context.Context) error,
+	createTx func() (txmgr.TxCandidate, error),
+	logCtx ...any,
+) error {
+	logger := r.logger.New(append([]any{"game", game, "method", method}, logCtx...)...)
+	if r.sender == nil {
+		// Earlier resolutions in the plan were never sent, so simulating later ones would fail.
+		tx, err := createTx()
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		*budget--
+		logger.Info("Dry run: would send resolution transaction", "to", tx.To, "data", fmt.Sprintf("%x", tx.TxData))
+		r.metrics.RecordResolution(method, ResultDryRun)
+		return nil
+	}
+	if err := simulate(ctx); isAlreadyResolved(err) {
+		logger.Debug("Already resolved")
+		r.metrics.RecordResolution(method, ResultAlreadyResolved)
+		return nil
+	} else if err != nil {
+		r.metrics.RecordResolution(method, ResultFailed)
+		return err
+	}
+	tx, err := createTx()
+	if err != nil {
+		r.metrics.RecordResolution(method, ResultFailed)
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	*budget--
+	logger.Info("Sending resolution transaction")
+	receipts, err := r.sender.SendAndWait("resolve "+method, tx)
+	if err == nil && len(receipts) == 1 && receipts[0] != nil && receipts[0].Status == ethTypes.ReceiptStatusSuccessful {
+		r.metrics.RecordResolution(method, ResultSuccess)
+		return nil
+	}
+	// The transaction may have lost a race with another party resolving the same claim or game.
+	if simErr := simulate(ctx); isAlreadyResolved(simErr) {
+		logger.Info("Resolved by another party")
+		r.metrics.RecordResolution(method, ResultAlreadyResolved)
+		return nil
+	}
+	r.metrics.RecordResolution(method, ResultFailed)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errResolutionFailed, err)
+	}
+	return errResolutionFailed
+}
+
+func (r *Resolver) backingOff(game common.Address) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	b, ok := r.backoffs[game]
+	return ok && r.clock.Now().Before(b.until)
+}
+
+// recordFailure skips game for the backoff duration, doubling for each consecutive failure up to maxBackoff.
+func (r *Resolver) recordFailure(game common.Address) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	b, ok := r.backoffs[game]
+	if !ok {
+		b = &gameBackoff{}
+		r.backoffs[game] = b
+	}
+	delay := r.backoff
+	for i := 0; i < b.failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	b.failures++
+	b.until = r.clock.Now().Add(min(delay, maxBackoff))
+}
+
+func (r *Resolver) clearFailures(game common.Address) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.backoffs, game)
+}
+
+func isResolved(claim *faultTypes.Claim) bool {
+	return claim.Bond != nil && claim.Bond.Cmp(monTypes.ResolvedBondAmount) == 0
+}
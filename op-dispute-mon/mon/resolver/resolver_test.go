@@ -0,0 +1,359 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const (
+	gameDuration = uint64(1000)
+	testBackoff  = time.Minute
+)
+
+var (
+	now        = time.Unix(100_000, 0)
+	gameAddr1  = common.Address{0xaa}
+	gameAddr2  = common.Address{0xbb}
+	errBoom    = errors.New("boom")
+	errAlready = fmt.Errorf("failed to call resolve claim: %w", &revertError{data: hexutil.Encode(alreadyResolvedSelectors[0])})
+)
+
+func TestResolver(t *testing.T) {
+	t.Run("ResolvesExpiredClaimsThenGame", func(t *testing.T) {
+		resolver, contracts, sender, metrics, _ := setupResolverTest(t)
+		game := newGame(gameAddr1, expiredClaim(-1), expiredClaim(0))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, []string{"resolveClaim(1)", "resolveClaim(0)", "resolve()"}, sender.sent(gameAddr1))
+		require.Equal(t, 2, metrics.count(MethodResolveClaim, ResultSuccess))
+		require.Equal(t, 1, metrics.count(MethodResolve, ResultSuccess))
+		require.Equal(t, []uint64{1, 0}, contracts[gameAddr1].simulatedClaims)
+	})
+
+	t.Run("SkipsUnexpiredClaims", func(t *testing.T) {
+		resolver, contracts, sender, _, _ := setupResolverTest(t)
+		game := newGame(gameAddr1, activeClaim(-1), activeClaim(0))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Empty(t, sender.txs)
+		require.Nil(t, contracts[gameAddr1], "should not create contract when nothing is resolvable")
+	})
+
+	t.Run("SkipsCompletedGames", func(t *testing.T) {
+		resolver, _, sender, _, _ := setupResolverTest(t)
+		game := newGame(gameAddr1, expiredClaim(-1))
+		game.Status = gameTypes.GameStatusDefenderWon
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Empty(t, sender.txs)
+	})
+
+	t.Run("ResolvesOnlyCompleteSubgames", func(t *testing.T) {
+		resolver, _, sender, _, _ := setupResolverTest(t)
+		// Claim 1 is still active so the root subgame can't be resolved, but its expired child can
+		game := newGame(gameAddr1, expiredClaim(-1), activeClaim(0), expiredClaim(1))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, []string{"resolveClaim(2)"}, sender.sent(gameAddr1))
+	})
+
+	t.Run("UncounteredLeafDoesNotBlockParent", func(t *testing.T) {
+		resolver, _, sender, _, _ := setupResolverTest(t)
+		// Leaf claims have no subgames so an active leaf doesn't prevent resolving its parent
+		game := newGame(gameAddr1, expiredClaim(-1), activeClaim(0))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, []string{"resolveClaim(0)", "resolve()"}, sender.sent(gameAddr1))
+	})
+
+	t.Run("ResolvesGameWithResolvedRoot", func(t *testing.T) {
+		resolver, _, sender, _, _ := setupResolverTest(t)
+		game := newGame(gameAddr1, resolvedClaim(-1), resolvedClaim(0))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, []string{"resolve()"}, sender.sent(gameAddr1))
+	})
+
+	t.Run("TreatsAlreadyResolvedAsSuccess", func(t *testing.T) {
+		resolver, contracts, sender, metrics, _ := setupResolverTest(t)
+		contracts[gameAddr1] = &stubGameContract{addr: gameAddr1, claimErrs: map[uint64]error{1: errAlready}}
+		game := newGame(gameAddr1, expiredClaim(-1), expiredClaim(0))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, []string{"resolveClaim(0)", "resolve()"}, sender.sent(gameAddr1))
+		require.Equal(t, 1, metrics.count(MethodResolveClaim, ResultAlreadyResolved))
+	})
+
+	t.Run("TreatsLostRaceAsSuccess", func(t *testing.T) {
+		resolver, contracts, sender, metrics, _ := setupResolverTest(t)
+		contract := &stubGameContract{addr: gameAddr1}
+		contracts[gameAddr1] = contract
+		// The transaction reverts because a challenger resolved the claim first
+		sender.onSend = func(tx string) (uint64, error) {
+			if tx != "resolveClaim(1)" {
+				return ethTypes.ReceiptStatusSuccessful, nil
+			}
+			contract.claimErrs = map[uint64]error{1: errAlready}
+			return ethTypes.ReceiptStatusFailed, nil
+		}
+		game := newGame(gameAddr1, expiredClaim(-1), expiredClaim(0))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Equal(t, 1, metrics.count(MethodResolveClaim, ResultAlreadyResolved))
+		require.Equal(t, 0, metrics.count(MethodResolveClaim, ResultFailed))
+		require.False(t, resolver.backingOff(gameAddr1))
+	})
+
+	t.Run("BacksOffAfterFailure", func(t *testing.T) {
+		resolver, _, sender, metrics, cl := setupResolverTest(t)
+		sender.onSend = func(tx string) (uint64, error) {
+			return 0, errBoom
+		}
+		games := []*monTypes.EnrichedGameData{newGame(gameAddr1, expiredClaim(-1), expiredClaim(0))}
+		resolver.resolveGames(context.Background(), games)
+		require.Equal(t, []string{"resolveClaim(1)"}, sender.sent(gameAddr1), "should stop at the first failure")
+		require.Equal(t, 1, metrics.count(MethodResolveClaim, ResultFailed))
+
+		resolver.resolveGames(context.Background(), games)
+		require.Len(t, sender.sent(gameAddr1), 1, "should not retry during backoff")
+
+		cl.AdvanceTime(testBackoff)
+		resolver.resolveGames(context.Background(), games)
+		require.Len(t, sender.sent(gameAddr1), 2, "should retry after backoff")
+
+		// Backoff doubles after consecutive failures
+		cl.AdvanceTime(testBackoff)
+		resolver.resolveGames(context.Background(), games)
+		require.Len(t, sender.sent(gameAddr1), 2)
+		cl.AdvanceTime(testBackoff)
+		resolver.resolveGames(context.Background(), games)
+		require.Len(t, sender.sent(gameAddr1), 3)
+
+		// Success resets the backoff
+		sender.onSend = nil
+		cl.AdvanceTime(4 * testBackoff)
+		resolver.resolveGames(context.Background(), games)
+		require.False(t, resolver.backingOff(gameAddr1))
+	})
+
+	t.Run("FailedGameDoesNotBlockOthers", func(t *testing.T) {
+		resolver, contracts, sender, _, _ := setupResolverTest(t)
+		contracts[gameAddr1] = &stubGameContract{addr: gameAddr1, claimErrs: map[uint64]error{0: errBoom}}
+		games := []*monTypes.EnrichedGameData{
+			newGame(gameAddr1, expiredClaim(-1)),
+			newGame(gameAddr2, expiredClaim(-1)),
+		}
+		resolver.resolveGames(context.Background(), games)
+		require.Empty(t, sender.sent(gameAddr1))
+		require.Equal(t, []string{"resolveClaim(0)", "resolve()"}, sender.sent(gameAddr2))
+		require.True(t, resolver.backingOff(gameAddr1))
+	})
+
+	t.Run("LimitsTransactionsPerCycle", func(t *testing.T) {
+		resolver, _, sender, _, _ := setupResolverTest(t)
+		resolver.maxTxs = 4
+		games := []*monTypes.EnrichedGameData{
+			newGame(gameAddr1, expiredClaim(-1), expiredClaim(0)),
+			newGame(gameAddr2, expiredClaim(-1), expiredClaim(0)),
+		}
+		resolver.resolveGames(context.Background(), games)
+		require.Len(t, sender.sent(gameAddr1), 3)
+		require.Equal(t, []string{"resolveClaim(1)"}, sender.sent(gameAddr2))
+		require.False(t, resolver.backingOff(gameAddr2), "reaching the limit is not a failure")
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		resolver, contracts, sender, metrics, _ := setupResolverTest(t)
+		resolver.sender = nil
+		game := newGame(gameAddr1, expiredClaim(-1), expiredClaim(0))
+		resolver.resolveGames(context.Background(), []*monTypes.EnrichedGameData{game})
+		require.Empty(t, sender.txs)
+		require.Equal(t, 2, metrics.count(MethodResolveClaim, ResultDryRun))
+		require.Equal(t, 1, metrics.count(MethodResolve, ResultDryRun))
+		require.Empty(t, contracts[gameAddr1].simulatedClaims, "should not simulate in dry-run mode")
+	})
+
+	t.Run("SkipsWhileRunning", func(t *testing.T) {
+		resolver, _, sender, _, _ := setupResolverTest(t)
+		release := make(chan struct{})
+		sent := make(chan struct{}, 10)
+		sender.onSend = func(tx string) (uint64, error) {
+			sent <- struct{}{}
+			<-release
+			return ethTypes.ReceiptStatusSuccessful, nil
+		}
+		games := []*monTypes.EnrichedGameData{newGame(gameAddr1, expiredClaim(-1))}
+		resolver.Resolve(context.Background(), games)
+		<-sent
+		resolver.Resolve(context.Background(), games)
+		close(release)
+		require.Eventually(t, func() bool { return !resolver.running.Load() }, 10*time.Second, time.Millisecond)
+		require.Equal(t, []string{"resolveClaim(0)", "resolve()"}, sender.sent(gameAddr1), "should only run once")
+	})
+}
+
+func TestIsAlreadyResolved(t *testing.T) {
+	for _, selector := range alreadyResolvedSelectors {
+		require.True(t, isAlreadyResolved(fmt.Errorf("wrapped: %w", &revertError{data: hexutil.Encode(selector)})))
+	}
+	require.False(t, isAlreadyResolved(nil))
+	require.False(t, isAlreadyResolved(errBoom))
+	require.False(t, isAlreadyResolved(&revertError{data: "0x12345678"}))
+	require.False(t, isAlreadyResolved(&revertError{data: "0x12"}))
+}
+
+func setupResolverTest(t *testing.T) (*Resolver, map[common.Address]*stubGameContract, *capturingSender, *stubResolverMetrics, *clock.DeterministicClock) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	cl := clock.NewDeterministicClock(now)
+	contracts := make(map[common.Address]*stubGameContract)
+	create := func(game gameTypes.GameMetadata) (GameContract, error) {
+		contract, ok := contracts[game.Proxy]
+		if !ok {
+			contract = &stubGameContract{addr: game.Proxy}
+			contracts[game.Proxy] = contract
+		}
+		return contract, nil
+	}
+	sender := &capturingSender{txs: make(map[common.Address][]string)}
+	metrics := &stubResolverMetrics{counts: make(map[string]int)}
+	resolver := NewResolver(context.Background(), logger, metrics, cl, create, sender, 10, testBackoff)
+	t.Cleanup(resolver.Close)
+	return resolver, contracts, sender, metrics, cl
+}
+
+func newGame(addr common.Address, claims ...faultTypes.Claim) *monTypes.EnrichedGameData {
+	for i := range claims {
+		claims[i].ContractIndex = i
+	}
+	return &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: addr},
+		Status:       gameTypes.GameStatusInProgress,
+		Duration:     gameDuration,
+		Claims:       claims,
+	}
+}
+
+func newClaim(parent int, age uint64) faultTypes.Claim {
+	parentIdx := parent
+	if parent < 0 {
+		parentIdx = math.MaxUint32
+	}
+	return faultTypes.Claim{
+		ClaimData:           faultTypes.ClaimData{Bond: big.NewInt(1)},
+		Clock:               faultTypes.NewClock(0, uint64(now.Unix())-age),
+		ParentContractIndex: parentIdx,
+	}
+}
+
+func expiredClaim(parent int) faultTypes.Claim {
+	return newClaim(parent, gameDuration/2+1)
+}
+
+func activeClaim(parent int) faultTypes.Claim {
+	return newClaim(parent, gameDuration/2)
+}
+
+func resolvedClaim(parent int) faultTypes.Claim {
+	claim := expiredClaim(parent)
+	claim.Bond = monTypes.ResolvedBondAmount
+	return claim
+}
+
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+type stubGameContract struct {
+	addr            common.Address
+	claimErrs       map[uint64]error
+	resolveErr      error
+	simulatedClaims []uint64
+}
+
+func (s *stubGameContract) CallResolveClaim(_ context.Context, claimIdx uint64) error {
+	s.simulatedClaims = append(s.simulatedClaims, claimIdx)
+	return s.claimErrs[claimIdx]
+}
+
+func (s *stubGameContract) ResolveClaimTx(claimIdx uint64) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{To: &s.addr, TxData: []byte(fmt.Sprintf("resolveClaim(%d)", claimIdx))}, nil
+}
+
+func (s *stubGameContract) CallResolve(_ context.Context) (gameTypes.GameStatus, error) {
+	return gameTypes.GameStatusDefenderWon, s.resolveErr
+}
+
+func (s *stubGameContract) ResolveTx() (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{To: &s.addr, TxData: []byte("resolve()")}, nil
+}
+
+// capturingSender records the transactions sent to each game, succeeding unless onSend says otherwise.
+type capturingSender struct {
+	lock   sync.Mutex
+	txs    map[common.Address][]string
+	onSend func(tx string) (uint64, error)
+}
+
+func (s *capturingSender) SendAndWait(_ string, txs ...txmgr.TxCandidate) ([]*ethTypes.Receipt, error) {
+	receipts := make([]*ethTypes.Receipt, len(txs))
+	var errs []error
+	for i, tx := range txs {
+		s.lock.Lock()
+		s.txs[*tx.To] = append(s.txs[*tx.To], string(tx.TxData))
+		onSend := s.onSend
+		s.lock.Unlock()
+		status := ethTypes.ReceiptStatusSuccessful
+		if onSend != nil {
+			var err error
+			status, err = onSend(string(tx.TxData))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		receipts[i] = &ethTypes.Receipt{Status: status}
+	}
+	return receipts, errors.Join(errs...)
+}
+
+func (s *capturingSender) sent(game common.Address) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.txs[game]
+}
+
+type stubResolverMetrics struct {
+	lock   sync.Mutex
+	counts map[string]int
+}
+
+func (s *stubResolverMetrics) RecordResolution(method string, result string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.counts[method+"/"+result]++
+}
+
+func (s *stubResolverMetrics) count(method string, result string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.counts[method+"/"+result]
+}
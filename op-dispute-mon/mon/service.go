@@ -4,60 +4,51 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/big"
+	"net"
+	"net/http"
+	"strconv"
 	"sync/atomic"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
-	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
-	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/resolution"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/api"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/resolver"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/version"
 
-	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
-	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
-	"github.com/ethereum-optimism/optimism/op-service/sources"
-	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
 type Service struct {
-	logger  log.Logger
-	metrics metrics.Metricer
-	monitor *gameMonitor
-
-	factoryContract *contracts.DisputeGameFactoryContract
+	logger   log.Logger
+	registry *prometheus.Registry
+	chains   []*chainMonitor
 
 	cl clock.Clock
 
-	delays       *resolution.DelayCalculator
-	extractor    *extract.Extractor
-	forecast     *forecast
-	game         *extract.GameCallerCreator
-	rollupClient *sources.RollupClient
-	detector     *detector
-	validator    *outputValidator
-
-	l1Client *ethclient.Client
+	txMgr *txmgr.SimpleTxManager
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 
+	apiSrv *httputil.HTTPServer
+
 	stopped atomic.Bool
 }
 
 // NewService creates a new Service.
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
 	s := &Service{
-		cl:      clock.SystemClock,
-		logger:  logger,
-		metrics: metrics.NewMetrics(),
+		cl:     clock.SystemClock,
+		logger: logger,
 	}
 
 	if err := s.initFromConfig(ctx, cfg); err != nil {
@@ -68,79 +59,65 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 }
 
 func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error {
-	if err := s.initL1Client(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init l1 client: %w", err)
-	}
+	chainMetrics, txMetrics := s.initMetrics(cfg)
 	if err := s.initPProf(&cfg.PprofConfig); err != nil {
 		return fmt.Errorf("failed to init profiling: %w", err)
 	}
 	if err := s.initMetricsServer(&cfg.MetricsConfig); err != nil {
 		return fmt.Errorf("failed to init metrics server: %w", err)
 	}
-	if err := s.initFactoryContract(cfg); err != nil {
-		return fmt.Errorf("failed to create factory contract bindings: %w", err)
+	txSender, err := s.initTxSender(ctx, cfg, txMetrics)
+	if err != nil {
+		return fmt.Errorf("failed to init tx sender: %w", err)
+	}
+	for i, chain := range cfg.MonitoredChains() {
+		c, err := newChainMonitor(ctx, s.logger, chainMetrics[i], s.cl, cfg, chain, txSender)
+		if err != nil {
+			return fmt.Errorf("failed to init chain %q: %w", chain.Name, err)
+		}
+		s.chains = append(s.chains, c)
 	}
-	if err := s.initOutputRollupClient(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init rollup client: %w", err)
+	if err := s.initAPIServer(cfg); err != nil {
+		return fmt.Errorf("failed to init api server: %w", err)
 	}
 
-	s.initOutputValidator()   // Must be called before initForecast
-	s.initGameCallerCreator() // Must be called before initForecast
-
-	s.initDelayCalculator()
-	s.initExtractor()
-
-	s.initForecast(cfg)
-	s.initDetector()
-
-	s.initMonitor(ctx, cfg) // Monitor must be initialized last
-
-	s.metrics.RecordInfo(version.SimpleWithMeta)
-	s.metrics.RecordUp()
+	for _, m := range chainMetrics {
+		m.RecordInfo(version.SimpleWithMeta)
+		m.RecordUp()
+	}
 
 	return nil
 }
 
-func (s *Service) initOutputValidator() {
-	s.validator = newOutputValidator(s.rollupClient)
-}
-
-func (s *Service) initGameCallerCreator() {
-	s.game = extract.NewGameCallerCreator(s.metrics, batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
-}
-
-func (s *Service) initDelayCalculator() {
-	s.delays = resolution.NewDelayCalculator(s.metrics, s.cl)
-}
-
-func (s *Service) initExtractor() {
-	s.extractor = extract.NewExtractor(s.logger, s.game.CreateContract, s.factoryContract.GetGamesAtOrAfter)
-}
-
-func (s *Service) initForecast(cfg *config.Config) {
-	s.forecast = newForecast(s.logger, s.metrics, s.validator)
-}
-
-func (s *Service) initDetector() {
-	s.detector = newDetector(s.logger, s.metrics, s.validator)
-}
-
-func (s *Service) initOutputRollupClient(ctx context.Context, cfg *config.Config) error {
-	outputRollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.RollupRpc)
-	if err != nil {
-		return fmt.Errorf("failed to dial rollup client: %w", err)
+// initMetrics creates the metrics for each monitored chain and the metrics of the tx manager shared by all chains.
+// When monitoring multiple chains, each chain's metrics are labelled with its name.
+func (s *Service) initMetrics(cfg *config.Config) ([]metrics.Metricer, txmetrics.TxMetricer) {
+	if len(cfg.Chains) == 0 {
+		m := metrics.NewMetrics()
+		s.registry = m.Registry()
+		return []metrics.Metricer{m}, m
 	}
-	s.rollupClient = outputRollupClient
-	return nil
+	s.registry = opmetrics.NewRegistry()
+	txMetrics := metrics.NewTxMetrics(s.registry)
+	chainMetrics := make([]metrics.Metricer, 0, len(cfg.Chains))
+	for _, chain := range cfg.Chains {
+		chainMetrics = append(chainMetrics, metrics.NewChainMetrics(s.registry, chain.Name, txMetrics))
+	}
+	return chainMetrics, txMetrics
 }
 
-func (s *Service) initL1Client(ctx context.Context, cfg *config.Config) error {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L1EthRpc)
+// initTxSender creates the sender for resolution transactions, shared by all chains. It returns nil if the
+// resolver is disabled or in dry-run mode.
+func (s *Service) initTxSender(ctx context.Context, cfg *config.Config, txMetrics txmetrics.TxMetricer) (resolver.TxSender, error) {
+	if !cfg.ResolverEnabled || cfg.ResolverDryRun {
+		return nil, nil
+	}
+	txMgr, err := txmgr.NewSimpleTxManager("dispute-mon", s.logger, txMetrics, cfg.TxMgrConfig)
 	if err != nil {
-		return fmt.Errorf("failed to dial L1: %w", err)
+		return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
 	}
-	s.l1Client = l1Client
-	return nil
+	s.txMgr = txMgr
+	return sender.NewTxSender(ctx, s.logger, txMgr, uint64(cfg.ResolverMaxTransactions)), nil
 }
 
 func (s *Service) initPProf(cfg *oppprof.CLIConfig) error {
@@ -165,11 +142,7 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 		return nil
 	}
 	s.logger.Debug("starting metrics server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
-	m, ok := s.metrics.(opmetrics.RegistryMetricer)
-	if !ok {
-		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", s.metrics)
-	}
-	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.ListenAddr, cfg.ListenPort)
+	metricsSrv, err := opmetrics.StartServer(s.registry, cfg.ListenAddr, cfg.ListenPort)
 	if err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
@@ -178,43 +151,38 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	return nil
 }
 
-func (s *Service) initFactoryContract(cfg *config.Config) error {
-	factoryContract, err := contracts.NewDisputeGameFactoryContract(cfg.GameFactoryAddress,
-		batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
+func (s *Service) initAPIServer(cfg *config.Config) error {
+	if !cfg.APIEnabled {
+		return nil
+	}
+	var handler http.Handler
+	if len(cfg.Chains) == 0 {
+		chain := s.chains[0].apiChain()
+		handler = api.NewHandler(s.logger, chain.Source, chain.Health)
+	} else {
+		chains := make([]api.Chain, 0, len(s.chains))
+		for _, c := range s.chains {
+			chains = append(chains, c.apiChain())
+		}
+		handler = api.NewChainsHandler(s.logger, chains)
+	}
+	addr := net.JoinHostPort(cfg.APIListenAddr, strconv.Itoa(cfg.APIListenPort))
+	s.logger.Debug("starting api server", "addr", addr)
+	apiSrv, err := httputil.StartHTTPServer(addr, handler)
 	if err != nil {
-		return fmt.Errorf("failed to bind the fault dispute game factory contract: %w", err)
+		return fmt.Errorf("failed to start api server: %w", err)
 	}
-	s.factoryContract = factoryContract
+	s.logger.Info("started api server", "addr", apiSrv.Addr())
+	s.apiSrv = apiSrv
 	return nil
 }
 
-func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
-	blockHashFetcher := func(ctx context.Context, blockNumber *big.Int) (common.Hash, error) {
-		block, err := s.l1Client.BlockByNumber(ctx, blockNumber)
-		if err != nil {
-			return common.Hash{}, fmt.Errorf("failed to fetch block by number: %w", err)
-		}
-		return block.Hash(), nil
-	}
-	s.monitor = newGameMonitor(
-		ctx,
-		s.logger,
-		s.cl,
-		cfg.MonitorInterval,
-		cfg.GameWindow,
-		s.delays.RecordClaimResolutionDelayMax,
-		s.detector.Detect,
-		s.forecast.Forecast,
-		s.extractor.Extract,
-		s.l1Client.BlockNumber,
-		blockHashFetcher,
-	)
-}
-
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("Starting scheduler")
 	s.logger.Info("Starting monitoring")
-	s.monitor.StartMonitoring()
+	for _, c := range s.chains {
+		c.start()
+	}
 	s.logger.Info("Dispute monitor game service start completed")
 	return nil
 }
@@ -227,6 +195,12 @@ func (s *Service) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping dispute mon service")
 
 	var result error
+	for _, c := range s.chains {
+		c.stop()
+	}
+	if s.txMgr != nil {
+		s.txMgr.Close()
+	}
 	if s.pprofService != nil {
 		if err := s.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
@@ -237,6 +211,11 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
 		}
 	}
+	if s.apiSrv != nil {
+		if err := s.apiSrv.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close api server: %w", err))
+		}
+	}
 	s.stopped.Store(true)
 	s.logger.Info("stopped dispute mon service", "err", result)
 	return result
@@ -2,9 +2,13 @@ package types
 
 import (
 	"math/big"
+	"strconv"
+	"time"
 
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -18,6 +22,141 @@ type EnrichedGameData struct {
 	Status        types.GameStatus
 	Duration      uint64
 	Claims        []faultTypes.Claim
+
+	// L1Head is the hash of the L1 block the game was created against.
+	L1Head common.Hash
+
+	// Credits is the unclaimed credit held by the game for each recipient. It is only loaded for resolved games.
+	Credits []Credit
+
+	// InCreationWindow is true if the game was created recently enough for its outcome to be forecast.
+	InCreationWindow bool
+	// InResolutionWindow is true if the game was created recently enough for its resolution, bonds and
+	// credit to be tracked.
+	InResolutionWindow bool
+
+	// HonestStakes is the stake each honest actor has in the game's unresolved claims.
+	// Honest actors without unresolved claims in the game are omitted.
+	HonestStakes map[common.Address]ActorStake
+}
+
+// ActorStake is the claims an actor has posted that are yet to be resolved, and the bonds posted with them.
+type ActorStake struct {
+	Claims int
+	Bonded *big.Int
+}
+
+// ClaimAgeBuckets are the upper bounds in seconds of the ranges the ages of unresolved claims are counted in.
+// Claims older than the last bound are counted in a final, unbounded range.
+var ClaimAgeBuckets = []uint64{
+	60 * 60, 24 * 60 * 60, 84 * 60 * 60, 7 * 24 * 60 * 60,
+}
+
+// ClaimAgeLabel returns the label of the claim age range at index i of ClaimantExposure.ClaimsByAge: the upper bound
+// in seconds, or "+Inf" for the final range.
+func ClaimAgeLabel(i int) string {
+	if i >= len(ClaimAgeBuckets) {
+		return "+Inf"
+	}
+	return strconv.FormatUint(ClaimAgeBuckets[i], 10)
+}
+
+// ClaimantExposure is the claims a claimant has posted across all monitored games that are yet to be resolved.
+type ClaimantExposure struct {
+	Claimant common.Address
+	Honest   bool     // Whether the claimant is one of the configured honest actors.
+	Claims   int      // Number of unresolved claims.
+	Bonded   *big.Int // Bonds posted with the unresolved claims.
+
+	// ClaimsByAge is the number of unresolved claims whose age falls in each range of ClaimAgeBuckets,
+	// with claims older than the last bucket counted at the final index.
+	ClaimsByAge []int
+	// OldestClaimAge is the age in seconds of the oldest unresolved claim.
+	OldestClaimAge uint64
+
+	PastClock int    // Number of unresolved claims whose chess clock has expired, so they can be resolved.
+	MaxDelay  uint64 // Longest time in seconds any of the claims has been resolvable for.
+}
+
+// Credit is the unclaimed credit a game holds for a recipient.
+type Credit struct {
+	Recipient common.Address
+	Amount    *big.Int
+	// Delayed is true if the game holds its bonds in a DelayedWETH contract, so credit can only be claimed
+	// once the withdrawal delay has elapsed.
+	Delayed bool
+	// UnlockTime is the time delayed credit can be claimed from. It is zero if the credit has not been unlocked.
+	UnlockTime time.Time
+}
+
+// Claimable returns true if the credit can be claimed at now.
+func (c Credit) Claimable(now time.Time) bool {
+	if !c.Delayed {
+		return true
+	}
+	return !c.UnlockTime.IsZero() && !now.Before(c.UnlockTime)
+}
+
+// LargePreimageState is the stage of a large preimage proposal's lifecycle.
+type LargePreimageState string
+
+const (
+	// LargePreimageUploading proposals are still having their data uploaded and can't be challenged yet.
+	LargePreimageUploading LargePreimageState = "uploading"
+	// LargePreimageActive proposals are fully uploaded and within their challenge period.
+	LargePreimageActive LargePreimageState = "active"
+	// LargePreimageExpiring proposals are active but their challenge period ends soon.
+	LargePreimageExpiring LargePreimageState = "expiring"
+	// LargePreimageCountered proposals were successfully challenged and can never be used.
+	LargePreimageCountered LargePreimageState = "countered"
+	// LargePreimageFinalized proposals were not challenged within their challenge period and can be squeezed
+	// into the oracle, after which the preimage can be used in games.
+	LargePreimageFinalized LargePreimageState = "finalized"
+)
+
+// LargePreimageStates lists every LargePreimageState.
+var LargePreimageStates = []LargePreimageState{
+	LargePreimageUploading,
+	LargePreimageActive,
+	LargePreimageExpiring,
+	LargePreimageCountered,
+	LargePreimageFinalized,
+}
+
+// LargePreimageProposal is a large preimage proposal submitted to a PreimageOracle.
+type LargePreimageProposal struct {
+	keccakTypes.LargePreimageMetaData
+	Oracle          common.Address
+	ChallengePeriod time.Duration
+}
+
+// ChallengeDeadline returns the time the proposal's challenge period ends.
+// It is zero if the proposal is still being uploaded.
+func (p LargePreimageProposal) ChallengeDeadline() time.Time {
+	if p.Timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(p.Timestamp), 0).Add(p.ChallengePeriod)
+}
+
+// State returns the state of the proposal at now. Active proposals are expiring if their challenge period ends
+// within expiringWindow.
+func (p LargePreimageProposal) State(now time.Time, expiringWindow time.Duration) LargePreimageState {
+	switch {
+	case p.Countered:
+		return LargePreimageCountered
+	case p.Timestamp == 0:
+		return LargePreimageUploading
+	}
+	deadline := p.ChallengeDeadline()
+	switch {
+	case !now.Before(deadline):
+		return LargePreimageFinalized
+	case deadline.Sub(now) <= expiringWindow:
+		return LargePreimageExpiring
+	default:
+		return LargePreimageActive
+	}
 }
 
 // BidirectionalTree is a tree of claims represented as a flat list of claims.
@@ -48,6 +187,45 @@ func (s *StatusBatch) Add(status types.GameStatus) {
 	}
 }
 
+// GameForecast is the forecast outcome of an in progress game, compared against the output root
+// the rollup node agrees with.
+type GameForecast struct {
+	Status       types.GameStatus // Status the game would resolve to if resolved now.
+	AgreeRoot    bool             // Whether the root claim matches the expected output root.
+	ExpectedRoot common.Hash      // Output root the rollup node reports for the game's L2 block.
+
+	// Severity is how urgently an unexpected forecast needs attention. It is empty if the forecast is expected.
+	Severity alerts.Severity
+
+	// CrossCheck is the outcome of checking the root claim against the secondary rollup node. It is empty if the
+	// root claim wasn't cross-checked.
+	CrossCheck CrossCheck
+}
+
+// CrossCheck is the outcome of checking a root claim the primary rollup node disagrees with against the secondary
+// rollup node.
+type CrossCheck string
+
+const (
+	// CrossCheckAgreeInvalid is both rollup nodes reporting the same output root, which differs from the root claim.
+	CrossCheckAgreeInvalid CrossCheck = "agree_invalid"
+	// CrossCheckAgreeValid is the secondary rollup node reporting the root claim as the output root, so the root
+	// claim is treated as valid and the divergence of the rollup nodes is alerted on.
+	CrossCheckAgreeValid CrossCheck = "agree_valid"
+	// CrossCheckDiverge is the rollup nodes reporting different output roots, neither of which is the root claim.
+	CrossCheckDiverge CrossCheck = "endpoints_diverge"
+	// CrossCheckFailed is the secondary rollup node failing to report an output root, so the primary's is used.
+	CrossCheckFailed CrossCheck = "failed"
+)
+
+// Expected returns true if the forecast status is the one implied by the root claim agreement.
+func (f GameForecast) Expected() bool {
+	if f.AgreeRoot {
+		return f.Status != types.GameStatusChallengerWon
+	}
+	return f.Status != types.GameStatusDefenderWon
+}
+
 type ForecastBatch struct {
 	AgreeDefenderAhead      int
 	DisagreeDefenderAhead   int
@@ -3,7 +3,9 @@ package types
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/stretchr/testify/require"
 )
@@ -68,6 +70,89 @@ func TestStatusBatch_Add(t *testing.T) {
 	}
 }
 
+func TestGameForecast_Expected(t *testing.T) {
+	tests := []struct {
+		status   types.GameStatus
+		agree    bool
+		expected bool
+	}{
+		{types.GameStatusDefenderWon, true, true},
+		{types.GameStatusChallengerWon, true, false},
+		{types.GameStatusChallengerWon, false, true},
+		{types.GameStatusDefenderWon, false, false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v-Agree-%v", test.status, test.agree), func(t *testing.T) {
+			forecast := GameForecast{Status: test.status, AgreeRoot: test.agree}
+			require.Equal(t, test.expected, forecast.Expected())
+		})
+	}
+}
+
+func TestCredit_Claimable(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tests := []struct {
+		name      string
+		credit    Credit
+		claimable bool
+	}{
+		{"NotDelayed", Credit{}, true},
+		{"NotUnlocked", Credit{Delayed: true}, false},
+		{"DelayPending", Credit{Delayed: true, UnlockTime: now.Add(time.Second)}, false},
+		{"DelayElapsed", Credit{Delayed: true, UnlockTime: now}, true},
+		{"DelayLongElapsed", Credit{Delayed: true, UnlockTime: now.Add(-time.Hour)}, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.claimable, test.credit.Claimable(now))
+		})
+	}
+}
+
+func TestLargePreimageProposal_State(t *testing.T) {
+	now := time.Unix(10_000, 0)
+	period := time.Hour
+	window := 10 * time.Minute
+	proposal := func(timestamp uint64, countered bool) LargePreimageProposal {
+		return LargePreimageProposal{
+			LargePreimageMetaData: keccakTypes.LargePreimageMetaData{Timestamp: timestamp, Countered: countered},
+			ChallengePeriod:       period,
+		}
+	}
+	uploaded := uint64(now.Unix())
+	tests := []struct {
+		name     string
+		proposal LargePreimageProposal
+		expected LargePreimageState
+	}{
+		{"Uploading", proposal(0, false), LargePreimageUploading},
+		{"JustUploaded", proposal(uploaded, false), LargePreimageActive},
+		{"OutsideExpiringWindow", proposal(uploaded-2999, false), LargePreimageActive},
+		{"StartOfExpiringWindow", proposal(uploaded-3000, false), LargePreimageExpiring},
+		{"InExpiringWindow", proposal(uploaded-3001, false), LargePreimageExpiring},
+		{"AboutToExpire", proposal(uploaded-3599, false), LargePreimageExpiring},
+		{"ChallengePeriodEnded", proposal(uploaded-3600, false), LargePreimageFinalized},
+		{"LongFinalized", proposal(1, false), LargePreimageFinalized},
+		{"CounteredWhileActive", proposal(uploaded, true), LargePreimageCountered},
+		{"CounteredBeforeUploaded", proposal(0, true), LargePreimageCountered},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.proposal.State(now, window))
+		})
+	}
+}
+
+func TestLargePreimageProposal_ChallengeDeadline(t *testing.T) {
+	proposal := LargePreimageProposal{ChallengePeriod: time.Hour}
+	require.True(t, proposal.ChallengeDeadline().IsZero())
+	proposal.Timestamp = 1000
+	require.Equal(t, time.Unix(1000, 0).Add(time.Hour), proposal.ChallengeDeadline())
+}
+
 func TestDetectionBatch_Update(t *testing.T) {
 	statusExpectations := []struct {
 		status types.GameStatus
@@ -159,6 +159,9 @@ func NewChallengerConfig(t *testing.T, sys EndpointProvider, options ...Option)
 	// The devnet can't set the absolute prestate output root because the contracts are deployed in L1 genesis
 	// before the L2 genesis is known.
 	cfg.AllowInvalidPrestate = true
+	// Devnet games use much smaller parameters than public networks.
+	cfg.GameParams = config.GameParams{}
+	cfg.OracleParams = config.OracleParams{}
 	cfg.TxMgrConfig.NumConfirmations = 1
 	cfg.TxMgrConfig.ReceiptQueryInterval = 1 * time.Second
 	if cfg.MaxConcurrency > 4 {
@@ -282,7 +282,8 @@ type FaultProofProgramTestScenario struct {
 func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *System, s *FaultProofProgramTestScenario) {
 	preimageDir := t.TempDir()
 	fppConfig := oppconf.NewConfig(sys.RollupConfig, sys.L2GenesisCfg.Config, s.L1Head, s.L2Head, s.L2OutputRoot, common.Hash(s.L2Claim), s.L2ClaimBlockNumber)
-	fppConfig.L1URL = sys.NodeEndpoint("l1")
+	fppConfig.L1URLs = []string{sys.NodeEndpoint("l1")}
+	fppConfig.L1BeaconURL = sys.L1BeaconEndpoint()
 	fppConfig.L2URL = sys.NodeEndpoint("sequencer")
 	fppConfig.DataDir = preimageDir
 	if s.Detached {
@@ -308,7 +309,7 @@ func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *Syste
 
 	t.Log("Running fault proof in offline mode")
 	// Should be able to rerun in offline mode using the pre-fetched images
-	fppConfig.L1URL = ""
+	fppConfig.L1URLs = nil
 	fppConfig.L2URL = ""
 	err = opp.FaultProofProgram(ctx, log, fppConfig)
 	require.NoError(t, err)
@@ -0,0 +1,310 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// errBatchUnsupported is returned when the server does not serve the batch routes.
+var errBatchUnsupported = errors.New("batch route not supported")
+
+// Batch frame status bytes. Requests always use batchStatusOK.
+const (
+	batchStatusOK       byte = 0x00
+	batchStatusNotFound byte = 0x01
+	batchStatusError    byte = 0x02
+)
+
+// maxBatchFrameSize bounds the payload of a single decoded frame.
+const maxBatchFrameSize = 1 << 30
+
+// batchFrame is a single item of the batch wire format: 1 status byte, a 4 byte big-endian
+// payload length and the payload.
+//
+// A /put_batch request carries two frames per item, the encoded commitment followed by the input,
+// and a /get_batch request carries one frame per commitment. Responses carry one frame per item,
+// in request order. For /get_batch an OK frame holds the input data, for /put_batch it is empty.
+// Error frames hold a human readable message.
+type batchFrame struct {
+	status  byte
+	payload []byte
+	// dropped is the size of a payload that was discarded because it exceeded the frame limit of the reader.
+	dropped uint32
+}
+
+func writeBatchFrames(w io.Writer, frames []batchFrame) error {
+	var header [5]byte
+	for _, f := range frames {
+		header[0] = f.status
+		binary.BigEndian.PutUint32(header[1:], uint32(len(f.payload)))
+		if _, err := w.Write(header[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(f.payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBatchFrames reads frames until EOF. It fails if more than n frames are read, unless n is negative.
+// Payloads larger than maxSize are discarded without being buffered and only their size is kept in the frame,
+// so a misbehaving peer can't make the reader allocate more than maxSize per frame. A maxSize of 0 only
+// applies the maxBatchFrameSize limit.
+func readBatchFrames(r io.Reader, n int, maxSize int) ([]batchFrame, error) {
+	var frames []batchFrame
+	var header [5]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); errors.Is(err, io.EOF) {
+			return frames, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read frame header: %w", err)
+		}
+		if n >= 0 && len(frames) == n {
+			return nil, fmt.Errorf("expected %d batch frames, got more", n)
+		}
+		size := binary.BigEndian.Uint32(header[1:])
+		if size > maxBatchFrameSize {
+			return nil, fmt.Errorf("frame of %d bytes exceeds limit", size)
+		}
+		if maxSize > 0 && int64(size) > int64(maxSize) {
+			if _, err := io.CopyN(io.Discard, r, int64(size)); err != nil {
+				return nil, fmt.Errorf("failed to read frame payload: %w", err)
+			}
+			frames = append(frames, batchFrame{status: header[0], dropped: size})
+			continue
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, fmt.Errorf("failed to read frame payload: %w", err)
+		}
+		frames = append(frames, batchFrame{status: header[0], payload: payload})
+	}
+}
+
+// BatchError reports the failed items of a batch operation.
+type BatchError struct {
+	// Errors has one entry per requested item, nil for the items that succeeded.
+	Errors []error
+}
+
+func (e *BatchError) Error() string {
+	var failed []string
+	for i, err := range e.Errors {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%d: %v", i, err))
+		}
+	}
+	return fmt.Sprintf("%d of %d batch items failed: %s", len(failed), len(e.Errors), strings.Join(failed, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// batchResult returns a *BatchError if any item failed, nil otherwise.
+func batchResult(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return &BatchError{Errors: errs}
+		}
+	}
+	return nil
+}
+
+// frameError converts a non OK response frame into the matching per item error.
+func frameError(f batchFrame) error {
+	switch f.status {
+	case batchStatusOK:
+		return nil
+	case batchStatusNotFound:
+		return ErrNotFound
+	default:
+		if f.dropped != 0 {
+			return &ServerError{Message: fmt.Sprintf("error message of %d bytes dropped", f.dropped)}
+		}
+		return &ServerError{Message: string(f.payload)}
+	}
+}
+
+// SetInputs stores all inputs with a single /put_batch request and returns their commitments in order.
+// Items that failed have a nil commitment and their error is reported in a *BatchError.
+// If the server does not support batching, the inputs are stored one by one.
+func (c *DAClient) SetInputs(ctx context.Context, imgs [][]byte) (_ []Commitment, err error) {
+	ctx, call := c.startCall(ctx, "SetInputs", nil)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	comms := make([]Commitment, len(imgs))
+	errs := make([]error, len(imgs))
+	var frames []batchFrame
+	var sent []int
+	for i, img := range imgs {
+		if len(img) == 0 {
+			errs[i] = ErrInvalidInput
+			continue
+		}
+		if err := c.checkInputSize(img); err != nil {
+			errs[i] = err
+			continue
+		}
+		comm, err := NewCommitment(c.commType, img)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		comms[i] = comm
+		frames = append(frames, batchFrame{payload: comm.Encode()}, batchFrame{payload: img})
+		sent = append(sent, i)
+	}
+	if len(sent) == 0 {
+		return comms, batchResult(errs)
+	}
+
+	resp, err := c.doBatch(ctx, "put_batch", frames, len(sent))
+	if errors.Is(err, errBatchUnsupported) {
+		for _, i := range sent {
+			comm, err := c.SetInput(ctx, imgs[i])
+			comms[i], errs[i] = comm, c.timeoutError(ctx, err)
+			if err == nil {
+				call.size += len(imgs[i])
+			}
+		}
+		return comms, batchResult(errs)
+	} else if err != nil {
+		return nil, err
+	}
+	for j, i := range sent {
+		if err := frameError(resp[j]); err != nil {
+			comms[i], errs[i] = nil, err
+			continue
+		}
+		c.metrics.RecordDABytesSent("SetInputs", len(imgs[i]))
+		call.size += len(imgs[i])
+	}
+	return comms, batchResult(errs)
+}
+
+// InputResult is the outcome of fetching a single input with GetInputs: either the input, verified if the
+// client verifies on read, or the error for that commitment.
+type InputResult struct {
+	Input []byte
+	// Err is nil on success, otherwise typically ErrNotFound, ErrCommitmentMismatch, a *ResponseTooLargeError,
+	// a *ServerError for items the server failed to read, or a transport error when inputs are fetched one by one.
+	Err error
+}
+
+// GetInputs fetches the inputs for all commitments with a single /get_batch request and returns one result
+// per commitment, in request order. A missing or invalid item does not fail the others: the returned error is
+// only set when the request itself failed, in which case there are no results. Callers can retry just the
+// items that failed. If the server does not support batching, inputs are fetched one by one.
+func (c *DAClient) GetInputs(ctx context.Context, keys []Commitment) (_ []InputResult, err error) {
+	ctx, call := c.startCall(ctx, "GetInputs", nil)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	results := make([]InputResult, len(keys))
+	comms := make([]Commitment, len(keys))
+	var frames []batchFrame
+	var sent []int
+	for i, key := range keys {
+		comm, err := DecodeCommitment(key)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		comms[i] = comm
+		frames = append(frames, batchFrame{payload: key})
+		sent = append(sent, i)
+	}
+	if len(sent) == 0 {
+		return results, nil
+	}
+
+	resp, err := c.doBatch(ctx, "get_batch", frames, len(sent))
+	if errors.Is(err, errBatchUnsupported) {
+		for _, i := range sent {
+			input, err := c.GetInput(ctx, keys[i])
+			results[i] = InputResult{Input: input, Err: c.timeoutError(ctx, err)}
+			call.size += len(input)
+		}
+		return results, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for j, i := range sent {
+		results[i] = c.batchInputResult(comms[i], resp[j])
+		if results[i].Err == nil {
+			c.metrics.RecordDABytesReceived("GetInputs", len(results[i].Input))
+			call.size += len(results[i].Input)
+		}
+	}
+	return results, nil
+}
+
+// batchInputResult checks the /get_batch response frame for the commitment.
+func (c *DAClient) batchInputResult(comm Commitment, f batchFrame) InputResult {
+	if f.dropped != 0 {
+		return InputResult{Err: &ResponseTooLargeError{Limit: int64(c.maxInputSize), Size: int64(f.dropped)}}
+	}
+	if err := frameError(f); err != nil {
+		return InputResult{Err: err}
+	}
+	if c.verifier != nil {
+		if err := c.verifier(comm, f.payload); err != nil {
+			return InputResult{Err: err}
+		}
+	}
+	return InputResult{Input: f.payload}
+}
+
+// doBatch posts the frames to the batch route and returns the response frames,
+// retrying transient failures. It expects exactly n response frames.
+// IPFS endpoints have no batch routes, so it always returns errBatchUnsupported in IPFS mode.
+func (c *DAClient) doBatch(ctx context.Context, route string, frames []batchFrame, n int) ([]batchFrame, error) {
+	if c.ipfs != 0 {
+		return nil, errBatchUnsupported
+	}
+	var body bytes.Buffer
+	if err := writeBatchFrames(&body, frames); err != nil {
+		return nil, fmt.Errorf("failed to encode batch: %w", err)
+	}
+	return doEndpoint(ctx, c, c.endpoints[0], func() ([]batchFrame, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s", c.url, route), bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/octet-stream")
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+			return nil, errBatchUnsupported
+		case resp.StatusCode != http.StatusOK:
+			return nil, fmt.Errorf("batch request failed: %w", newStatusError(resp))
+		}
+		res, err := readBatchFrames(resp.Body, n, c.maxInputSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(res) != n {
+			return nil, fmt.Errorf("expected %d batch response frames, got %d", n, len(res))
+		}
+		return res, nil
+	})
+}
@@ -0,0 +1,283 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+// newBatchTestServer serves the single item and, if batch is set, the batch routes from store.
+func newBatchTestServer(t *testing.T, store ethdb.KeyValueStore, batch bool) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get/", func(w http.ResponseWriter, r *http.Request) {
+		comm, err := hexutil.Decode(r.URL.Path[len("/get/"):])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		input, err := store.Get(comm)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(input)
+	})
+	mux.HandleFunc("/put/", func(w http.ResponseWriter, r *http.Request) {
+		comm, err := hexutil.Decode(r.URL.Path[len("/put/"):])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		input, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := store.Put(comm, input); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	if batch {
+		mux.HandleFunc("/get_batch", func(w http.ResponseWriter, r *http.Request) {
+			frames, err := readBatchFrames(r.Body, -1, 0)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			resp := make([]batchFrame, len(frames))
+			for i, f := range frames {
+				input, err := store.Get(f.payload)
+				if err != nil {
+					resp[i] = batchFrame{status: batchStatusNotFound}
+					continue
+				}
+				resp[i] = batchFrame{payload: input}
+			}
+			_ = writeBatchFrames(w, resp)
+		})
+		mux.HandleFunc("/put_batch", func(w http.ResponseWriter, r *http.Request) {
+			frames, err := readBatchFrames(r.Body, -1, 0)
+			if err != nil || len(frames)%2 != 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			resp := make([]batchFrame, len(frames)/2)
+			for i := range resp {
+				comm, input := frames[2*i].payload, frames[2*i+1].payload
+				if bytes.Equal(input, []byte("reject me")) {
+					resp[i] = batchFrame{status: batchStatusError, payload: []byte("rejected")}
+					continue
+				}
+				if err := store.Put(comm, input); err != nil {
+					resp[i] = batchFrame{status: batchStatusError, payload: []byte(err.Error())}
+				}
+			}
+			_ = writeBatchFrames(w, resp)
+		})
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBatchFrames(t *testing.T) {
+	frames := []batchFrame{
+		{status: batchStatusOK, payload: []byte("hello")},
+		{status: batchStatusNotFound, payload: []byte{}},
+		{status: batchStatusError, payload: []byte("oops")},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeBatchFrames(&buf, frames))
+	decoded, err := readBatchFrames(&buf, -1, 0)
+	require.NoError(t, err)
+	require.Equal(t, frames, decoded)
+
+	// truncated payload
+	buf.Reset()
+	require.NoError(t, writeBatchFrames(&buf, frames))
+	_, err = readBatchFrames(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), -1, 0)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	// more frames than expected
+	_, err = readBatchFrames(bytes.NewReader(buf.Bytes()), 2, 0)
+	require.ErrorContains(t, err, "expected 2 batch frames")
+
+	// payloads over the limit are dropped
+	decoded, err = readBatchFrames(bytes.NewReader(buf.Bytes()), 3, 4)
+	require.NoError(t, err)
+	require.Equal(t, []batchFrame{
+		{status: batchStatusOK, dropped: 5},
+		frames[1],
+		frames[2],
+	}, decoded)
+}
+
+func TestDAClientBatch(t *testing.T) {
+	for _, batch := range []bool{true, false} {
+		batch := batch
+		name := "Batched"
+		if !batch {
+			name = "Fallback"
+		}
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := memorydb.New()
+			srv := newBatchTestServer(t, store, batch)
+			client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy))
+
+			inputs := [][]byte{[]byte("one"), []byte("two"), {}, []byte("three")}
+			comms, err := client.SetInputs(ctx, inputs)
+			var batchErr *BatchError
+			require.ErrorAs(t, err, &batchErr)
+			require.Len(t, batchErr.Errors, len(inputs))
+			require.ErrorIs(t, err, ErrInvalidInput)
+			require.ErrorIs(t, batchErr.Errors[2], ErrInvalidInput)
+			require.Nil(t, comms[2])
+			for _, i := range []int{0, 1, 3} {
+				require.NoError(t, batchErr.Errors[i])
+				require.Equal(t, Keccak256(inputs[i]), comms[i])
+			}
+
+			// a missing entry and a corrupted entry in between valid ones
+			missing := Keccak256([]byte("missing"))
+			require.NoError(t, store.Put(comms[3], []byte("corrupted")))
+			keys := []Commitment{comms[0], missing, comms[1], comms[3]}
+			results, err := client.GetInputs(ctx, keys)
+			require.NoError(t, err)
+			require.Len(t, results, len(keys))
+			require.Equal(t, InputResult{Input: inputs[0]}, results[0])
+			require.ErrorIs(t, results[1].Err, ErrNotFound)
+			require.Nil(t, results[1].Input)
+			require.Equal(t, InputResult{Input: inputs[1]}, results[2])
+			require.ErrorIs(t, results[3].Err, ErrCommitmentMismatch)
+			require.Nil(t, results[3].Input)
+
+			results, err = client.GetInputs(ctx, []Commitment{comms[1], comms[0]})
+			require.NoError(t, err)
+			require.Equal(t, []InputResult{{Input: inputs[1]}, {Input: inputs[0]}}, results)
+		})
+	}
+}
+
+func TestDAClientBatchServerItemError(t *testing.T) {
+	ctx := context.Background()
+	srv := newBatchTestServer(t, memorydb.New(), true)
+	client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy))
+
+	comms, err := client.SetInputs(ctx, [][]byte{[]byte("ok"), []byte("reject me")})
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.NoError(t, batchErr.Errors[0])
+	require.Equal(t, Keccak256([]byte("ok")), comms[0])
+	require.ErrorContains(t, batchErr.Errors[1], "rejected")
+	require.Nil(t, comms[1])
+}
+
+func TestDAClientGetInputsPartialResults(t *testing.T) {
+	ctx := context.Background()
+	valid := []byte("valid")
+	keys := []Commitment{
+		Keccak256(valid),
+		Keccak256([]byte("missing")),
+		Keccak256([]byte("corrupted")),
+		Keccak256([]byte("too large")),
+		Keccak256([]byte("failing")),
+		{0x7f, 0x01},
+	}
+	// response frames for the first five keys, the last one is rejected by the client
+	frames := []batchFrame{
+		{payload: valid},
+		{status: batchStatusNotFound},
+		{payload: []byte("not what was committed to")},
+		{payload: make([]byte, 100)},
+		{status: batchStatusError, payload: []byte("disk failure")},
+	}
+	checkResults := func(t *testing.T, results []InputResult, failing func(t *testing.T, err error)) {
+		require.Len(t, results, len(keys))
+		require.Equal(t, InputResult{Input: valid}, results[0])
+		require.ErrorIs(t, results[1].Err, ErrNotFound)
+		require.ErrorIs(t, results[2].Err, ErrCommitmentMismatch)
+		var tooLarge *ResponseTooLargeError
+		require.ErrorAs(t, results[3].Err, &tooLarge)
+		require.NotErrorIs(t, results[3].Err, ErrCommitmentMismatch)
+		failing(t, results[4].Err)
+		require.ErrorIs(t, results[5].Err, ErrUnsupportedCommitment)
+		for _, res := range results[1:] {
+			require.Nil(t, res.Input)
+		}
+	}
+
+	t.Run("Batched", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req, err := readBatchFrames(r.Body, -1, 0)
+			require.NoError(t, err)
+			require.Len(t, req, len(frames))
+			_ = writeBatchFrames(w, frames)
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithMaxInputSize(50), WithRetryPolicy(NoRetryPolicy))
+
+		results, err := client.GetInputs(ctx, keys)
+		require.NoError(t, err)
+		checkResults(t, results, func(t *testing.T, err error) {
+			var se *ServerError
+			require.ErrorAs(t, err, &se)
+			require.Equal(t, "disk failure", se.Message)
+		})
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i, key := range keys[:len(frames)] {
+				if r.URL.Path != fmt.Sprintf("/get/0x%x", key.Encode()) {
+					continue
+				}
+				switch f := frames[i]; {
+				case f.status == batchStatusNotFound:
+					w.WriteHeader(http.StatusNotFound)
+				case f.status == batchStatusError:
+					// drop the connection to cause a transport error
+					conn, _, err := w.(http.Hijacker).Hijack()
+					require.NoError(t, err)
+					_ = conn.Close()
+				default:
+					_, _ = w.Write(f.payload)
+				}
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithMaxInputSize(50), WithRetryPolicy(NoRetryPolicy))
+
+		results, err := client.GetInputs(ctx, keys)
+		require.NoError(t, err)
+		checkResults(t, results, func(t *testing.T, err error) {
+			var ue *url.Error
+			require.ErrorAs(t, err, &ue)
+		})
+	})
+
+	t.Run("RequestFailure", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy))
+
+		results, err := client.GetInputs(ctx, keys)
+		var se *ServerError
+		require.ErrorAs(t, err, &se)
+		require.Nil(t, results)
+	})
+}
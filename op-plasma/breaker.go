@@ -0,0 +1,147 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrServiceUnavailable is returned without contacting a DA server while its circuit breaker is open.
+// It is not retried: the breaker lets a request through again once its cooldown elapsed.
+var ErrServiceUnavailable = errors.New("DA service unavailable")
+
+// BreakerState is the state of the circuit breaker of a DA server endpoint.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all requests through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails all requests fast with ErrServiceUnavailable.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe request through, other requests fail fast.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker per DA server endpoint. After threshold consecutive transport
+// failures or 5xx responses, the breaker opens and requests to the endpoint fail fast with ErrServiceUnavailable,
+// so failover moves on to the next endpoint. Once cooldown elapsed, a single probe request is let through:
+// the breaker closes if the endpoint served it and opens again otherwise. Disabled by default.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) DAClientOption {
+	return func(c *DAClient) {
+		c.breakerThreshold = threshold
+		c.breakerCooldown = cooldown
+	}
+}
+
+// breaker is the circuit breaker state of an endpoint.
+type breaker struct {
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	openUntil time.Time
+	// probing is set while the probe request of the half-open breaker is in flight.
+	probing bool
+}
+
+// allow reports whether a request can be sent, and the state transition it caused if any:
+// an open breaker half-opens once its cooldown elapsed, for the request to probe the endpoint.
+func (b *breaker) allow(now time.Time) (ok bool, from BreakerState, to BreakerState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	from = b.state
+	if b.state == BreakerOpen && !now.Before(b.openUntil) {
+		b.state = BreakerHalfOpen
+	}
+	switch b.state {
+	case BreakerClosed:
+		return true, from, b.state
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, from, b.state
+		}
+		b.probing = true
+		return true, from, b.state
+	default:
+		return false, from, b.state
+	}
+}
+
+// record updates the breaker with the outcome of a request it allowed and returns the state transition.
+// Transient errors count as failures, other outcomes show the endpoint is reachable. Requests cancelled by
+// the caller tell nothing about the endpoint and only end the probe.
+func (b *breaker) record(err error, now time.Time, threshold int, cooldown time.Duration) (from BreakerState, to BreakerState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	from = b.state
+	wasProbe := b.probing
+	b.probing = false
+	switch {
+	case err != nil && !isRetryable(err) && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)):
+		// cancelled by the caller
+	case err == nil || !isRetryable(err):
+		b.state, b.failures = BreakerClosed, 0
+	case wasProbe || b.failures+1 >= threshold:
+		b.state, b.failures, b.openUntil = BreakerOpen, 0, now.Add(cooldown)
+	default:
+		b.failures++
+	}
+	return from, b.state
+}
+
+// open reports whether the breaker is open and still cooling down.
+func (b *breaker) open(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == BreakerOpen && now.Before(b.openUntil)
+}
+
+// doEndpoint runs op against the endpoint with retries. If the circuit breaker is enabled, every attempt
+// passes through the breaker of the endpoint, failing fast with ErrServiceUnavailable while it is open.
+func doEndpoint[T any](ctx context.Context, c *DAClient, e *endpoint, op func() (T, error)) (T, error) {
+	if c.breakerThreshold <= 0 {
+		return doWithRetry(ctx, c.retry, op)
+	}
+	return doWithRetry(ctx, c.retry, func() (T, error) {
+		var empty T
+		ok, from, to := e.breaker.allow(c.clock.Now())
+		c.breakerChanged(e, from, to)
+		if !ok {
+			return empty, fmt.Errorf("%w: circuit breaker of %s is %v", ErrServiceUnavailable, e.url, to)
+		}
+		res, err := op()
+		from, to = e.breaker.record(err, c.clock.Now(), c.breakerThreshold, c.breakerCooldown)
+		c.breakerChanged(e, from, to)
+		return res, err
+	})
+}
+
+// breakerChanged reports a state transition of the breaker of the endpoint to the metrics and the log.
+func (c *DAClient) breakerChanged(e *endpoint, from BreakerState, to BreakerState) {
+	if from == to {
+		return
+	}
+	c.metrics.RecordDABreakerState(e.url, to)
+	if c.log == nil {
+		return
+	}
+	if to == BreakerOpen {
+		c.log.Warn("DA server circuit breaker opened", "url", e.url, "from", from, "cooldown", c.breakerCooldown)
+	} else {
+		c.log.Info("DA server circuit breaker state changed", "url", e.url, "from", from, "to", to)
+	}
+}
@@ -0,0 +1,161 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestBreakerStateMachine(t *testing.T) {
+	const threshold, cooldown = 3, time.Minute
+	now := time.Unix(1000, 0)
+	transient := &url.Error{Op: "Get", URL: "http://da", Err: errors.New("connection refused")}
+	serverErr := &ServerError{StatusCode: http.StatusServiceUnavailable}
+
+	allow := func(b *breaker) bool {
+		ok, _, _ := b.allow(now)
+		return ok
+	}
+	fail := func(b *breaker, err error) BreakerState {
+		require.True(t, allow(b))
+		_, to := b.record(err, now, threshold, cooldown)
+		return to
+	}
+
+	t.Run("OpensAfterConsecutiveFailures", func(t *testing.T) {
+		var b breaker
+		require.Equal(t, BreakerClosed, fail(&b, transient))
+		require.Equal(t, BreakerClosed, fail(&b, serverErr))
+		// non-transient outcomes show the endpoint is reachable and reset the count
+		require.Equal(t, BreakerClosed, fail(&b, ErrNotFound))
+		require.Equal(t, BreakerClosed, fail(&b, transient))
+		require.Equal(t, BreakerClosed, fail(&b, transient))
+		require.Equal(t, BreakerOpen, fail(&b, serverErr))
+		require.False(t, allow(&b))
+		require.True(t, b.open(now))
+	})
+
+	t.Run("HalfOpenProbe", func(t *testing.T) {
+		var b breaker
+		for i := 0; i < threshold; i++ {
+			fail(&b, transient)
+		}
+		now = now.Add(cooldown - time.Second)
+		require.False(t, allow(&b))
+
+		now = now.Add(time.Second)
+		ok, from, to := b.allow(now)
+		require.True(t, ok, "the first request after the cooldown probes the endpoint")
+		require.Equal(t, BreakerOpen, from)
+		require.Equal(t, BreakerHalfOpen, to)
+		require.False(t, allow(&b), "only a single probe is in flight")
+
+		// a failed probe opens the breaker again for another cooldown
+		_, to = b.record(transient, now, threshold, cooldown)
+		require.Equal(t, BreakerOpen, to)
+		require.False(t, allow(&b))
+
+		now = now.Add(cooldown)
+		require.True(t, allow(&b))
+		// a cancelled probe tells nothing about the endpoint
+		_, to = b.record(context.Canceled, now, threshold, cooldown)
+		require.Equal(t, BreakerHalfOpen, to)
+
+		require.True(t, allow(&b))
+		_, to = b.record(nil, now, threshold, cooldown)
+		require.Equal(t, BreakerClosed, to)
+		require.True(t, allow(&b))
+	})
+}
+
+func TestDAClientCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("breaker input")
+	comm := Keccak256(input)
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	srv := newFailoverServer(t, http.StatusInternalServerError, input)
+	m := MakeDAMetrics("test", opmetrics.With(prometheus.NewRegistry()))
+	logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+	client := NewDAClient(srv.URL, true, WithClock(clk), WithMetrics(m), WithLogger(logger),
+		WithCircuitBreaker(2, time.Minute), WithRetryPolicy(RetryPolicy{MaxAttempts: 5}))
+	breakerState := func() float64 {
+		return testutil.ToFloat64(m.BreakerState.WithLabelValues(srv.URL))
+	}
+
+	// the breaker opens during the retries of the first call, cutting them short
+	_, err := client.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrServiceUnavailable)
+	require.EqualValues(t, 2, srv.requests.Load())
+	require.EqualValues(t, BreakerOpen, breakerState())
+	require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("DA server circuit breaker opened")))
+
+	// calls fail fast while the breaker is open
+	for _, call := range []func() error{
+		func() error { _, err := client.GetInput(ctx, comm); return err },
+		func() error { _, err := client.SetInput(ctx, input); return err },
+		func() error { _, err := client.Exists(ctx, comm); return err },
+	} {
+		require.ErrorIs(t, call(), ErrServiceUnavailable)
+	}
+	require.EqualValues(t, 2, srv.requests.Load())
+	require.Equal(t, ErrorClassUnavailable, ErrorClass(err))
+
+	// a failed probe after the cooldown opens the breaker again
+	clk.AdvanceTime(time.Minute)
+	_, err = client.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrServiceUnavailable)
+	require.EqualValues(t, 3, srv.requests.Load())
+	require.EqualValues(t, BreakerOpen, breakerState())
+
+	// a successful probe closes it
+	srv.status.Store(http.StatusOK)
+	clk.AdvanceTime(time.Minute)
+	got, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+	require.EqualValues(t, BreakerClosed, breakerState())
+	changes := logs.FindLogs(testlog.NewMessageFilter("DA server circuit breaker state changed"))
+	require.NotEmpty(t, changes)
+	require.Equal(t, BreakerClosed, changes[len(changes)-1].AttrValue("to"))
+}
+
+func TestDAClientCircuitBreakerFailover(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("failover input")
+	comm := Keccak256(input)
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	primary := newFailoverServer(t, http.StatusBadGateway, input)
+	replica := newFailoverServer(t, http.StatusOK, input)
+	client := NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL), WithClock(clk),
+		WithCircuitBreaker(1, time.Minute), WithEndpointHealth(100, time.Minute), WithRetryPolicy(NoRetryPolicy))
+
+	for i := 0; i < 3; i++ {
+		got, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, got)
+	}
+	// the open breaker of the primary routes the following calls to the replica directly
+	require.EqualValues(t, 1, primary.requests.Load())
+	require.EqualValues(t, 3, replica.requests.Load())
+
+	// without a breaker, every call tries the primary first until it is considered unhealthy
+	client = NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL), WithClock(clk),
+		WithEndpointHealth(100, time.Minute), WithRetryPolicy(NoRetryPolicy))
+	for i := 0; i < 3; i++ {
+		_, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 4, primary.requests.Load())
+}
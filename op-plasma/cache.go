@@ -0,0 +1,65 @@
+package plasma
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+)
+
+// inputCache is an LRU cache of verified inputs keyed by commitment, bounded by the total size of the inputs.
+// Inputs are copied on the way in and out, so callers cannot mutate the cached data.
+// inputCache is safe for concurrent use.
+type inputCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	// ll orders the entries from most to least recently used.
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key   string
+	input []byte
+}
+
+func newInputCache(maxBytes int) *inputCache {
+	return &inputCache{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// get returns a copy of the cached input for the commitment.
+func (c *inputCache) get(comm Commitment) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[string(comm.Encode())]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	return slices.Clone(elem.Value.(*cacheEntry).input), true
+}
+
+// add stores a copy of the input, evicting the least recently used inputs to stay within the size bound.
+// Inputs larger than the bound are not cached. It returns the size of the cache after the addition.
+func (c *inputCache) add(comm Commitment, input []byte) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := string(comm.Encode())
+	if _, ok := c.items[key]; ok || len(input) > c.maxBytes {
+		return c.size
+	}
+	c.items[key] = c.ll.PushFront(&cacheEntry{key: key, input: slices.Clone(input)})
+	c.size += len(input)
+	for c.size > c.maxBytes {
+		oldest := c.ll.Back()
+		entry := oldest.Value.(*cacheEntry)
+		c.ll.Remove(oldest)
+		delete(c.items, entry.key)
+		c.size -= len(entry.input)
+	}
+	return c.size
+}
@@ -0,0 +1,142 @@
+package plasma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+func TestInputCache(t *testing.T) {
+	cache := newInputCache(10)
+	a, b, c := []byte("aaaa"), []byte("bbbb"), []byte("cccc")
+
+	require.Equal(t, 4, cache.add(Keccak256(a), a))
+	require.Equal(t, 8, cache.add(Keccak256(b), b))
+	// touch a so that b is the least recently used
+	_, ok := cache.get(Keccak256(a))
+	require.True(t, ok)
+	require.Equal(t, 8, cache.add(Keccak256(c), c))
+
+	_, ok = cache.get(Keccak256(b))
+	require.False(t, ok, "least recently used input should be evicted")
+	for _, input := range [][]byte{a, c} {
+		data, ok := cache.get(Keccak256(input))
+		require.True(t, ok)
+		require.Equal(t, input, data)
+	}
+
+	// inputs larger than the cache are not stored
+	large := []byte("larger than the cache")
+	require.Equal(t, 8, cache.add(Keccak256(large), large))
+	_, ok = cache.get(Keccak256(large))
+	require.False(t, ok)
+
+	// neither the added nor the returned slices alias the cached data
+	a[0] = 'x'
+	data, _ := cache.get(Keccak256([]byte("aaaa")))
+	require.Equal(t, []byte("aaaa"), data)
+	data[0] = 'y'
+	data, _ = cache.get(Keccak256([]byte("aaaa")))
+	require.Equal(t, []byte("aaaa"), data)
+}
+
+func TestDAClientCache(t *testing.T) {
+	ctx := context.Background()
+	inputs := make(map[string][]byte)
+	var comms []Commitment
+	for i := 0; i < 4; i++ {
+		input := []byte(fmt.Sprintf("cached input %d", i))
+		comm := Keccak256(input)
+		inputs[fmt.Sprintf("/get/0x%x", comm.Encode())] = input
+		comms = append(comms, comm)
+	}
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		input, ok := inputs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(input)
+	}))
+	t.Cleanup(srv.Close)
+
+	m := MakeDAMetrics("test", opmetrics.With(prometheus.NewRegistry()))
+	client := NewDAClient(srv.URL, true, WithCache(1024), WithMetrics(m))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				for _, comm := range comms {
+					data, err := client.GetInput(ctx, comm)
+					if !assert.NoError(t, err) {
+						return
+					}
+					assert.Equal(t, inputs[fmt.Sprintf("/get/0x%x", comm.Encode())], data)
+					// mutating the result must not affect other callers
+					data[0] ^= 0xff
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// concurrent misses may fetch the same input more than once, but repeated reads are served from the cache
+	gets := 8 * 10 * len(comms)
+	hits := testutil.ToFloat64(m.CacheGetsTotal.WithLabelValues("true"))
+	misses := testutil.ToFloat64(m.CacheGetsTotal.WithLabelValues("false"))
+	require.EqualValues(t, gets, hits+misses)
+	require.EqualValues(t, misses, requests.Load())
+	require.Less(t, misses, float64(gets)/2)
+
+	var size int
+	for _, input := range inputs {
+		size += len(input)
+	}
+	require.EqualValues(t, size, testutil.ToFloat64(m.CacheSizeBytes))
+
+	t.Run("NotFoundNotCached", func(t *testing.T) {
+		unknown := Keccak256([]byte("unknown"))
+		_, err := client.GetInput(ctx, unknown)
+		require.ErrorIs(t, err, ErrNotFound)
+		_, ok := client.cache.get(unknown)
+		require.False(t, ok)
+	})
+
+	t.Run("MismatchNotCached", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("wrong input"))
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithCache(1024), WithRetryPolicy(NoRetryPolicy))
+		_, err := client.GetInput(ctx, comms[0])
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+		_, ok := client.cache.get(comms[0])
+		require.False(t, ok)
+	})
+
+	t.Run("NoVerifyNoCache", func(t *testing.T) {
+		requests.Store(0)
+		client := NewDAClient(srv.URL, false, WithCache(1024))
+		for i := 0; i < 2; i++ {
+			_, err := client.GetInput(ctx, comms[0])
+			require.NoError(t, err)
+		}
+		require.EqualValues(t, 2, requests.Load())
+	})
+}
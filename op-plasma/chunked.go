@@ -0,0 +1,182 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultChunkSize is the size of the chunks uploaded by SetInputChunked unless configured otherwise.
+const DefaultChunkSize = 8 << 20
+
+// DefaultUploadParallelism is the number of chunks uploaded concurrently by SetInputChunked unless configured otherwise.
+const DefaultUploadParallelism = 4
+
+// chunkKeyPrefix prefixes the keccak256 hash of a chunk to form its key in the DAServer store.
+// It cannot collide with an encoded commitment, which starts with the commitment type byte.
+const chunkKeyPrefix = "chunk:"
+
+func chunkKey(hash []byte) Commitment {
+	return append(Commitment(chunkKeyPrefix), hash...)
+}
+
+// WithChunkSize sets the size of the chunks uploaded by SetInputChunked. Defaults to DefaultChunkSize.
+func WithChunkSize(size int) DAClientOption {
+	return func(c *DAClient) {
+		c.chunkSize = size
+	}
+}
+
+// WithUploadParallelism sets the number of chunks uploaded concurrently by SetInputChunked.
+// Defaults to DefaultUploadParallelism.
+func WithUploadParallelism(n int) DAClientOption {
+	return func(c *DAClient) {
+		c.uploadParallelism = n
+	}
+}
+
+// SetInputChunked uploads size bytes read from r in fixed size chunks and returns their commitment, which is
+// identical to the one SetInput returns for the same bytes. The upload is made of three steps:
+//
+//  1. POST /missing_chunks with the keccak256 hashes of the chunks, to learn which chunks the server lacks.
+//  2. POST /put_chunk/0x<keccak256(chunk)> for every missing chunk, concurrently and with retries.
+//  3. POST /put_chunked/0x<commitment> with the manifest of chunk hashes, for the server to assemble and
+//     verify the input.
+//
+// Chunks the server already has are skipped, so calling SetInputChunked again after a failed upload resumes it.
+// Like the streaming methods, chunked uploads are not bound by the client's maximum input size, and only hold
+// the chunks being uploaded in memory. The input is read twice: once to compute the hashes, once to upload.
+// The bundled DAServer bounds the assembled input by its max chunked input size rather than its max request size.
+// The upload as a whole is not bound by the call timeout: each of its steps and chunk uploads gets its own.
+func (c *DAClient) SetInputChunked(ctx context.Context, r io.ReaderAt, size int64) (_ Commitment, err error) {
+	ctx, call := c.startCall(ctx, "SetInputChunked", nil)
+	defer func() { call.end(err) }()
+	if err := c.checkDARoutes(); err != nil {
+		return nil, err
+	}
+	if size <= 0 || c.chunkSize <= 0 {
+		return nil, ErrInvalidInput
+	}
+	if err := c.commType.checkSize(size); err != nil {
+		return nil, err
+	}
+	comm, hashes, err := c.hashChunks(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	call.key, call.size = comm, int(size)
+
+	manifest := make([]batchFrame, len(hashes))
+	for i, h := range hashes {
+		manifest[i] = batchFrame{payload: h}
+	}
+	var resp []batchFrame
+	err = c.callWithTimeout(ctx, func(ctx context.Context) (err error) {
+		resp, err = c.doBatch(ctx, "missing_chunks", manifest, len(hashes))
+		return err
+	})
+	if errors.Is(err, errBatchUnsupported) {
+		return nil, fmt.Errorf("server does not support chunked uploads: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to query missing chunks: %w", err)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(c.uploadParallelism, 1))
+	for i, f := range resp {
+		if f.status == batchStatusOK {
+			continue
+		}
+		i := i
+		g.Go(func() error {
+			off := int64(i) * int64(c.chunkSize)
+			chunk := make([]byte, min(int64(c.chunkSize), size-off))
+			if _, err := r.ReadAt(chunk, off); err != nil && !errors.Is(err, io.EOF) {
+				return fmt.Errorf("failed to read chunk %d: %w", i, err)
+			}
+			err := c.callWithTimeout(gctx, func(ctx context.Context) error {
+				_, err := doEndpoint(ctx, c, c.endpoints[0], func() (struct{}, error) {
+					return struct{}{}, c.putChunk(ctx, hashes[i], chunk)
+				})
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to upload chunk %d: %w", i, err)
+			}
+			c.metrics.RecordDABytesSent("SetInputChunked", len(chunk))
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	if err := writeBatchFrames(&body, manifest); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	err = c.callWithTimeout(ctx, func(ctx context.Context) error {
+		_, err := doEndpoint(ctx, c, c.endpoints[0], func() (struct{}, error) {
+			return struct{}{}, c.post(ctx, fmt.Sprintf("%s/put_chunked/0x%x", c.url, comm.Encode()), body.Bytes())
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize chunked upload: %w", err)
+	}
+	return comm, nil
+}
+
+// hashChunks reads the input once, computing its commitment and the keccak256 hash of every chunk.
+func (c *DAClient) hashChunks(r io.Reader) (Commitment, [][]byte, error) {
+	h, err := c.commType.hasher()
+	if err != nil {
+		return nil, nil, err
+	}
+	var hashes [][]byte
+	chunk := make([]byte, c.chunkSize)
+	for {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			h.Write(chunk[:n])
+			hashes = append(hashes, crypto.Keccak256(chunk[:n]))
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("failed to read input: %w", err)
+		}
+	}
+	return append(Commitment{byte(c.commType)}, h.Sum(nil)...), hashes, nil
+}
+
+func (c *DAClient) putChunk(ctx context.Context, hash []byte, chunk []byte) error {
+	return c.post(ctx, fmt.Sprintf("%s/put_chunk/0x%x", c.url, hash), chunk)
+}
+
+// post sends the body to the url and expects a 200 response. A 422 response is reported as ErrCommitmentMismatch.
+func (c *DAClient) post(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnprocessableEntity:
+		return newMismatchError(resp)
+	case resp.StatusCode != http.StatusOK:
+		return newStatusError(resp)
+	}
+	return nil
+}
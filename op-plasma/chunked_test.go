@@ -0,0 +1,218 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// chunkServer serves a DAServer, counting chunk uploads and rejecting them once failAfter is reached.
+type chunkServer struct {
+	*httptest.Server
+	store     *memStore
+	uploads   atomic.Int32
+	failAfter atomic.Int32
+}
+
+func newChunkServer(t *testing.T, opts ...DAServerOption) *chunkServer {
+	s := &chunkServer{store: newMemStore()}
+	s.failAfter.Store(-1)
+	handler := NewDAServer("", s.store, testlog.Logger(t, log.LevelDebug), opts...).Handler()
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/put_chunk/") {
+			if n := s.uploads.Add(1); s.failAfter.Load() >= 0 && n > s.failAfter.Load() {
+				http.Error(w, "upload quota exceeded", http.StatusForbidden)
+				return
+			}
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSetInputChunked(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1234))
+	// deliberately not a multiple of the chunk size
+	input := testutils.RandomData(rng, 10*1024+123)
+
+	t.Run("MatchesSingleShotUpload", func(t *testing.T) {
+		for _, commType := range []CommitmentType{Keccak256CommitmentType, Sha256CommitmentType} {
+			srv := newChunkServer(t)
+			client := NewDAClient(srv.URL, true, WithChunkSize(1024), WithUploadParallelism(3),
+				WithMaxInputSize(0), WithCommitmentType(commType), WithRetryPolicy(NoRetryPolicy))
+
+			comm, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+			require.NoError(t, err)
+			expected, err := NewCommitment(commType, input)
+			require.NoError(t, err)
+			require.Equal(t, expected, comm)
+			require.EqualValues(t, 11, srv.uploads.Load())
+
+			data, err := client.GetInput(ctx, comm)
+			require.NoError(t, err)
+			require.Equal(t, input, data)
+			// the chunks are kept as other uploads may share them
+			require.Len(t, srv.store.data, 12)
+		}
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		srv := newChunkServer(t)
+		srv.failAfter.Store(4)
+		client := NewDAClient(srv.URL, true, WithChunkSize(1024), WithUploadParallelism(1),
+			WithMaxInputSize(0), WithRetryPolicy(NoRetryPolicy))
+
+		_, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+		var re *RequestError
+		require.ErrorAs(t, err, &re)
+		require.Equal(t, http.StatusForbidden, re.StatusCode)
+		require.Len(t, srv.store.data, 4)
+
+		srv.failAfter.Store(-1)
+		srv.uploads.Store(0)
+		comm, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+		require.NoError(t, err)
+		require.EqualValues(t, 7, srv.uploads.Load(), "chunks already stored must be skipped")
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+
+	t.Run("SharedChunks", func(t *testing.T) {
+		srv := newChunkServer(t)
+		client := NewDAClient(srv.URL, true, WithChunkSize(1024), WithUploadParallelism(1),
+			WithMaxInputSize(0), WithRetryPolicy(NoRetryPolicy))
+		first, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+		require.NoError(t, err)
+
+		// the second input shares all but the last chunk with the first
+		other := append(bytes.Clone(input[:10*1024]), 0x01)
+		srv.uploads.Store(0)
+		second, err := client.SetInputChunked(ctx, bytes.NewReader(other), int64(len(other)))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, srv.uploads.Load(), "shared chunks must not be uploaded again")
+		data, err := client.GetInput(ctx, first)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+		data, err = client.GetInput(ctx, second)
+		require.NoError(t, err)
+		require.Equal(t, other, data)
+	})
+
+	t.Run("LargerThanMaxRequestSize", func(t *testing.T) {
+		// only the chunks and the manifest are bound by the max request size
+		srv := newChunkServer(t, WithMaxRequestSize(2048))
+		client := NewDAClient(srv.URL, true, WithChunkSize(1024), WithUploadParallelism(1),
+			WithMaxInputSize(0), WithRetryPolicy(NoRetryPolicy))
+		comm, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+		require.NoError(t, err)
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		srv := newChunkServer(t, WithMaxChunkedInputSize(4096))
+		client := NewDAClient(srv.URL, true, WithChunkSize(1024), WithUploadParallelism(1),
+			WithMaxInputSize(0), WithRetryPolicy(NoRetryPolicy))
+		_, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+		var re *RequestError
+		require.ErrorAs(t, err, &re)
+		require.Equal(t, http.StatusRequestEntityTooLarge, re.StatusCode)
+	})
+
+	t.Run("StreamedToStorage", func(t *testing.T) {
+		store := &streamStore{memStore: newMemStore()}
+		srv := httptest.NewServer(NewDAServer("", store, testlog.Logger(t, log.LevelDebug)).Handler())
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithChunkSize(1024), WithUploadParallelism(1),
+			WithMaxInputSize(0), WithRetryPolicy(NoRetryPolicy))
+		comm, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+		require.NoError(t, err)
+		require.Equal(t, 1024, store.maxRead, "the input must be streamed one chunk at a time")
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+
+	t.Run("ChangedInput", func(t *testing.T) {
+		srv := newChunkServer(t)
+		client := NewDAClient(srv.URL, true, WithChunkSize(1024), WithUploadParallelism(1),
+			WithMaxInputSize(0), WithRetryPolicy(NoRetryPolicy))
+		// the input is read twice, the second read returns different data than the hashed one
+		r := &changingReaderAt{data: input}
+		_, err := client.SetInputChunked(ctx, r, int64(len(input)))
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy))
+		_, err := client.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+		require.ErrorIs(t, err, errBatchUnsupported)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		client := NewDAClient("http://localhost", true)
+		_, err := client.SetInputChunked(ctx, bytes.NewReader(nil), 0)
+		require.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+// changingReaderAt flips the bytes it returns once the data was fully read once.
+type changingReaderAt struct {
+	data []byte
+	read int
+}
+
+func (r *changingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := bytes.NewReader(r.data).ReadAt(p, off)
+	if r.read >= len(r.data) {
+		for i := range p[:n] {
+			p[i] ^= 0xff
+		}
+	}
+	r.read += n
+	return n, err
+}
+
+// streamStore is a memStore implementing StreamStorage, recording the largest read of the stream.
+type streamStore struct {
+	*memStore
+	maxRead int
+}
+
+func (s *streamStore) PutStream(ctx context.Context, key Commitment, r io.Reader, size int64) error {
+	value := make([]byte, 0, size)
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		value = append(value, buf[:n]...)
+		s.maxRead = max(s.maxRead, n)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	if int64(len(value)) != size {
+		return io.ErrUnexpectedEOF
+	}
+	return s.Put(ctx, key, value)
+}
@@ -11,6 +11,11 @@ const (
 	EnabledFlagName         = "plasma.enabled"
 	DaServerAddressFlagName = "plasma.da-server"
 	VerifyOnReadFlagName    = "plasma.verify-on-read"
+	DaReplicasFlagName      = "plasma.da-server-replicas"
+	MirrorWritesFlagName    = "plasma.mirror-writes"
+	WriteQuorumFlagName     = "plasma.write-quorum"
+	ReadRepairFlagName      = "plasma.read-repair"
+	IPFSFlagName            = "plasma.ipfs"
 )
 
 func plasmaEnv(envprefix, v string) []string {
@@ -39,6 +44,39 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 			EnvVars:  plasmaEnv(envPrefix, "VERIFY_ON_READ"),
 			Category: category,
 		},
+		&cli.StringSliceFlag{
+			Name:     DaReplicasFlagName,
+			Usage:    "HTTP addresses of replica DA Servers, used in order when the primary DA Server fails",
+			EnvVars:  plasmaEnv(envPrefix, "DA_SERVER_REPLICAS"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     MirrorWritesFlagName,
+			Usage:    "Mirror inputs to the replica DA Servers after they are stored on the primary DA Server",
+			Value:    false,
+			EnvVars:  plasmaEnv(envPrefix, "MIRROR_WRITES"),
+			Category: category,
+		},
+		&cli.UintFlag{
+			Name:     WriteQuorumFlagName,
+			Usage:    "Write inputs to the primary and replica DA Servers concurrently, requiring this many of them to store each input. 0 only writes to the primary",
+			Value:    0,
+			EnvVars:  plasmaEnv(envPrefix, "WRITE_QUORUM"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     ReadRepairFlagName,
+			Usage:    "Write inputs back to the DA Servers that lacked them or returned corrupted data when they are read from another DA Server",
+			Value:    false,
+			EnvVars:  plasmaEnv(envPrefix, "READ_REPAIR"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     IPFSFlagName,
+			Usage:    "Store inputs on IPFS under CID commitments, addressing the DA Servers as IPFS 'gateway' or 'node-api'",
+			EnvVars:  plasmaEnv(envPrefix, "IPFS"),
+			Category: category,
+		},
 	}
 }
 
@@ -46,6 +84,13 @@ type CLIConfig struct {
 	Enabled      bool
 	DAServerURL  string
 	VerifyOnRead bool
+	ReplicaURLs  []string
+	MirrorWrites bool
+	// WriteQuorum is the number of DA servers that must store each input, 0 to only write to the primary.
+	WriteQuorum uint
+	ReadRepair  bool
+	// IPFS is the addressing of the DA servers as IPFS endpoints, empty for DA servers.
+	IPFS string
 }
 
 func (c CLIConfig) Check() error {
@@ -56,12 +101,39 @@ func (c CLIConfig) Check() error {
 		if _, err := url.Parse(c.DAServerURL); err != nil {
 			return fmt.Errorf("DA server URL is invalid: %w", err)
 		}
+		for _, replica := range c.ReplicaURLs {
+			if _, err := url.Parse(replica); err != nil {
+				return fmt.Errorf("DA server replica URL %q is invalid: %w", replica, err)
+			}
+		}
+		if endpoints := 1 + len(c.ReplicaURLs); c.WriteQuorum > uint(endpoints) {
+			return fmt.Errorf("write quorum of %d exceeds the %d configured DA servers", c.WriteQuorum, endpoints)
+		}
+		if c.IPFS != "" {
+			if _, err := ParseIPFSAddressing(c.IPFS); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
-func (c CLIConfig) NewDAClient() *DAClient {
-	return &DAClient{url: c.DAServerURL, verify: c.VerifyOnRead}
+// NewDAClient creates a DAClient from the config. It returns an error if the IPFS addressing is invalid.
+func (c CLIConfig) NewDAClient() (*DAClient, error) {
+	opts := []DAClientOption{
+		WithReplicaURLs(c.ReplicaURLs...),
+		WithMirroredWrites(c.MirrorWrites),
+		WithWriteQuorum(int(c.WriteQuorum)),
+		WithReadRepair(c.ReadRepair),
+	}
+	if c.IPFS != "" {
+		addressing, err := ParseIPFSAddressing(c.IPFS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithIPFS(addressing))
+	}
+	return NewDAClient(c.DAServerURL, c.VerifyOnRead, opts...), nil
 }
 
 func ReadCLIConfig(c *cli.Context) CLIConfig {
@@ -69,5 +141,10 @@ func ReadCLIConfig(c *cli.Context) CLIConfig {
 		Enabled:      c.Bool(EnabledFlagName),
 		DAServerURL:  c.String(DaServerAddressFlagName),
 		VerifyOnRead: c.Bool(VerifyOnReadFlagName),
+		ReplicaURLs:  c.StringSlice(DaReplicasFlagName),
+		MirrorWrites: c.Bool(MirrorWritesFlagName),
+		WriteQuorum:  c.Uint(WriteQuorumFlagName),
+		ReadRepair:   c.Bool(ReadRepairFlagName),
+		IPFS:         c.String(IPFSFlagName),
 	}
 }
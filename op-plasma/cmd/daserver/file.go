@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+)
+
+// FileStorage stores each DA input as a plain file in a directory, named by the hex encoded commitment
+// and holding the raw input, so the directory can be served or synced by other tools.
+// Files are written to a temporary file first and renamed into place, so readers never see partial inputs.
+type FileStorage struct {
+	dir string
+}
+
+var _ plasma.StreamStorage = (*FileStorage)(nil)
+
+// NewFileStorage creates a FileStorage in dir, creating the directory if needed.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+func (s *FileStorage) path(key plasma.Commitment) string {
+	return filepath.Join(s.dir, hex.EncodeToString(key))
+}
+
+func (s *FileStorage) Get(ctx context.Context, key plasma.Commitment) ([]byte, error) {
+	value, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, plasma.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read input file: %w", err)
+	}
+	return value, nil
+}
+
+func (s *FileStorage) Put(ctx context.Context, key plasma.Commitment, value []byte) error {
+	return s.PutStream(ctx, key, bytes.NewReader(value), int64(len(value)))
+}
+
+func (s *FileStorage) PutStream(ctx context.Context, key plasma.Commitment, r io.Reader, size int64) error {
+	f, err := os.CreateTemp(s.dir, hex.EncodeToString(key)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(f.Name()) // Clean up the temp file if it doesn't actually get moved into place
+	if n, err := io.Copy(f, io.LimitReader(r, size)); err != nil || n != size {
+		_ = f.Close()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("failed to write input file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close input file: %w", err)
+	}
+	if err := os.Rename(f.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("failed to move input file into place: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStorage) Has(ctx context.Context, key plasma.Commitment) (bool, error) {
+	_, err := os.Stat(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to stat input file: %w", err)
+	}
+	return true, nil
+}
+
+func (s *FileStorage) Delete(ctx context.Context, key plasma.Commitment) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return plasma.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to remove input file: %w", err)
+	}
+	return nil
+}
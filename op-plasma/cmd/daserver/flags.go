@@ -0,0 +1,120 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const EnvVarPrefix = "OP_PLASMA_DA_SERVER"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
+}
+
+var (
+	ListenAddrFlag = &cli.StringFlag{
+		Name:    "addr",
+		Usage:   "Address to listen on",
+		Value:   "127.0.0.1",
+		EnvVars: prefixEnvVars("ADDR"),
+	}
+	PortFlag = &cli.IntFlag{
+		Name:    "port",
+		Usage:   "Port to listen on",
+		Value:   3100,
+		EnvVars: prefixEnvVars("PORT"),
+	}
+	DataDirFlag = &cli.StringFlag{
+		Name:    "datadir",
+		Usage:   "Directory to store inputs in, in the op-program pre-image format. Default uses in-memory storage",
+		EnvVars: prefixEnvVars("DATADIR"),
+	}
+	FilePathFlag = &cli.StringFlag{
+		Name:    "file.path",
+		Usage:   "Directory to store inputs in as plain files named by commitment",
+		EnvVars: prefixEnvVars("FILE_PATH"),
+	}
+	S3BucketFlag = &cli.StringFlag{
+		Name:    "s3.bucket",
+		Usage:   "S3 bucket to store inputs in",
+		EnvVars: prefixEnvVars("S3_BUCKET"),
+	}
+	S3EndpointFlag = &cli.StringFlag{
+		Name:    "s3.endpoint",
+		Usage:   "Host and optional port of the S3 API",
+		Value:   "s3.amazonaws.com",
+		EnvVars: prefixEnvVars("S3_ENDPOINT"),
+	}
+	S3PrefixFlag = &cli.StringFlag{
+		Name:    "s3.prefix",
+		Usage:   "Prefix of the S3 object names of inputs",
+		EnvVars: prefixEnvVars("S3_PREFIX"),
+	}
+	S3RegionFlag = &cli.StringFlag{
+		Name:    "s3.region",
+		Usage:   "Region of the S3 bucket. Looked up from the endpoint if not set",
+		EnvVars: prefixEnvVars("S3_REGION"),
+	}
+	S3AccessKeyIDFlag = &cli.StringFlag{
+		Name:    "s3.access-key-id",
+		Usage:   "S3 access key ID. If not set, credentials are read from the AWS or MinIO environment variables or the IAM role",
+		EnvVars: prefixEnvVars("S3_ACCESS_KEY_ID"),
+	}
+	S3SecretAccessKeyFlag = &cli.StringFlag{
+		Name:    "s3.secret-access-key",
+		Usage:   "S3 secret access key",
+		EnvVars: prefixEnvVars("S3_SECRET_ACCESS_KEY"),
+	}
+	S3InsecureFlag = &cli.BoolFlag{
+		Name:    "s3.insecure",
+		Usage:   "Connect to the S3 endpoint over plain HTTP",
+		EnvVars: prefixEnvVars("S3_INSECURE"),
+	}
+	MaxRequestSizeFlag = &cli.Int64Flag{
+		Name:    "max-request-size",
+		Usage:   "Maximum size in bytes of a request body, including inputs streamed to /put",
+		Value:   plasma.DefaultMaxRequestSize,
+		EnvVars: prefixEnvVars("MAX_REQUEST_SIZE"),
+	}
+	MaxChunkedInputSizeFlag = &cli.Int64Flag{
+		Name:    "max-chunked-input-size",
+		Usage:   "Maximum size in bytes of an input assembled from uploaded chunks",
+		Value:   plasma.DefaultMaxChunkedInputSize,
+		EnvVars: prefixEnvVars("MAX_CHUNKED_INPUT_SIZE"),
+	}
+	DeleteTokenFlag = &cli.StringFlag{
+		Name:    "delete-token",
+		Usage:   "Bearer token authorizing input deletion. Deletion is disabled if not set",
+		EnvVars: prefixEnvVars("DELETE_TOKEN"),
+	}
+	StatusTokenFlag = &cli.StringFlag{
+		Name:    "status-token",
+		Usage:   "Bearer token authorizing commitment status updates. Status updates are disabled if not set",
+		EnvVars: prefixEnvVars("STATUS_TOKEN"),
+	}
+)
+
+var Flags = []cli.Flag{
+	ListenAddrFlag,
+	PortFlag,
+	DataDirFlag,
+	FilePathFlag,
+	S3BucketFlag,
+	S3EndpointFlag,
+	S3PrefixFlag,
+	S3RegionFlag,
+	S3AccessKeyIDFlag,
+	S3SecretAccessKeyFlag,
+	S3InsecureFlag,
+	MaxRequestSizeFlag,
+	MaxChunkedInputSizeFlag,
+	DeleteTokenFlag,
+	StatusTokenFlag,
+}
+
+func init() {
+	Flags = append(Flags, oplog.CLIFlags(EnvVarPrefix)...)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+)
+
+// DeletableKV is an op-program kvstore.KV that supports removing pre-images.
+type DeletableKV interface {
+	kvstore.KV
+	kvstore.Deleter
+}
+
+// KVAdapter stores DA inputs in an op-program kvstore.KV. As the kvstore uses 32 byte keys,
+// inputs are stored under the keccak256 hash of their encoded commitment.
+type KVAdapter struct {
+	kv DeletableKV
+}
+
+var _ plasma.Storage = (*KVAdapter)(nil)
+
+func NewKVAdapter(kv DeletableKV) *KVAdapter {
+	return &KVAdapter{kv: kv}
+}
+
+func (a *KVAdapter) Get(ctx context.Context, key plasma.Commitment) ([]byte, error) {
+	value, err := a.kv.Get(crypto.Keccak256Hash(key))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil, plasma.ErrNotFound
+	}
+	return value, err
+}
+
+func (a *KVAdapter) Put(ctx context.Context, key plasma.Commitment, value []byte) error {
+	return a.kv.Put(crypto.Keccak256Hash(key), value)
+}
+
+// Has looks up the value, as the kvstore has no cheaper way to check for a key.
+func (a *KVAdapter) Has(ctx context.Context, key plasma.Commitment) (bool, error) {
+	_, err := a.Get(ctx, key)
+	if errors.Is(err, plasma.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (a *KVAdapter) Delete(ctx context.Context, key plasma.Commitment) error {
+	err := a.kv.Delete(crypto.Keccak256Hash(key))
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return plasma.ErrNotFound
+	}
+	return err
+}
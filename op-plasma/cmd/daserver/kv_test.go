@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestKVAdapter(t *testing.T) {
+	for name, kv := range map[string]DeletableKV{
+		"mem":  kvstore.NewMemKV(),
+		"disk": kvstore.NewDiskKV(t.TempDir()),
+	} {
+		kv := kv
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := plasma.NewDAServer("127.0.0.1:0", NewKVAdapter(kv), testlog.Logger(t, log.LevelDebug),
+				plasma.WithDeleteToken("secret"))
+			require.NoError(t, srv.Start(ctx))
+			t.Cleanup(func() {
+				require.NoError(t, srv.Stop(ctx))
+			})
+
+			client := plasma.NewDAClient(fmt.Sprintf("http://%s", srv.Addr()), true, plasma.WithAuthToken("secret"))
+			input := []byte("stored in a kvstore")
+			comm, err := client.SetInput(ctx, input)
+			require.NoError(t, err)
+			data, err := client.GetInput(ctx, comm)
+			require.NoError(t, err)
+			require.Equal(t, input, data)
+
+			_, err = client.GetInput(ctx, plasma.Keccak256([]byte("unknown")))
+			require.ErrorIs(t, err, plasma.ErrNotFound)
+
+			require.NoError(t, client.DeleteInput(ctx, comm))
+			_, err = client.GetInput(ctx, comm)
+			require.ErrorIs(t, err, plasma.ErrNotFound)
+			require.ErrorIs(t, client.DeleteInput(ctx, comm), plasma.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+var (
+	Version   = "v0.0.1"
+	GitCommit = ""
+	GitDate   = ""
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Flags = cliapp.ProtectFlags(Flags)
+	app.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
+	app.Name = "da-server"
+	app.Usage = "Plasma DA Storage Service"
+	app.Description = "Service for storing plasma DA inputs, served to the DAClient over HTTP"
+	app.Action = cliapp.LifecycleCmd(StartDAServer)
+
+	ctx := opio.WithInterruptBlocker(context.Background())
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+// StartDAServer creates the DA server lifecycle from the CLI flags.
+func StartDAServer(cliCtx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+	logger := oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx))
+	oplog.SetGlobalLogHandler(logger.Handler())
+
+	store, err := newStorage(cliCtx, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := net.JoinHostPort(cliCtx.String(ListenAddrFlag.Name), strconv.Itoa(cliCtx.Int(PortFlag.Name)))
+	return plasma.NewDAServer(addr, store, logger,
+		plasma.WithMaxRequestSize(cliCtx.Int64(MaxRequestSizeFlag.Name)),
+		plasma.WithMaxChunkedInputSize(cliCtx.Int64(MaxChunkedInputSizeFlag.Name)),
+		plasma.WithDeleteToken(cliCtx.String(DeleteTokenFlag.Name)),
+		plasma.WithStatusToken(cliCtx.String(StatusTokenFlag.Name))), nil
+}
+
+// newStorage creates the storage selected by the CLI flags, in-memory storage if none is.
+func newStorage(cliCtx *cli.Context, logger log.Logger) (plasma.Storage, error) {
+	dataDir, filePath, bucket := cliCtx.String(DataDirFlag.Name), cliCtx.String(FilePathFlag.Name), cliCtx.String(S3BucketFlag.Name)
+	selected := 0
+	for _, v := range []string{dataDir, filePath, bucket} {
+		if v != "" {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return nil, fmt.Errorf("only one of --%s, --%s and --%s can be set", DataDirFlag.Name, FilePathFlag.Name, S3BucketFlag.Name)
+	}
+	switch {
+	case dataDir != "":
+		logger.Info("Using disk storage", "datadir", dataDir)
+		if err := os.MkdirAll(dataDir, 0755); err != nil {
+			return nil, fmt.Errorf("creating datadir: %w", err)
+		}
+		return NewKVAdapter(kvstore.NewDiskKV(dataDir)), nil
+	case filePath != "":
+		logger.Info("Using file storage", "path", filePath)
+		return NewFileStorage(filePath)
+	case bucket != "":
+		cfg := S3Config{
+			Endpoint:        cliCtx.String(S3EndpointFlag.Name),
+			Bucket:          bucket,
+			Prefix:          cliCtx.String(S3PrefixFlag.Name),
+			Region:          cliCtx.String(S3RegionFlag.Name),
+			AccessKeyID:     cliCtx.String(S3AccessKeyIDFlag.Name),
+			SecretAccessKey: cliCtx.String(S3SecretAccessKeyFlag.Name),
+			Insecure:        cliCtx.Bool(S3InsecureFlag.Name),
+		}
+		logger.Info("Using S3 storage", "endpoint", cfg.Endpoint, "bucket", cfg.Bucket, "prefix", cfg.Prefix)
+		return NewS3Storage(cfg)
+	default:
+		logger.Info("Using in-memory storage")
+		return NewKVAdapter(kvstore.NewMemKV()), nil
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+)
+
+// S3Config configures the S3 bucket DA inputs are stored in.
+type S3Config struct {
+	// Endpoint is the host and optional port of the S3 API, e.g. s3.amazonaws.com.
+	Endpoint string
+	Bucket   string
+	// Prefix is prepended to the object name of every input.
+	Prefix string
+	// Region of the bucket. If empty, it is looked up from the endpoint.
+	Region string
+	// AccessKeyID and SecretAccessKey are static credentials. If unset, credentials are read from the
+	// standard AWS and MinIO environment variables, falling back to the EC2/ECS IAM role.
+	AccessKeyID     string
+	SecretAccessKey string
+	// Insecure connects to the endpoint over plain HTTP.
+	Insecure bool
+	// Transport is the HTTP transport used to reach the endpoint. Defaults to the MinIO client's transport.
+	Transport http.RoundTripper
+}
+
+// Credentials returns the static credentials if configured, otherwise the chain of AWS, MinIO
+// environment and IAM role credentials.
+func (c S3Config) Credentials() *credentials.Credentials {
+	if c.AccessKeyID != "" || c.SecretAccessKey != "" {
+		return credentials.NewStaticV4(c.AccessKeyID, c.SecretAccessKey, "")
+	}
+	return credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.EnvMinio{},
+		&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+	})
+}
+
+// S3Storage stores each DA input as an object named by the prefix and the hex encoded commitment.
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+var _ plasma.StreamStorage = (*S3Storage)(nil)
+
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:     cfg.Credentials(),
+		Secure:    !cfg.Insecure,
+		Region:    cfg.Region,
+		Transport: cfg.Transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	return &S3Storage{client: client, bucket: cfg.Bucket, prefix: cfg.Prefix}, nil
+}
+
+func (s *S3Storage) object(key plasma.Commitment) string {
+	return s.prefix + hex.EncodeToString(key)
+}
+
+func (s *S3Storage) Get(ctx context.Context, key plasma.Commitment) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.object(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s3Error("get object", err)
+	}
+	defer obj.Close()
+	value, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, s3Error("read object", err)
+	}
+	return value, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key plasma.Commitment, value []byte) error {
+	return s.PutStream(ctx, key, bytes.NewReader(value), int64(len(value)))
+}
+
+func (s *S3Storage) PutStream(ctx context.Context, key plasma.Commitment, r io.Reader, size int64) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.object(key), r, size,
+		minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		return s3Error("put object", err)
+	}
+	return nil
+}
+
+func (s *S3Storage) Has(ctx context.Context, key plasma.Commitment) (bool, error) {
+	_, err := s.client.StatObject(ctx, s.bucket, s.object(key), minio.StatObjectOptions{})
+	if err := s3Error("stat object", err); errors.Is(err, plasma.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete removes the object. S3 does not report deletes of unknown objects, so the object is looked up first.
+func (s *S3Storage) Delete(ctx context.Context, key plasma.Commitment) error {
+	if ok, err := s.Has(ctx, key); err != nil {
+		return err
+	} else if !ok {
+		return plasma.ErrNotFound
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, s.object(key), minio.RemoveObjectOptions{}); err != nil {
+		return s3Error("remove object", err)
+	}
+	return nil
+}
+
+// s3Error maps a missing object to plasma.ErrNotFound, and throttling, server and network errors
+// to plasma.ErrStorageUnavailable.
+func s3Error(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	resp := minio.ToErrorResponse(err)
+	var netErr net.Error
+	switch {
+	case resp.Code == "NoSuchKey" || resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("failed to %s: %w: %w", op, plasma.ErrNotFound, err)
+	case resp.Code == "SlowDown" || resp.StatusCode >= 500 || errors.As(err, &netErr):
+		return fmt.Errorf("failed to %s: %w: %w", op, plasma.ErrStorageUnavailable, err)
+	default:
+		return fmt.Errorf("failed to %s: %w", op, err)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-plasma/storagetest"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+)
+
+// fakeS3 serves the path-style S3 object routes used by S3Storage, without checking signatures.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	// status, if set, is returned for all requests.
+	status int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{objects: make(map[string][]byte)}
+	srv := httptest.NewTLSServer(http.HandlerFunc(f.ServeHTTP))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the body is read before locking, as streamed puts may read other objects while they are sent
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+	name := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[name] = body
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[name]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3Storage(t *testing.T, srv *httptest.Server) *S3Storage {
+	store, err := NewS3Storage(S3Config{
+		Endpoint:        strings.TrimPrefix(srv.URL, "https://"),
+		Bucket:          "inputs",
+		Prefix:          "plasma/",
+		Region:          "us-east-1",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		Transport:       srv.Client().Transport,
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func TestStorageConformance(t *testing.T) {
+	t.Run("MemKV", func(t *testing.T) {
+		storagetest.Run(t, func(t *testing.T) plasma.Storage {
+			return NewKVAdapter(kvstore.NewMemKV())
+		})
+	})
+	t.Run("DiskKV", func(t *testing.T) {
+		storagetest.Run(t, func(t *testing.T) plasma.Storage {
+			return NewKVAdapter(kvstore.NewDiskKV(t.TempDir()))
+		})
+	})
+	t.Run("File", func(t *testing.T) {
+		storagetest.Run(t, func(t *testing.T) plasma.Storage {
+			store, err := NewFileStorage(t.TempDir())
+			require.NoError(t, err)
+			return store
+		})
+	})
+	t.Run("S3", func(t *testing.T) {
+		storagetest.Run(t, func(t *testing.T) plasma.Storage {
+			_, srv := newFakeS3(t)
+			return newTestS3Storage(t, srv)
+		})
+	})
+}
+
+func TestS3StorageErrors(t *testing.T) {
+	maxRetry := minio.MaxRetry
+	minio.MaxRetry = 1
+	t.Cleanup(func() { minio.MaxRetry = maxRetry })
+
+	ctx := context.Background()
+	s3, srv := newFakeS3(t)
+	store := newTestS3Storage(t, srv)
+	key := plasma.Keccak256([]byte("input"))
+	require.NoError(t, store.Put(ctx, key, []byte("input")))
+	s3.mu.Lock()
+	_, ok := s3.objects["/inputs/plasma/"+hex.EncodeToString(key)]
+	s3.mu.Unlock()
+	assert.True(t, ok, "object must be named by the prefixed hex commitment")
+
+	s3.mu.Lock()
+	s3.status = http.StatusServiceUnavailable
+	s3.mu.Unlock()
+	_, err := store.Get(ctx, key)
+	require.ErrorIs(t, err, plasma.ErrStorageUnavailable)
+	require.ErrorIs(t, store.Put(ctx, key, []byte("input")), plasma.ErrStorageUnavailable)
+
+	s3.mu.Lock()
+	s3.status = http.StatusForbidden
+	s3.mu.Unlock()
+	_, err = store.Has(ctx, key)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, plasma.ErrStorageUnavailable)
+	require.NotErrorIs(t, err, plasma.ErrNotFound)
+}
@@ -0,0 +1,213 @@
+package plasma
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrUnsupportedCommitment is returned when the commitment version byte is not a known commitment type.
+var ErrUnsupportedCommitment = errors.New("unsupported commitment type")
+
+// ErrInvalidCommitment is returned when the commitment cannot be decoded.
+var ErrInvalidCommitment = errors.New("invalid commitment")
+
+// CommitmentType is the version byte prefixed to every encoded commitment.
+type CommitmentType byte
+
+const (
+	// Keccak256CommitmentType is the default commitment type: keccak256(input).
+	Keccak256CommitmentType CommitmentType = 0x00
+	// Sha256CommitmentType commits to the input with sha256(input).
+	Sha256CommitmentType CommitmentType = 0x01
+	// IPFSCommitmentType commits to the input with the binary CIDv1 of the input as a single raw block
+	// (raw codec, sha2-256 multihash), so the input can be retrieved by CID from any IPFS gateway.
+	IPFSCommitmentType CommitmentType = 0x02
+	// KZGCommitmentType commits to the input with the versioned hash of the KZG commitment to the input
+	// encoded as a single blob, so the input can be posted as a blob without computing a new commitment.
+	// Inputs are limited to the capacity of one blob, see BlobTooLargeError.
+	KZGCommitmentType CommitmentType = 0x03
+)
+
+// ipfsCIDPrefix is the binary CIDv1 prefix of a raw block addressed by its sha2-256 multihash:
+// CID version, raw codec, sha2-256 multihash code and digest length.
+var ipfsCIDPrefix = []byte{0x01, cid.Raw, multihash.SHA2_256, sha256.Size}
+
+// ipfsCIDLen is the length of an IPFS commitment digest: the CID prefix followed by the sha256 digest.
+var ipfsCIDLen = len(ipfsCIDPrefix) + sha256.Size
+
+// legacyCommitmentLen is the length of a raw keccak256 key without a version byte.
+const legacyCommitmentLen = 32
+
+func (t CommitmentType) String() string {
+	switch t {
+	case Keccak256CommitmentType:
+		return "keccak256"
+	case Sha256CommitmentType:
+		return "sha256"
+	case IPFSCommitmentType:
+		return "ipfs"
+	case KZGCommitmentType:
+		return "kzg"
+	default:
+		return fmt.Sprintf("unknown(0x%02x)", byte(t))
+	}
+}
+
+// digest returns the hash of the input for the commitment type.
+func (t CommitmentType) digest(input []byte) ([]byte, error) {
+	switch t {
+	case Keccak256CommitmentType:
+		return crypto.Keccak256(input), nil
+	case Sha256CommitmentType:
+		h := sha256.Sum256(input)
+		return h[:], nil
+	case IPFSCommitmentType:
+		h := sha256.Sum256(input)
+		return append(bytes.Clone(ipfsCIDPrefix), h[:]...), nil
+	case KZGCommitmentType:
+		return kzgDigest(input)
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedCommitment, t)
+	}
+}
+
+// hasher returns a hash to compute the commitment digest incrementally.
+func (t CommitmentType) hasher() (hash.Hash, error) {
+	switch t {
+	case Keccak256CommitmentType:
+		return sha3.NewLegacyKeccak256(), nil
+	case Sha256CommitmentType:
+		return sha256.New(), nil
+	case IPFSCommitmentType:
+		return &prefixedHash{Hash: sha256.New(), prefix: ipfsCIDPrefix}, nil
+	case KZGCommitmentType:
+		return &blobHash{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedCommitment, t)
+	}
+}
+
+// Commitment is a self describing commitment to an input: a CommitmentType byte followed by the digest.
+// Raw 32 byte keys without a version byte are accepted by DecodeCommitment and interpreted as keccak256
+// commitments for compatibility with callers that predate versioned commitments.
+type Commitment []byte
+
+// NewCommitment computes the commitment of the given type for the input.
+func NewCommitment(t CommitmentType, input []byte) (Commitment, error) {
+	digest, err := t.digest(input)
+	if err != nil {
+		return nil, err
+	}
+	return append(Commitment{byte(t)}, digest...), nil
+}
+
+// Keccak256 returns the keccak256 commitment for the input.
+func Keccak256(input []byte) Commitment {
+	return append(Commitment{byte(Keccak256CommitmentType)}, crypto.Keccak256(input)...)
+}
+
+// DecodeCommitment validates the encoded commitment and returns it in its versioned form.
+// A raw 32 byte key is treated as a legacy keccak256 commitment.
+func DecodeCommitment(data []byte) (Commitment, error) {
+	if len(data) == legacyCommitmentLen {
+		return append(Commitment{byte(Keccak256CommitmentType)}, data...), nil
+	}
+	if len(data) == 0 {
+		return nil, ErrInvalidCommitment
+	}
+	t := CommitmentType(data[0])
+	switch t {
+	case Keccak256CommitmentType, Sha256CommitmentType:
+		if len(data) != 1+32 {
+			return nil, fmt.Errorf("%w: %v commitment has length %d", ErrInvalidCommitment, t, len(data))
+		}
+	case IPFSCommitmentType:
+		if len(data) != 1+ipfsCIDLen || !bytes.HasPrefix(data[1:], ipfsCIDPrefix) {
+			return nil, fmt.Errorf("%w: %v commitment is not a raw sha2-256 CIDv1", ErrInvalidCommitment, t)
+		}
+	case KZGCommitmentType:
+		if !isVersionedKZGHash(data[1:]) {
+			return nil, fmt.Errorf("%w: %v commitment is not a KZG versioned hash", ErrInvalidCommitment, t)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedCommitment, t)
+	}
+	return Commitment(data), nil
+}
+
+// CommitmentFromCID returns the IPFS commitment for the CID in any multibase encoding.
+// Only CIDv1 of raw blocks with a sha2-256 multihash are supported.
+func CommitmentFromCID(s string) (Commitment, error) {
+	c, err := cid.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCommitment, err)
+	}
+	return DecodeCommitment(append(Commitment{byte(IPFSCommitmentType)}, c.Bytes()...))
+}
+
+// CID returns the base32 string form of an IPFS commitment, as used to address the input on IPFS.
+func (c Commitment) CID() (string, error) {
+	if c.Type() != IPFSCommitmentType {
+		return "", fmt.Errorf("%w: %v commitment has no CID", ErrUnsupportedCommitment, c.Type())
+	}
+	id, err := cid.Cast(c.Digest())
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCommitment, err)
+	}
+	return id.String(), nil
+}
+
+// Type returns the commitment type. It assumes the commitment is in its versioned form.
+func (c Commitment) Type() CommitmentType {
+	if len(c) == 0 {
+		return Keccak256CommitmentType
+	}
+	return CommitmentType(c[0])
+}
+
+// Digest returns the commitment without its version byte.
+func (c Commitment) Digest() []byte {
+	if len(c) == 0 {
+		return nil
+	}
+	return c[1:]
+}
+
+// Encode returns the serialized commitment, including the version byte.
+func (c Commitment) Encode() []byte {
+	return []byte(c)
+}
+
+// Verify checks the input against the commitment, dispatching on the commitment type.
+func (c Commitment) Verify(input []byte) error {
+	digest, err := c.Type().digest(input)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(digest, c.Digest()) {
+		return ErrCommitmentMismatch
+	}
+	return nil
+}
+
+// prefixedHash prepends a fixed prefix to the digest of the wrapped hash.
+type prefixedHash struct {
+	hash.Hash
+	prefix []byte
+}
+
+func (h *prefixedHash) Sum(b []byte) []byte {
+	return h.Hash.Sum(append(b, h.prefix...))
+}
+
+func (h *prefixedHash) Size() int {
+	return len(h.prefix) + h.Hash.Size()
+}
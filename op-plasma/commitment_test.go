@@ -0,0 +1,99 @@
+package plasma
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitmentData(t *testing.T) {
+	input := []byte("hello plasma")
+
+	tests := []struct {
+		name     string
+		commType CommitmentType
+		digest   []byte
+	}{
+		{name: "keccak256", commType: Keccak256CommitmentType, digest: crypto.Keccak256(input)},
+		{name: "sha256", commType: Sha256CommitmentType, digest: func() []byte { h := sha256.Sum256(input); return h[:] }()},
+		{name: "ipfs", commType: IPFSCommitmentType, digest: func() []byte { h := sha256.Sum256(input); return append([]byte{0x01, 0x55, 0x12, 0x20}, h[:]...) }()},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			comm, err := NewCommitment(test.commType, input)
+			require.NoError(t, err)
+			require.Equal(t, test.commType, comm.Type())
+			require.Equal(t, test.digest, comm.Digest())
+			require.Equal(t, append([]byte{byte(test.commType)}, test.digest...), comm.Encode())
+			require.NoError(t, comm.Verify(input))
+			require.ErrorIs(t, comm.Verify([]byte("other")), ErrCommitmentMismatch)
+
+			decoded, err := DecodeCommitment(comm.Encode())
+			require.NoError(t, err)
+			require.Equal(t, comm, decoded)
+		})
+	}
+}
+
+func TestDecodeCommitment(t *testing.T) {
+	input := []byte("hello plasma")
+
+	t.Run("LegacyKeccak", func(t *testing.T) {
+		comm, err := DecodeCommitment(crypto.Keccak256(input))
+		require.NoError(t, err)
+		require.Equal(t, Keccak256(input), comm)
+		require.NoError(t, comm.Verify(input))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := DecodeCommitment(nil)
+		require.ErrorIs(t, err, ErrInvalidCommitment)
+	})
+
+	t.Run("WrongLength", func(t *testing.T) {
+		_, err := DecodeCommitment([]byte{byte(Sha256CommitmentType), 0x01, 0x02})
+		require.ErrorIs(t, err, ErrInvalidCommitment)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := DecodeCommitment(append([]byte{0xff}, crypto.Keccak256(input)...))
+		require.ErrorIs(t, err, ErrUnsupportedCommitment)
+
+		_, err = NewCommitment(CommitmentType(0xff), input)
+		require.ErrorIs(t, err, ErrUnsupportedCommitment)
+	})
+}
+
+func TestCommitmentCID(t *testing.T) {
+	// the CIDv1 of the raw block "hello world", as computed by IPFS implementations
+	const helloCID = "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e"
+	comm, err := NewCommitment(IPFSCommitmentType, []byte("hello world"))
+	require.NoError(t, err)
+	id, err := comm.CID()
+	require.NoError(t, err)
+	require.Equal(t, helloCID, id)
+
+	decoded, err := CommitmentFromCID(helloCID)
+	require.NoError(t, err)
+	require.Equal(t, comm, decoded)
+
+	h, err := IPFSCommitmentType.hasher()
+	require.NoError(t, err)
+	_, _ = h.Write([]byte("hello world"))
+	require.Equal(t, comm.Digest(), h.Sum(nil))
+	require.Equal(t, len(comm.Digest()), h.Size())
+
+	_, err = Keccak256([]byte("hello world")).CID()
+	require.ErrorIs(t, err, ErrUnsupportedCommitment)
+
+	// CIDv0 and dag-pb CIDs do not address the input as a single raw block
+	_, err = CommitmentFromCID("QmWATWQ7fVPP2EFGu71UkfnqhYXDYH566qy47CnJDgvs8u")
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+	_, err = CommitmentFromCID("not a cid")
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+	_, err = DecodeCommitment(append([]byte{byte(IPFSCommitmentType), 0x01, 0x70}, comm.Digest()[2:]...))
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+}
@@ -3,14 +3,37 @@ package plasma
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 )
 
+// DefaultMaxInputSize is the largest input accepted by SetInput and returned by GetInput unless configured
+// otherwise. Challenged inputs are resolved by posting them as calldata to the DataAvailabilityChallenge
+// contract, so larger inputs could not be resolved on L1 where transactions are limited to 128KiB.
+const DefaultMaxInputSize = 128 * 1024
+
+// DefaultResponseHeaderTimeout bounds how long the HTTP client used by NewDAClient waits for the response
+// headers of a request, unless another client is configured. The client has no overall request timeout, which
+// would cut off large streamed transfers: calls are bounded by the call timeout instead, see DefaultCallTimeout.
+const DefaultResponseHeaderTimeout = 30 * time.Second
+
+// DefaultIdleConnTimeout is how long the HTTP client used by NewDAClient keeps idle connections open.
+const DefaultIdleConnTimeout = 90 * time.Second
+
 // ErrNotFound is returned when the server could not find the input.
 var ErrNotFound = errors.New("not found")
 
@@ -20,67 +43,679 @@ var ErrCommitmentMismatch = errors.New("commitment mismatch")
 // ErrInvalidInput is returned when the input is not valid for posting to the DA storage.
 var ErrInvalidInput = errors.New("invalid input")
 
+// ErrInputTooLarge is returned when an input exceeds the client's maximum input size.
+var ErrInputTooLarge = errors.New("input too large")
+
+// maxErrorMessageSize bounds how much of an error response body is included in RequestError and ServerError.
+const maxErrorMessageSize = 512
+
+// RequestError is returned when the DA server rejects a request with a 4xx status code, other than
+// 404 for GetInput which is reported as ErrNotFound. It is permanent and never retried.
+type RequestError struct {
+	StatusCode int
+	// Message is the start of the response body, if any.
+	Message string
+}
+
+func (e *RequestError) Error() string {
+	return statusErrorString("request rejected", e.StatusCode, e.Message)
+}
+
+// ServerError is returned when the DA server fails to handle a request with a 5xx status code.
+// It is transient and retried according to the client's RetryPolicy.
+type ServerError struct {
+	// StatusCode is the HTTP status code, or 0 for the failed items of a batch response.
+	StatusCode int
+	// Message is the start of the response body, if any.
+	Message string
+}
+
+func (e *ServerError) Error() string {
+	return statusErrorString("server error", e.StatusCode, e.Message)
+}
+
+func statusErrorString(prefix string, code int, msg string) string {
+	if code == 0 {
+		return fmt.Sprintf("%s: %s", prefix, msg)
+	}
+	if msg == "" {
+		return fmt.Sprintf("%s: status %d", prefix, code)
+	}
+	return fmt.Sprintf("%s: status %d: %s", prefix, code, msg)
+}
+
+// newStatusError returns a *ServerError for 5xx responses and a *RequestError otherwise,
+// including the start of the response body. The caller remains responsible for closing the body.
+func newStatusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageSize))
+	msg := strings.TrimSpace(string(body))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return &ServerError{StatusCode: resp.StatusCode, Message: msg}
+	}
+	return &RequestError{StatusCode: resp.StatusCode, Message: msg}
+}
+
+// ResponseTooLargeError is returned when the server responds with more data than the client's maximum
+// input size. It matches ErrInputTooLarge with errors.Is, and never ErrCommitmentMismatch: the response
+// is abandoned before it could be verified.
+type ResponseTooLargeError struct {
+	// Limit is the maximum input size of the client.
+	Limit int64
+	// Size is the size announced by the server, or -1 if the response was cut off while reading.
+	Size int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	if e.Size < 0 {
+		return fmt.Sprintf("%v: server response exceeds limit of %d bytes", ErrInputTooLarge, e.Limit)
+	}
+	return fmt.Sprintf("%v: server announced %d bytes, exceeding limit of %d bytes", ErrInputTooLarge, e.Size, e.Limit)
+}
+
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrInputTooLarge
+}
+
 // DAClient is an HTTP client to communicate with a DA storage service.
 // It creates commitments and retrieves input data + verifies if needed.
-// Currently only supports Keccak256 commitments but may be extended eventually.
+// Commitments are versioned, see Commitment for the supported types.
 type DAClient struct {
+	// url is the base URL of the primary DA server.
 	url string
-	// VerifyOnRead sets the client to verify the commitment on read.
-	// SHOULD enable if the storage service is not trusted.
-	verify bool
+	// endpoints are the primary followed by the replica DA servers, used for failover on read.
+	endpoints []*endpoint
+	// mirror enables best-effort mirroring of writes to the replicas.
+	mirror bool
+	// writeQuorum is the number of endpoints that must store written inputs, 0 to only write to the primary.
+	writeQuorum int
+	// readRepair enables writing inputs read back to the endpoints that lacked them.
+	readRepair bool
+	// repairs tracks the read repairs running in the background, see Close.
+	repairs sync.WaitGroup
+	// observer is notified of the outcome of every failover request, may be nil.
+	observer EndpointObserver
+	// failureThreshold and cooldown configure when endpoints are considered unhealthy.
+	failureThreshold int
+	cooldown         time.Duration
+	// breakerThreshold and breakerCooldown configure the circuit breaker of the endpoints, disabled if 0.
+	breakerThreshold int
+	breakerCooldown  time.Duration
+	clock            clock.Clock
+	// verifier checks the data read against the commitment, nil if reads are not verified.
+	// SHOULD be set if the storage service is not trusted.
+	verifier Verifier
+	// customVerifier is set if the verifier was configured with WithVerifier. Otherwise it is VerifyCommitment,
+	// which is computed incrementally while the data is read.
+	customVerifier bool
+	// commType is the type of commitment created by SetInput.
+	commType CommitmentType
+	// retry is the policy applied to transient request failures.
+	retry RetryPolicy
+	// client is the HTTP client all requests are sent with.
+	client *http.Client
+	// maxInputSize is the maximum size of inputs stored or fetched in memory, 0 for no limit.
+	maxInputSize int
+	// metrics records the requests made by the client.
+	metrics Metricer
+	// authToken is sent as bearer token with DeleteInput and SetCommitmentStatus requests.
+	authToken string
+	// cache holds recently verified inputs, may be nil.
+	cache *inputCache
+	// callTimeout bounds calls made with a context without deadline, 0 for no bound.
+	callTimeout time.Duration
+	// log and tracer record every call of the client, may be nil.
+	log    log.Logger
+	tracer trace.Tracer
+	// ipfs is the addressing of the endpoints in IPFS mode, 0 for DA servers.
+	ipfs IPFSAddressing
+	// chunkSize and uploadParallelism configure SetInputChunked.
+	chunkSize         int
+	uploadParallelism int
+}
+
+// DAClientOption configures optional DAClient behavior.
+type DAClientOption func(c *DAClient)
+
+// WithHTTPClient sets the HTTP client all requests are sent with.
+func WithHTTPClient(client *http.Client) DAClientOption {
+	return func(c *DAClient) {
+		c.client = client
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request, including reading the response body.
+// It applies to streamed and chunked transfers too, so it bounds the size of the inputs they can transfer.
+// The configured HTTP client is copied, not modified.
+func WithTimeout(timeout time.Duration) DAClientOption {
+	return func(c *DAClient) {
+		client := *c.client
+		client.Timeout = timeout
+		c.client = &client
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the DA server.
+// The configured HTTP client and its transport are copied, not modified.
+func WithTLSConfig(cfg *tls.Config) DAClientOption {
+	return func(c *DAClient) {
+		transport, ok := c.client.Transport.(*http.Transport)
+		if !ok || transport == nil {
+			transport = http.DefaultTransport.(*http.Transport)
+		}
+		transport = transport.Clone()
+		transport.TLSClientConfig = cfg
+		client := *c.client
+		client.Transport = transport
+		c.client = &client
+	}
+}
+
+// WithMaxInputSize sets the maximum size of inputs stored with SetInput or fetched with GetInput.
+// A size of 0 disables the limit. Defaults to DefaultMaxInputSize.
+// The streaming methods are not bound by this limit as they never hold the input in memory, except for
+// GetInputReader with a custom verifier, which buffers the input to verify it.
+func WithMaxInputSize(size int) DAClientOption {
+	return func(c *DAClient) {
+		c.maxInputSize = size
+	}
+}
+
+// WithReplicaURLs adds replica DA servers. GetInput falls back to them, in order, when the primary
+// fails or does not have the input, skipping endpoints that recently failed repeatedly.
+func WithReplicaURLs(urls ...string) DAClientOption {
+	return func(c *DAClient) {
+		for _, url := range urls {
+			c.endpoints = append(c.endpoints, &endpoint{url: url})
+		}
+	}
+}
+
+// WithMirroredWrites makes SetInput mirror inputs to the replicas after the primary accepted them.
+// Mirroring is best-effort: failures are only reported to the EndpointObserver.
+func WithMirroredWrites(mirror bool) DAClientOption {
+	return func(c *DAClient) {
+		c.mirror = mirror
+	}
+}
+
+// WithEndpointObserver sets a callback notified of which endpoint served each request and of endpoint health.
+func WithEndpointObserver(observer EndpointObserver) DAClientOption {
+	return func(c *DAClient) {
+		c.observer = observer
+	}
+}
+
+// WithEndpointHealth configures the number of consecutive transient failures after which an endpoint is
+// considered unhealthy, and how long it is skipped for.
+func WithEndpointHealth(threshold int, cooldown time.Duration) DAClientOption {
+	return func(c *DAClient) {
+		c.failureThreshold = threshold
+		c.cooldown = cooldown
+	}
 }
 
-func NewDAClient(url string, verify bool) *DAClient {
-	return &DAClient{url, verify}
+// WithClock sets the clock used to track endpoint health.
+func WithClock(clock clock.Clock) DAClientOption {
+	return func(c *DAClient) {
+		c.clock = clock
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry transient request failures. Defaults to DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) DAClientOption {
+	return func(c *DAClient) {
+		c.retry = policy
+	}
+}
+
+// WithMetrics sets the metrics recording the client's requests. Defaults to NoopMetrics.
+func WithMetrics(m Metricer) DAClientOption {
+	return func(c *DAClient) {
+		c.metrics = m
+	}
+}
+
+// WithCache enables an in-memory LRU cache of verified inputs, bounded by their total size in bytes.
+// Repeated GetInput calls for a cached commitment return a copy of the input without contacting the server.
+// Only inputs that were verified on read are cached, so the cache has no effect if the client does not verify.
+func WithCache(maxBytes int) DAClientOption {
+	return func(c *DAClient) {
+		if maxBytes > 0 {
+			c.cache = newInputCache(maxBytes)
+		} else {
+			c.cache = nil
+		}
+	}
+}
+
+// WithAuthToken sets the bearer token authorizing DeleteInput and SetCommitmentStatus requests.
+func WithAuthToken(token string) DAClientOption {
+	return func(c *DAClient) {
+		c.authToken = token
+	}
+}
+
+// WithCommitmentType sets the type of commitment computed by SetInput. Defaults to keccak256.
+func WithCommitmentType(t CommitmentType) DAClientOption {
+	return func(c *DAClient) {
+		c.commType = t
+	}
+}
+
+// NewDAClient creates a client of the DA server at url. If verify is set, the data read is checked with
+// VerifyCommitment, unless another Verifier is configured with WithVerifier.
+func NewDAClient(url string, verify bool, opts ...DAClientOption) *DAClient {
+	c := &DAClient{
+		url:       url,
+		endpoints: []*endpoint{{url: url}},
+		commType:  Keccak256CommitmentType,
+		retry:     DefaultRetryPolicy,
+		client:    newDefaultHTTPClient(),
+
+		callTimeout: DefaultCallTimeout,
+		metrics:     NoopMetrics,
+
+		maxInputSize:      DefaultMaxInputSize,
+		chunkSize:         DefaultChunkSize,
+		uploadParallelism: DefaultUploadParallelism,
+		failureThreshold:  DefaultEndpointFailureThreshold,
+		cooldown:          DefaultEndpointCooldown,
+		clock:             clock.SystemClock,
+	}
+	if verify {
+		c.verifier = VerifyCommitment
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// newDefaultHTTPClient returns the HTTP client used unless configured otherwise, bounding requests with
+// transport level timeouts only.
+func newDefaultHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = DefaultResponseHeaderTimeout
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	return &http.Client{Transport: transport}
 }
 
 // GetInput returns the input data for the given commitment bytes.
-func (c *DAClient) GetInput(ctx context.Context, key []byte) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/get/0x%x", c.url, key), nil)
+// Raw 32 byte keccak256 keys without a version byte are still accepted and requested as-is.
+// Transient failures are retried according to the client's RetryPolicy. If replicas are configured,
+// they are tried in order when an endpoint fails, does not have the input (replication may lag)
+// or returns data that does not match the commitment, and if read repair is enabled, the endpoints
+// that lacked the input are repaired in the background. Responses exceeding the maximum input size are abandoned
+// with a *ResponseTooLargeError, so a misbehaving server cannot make the client buffer unbounded data.
+func (c *DAClient) GetInput(ctx context.Context, key Commitment) (_ []byte, err error) {
+	ctx, call := c.startCall(ctx, "GetInput", key)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	comm, err := DecodeCommitment(key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	if c.cache != nil && c.verifier != nil {
+		input, ok := c.cache.get(comm)
+		c.metrics.RecordDACacheGet(ok)
+		if ok {
+			call.size = len(input)
+			return input, nil
+		}
+	}
+	var (
+		lastErr error
+		lagging []*endpoint
+	)
+	for _, e := range c.orderedEndpoints() {
+		input, err := doEndpoint(ctx, c, e, func() ([]byte, error) {
+			return c.getInput(ctx, e.url, key, comm)
+		})
+		c.recordResult(e, "GetInput", err)
+		if err == nil {
+			c.metrics.RecordDABytesReceived("GetInput", len(input))
+			call.size = len(input)
+			if c.cache != nil && c.verifier != nil {
+				c.metrics.RecordDACacheSize(c.cache.add(comm, input))
+			}
+			if c.readRepair && c.verifier != nil && len(lagging) > 0 {
+				// the input is returned to the caller, who may modify it while it is uploaded
+				c.repairInput(comm, slices.Clone(input), lagging)
+			}
+			return input, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrCommitmentMismatch) {
+			lagging = append(lagging, e)
+		}
+		// prefer reporting a more specific error than not found from another endpoint
+		if lastErr == nil || errors.Is(lastErr, ErrNotFound) {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// getInput fetches the input for key, requested as-is, from the endpoint at baseURL. The response is read through
+// a limited reader and, if the client verifies on read, checked against comm: hashed while it is read by the
+// default verifier, or passed to the custom verifier once read.
+func (c *DAClient) getInput(ctx context.Context, baseURL string, key Commitment, comm Commitment) ([]byte, error) {
+	req, err := c.newGetRequest(ctx, baseURL, key, comm)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrNotFound
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
-	input, err := io.ReadAll(resp.Body)
-	if err != nil {
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.getStatusError(resp)
+	}
+	limit := int64(c.maxInputSize)
+	if limit != 0 && resp.ContentLength > limit {
+		return nil, &ResponseTooLargeError{Limit: limit, Size: resp.ContentLength}
+	}
+	body := io.Reader(resp.Body)
+	if limit != 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+	var h hash.Hash
+	if c.hashOnRead() {
+		if h, err = comm.Type().hasher(); err != nil {
+			return nil, err
+		}
+		body = io.TeeReader(body, h)
+	}
+	var buf bytes.Buffer
+	if limit != 0 && resp.ContentLength > 0 {
+		buf.Grow(int(resp.ContentLength))
+	}
+	if _, err := buf.ReadFrom(body); err != nil {
 		return nil, err
 	}
-	if c.verify {
-		exp := crypto.Keccak256(input)
-		if !bytes.Equal(exp, key) {
-			return nil, ErrCommitmentMismatch
+	if limit != 0 && int64(buf.Len()) > limit {
+		return nil, &ResponseTooLargeError{Limit: limit, Size: -1}
+	}
+	if h != nil && !bytes.Equal(h.Sum(nil), comm.Digest()) {
+		return nil, ErrCommitmentMismatch
+	}
+	if c.verifier != nil && c.customVerifier {
+		if err := c.verifier(comm, buf.Bytes()); err != nil {
+			return nil, err
 		}
 	}
-	return input, nil
+	return buf.Bytes(), nil
 }
 
-// SetInput sets the input data and returns the keccak256 hash commitment.
-func (c *DAClient) SetInput(ctx context.Context, img []byte) ([]byte, error) {
+// Exists reports whether the primary DA server has the input for the given commitment, without downloading it.
+// It sends a HEAD request to the get route, falling back to a ranged GET of the first byte if the server does
+// not support HEAD. The input is not verified, so Exists is only a hint for skipping redundant uploads.
+func (c *DAClient) Exists(ctx context.Context, key Commitment) (_ bool, err error) {
+	ctx, call := c.startCall(ctx, "Exists", key)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	if err := c.checkDARoutes(); err != nil {
+		return false, err
+	}
+	comm, err := DecodeCommitment(key)
+	if err != nil {
+		return false, err
+	}
+	primary := c.endpoints[0]
+	exists, err := doEndpoint(ctx, c, primary, func() (bool, error) {
+		exists, err := c.exists(ctx, primary.url, comm, http.MethodHead)
+		if errors.Is(err, errHeadUnsupported) {
+			return c.exists(ctx, primary.url, comm, http.MethodGet)
+		}
+		return exists, err
+	})
+	c.recordResult(primary, "Exists", err)
+	return exists, err
+}
+
+// errHeadUnsupported is returned by exists when the server does not support HEAD requests.
+var errHeadUnsupported = errors.New("HEAD not supported")
+
+func (c *DAClient) exists(ctx context.Context, baseURL string, key Commitment, method string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/get/0x%x", baseURL, key.Encode()), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent, http.StatusRequestedRangeNotSatisfiable:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		if method == http.MethodHead {
+			return false, errHeadUnsupported
+		}
+	}
+	return false, newStatusError(resp)
+}
+
+// checkInputSize returns ErrInputTooLarge if the input exceeds the maximum input size.
+func (c *DAClient) checkInputSize(img []byte) error {
+	if c.maxInputSize != 0 && len(img) > c.maxInputSize {
+		return fmt.Errorf("%w: %d bytes exceeds limit of %d", ErrInputTooLarge, len(img), c.maxInputSize)
+	}
+	return nil
+}
+
+// SetInput sets the input data and returns its commitment, using the configured commitment type.
+// Transient failures are retried according to the client's RetryPolicy. The input is written to the
+// primary DA server and, if mirrored writes are enabled, then copied to the replicas on a best-effort basis.
+// With a write quorum, it is written to all endpoints concurrently instead, see WithWriteQuorum.
+// The server recomputes the commitment, a disagreement is reported as ErrCommitmentMismatch.
+func (c *DAClient) SetInput(ctx context.Context, img []byte) (_ Commitment, err error) {
+	ctx, call := c.startCall(ctx, "SetInput", nil)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
 	if len(img) == 0 {
 		return nil, ErrInvalidInput
 	}
-	key := crypto.Keccak256(img)
+	if err := c.checkInputSize(img); err != nil {
+		return nil, err
+	}
+	key, err := NewCommitment(c.commType, img)
+	if err != nil {
+		return nil, err
+	}
+	call.key, call.size = key, len(img)
+	if err := c.storeInput(ctx, "SetInput", key, img); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// SetInputWithCommitment stores the input under a commitment the caller computed earlier, like SetInput.
+// The commitment type of expected is used and the input is checked against it before it is sent, as well
+// as by the server, so an inconsistency anywhere between the caller and the storage is reported as
+// ErrCommitmentMismatch instead of storing the input under the wrong key.
+func (c *DAClient) SetInputWithCommitment(ctx context.Context, img []byte, expected Commitment) (err error) {
+	ctx, call := c.startCall(ctx, "SetInputWithCommitment", expected)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	if len(img) == 0 {
+		return ErrInvalidInput
+	}
+	if err := c.checkInputSize(img); err != nil {
+		return err
+	}
+	key, err := DecodeCommitment(expected)
+	if err != nil {
+		return err
+	}
+	if err := key.Verify(img); err != nil {
+		return err
+	}
+	call.size = len(img)
+	return c.storeInput(ctx, "SetInputWithCommitment", key, img)
+}
+
+// storeInput writes the input to the primary with retries, then mirrors it to the replicas if enabled.
+// With a write quorum, it is written to all endpoints instead.
+func (c *DAClient) storeInput(ctx context.Context, method string, key Commitment, img []byte) error {
+	if c.writeQuorum > 0 {
+		if err := c.storeQuorum(ctx, method, key, img); err != nil {
+			return err
+		}
+		c.metrics.RecordDABytesSent(method, len(img))
+		return nil
+	}
+	primary := c.endpoints[0]
+	_, err := doEndpoint(ctx, c, primary, func() (Commitment, error) {
+		return key, c.setInput(ctx, primary.url, key, img)
+	})
+	c.recordResult(primary, method, err)
+	if err != nil {
+		return err
+	}
+	c.metrics.RecordDABytesSent(method, len(img))
+	if c.mirror {
+		c.mirrorInput(ctx, key, img)
+	}
+	return nil
+}
+
+// mirrorInput concurrently writes the input to all replicas, reporting the outcomes to the observer only.
+func (c *DAClient) mirrorInput(ctx context.Context, key Commitment, img []byte) {
+	c.forEachReplica(ctx, "Mirror", func(baseURL string) error {
+		return c.setInput(ctx, baseURL, key, img)
+	})
+}
+
+// forEachReplica concurrently runs op with retries against all replicas, reporting the outcomes to the
+// observer only.
+func (c *DAClient) forEachReplica(ctx context.Context, method string, op func(baseURL string) error) {
+	var wg sync.WaitGroup
+	for _, e := range c.endpoints[1:] {
+		e := e
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := doEndpoint(ctx, c, e, func() (struct{}, error) {
+				return struct{}{}, op(e.url)
+			})
+			c.recordResult(e, method, err)
+		}()
+	}
+	wg.Wait()
+}
+
+// setInput stores the input under key on the endpoint at baseURL. DA servers respond with the commitment they
+// stored the input under: a response committing to another commitment is reported as ErrCommitmentMismatch.
+func (c *DAClient) setInput(ctx context.Context, baseURL string, key Commitment, img []byte) error {
+	if c.ipfs != 0 {
+		return c.setIPFSInput(ctx, baseURL, key, img)
+	}
 	body := bytes.NewReader(img)
-	url := fmt.Sprintf("%s/put/0x%x", c.url, key)
+	url := fmt.Sprintf("%s/put/0x%x", baseURL, key.Encode())
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/octet-stream")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to store preimage: %v", resp.StatusCode)
+	switch {
+	case resp.StatusCode == http.StatusUnprocessableEntity:
+		return newMismatchError(resp)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("failed to store preimage: %w", newStatusError(resp))
 	}
-	return key, nil
+	serverComm, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageSize))
+	if err != nil {
+		return fmt.Errorf("failed to read commitment from server: %w", err)
+	}
+	if len(serverComm) > 0 && !bytes.Equal(serverComm, key.Encode()) {
+		return fmt.Errorf("%w: server committed to %x, expected %x", ErrCommitmentMismatch, serverComm, key.Encode())
+	}
+	return nil
+}
+
+// newMismatchError converts the server's 422 response to a put into an ErrCommitmentMismatch,
+// including the commitment computed by the server if the response describes it.
+func newMismatchError(resp *http.Response) error {
+	var body mismatchResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxErrorMessageSize)).Decode(&body); err != nil || body.Computed == nil {
+		return fmt.Errorf("%w: rejected by server", ErrCommitmentMismatch)
+	}
+	return fmt.Errorf("%w: server computed %s for commitment %s", ErrCommitmentMismatch, body.Computed, body.Commitment)
+}
+
+// DeleteInput removes the input for the given commitment from the primary DA server, authorized by the
+// token set with WithAuthToken. It returns ErrNotFound if the server does not have the input.
+// If mirrored writes are enabled, the input is then removed from the replicas on a best-effort basis.
+// The client never deletes inputs on its own: this must be called explicitly, e.g. once the challenge
+// window of the input closed.
+func (c *DAClient) DeleteInput(ctx context.Context, key Commitment) (err error) {
+	ctx, call := c.startCall(ctx, "DeleteInput", key)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	if err := c.checkDARoutes(); err != nil {
+		return err
+	}
+	comm, err := DecodeCommitment(key)
+	if err != nil {
+		return err
+	}
+	primary := c.endpoints[0]
+	_, err = doEndpoint(ctx, c, primary, func() (struct{}, error) {
+		return struct{}{}, c.deleteInput(ctx, primary.url, comm)
+	})
+	c.recordResult(primary, "DeleteInput", err)
+	if err != nil {
+		return err
+	}
+	if c.mirror {
+		c.forEachReplica(ctx, "MirrorDelete", func(baseURL string) error {
+			if err := c.deleteInput(ctx, baseURL, comm); !errors.Is(err, ErrNotFound) {
+				return err
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+func (c *DAClient) deleteInput(ctx context.Context, baseURL string, key Commitment) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/del/0x%x", baseURL, key.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("failed to delete input: %w", newStatusError(resp))
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package plasma
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
@@ -76,7 +77,8 @@ func TestDAClient(t *testing.T) {
 	}
 	require.NoError(t, cfg.Check())
 
-	client := cfg.NewDAClient()
+	client, err := cfg.NewDAClient()
+	require.NoError(t, err)
 
 	rng := rand.New(rand.NewSource(1234))
 
@@ -85,7 +87,9 @@ func TestDAClient(t *testing.T) {
 	comm, err := client.SetInput(ctx, input)
 	require.NoError(t, err)
 
-	require.Equal(t, comm, crypto.Keccak256(input))
+	require.Equal(t, Keccak256(input), comm)
+	require.Equal(t, Keccak256CommitmentType, comm.Type())
+	require.Equal(t, crypto.Keccak256(input), comm.Digest())
 
 	stored, err := client.GetInput(ctx, comm)
 	require.NoError(t, err)
@@ -98,8 +102,33 @@ func TestDAClient(t *testing.T) {
 	_, err = client.GetInput(ctx, comm)
 	require.ErrorIs(t, err, ErrCommitmentMismatch)
 
+	// legacy raw keccak256 keys are requested as-is and still verified
+	legacyInput := testutils.RandomData(rng, 100)
+	legacyKey := crypto.Keccak256(legacyInput)
+	require.NoError(t, store.Put(legacyKey, legacyInput))
+	stored, err = client.GetInput(ctx, legacyKey)
+	require.NoError(t, err)
+	require.Equal(t, legacyInput, stored)
+
+	require.NoError(t, store.Put(legacyKey, []byte("bad data")))
+	_, err = client.GetInput(ctx, legacyKey)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+
+	// sha256 commitments are dispatched by their version byte
+	shaClient := NewDAClient(tsrv.URL, true, WithCommitmentType(Sha256CommitmentType))
+	shaComm, err := shaClient.SetInput(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, Sha256CommitmentType, shaComm.Type())
+	stored, err = client.GetInput(ctx, shaComm)
+	require.NoError(t, err)
+	require.Equal(t, input, stored)
+
+	// unknown commitment versions are rejected rather than reported as a mismatch
+	_, err = client.GetInput(ctx, append([]byte{0x7f}, crypto.Keccak256(input)...))
+	require.ErrorIs(t, err, ErrUnsupportedCommitment)
+
 	// test not found error
-	comm = crypto.Keccak256(testutils.RandomData(rng, 32))
+	comm = Keccak256(testutils.RandomData(rng, 32))
 	_, err = client.GetInput(ctx, comm)
 	require.ErrorIs(t, err, ErrNotFound)
 
@@ -115,3 +144,151 @@ func TestDAClient(t *testing.T) {
 	_, err = client.GetInput(ctx, crypto.Keccak256(input))
 	require.Error(t, err)
 }
+
+// closeTracker records whether the response body was closed.
+type closeTracker struct {
+	io.ReadCloser
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return c.ReadCloser.Close()
+}
+
+func TestDAClientResponseHandling(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("some input")
+	comm := Keccak256(input)
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(t *testing.T, data []byte, err error)
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+			body:   string(input),
+			check: func(t *testing.T, data []byte, err error) {
+				require.NoError(t, err)
+				require.Equal(t, input, data)
+			},
+		},
+		{
+			name:   "NotFound",
+			status: http.StatusNotFound,
+			body:   "404 page not found",
+			check: func(t *testing.T, data []byte, err error) {
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			name:   "ServerErrorWithHTML",
+			status: http.StatusInternalServerError,
+			body:   "<html><body><h1>500 Internal Server Error</h1></body></html>",
+			check: func(t *testing.T, data []byte, err error) {
+				require.NotErrorIs(t, err, ErrCommitmentMismatch)
+				var se *ServerError
+				require.ErrorAs(t, err, &se)
+				require.Equal(t, http.StatusInternalServerError, se.StatusCode)
+				require.Contains(t, se.Message, "500 Internal Server Error")
+				require.True(t, isRetryable(err))
+			},
+		},
+		{
+			name:   "RequestError",
+			status: http.StatusForbidden,
+			body:   "access denied\n",
+			check: func(t *testing.T, data []byte, err error) {
+				var re *RequestError
+				require.ErrorAs(t, err, &re)
+				require.Equal(t, http.StatusForbidden, re.StatusCode)
+				require.Equal(t, "access denied", re.Message)
+				require.False(t, isRetryable(err))
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			t.Cleanup(srv.Close)
+			var bodies []*closeTracker
+			httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				resp, err := http.DefaultTransport.RoundTrip(r)
+				if err != nil {
+					return nil, err
+				}
+				body := &closeTracker{ReadCloser: resp.Body}
+				bodies = append(bodies, body)
+				resp.Body = body
+				return resp, nil
+			})}
+			client := NewDAClient(srv.URL, true, WithHTTPClient(httpClient), WithRetryPolicy(NoRetryPolicy))
+
+			data, err := client.GetInput(ctx, comm)
+			test.check(t, data, err)
+			require.Len(t, bodies, 1)
+			require.True(t, bodies[0].closed, "response body not closed")
+		})
+	}
+}
+
+func TestDAClientExists(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("already stored")
+
+	t.Run("DAServer", func(t *testing.T) {
+		_, url := startDAServer(t, newMemStore())
+		client := NewDAClient(url, true, WithRetryPolicy(NoRetryPolicy))
+		comm, err := client.SetInput(ctx, input)
+		require.NoError(t, err)
+
+		exists, err := client.Exists(ctx, comm)
+		require.NoError(t, err)
+		require.True(t, exists)
+		exists, err = client.Exists(ctx, Keccak256([]byte("unknown")))
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		resp, err := http.Head(fmt.Sprintf("%s/get/0x%x", url, comm.Encode()))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.EqualValues(t, len(input), resp.ContentLength)
+	})
+
+	t.Run("HeadUnsupported", func(t *testing.T) {
+		comm := Keccak256(input)
+		var methods []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			switch {
+			case r.Method == http.MethodHead:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			case r.URL.Path != fmt.Sprintf("/get/0x%x", comm.Encode()):
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				require.Equal(t, "bytes=0-0", r.Header.Get("Range"))
+				w.WriteHeader(http.StatusPartialContent)
+				_, _ = w.Write(input[:1])
+			}
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy))
+
+		exists, err := client.Exists(ctx, comm)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, []string{http.MethodHead, http.MethodGet}, methods)
+
+		exists, err = client.Exists(ctx, Keccak256([]byte("unknown")))
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
@@ -9,8 +9,8 @@ import (
 )
 
 type DAStorage interface {
-	GetInput(ctx context.Context, key []byte) ([]byte, error)
-	SetInput(ctx context.Context, img []byte) ([]byte, error)
+	GetInput(ctx context.Context, key Commitment) ([]byte, error)
+	SetInput(ctx context.Context, img []byte) (Commitment, error)
 }
 
 type DA struct {
@@ -23,11 +23,15 @@ type Input struct {
 }
 
 // NewPlasmaDA creates a new PlasmaDA instance with the given log and CLIConfig.
-func NewPlasmaDA(log log.Logger, cfg CLIConfig) *DA {
+func NewPlasmaDA(log log.Logger, cfg CLIConfig) (*DA, error) {
+	storage, err := cfg.NewDAClient()
+	if err != nil {
+		return nil, err
+	}
 	return &DA{
 		log:     log,
-		storage: cfg.NewDAClient(),
-	}
+		storage: storage,
+	}, nil
 }
 
 // NewPlasmaDAWithStorage creates a new PlasmaDA instance with the given log and DAStorage interface.
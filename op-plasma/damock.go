@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/log"
@@ -24,21 +23,29 @@ func NewMockDAClient(log log.Logger) *MockDAClient {
 	}
 }
 
-func (c *MockDAClient) GetInput(ctx context.Context, key []byte) ([]byte, error) {
-	bytes, err := c.store.Get(key)
+func (c *MockDAClient) GetInput(ctx context.Context, key Commitment) ([]byte, error) {
+	comm, err := DecodeCommitment(key)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := c.store.Get(comm.Encode())
 	if err != nil {
 		return nil, ErrNotFound
 	}
 	return bytes, nil
 }
 
-func (c *MockDAClient) SetInput(ctx context.Context, data []byte) ([]byte, error) {
-	key := crypto.Keccak256(data)
-	return key, c.store.Put(key, data)
+func (c *MockDAClient) SetInput(ctx context.Context, data []byte) (Commitment, error) {
+	key := Keccak256(data)
+	return key, c.store.Put(key.Encode(), data)
 }
 
 func (c *MockDAClient) DeleteData(key []byte) error {
-	return c.store.Delete(key)
+	comm, err := DecodeCommitment(key)
+	if err != nil {
+		return err
+	}
+	return c.store.Delete(comm.Encode())
 }
 
 type DAErrFaker struct {
@@ -48,7 +55,7 @@ type DAErrFaker struct {
 	setInputErr error
 }
 
-func (f *DAErrFaker) GetInput(ctx context.Context, key []byte) ([]byte, error) {
+func (f *DAErrFaker) GetInput(ctx context.Context, key Commitment) ([]byte, error) {
 	if err := f.getInputErr; err != nil {
 		f.getInputErr = nil
 		return nil, err
@@ -56,7 +63,7 @@ func (f *DAErrFaker) GetInput(ctx context.Context, key []byte) ([]byte, error) {
 	return f.Client.GetInput(ctx, key)
 }
 
-func (f *DAErrFaker) SetPreImage(ctx context.Context, data []byte) ([]byte, error) {
+func (f *DAErrFaker) SetPreImage(ctx context.Context, data []byte) (Commitment, error) {
 	if err := f.setInputErr; err != nil {
 		f.setInputErr = nil
 		return nil, err
@@ -0,0 +1,556 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+)
+
+// DefaultMaxRequestSize is the largest request body accepted by the DAServer unless configured otherwise.
+const DefaultMaxRequestSize = 32 << 20
+
+// DefaultMaxChunkedInputSize is the largest input assembled from chunks by the DAServer unless configured otherwise.
+const DefaultMaxChunkedInputSize = 1 << 30
+
+// DAServer is an HTTP DA storage service serving the routes used by DAClient:
+//
+//	GET  /get/0x<commitment>  returns the input, 404 if unknown. HEAD only returns its Content-Length.
+//	POST /put/0x<commitment>  stores the body, 422 with a mismatchResponse if it does not match the commitment.
+//	POST /put                 stores the body and returns its keccak256 commitment, 413 if it exceeds the max request size.
+//	POST /get_batch           batched /get, see batchFrame for the wire format.
+//	POST /put_batch           batched /put/0x<commitment>.
+//	DELETE /del/0x<commitment>  removes the input, 404 if unknown. Requires the delete token.
+//	GET  /status/0x<commitment>  returns the JSON challenge window Status of the commitment, 404 if unknown.
+//	PUT  /status/0x<commitment>  stores the JSON Status of the commitment. Requires the status token.
+//
+// Large inputs can be uploaded in chunks, see DAClient.SetInputChunked:
+//
+//	POST /missing_chunks              batched chunk lookup, by keccak256 hash of the chunk.
+//	POST /put_chunk/0x<hash>          stores a chunk, 422 if it does not match the keccak256 hash.
+//	POST /put_chunked/0x<commitment>  assembles the chunks listed in the manifest body and stores the input,
+//	                                  409 if chunks are missing, 413 if the input exceeds the max chunked input
+//	                                  size and 422 if it does not match the commitment.
+//
+// Chunks are content-addressed and may be shared by concurrent uploads, so they are kept once an input is stored.
+// Assembled inputs are verified and stored one chunk at a time when the storage is a StreamStorage, and are only
+// held in memory for other storages.
+//
+// Successful puts respond with the encoded commitment. Storage failures are reported with 404 if the
+// input is unknown, 503 if the storage is unavailable and 500 otherwise.
+type DAServer struct {
+	log            log.Logger
+	addr           string
+	store          Storage
+	maxRequestSize int64
+	// maxChunkedInputSize bounds the inputs assembled from chunks, which are not bound by maxRequestSize.
+	maxChunkedInputSize int64
+	// deleteToken is the bearer token authorizing deletes. Deletes are disabled when empty.
+	deleteToken string
+	// statuses holds the commitment statuses served on the /status/ route.
+	statuses StatusStore
+	// statusToken is the bearer token authorizing status updates. Updates are disabled when empty.
+	statusToken string
+
+	httpServer *httputil.HTTPServer
+	stopped    atomic.Bool
+}
+
+// mismatchResponse is the JSON body of the 422 response to a put of an input that does not match the commitment.
+type mismatchResponse struct {
+	Error      string        `json:"error"`
+	Commitment hexutil.Bytes `json:"commitment"`
+	Computed   hexutil.Bytes `json:"computed"`
+}
+
+// DAServerOption configures optional DAServer behavior.
+type DAServerOption func(s *DAServer)
+
+// WithMaxRequestSize sets the largest request body accepted by the server. Defaults to DefaultMaxRequestSize.
+func WithMaxRequestSize(size int64) DAServerOption {
+	return func(s *DAServer) {
+		s.maxRequestSize = size
+	}
+}
+
+// WithMaxChunkedInputSize sets the largest input assembled from uploaded chunks. Defaults to DefaultMaxChunkedInputSize.
+func WithMaxChunkedInputSize(size int64) DAServerOption {
+	return func(s *DAServer) {
+		s.maxChunkedInputSize = size
+	}
+}
+
+// WithDeleteToken enables the /del/ route, authorizing requests that carry the token as a bearer token.
+func WithDeleteToken(token string) DAServerOption {
+	return func(s *DAServer) {
+		s.deleteToken = token
+	}
+}
+
+// NewDAServer creates a DAServer listening on addr, backed by store.
+func NewDAServer(addr string, store Storage, log log.Logger, opts ...DAServerOption) *DAServer {
+	s := &DAServer{
+		log:                 log,
+		addr:                addr,
+		store:               store,
+		maxRequestSize:      DefaultMaxRequestSize,
+		maxChunkedInputSize: DefaultMaxChunkedInputSize,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.statuses == nil {
+		s.statuses = NewStorageStatusStore(store)
+	}
+	return s
+}
+
+// Handler returns the HTTP handler serving the DA storage routes.
+func (s *DAServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get/", s.handleGet)
+	mux.HandleFunc("/put/", s.handlePut)
+	mux.HandleFunc("/put", s.handlePut)
+	mux.HandleFunc("/get_batch", s.handleGetBatch)
+	mux.HandleFunc("/put_batch", s.handlePutBatch)
+	mux.HandleFunc("/del/", s.handleDelete)
+	mux.HandleFunc("/missing_chunks", s.handleMissingChunks)
+	mux.HandleFunc("/put_chunk/", s.handlePutChunk)
+	mux.HandleFunc("/put_chunked/", s.handlePutChunked)
+	mux.HandleFunc("/status/", s.handleStatus)
+	return mux
+}
+
+// Start starts serving requests on the configured address.
+func (s *DAServer) Start(ctx context.Context) error {
+	srv, err := httputil.StartHTTPServer(s.addr, s.Handler())
+	if err != nil {
+		return fmt.Errorf("failed to start DA server: %w", err)
+	}
+	s.httpServer = srv
+	s.log.Info("Started DA server", "addr", srv.Addr())
+	return nil
+}
+
+// Stop gracefully shuts down the server, force closing remaining connections if ctx is done.
+func (s *DAServer) Stop(ctx context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+	if err := s.httpServer.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop DA server: %w", err)
+	}
+	s.stopped.Store(true)
+	s.log.Info("Stopped DA server")
+	return nil
+}
+
+func (s *DAServer) Stopped() bool {
+	return s.stopped.Load()
+}
+
+// Addr returns the address the server is listening on, or nil if it is not started.
+func (s *DAServer) Addr() net.Addr {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Addr()
+}
+
+// commitmentFromPath decodes the hex encoded commitment following the route prefix of the request path.
+func commitmentFromPath(path string, prefix string) (Commitment, error) {
+	data, err := hexutil.Decode(path[len(prefix):])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCommitment, err)
+	}
+	return DecodeCommitment(data)
+}
+
+func (s *DAServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	comm, err := commitmentFromPath(r.URL.Path, "/get/")
+	if err != nil {
+		s.log.Debug("Invalid commitment", "path", r.URL.Path, "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input, err := s.store.Get(r.Context(), comm)
+	if err != nil {
+		s.writeStorageError(w, "Failed to read input", comm, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(input)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := w.Write(input); err != nil {
+		s.log.Debug("Failed to write response", "err", err)
+	}
+}
+
+func (s *DAServer) handlePut(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	input, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+	if len(input) == 0 {
+		http.Error(w, ErrInvalidInput.Error(), http.StatusBadRequest)
+		return
+	}
+	var comm Commitment
+	if r.URL.Path == "/put" || r.URL.Path == "/put/" {
+		comm = Keccak256(input)
+	} else {
+		var err error
+		comm, err = commitmentFromPath(r.URL.Path, "/put/")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if status, err := s.storeInput(r.Context(), comm, input); errors.Is(err, ErrCommitmentMismatch) {
+		s.writeMismatch(w, comm, input)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if _, err := w.Write(comm.Encode()); err != nil {
+		s.log.Debug("Failed to write response", "err", err)
+	}
+}
+
+func (s *DAServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !authorized(w, r, s.deleteToken, "deletes") {
+		return
+	}
+	comm, err := commitmentFromPath(r.URL.Path, "/del/")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.store.Delete(r.Context(), comm); err != nil {
+		s.writeStorageError(w, "Failed to delete input", comm, err)
+		return
+	}
+	s.log.Info("Deleted input", "commitment", comm)
+}
+
+// authorized checks the bearer token of the request against token, writing the error response and
+// returning false if the request is not authorized.
+func authorized(w http.ResponseWriter, r *http.Request, token string, action string) bool {
+	if token == "" {
+		http.Error(w, action+" are disabled", http.StatusForbidden)
+		return false
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// writeStorageError responds to a failed storage operation with the status matching the error.
+func (s *DAServer) writeStorageError(w http.ResponseWriter, msg string, key Commitment, err error) {
+	status := storageStatus(err)
+	if status != http.StatusNotFound {
+		s.log.Error(msg, "commitment", key, "err", err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// writeMismatch responds with the commitment computed for the input, for the client to report the mismatch.
+func (s *DAServer) writeMismatch(w http.ResponseWriter, comm Commitment, input []byte) {
+	computed, err := NewCommitment(comm.Type(), input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.writeComputedMismatch(w, comm, computed)
+}
+
+func (s *DAServer) writeComputedMismatch(w http.ResponseWriter, comm Commitment, computed Commitment) {
+	s.log.Warn("Rejected input not matching its commitment", "commitment", comm, "computed", computed)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	if err := json.NewEncoder(w).Encode(mismatchResponse{
+		Error:      ErrCommitmentMismatch.Error(),
+		Commitment: comm.Encode(),
+		Computed:   computed.Encode(),
+	}); err != nil {
+		s.log.Debug("Failed to write response", "err", err)
+	}
+}
+
+// storeInput verifies the input against the commitment and stores it.
+// On failure it returns the HTTP status code describing the error.
+func (s *DAServer) storeInput(ctx context.Context, comm Commitment, input []byte) (int, error) {
+	if err := comm.Verify(input); errors.Is(err, ErrCommitmentMismatch) {
+		return http.StatusUnprocessableEntity, err
+	} else if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := s.store.Put(ctx, comm, input); err != nil {
+		s.log.Error("Failed to store input", "commitment", comm, "err", err)
+		return storageStatus(err), errors.New("failed to store input")
+	}
+	return http.StatusOK, nil
+}
+
+// readBody reads the request body within the request size limit. It writes the error response and
+// returns false if the body could not be read.
+func (s *DAServer) readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxRequestSize))
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		http.Error(w, fmt.Sprintf("request body exceeds %d bytes", maxErr.Limit), http.StatusRequestEntityTooLarge)
+		return nil, false
+	} else if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return nil, false
+	}
+	return body, true
+}
+
+func (s *DAServer) handleGetBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+	frames, err := readBatchFrames(bytes.NewReader(body), -1, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := make([]batchFrame, len(frames))
+	for i, f := range frames {
+		comm, err := DecodeCommitment(f.payload)
+		if err != nil {
+			resp[i] = batchFrame{status: batchStatusError, payload: []byte(err.Error())}
+			continue
+		}
+		input, err := s.store.Get(r.Context(), comm)
+		if errors.Is(err, ErrNotFound) {
+			resp[i] = batchFrame{status: batchStatusNotFound}
+		} else if err != nil {
+			s.log.Error("Failed to read input", "commitment", comm, "err", err)
+			resp[i] = batchFrame{status: batchStatusError, payload: []byte("failed to read input")}
+		} else {
+			resp[i] = batchFrame{payload: input}
+		}
+	}
+	s.writeBatch(w, resp)
+}
+
+func (s *DAServer) handlePutBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+	frames, err := readBatchFrames(bytes.NewReader(body), -1, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(frames)%2 != 0 {
+		http.Error(w, "expected commitment and input frame pairs", http.StatusBadRequest)
+		return
+	}
+	resp := make([]batchFrame, len(frames)/2)
+	for i := range resp {
+		comm, err := DecodeCommitment(frames[2*i].payload)
+		if err != nil {
+			resp[i] = batchFrame{status: batchStatusError, payload: []byte(err.Error())}
+			continue
+		}
+		if _, err := s.storeInput(r.Context(), comm, frames[2*i+1].payload); err != nil {
+			resp[i] = batchFrame{status: batchStatusError, payload: []byte(err.Error())}
+		}
+	}
+	s.writeBatch(w, resp)
+}
+
+func (s *DAServer) writeBatch(w http.ResponseWriter, frames []batchFrame) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := writeBatchFrames(w, frames); err != nil {
+		s.log.Debug("Failed to write batch response", "err", err)
+	}
+}
+
+func (s *DAServer) handleMissingChunks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+	frames, err := readBatchFrames(bytes.NewReader(body), -1, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := make([]batchFrame, len(frames))
+	for i, f := range frames {
+		ok, err := s.store.Has(r.Context(), chunkKey(f.payload))
+		if err != nil {
+			s.log.Error("Failed to look up chunk", "hash", hexutil.Bytes(f.payload), "err", err)
+			resp[i] = batchFrame{status: batchStatusError, payload: []byte("failed to look up chunk")}
+		} else if !ok {
+			resp[i] = batchFrame{status: batchStatusNotFound}
+		}
+	}
+	s.writeBatch(w, resp)
+}
+
+func (s *DAServer) handlePutChunk(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	chunk, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+	comm, err := commitmentFromPath(r.URL.Path, "/put_chunk/")
+	if err != nil || comm.Type() != Keccak256CommitmentType {
+		http.Error(w, "invalid chunk hash", http.StatusBadRequest)
+		return
+	}
+	if err := comm.Verify(chunk); err != nil {
+		s.writeMismatch(w, comm, chunk)
+		return
+	}
+	if err := s.store.Put(r.Context(), chunkKey(comm.Digest()), chunk); err != nil {
+		s.writeStorageError(w, "Failed to store chunk", comm, err)
+	}
+}
+
+func (s *DAServer) handlePutChunked(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, ok := s.readBody(w, r)
+	if !ok {
+		return
+	}
+	comm, err := commitmentFromPath(r.URL.Path, "/put_chunked/")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	manifest, err := readBatchFrames(bytes.NewReader(body), -1, 0)
+	if err != nil || len(manifest) == 0 {
+		http.Error(w, "invalid chunk manifest", http.StatusBadRequest)
+		return
+	}
+	// the chunks are read one at a time twice: once to verify the commitment, once to store the input
+	h, err := comm.Type().hasher()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var size int64
+	for i, f := range manifest {
+		chunk, err := s.store.Get(r.Context(), chunkKey(f.payload))
+		if errors.Is(err, ErrNotFound) {
+			http.Error(w, fmt.Sprintf("missing chunk %d", i), http.StatusConflict)
+			return
+		} else if err != nil {
+			s.writeStorageError(w, "Failed to read chunk", chunkKey(f.payload), err)
+			return
+		}
+		if size += int64(len(chunk)); size > s.maxChunkedInputSize {
+			http.Error(w, fmt.Sprintf("chunked input exceeds %d bytes", s.maxChunkedInputSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.Write(chunk)
+	}
+	if digest := h.Sum(nil); !bytes.Equal(digest, comm.Digest()) {
+		s.writeComputedMismatch(w, comm, append(Commitment{byte(comm.Type())}, digest...))
+		return
+	}
+	if err := s.putChunked(r.Context(), comm, manifest, size); err != nil {
+		s.log.Error("Failed to store input", "commitment", comm, "err", err)
+		http.Error(w, "failed to store input", storageStatus(err))
+		return
+	}
+	s.log.Info("Stored chunked input", "commitment", comm, "chunks", len(manifest), "size", size)
+	if _, err := w.Write(comm.Encode()); err != nil {
+		s.log.Debug("Failed to write response", "err", err)
+	}
+}
+
+// putChunked stores the verified input of the given size assembled from the chunks of the manifest.
+func (s *DAServer) putChunked(ctx context.Context, comm Commitment, manifest []batchFrame, size int64) error {
+	r := &chunkReader{ctx: ctx, store: s.store, manifest: manifest}
+	if stream, ok := s.store.(StreamStorage); ok {
+		return stream.PutStream(ctx, comm, r, size)
+	}
+	input := make([]byte, size)
+	if _, err := io.ReadFull(r, input); err != nil {
+		return err
+	}
+	return s.store.Put(ctx, comm, input)
+}
+
+// chunkReader reads the input assembled from the chunks of a manifest, fetching one chunk at a time.
+type chunkReader struct {
+	ctx      context.Context
+	store    Storage
+	manifest []batchFrame
+	chunk    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if len(r.manifest) == 0 {
+			return 0, io.EOF
+		}
+		chunk, err := r.store.Get(r.ctx, chunkKey(r.manifest[0].payload))
+		if err != nil {
+			return 0, fmt.Errorf("failed to read chunk: %w", err)
+		}
+		r.chunk, r.manifest = chunk, r.manifest[1:]
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
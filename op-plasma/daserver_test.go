@@ -0,0 +1,307 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// memStore is an in-memory Storage.
+type memStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: make(map[string][]byte)}
+}
+
+func (m *memStore) Get(ctx context.Context, key Commitment) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.data[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+func (m *memStore) Put(ctx context.Context, key Commitment, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[string(key)] = value
+	return nil
+}
+
+func (m *memStore) Has(ctx context.Context, key Commitment) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.data[string(key)]
+	return ok, nil
+}
+
+func (m *memStore) Delete(ctx context.Context, key Commitment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.data[string(key)]; !ok {
+		return ErrNotFound
+	}
+	delete(m.data, string(key))
+	return nil
+}
+
+func startDAServer(t *testing.T, store Storage, opts ...DAServerOption) (*DAServer, string) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	srv := NewDAServer("127.0.0.1:0", store, logger, opts...)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = srv.Stop(context.Background())
+	})
+	return srv, fmt.Sprintf("http://%s", srv.Addr())
+}
+
+func TestDAServer(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	_, url := startDAServer(t, store, WithMaxRequestSize(1024))
+	client := NewDAClient(url, true, WithRetryPolicy(NoRetryPolicy))
+	input := []byte("an input stored on the DA server")
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		comm, err := client.SetInput(ctx, input)
+		require.NoError(t, err)
+		require.Equal(t, Keccak256(input), comm)
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+
+		// legacy raw keccak256 keys resolve to the same entry
+		data, err = client.GetInput(ctx, crypto.Keccak256(input))
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+
+	t.Run("Sha256", func(t *testing.T) {
+		shaClient := NewDAClient(url, true, WithCommitmentType(Sha256CommitmentType))
+		comm, err := shaClient.SetInput(ctx, input)
+		require.NoError(t, err)
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := client.GetInput(ctx, Keccak256([]byte("unknown")))
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("CommitmentMismatch", func(t *testing.T) {
+		comm := Keccak256([]byte("something else"))
+		resp, err := http.Post(fmt.Sprintf("%s/put/%s", url, hexutil.Encode(comm)), "application/octet-stream", bytes.NewReader(input))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		var body mismatchResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, ErrCommitmentMismatch.Error(), body.Error)
+		require.Equal(t, comm.Encode(), []byte(body.Commitment))
+		require.Equal(t, Keccak256(input).Encode(), []byte(body.Computed))
+		_, err = client.GetInput(ctx, comm)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("InvalidCommitment", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/get/0xzz", url))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("RequestTooLarge", func(t *testing.T) {
+		_, err := client.SetInput(ctx, make([]byte, 1025))
+		require.ErrorContains(t, err, "413")
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		comm, err := client.SetInputStream(ctx, bytes.NewReader(input), int64(len(input)))
+		require.NoError(t, err)
+		require.Equal(t, Keccak256(input), comm)
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		inputs := [][]byte{[]byte("first"), []byte("second")}
+		comms, err := client.SetInputs(ctx, inputs)
+		require.NoError(t, err)
+		results, err := client.GetInputs(ctx, []Commitment{comms[1], Keccak256([]byte("missing")), comms[0]})
+		require.NoError(t, err)
+		require.Equal(t, inputs[1], results[0].Input)
+		require.ErrorIs(t, results[1].Err, ErrNotFound)
+		require.Equal(t, inputs[0], results[2].Input)
+	})
+}
+
+func TestDAServerStop(t *testing.T) {
+	srv, url := startDAServer(t, newMemStore())
+	require.False(t, srv.Stopped())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, srv.Stop(ctx))
+	require.True(t, srv.Stopped())
+
+	client := NewDAClient(url, true, WithRetryPolicy(NoRetryPolicy))
+	_, err := client.GetInput(context.Background(), Keccak256([]byte("input")))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestDAServerDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	_, url := startDAServer(t, store, WithDeleteToken("secret"))
+	client := NewDAClient(url, true, WithAuthToken("secret"), WithRetryPolicy(NoRetryPolicy))
+
+	input := []byte("expired input")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		unauthorized := NewDAClient(url, true, WithAuthToken("wrong"), WithRetryPolicy(NoRetryPolicy))
+		var re *RequestError
+		require.ErrorAs(t, unauthorized.DeleteInput(ctx, comm), &re)
+		require.Equal(t, http.StatusUnauthorized, re.StatusCode)
+		_, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteThenGet", func(t *testing.T) {
+		require.NoError(t, client.DeleteInput(ctx, comm))
+		_, err := client.GetInput(ctx, comm)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("NonexistentKey", func(t *testing.T) {
+		require.ErrorIs(t, client.DeleteInput(ctx, Keccak256([]byte("unknown"))), ErrNotFound)
+	})
+
+	t.Run("WrongMethod", func(t *testing.T) {
+		resp, err := http.Post(fmt.Sprintf("%s/del/0x%x", url, comm.Encode()), "application/octet-stream", nil)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, url := startDAServer(t, newMemStore())
+		client := NewDAClient(url, true, WithAuthToken("secret"), WithRetryPolicy(NoRetryPolicy))
+		comm, err := client.SetInput(ctx, input)
+		require.NoError(t, err)
+		var re *RequestError
+		require.ErrorAs(t, client.DeleteInput(ctx, comm), &re)
+		require.Equal(t, http.StatusForbidden, re.StatusCode)
+	})
+}
+
+func TestSetInputCommitmentMismatch(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("input to cross-check")
+	_, url := startDAServer(t, newMemStore())
+
+	t.Run("ServerRejectsCorruptedInput", func(t *testing.T) {
+		// corrupt the input in transit, after the client computed the commitment
+		corrupting := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
+			}
+			body[0] ^= 0xff
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			return http.DefaultTransport.RoundTrip(r)
+		})}
+		client := NewDAClient(url, true, WithHTTPClient(corrupting), WithRetryPolicy(testRetryPolicy))
+		_, err := client.SetInput(ctx, input)
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+		require.ErrorContains(t, err, "server computed")
+
+		err = client.SetInputWithCommitment(ctx, input, Keccak256(input))
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+	})
+
+	t.Run("SetInputWithCommitment", func(t *testing.T) {
+		client := NewDAClient(url, true, WithRetryPolicy(NoRetryPolicy))
+		comm, err := NewCommitment(Sha256CommitmentType, input)
+		require.NoError(t, err)
+		require.NoError(t, client.SetInputWithCommitment(ctx, input, comm))
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+
+		wrong := Keccak256([]byte("computed for other data"))
+		require.ErrorIs(t, client.SetInputWithCommitment(ctx, input, wrong), ErrCommitmentMismatch)
+		exists, err := client.Exists(ctx, wrong)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+// failingStore is a Storage failing every operation with err.
+type failingStore struct {
+	err error
+}
+
+func (f failingStore) Get(ctx context.Context, key Commitment) ([]byte, error)     { return nil, f.err }
+func (f failingStore) Put(ctx context.Context, key Commitment, value []byte) error { return f.err }
+func (f failingStore) Has(ctx context.Context, key Commitment) (bool, error)       { return false, f.err }
+func (f failingStore) Delete(ctx context.Context, key Commitment) error            { return f.err }
+
+func TestDAServerStorageErrors(t *testing.T) {
+	input := []byte("an input")
+	comm := Keccak256(input)
+	for _, test := range []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "NotFound", err: fmt.Errorf("no such object: %w", ErrNotFound), status: http.StatusNotFound},
+		{name: "Unavailable", err: fmt.Errorf("throttled: %w", ErrStorageUnavailable), status: http.StatusServiceUnavailable},
+		{name: "Timeout", err: context.DeadlineExceeded, status: http.StatusServiceUnavailable},
+		{name: "Other", err: errors.New("disk corrupted"), status: http.StatusInternalServerError},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, url := startDAServer(t, failingStore{err: test.err}, WithDeleteToken("secret"))
+			do := func(method string, path string, body []byte) int {
+				req, err := http.NewRequest(method, url+path, bytes.NewReader(body))
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer secret")
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+				return resp.StatusCode
+			}
+			hexComm := hexutil.Encode(comm.Encode())
+			require.Equal(t, test.status, do(http.MethodGet, "/get/"+hexComm, nil))
+			require.Equal(t, test.status, do(http.MethodHead, "/get/"+hexComm, nil))
+			require.Equal(t, test.status, do(http.MethodDelete, "/del/"+hexComm, nil))
+			if test.status != http.StatusNotFound {
+				// a put never reports not found, the status of other failures is the same
+				require.Equal(t, test.status, do(http.MethodPost, "/put/"+hexComm, input))
+				require.Equal(t, test.status, do(http.MethodPost, "/put_chunk/"+hexComm, input))
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package plasma
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEndpointFailureThreshold is the number of consecutive transient failures after which
+	// an endpoint is considered unhealthy.
+	DefaultEndpointFailureThreshold = 3
+	// DefaultEndpointCooldown is how long an unhealthy endpoint is skipped before it is tried again.
+	DefaultEndpointCooldown = 30 * time.Second
+)
+
+// EndpointResult describes the outcome of a request to one of the client's DA server endpoints.
+type EndpointResult struct {
+	// URL is the base URL of the endpoint.
+	URL string
+	// Method is the client method the request was made for, e.g. "GetInput".
+	Method string
+	// Err is the error returned by the endpoint, nil if it served the request.
+	Err error
+	// Healthy is the health of the endpoint after recording the outcome.
+	Healthy bool
+}
+
+// EndpointObserver is notified about the outcome of every request to a DA server endpoint.
+type EndpointObserver func(res EndpointResult)
+
+// endpoint is a DA server base URL with its health state. Consecutive transient failures mark the
+// endpoint unhealthy for a cooldown period during which failover skips it.
+type endpoint struct {
+	url string
+
+	mu             sync.Mutex
+	failures       int
+	unhealthyUntil time.Time
+
+	breaker breaker
+}
+
+func (e *endpoint) healthy(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !now.Before(e.unhealthyUntil)
+}
+
+// record updates the health of the endpoint with the outcome of a request and returns the resulting health.
+// Only transient errors count as failures, any other outcome shows the endpoint is reachable, except for
+// requests the circuit breaker failed fast, which leave the health unchanged.
+func (e *endpoint) record(err error, now time.Time, threshold int, cooldown time.Duration) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if errors.Is(err, ErrServiceUnavailable) {
+		return !now.Before(e.unhealthyUntil)
+	}
+	if err == nil || !isRetryable(err) {
+		e.failures = 0
+		return !now.Before(e.unhealthyUntil)
+	}
+	e.failures++
+	if e.failures >= threshold {
+		e.unhealthyUntil = now.Add(cooldown)
+		e.failures = 0
+	}
+	return !now.Before(e.unhealthyUntil)
+}
+
+// orderedEndpoints returns the healthy endpoints in configured order, followed by the unhealthy ones
+// so a request is still attempted when every endpoint is unhealthy. Endpoints with an open circuit
+// breaker are unhealthy.
+func (c *DAClient) orderedEndpoints() []*endpoint {
+	now := c.clock.Now()
+	healthy := make([]*endpoint, 0, len(c.endpoints))
+	var unhealthy []*endpoint
+	for _, e := range c.endpoints {
+		if e.healthy(now) && !e.breaker.open(now) {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// recordResult records the outcome of a request to the endpoint and notifies the observer.
+func (c *DAClient) recordResult(e *endpoint, method string, err error) {
+	healthy := e.record(err, c.clock.Now(), c.failureThreshold, c.cooldown)
+	if c.observer != nil {
+		c.observer(EndpointResult{URL: e.url, Method: method, Err: err, Healthy: healthy})
+	}
+}
@@ -0,0 +1,179 @@
+package plasma
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// failoverServer serves a single input with a configurable status and records requests.
+type failoverServer struct {
+	*httptest.Server
+	status   atomic.Int32
+	requests atomic.Int32
+	puts     atomic.Int32
+	input    []byte
+}
+
+func newFailoverServer(t *testing.T, status int, input []byte) *failoverServer {
+	s := &failoverServer{input: input}
+	s.status.Store(int32(status))
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		if r.Method == http.MethodPost {
+			s.puts.Add(1)
+		}
+		status := int(s.status.Load())
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(s.input)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+type observed struct {
+	mu      sync.Mutex
+	results []EndpointResult
+}
+
+func (o *observed) observe(res EndpointResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.results = append(o.results, res)
+}
+
+func (o *observed) last() EndpointResult {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.results[len(o.results)-1]
+}
+
+func TestDAClientFailover(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("replicated input")
+	comm := Keccak256(input)
+
+	t.Run("FallbackOnServerError", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusInternalServerError, input)
+		replica := newFailoverServer(t, http.StatusOK, input)
+		var obs observed
+		client := NewDAClient(primary.URL, true, WithRetryPolicy(NoRetryPolicy),
+			WithReplicaURLs(replica.URL), WithEndpointObserver(obs.observe))
+
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+		require.Equal(t, replica.URL, obs.last().URL)
+		require.NoError(t, obs.last().Err)
+	})
+
+	t.Run("FallbackOnNotFound", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusNotFound, input)
+		replica := newFailoverServer(t, http.StatusOK, input)
+		client := NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL))
+
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+		require.EqualValues(t, 1, primary.requests.Load())
+	})
+
+	t.Run("FallbackOnMismatch", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusOK, []byte("corrupted"))
+		replica := newFailoverServer(t, http.StatusOK, input)
+		client := NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL))
+
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+
+	t.Run("NotFoundEverywhere", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusNotFound, input)
+		replica := newFailoverServer(t, http.StatusNotFound, input)
+		client := NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL))
+
+		_, err := client.GetInput(ctx, comm)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("SkipUnhealthy", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusBadGateway, input)
+		replica := newFailoverServer(t, http.StatusOK, input)
+		clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+		var obs observed
+		client := NewDAClient(primary.URL, true, WithRetryPolicy(NoRetryPolicy), WithReplicaURLs(replica.URL),
+			WithEndpointHealth(2, time.Minute), WithClock(clk), WithEndpointObserver(obs.observe))
+
+		for i := 0; i < 2; i++ {
+			_, err := client.GetInput(ctx, comm)
+			require.NoError(t, err)
+		}
+		require.EqualValues(t, 2, primary.requests.Load())
+		require.False(t, obs.results[2].Healthy, "primary marked unhealthy after second failure")
+
+		// unhealthy primary is skipped while in cooldown
+		_, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, primary.requests.Load())
+
+		// and tried again once the cooldown passed
+		primary.status.Store(http.StatusOK)
+		clk.AdvanceTime(time.Minute)
+		_, err = client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, primary.requests.Load())
+		require.Equal(t, primary.URL, obs.last().URL)
+		require.True(t, obs.last().Healthy)
+	})
+
+	t.Run("WritePrimaryOnly", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusOK, input)
+		replica := newFailoverServer(t, http.StatusOK, input)
+		client := NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL))
+
+		_, err := client.SetInput(ctx, input)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, primary.puts.Load())
+		require.EqualValues(t, 0, replica.puts.Load())
+	})
+
+	t.Run("MirrorWrites", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusOK, input)
+		replica := newFailoverServer(t, http.StatusOK, input)
+		broken := newFailoverServer(t, http.StatusBadRequest, input)
+		var obs observed
+		client := NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL, broken.URL),
+			WithMirroredWrites(true), WithEndpointObserver(obs.observe))
+
+		got, err := client.SetInput(ctx, input)
+		require.NoError(t, err, "mirror failures are best-effort")
+		require.Equal(t, comm, got)
+		require.EqualValues(t, 1, primary.puts.Load())
+		require.EqualValues(t, 1, replica.puts.Load())
+		require.EqualValues(t, 1, broken.puts.Load())
+		require.Len(t, obs.results, 3)
+	})
+
+	t.Run("PrimaryWriteFailure", func(t *testing.T) {
+		primary := newFailoverServer(t, http.StatusBadRequest, input)
+		replica := newFailoverServer(t, http.StatusOK, input)
+		client := NewDAClient(primary.URL, true, WithReplicaURLs(replica.URL), WithMirroredWrites(true))
+
+		_, err := client.SetInput(ctx, input)
+		require.Error(t, err)
+		require.EqualValues(t, 0, replica.puts.Load())
+	})
+}
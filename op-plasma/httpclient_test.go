@@ -0,0 +1,102 @@
+package plasma
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAClientHTTPClient(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("some input")
+
+	t.Run("DefaultTimeout", func(t *testing.T) {
+		client := NewDAClient("http://localhost", true)
+		require.NotSame(t, http.DefaultClient, client.client)
+		// large transfers must not be cut off by an overall request timeout
+		require.Zero(t, client.client.Timeout)
+		transport := client.client.Transport.(*http.Transport)
+		require.Equal(t, DefaultResponseHeaderTimeout, transport.ResponseHeaderTimeout)
+		require.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
+		require.Zero(t, http.DefaultTransport.(*http.Transport).ResponseHeaderTimeout, "default transport must not be modified")
+	})
+
+	t.Run("ResponseHeaderTimeoutFires", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(srv.Close)
+		t.Cleanup(func() { close(release) })
+
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy))
+		client.client.Transport.(*http.Transport).ResponseHeaderTimeout = 50 * time.Millisecond
+		start := time.Now()
+		_, err := client.GetInput(ctx, Keccak256(input))
+		require.ErrorContains(t, err, "timeout awaiting response headers")
+		require.Less(t, time.Since(start), 10*time.Second)
+	})
+
+	t.Run("TimeoutFires", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(srv.Close)
+		t.Cleanup(func() { close(release) })
+
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy), WithTimeout(50*time.Millisecond))
+		start := time.Now()
+		_, err := client.GetInput(ctx, Keccak256(input))
+		require.ErrorContains(t, err, "Client.Timeout exceeded")
+		require.Less(t, time.Since(start), 10*time.Second)
+	})
+
+	t.Run("CustomClientUsed", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(input)
+		}))
+		t.Cleanup(srv.Close)
+
+		var used bool
+		custom := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			used = true
+			return http.DefaultTransport.RoundTrip(r)
+		})}
+		client := NewDAClient(srv.URL, true, WithHTTPClient(custom), WithTimeout(time.Minute))
+		data, err := client.GetInput(ctx, Keccak256(input))
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+		require.True(t, used)
+		require.Zero(t, custom.Timeout, "caller's client must not be modified")
+	})
+
+	t.Run("TLSConfig", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(input)
+		}))
+		t.Cleanup(srv.Close)
+
+		// the test server certificate is not trusted by default
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(NoRetryPolicy))
+		_, err := client.GetInput(ctx, Keccak256(input))
+		require.Error(t, err)
+
+		tlsCfg := &tls.Config{RootCAs: srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}
+		client = NewDAClient(srv.URL, true, WithTLSConfig(tlsCfg))
+		data, err := client.GetInput(ctx, Keccak256(input))
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,163 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// IPFSAddressing selects the HTTP API used to reach the endpoints of a client in IPFS mode.
+type IPFSAddressing int
+
+const (
+	// IPFSGateway addresses the endpoints as HTTP gateways. Inputs are read as raw blocks from /ipfs/<cid>
+	// and written with a POST to /ipfs/, which requires a writable gateway.
+	IPFSGateway IPFSAddressing = iota + 1
+	// IPFSNodeAPI addresses the endpoints as the RPC API of an IPFS node. Inputs are read and written as
+	// raw blocks with /api/v0/block/get and /api/v0/block/put, and pinned when written.
+	IPFSNodeAPI
+)
+
+func (a IPFSAddressing) String() string {
+	switch a {
+	case IPFSGateway:
+		return "gateway"
+	case IPFSNodeAPI:
+		return "node-api"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(a))
+	}
+}
+
+// ParseIPFSAddressing parses the name of an IPFS addressing, as returned by String.
+func ParseIPFSAddressing(s string) (IPFSAddressing, error) {
+	for _, a := range []IPFSAddressing{IPFSGateway, IPFSNodeAPI} {
+		if s == a.String() {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown IPFS addressing %q, expected gateway or node-api", s)
+}
+
+// ErrIPFSUnsupported is returned by operations relying on DA server routes when the client is in IPFS mode.
+var ErrIPFSUnsupported = errors.New("not supported in IPFS mode")
+
+// WithIPFS puts the client in IPFS mode: the endpoints are IPFS gateways or nodes addressed as configured
+// and SetInput creates IPFS commitments. Inputs are stored as a single raw block, so the CID embedded in
+// the commitment addresses the input on any gateway, and only IPFS commitments can be read.
+// DeleteInput, Exists, streamed and chunked transfers return ErrIPFSUnsupported, batch operations
+// fall back to single requests.
+func WithIPFS(addressing IPFSAddressing) DAClientOption {
+	return func(c *DAClient) {
+		c.ipfs = addressing
+		c.commType = IPFSCommitmentType
+	}
+}
+
+// checkDARoutes returns ErrIPFSUnsupported if the client is in IPFS mode.
+func (c *DAClient) checkDARoutes() error {
+	if c.ipfs != 0 {
+		return ErrIPFSUnsupported
+	}
+	return nil
+}
+
+// newGetRequest creates the request fetching the input for key, requested as-is, from the endpoint at baseURL.
+func (c *DAClient) newGetRequest(ctx context.Context, baseURL string, key Commitment, comm Commitment) (*http.Request, error) {
+	method, url := http.MethodGet, fmt.Sprintf("%s/get/0x%x", baseURL, []byte(key))
+	if c.ipfs != 0 {
+		id, err := comm.CID()
+		if err != nil {
+			return nil, err
+		}
+		if c.ipfs == IPFSNodeAPI {
+			method, url = http.MethodPost, fmt.Sprintf("%s/api/v0/block/get?arg=%s", baseURL, id)
+		} else {
+			url = fmt.Sprintf("%s/ipfs/%s", baseURL, id)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if c.ipfs == IPFSGateway {
+		// request the block itself rather than a deserialized response
+		req.Header.Set("Accept", "application/vnd.ipld.raw")
+	}
+	return req, nil
+}
+
+// getStatusError converts a non 200 response to a get into an error. An IPFS node API reports unknown
+// blocks with a 500 response, which is mapped to ErrNotFound like a 404.
+func (c *DAClient) getStatusError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	err := newStatusError(resp)
+	var se *ServerError
+	if c.ipfs == IPFSNodeAPI && errors.As(err, &se) && strings.Contains(se.Message, "not found") {
+		return ErrNotFound
+	}
+	return err
+}
+
+// setIPFSInput stores the input as a raw block on the IPFS endpoint at baseURL and checks that the
+// endpoint addressed it by the CID of key.
+func (c *DAClient) setIPFSInput(ctx context.Context, baseURL string, key Commitment, img []byte) error {
+	id, err := key.CID()
+	if err != nil {
+		return err
+	}
+	var req *http.Request
+	if c.ipfs == IPFSNodeAPI {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("file", id)
+		if err != nil {
+			return fmt.Errorf("failed to encode input: %w", err)
+		}
+		if _, err := part.Write(img); err != nil {
+			return fmt.Errorf("failed to encode input: %w", err)
+		}
+		if err := form.Close(); err != nil {
+			return fmt.Errorf("failed to encode input: %w", err)
+		}
+		url := fmt.Sprintf("%s/api/v0/block/put?cid-codec=raw&mhtype=sha2-256&pin=true", baseURL)
+		if req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, &body); err != nil {
+			return fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		req.Header.Set("Content-Type", form.FormDataContentType())
+	} else {
+		if req, err = http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/ipfs/", bytes.NewReader(img)); err != nil {
+			return fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to store preimage: %w", newStatusError(resp))
+	}
+	// the node API responds with the stat of the block, a writable gateway with the CID in a header
+	stored := resp.Header.Get("Ipfs-Hash")
+	if c.ipfs == IPFSNodeAPI {
+		var stat struct{ Key string }
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxErrorMessageSize)).Decode(&stat); err != nil {
+			return fmt.Errorf("failed to decode IPFS response: %w", err)
+		}
+		stored = stat.Key
+	}
+	if comm, err := CommitmentFromCID(stored); err != nil || !bytes.Equal(comm, key) {
+		return fmt.Errorf("%w: IPFS endpoint stored input as %q, expected %s", ErrCommitmentMismatch, stored, id)
+	}
+	return nil
+}
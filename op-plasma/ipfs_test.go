@@ -0,0 +1,192 @@
+package plasma
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIPFS serves raw blocks through the gateway and node API routes used by the client.
+type fakeIPFS struct {
+	mu     sync.Mutex
+	blocks map[string][]byte
+	// codec is the codec of the CIDs created for stored blocks.
+	codec uint64
+}
+
+func newFakeIPFS(t *testing.T) (*fakeIPFS, *httptest.Server) {
+	f := &fakeIPFS{blocks: make(map[string][]byte), codec: cid.Raw}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ipfs/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			data, err := io.ReadAll(r.Body)
+			if !assert.NoError(t, err) {
+				return
+			}
+			w.Header().Set("Ipfs-Hash", f.put(t, data))
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			assert.Equal(t, "application/vnd.ipld.raw", r.Header.Get("Accept"))
+			data, ok := f.get(strings.TrimPrefix(r.URL.Path, "/ipfs/"))
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v0/block/put", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "raw", r.URL.Query().Get("cid-codec"))
+		assert.Equal(t, "sha2-256", r.URL.Query().Get("mhtype"))
+		file, _, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			return
+		}
+		data, err := io.ReadAll(file)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Key": f.put(t, data), "Size": len(data)})
+	})
+	mux.HandleFunc("/api/v0/block/get", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		data, ok := f.get(r.URL.Query().Get("arg"))
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"Message":"block was not found locally (offline)","Code":0,"Type":"error"}`))
+			return
+		}
+		_, _ = w.Write(data)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeIPFS) put(t *testing.T, data []byte) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	hash, err := multihash.Sum(data, multihash.SHA2_256, -1)
+	assert.NoError(t, err)
+	id := cid.NewCidV1(f.codec, hash).String()
+	f.blocks[id] = data
+	return id
+}
+
+func (f *fakeIPFS) get(id string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.blocks[id]
+	return data, ok
+}
+
+func (f *fakeIPFS) set(id string, data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blocks[id] = data
+}
+
+func TestDAClientIPFS(t *testing.T) {
+	for _, addressing := range []IPFSAddressing{IPFSGateway, IPFSNodeAPI} {
+		addressing := addressing
+		name := map[IPFSAddressing]string{IPFSGateway: "Gateway", IPFSNodeAPI: "NodeAPI"}[addressing]
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			ipfs, srv := newFakeIPFS(t)
+			client := NewDAClient(srv.URL, true, WithIPFS(addressing), WithRetryPolicy(NoRetryPolicy))
+
+			input := []byte("pinned to ipfs")
+			comm, err := client.SetInput(ctx, input)
+			require.NoError(t, err)
+			require.Equal(t, IPFSCommitmentType, comm.Type())
+			id, err := comm.CID()
+			require.NoError(t, err)
+			stored, ok := ipfs.get(id)
+			require.True(t, ok, "input must be addressed by the CID of the commitment")
+			require.Equal(t, input, stored)
+
+			got, err := client.GetInput(ctx, comm)
+			require.NoError(t, err)
+			require.Equal(t, input, got)
+
+			other := []byte("stored by another client")
+			otherComm, err := NewCommitment(IPFSCommitmentType, other)
+			require.NoError(t, err)
+			require.NoError(t, client.SetInputWithCommitment(ctx, other, otherComm))
+
+			results, err := client.GetInputs(ctx, []Commitment{comm, otherComm})
+			require.NoError(t, err)
+			require.Equal(t, []InputResult{{Input: input}, {Input: other}}, results)
+
+			missing, err := NewCommitment(IPFSCommitmentType, []byte("missing"))
+			require.NoError(t, err)
+			_, err = client.GetInput(ctx, missing)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			// the multihash of the content returned by the endpoint must match the commitment
+			ipfs.set(id, []byte("tampered"))
+			_, err = client.GetInput(ctx, comm)
+			require.ErrorIs(t, err, ErrCommitmentMismatch)
+
+			_, err = client.GetInput(ctx, Keccak256(input))
+			require.ErrorIs(t, err, ErrUnsupportedCommitment)
+			require.ErrorIs(t, client.DeleteInput(ctx, comm), ErrIPFSUnsupported)
+			_, err = client.Exists(ctx, comm)
+			require.ErrorIs(t, err, ErrIPFSUnsupported)
+		})
+	}
+
+	t.Run("NotRawBlock", func(t *testing.T) {
+		// an endpoint chunking the input into a DAG does not address it by the commitment
+		ipfs, srv := newFakeIPFS(t)
+		ipfs.codec = cid.DagProtobuf
+		client := NewDAClient(srv.URL, true, WithIPFS(IPFSGateway), WithRetryPolicy(NoRetryPolicy))
+		_, err := client.SetInput(context.Background(), []byte("chunked"))
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+	})
+}
+
+func TestDAServerIPFSCommitment(t *testing.T) {
+	// without IPFS addressing, IPFS commitments are stored on the DA server like any other type
+	ctx := context.Background()
+	_, url := startDAServer(t, newMemStore())
+	client := NewDAClient(url, true, WithCommitmentType(IPFSCommitmentType))
+	input := []byte("stored on the DA server")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, IPFSCommitmentType, comm.Type())
+	got, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+}
+
+func TestIPFSCLIConfig(t *testing.T) {
+	_, srv := newFakeIPFS(t)
+	cfg := CLIConfig{Enabled: true, DAServerURL: srv.URL, VerifyOnRead: true, IPFS: "node-api"}
+	require.NoError(t, cfg.Check())
+	client, err := cfg.NewDAClient()
+	require.NoError(t, err)
+	input := []byte("configured from the CLI")
+	comm, err := client.SetInput(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, IPFSCommitmentType, comm.Type())
+
+	cfg.IPFS = "kubo"
+	require.ErrorContains(t, cfg.Check(), "unknown IPFS addressing")
+	_, err = cfg.NewDAClient()
+	require.ErrorContains(t, err, "unknown IPFS addressing")
+}
@@ -0,0 +1,95 @@
+package plasma
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BlobTooLargeError is returned when an input exceeds the capacity of the single blob a KZG commitment
+// commits to. It matches ErrInputTooLarge with errors.Is.
+type BlobTooLargeError struct {
+	// Size is the size of the input.
+	Size int64
+}
+
+func (e *BlobTooLargeError) Error() string {
+	return fmt.Sprintf("%v: %d bytes exceeds blob capacity of %d bytes", ErrInputTooLarge, e.Size, eth.MaxBlobDataSize)
+}
+
+func (e *BlobTooLargeError) Is(target error) bool {
+	return target == ErrInputTooLarge
+}
+
+// checkSize returns a *BlobTooLargeError if an input of the given size cannot be committed to with
+// the commitment type, so streamed and chunked uploads fail before any data is sent.
+func (t CommitmentType) checkSize(size int64) error {
+	if t == KZGCommitmentType && size > eth.MaxBlobDataSize {
+		return &BlobTooLargeError{Size: size}
+	}
+	return nil
+}
+
+// kzgDigest encodes the input into the field elements of a blob, as the batcher does for blob transactions,
+// and returns the versioned hash of the KZG commitment to the blob.
+func kzgDigest(input []byte) ([]byte, error) {
+	if err := KZGCommitmentType.checkSize(int64(len(input))); err != nil {
+		return nil, err
+	}
+	var blob eth.Blob
+	if err := blob.FromData(input); err != nil {
+		return nil, fmt.Errorf("failed to encode blob: %w", err)
+	}
+	commitment, err := blob.ComputeKZGCommitment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute KZG commitment: %w", err)
+	}
+	h := eth.KZGToVersionedHash(commitment)
+	return h[:], nil
+}
+
+// blobHash buffers the input to compute its KZG digest on Sum, as KZG commitments cannot be computed
+// incrementally. Inputs exceeding the blob capacity are not buffered and sum to a digest that is not a
+// valid versioned hash, so they never match a commitment.
+type blobHash struct {
+	buf  bytes.Buffer
+	size int64
+}
+
+func (h *blobHash) Write(p []byte) (int, error) {
+	h.size += int64(len(p))
+	if h.size <= eth.MaxBlobDataSize {
+		h.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+func (h *blobHash) Sum(b []byte) []byte {
+	digest, err := kzgDigest(h.buf.Bytes())
+	if err != nil || h.size > eth.MaxBlobDataSize {
+		return append(b, make([]byte, common.HashLength)...)
+	}
+	return append(b, digest...)
+}
+
+func (h *blobHash) Reset() {
+	h.buf.Reset()
+	h.size = 0
+}
+
+func (h *blobHash) Size() int {
+	return common.HashLength
+}
+
+func (h *blobHash) BlockSize() int {
+	return 32
+}
+
+// isVersionedKZGHash reports whether the digest has the length and version byte of a KZG versioned hash.
+func isVersionedKZGHash(digest []byte) bool {
+	return len(digest) == common.HashLength && digest[0] == params.BlobTxHashVersion
+}
@@ -0,0 +1,110 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestKZGCommitment(t *testing.T) {
+	input := []byte("posted as a blob later")
+	comm, err := NewCommitment(KZGCommitmentType, input)
+	require.NoError(t, err)
+	require.Equal(t, KZGCommitmentType, comm.Type())
+
+	// the digest is the versioned hash the input has as blob, computed like the batcher does
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(input))
+	kzgComm, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	versionedHash := eth.KZGToVersionedHash(kzgComm)
+	require.Equal(t, versionedHash[:], comm.Digest())
+	require.Equal(t, byte(params.BlobTxHashVersion), comm.Digest()[0])
+
+	require.NoError(t, comm.Verify(input))
+	require.ErrorIs(t, comm.Verify([]byte("other")), ErrCommitmentMismatch)
+	decoded, err := DecodeCommitment(comm.Encode())
+	require.NoError(t, err)
+	require.Equal(t, comm, decoded)
+
+	t.Run("Hasher", func(t *testing.T) {
+		h, err := KZGCommitmentType.hasher()
+		require.NoError(t, err)
+		_, _ = h.Write(input[:5])
+		_, _ = h.Write(input[5:])
+		require.Equal(t, comm.Digest(), h.Sum(nil))
+
+		h.Reset()
+		_, _ = h.Write(make([]byte, eth.MaxBlobDataSize+1))
+		require.False(t, isVersionedKZGHash(h.Sum(nil)), "oversized inputs must never match a commitment")
+	})
+
+	t.Run("NotVersionedHash", func(t *testing.T) {
+		invalid := bytes.Clone(comm.Encode())
+		invalid[1] = 0x00
+		_, err := DecodeCommitment(invalid)
+		require.ErrorIs(t, err, ErrInvalidCommitment)
+		_, err = DecodeCommitment(comm.Encode()[:20])
+		require.ErrorIs(t, err, ErrInvalidCommitment)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		full := bytes.Repeat([]byte{0xff}, eth.MaxBlobDataSize)
+		_, err := NewCommitment(KZGCommitmentType, full)
+		require.NoError(t, err, "an input filling the blob fits")
+
+		_, err = NewCommitment(KZGCommitmentType, append(full, 0x00))
+		var tooLarge *BlobTooLargeError
+		require.ErrorAs(t, err, &tooLarge)
+		require.EqualValues(t, eth.MaxBlobDataSize+1, tooLarge.Size)
+		require.ErrorIs(t, err, ErrInputTooLarge)
+	})
+}
+
+func TestDAClientKZG(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	_, url := startDAServer(t, store)
+	client := NewDAClient(url, true, WithCommitmentType(KZGCommitmentType), WithRetryPolicy(NoRetryPolicy))
+
+	input := []byte("a batch for a blob")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, KZGCommitmentType, comm.Type())
+	expected, err := NewCommitment(KZGCommitmentType, input)
+	require.NoError(t, err)
+	require.Equal(t, expected, comm)
+
+	got, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+
+	r, _, err := client.GetInputReader(ctx, comm)
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, input, got)
+
+	// the returned data is verified by recomputing its KZG commitment
+	require.NoError(t, store.Put(ctx, comm, []byte("tampered")))
+	_, err = client.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+
+	t.Run("TooLarge", func(t *testing.T) {
+		large := make([]byte, eth.MaxBlobDataSize+1)
+		var tooLarge *BlobTooLargeError
+		_, err := client.SetInput(ctx, large)
+		require.ErrorAs(t, err, &tooLarge)
+		_, err = client.SetInputStream(ctx, bytes.NewReader(large), int64(len(large)))
+		require.ErrorAs(t, err, &tooLarge)
+		_, err = client.SetInputChunked(ctx, bytes.NewReader(large), int64(len(large)))
+		require.ErrorAs(t, err, &tooLarge)
+	})
+}
@@ -0,0 +1,122 @@
+package plasma
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAClientMaxInputSize(t *testing.T) {
+	ctx := context.Background()
+	var requests atomic.Int32
+	var served []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(served)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewDAClient(srv.URL, true, WithMaxInputSize(10))
+
+	t.Run("SetInputTooLarge", func(t *testing.T) {
+		requests.Store(0)
+		_, err := client.SetInput(ctx, make([]byte, 11))
+		require.ErrorIs(t, err, ErrInputTooLarge)
+		require.Zero(t, requests.Load(), "must fail before any network traffic")
+
+		_, err = client.SetInput(ctx, make([]byte, 10))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, requests.Load())
+	})
+
+	t.Run("GetInputTooLarge", func(t *testing.T) {
+		served = make([]byte, 11)
+		_, err := client.GetInput(ctx, Keccak256(served))
+		require.ErrorIs(t, err, ErrInputTooLarge)
+		require.NotErrorIs(t, err, ErrCommitmentMismatch)
+		var tooLarge *ResponseTooLargeError
+		require.ErrorAs(t, err, &tooLarge)
+		require.EqualValues(t, 10, tooLarge.Limit)
+		require.EqualValues(t, 11, tooLarge.Size, "announced Content-Length must fail before reading")
+
+		served = make([]byte, 10)
+		data, err := client.GetInput(ctx, Keccak256(served))
+		require.NoError(t, err)
+		require.Equal(t, served, data)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		client := NewDAClient(srv.URL, true, WithMaxInputSize(0))
+		served = make([]byte, DefaultMaxInputSize+1)
+		data, err := client.GetInput(ctx, Keccak256(served))
+		require.NoError(t, err)
+		require.Equal(t, served, data)
+
+		_, err = client.SetInput(ctx, served)
+		require.NoError(t, err)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		client := NewDAClient(srv.URL, true)
+		_, err := client.SetInput(ctx, make([]byte, DefaultMaxInputSize+1))
+		require.ErrorIs(t, err, ErrInputTooLarge)
+	})
+}
+
+func TestDAClientEndlessResponse(t *testing.T) {
+	chunk := make([]byte, 64*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// stream without Content-Length until the client hangs up
+		for {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	const limit = 1 << 20
+	transport := &countingTransport{}
+	client := NewDAClient(srv.URL, true, WithMaxInputSize(limit), WithRetryPolicy(NoRetryPolicy),
+		WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.GetInput(context.Background(), Keccak256([]byte("input")))
+	var tooLarge *ResponseTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	require.EqualValues(t, -1, tooLarge.Size)
+	require.NotErrorIs(t, err, ErrCommitmentMismatch)
+	require.EqualValues(t, limit+1, transport.read.Load(), "should stop reading the body once over the limit")
+}
+
+// countingTransport counts the response body bytes read by the client.
+type countingTransport struct {
+	read atomic.Int64
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, read: &t.read}
+	return resp, nil
+}
+
+type countingBody struct {
+	io.ReadCloser
+	read *atomic.Int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read.Add(int64(n))
+	return n, err
+}
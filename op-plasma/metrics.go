@@ -0,0 +1,192 @@
+package plasma
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const DAClientSubsystem = "da_client"
+
+// Error classes recorded by the metrics.
+const (
+	ErrorClassNotFound           = "not_found"
+	ErrorClassCommitmentMismatch = "commitment_mismatch"
+	ErrorClassTransport          = "transport"
+	ErrorClassServer             = "server"
+	ErrorClassTimeout            = "timeout"
+	ErrorClassUnavailable        = "unavailable"
+	ErrorClassOther              = "other"
+)
+
+// Metricer records the requests made by the DAClient.
+type Metricer interface {
+	// RecordDARequest records a call to the client method and returns a function to call with its outcome.
+	RecordDARequest(method string) func(err error)
+	// RecordDABytesSent records the input bytes uploaded by the client method.
+	RecordDABytesSent(method string, n int)
+	// RecordDABytesReceived records the input bytes downloaded by the client method.
+	RecordDABytesReceived(method string, n int)
+	// RecordDACacheGet records a lookup in the verified input cache and whether it was a hit.
+	RecordDACacheGet(hit bool)
+	// RecordDACacheSize records the total size in bytes of the inputs in the verified input cache.
+	RecordDACacheSize(bytes int)
+	// RecordDABreakerState records the new state of the circuit breaker of the DA server endpoint.
+	RecordDABreakerState(url string, state BreakerState)
+}
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordDARequest(string) func(error)        { return func(error) {} }
+func (*NoopMetricsImpl) RecordDABytesSent(string, int)             {}
+func (*NoopMetricsImpl) RecordDABytesReceived(string, int)         {}
+func (*NoopMetricsImpl) RecordDACacheGet(bool)                     {}
+func (*NoopMetricsImpl) RecordDACacheSize(int)                     {}
+func (*NoopMetricsImpl) RecordDABreakerState(string, BreakerState) {}
+
+// DAMetrics tracks the DAClient metrics in prometheus.
+type DAMetrics struct {
+	RequestsTotal          *prometheus.CounterVec
+	ErrorsTotal            *prometheus.CounterVec
+	RequestDurationSeconds *prometheus.HistogramVec
+	BytesSentTotal         *prometheus.CounterVec
+	BytesReceivedTotal     *prometheus.CounterVec
+	CacheGetsTotal         *prometheus.CounterVec
+	CacheSizeBytes         prometheus.Gauge
+	BreakerState           *prometheus.GaugeVec
+}
+
+var _ Metricer = (*DAMetrics)(nil)
+
+// MakeDAMetrics creates a new DAMetrics instance with the given namespace for the service.
+func MakeDAMetrics(ns string, factory opmetrics.Factory) *DAMetrics {
+	return &DAMetrics{
+		RequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "requests_total",
+			Help:      "Total requests made by the DA client",
+		}, []string{
+			"method",
+		}),
+		ErrorsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "errors_total",
+			Help:      "Total failed requests made by the DA client, by error class",
+		}, []string{
+			"method",
+			"class",
+		}),
+		RequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "request_duration_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+			Help:      "Histogram of DA client request durations",
+		}, []string{
+			"method",
+		}),
+		BytesSentTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "bytes_sent_total",
+			Help:      "Total input bytes uploaded by the DA client",
+		}, []string{
+			"method",
+		}),
+		BytesReceivedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "bytes_received_total",
+			Help:      "Total input bytes downloaded by the DA client",
+		}, []string{
+			"method",
+		}),
+		CacheGetsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "cache_get_total",
+			Help:      "Verified input cache lookups, hitting or not",
+		}, []string{
+			"hit",
+		}),
+		CacheSizeBytes: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "cache_size_bytes",
+			Help:      "Total size of the inputs in the verified input cache",
+		}),
+		BreakerState: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: DAClientSubsystem,
+			Name:      "circuit_breaker_state",
+			Help:      "State of the circuit breaker of the DA server endpoint: 0 closed, 1 open, 2 half-open",
+		}, []string{
+			"url",
+		}),
+	}
+}
+
+func (m *DAMetrics) RecordDARequest(method string) func(err error) {
+	m.RequestsTotal.WithLabelValues(method).Inc()
+	timer := prometheus.NewTimer(m.RequestDurationSeconds.WithLabelValues(method))
+	return func(err error) {
+		timer.ObserveDuration()
+		if err != nil {
+			m.ErrorsTotal.WithLabelValues(method, ErrorClass(err)).Inc()
+		}
+	}
+}
+
+func (m *DAMetrics) RecordDABytesSent(method string, n int) {
+	m.BytesSentTotal.WithLabelValues(method).Add(float64(n))
+}
+
+func (m *DAMetrics) RecordDABytesReceived(method string, n int) {
+	m.BytesReceivedTotal.WithLabelValues(method).Add(float64(n))
+}
+
+func (m *DAMetrics) RecordDACacheGet(hit bool) {
+	if hit {
+		m.CacheGetsTotal.WithLabelValues("true").Inc()
+	} else {
+		m.CacheGetsTotal.WithLabelValues("false").Inc()
+	}
+}
+
+func (m *DAMetrics) RecordDACacheSize(bytes int) {
+	m.CacheSizeBytes.Set(float64(bytes))
+}
+
+func (m *DAMetrics) RecordDABreakerState(url string, state BreakerState) {
+	m.BreakerState.WithLabelValues(url).Set(float64(state))
+}
+
+// ErrorClass converts a DAClient error into a metrics friendly error class.
+func ErrorClass(err error) string {
+	var re *RequestError
+	var se *ServerError
+	var ue *url.Error
+	switch {
+	case errors.Is(err, ErrServiceUnavailable):
+		return ErrorClassUnavailable
+	case errors.Is(err, ErrTimeout):
+		return ErrorClassTimeout
+	case errors.Is(err, ErrNotFound):
+		return ErrorClassNotFound
+	case errors.Is(err, ErrCommitmentMismatch):
+		return ErrorClassCommitmentMismatch
+	case errors.As(err, &re), errors.As(err, &se):
+		return ErrorClassServer
+	case errors.As(err, &ue):
+		return ErrorClassTransport
+	default:
+		return ErrorClassOther
+	}
+}
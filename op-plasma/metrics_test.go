@@ -0,0 +1,94 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+func TestDAClientMetrics(t *testing.T) {
+	ctx := context.Background()
+	_, addr := startDAServer(t, newMemStore())
+	m := MakeDAMetrics("test", opmetrics.With(prometheus.NewRegistry()))
+	client := NewDAClient(addr, true, WithMetrics(m), WithRetryPolicy(NoRetryPolicy))
+
+	input := []byte("metered input")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+	data, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, data)
+	_, err = client.GetInput(ctx, Keccak256([]byte("unknown")))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	r, _, err := client.GetInputReader(ctx, comm)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close())
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RequestsTotal.WithLabelValues("SetInput")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RequestsTotal.WithLabelValues("GetInput")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.ErrorsTotal.WithLabelValues("GetInput", ErrorClassNotFound)))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.ErrorsTotal.WithLabelValues("SetInput", ErrorClassServer)))
+	require.Equal(t, float64(len(input)), testutil.ToFloat64(m.BytesSentTotal.WithLabelValues("SetInput")))
+	require.Equal(t, float64(len(input)), testutil.ToFloat64(m.BytesReceivedTotal.WithLabelValues("GetInput")))
+	require.Equal(t, float64(len(input)), testutil.ToFloat64(m.BytesReceivedTotal.WithLabelValues("GetInputReader")))
+	require.Equal(t, 3, testutil.CollectAndCount(m.RequestDurationSeconds, "test_da_client_request_duration_seconds"))
+
+	t.Run("ServerAndMismatchErrors", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte("wrong input"))
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithMetrics(m), WithRetryPolicy(NoRetryPolicy))
+
+		_, err := client.SetInput(ctx, input)
+		require.Error(t, err)
+		_, err = client.GetInput(ctx, comm)
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+
+		require.Equal(t, 1.0, testutil.ToFloat64(m.ErrorsTotal.WithLabelValues("SetInput", ErrorClassServer)))
+		require.Equal(t, 1.0, testutil.ToFloat64(m.ErrorsTotal.WithLabelValues("GetInput", ErrorClassCommitmentMismatch)))
+	})
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{fmt.Errorf("wrapped: %w", ErrNotFound), ErrorClassNotFound},
+		{ErrCommitmentMismatch, ErrorClassCommitmentMismatch},
+		{fmt.Errorf("failed after 3 attempts: %w", &ServerError{StatusCode: http.StatusBadGateway}), ErrorClassServer},
+		{&url.Error{Op: "Get", URL: "http://localhost", Err: errors.New("connection refused")}, ErrorClassTransport},
+		{ErrInputTooLarge, ErrorClassOther},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.class, ErrorClass(test.err), test.err.Error())
+	}
+}
+
+func TestNoopMetrics(t *testing.T) {
+	_, addr := startDAServer(t, newMemStore())
+	client := NewDAClient(addr, true)
+	require.Equal(t, NoopMetrics, client.metrics)
+	_, err := client.SetInput(context.Background(), []byte("input"))
+	require.NoError(t, err)
+}
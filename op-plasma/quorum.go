@@ -0,0 +1,148 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrQuorumNotMet is matched by the *QuorumError returned when fewer endpoints than the write quorum
+// stored an input.
+var ErrQuorumNotMet = errors.New("write quorum not met")
+
+// QuorumError is returned by quorum writes when fewer than Quorum endpoints stored the input.
+// It matches ErrQuorumNotMet as well as the errors of the failed endpoints with errors.Is.
+type QuorumError struct {
+	// Quorum is the number of endpoints required to store the input.
+	Quorum int
+	// Acks is the number of endpoints that stored the input.
+	Acks int
+	// Failed maps the URL of every endpoint that did not store the input to its error.
+	Failed map[string]error
+}
+
+func (e *QuorumError) Error() string {
+	urls := make([]string, 0, len(e.Failed))
+	for url := range e.Failed {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	failures := make([]string, len(urls))
+	for i, url := range urls {
+		failures[i] = fmt.Sprintf("%s: %v", url, e.Failed[url])
+	}
+	return fmt.Sprintf("%v: %d of %d acknowledgements, failed replicas: %s",
+		ErrQuorumNotMet, e.Acks, e.Quorum, strings.Join(failures, "; "))
+}
+
+func (e *QuorumError) Is(target error) bool {
+	return target == ErrQuorumNotMet
+}
+
+func (e *QuorumError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// WithWriteQuorum makes SetInput and SetInputWithCommitment write inputs to the primary and all replicas
+// concurrently, succeeding once quorum of them stored the input. Every endpoint must acknowledge the
+// commitment the client computed, an endpoint committing to another one does not count towards the quorum.
+// Endpoints that failed are listed by the *QuorumError if the quorum is not met, and otherwise reported to
+// the EndpointObserver and the logger only. A quorum of 0 disables quorum writes, the default.
+func WithWriteQuorum(quorum int) DAClientOption {
+	return func(c *DAClient) {
+		c.writeQuorum = quorum
+	}
+}
+
+// WithReadRepair makes GetInput write a verified input back, in the background, to the endpoints that were
+// tried before the one that served it and either did not have the input or returned corrupted data.
+// Repair requires reads to be verified, so corrupted data is never copied across endpoints.
+func WithReadRepair(repair bool) DAClientOption {
+	return func(c *DAClient) {
+		c.readRepair = repair
+	}
+}
+
+// storeQuorum concurrently writes the input to all endpoints with retries and waits for all of them,
+// returning a *QuorumError if fewer than the write quorum stored it.
+func (c *DAClient) storeQuorum(ctx context.Context, method string, key Commitment, img []byte) error {
+	if c.writeQuorum > len(c.endpoints) {
+		return fmt.Errorf("%w: quorum of %d exceeds the %d configured endpoints", ErrQuorumNotMet, c.writeQuorum, len(c.endpoints))
+	}
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed = make(map[string]error)
+	)
+	for _, e := range c.endpoints {
+		e := e
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := doEndpoint(ctx, c, e, func() (struct{}, error) {
+				return struct{}{}, c.setInput(ctx, e.url, key, img)
+			})
+			c.recordResult(e, method, err)
+			if err != nil {
+				mu.Lock()
+				failed[e.url] = err
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	acks := len(c.endpoints) - len(failed)
+	if acks < c.writeQuorum {
+		return &QuorumError{Quorum: c.writeQuorum, Acks: acks, Failed: failed}
+	}
+	if len(failed) > 0 && c.log != nil {
+		for url, err := range failed {
+			c.log.Warn("DA replica failed to store input", "method", method, "url", url,
+				"commitment", shortCommitment(key), "acks", acks, "quorum", c.writeQuorum, "err", err)
+		}
+	}
+	return nil
+}
+
+// repairInput writes the verified input back to the lagging endpoints in the background, bounded by the
+// client's call timeout. Outcomes are reported to the EndpointObserver as "Repair" requests.
+// The input must not be modified by the caller afterwards.
+func (c *DAClient) repairInput(comm Commitment, input []byte, lagging []*endpoint) {
+	timeout := c.callTimeout
+	if timeout == 0 {
+		timeout = DefaultCallTimeout
+	}
+	c.repairs.Add(1)
+	go func() {
+		defer c.repairs.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		for _, e := range lagging {
+			_, err := doEndpoint(ctx, c, e, func() (struct{}, error) {
+				return struct{}{}, c.setInput(ctx, e.url, comm, input)
+			})
+			c.recordResult(e, "Repair", err)
+			if c.log == nil {
+				continue
+			}
+			if err != nil {
+				c.log.Warn("Failed to repair DA replica", "url", e.url, "commitment", shortCommitment(comm), "err", err)
+			} else {
+				c.log.Info("Repaired DA replica", "url", e.url, "commitment", shortCommitment(comm))
+			}
+		}
+	}()
+}
+
+// Close waits for the read repairs running in the background to finish, which are bounded by the call timeout.
+// The client must not be used once closed.
+func (c *DAClient) Close() {
+	c.repairs.Wait()
+}
@@ -0,0 +1,141 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAClientWriteQuorum(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("quorum input")
+	comm := Keccak256(input)
+	_, primary := startDAServer(t, newMemStore())
+	_, replica := startDAServer(t, newMemStore())
+	down := newFailoverServer(t, http.StatusServiceUnavailable, nil)
+	stored := func(url string) bool {
+		_, err := NewDAClient(url, true).GetInput(ctx, comm)
+		return err == nil
+	}
+
+	t.Run("ReplicaDown", func(t *testing.T) {
+		var obs observed
+		client := NewDAClient(primary, true, WithReplicaURLs(replica, down.URL), WithWriteQuorum(2),
+			WithEndpointObserver(obs.observe), WithRetryPolicy(NoRetryPolicy))
+		got, err := client.SetInput(ctx, input)
+		require.NoError(t, err)
+		require.Equal(t, comm, got)
+		require.True(t, stored(primary))
+		require.True(t, stored(replica))
+
+		// the failed replica is reported to the observer
+		require.Len(t, obs.results, 3)
+		for _, res := range obs.results {
+			require.Equal(t, "SetInput", res.Method)
+			if res.URL == down.URL {
+				require.Error(t, res.Err)
+			} else {
+				require.NoError(t, res.Err)
+			}
+		}
+	})
+
+	t.Run("QuorumNotMet", func(t *testing.T) {
+		client := NewDAClient(primary, true, WithReplicaURLs(replica, down.URL), WithWriteQuorum(3),
+			WithRetryPolicy(NoRetryPolicy))
+		err := client.SetInputWithCommitment(ctx, input, comm)
+		require.ErrorIs(t, err, ErrQuorumNotMet)
+		var qerr *QuorumError
+		require.ErrorAs(t, err, &qerr)
+		require.Equal(t, 3, qerr.Quorum)
+		require.Equal(t, 2, qerr.Acks)
+		require.Len(t, qerr.Failed, 1)
+		var serverErr *ServerError
+		require.ErrorAs(t, qerr.Failed[down.URL], &serverErr)
+		require.ErrorContains(t, err, down.URL)
+	})
+
+	t.Run("QuorumExceedsEndpoints", func(t *testing.T) {
+		client := NewDAClient(primary, true, WithReplicaURLs(replica), WithWriteQuorum(3))
+		_, err := client.SetInput(ctx, input)
+		require.ErrorIs(t, err, ErrQuorumNotMet)
+		var qerr *QuorumError
+		require.False(t, errors.As(err, &qerr), "no write is attempted")
+	})
+}
+
+func TestDAClientWriteQuorumCommitmentConsistency(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("consistent input")
+	_, primary := startDAServer(t, newMemStore())
+	// a replica acknowledging the input under another commitment
+	inconsistent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(Keccak256([]byte("other input")).Encode())
+	}))
+	t.Cleanup(inconsistent.Close)
+
+	client := NewDAClient(primary, true, WithReplicaURLs(inconsistent.URL), WithWriteQuorum(2),
+		WithRetryPolicy(NoRetryPolicy))
+	_, err := client.SetInput(ctx, input)
+	require.ErrorIs(t, err, ErrQuorumNotMet)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+	var qerr *QuorumError
+	require.ErrorAs(t, err, &qerr)
+	require.Equal(t, 1, qerr.Acks)
+	require.Contains(t, qerr.Failed, inconsistent.URL)
+
+	client = NewDAClient(primary, true, WithReplicaURLs(inconsistent.URL), WithWriteQuorum(1),
+		WithRetryPolicy(NoRetryPolicy))
+	_, err = client.SetInput(ctx, input)
+	require.NoError(t, err)
+}
+
+func TestDAClientReadRepair(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("repaired input")
+	comm := Keccak256(input)
+	setup := func(repair bool) (corrupted *failoverServer, lagging string, client *DAClient) {
+		corrupted = newFailoverServer(t, http.StatusOK, []byte("corrupted input"))
+		_, lagging = startDAServer(t, newMemStore())
+		_, healthy := startDAServer(t, newMemStore())
+		require.NoError(t, NewDAClient(healthy, true).SetInputWithCommitment(ctx, input, comm))
+		client = NewDAClient(corrupted.URL, true, WithReplicaURLs(lagging, healthy), WithReadRepair(repair),
+			WithRetryPolicy(NoRetryPolicy))
+		return corrupted, lagging, client
+	}
+
+	corrupted, lagging, client := setup(true)
+	got, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+	// the caller owns the returned input, modifying it must not affect the repair
+	copy(got, "modified")
+	client.Close()
+	// the verified input is written back to the replica lacking it and to the one returning corrupted data
+	got, err = NewDAClient(lagging, true).GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+	require.EqualValues(t, 1, corrupted.puts.Load())
+
+	corrupted, lagging, client = setup(false)
+	_, err = client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	_, err = NewDAClient(lagging, true).GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Zero(t, corrupted.puts.Load())
+
+	// unverified reads are never repaired, so corrupted data cannot spread
+	corrupted, _, _ = setup(true)
+	_, lagging = startDAServer(t, newMemStore())
+	client = NewDAClient(lagging, false, WithReplicaURLs(corrupted.URL), WithReadRepair(true))
+	_, err = client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	_, err = NewDAClient(lagging, true).GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,94 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy configures how DAClient requests are retried on transient failures.
+// Connection errors and 5xx responses are retried, anything else is returned immediately.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one. Values below 1 mean a single attempt.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on every subsequent retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by NewDAClient unless overridden with WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// NoRetryPolicy makes a single attempt per request.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// delay returns the exponential backoff delay with jitter before the given retry (0 indexed).
+// The returned delay is uniformly distributed in [d/2, d] where d = min(BaseDelay * 2^attempt, MaxDelay).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// isRetryable reports whether the request error is transient: a transport failure, a 5xx response or
+// the default call timeout expiring. Other context errors are never retried.
+func isRetryable(err error) bool {
+	if errors.Is(err, ErrTimeout) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var se *ServerError
+	if errors.As(err, &se) {
+		return true
+	}
+	var ue *url.Error
+	return errors.As(err, &ue)
+}
+
+// doWithRetry runs op until it succeeds, returns a non retryable error, the policy is exhausted
+// or the context is done.
+func doWithRetry[T any](ctx context.Context, policy RetryPolicy, op func() (T, error)) (T, error) {
+	var empty T
+	attempts := max(policy.MaxAttempts, 1)
+	for i := 0; ; i++ {
+		res, err := op()
+		if err == nil {
+			return res, nil
+		}
+		if !isRetryable(err) {
+			return empty, err
+		}
+		if i+1 >= attempts {
+			if attempts > 1 {
+				return empty, fmt.Errorf("failed after %d attempts: %w", attempts, err)
+			}
+			return empty, err
+		}
+		timer := time.NewTimer(policy.delay(i))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return empty, errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package plasma
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    5 * time.Millisecond,
+}
+
+// flakyServer responds with failStatus for the first failures requests and then serves
+// the input on /get/ and accepts anything on /put/.
+func flakyServer(t *testing.T, failures int32, failStatus int, input []byte) (*httptest.Server, *atomic.Int32) {
+	var count atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) <= failures {
+			w.WriteHeader(failStatus)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(input)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &count
+}
+
+func TestDAClientRetry(t *testing.T) {
+	ctx := context.Background()
+	input := []byte("some input")
+	comm := Keccak256(input)
+
+	t.Run("RecoverFromServerErrors", func(t *testing.T) {
+		srv, count := flakyServer(t, 2, http.StatusBadGateway, input)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(testRetryPolicy))
+
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+		require.EqualValues(t, 3, count.Load())
+
+		srv, count = flakyServer(t, 2, http.StatusInternalServerError, input)
+		client = NewDAClient(srv.URL, true, WithRetryPolicy(testRetryPolicy))
+		_, err = client.SetInput(ctx, input)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, count.Load())
+	})
+
+	t.Run("ExhaustAttempts", func(t *testing.T) {
+		srv, count := flakyServer(t, 100, http.StatusServiceUnavailable, input)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(testRetryPolicy))
+
+		_, err := client.GetInput(ctx, comm)
+		require.Error(t, err)
+		require.EqualValues(t, 4, count.Load())
+
+		count.Store(0)
+		_, err = client.SetInput(ctx, input)
+		require.Error(t, err)
+		require.EqualValues(t, 4, count.Load())
+	})
+
+	t.Run("NotFoundShortCircuits", func(t *testing.T) {
+		srv, count := flakyServer(t, 100, http.StatusNotFound, input)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(testRetryPolicy))
+
+		_, err := client.GetInput(ctx, comm)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.EqualValues(t, 1, count.Load())
+	})
+
+	t.Run("ClientErrorNotRetried", func(t *testing.T) {
+		srv, count := flakyServer(t, 100, http.StatusBadRequest, input)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(testRetryPolicy))
+
+		_, err := client.SetInput(ctx, input)
+		require.Error(t, err)
+		require.EqualValues(t, 1, count.Load())
+	})
+
+	t.Run("TransportErrorsRetried", func(t *testing.T) {
+		srv, _ := flakyServer(t, 0, http.StatusOK, input)
+		srv.Close()
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(testRetryPolicy))
+
+		var attempts int
+		_, err := doWithRetry(ctx, testRetryPolicy, func() ([]byte, error) {
+			attempts++
+			return client.getInput(ctx, srv.URL, comm, comm)
+		})
+		require.Error(t, err)
+		require.Equal(t, 4, attempts)
+	})
+
+	t.Run("RespectContextDeadline", func(t *testing.T) {
+		srv, count := flakyServer(t, 100, http.StatusBadGateway, input)
+		client := NewDAClient(srv.URL, true, WithRetryPolicy(RetryPolicy{
+			MaxAttempts: 100,
+			BaseDelay:   time.Hour,
+			MaxDelay:    time.Hour,
+		}))
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := client.GetInput(ctx, comm)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), 10*time.Second)
+		require.EqualValues(t, 1, count.Load())
+	})
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, exp := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		exp := exp * time.Millisecond
+		d := policy.delay(attempt)
+		require.GreaterOrEqual(t, d, exp/2)
+		require.LessOrEqual(t, d, exp)
+	}
+}
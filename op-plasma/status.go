@@ -0,0 +1,320 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CommitmentState is the state of a commitment in its challenge window, as last reported to the DA server.
+type CommitmentState uint8
+
+const (
+	// StateInChallengeWindow is the state of a commitment that can still be challenged.
+	StateInChallengeWindow CommitmentState = iota + 1
+	// StateChallenged is the state of a commitment with an unresolved challenge.
+	StateChallenged
+	// StateFinalized is the state of a commitment that can no longer be challenged.
+	StateFinalized
+)
+
+var stateNames = map[CommitmentState]string{
+	StateInChallengeWindow: "in_challenge_window",
+	StateChallenged:        "challenged",
+	StateFinalized:         "finalized",
+}
+
+func (s CommitmentState) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(s))
+}
+
+func (s CommitmentState) MarshalText() ([]byte, error) {
+	name, ok := stateNames[s]
+	if !ok {
+		return nil, fmt.Errorf("unknown commitment state %d", uint8(s))
+	}
+	return []byte(name), nil
+}
+
+func (s *CommitmentState) UnmarshalText(text []byte) error {
+	for state, name := range stateNames {
+		if name == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown commitment state %q", text)
+}
+
+// Status is the challenge window status of a commitment. It is reported as-is by the DA server: the client
+// does not interpret it, e.g. a commitment past its ChallengeWindowEnd may still be reported in the window
+// until the status is updated. Times have second precision, unset times are zero.
+type Status struct {
+	State CommitmentState
+	// SubmittedAt is when the commitment was submitted to L1.
+	SubmittedAt time.Time
+	// ChallengeWindowEnd is when the challenge window of the commitment closes.
+	ChallengeWindowEnd time.Time
+	// ChallengedAt is when the commitment was challenged.
+	ChallengedAt time.Time
+	// UpdatedAt is when the DA server last stored the status, set by the server.
+	UpdatedAt time.Time
+}
+
+// statusJSON is the wire format of a Status, with times in unix seconds and unset times omitted.
+type statusJSON struct {
+	State              CommitmentState `json:"state"`
+	SubmittedAt        int64           `json:"submittedAt,omitempty"`
+	ChallengeWindowEnd int64           `json:"challengeWindowEnd,omitempty"`
+	ChallengedAt       int64           `json:"challengedAt,omitempty"`
+	UpdatedAt          int64           `json:"updatedAt,omitempty"`
+}
+
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(statusJSON{
+		State:              s.State,
+		SubmittedAt:        unixTime(s.SubmittedAt),
+		ChallengeWindowEnd: unixTime(s.ChallengeWindowEnd),
+		ChallengedAt:       unixTime(s.ChallengedAt),
+		UpdatedAt:          unixTime(s.UpdatedAt),
+	})
+}
+
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var v statusJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = Status{
+		State:              v.State,
+		SubmittedAt:        fromUnixTime(v.SubmittedAt),
+		ChallengeWindowEnd: fromUnixTime(v.ChallengeWindowEnd),
+		ChallengedAt:       fromUnixTime(v.ChallengedAt),
+		UpdatedAt:          fromUnixTime(v.UpdatedAt),
+	}
+	return nil
+}
+
+// maxStatusSize bounds the status responses read by the client and the status updates read by the server.
+const maxStatusSize = 4096
+
+// StatusStore holds the commitment statuses served by a DAServer. It is updated by the operator or an
+// L1 watcher through the /status/ route. GetStatus returns ErrNotFound for unknown commitments.
+type StatusStore interface {
+	GetStatus(ctx context.Context, key Commitment) (Status, error)
+	SetStatus(ctx context.Context, key Commitment, status Status) error
+}
+
+// statusKeyPrefix prefixes the encoded commitment to form the key of its status in the DAServer store.
+// Like chunkKeyPrefix, it cannot collide with an encoded commitment.
+const statusKeyPrefix = "status:"
+
+type storageStatusStore struct {
+	store Storage
+}
+
+// NewStorageStatusStore returns a StatusStore keeping the statuses in store, next to the inputs.
+// It is the default StatusStore of a DAServer.
+func NewStorageStatusStore(store Storage) StatusStore {
+	return &storageStatusStore{store: store}
+}
+
+func statusKey(key Commitment) Commitment {
+	return append(Commitment(statusKeyPrefix), key.Encode()...)
+}
+
+func (s *storageStatusStore) GetStatus(ctx context.Context, key Commitment) (Status, error) {
+	data, err := s.store.Get(ctx, statusKey(key))
+	if err != nil {
+		return Status{}, err
+	}
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return Status{}, fmt.Errorf("failed to decode stored status: %w", err)
+	}
+	return status, nil
+}
+
+func (s *storageStatusStore) SetStatus(ctx context.Context, key Commitment, status Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return s.store.Put(ctx, statusKey(key), data)
+}
+
+// WithStatusStore sets the store of the commitment statuses. Defaults to NewStorageStatusStore of the server storage.
+func WithStatusStore(store StatusStore) DAServerOption {
+	return func(s *DAServer) {
+		s.statuses = store
+	}
+}
+
+// WithStatusToken enables status updates on the /status/ route, authorizing requests that carry the token
+// as a bearer token.
+func WithStatusToken(token string) DAServerOption {
+	return func(s *DAServer) {
+		s.statusToken = token
+	}
+}
+
+func (s *DAServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Method == http.MethodPut && !authorized(w, r, s.statusToken, "status updates") {
+		return
+	}
+	comm, err := commitmentFromPath(r.URL.Path, "/status/")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Method == http.MethodPut {
+		s.handleSetStatus(w, r, comm)
+		return
+	}
+	status, err := s.statuses.GetStatus(r.Context(), comm)
+	if err != nil {
+		s.writeStorageError(w, "Failed to get status", comm, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		s.log.Debug("Failed to write response", "err", err)
+	}
+}
+
+func (s *DAServer) handleSetStatus(w http.ResponseWriter, r *http.Request, comm Commitment) {
+	var status Status
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxStatusSize)).Decode(&status); err != nil {
+		http.Error(w, fmt.Sprintf("invalid status: %v", err), http.StatusBadRequest)
+		return
+	}
+	if _, ok := stateNames[status.State]; !ok {
+		http.Error(w, "missing commitment state", http.StatusBadRequest)
+		return
+	}
+	status.UpdatedAt = time.Now()
+	if err := s.statuses.SetStatus(r.Context(), comm, status); err != nil {
+		s.writeStorageError(w, "Failed to set status", comm, err)
+		return
+	}
+	s.log.Info("Updated commitment status", "commitment", comm, "state", status.State)
+}
+
+// GetCommitmentStatus returns the challenge window status of the commitment reported by the primary DA server,
+// or ErrNotFound if the server has no status for it. The status is returned as reported, without applying
+// any policy: callers decide e.g. whether a finalized commitment can be pruned.
+func (c *DAClient) GetCommitmentStatus(ctx context.Context, key Commitment) (_ Status, err error) {
+	ctx, call := c.startCall(ctx, "GetCommitmentStatus", key)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	if err := c.checkDARoutes(); err != nil {
+		return Status{}, err
+	}
+	comm, err := DecodeCommitment(key)
+	if err != nil {
+		return Status{}, err
+	}
+	primary := c.endpoints[0]
+	status, err := doEndpoint(ctx, c, primary, func() (Status, error) {
+		return c.getStatus(ctx, primary.url, comm)
+	})
+	c.recordResult(primary, "GetCommitmentStatus", err)
+	return status, err
+}
+
+func (c *DAClient) getStatus(ctx context.Context, baseURL string, key Commitment) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/status/0x%x", baseURL, key.Encode()), nil)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Status{}, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return Status{}, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return Status{}, fmt.Errorf("failed to get status: %w", newStatusError(resp))
+	}
+	var status Status
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxStatusSize)).Decode(&status); err != nil {
+		return Status{}, fmt.Errorf("failed to decode status: %w", err)
+	}
+	return status, nil
+}
+
+// SetCommitmentStatus stores the challenge window status of the commitment on the primary DA server,
+// authorized by the token set with WithAuthToken. It is meant for the operator or an L1 watcher tracking
+// the challenges of the commitments. The UpdatedAt time is set by the server.
+func (c *DAClient) SetCommitmentStatus(ctx context.Context, key Commitment, status Status) (err error) {
+	ctx, call := c.startCall(ctx, "SetCommitmentStatus", key)
+	defer func() { call.end(err) }()
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	if err := c.checkDARoutes(); err != nil {
+		return err
+	}
+	comm, err := DecodeCommitment(key)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	primary := c.endpoints[0]
+	_, err = doEndpoint(ctx, c, primary, func() (struct{}, error) {
+		return struct{}{}, c.setStatus(ctx, primary.url, comm, body)
+	})
+	c.recordResult(primary, "SetCommitmentStatus", err)
+	return err
+}
+
+func (c *DAClient) setStatus(ctx context.Context, baseURL string, key Commitment, body []byte) error {
+	url := fmt.Sprintf("%s/status/0x%x", baseURL, key.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to set status: %w", newStatusError(resp))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitmentStatus(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	_, url := startDAServer(t, store, WithStatusToken("secret"))
+	client := NewDAClient(url, true, WithAuthToken("secret"), WithRetryPolicy(NoRetryPolicy))
+
+	comm, err := client.SetInput(ctx, []byte("an input"))
+	require.NoError(t, err)
+	_, err = client.GetCommitmentStatus(ctx, comm)
+	require.ErrorIs(t, err, ErrNotFound, "a stored input without status is unknown")
+
+	submitted := time.Unix(1700000000, 0)
+	status := Status{
+		State:              StateInChallengeWindow,
+		SubmittedAt:        submitted,
+		ChallengeWindowEnd: submitted.Add(time.Hour),
+	}
+	before := time.Now().Truncate(time.Second)
+	require.NoError(t, client.SetCommitmentStatus(ctx, comm, status))
+	got, err := client.GetCommitmentStatus(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, StateInChallengeWindow, got.State)
+	require.True(t, submitted.Equal(got.SubmittedAt))
+	require.True(t, submitted.Add(time.Hour).Equal(got.ChallengeWindowEnd))
+	require.True(t, got.ChallengedAt.IsZero())
+	require.False(t, got.UpdatedAt.Before(before), "the server sets the update time")
+
+	// the status is kept in the server storage, next to the input
+	_, err = store.Get(ctx, statusKey(comm))
+	require.NoError(t, err)
+	input, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, []byte("an input"), input)
+
+	status.State, status.ChallengedAt = StateChallenged, submitted.Add(time.Minute)
+	require.NoError(t, client.SetCommitmentStatus(ctx, comm, status))
+	got, err = client.GetCommitmentStatus(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, StateChallenged, got.State)
+	require.True(t, submitted.Add(time.Minute).Equal(got.ChallengedAt))
+
+	// statuses are served as-is, also for commitments without input
+	other := Keccak256([]byte("not stored"))
+	require.NoError(t, client.SetCommitmentStatus(ctx, other, Status{State: StateFinalized}))
+	got, err = client.GetCommitmentStatus(ctx, other)
+	require.NoError(t, err)
+	require.Equal(t, StateFinalized, got.State)
+	require.True(t, got.SubmittedAt.IsZero())
+}
+
+func TestCommitmentStatusUpdates(t *testing.T) {
+	ctx := context.Background()
+	comm := Keccak256([]byte("an input"))
+	status := Status{State: StateFinalized}
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, url := startDAServer(t, newMemStore())
+		client := NewDAClient(url, true, WithAuthToken("secret"), WithRetryPolicy(NoRetryPolicy))
+		var reqErr *RequestError
+		require.ErrorAs(t, client.SetCommitmentStatus(ctx, comm, status), &reqErr)
+		require.Equal(t, http.StatusForbidden, reqErr.StatusCode)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		_, url := startDAServer(t, newMemStore(), WithStatusToken("secret"))
+		client := NewDAClient(url, true, WithAuthToken("wrong"), WithRetryPolicy(NoRetryPolicy))
+		var reqErr *RequestError
+		require.ErrorAs(t, client.SetCommitmentStatus(ctx, comm, status), &reqErr)
+		require.Equal(t, http.StatusUnauthorized, reqErr.StatusCode)
+		_, err := client.GetCommitmentStatus(ctx, comm)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		_, url := startDAServer(t, newMemStore(), WithStatusToken("secret"))
+		for _, body := range []string{`{"state":"pending"}`, `{"submittedAt":1}`, `not json`} {
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/status/0x%x", url, comm.Encode()), bytes.NewReader([]byte(body)))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer secret")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+		}
+		client := NewDAClient(url, true, WithAuthToken("secret"), WithRetryPolicy(NoRetryPolicy))
+		require.ErrorContains(t, client.SetCommitmentStatus(ctx, comm, Status{}), "unknown commitment state")
+	})
+
+	t.Run("StorageUnavailable", func(t *testing.T) {
+		_, url := startDAServer(t, failingStore{err: ErrStorageUnavailable}, WithStatusToken("secret"))
+		client := NewDAClient(url, true, WithAuthToken("secret"), WithRetryPolicy(NoRetryPolicy))
+		var srvErr *ServerError
+		require.ErrorAs(t, client.SetCommitmentStatus(ctx, comm, status), &srvErr)
+		require.Equal(t, http.StatusServiceUnavailable, srvErr.StatusCode)
+		_, err := client.GetCommitmentStatus(ctx, comm)
+		require.ErrorAs(t, err, &srvErr)
+	})
+
+	t.Run("CustomStore", func(t *testing.T) {
+		statuses := &memStatusStore{statuses: make(map[string]Status)}
+		_, url := startDAServer(t, newMemStore(), WithStatusStore(statuses), WithStatusToken("secret"))
+		client := NewDAClient(url, true, WithAuthToken("secret"), WithRetryPolicy(NoRetryPolicy))
+		require.NoError(t, client.SetCommitmentStatus(ctx, comm, status))
+		require.Equal(t, StateFinalized, statuses.statuses[string(comm.Encode())].State)
+	})
+}
+
+// memStatusStore is a StatusStore keeping the statuses in a map.
+type memStatusStore struct {
+	statuses map[string]Status
+}
+
+func (m *memStatusStore) GetStatus(ctx context.Context, key Commitment) (Status, error) {
+	status, ok := m.statuses[string(key.Encode())]
+	if !ok {
+		return Status{}, ErrNotFound
+	}
+	return status, nil
+}
+
+func (m *memStatusStore) SetStatus(ctx context.Context, key Commitment, status Status) error {
+	m.statuses[string(key.Encode())] = status
+	return nil
+}
+
+func TestStatusJSON(t *testing.T) {
+	status := Status{State: StateChallenged, SubmittedAt: time.Unix(100, 0), ChallengedAt: time.Unix(200, 0)}
+	data, err := json.Marshal(status)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"state":"challenged","submittedAt":100,"challengedAt":200}`, string(data))
+
+	var decoded Status
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, status, decoded)
+	require.Error(t, json.Unmarshal([]byte(`{"state":"unknown"}`), &decoded))
+	require.Equal(t, "unknown(0)", CommitmentState(0).String())
+}
@@ -0,0 +1,44 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrStorageUnavailable is wrapped by Storage implementations when the backend failed transiently,
+// e.g. it is unreachable or throttling requests. The DAServer responds with 503 so clients retry later.
+var ErrStorageUnavailable = errors.New("storage unavailable")
+
+// Storage is the storage backing a DAServer, keyed by commitment.
+// Implementations must treat the commitment as an opaque key: the server also stores upload chunks
+// under keys that are not valid commitments. Get and Delete return ErrNotFound when there is no value
+// for the key, and Put overwrites any existing value.
+type Storage interface {
+	Get(ctx context.Context, key Commitment) ([]byte, error)
+	Put(ctx context.Context, key Commitment, value []byte) error
+	Has(ctx context.Context, key Commitment) (bool, error)
+	Delete(ctx context.Context, key Commitment) error
+}
+
+// StreamStorage is implemented by Storage backends that can store a value read from a stream of the given size.
+// The DAServer uses it to store assembled chunked inputs without holding them in memory, and falls back to Put
+// for other backends. A failed PutStream must not leave a partial value behind.
+type StreamStorage interface {
+	Storage
+	PutStream(ctx context.Context, key Commitment, r io.Reader, size int64) error
+}
+
+// storageStatus returns the HTTP status reporting a failed storage operation, so all Storage
+// implementations are served consistently.
+func storageStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrStorageUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,165 @@
+// Package storagetest provides a conformance test suite for plasma.Storage implementations.
+package storagetest
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// Run tests the Storage semantics the DAServer relies on, and that the DAServer serves the
+// storage with the expected statuses. newStorage must return an empty storage.
+func Run(t *testing.T, newStorage func(t *testing.T) plasma.Storage) {
+	t.Run("GetPut", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStorage(t)
+		input := []byte("an input")
+		key := plasma.Keccak256(input)
+
+		_, err := store.Get(ctx, key)
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+		ok, err := store.Has(ctx, key)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		require.NoError(t, store.Put(ctx, key, input))
+		value, err := store.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, input, value)
+		ok, err = store.Has(ctx, key)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// values are overwritten
+		require.NoError(t, store.Put(ctx, key, []byte("another input")))
+		value, err = store.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, []byte("another input"), value)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStorage(t)
+		key := plasma.Keccak256([]byte("deleted"))
+		require.ErrorIs(t, store.Delete(ctx, key), plasma.ErrNotFound)
+
+		require.NoError(t, store.Put(ctx, key, []byte("deleted")))
+		require.NoError(t, store.Delete(ctx, key))
+		_, err := store.Get(ctx, key)
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+		ok, err := store.Has(ctx, key)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.ErrorIs(t, store.Delete(ctx, key), plasma.ErrNotFound)
+	})
+
+	t.Run("OpaqueKeys", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStorage(t)
+		hash := plasma.Keccak256([]byte("key")).Digest()
+		ipfs, err := plasma.NewCommitment(plasma.IPFSCommitmentType, []byte("key"))
+		require.NoError(t, err)
+		keys := []plasma.Commitment{
+			plasma.Keccak256([]byte("key")),
+			append(plasma.Commitment{byte(plasma.Sha256CommitmentType)}, hash...),
+			ipfs,
+			// upload chunks are stored under keys that are not commitments
+			append(plasma.Commitment("chunk:"), hash...),
+			// keys prefixing each other must not collide
+			plasma.Commitment{0x01},
+			plasma.Commitment{0x01, 0x02},
+		}
+		for i, key := range keys {
+			require.NoError(t, store.Put(ctx, key, []byte{byte(i)}))
+		}
+		for i, key := range keys {
+			value, err := store.Get(ctx, key)
+			require.NoError(t, err)
+			require.Equal(t, []byte{byte(i)}, value, "key %x", key)
+		}
+	})
+
+	t.Run("LargeValue", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStorage(t)
+		input := testutils.RandomData(rand.New(rand.NewSource(1234)), 4<<20)
+		key := plasma.Keccak256(input)
+		require.NoError(t, store.Put(ctx, key, input))
+		value, err := store.Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(input, value))
+	})
+
+	t.Run("PutStream", func(t *testing.T) {
+		ctx := context.Background()
+		store, ok := newStorage(t).(plasma.StreamStorage)
+		if !ok {
+			t.Skip("storage does not implement StreamStorage")
+		}
+		input := testutils.RandomData(rand.New(rand.NewSource(1234)), 1<<20)
+		key := plasma.Keccak256(input)
+		require.NoError(t, store.PutStream(ctx, key, bytes.NewReader(input), int64(len(input))))
+		value, err := store.Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(input, value))
+
+		// a stream shorter than announced leaves no partial value behind
+		short := plasma.Keccak256([]byte("short"))
+		require.Error(t, store.PutStream(ctx, short, bytes.NewReader(input[:100]), int64(len(input))))
+		ok, err = store.Has(ctx, short)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("Server", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStorage(t)
+		srv := httptest.NewServer(plasma.NewDAServer("", store, testlog.Logger(t, log.LevelDebug),
+			plasma.WithDeleteToken("secret")).Handler())
+		t.Cleanup(srv.Close)
+		client := plasma.NewDAClient(srv.URL, true, plasma.WithAuthToken("secret"),
+			plasma.WithChunkSize(1024), plasma.WithRetryPolicy(plasma.NoRetryPolicy))
+
+		input := []byte("served by the DA server")
+		comm, err := client.SetInput(ctx, input)
+		require.NoError(t, err)
+		value, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, value)
+		exists, err := client.Exists(ctx, comm)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		unknown := plasma.Keccak256([]byte("unknown"))
+		_, err = client.GetInput(ctx, unknown)
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+		exists, err = client.Exists(ctx, unknown)
+		require.NoError(t, err)
+		require.False(t, exists)
+		results, err := client.GetInputs(ctx, []plasma.Commitment{comm, unknown})
+		require.NoError(t, err)
+		require.Equal(t, input, results[0].Input)
+		require.ErrorIs(t, results[1].Err, plasma.ErrNotFound)
+
+		chunked := testutils.RandomData(rand.New(rand.NewSource(1234)), 10*1024+1)
+		chunkedComm, err := client.SetInputChunked(ctx, bytes.NewReader(chunked), int64(len(chunked)))
+		require.NoError(t, err)
+		value, err = client.GetInput(ctx, chunkedComm)
+		require.NoError(t, err)
+		require.Equal(t, chunked, value)
+
+		require.NoError(t, client.DeleteInput(ctx, comm))
+		_, err = client.GetInput(ctx, comm)
+		require.ErrorIs(t, err, plasma.ErrNotFound)
+		require.ErrorIs(t, client.DeleteInput(ctx, comm), plasma.ErrNotFound)
+	})
+}
@@ -0,0 +1,247 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SetInputStream uploads size bytes read from r to the server's /put route and returns their commitment,
+// computed incrementally while the body is streamed so the input is never held in memory.
+// The server responds with the commitment it computed, which must match the client's one. The /put route
+// always commits with keccak256, so other commitment types are rejected with ErrUnsupportedCommitment before
+// anything is sent. The bundled DAServer buffers /put bodies up to its max request size (DefaultMaxRequestSize
+// unless configured otherwise) and rejects larger ones with 413; use SetInputChunked for larger inputs.
+// Streamed uploads cannot be replayed and are therefore not retried. The upload times out once it made
+// no progress for the call timeout, rather than after the call timeout, so large inputs are not cut off.
+func (c *DAClient) SetInputStream(ctx context.Context, r io.Reader, size int64) (_ Commitment, err error) {
+	ctx, call := c.startCall(ctx, "SetInputStream", nil)
+	defer func() { call.end(err) }()
+	ctx, progress, end := c.withIdleTimeout(ctx, &err)
+	defer end()
+	if err := c.checkDARoutes(); err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return nil, ErrInvalidInput
+	}
+	if err := c.commType.checkSize(size); err != nil {
+		return nil, err
+	}
+	if c.commType != Keccak256CommitmentType {
+		return nil, fmt.Errorf("%w: streamed uploads only support %v commitments", ErrUnsupportedCommitment, Keccak256CommitmentType)
+	}
+	h, err := c.commType.hasher()
+	if err != nil {
+		return nil, err
+	}
+	counter := &countingWriter{}
+	body := &progressReader{r: io.TeeReader(io.LimitReader(r, size), io.MultiWriter(h, counter)), progress: progress}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/put", c.url), io.NopCloser(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to store input stream: %w", newStatusError(resp))
+	}
+	if counter.n != size {
+		return nil, fmt.Errorf("%w: input stream ended after %d of %d bytes", ErrInvalidInput, counter.n, size)
+	}
+	comm := append(Commitment{byte(c.commType)}, h.Sum(nil)...)
+	serverComm, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commitment from server: %w", err)
+	}
+	if !bytes.Equal(serverComm, comm.Encode()) {
+		return nil, fmt.Errorf("%w: server committed to %x, expected %x", ErrCommitmentMismatch, serverComm, comm.Encode())
+	}
+	c.metrics.RecordDABytesSent("SetInputStream", int(counter.n))
+	call.key, call.size = comm, int(counter.n)
+	return comm, nil
+}
+
+// GetInputReader returns a stream of the input for the given commitment and its size, or -1 if the
+// server did not announce it. The caller must close the reader.
+// If the client verifies on read, the commitment is checked incrementally: the final Read and Close
+// return ErrCommitmentMismatch when the streamed data does not match the commitment. Closing the
+// reader before it is fully consumed skips the verification. The bytes read are recorded on Close.
+// The stream times out once it made no progress for the call timeout, including while the caller does not read.
+// Custom verifiers need the whole input, so it is buffered while streamed and bound by the client's
+// maximum input size: Read returns a ResponseTooLargeError once the stream exceeds it.
+func (c *DAClient) GetInputReader(ctx context.Context, key Commitment) (_ io.ReadCloser, _ int64, err error) {
+	ctx, call := c.startCall(ctx, "GetInputReader", key)
+	defer func() { call.end(err) }()
+	// the stream is bounded by the idle timeout too, so the context is only released on Close
+	ctx, progress, end := c.withIdleTimeout(ctx, &err)
+	defer func() {
+		if err != nil {
+			end()
+		}
+	}()
+	if err := c.checkDARoutes(); err != nil {
+		return nil, 0, err
+	}
+	comm, err := DecodeCommitment(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := doEndpoint(ctx, c, c.endpoints[0], func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/get/0x%x", c.url, []byte(key)), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+			if resp.StatusCode == http.StatusNotFound {
+				return nil, ErrNotFound
+			}
+			return nil, newStatusError(resp)
+		}
+		return resp, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	call.size = int(max(resp.ContentLength, 0))
+	body := &meteredReader{body: resp.Body, m: c.metrics, method: "GetInputReader", end: end, progress: progress,
+		wrapErr: func(err error) error { return c.timeoutError(ctx, err) }}
+	if c.verifier == nil {
+		return body, resp.ContentLength, nil
+	}
+	if !c.hashOnRead() {
+		limit := int64(c.maxInputSize)
+		if limit != 0 && resp.ContentLength > limit {
+			body.Close()
+			return nil, 0, &ResponseTooLargeError{Limit: limit, Size: resp.ContentLength}
+		}
+		buf := &cappedBuffer{limit: limit}
+		check := func() error { return c.verifier(comm, buf.Bytes()) }
+		return &verifyingReader{body: body, w: buf, check: check}, resp.ContentLength, nil
+	}
+	h, err := comm.Type().hasher()
+	if err != nil {
+		body.Close()
+		return nil, 0, err
+	}
+	check := func() error {
+		if !bytes.Equal(h.Sum(nil), comm.Digest()) {
+			return ErrCommitmentMismatch
+		}
+		return nil
+	}
+	return &verifyingReader{body: body, w: h, check: check}, resp.ContentLength, nil
+}
+
+// meteredReader counts the bytes read from body and records them as received on Close.
+// Read errors other than io.EOF are converted with wrapErr, progress is called after reads returning data
+// and end is called once closed, if set.
+type meteredReader struct {
+	body     io.ReadCloser
+	m        Metricer
+	method   string
+	wrapErr  func(error) error
+	progress func()
+	end      func()
+	n        int
+	closed   bool
+}
+
+func (r *meteredReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.n += n
+	if n > 0 && r.progress != nil {
+		r.progress()
+	}
+	if err != nil && err != io.EOF && r.wrapErr != nil {
+		err = r.wrapErr(err)
+	}
+	return n, err
+}
+
+func (r *meteredReader) Close() error {
+	if r.closed {
+		return r.body.Close()
+	}
+	r.closed = true
+	r.m.RecordDABytesReceived(r.method, r.n)
+	err := r.body.Close()
+	if r.end != nil {
+		r.end()
+	}
+	return err
+}
+
+// verifyingReader copies everything read from body to w and verifies it with check at EOF.
+// A failed write to w ends the stream with the write error.
+type verifyingReader struct {
+	body  io.ReadCloser
+	w     io.Writer
+	check func() error
+	// done is set once the body returned EOF, err is the result of the verification.
+	done bool
+	err  error
+}
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	if v.done {
+		return 0, v.eofErr()
+	}
+	n, err := v.body.Read(p)
+	if _, werr := v.w.Write(p[:n]); werr != nil {
+		v.done, v.err = true, werr
+		return n, werr
+	}
+	if errors.Is(err, io.EOF) {
+		v.done = true
+		v.err = v.check()
+		return n, v.eofErr()
+	}
+	return n, err
+}
+
+func (v *verifyingReader) eofErr() error {
+	if v.err != nil {
+		return v.err
+	}
+	return io.EOF
+}
+
+func (v *verifyingReader) Close() error {
+	return errors.Join(v.body.Close(), v.err)
+}
+
+// cappedBuffer is a bytes.Buffer that fails writes exceeding limit bytes in total, 0 for no limit.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit int64
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.limit != 0 && int64(b.Len()+len(p)) > b.limit {
+		return 0, &ResponseTooLargeError{Limit: b.limit, Size: -1}
+	}
+	return b.Buffer.Write(p)
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
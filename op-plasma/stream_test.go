@@ -0,0 +1,159 @@
+package plasma
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// synthSize is the size of the synthetic stream, large enough that buffering it would show up
+// clearly in the allocation statistics.
+const synthSize = 64 << 20
+
+// synthReader returns a deterministic pseudo random stream of the given size.
+func synthReader(size int64) io.Reader {
+	return io.LimitReader(rand.New(rand.NewSource(42)), size)
+}
+
+// synthCommitment computes the keccak256 commitment of the synthetic stream without buffering it.
+func synthCommitment(t *testing.T, size int64) Commitment {
+	h := sha3.NewLegacyKeccak256()
+	_, err := io.Copy(h, synthReader(size))
+	require.NoError(t, err)
+	return append(Commitment{byte(Keccak256CommitmentType)}, h.Sum(nil)...)
+}
+
+// newStreamTestServer hashes uploads on /put without buffering them and serves the
+// synthetic stream of the given size on /get/.
+func newStreamTestServer(t *testing.T, size int64, corrupt bool) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/put", func(w http.ResponseWriter, r *http.Request) {
+		h := sha3.NewLegacyKeccak256()
+		if _, err := io.Copy(h, r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write(append([]byte{byte(Keccak256CommitmentType)}, h.Sum(nil)...))
+	})
+	mux.HandleFunc("/get/", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := hexutil.Decode(r.URL.Path[len("/get/"):]); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		if corrupt {
+			_, _ = io.Copy(w, synthReader(size-1))
+			_, _ = w.Write([]byte{0xff})
+			return
+		}
+		_, _ = io.Copy(w, synthReader(size))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func totalAlloc() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.TotalAlloc
+}
+
+func TestDAClientStreaming(t *testing.T) {
+	ctx := context.Background()
+	expected := synthCommitment(t, synthSize)
+
+	t.Run("Upload", func(t *testing.T) {
+		srv := newStreamTestServer(t, synthSize, false)
+		client := NewDAClient(srv.URL, true)
+
+		before := totalAlloc()
+		comm, err := client.SetInputStream(ctx, synthReader(synthSize), synthSize)
+		require.NoError(t, err)
+		require.Equal(t, expected, comm)
+		allocated := totalAlloc() - before
+		require.Less(t, allocated, uint64(synthSize/4), fmt.Sprintf("allocated %d bytes", allocated))
+	})
+
+	t.Run("UploadShortStream", func(t *testing.T) {
+		srv := newStreamTestServer(t, synthSize, false)
+		client := NewDAClient(srv.URL, true)
+		_, err := client.SetInputStream(ctx, synthReader(100), 200)
+		require.Error(t, err)
+	})
+
+	t.Run("UploadServerMismatch", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			_, _ = w.Write(append([]byte{byte(Keccak256CommitmentType)}, make([]byte, 32)...))
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true)
+		_, err := client.SetInputStream(ctx, synthReader(1000), 1000)
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+	})
+
+	t.Run("UploadUnsupportedCommitment", func(t *testing.T) {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+		}))
+		t.Cleanup(srv.Close)
+		client := NewDAClient(srv.URL, true, WithCommitmentType(Sha256CommitmentType))
+		_, err := client.SetInputStream(ctx, synthReader(1000), 1000)
+		require.ErrorIs(t, err, ErrUnsupportedCommitment)
+		require.Zero(t, requests)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		srv := newStreamTestServer(t, synthSize, false)
+		client := NewDAClient(srv.URL, true)
+
+		before := totalAlloc()
+		r, size, err := client.GetInputReader(ctx, expected)
+		require.NoError(t, err)
+		require.EqualValues(t, synthSize, size)
+		n, err := io.Copy(io.Discard, r)
+		require.NoError(t, err)
+		require.EqualValues(t, synthSize, n)
+		require.NoError(t, r.Close())
+		allocated := totalAlloc() - before
+		require.Less(t, allocated, uint64(synthSize/4), fmt.Sprintf("allocated %d bytes", allocated))
+	})
+
+	t.Run("DownloadMismatch", func(t *testing.T) {
+		srv := newStreamTestServer(t, 1<<20, true)
+		client := NewDAClient(srv.URL, true)
+
+		r, _, err := client.GetInputReader(ctx, synthCommitment(t, 1<<20))
+		require.NoError(t, err)
+		_, err = io.Copy(io.Discard, r)
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+		require.ErrorIs(t, r.Close(), ErrCommitmentMismatch)
+	})
+
+	t.Run("DownloadOtherCommitmentType", func(t *testing.T) {
+		srv := newStreamTestServer(t, 1000, false)
+		client := NewDAClient(srv.URL, true)
+
+		h := sha256.New()
+		_, err := io.Copy(h, synthReader(1000))
+		require.NoError(t, err)
+		r, _, err := client.GetInputReader(ctx, append(Commitment{byte(Sha256CommitmentType)}, h.Sum(nil)...))
+		require.NoError(t, err)
+		_, err = io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+	})
+}
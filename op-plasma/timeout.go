@@ -0,0 +1,85 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultCallTimeout bounds every DAClient call made with a context without deadline, unless configured
+// otherwise with WithCallTimeout. It covers all retries, failover and mirroring of the call.
+// Transfers whose duration depends on the input size are bounded differently: streams time out once they
+// made no progress for the call timeout, and every request of a chunked upload gets its own call timeout.
+const DefaultCallTimeout = 2 * time.Minute
+
+// ErrTimeout is returned when a call exceeded the client's default call timeout. Unlike a deadline set by
+// the caller, it is a transient failure and the call can be retried.
+var ErrTimeout = errors.New("DA call timed out")
+
+// WithCallTimeout sets the timeout applied to calls made with a context without deadline.
+// A deadline set by the caller is always left alone, shorter or longer. Zero disables the default timeout.
+func WithCallTimeout(timeout time.Duration) DAClientOption {
+	return func(c *DAClient) {
+		c.callTimeout = timeout
+	}
+}
+
+// withCallTimeout bounds ctx with the default call timeout if it has no deadline. The returned function
+// must be deferred by the call: it releases the context and converts the error pointed to by errp
+// with timeoutError.
+func (c *DAClient) withCallTimeout(ctx context.Context, errp *error) (context.Context, func()) {
+	if _, ok := ctx.Deadline(); ok || c.callTimeout <= 0 {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, c.callTimeout, ErrTimeout)
+	return ctx, func() {
+		*errp = c.timeoutError(ctx, *errp)
+		cancel()
+	}
+}
+
+// timeoutError wraps err with ErrTimeout if ctx expired because of the default call timeout.
+func (c *DAClient) timeoutError(ctx context.Context, err error) error {
+	if err == nil || errors.Is(err, ErrTimeout) || !errors.Is(context.Cause(ctx), ErrTimeout) {
+		return err
+	}
+	return fmt.Errorf("%w after %v: %w", ErrTimeout, c.callTimeout, err)
+}
+
+// withIdleTimeout is like withCallTimeout, except that the timeout restarts on every call to the returned
+// progress function. The returned end function must be deferred by the call.
+func (c *DAClient) withIdleTimeout(ctx context.Context, errp *error) (_ context.Context, progress func(), end func()) {
+	if _, ok := ctx.Deadline(); ok || c.callTimeout <= 0 {
+		return ctx, func() {}, func() {}
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	timer := time.AfterFunc(c.callTimeout, func() { cancel(ErrTimeout) })
+	return ctx, func() { timer.Reset(c.callTimeout) }, func() {
+		timer.Stop()
+		*errp = c.timeoutError(ctx, *errp)
+		cancel(context.Canceled)
+	}
+}
+
+// callWithTimeout runs fn bounded by the call timeout, for the steps of calls that are not bound as a whole.
+func (c *DAClient) callWithTimeout(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, end := c.withCallTimeout(ctx, &err)
+	defer end()
+	return fn(ctx)
+}
+
+// progressReader calls progress after every read returning data.
+type progressReader struct {
+	r        io.Reader
+	progress func()
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.progress()
+	}
+	return n, err
+}
@@ -0,0 +1,217 @@
+package plasma
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// newHangingServer returns a server that only responds once the request is canceled or the test ends.
+func newHangingServer(t *testing.T) *httptest.Server {
+	stop := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-stop:
+		}
+	}))
+	t.Cleanup(func() {
+		close(stop)
+		srv.Close()
+	})
+	return srv
+}
+
+func TestDAClientCallTimeout(t *testing.T) {
+	input := []byte("an input")
+	comm := Keccak256(input)
+	calls := map[string]func(ctx context.Context, c *DAClient) error{
+		"GetInput": func(ctx context.Context, c *DAClient) error {
+			_, err := c.GetInput(ctx, comm)
+			return err
+		},
+		"Exists": func(ctx context.Context, c *DAClient) error {
+			_, err := c.Exists(ctx, comm)
+			return err
+		},
+		"SetInput": func(ctx context.Context, c *DAClient) error {
+			_, err := c.SetInput(ctx, input)
+			return err
+		},
+		"SetInputWithCommitment": func(ctx context.Context, c *DAClient) error {
+			return c.SetInputWithCommitment(ctx, input, comm)
+		},
+		"DeleteInput": func(ctx context.Context, c *DAClient) error {
+			return c.DeleteInput(ctx, comm)
+		},
+		"GetInputs": func(ctx context.Context, c *DAClient) error {
+			_, err := c.GetInputs(ctx, []Commitment{comm})
+			return err
+		},
+		"SetInputs": func(ctx context.Context, c *DAClient) error {
+			_, err := c.SetInputs(ctx, [][]byte{input})
+			return err
+		},
+		"SetInputStream": func(ctx context.Context, c *DAClient) error {
+			_, err := c.SetInputStream(ctx, bytes.NewReader(input), int64(len(input)))
+			return err
+		},
+		"SetInputChunked": func(ctx context.Context, c *DAClient) error {
+			_, err := c.SetInputChunked(ctx, bytes.NewReader(input), int64(len(input)))
+			return err
+		},
+		"GetInputReader": func(ctx context.Context, c *DAClient) error {
+			_, _, err := c.GetInputReader(ctx, comm)
+			return err
+		},
+	}
+	srv := newHangingServer(t)
+
+	t.Run("Default", func(t *testing.T) {
+		client := NewDAClient(srv.URL, true, WithCallTimeout(50*time.Millisecond), WithRetryPolicy(NoRetryPolicy))
+		for name, call := range calls {
+			err := call(context.Background(), client)
+			require.ErrorIs(t, err, ErrTimeout, name)
+			require.True(t, isRetryable(err), name)
+			require.Equal(t, ErrorClassTimeout, ErrorClass(err), name)
+		}
+	})
+
+	t.Run("ShorterCallerDeadline", func(t *testing.T) {
+		client := NewDAClient(srv.URL, true, WithCallTimeout(time.Minute), WithRetryPolicy(NoRetryPolicy))
+		for name, call := range calls {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			start := time.Now()
+			err := call(ctx, client)
+			cancel()
+			require.ErrorIs(t, err, context.DeadlineExceeded, name)
+			require.NotErrorIs(t, err, ErrTimeout, name)
+			require.Less(t, time.Since(start), 5*time.Second, name)
+		}
+	})
+
+	t.Run("LongerCallerDeadline", func(t *testing.T) {
+		// the server responds after the default timeout, but within the caller's deadline
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write(input)
+		}))
+		t.Cleanup(slow.Close)
+		client := NewDAClient(slow.URL, true, WithCallTimeout(50*time.Millisecond), WithRetryPolicy(NoRetryPolicy))
+
+		_, err := client.GetInput(context.Background(), comm)
+		require.ErrorIs(t, err, ErrTimeout)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		data, err := client.GetInput(ctx, comm)
+		require.NoError(t, err)
+		require.Equal(t, input, data)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		client := NewDAClient(srv.URL, true, WithCallTimeout(0), WithRetryPolicy(NoRetryPolicy))
+		ctx, cancel := context.WithCancel(context.Background())
+		errc := make(chan error, 1)
+		go func() {
+			_, err := client.GetInput(ctx, comm)
+			errc <- err
+		}()
+		select {
+		case err := <-errc:
+			t.Fatalf("call returned without timeout: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		cancel()
+		require.ErrorIs(t, <-errc, context.Canceled)
+	})
+
+	t.Run("Transfers", func(t *testing.T) {
+		// streamed and chunked transfers outlast the call timeout as long as they make progress
+		const pieces = 5
+		const delay = 40 * time.Millisecond
+		data := bytes.Repeat([]byte{0x01}, pieces*1024)
+		dataComm := Keccak256(data)
+		handler := NewDAServer("", newMemStore(), testlog.Logger(t, log.LevelDebug)).Handler()
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasPrefix(r.URL.Path, "/put_chunk/"):
+				time.Sleep(delay)
+			case strings.HasPrefix(r.URL.Path, "/get/"):
+				w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+				for i := 0; i < pieces; i++ {
+					time.Sleep(delay)
+					_, _ = w.Write(data[i*1024 : (i+1)*1024])
+					w.(http.Flusher).Flush()
+				}
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(slow.Close)
+		client := NewDAClient(slow.URL, true, WithCallTimeout(100*time.Millisecond), WithRetryPolicy(NoRetryPolicy),
+			WithChunkSize(1024), WithUploadParallelism(1))
+
+		comm, err := client.SetInputChunked(context.Background(), bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Equal(t, dataComm, comm)
+
+		comm, err = client.SetInputStream(context.Background(), &slowReader{r: bytes.NewReader(data), delay: delay}, int64(len(data)))
+		require.NoError(t, err)
+		require.Equal(t, dataComm, comm)
+
+		r, _, err := client.GetInputReader(context.Background(), dataComm)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		require.Equal(t, data, got)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		// the stream returned by GetInputReader times out once it stalls for the call timeout
+		stop := make(chan struct{})
+		stalling := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", "100")
+			_, _ = w.Write(input)
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-stop:
+			}
+		}))
+		t.Cleanup(func() {
+			close(stop)
+			stalling.Close()
+		})
+		client := NewDAClient(stalling.URL, false, WithCallTimeout(100*time.Millisecond), WithRetryPolicy(NoRetryPolicy))
+		r, _, err := client.GetInputReader(context.Background(), comm)
+		require.NoError(t, err)
+		defer r.Close()
+		_, err = io.ReadAll(r)
+		require.ErrorIs(t, err, ErrTimeout)
+		require.NotErrorIs(t, err, ErrCommitmentMismatch)
+	})
+}
+
+// slowReader reads at most 1024 bytes at a time from r, waiting delay before every read.
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(r.delay)
+	return r.r.Read(p[:min(len(p), 1024)])
+}
@@ -0,0 +1,113 @@
+package plasma
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name of the tracer creating the DAClient spans.
+const TracerName = "github.com/ethereum-optimism/optimism/op-plasma"
+
+// Span attributes recorded for every DAClient call.
+const (
+	AttrMethod     = attribute.Key("da.method")
+	AttrCommitment = attribute.Key("da.commitment")
+	AttrSize       = attribute.Key("da.size")
+	AttrStatus     = attribute.Key("da.status")
+)
+
+// StatusSuccess is the status recorded for successful calls. Failed calls record their ErrorClass.
+const StatusSuccess = "success"
+
+// WithLogger logs every call of the client with its method, commitment, size, status and duration.
+// Successful calls are logged at debug level and failed calls at warn level. Calls are not logged by default.
+func WithLogger(logger log.Logger) DAClientOption {
+	return func(c *DAClient) {
+		c.log = logger
+	}
+}
+
+// WithTracerProvider creates an OpenTelemetry span for every call of the client, as child of the span of
+// the call context, with the attributes also logged by WithLogger. No spans are created by default.
+func WithTracerProvider(provider trace.TracerProvider) DAClientOption {
+	return func(c *DAClient) {
+		c.tracer = provider.Tracer(TracerName)
+	}
+}
+
+// call records a public DAClient call in the metrics, and in the log and a span if enabled.
+type call struct {
+	c      *DAClient
+	method string
+	start  time.Time
+	done   func(error)
+	span   trace.Span
+	// key is the commitment the call is about, if any.
+	key Commitment
+	// size is the number of input bytes sent or received by the call.
+	size int
+}
+
+// startCall starts recording the call of method. The call must end with the error returned to the caller.
+func (c *DAClient) startCall(ctx context.Context, method string, key Commitment) (context.Context, *call) {
+	cl := &call{c: c, method: method, start: time.Now(), done: c.metrics.RecordDARequest(method), key: key}
+	if c.tracer != nil {
+		ctx, cl.span = c.tracer.Start(ctx, "DAClient."+method, trace.WithSpanKind(trace.SpanKindClient))
+	}
+	return ctx, cl
+}
+
+func (cl *call) end(err error) {
+	cl.done(err)
+	if cl.span == nil && cl.c.log == nil {
+		return
+	}
+	status := StatusSuccess
+	if err != nil {
+		status = ErrorClass(err)
+	}
+	if cl.span != nil {
+		attrs := []attribute.KeyValue{AttrMethod.String(cl.method), AttrStatus.String(status)}
+		if cl.key != nil {
+			attrs = append(attrs, AttrCommitment.String(shortCommitment(cl.key)))
+		}
+		if cl.size > 0 {
+			attrs = append(attrs, AttrSize.Int(cl.size))
+		}
+		cl.span.SetAttributes(attrs...)
+		if err != nil {
+			cl.span.RecordError(err)
+			cl.span.SetStatus(codes.Error, status)
+		}
+		cl.span.End()
+	}
+	if cl.c.log != nil {
+		ctx := []any{"method", cl.method, "status", status, "duration", time.Since(cl.start)}
+		if cl.key != nil {
+			ctx = append(ctx, "commitment", shortCommitment(cl.key))
+		}
+		if cl.size > 0 {
+			ctx = append(ctx, "size", cl.size)
+		}
+		if err != nil {
+			cl.c.log.Warn("DA request failed", append(ctx, "err", err)...)
+		} else {
+			cl.c.log.Debug("DA request", ctx...)
+		}
+	}
+}
+
+// shortCommitment returns the start and end of the hex encoded commitment, enough to identify it in logs.
+func shortCommitment(key Commitment) string {
+	data := key.Encode()
+	if len(data) <= 8 {
+		return fmt.Sprintf("0x%x", data)
+	}
+	return fmt.Sprintf("0x%x..%x", data[:5], data[len(data)-3:])
+}
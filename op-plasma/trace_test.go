@@ -0,0 +1,103 @@
+package plasma
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestDAClientTracing(t *testing.T) {
+	ctx := context.Background()
+	_, url := startDAServer(t, newMemStore())
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+	client := NewDAClient(url, true, WithTracerProvider(provider), WithLogger(logger), WithRetryPolicy(NoRetryPolicy))
+
+	input := []byte("traced input")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+	_, err = client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	missing := Keccak256([]byte("missing"))
+	_, err = client.GetInput(ctx, missing)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for i, expected := range []struct {
+		name   string
+		key    Commitment
+		size   int64
+		status string
+	}{
+		{name: "SetInput", key: comm, size: int64(len(input)), status: StatusSuccess},
+		{name: "GetInput", key: comm, size: int64(len(input)), status: StatusSuccess},
+		{name: "GetInput", key: missing, status: ErrorClassNotFound},
+	} {
+		span := spans[i]
+		require.Equal(t, "DAClient."+expected.name, span.Name())
+		attrs := spanAttrs(span)
+		require.Equal(t, expected.name, attrs[AttrMethod].AsString())
+		require.Equal(t, shortCommitment(expected.key), attrs[AttrCommitment].AsString())
+		require.Equal(t, expected.size, attrs[AttrSize].AsInt64())
+		require.Equal(t, expected.status, attrs[AttrStatus].AsString())
+	}
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+	require.Equal(t, codes.Error, spans[2].Status().Code)
+	require.Len(t, spans[2].Events(), 1, "the error is recorded on the span")
+
+	debug := logs.FindLogs(testlog.NewLevelFilter(log.LevelDebug), testlog.NewMessageFilter("DA request"))
+	require.Len(t, debug, 2)
+	require.Equal(t, "SetInput", debug[0].AttrValue("method"))
+	require.Equal(t, shortCommitment(comm), debug[0].AttrValue("commitment"))
+	require.EqualValues(t, len(input), debug[0].AttrValue("size"))
+	require.Equal(t, StatusSuccess, debug[0].AttrValue("status"))
+	require.NotNil(t, debug[0].AttrValue("duration"))
+
+	warn := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("DA request failed"))
+	require.NotNil(t, warn)
+	require.Equal(t, "GetInput", warn.AttrValue("method"))
+	require.Equal(t, ErrorClassNotFound, warn.AttrValue("status"))
+	require.NotNil(t, warn.AttrValue("err"))
+}
+
+func TestDAClientTracingParentSpan(t *testing.T) {
+	_, url := startDAServer(t, newMemStore())
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := NewDAClient(url, true, WithTracerProvider(provider))
+
+	// calls are traced as children of the span of the call context
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "derive")
+	_, err := client.SetInput(ctx, []byte("child"))
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestShortCommitment(t *testing.T) {
+	comm := Keccak256([]byte("input"))
+	short := shortCommitment(comm)
+	require.Len(t, short, len("0x")+10+len("..")+6)
+	require.Equal(t, "0x0102", shortCommitment(Commitment{0x01, 0x02}))
+}
@@ -0,0 +1,49 @@
+package plasma
+
+import (
+	"fmt"
+)
+
+// Verifier checks the data read from a DA server against the commitment it was requested with.
+// It returns ErrCommitmentMismatch, possibly wrapped, if the data does not match, in which case
+// the data is neither returned nor cached by the client.
+type Verifier func(comm Commitment, data []byte) error
+
+// VerifyCommitment is the default Verifier: it recomputes the commitment of the data with the
+// commitment type of comm.
+func VerifyCommitment(comm Commitment, data []byte) error {
+	return comm.Verify(data)
+}
+
+// VerifyKeccak256 is a Verifier only accepting keccak256 commitments.
+func VerifyKeccak256(comm Commitment, data []byte) error {
+	return verifyType(Keccak256CommitmentType, comm, data)
+}
+
+// VerifySha256 is a Verifier only accepting sha256 commitments.
+func VerifySha256(comm Commitment, data []byte) error {
+	return verifyType(Sha256CommitmentType, comm, data)
+}
+
+// hashOnRead reports whether the data read is verified by hashing it while it is read, which the client
+// does for the default verifier.
+func (c *DAClient) hashOnRead() bool {
+	return c.verifier != nil && !c.customVerifier
+}
+
+func verifyType(t CommitmentType, comm Commitment, data []byte) error {
+	if comm.Type() != t {
+		return fmt.Errorf("%w: expected %v commitment, got %v", ErrUnsupportedCommitment, t, comm.Type())
+	}
+	return comm.Verify(data)
+}
+
+// WithVerifier sets the Verifier checking all data read by the client, including batched and streaming reads,
+// regardless of the verify argument of NewDAClient. Streaming reads buffer the stream to verify it at EOF.
+// A nil verifier disables verification.
+func WithVerifier(v Verifier) DAClientOption {
+	return func(c *DAClient) {
+		c.verifier = v
+		c.customVerifier = true
+	}
+}
@@ -0,0 +1,153 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingVerifier is a custom Verifier recording the data it was given, rejecting data equal to reject.
+type recordingVerifier struct {
+	mu     sync.Mutex
+	seen   []string
+	reject string
+}
+
+func (v *recordingVerifier) verify(comm Commitment, data []byte) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.seen = append(v.seen, string(data))
+	if string(data) == v.reject {
+		return errors.New("rejected by custom verifier")
+	}
+	return nil
+}
+
+func TestDAClientCustomVerifier(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	_, url := startDAServer(t, store)
+	accepted, rejected := []byte("accepted"), []byte("rejected")
+	acceptedComm, rejectedComm := Keccak256(accepted), Keccak256(rejected)
+	writer := NewDAClient(url, false)
+	require.NoError(t, writer.SetInputWithCommitment(ctx, accepted, acceptedComm))
+	require.NoError(t, writer.SetInputWithCommitment(ctx, rejected, rejectedComm))
+
+	newClient := func(opts ...DAClientOption) (*DAClient, *recordingVerifier) {
+		v := &recordingVerifier{reject: string(rejected)}
+		opts = append(opts, WithVerifier(v.verify), WithRetryPolicy(NoRetryPolicy))
+		return NewDAClient(url, false, opts...), v
+	}
+
+	t.Run("GetInput", func(t *testing.T) {
+		client, v := newClient(WithCache(1024))
+		got, err := client.GetInput(ctx, acceptedComm)
+		require.NoError(t, err)
+		require.Equal(t, accepted, got)
+		_, err = client.GetInput(ctx, rejectedComm)
+		require.ErrorContains(t, err, "rejected by custom verifier")
+		require.Equal(t, []string{"accepted", "rejected"}, v.seen)
+
+		// rejected data is not cached: the verifier is consulted again
+		_, err = client.GetInput(ctx, rejectedComm)
+		require.Error(t, err)
+		require.Equal(t, []string{"accepted", "rejected", "rejected"}, v.seen)
+		_, err = client.GetInput(ctx, acceptedComm)
+		require.NoError(t, err)
+		require.Len(t, v.seen, 3, "verified data is served from the cache")
+	})
+
+	t.Run("GetInputs", func(t *testing.T) {
+		client, v := newClient()
+		results, err := client.GetInputs(ctx, []Commitment{acceptedComm, rejectedComm})
+		require.NoError(t, err)
+		require.Equal(t, accepted, results[0].Input)
+		require.Nil(t, results[1].Input)
+		require.ErrorContains(t, results[1].Err, "rejected by custom verifier")
+		require.ElementsMatch(t, []string{"accepted", "rejected"}, v.seen)
+	})
+
+	t.Run("GetInputReader", func(t *testing.T) {
+		client, v := newClient()
+		r, _, err := client.GetInputReader(ctx, acceptedComm)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		require.Equal(t, accepted, got)
+
+		r, _, err = client.GetInputReader(ctx, rejectedComm)
+		require.NoError(t, err)
+		_, err = io.ReadAll(r)
+		require.ErrorContains(t, err, "rejected by custom verifier")
+		require.Error(t, r.Close())
+		require.Equal(t, []string{"accepted", "rejected"}, v.seen)
+	})
+
+	t.Run("GetInputReaderTooLarge", func(t *testing.T) {
+		// the input is buffered for the verifier, so it is bound by the maximum input size
+		client, v := newClient(WithMaxInputSize(len(accepted) - 1))
+		_, _, err := client.GetInputReader(ctx, acceptedComm)
+		var tooLarge *ResponseTooLargeError
+		require.ErrorAs(t, err, &tooLarge)
+		require.EqualValues(t, len(accepted), tooLarge.Size)
+
+		// without an announced size the stream is cut off once it exceeds the limit
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(accepted[:4])
+			w.(http.Flusher).Flush()
+			_, _ = w.Write(accepted[4:])
+		}))
+		t.Cleanup(srv.Close)
+		client = NewDAClient(srv.URL, false, WithVerifier(v.verify), WithMaxInputSize(len(accepted)-1))
+		r, size, err := client.GetInputReader(ctx, acceptedComm)
+		require.NoError(t, err)
+		require.EqualValues(t, -1, size)
+		_, err = io.ReadAll(r)
+		require.ErrorAs(t, err, &tooLarge)
+		require.EqualValues(t, -1, tooLarge.Size)
+		require.ErrorIs(t, r.Close(), ErrInputTooLarge)
+		require.Empty(t, v.seen)
+	})
+
+	t.Run("OverridesDefault", func(t *testing.T) {
+		// the custom verifier replaces the commitment check enabled by NewDAClient
+		require.NoError(t, store.Put(ctx, acceptedComm, []byte("tampered")))
+		t.Cleanup(func() { require.NoError(t, store.Put(ctx, acceptedComm, accepted)) })
+		client := NewDAClient(url, true, WithVerifier(func(Commitment, []byte) error { return nil }))
+		got, err := client.GetInput(ctx, acceptedComm)
+		require.NoError(t, err)
+		require.Equal(t, []byte("tampered"), got)
+
+		_, err = NewDAClient(url, true).GetInput(ctx, acceptedComm)
+		require.ErrorIs(t, err, ErrCommitmentMismatch)
+		got, err = NewDAClient(url, true, WithVerifier(nil)).GetInput(ctx, acceptedComm)
+		require.NoError(t, err, "a nil verifier disables verification")
+		require.Equal(t, []byte("tampered"), got)
+	})
+}
+
+func TestBuiltinVerifiers(t *testing.T) {
+	input := []byte("input")
+	sha, err := NewCommitment(Sha256CommitmentType, input)
+	require.NoError(t, err)
+	keccak := Keccak256(input)
+
+	require.NoError(t, VerifyKeccak256(keccak, input))
+	require.ErrorIs(t, VerifyKeccak256(keccak, []byte("other")), ErrCommitmentMismatch)
+	require.ErrorIs(t, VerifyKeccak256(sha, input), ErrUnsupportedCommitment)
+
+	require.NoError(t, VerifySha256(sha, input))
+	require.ErrorIs(t, VerifySha256(sha, []byte("other")), ErrCommitmentMismatch)
+	require.ErrorIs(t, VerifySha256(keccak, input), ErrUnsupportedCommitment)
+
+	require.NoError(t, VerifyCommitment(sha, input))
+	require.NoError(t, VerifyCommitment(keccak, input))
+	require.ErrorIs(t, VerifyCommitment(keccak, []byte("other")), ErrCommitmentMismatch)
+}
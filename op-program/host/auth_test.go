@@ -0,0 +1,39 @@
+package host
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestHTTPAuthToken(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	cfg := &config.Config{APIAuthToken: "s3cret"}
+	srv := httptest.NewServer(newHTTPHandler(logger, cfg, kvstore.NewMemKV().Get, func(string) error { return nil }))
+	t.Cleanup(srv.Close)
+
+	sendHint := func(t *testing.T, authorization string) int {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/hint/"+l1.HintL1BlockHeader+"%200x00", nil)
+		require.NoError(t, err)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, sendHint(t, "Bearer s3cret"))
+	require.Equal(t, http.StatusUnauthorized, sendHint(t, ""))
+	require.Equal(t, http.StatusUnauthorized, sendHint(t, "Bearer wrong"))
+	require.Equal(t, http.StatusUnauthorized, sendHint(t, "s3cret"))
+}
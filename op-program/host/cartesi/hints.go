@@ -0,0 +1,30 @@
+package cartesi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// NewHintHandler returns the handler of HintMachinePage hints, which puts the requested page from the source
+// as keccak256 pre-image. Pages that do not match their hash are rejected.
+func NewHintHandler(source PageSource) func(ctx context.Context, hintBytes []byte, put func(key common.Hash, value []byte) error) error {
+	return func(ctx context.Context, hintBytes []byte, put func(key common.Hash, value []byte) error) error {
+		if len(hintBytes) != common.HashLength {
+			return fmt.Errorf("invalid machine page hint: %x", hintBytes)
+		}
+		hash := common.Hash(hintBytes)
+		page, err := source.Page(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if actual := crypto.Keccak256Hash(page); actual != hash {
+			return fmt.Errorf("machine page %s has hash %s", hash, actual)
+		}
+		return put(preimage.Keccak256Key(hash).PreimageKey(), page)
+	}
+}
@@ -0,0 +1,38 @@
+package cartesi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func TestHintHandler(t *testing.T) {
+	dir := t.TempDir()
+	page := []byte("machine page")
+	hash := crypto.Keccak256Hash(page)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash.Hex()), page, 0o644))
+	wrongHash := common.Hash{0xbb}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, wrongHash.Hex()), page, 0o644))
+	handler := NewHintHandler(NewSnapshotPageSource(dir))
+
+	stored := make(map[common.Hash][]byte)
+	put := func(key common.Hash, value []byte) error {
+		stored[key] = value
+		return nil
+	}
+
+	require.NoError(t, handler(context.Background(), hash.Bytes(), put))
+	require.Equal(t, map[common.Hash][]byte{preimage.Keccak256Key(hash).PreimageKey(): page}, stored)
+
+	require.ErrorContains(t, handler(context.Background(), wrongHash.Bytes(), put), "has hash")
+	require.ErrorIs(t, handler(context.Background(), common.Hash{0xaa}.Bytes(), put), ErrPageNotFound)
+	require.ErrorContains(t, handler(context.Background(), []byte{1, 2, 3}, put), "invalid machine page hint")
+	require.Len(t, stored, 1)
+}
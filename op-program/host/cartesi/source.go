@@ -0,0 +1,78 @@
+package cartesi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// HintMachinePage is the hint type requesting the machine page with the keccak256 hash in the hint.
+const HintMachinePage = "cartesi-machine-page"
+
+// ErrPageNotFound is returned when a page source does not have the requested page.
+var ErrPageNotFound = errors.New("machine page not found")
+
+// PageSource provides the pages of Cartesi machines by their keccak256 hash.
+type PageSource interface {
+	Page(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// HTTPPageSource gets pages from a machine server, at /pages/ followed by the hex encoded hash.
+type HTTPPageSource struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPPageSource(url string, client *http.Client) *HTTPPageSource {
+	return &HTTPPageSource{url: strings.TrimSuffix(url, "/"), client: client}
+}
+
+func (s *HTTPPageSource) Page(ctx context.Context, hash common.Hash) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/pages/%s", s.url, hash.Hex()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request page %s: %w", hash, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPageNotFound, hash)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get page %s: status %d", hash, resp.StatusCode)
+	}
+	page, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page %s: %w", hash, err)
+	}
+	return page, nil
+}
+
+// SnapshotPageSource reads pages from a snapshot directory, where every page is a file named by its hex encoded hash.
+type SnapshotPageSource struct {
+	dir string
+}
+
+func NewSnapshotPageSource(dir string) *SnapshotPageSource {
+	return &SnapshotPageSource{dir: dir}
+}
+
+func (s *SnapshotPageSource) Page(_ context.Context, hash common.Hash) ([]byte, error) {
+	page, err := os.ReadFile(filepath.Join(s.dir, hash.Hex()))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrPageNotFound, hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page %s: %w", hash, err)
+	}
+	return page, nil
+}
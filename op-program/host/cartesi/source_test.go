@@ -0,0 +1,56 @@
+package cartesi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPPageSource(t *testing.T) {
+	page := []byte("machine page")
+	hash := crypto.Keccak256Hash(page)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/pages/" + hash.Hex():
+			_, _ = w.Write(page)
+		case "/pages/" + common.Hash{0xee}.Hex():
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	source := NewHTTPPageSource(srv.URL+"/", srv.Client())
+
+	actual, err := source.Page(context.Background(), hash)
+	require.NoError(t, err)
+	require.Equal(t, page, actual)
+
+	_, err = source.Page(context.Background(), common.Hash{0xaa})
+	require.ErrorIs(t, err, ErrPageNotFound)
+
+	_, err = source.Page(context.Background(), common.Hash{0xee})
+	require.ErrorContains(t, err, "status 500")
+}
+
+func TestSnapshotPageSource(t *testing.T) {
+	dir := t.TempDir()
+	page := []byte("machine page")
+	hash := crypto.Keccak256Hash(page)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash.Hex()), page, 0o644))
+	source := NewSnapshotPageSource(dir)
+
+	actual, err := source.Page(context.Background(), hash)
+	require.NoError(t, err)
+	require.Equal(t, page, actual)
+
+	_, err = source.Page(context.Background(), common.Hash{0xaa})
+	require.ErrorIs(t, err, ErrPageNotFound)
+}
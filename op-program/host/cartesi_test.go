@@ -0,0 +1,37 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/cartesi"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+)
+
+func TestPrefetcherConfigCartesi(t *testing.T) {
+	newConfig := func() *config.Config {
+		return config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	}
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		require.Empty(t, makePrefetcherConfig(newConfig()).HintHandlers)
+	})
+
+	t.Run("MachineURL", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.CartesiMachineURL = "http://machine:8080"
+		require.Contains(t, makePrefetcherConfig(cfg).HintHandlers, cartesi.HintMachinePage)
+		require.IsType(t, &cartesi.HTTPPageSource{}, makeCartesiPageSource(cfg))
+	})
+
+	t.Run("SnapshotDir", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.CartesiSnapshotDir = t.TempDir()
+		require.Contains(t, makePrefetcherConfig(cfg).HintHandlers, cartesi.HintMachinePage)
+		require.IsType(t, &cartesi.SnapshotPageSource{}, makeCartesiPageSource(cfg))
+	})
+}
@@ -55,6 +55,11 @@ func run(args []string, action ConfigAction) error {
 		if err != nil {
 			return err
 		}
+		if ctx.IsSet(flags.ConfigFile.Name) {
+			cfg.Reload = func() (*config.Config, error) {
+				return config.NewConfigFromCLI(logger, ctx)
+			}
+		}
 		return action(logger, cfg)
 	}
 
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -187,12 +188,32 @@ func TestL1Head(t *testing.T) {
 	t.Run("Invalid", func(t *testing.T) {
 		verifyArgsInvalid(t, config.ErrInvalidL1Head.Error(), replaceRequiredArg("--l1.head", "something"))
 	})
+
+	t.Run("OptionalInServerMode", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept("--l1.head", "--server", "--l1", "http://l1", "--l1.beacon", "http://beacon", "--l2", "http://l2"))
+		require.Equal(t, common.Hash{}, cfg.L1Head)
+		require.True(t, cfg.ResolvesL1Head())
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "l1head")
+		require.NoError(t, os.WriteFile(path, []byte(l1HeadValue+"\n"), 0o644))
+		cfg := configForArgs(t, addRequiredArgsExcept("--l1.head", "--l1.head.file", path))
+		require.Equal(t, common.HexToHash(l1HeadValue), cfg.L1Head)
+		require.Equal(t, path, cfg.L1HeadFile)
+	})
+
+	t.Run("FileAndFlag", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "l1head")
+		require.NoError(t, os.WriteFile(path, []byte(l1HeadValue), 0o644))
+		verifyArgsInvalid(t, config.ErrL1HeadAndL1HeadFile.Error(), addRequiredArgs("--l1.head.file", path))
+	})
 }
 
 func TestL1(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l1", expected))
-	require.Equal(t, expected, cfg.L1URL)
+	require.Equal(t, expected, cfg.L1URL())
 }
 
 func TestL1TrustRPC(t *testing.T) {
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// DefaultAPIAddress is the address the HTTP API listens on if none is configured, only reachable from the local host.
+const DefaultAPIAddress = "127.0.0.1:7300"
+
+// unixSocketPrefix marks an API address as the path of a unix domain socket.
+const unixSocketPrefix = "unix://"
+
+// APIListenAddress returns the network and address the HTTP API listens on, as accepted by net.Listen.
+// The API address is either a host:port TCP address with a numeric port, or a unix:// URL of a socket path.
+func (c *Config) APIListenAddress() (network string, address string, err error) {
+	if c.APIAddress == "" {
+		return "", "", ErrMissingAPIAddress
+	}
+	if path, ok := strings.CutPrefix(c.APIAddress, unixSocketPrefix); ok {
+		if path == "" {
+			return "", "", fmt.Errorf("%w %q: missing socket path", ErrInvalidAPIAddress, c.APIAddress)
+		}
+		return "unix", path, nil
+	}
+	_, port, err := net.SplitHostPort(c.APIAddress)
+	if err != nil {
+		return "", "", fmt.Errorf("%w %q: %w", ErrInvalidAPIAddress, c.APIAddress, err)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", "", fmt.Errorf("%w %q: invalid port %q", ErrInvalidAPIAddress, c.APIAddress, port)
+	}
+	return "tcp", c.APIAddress, nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIListenAddress(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := validConfig()
+		require.Equal(t, DefaultAPIAddress, cfg.APIAddress)
+		network, address, err := cfg.APIListenAddress()
+		require.NoError(t, err)
+		require.Equal(t, "tcp", network)
+		require.Equal(t, "127.0.0.1:7300", address)
+	})
+
+	valid := []struct {
+		name    string
+		addr    string
+		network string
+		address string
+	}{
+		{"Loopback", "127.0.0.1:8000", "tcp", "127.0.0.1:8000"},
+		{"AllInterfaces", "0.0.0.0:8000", "tcp", "0.0.0.0:8000"},
+		{"EmptyHost", ":8000", "tcp", ":8000"},
+		{"Hostname", "localhost:8000", "tcp", "localhost:8000"},
+		{"IPv6", "[::1]:8000", "tcp", "[::1]:8000"},
+		{"RandomPort", "127.0.0.1:0", "tcp", "127.0.0.1:0"},
+		{"UnixSocket", "unix:///run/op-program.sock", "unix", "/run/op-program.sock"},
+		{"RelativeUnixSocket", "unix://op-program.sock", "unix", "op-program.sock"},
+	}
+	for _, test := range valid {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.APIAddress = test.addr
+			require.NoError(t, cfg.Check())
+			network, address, err := cfg.APIListenAddress()
+			require.NoError(t, err)
+			require.Equal(t, test.network, network)
+			require.Equal(t, test.address, address)
+		})
+	}
+
+	invalid := []struct {
+		name     string
+		addr     string
+		expected error
+	}{
+		{"Empty", "", ErrMissingAPIAddress},
+		{"MissingPort", "127.0.0.1", ErrInvalidAPIAddress},
+		{"EmptyPort", "127.0.0.1:", ErrInvalidAPIAddress},
+		{"NamedPort", "127.0.0.1:http", ErrInvalidAPIAddress},
+		{"PortOutOfRange", "127.0.0.1:65536", ErrInvalidAPIAddress},
+		{"URL", "http://127.0.0.1:8000", ErrInvalidAPIAddress},
+		{"UnixWithoutPath", "unix://", ErrInvalidAPIAddress},
+	}
+	for _, test := range invalid {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.APIAddress = test.addr
+			require.ErrorIs(t, cfg.Check(), test.expected)
+		})
+	}
+}
+
+func TestAPIAddressFromCLI(t *testing.T) {
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data")...)
+	require.NoError(t, err)
+	require.Equal(t, DefaultAPIAddress, cfg.APIAddress)
+
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--api.address", "unix:///tmp/api.sock")...)
+	require.NoError(t, err)
+	require.Equal(t, "unix:///tmp/api.sock", cfg.APIAddress)
+	require.NoError(t, cfg.Check())
+
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--api.address", "")...)
+	require.NoError(t, err)
+	require.ErrorIs(t, cfg.Check(), ErrMissingAPIAddress)
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestL1BeaconURL(t *testing.T) {
+	fetchingConfig := func() *Config {
+		cfg := validConfig()
+		cfg.L1URLs = []string{"http://l1"}
+		cfg.L2URL = "http://l2"
+		return cfg
+	}
+
+	t.Run("RequiredWithEcotone", func(t *testing.T) {
+		cfg := fetchingConfig()
+		require.NotNil(t, cfg.Rollup.EcotoneTime)
+		require.True(t, cfg.L1BeaconRequired())
+		require.False(t, cfg.FetchingEnabled())
+		err := cfg.Check()
+		require.ErrorIs(t, err, ErrMissingL1BeaconURL)
+		require.ErrorContains(t, err, "flag l1.beacon is required")
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		cfg := fetchingConfig()
+		cfg.L1BeaconURL = "http://beacon"
+		require.NoError(t, cfg.Check())
+		require.True(t, cfg.FetchingEnabled())
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		cfg := fetchingConfig()
+		cfg.L1BeaconIgnore = true
+		require.False(t, cfg.L1BeaconRequired())
+		require.NoError(t, cfg.Check())
+		require.True(t, cfg.FetchingEnabled())
+	})
+
+	t.Run("OptionalBeforeEcotone", func(t *testing.T) {
+		cfg := fetchingConfig()
+		rollupCfg := *cfg.Rollup
+		rollupCfg.EcotoneTime = nil
+		cfg.Rollup = &rollupCfg
+		require.False(t, cfg.L1BeaconRequired())
+		require.NoError(t, cfg.Check())
+		require.True(t, cfg.FetchingEnabled())
+	})
+
+	t.Run("NotRequiredWithoutFetching", func(t *testing.T) {
+		cfg := validConfig()
+		require.NoError(t, cfg.Check())
+	})
+}
+
+func TestL1BeaconIgnoreFromCLI(t *testing.T) {
+	args := append(requiredArgs(t), "--l1", "http://l1", "--l2", "http://l2")
+	cfg, err := configFromArgs(t, args...)
+	require.NoError(t, err)
+	require.ErrorIs(t, cfg.Check(), ErrMissingL1BeaconURL)
+
+	cfg, err = configFromArgs(t, append(args, "--l1.beacon.ignore")...)
+	require.NoError(t, err)
+	require.True(t, cfg.L1BeaconIgnore)
+	require.NoError(t, cfg.Check())
+
+	path := writeConfigFile(t, "config.toml", requiredFileFields(t)+"l1_beacon_ignore = true\n")
+	cfg, err = configFromArgs(t, "--config", path, "--l1", "http://l1", "--l2", "http://l2")
+	require.NoError(t, err)
+	require.True(t, cfg.L1BeaconIgnore)
+	require.NoError(t, cfg.Check())
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartesi(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		cfg := validConfig()
+		require.NoError(t, cfg.Check())
+		require.False(t, cfg.CartesiEnabled())
+		require.Equal(t, 30*time.Second, cfg.CartesiTimeout)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.CartesiTimeout = maxRequestTimeout
+		require.NoError(t, cfg.Check())
+		cfg.CartesiTimeout = 0
+		require.ErrorIs(t, cfg.Check(), ErrInvalidRequestTimeout)
+		cfg.CartesiTimeout = maxRequestTimeout + 1
+		require.ErrorIs(t, cfg.Check(), ErrInvalidRequestTimeout)
+	})
+
+	tests := []struct {
+		name        string
+		machineURL  string
+		snapshotDir string
+		expected    error
+	}{
+		{name: "MachineURL", machineURL: "http://machine:8080"},
+		{name: "SnapshotDir", snapshotDir: dir},
+		{name: "Both", machineURL: "http://machine:8080", snapshotDir: dir, expected: ErrCartesiSourcesExclusive},
+		{name: "InvalidMachineURL", machineURL: "machine:8080", expected: ErrInvalidCartesiMachineURL},
+		{name: "NonHTTPMachineURL", machineURL: "ws://machine:8080", expected: ErrInvalidCartesiMachineURL},
+		{name: "MissingSnapshotDir", snapshotDir: filepath.Join(dir, "missing"), expected: ErrInvalidCartesiSnapshotDir},
+		{name: "SnapshotDirIsFile", snapshotDir: file, expected: ErrInvalidCartesiSnapshotDir},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.CartesiMachineURL = test.machineURL
+			cfg.CartesiSnapshotDir = test.snapshotDir
+			require.True(t, cfg.CartesiEnabled())
+			if test.expected == nil {
+				require.NoError(t, cfg.Check())
+			} else {
+				require.ErrorIs(t, cfg.Check(), test.expected)
+			}
+		})
+	}
+}
+
+func TestCartesiFromCLI(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--cartesi.machine-url", "http://machine:8080")...)
+	require.NoError(t, err)
+	require.Equal(t, "http://machine:8080", cfg.CartesiMachineURL)
+	require.NoError(t, cfg.Check())
+
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data",
+		"--cartesi.machine-url", "http://machine:8080", "--cartesi.request-timeout", "1m")...)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, cfg.CartesiTimeout)
+	require.NoError(t, cfg.Check())
+
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--cartesi.snapshot-dir", dir)...)
+	require.NoError(t, err)
+	require.Equal(t, dir, cfg.CartesiSnapshotDir)
+	require.NoError(t, cfg.Check())
+
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data",
+		"--cartesi.machine-url", "http://machine:8080", "--cartesi.snapshot-dir", dir)...)
+	require.NoError(t, err)
+	require.ErrorIs(t, cfg.Check(), ErrCartesiSourcesExclusive)
+}
@@ -1,44 +1,167 @@
 package config
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slog"
 )
 
 var (
-	ErrMissingRollupConfig = errors.New("missing rollup config")
-	ErrMissingL2Genesis    = errors.New("missing l2 genesis")
-	ErrInvalidL1Head       = errors.New("invalid l1 head")
-	ErrInvalidL2Head       = errors.New("invalid l2 head")
-	ErrInvalidL2OutputRoot = errors.New("invalid l2 output root")
-	ErrL1AndL2Inconsistent = errors.New("l1 and l2 options must be specified together or both omitted")
-	ErrInvalidL2Claim      = errors.New("invalid l2 claim")
-	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
-	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
-	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrMissingRollupConfig           = errors.New("missing rollup config")
+	ErrInvalidRollupConfig           = errors.New("invalid rollup config")
+	ErrRollupConfigOrNetworkRequired = errors.New("flag rollup.config or network is required")
+	ErrNetworkAndRollupConfig        = errors.New("cannot specify both rollup.config and network")
+	ErrInvalidNetwork                = errors.New("invalid network")
+	ErrMissingL2Genesis              = errors.New("missing l2 genesis")
+	ErrL2GenesisRequired             = errors.New("flag l2.genesis is required for custom networks set with rollup.config")
+	ErrNetworkAndL2Genesis           = errors.New("cannot specify both l2.genesis and network")
+	ErrL2GenesisNotFound             = errors.New("l2 genesis file not found")
+	ErrInvalidL2Genesis              = errors.New("invalid l2 genesis file")
+	ErrL2ChainIDMismatch             = errors.New("l2 genesis chain id does not match the rollup config")
+	ErrL2GenesisWithoutConfig        = errors.New("l2 genesis file has no chain config")
+	ErrInvalidL1Head                 = errors.New("invalid l1 head")
+	ErrL1HeadAndL1HeadFile           = errors.New("cannot specify both l1.head and l1.head.file")
+	ErrInvalidL1HeadFile             = errors.New("invalid l1 head file")
+	ErrInvalidL1URL                  = errors.New("invalid l1 url")
+	ErrInvalidL1BeaconURL            = errors.New("invalid l1 beacon url")
+	ErrInvalidL1RPCKind              = errors.New("invalid l1 rpc kind")
+	ErrInvalidL2URL                  = errors.New("invalid l2 url")
+	ErrInvalidDAURL                  = errors.New("invalid da server url")
+	ErrMissingL1BeaconURL            = errors.New("flag l1.beacon is required to fetch blobs once ecotone is scheduled, set l1.beacon.ignore to run without it")
+	ErrInvalidL2Head                 = errors.New("invalid l2 head")
+	ErrInvalidL2OutputRoot           = errors.New("invalid l2 output root")
+	ErrL1AndL2Inconsistent           = errors.New("l1 and l2 options must be specified together or both omitted")
+	ErrInvalidL2Claim                = errors.New("invalid l2 claim")
+	ErrInvalidL2ClaimBlock           = errors.New("invalid l2 claim block number")
+	ErrDataDirRequired               = errors.New("datadir must be specified when in non-fetching mode")
+	ErrNoExecInServerMode            = errors.New("exec command must not be set when in server mode")
+	ErrInvalidExecCmd                = errors.New("exec command must not be blank")
+
+	ErrInvalidKVBackend             = errors.New("invalid kv backend")
+	ErrKVBackendRequiresDataDir     = errors.New("kv backend requires datadir")
+	ErrMemKVWithDataDir             = errors.New("datadir must not be set with the mem kv backend")
+	ErrMemKVReadOnly                = errors.New("datadir.readonly is not supported by the mem kv backend")
+	ErrDataDirReadOnlyWithFetching  = errors.New("datadir.readonly requires fetching to be disabled")
+	ErrInvalidKVPebbleCacheSize     = errors.New("pebble kv backend cache size must be above 0")
+	ErrDataDirMaxSizeWithoutDataDir = errors.New("datadir.max-size requires a datadir")
+
+	ErrInvalidL1DialAttempts = errors.New("invalid l1 dial attempts")
+	ErrInvalidL1RetryBackoff = errors.New("invalid l1 retry backoff")
+	ErrInvalidRequestTimeout = errors.New("invalid request timeout")
+
+	ErrUnknownHintType = errors.New("unknown hint type")
+
+	ErrMissingAPIAddress = errors.New("missing api address")
+	ErrInvalidAPIAddress = errors.New("invalid api address")
+
+	ErrAPITLSCertKeyInconsistent = errors.New("api tls cert and key must be specified together")
+	ErrAPITLSClientCAWithoutCert = errors.New("api tls client ca requires the api tls cert and key")
+	ErrInvalidAPITLS             = errors.New("invalid api tls config")
+	ErrInvalidAPITLSClientCA     = errors.New("invalid api tls client ca")
+
+	ErrCartesiSourcesExclusive   = errors.New("cartesi.machine-url and cartesi.snapshot-dir must not be set together")
+	ErrInvalidCartesiMachineURL  = errors.New("invalid cartesi machine url")
+	ErrInvalidCartesiSnapshotDir = errors.New("invalid cartesi snapshot dir")
+
+	ErrMissingMetricsAddr = errors.New("metrics.enabled requires a metrics address")
+	ErrInvalidMetricsPort = errors.New("invalid metrics port")
+
+	ErrSecretSources = errors.New("secret must be set with either its env var or its file")
+	ErrEmptySecret   = errors.New("empty secret")
+)
+
+const (
+	// DefaultL1DialAttempts is the number of attempts to dial each L1 RPC by default.
+	DefaultL1DialAttempts = 10
+	// DefaultL1RetryBackoff is the maximum delay between retries of a failed request by default.
+	DefaultL1RetryBackoff = 10 * time.Second
+	// DefaultBeaconRequestTimeout is the timeout of L1 beacon requests by default.
+	DefaultBeaconRequestTimeout = 30 * time.Second
+	// DefaultCartesiTimeout is the timeout of Cartesi machine page requests by default.
+	DefaultCartesiTimeout = 30 * time.Second
+
+	maxL1DialAttempts = 100
+	maxL1RetryBackoff = 10 * time.Minute
+	maxRequestTimeout = 10 * time.Minute
 )
 
 type Config struct {
+	Rollup *rollup.Config
 	// DataDir is the directory to read/write pre-image data from/to.
 	// If not set, an in-memory key-value store is used and fetching data must be enabled
 	DataDir string
+	// DataDirReadOnly indicates pre-images are only read from the DataDir and never written to it.
+	DataDirReadOnly bool
+	// DataDirMaxSize is the maximum size in bytes of the pre-image data in the DataDir, new pre-images are
+	// rejected once it is reached. If 0, the size is not limited.
+	DataDirMaxSize uint64
+	// KVBackend is the key-value store pre-images are kept in.
+	// If not set, the disk backend is used when DataDir is set and the mem backend otherwise.
+	KVBackend types.KVBackend
+	// KVPebbleCacheSize is the size of the block cache of the pebble backend in MiB.
+	KVPebbleCacheSize uint64
 
 	// L1Head is the block hash of the L1 chain head block
-	L1Head      common.Hash
-	L1URL       string
+	// If not set in server mode with fetching enabled, the latest finalized L1 block is used, see ResolvesL1Head.
+	L1Head common.Hash
+	// L1HeadFile is the file the L1Head is read from instead, read again when the config is reloaded.
+	L1HeadFile string
+	// L1URLs are the L1 JSON-RPC endpoints, tried in order when fetching pre-images.
+	L1URLs      []string
 	L1BeaconURL string
-	L1TrustRPC  bool
-	L1RPCKind   sources.RPCProviderKind
+	// L1BeaconIgnore allows fetching without an L1BeaconURL when the rollup has Ecotone scheduled.
+	// Blob hints then fail when they are processed.
+	L1BeaconIgnore bool
+	L1TrustRPC     bool
+	L1RPCKind      sources.RPCProviderKind
+
+	// L1DialAttempts is the number of attempts to dial each L1 RPC, with an exponential backoff.
+	L1DialAttempts uint
+	// L1Retries is the maximum number of retries of a failed L1 or beacon request.
+	// If 0, requests are retried until they succeed.
+	L1Retries uint
+	// L1RetryBackoff is the maximum delay between retries, the delay grows exponentially up to it.
+	L1RetryBackoff time.Duration
+	// L1RequestTimeout is the timeout of each attempt of an L1 RPC request. If 0, attempts have no timeout.
+	L1RequestTimeout time.Duration
+	// BeaconRequestTimeout is the timeout of each L1 beacon request.
+	BeaconRequestTimeout time.Duration
+
+	// L2Head is the l2 block hash contained in the L2 Output referenced by the L2OutputRoot
+	L2Head common.Hash
+	// L2OutputRoot is the agreed L2 output root to start derivation from
+	L2OutputRoot common.Hash
+	L2URL        string
+	// L2Claim is the claimed L2 output root to verify
+	L2Claim common.Hash
+	// L2ClaimBlockNumber is the block number the claimed L2 output root is from
+	// Must be above 0 and to be a valid claim needs to be above the L2Head block.
+	L2ClaimBlockNumber uint64
+	// L2ChainConfig is the op-geth chain config for the L2 execution engine
+	L2ChainConfig *params.ChainConfig
 
 	// ExecCmd specifies the client program to execute in a separate process.
 	// If unset, the fault proof client is run in the same process.
@@ -51,69 +174,597 @@ type Config struct {
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
 
+	// APIAddress is the address the HTTP API listens on, host:port or unix:// followed by a socket path.
 	APIAddress string
+	// APITLSCert and APITLSKey are the files of the certificate and key the HTTP API is served with over TLS.
+	// The API is served over plain HTTP if unset.
+	APITLSCert string
+	APITLSKey  string
+	// APITLSClientCA is the file of the CA certificates client certificates are verified against, if set.
+	APITLSClientCA string
+	// apiTLS is the TLS config loaded from the API TLS files, see APITLSConfig.
+	apiTLS *tls.Config
+	// APIAuthToken is the bearer token HTTP API requests must be authorized with, if set.
+	// It is a secret, only set with its env var or read from a file, and redacted from logs.
+	APIAuthToken string
+
+	// AllowedHints are the hint types accepted by the HTTP API and the prefetcher.
+	// If empty, all hint types in types.HintTypes are allowed.
+	AllowedHints []string
+
+	// MaxPreimageSize is the maximum size in bytes of a pre-image to fetch, store or serve in a single response.
+	// If 0, the size is not limited.
+	MaxPreimageSize uint64
+
+	// CartesiMachineURL is the HTTP endpoint of the machine server Cartesi machine pages are fetched from.
+	// CartesiSnapshotDir is the directory of a machine snapshot pages are read from instead.
+	// At most one of them may be set, Cartesi machine page hints are only handled if one is.
+	CartesiMachineURL  string
+	CartesiSnapshotDir string
+	// CartesiTimeout is the timeout of each request for a page from the CartesiMachineURL.
+	CartesiTimeout time.Duration
+
+	// DAURL is the address of the plasma DA storage service used to resolve keccak256 pre-images
+	// missing from the DataDir when fetching is disabled. Optional.
+	DAURL string
+
+	// Preset is the name of the preset the defaults of the config are taken from, if any. See Presets.
+	Preset string
+
+	// MetricsConfig configures the metrics server, which is only started if enabled.
+	MetricsConfig opmetrics.CLIConfig
+
+	// LogLevel is the log level set with the log.level flag or in the config file.
+	// If nil, the logger keeps the level it was created with.
+	LogLevel *slog.Level
+
+	// Reload loads the config again from the same flags and config file, if set.
+	// The running preimage server reloads its config on SIGHUP, see ReloadableSettings.
+	Reload func() (*Config, error)
 }
 
+// Check validates every option of the config and the constraints between them.
+// The error of an invalid config is a *FlagError naming the flag of the offending option,
+// wrapping the sentinel error of the failed check.
 func (c *Config) Check() error {
-	if c.L1Head == (common.Hash{}) {
-		return ErrInvalidL1Head
+	for _, check := range []func() error{
+		c.checkChain,
+		c.checkProofInputs,
+		c.checkSources,
+		c.checkRetries,
+		c.checkKV,
+		c.checkProgram,
+		c.checkCartesi,
+		c.checkMetrics,
+		c.checkAPI,
+	} {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkChain validates the rollup and L2 chain configs, and that they match the preset if one is set.
+func (c *Config) checkChain() error {
+	if c.Rollup == nil {
+		return flagError(flags.RollupConfig.Name, ErrMissingRollupConfig)
+	}
+	if err := c.Rollup.Check(); err != nil {
+		return flagError(flags.RollupConfig.Name, fmt.Errorf("%w: %w", ErrInvalidRollupConfig, err))
+	}
+	if err := c.checkPreset(); err != nil {
+		return flagError(flags.Preset.Name, err)
+	}
+	if c.L2ChainConfig == nil {
+		return flagError(flags.L2GenesisPath.Name, ErrMissingL2Genesis)
+	}
+	if c.L2ChainConfig.ChainID == nil || c.L2ChainConfig.ChainID.Cmp(c.Rollup.L2ChainID) != 0 {
+		return flagError(flags.L2GenesisPath.Name, fmt.Errorf("%w: l2 genesis is for chain %v, rollup config for chain %v",
+			ErrL2ChainIDMismatch, c.L2ChainConfig.ChainID, c.Rollup.L2ChainID))
+	}
+	return nil
+}
+
+// checkProofInputs validates the agreed and claimed outputs the program verifies.
+func (c *Config) checkProofInputs() error {
+	if c.L1Head == (common.Hash{}) && !c.ResolvesL1Head() {
+		return flagError(flags.L1Head.Name, ErrInvalidL1Head)
+	}
+	if c.L2Head == (common.Hash{}) {
+		return flagError(flags.L2Head.Name, ErrInvalidL2Head)
+	}
+	if c.L2OutputRoot == (common.Hash{}) {
+		return flagError(flags.L2OutputRoot.Name, ErrInvalidL2OutputRoot)
+	}
+	if c.L2ClaimBlockNumber == 0 {
+		return flagError(flags.L2BlockNumber.Name, ErrInvalidL2ClaimBlock)
+	}
+	return nil
+}
+
+// checkSources validates the endpoints pre-images are fetched from, and that a DataDir is set without them.
+func (c *Config) checkSources() error {
+	for _, l1URL := range c.L1URLs {
+		if !validURL(l1URL) {
+			return flagError(flags.L1NodeAddr.Name, fmt.Errorf("%w: %q", ErrInvalidL1URL, l1URL))
+		}
+	}
+	if c.L2URL != "" && !validURL(c.L2URL) {
+		return flagError(flags.L2NodeAddr.Name, fmt.Errorf("%w: %q", ErrInvalidL2URL, c.L2URL))
+	}
+	if len(c.L1URLs) > 0 && c.L2URL == "" {
+		return flagError(flags.L2NodeAddr.Name, ErrL1AndL2Inconsistent)
+	}
+	if len(c.L1URLs) == 0 && c.L2URL != "" {
+		return flagError(flags.L1NodeAddr.Name, ErrL1AndL2Inconsistent)
+	}
+	if c.L1BeaconURL != "" && !validURL(c.L1BeaconURL) {
+		return flagError(flags.L1BeaconAddr.Name, fmt.Errorf("%w: %q", ErrInvalidL1BeaconURL, c.L1BeaconURL))
+	}
+	if len(c.L1URLs) > 0 && c.L1BeaconURL == "" && c.L1BeaconRequired() {
+		return flagError(flags.L1BeaconAddr.Name, ErrMissingL1BeaconURL)
+	}
+	if !sources.ValidRPCProviderKind(c.L1RPCKind) {
+		return flagError(flags.L1RPCProviderKind.Name, fmt.Errorf("%w %q, valid options: %s",
+			ErrInvalidL1RPCKind, c.L1RPCKind, openum.EnumString(sources.RPCProviderKinds)))
+	}
+	if c.DAURL != "" && !validURL(c.DAURL) {
+		return flagError(flags.DAServerAddr.Name, fmt.Errorf("%w: %q", ErrInvalidDAURL, c.DAURL))
 	}
 	if !c.FetchingEnabled() && c.DataDir == "" {
-		return ErrDataDirRequired
+		return flagError(flags.DataDir.Name, ErrDataDirRequired)
 	}
+	return nil
+}
+
+// validURL reports whether rawURL is an absolute URL with a host.
+func validURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// checkProgram validates how the client program is run and the hints it may send.
+func (c *Config) checkProgram() error {
 	if c.ServerMode && c.ExecCmd != "" {
-		return ErrNoExecInServerMode
+		return flagError(flags.Exec.Name, ErrNoExecInServerMode)
+	}
+	if c.ExecCmd != "" && strings.TrimSpace(c.ExecCmd) == "" {
+		return flagError(flags.Exec.Name, ErrInvalidExecCmd)
+	}
+	for _, hintType := range c.AllowedHints {
+		if !slices.Contains(types.HintTypes, hintType) {
+			return flagError(flags.HintsAllowed.Name, fmt.Errorf("%w %q, valid options: %s",
+				ErrUnknownHintType, hintType, strings.Join(types.HintTypes, ", ")))
+		}
+	}
+	return nil
+}
+
+// checkAPI validates the listen address and TLS files of the HTTP API.
+func (c *Config) checkAPI() error {
+	if _, _, err := c.APIListenAddress(); err != nil {
+		return flagError(flags.APIAddress.Name, err)
+	}
+	if _, err := c.APITLSConfig(); err != nil {
+		return flagError(c.apiTLSFlag(err), err)
 	}
 	return nil
 }
 
+// apiTLSFlag returns the name of the API TLS flag the APITLSConfig error err is caused by.
+func (c *Config) apiTLSFlag(err error) string {
+	switch {
+	case errors.Is(err, ErrAPITLSCertKeyInconsistent) && c.APITLSCert == "":
+		return flags.APITLSCert.Name
+	case errors.Is(err, ErrAPITLSCertKeyInconsistent):
+		return flags.APITLSKey.Name
+	case errors.Is(err, ErrAPITLSClientCAWithoutCert) || errors.Is(err, ErrInvalidAPITLSClientCA):
+		return flags.APITLSClientCA.Name
+	default:
+		return flags.APITLSCert.Name
+	}
+}
+
+// checkRetries validates the L1 retry and timeout settings.
+func (c *Config) checkRetries() error {
+	if c.L1DialAttempts < 1 || c.L1DialAttempts > maxL1DialAttempts {
+		return flagError(flags.L1DialAttempts.Name, fmt.Errorf("%w: %v, must be between 1 and %v", ErrInvalidL1DialAttempts, c.L1DialAttempts, maxL1DialAttempts))
+	}
+	if c.L1RetryBackoff <= 0 || c.L1RetryBackoff > maxL1RetryBackoff {
+		return flagError(flags.L1RetryBackoff.Name, fmt.Errorf("%w: %v, must be above 0 and at most %v", ErrInvalidL1RetryBackoff, c.L1RetryBackoff, maxL1RetryBackoff))
+	}
+	if c.L1RequestTimeout < 0 || c.L1RequestTimeout > maxRequestTimeout {
+		return flagError(flags.L1RequestTimeout.Name, fmt.Errorf("%w: l1 %v, must be between 0 and %v", ErrInvalidRequestTimeout, c.L1RequestTimeout, maxRequestTimeout))
+	}
+	if c.BeaconRequestTimeout <= 0 || c.BeaconRequestTimeout > maxRequestTimeout {
+		return flagError(flags.BeaconRequestTimeout.Name, fmt.Errorf("%w: beacon %v, must be above 0 and at most %v", ErrInvalidRequestTimeout, c.BeaconRequestTimeout, maxRequestTimeout))
+	}
+	if c.CartesiTimeout <= 0 || c.CartesiTimeout > maxRequestTimeout {
+		return flagError(flags.CartesiRequestTimeout.Name, fmt.Errorf("%w: cartesi %v, must be above 0 and at most %v", ErrInvalidRequestTimeout, c.CartesiTimeout, maxRequestTimeout))
+	}
+	return nil
+}
+
+// checkCartesi validates the source of Cartesi machine pages.
+func (c *Config) checkCartesi() error {
+	if c.CartesiMachineURL != "" && c.CartesiSnapshotDir != "" {
+		return flagError(flags.CartesiSnapshotDir.Name, ErrCartesiSourcesExclusive)
+	}
+	if c.CartesiMachineURL != "" {
+		if u, err := url.Parse(c.CartesiMachineURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return flagError(flags.CartesiMachineURL.Name, fmt.Errorf("%w: %q", ErrInvalidCartesiMachineURL, redactURL(c.CartesiMachineURL)))
+		}
+	}
+	if c.CartesiSnapshotDir != "" {
+		if info, err := os.Stat(c.CartesiSnapshotDir); err != nil {
+			return flagError(flags.CartesiSnapshotDir.Name, fmt.Errorf("%w: %w", ErrInvalidCartesiSnapshotDir, err))
+		} else if !info.IsDir() {
+			return flagError(flags.CartesiSnapshotDir.Name, fmt.Errorf("%w: %s is not a directory", ErrInvalidCartesiSnapshotDir, c.CartesiSnapshotDir))
+		}
+	}
+	return nil
+}
+
+// checkMetrics validates the metrics server settings, if it is enabled.
+func (c *Config) checkMetrics() error {
+	if !c.MetricsConfig.Enabled {
+		return nil
+	}
+	if c.MetricsConfig.ListenAddr == "" {
+		return flagError(opmetrics.ListenAddrFlagName, ErrMissingMetricsAddr)
+	}
+	if c.MetricsConfig.ListenPort < 0 || c.MetricsConfig.ListenPort > math.MaxUint16 {
+		return flagError(opmetrics.PortFlagName, fmt.Errorf("%w: %d, must be between 0 and %d", ErrInvalidMetricsPort, c.MetricsConfig.ListenPort, math.MaxUint16))
+	}
+	return nil
+}
+
+// CartesiEnabled reports whether a source of Cartesi machine pages is configured.
+func (c *Config) CartesiEnabled() bool {
+	return c.CartesiMachineURL != "" || c.CartesiSnapshotDir != ""
+}
+
+// checkKV validates the KV backend and the settings it requires.
+func (c *Config) checkKV() error {
+	backend := c.ResolvedKVBackend()
+	if !types.ValidKVBackend(backend) {
+		return flagError(flags.KVBackend.Name, fmt.Errorf("%w %q, valid options: %s", ErrInvalidKVBackend, backend, openum.EnumString(types.KVBackends)))
+	}
+	if backend.UsesDataDir() && c.DataDir == "" {
+		return flagError(flags.DataDir.Name, fmt.Errorf("%w: %v", ErrKVBackendRequiresDataDir, backend))
+	}
+	if backend == types.KVBackendMem && c.DataDirReadOnly {
+		return flagError(flags.DataDirReadOnly.Name, ErrMemKVReadOnly)
+	}
+	if backend == types.KVBackendMem && c.DataDir != "" {
+		return flagError(flags.DataDir.Name, ErrMemKVWithDataDir)
+	}
+	if c.DataDirReadOnly && c.FetchingEnabled() {
+		return flagError(flags.DataDirReadOnly.Name, ErrDataDirReadOnlyWithFetching)
+	}
+	if c.DataDirMaxSize > 0 && !backend.UsesDataDir() {
+		return flagError(flags.DataDirMaxSize.Name, ErrDataDirMaxSizeWithoutDataDir)
+	}
+	if backend == types.KVBackendPebble && c.KVPebbleCacheSize == 0 {
+		return flagError(flags.KVPebbleCacheSize.Name, ErrInvalidKVPebbleCacheSize)
+	}
+	return nil
+}
+
+// ResolvedKVBackend returns the configured KV backend, or the default backend for the DataDir if none is set.
+func (c *Config) ResolvedKVBackend() types.KVBackend {
+	if c.KVBackend != "" {
+		return c.KVBackend
+	}
+	if c.DataDir != "" {
+		return types.KVBackendDisk
+	}
+	return types.KVBackendMem
+}
+
+// FetchingEnabled reports whether pre-images are fetched from the L1 and L2 nodes, which requires an
+// L1 beacon URL to fetch blobs if L1BeaconRequired.
 func (c *Config) FetchingEnabled() bool {
-	// TODO: Include Beacon URL once cancun is active on all chains we fault prove.
-	return c.L1URL != ""
+	return len(c.L1URLs) > 0 && c.L2URL != "" && (c.L1BeaconURL != "" || !c.L1BeaconRequired())
+}
+
+// L1BeaconRequired reports whether an L1 beacon URL is required to fetch pre-images. Blobs are only used
+// once Ecotone is active, so the beacon is optional for chains without Ecotone scheduled or if L1BeaconIgnore is set.
+func (c *Config) L1BeaconRequired() bool {
+	return !c.L1BeaconIgnore && c.Rollup != nil && c.Rollup.EcotoneTime != nil
+}
+
+// ResolvesL1Head reports whether the L1 head is resolved from the latest finalized L1 block at startup.
+// Only the preimage server resolves it, when fetching is enabled and no L1 head is set.
+func (c *Config) ResolvesL1Head() bool {
+	return c.L1Head == (common.Hash{}) && c.L1HeadFile == "" && c.ServerMode && c.FetchingEnabled()
+}
+
+// L1URL returns the first L1 JSON-RPC endpoint, or an empty string if none is configured.
+func (c *Config) L1URL() string {
+	if len(c.L1URLs) == 0 {
+		return ""
+	}
+	return c.L1URLs[0]
 }
 
 // NewConfig creates a Config with all optional values set to the CLI default value
 func NewConfig(
+	rollupCfg *rollup.Config,
+	l2Genesis *params.ChainConfig,
 	l1Head common.Hash,
+	l2Head common.Hash,
+	l2OutputRoot common.Hash,
+	l2Claim common.Hash,
+	l2ClaimBlockNum uint64,
 ) *Config {
 	return &Config{
-		L1Head:              l1Head,
-		L1RPCKind:           sources.RPCKindStandard,
-		IsCustomChainConfig: false,
+		Rollup:               rollupCfg,
+		L2ChainConfig:        l2Genesis,
+		L1Head:               l1Head,
+		L2Head:               l2Head,
+		L2OutputRoot:         l2OutputRoot,
+		L2Claim:              l2Claim,
+		L2ClaimBlockNumber:   l2ClaimBlockNum,
+		L1RPCKind:            sources.RPCKindStandard,
+		L1DialAttempts:       DefaultL1DialAttempts,
+		L1RetryBackoff:       DefaultL1RetryBackoff,
+		BeaconRequestTimeout: DefaultBeaconRequestTimeout,
+		CartesiTimeout:       DefaultCartesiTimeout,
+		KVPebbleCacheSize:    32,
+		APIAddress:           DefaultAPIAddress,
+		MetricsConfig:        opmetrics.DefaultCLIConfig(),
+		IsCustomChainConfig:  isCustomChainConfig(l2Genesis),
+	}
+}
+
+// isCustomChainConfig reports whether the chain config is not one of the known OP Stack chains,
+// in which case the client reads it from the boot info instead of its built-in configs.
+func isCustomChainConfig(chainConfig *params.ChainConfig) bool {
+	if chainConfig == nil || chainConfig.ChainID == nil {
+		return false
 	}
+	_, err := params.LoadOPStackChainConfig(chainConfig.ChainID.Uint64())
+	return err != nil
 }
 
+// NewConfigFromCLI creates a Config from the CLI flags. Options are taken from, in order of precedence,
+// the CLI flags, their env vars, the config file set with the config flag and the defaults of NewConfig.
+// The resulting Config still has to be validated with Check.
 func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err := flags.CheckRequired(ctx); err != nil {
 		return nil, err
 	}
-	l1Head := common.HexToHash(ctx.String(flags.L1Head.Name))
+	cfg := NewConfig(nil, nil, common.Hash{}, common.Hash{}, common.Hash{}, common.Hash{}, 0)
+	var file *fileConfig
+	if path := ctx.String(flags.ConfigFile.Name); path != "" {
+		var err error
+		if file, err = loadConfigFile(path); err != nil {
+			return nil, err
+		}
+		log.Info("Loaded config file", "path", path)
+	}
+	// the preset provides defaults for the options of the config file and flags, so it is applied first
+	if file != nil {
+		setIfPresent(&cfg.Preset, file.Preset)
+	}
+	setFromCLI(ctx, flags.Preset.Name, &cfg.Preset, ctx.String)
+	if cfg.Preset != "" {
+		preset, err := LookupPreset(cfg.Preset)
+		if err != nil {
+			return nil, err
+		}
+		preset.apply(cfg)
+		log.Info("Using preset", "preset", cfg.Preset)
+	}
+	var rollupConfigPath, network, l2GenesisPath, apiAuthTokenFile string
+	if file != nil {
+		file.apply(cfg)
+		setIfPresent(&rollupConfigPath, file.RollupConfig)
+		setIfPresent(&network, file.Network)
+		setIfPresent(&l2GenesisPath, file.L2Genesis)
+		setIfPresent(&apiAuthTokenFile, file.APIAuthTokenFile)
+	}
+	setFromCLI(ctx, flags.RollupConfig.Name, &rollupConfigPath, ctx.String)
+	setFromCLI(ctx, flags.Network.Name, &network, ctx.String)
+	setFromCLI(ctx, flags.L2GenesisPath.Name, &l2GenesisPath, ctx.String)
+	if cfg.Preset != "" && rollupConfigPath == "" && network == "" {
+		network = Presets[cfg.Preset].Network
+	}
+	switch {
+	case rollupConfigPath != "" && network != "":
+		return nil, ErrNetworkAndRollupConfig
+	case l2GenesisPath != "" && network != "":
+		return nil, ErrNetworkAndL2Genesis
+	case network != "":
+		rollupCfg, chainCfg, err := loadNetworkConfig(network)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Rollup = rollupCfg
+		cfg.L2ChainConfig = chainCfg
+		log.Info("Using network config", "network", network, "l1ChainID", rollupCfg.L1ChainID, "l2ChainID", rollupCfg.L2ChainID)
+	case rollupConfigPath != "":
+		if l2GenesisPath == "" {
+			return nil, ErrL2GenesisRequired
+		}
+		rollupCfg, err := loadRollupConfig(rollupConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		chainCfg, err := loadChainConfigFromGenesis(l2GenesisPath)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Rollup = rollupCfg
+		cfg.L2ChainConfig = chainCfg
+		// the client reads the chain config of custom networks from the host instead of its built-in configs
+		cfg.IsCustomChainConfig = true
+		log.Info("Using custom network config", "l2Genesis", l2GenesisPath, "l1ChainID", rollupCfg.L1ChainID, "l2ChainID", chainCfg.ChainID)
+	default:
+		return nil, ErrRollupConfigOrNetworkRequired
+	}
+	if ctx.IsSet(flags.L1Head.Name) {
+		cfg.L1Head = common.HexToHash(ctx.String(flags.L1Head.Name))
+		if cfg.L1Head == (common.Hash{}) {
+			return nil, ErrInvalidL1Head
+		}
+	}
+	setFromCLI(ctx, flags.L1HeadFile.Name, &cfg.L1HeadFile, ctx.String)
+	if cfg.L1HeadFile != "" {
+		if cfg.L1Head != (common.Hash{}) {
+			return nil, ErrL1HeadAndL1HeadFile
+		}
+		l1Head, err := readL1HeadFile(cfg.L1HeadFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.L1Head = l1Head
+	}
+	if ctx.IsSet(flags.L2Head.Name) {
+		cfg.L2Head = common.HexToHash(ctx.String(flags.L2Head.Name))
+	}
+	if cfg.L2Head == (common.Hash{}) {
+		return nil, ErrInvalidL2Head
+	}
+	if ctx.IsSet(flags.L2OutputRoot.Name) {
+		cfg.L2OutputRoot = common.HexToHash(ctx.String(flags.L2OutputRoot.Name))
+	}
+	if cfg.L2OutputRoot == (common.Hash{}) {
+		return nil, ErrInvalidL2OutputRoot
+	}
+	if ctx.IsSet(flags.L2Claim.Name) {
+		strClaim := ctx.String(flags.L2Claim.Name)
+		cfg.L2Claim = common.HexToHash(strClaim)
+		// Require a valid hash, with the zero hash explicitly allowed.
+		if cfg.L2Claim == (common.Hash{}) && !isZeroHash(strClaim) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidL2Claim, strClaim)
+		}
+	}
+	setFromCLI(ctx, flags.L2BlockNumber.Name, &cfg.L2ClaimBlockNumber, ctx.Uint64)
+	setFromCLI(ctx, flags.L2NodeAddr.Name, &cfg.L2URL, ctx.String)
+	setFromCLI(ctx, flags.DataDir.Name, &cfg.DataDir, ctx.String)
+	setFromCLI(ctx, flags.DataDirReadOnly.Name, &cfg.DataDirReadOnly, ctx.Bool)
+	if ctx.IsSet(flags.DataDirMaxSize.Name) {
+		cfg.DataDirMaxSize = uint64(*ctx.Generic(flags.DataDirMaxSize.Name).(*types.ByteSize))
+	}
+	if ctx.IsSet(flags.KVBackend.Name) {
+		cfg.KVBackend = types.KVBackend(ctx.String(flags.KVBackend.Name))
+	}
+	setFromCLI(ctx, flags.KVPebbleCacheSize.Name, &cfg.KVPebbleCacheSize, ctx.Uint64)
+	setFromCLI(ctx, flags.L1NodeAddr.Name, &cfg.L1URLs, ctx.StringSlice)
+	setFromCLI(ctx, flags.L1BeaconAddr.Name, &cfg.L1BeaconURL, ctx.String)
+	setFromCLI(ctx, flags.L1BeaconIgnore.Name, &cfg.L1BeaconIgnore, ctx.Bool)
+	setFromCLI(ctx, flags.L1TrustRPC.Name, &cfg.L1TrustRPC, ctx.Bool)
+	if ctx.IsSet(flags.L1RPCProviderKind.Name) {
+		cfg.L1RPCKind = sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name))
+	}
+	setFromCLI(ctx, flags.L1DialAttempts.Name, &cfg.L1DialAttempts, ctx.Uint)
+	setFromCLI(ctx, flags.L1Retries.Name, &cfg.L1Retries, ctx.Uint)
+	setFromCLI(ctx, flags.L1RetryBackoff.Name, &cfg.L1RetryBackoff, ctx.Duration)
+	setFromCLI(ctx, flags.L1RequestTimeout.Name, &cfg.L1RequestTimeout, ctx.Duration)
+	setFromCLI(ctx, flags.BeaconRequestTimeout.Name, &cfg.BeaconRequestTimeout, ctx.Duration)
+	setFromCLI(ctx, flags.Exec.Name, &cfg.ExecCmd, ctx.String)
+	setFromCLI(ctx, flags.Server.Name, &cfg.ServerMode, ctx.Bool)
+	setFromCLI(ctx, flags.APIAddress.Name, &cfg.APIAddress, ctx.String)
+	setFromCLI(ctx, flags.APITLSCert.Name, &cfg.APITLSCert, ctx.String)
+	setFromCLI(ctx, flags.APITLSKey.Name, &cfg.APITLSKey, ctx.String)
+	setFromCLI(ctx, flags.APITLSClientCA.Name, &cfg.APITLSClientCA, ctx.String)
+	setFromCLI(ctx, flags.APIAuthTokenFile.Name, &apiAuthTokenFile, ctx.String)
+	apiAuthToken, err := readSecret(flags.APIAuthTokenEnvVar, apiAuthTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("api auth token: %w", err)
+	}
+	cfg.APIAuthToken = apiAuthToken
+	setFromCLI(ctx, flags.HintsAllowed.Name, &cfg.AllowedHints, ctx.StringSlice)
+	if ctx.IsSet(flags.MaxPreimageSize.Name) {
+		cfg.MaxPreimageSize = uint64(*ctx.Generic(flags.MaxPreimageSize.Name).(*types.ByteSize))
+	}
+	setFromCLI(ctx, flags.CartesiMachineURL.Name, &cfg.CartesiMachineURL, ctx.String)
+	setFromCLI(ctx, flags.CartesiSnapshotDir.Name, &cfg.CartesiSnapshotDir, ctx.String)
+	setFromCLI(ctx, flags.CartesiRequestTimeout.Name, &cfg.CartesiTimeout, ctx.Duration)
+	setFromCLI(ctx, flags.DAServerAddr.Name, &cfg.DAURL, ctx.String)
+	setFromCLI(ctx, opmetrics.EnabledFlagName, &cfg.MetricsConfig.Enabled, ctx.Bool)
+	setFromCLI(ctx, opmetrics.ListenAddrFlagName, &cfg.MetricsConfig.ListenAddr, ctx.String)
+	setFromCLI(ctx, opmetrics.PortFlagName, &cfg.MetricsConfig.ListenPort, ctx.Int)
+	if ctx.IsSet(oplog.LevelFlagName) {
+		lvl := ctx.Generic(oplog.LevelFlagName).(*oplog.LevelFlagValue).Level()
+		cfg.LogLevel = &lvl
+	}
+	return cfg, nil
+}
+
+// readL1HeadFile reads the hex encoded L1 head hash from the file at path, ignoring surrounding whitespace.
+func readL1HeadFile(path string) (common.Hash, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %w", ErrInvalidL1HeadFile, err)
+	}
+	content := strings.TrimSpace(string(data))
+	l1Head := common.HexToHash(content)
 	if l1Head == (common.Hash{}) {
-		return nil, ErrInvalidL1Head
+		return common.Hash{}, fmt.Errorf("%w %s: %q is not a block hash", ErrInvalidL1HeadFile, path, content)
 	}
-	return &Config{
-		DataDir:             ctx.String(flags.DataDir.Name),
-		L1Head:              l1Head,
-		L1URL:               ctx.String(flags.L1NodeAddr.Name),
-		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
-		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
-		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
-		ExecCmd:             ctx.String(flags.Exec.Name),
-		ServerMode:          ctx.Bool(flags.Server.Name),
-		APIAddress:          ctx.String(flags.APIAddress.Name),
-		IsCustomChainConfig: false,
-	}, nil
+	return l1Head, nil
 }
 
-func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
+// isZeroHash reports whether s is an explicit, complete encoding of the zero hash, with or without 0x prefix.
+func isZeroHash(s string) bool {
+	s = strings.TrimPrefix(s, "0x")
+	return len(s) == 2*common.HashLength && strings.Trim(s, "0") == ""
+}
+
+// setFromCLI sets dst to the value of the named flag if it was set on the command line or with its env var.
+func setFromCLI[T any](ctx *cli.Context, name string, dst *T, get func(name string) T) {
+	if ctx.IsSet(name) {
+		*dst = get(name)
+	}
+}
+
+// loadNetworkConfig returns the rollup and L2 chain config of the predefined network with the given name.
+func loadNetworkConfig(name string) (*rollup.Config, *params.ChainConfig, error) {
+	chain := chaincfg.ChainByName(name)
+	if chain == nil {
+		return nil, nil, fmt.Errorf("%w: %q, available networks: %s", ErrInvalidNetwork, name, strings.Join(chaincfg.AvailableNetworks(), ", "))
+	}
+	rollupCfg, err := rollup.LoadOPStackRollupConfig(chain.ChainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load rollup config of network %s: %w", name, err)
+	}
+	chainCfg, err := params.LoadOPStackChainConfig(chain.ChainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load l2 chain config of network %s: %w", name, err)
+	}
+	return rollupCfg, chainCfg, nil
+}
+
+func loadRollupConfig(path string) (*rollup.Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
+		return nil, fmt.Errorf("read rollup config: %w", err)
+	}
+	var rollupCfg rollup.Config
+	if err := json.Unmarshal(data, &rollupCfg); err != nil {
+		return nil, fmt.Errorf("parse rollup config: %w", err)
+	}
+	return &rollupCfg, nil
+}
+
+// loadChainConfigFromGenesis returns the chain config of the op-geth genesis file at path.
+func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrL2GenesisNotFound, path)
+	} else if err != nil {
 		return nil, fmt.Errorf("read l2 genesis file: %w", err)
 	}
 	var genesis core.Genesis
-	err = json.Unmarshal(data, &genesis)
-	if err != nil {
-		return nil, fmt.Errorf("parse l2 genesis file: %w", err)
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrInvalidL2Genesis, path, err)
+	}
+	if genesis.Config == nil {
+		return nil, fmt.Errorf("%w: %s", ErrL2GenesisWithoutConfig, path)
 	}
 	return genesis.Config, nil
 }
@@ -1,12 +1,18 @@
 package config
 
 import (
+	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
@@ -89,7 +95,7 @@ func TestL2GenesisRequired(t *testing.T) {
 func TestFetchingArgConsistency(t *testing.T) {
 	t.Run("RequireL2WhenL1Set", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
+		cfg.L1URLs = []string{"https://example.com:1234"}
 		require.ErrorIs(t, cfg.Check(), ErrL1AndL2Inconsistent)
 	})
 	t.Run("RequireL1WhenL2Set", func(t *testing.T) {
@@ -99,13 +105,14 @@ func TestFetchingArgConsistency(t *testing.T) {
 	})
 	t.Run("AllowNeitherSet", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = ""
+		cfg.L1URLs = nil
 		cfg.L2URL = ""
 		require.NoError(t, cfg.Check())
 	})
 	t.Run("AllowBothSet", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
+		cfg.L1URLs = []string{"https://example.com:1234"}
+		cfg.L1BeaconURL = "https://example.com:9012"
 		cfg.L2URL = "https://example.com:4678"
 		require.NoError(t, cfg.Check())
 	})
@@ -131,13 +138,21 @@ func TestFetchingEnabled(t *testing.T) {
 
 	t.Run("FetchingNotEnabledWhenNoL2UrlSpecified", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
+		cfg.L1URLs = []string{"https://example.com:1234"}
 		require.False(t, cfg.FetchingEnabled(), "Should not enable L2 fetching when L2 node URL not supplied")
 	})
 
-	t.Run("FetchingEnabledWhenBothFetcherUrlsSpecified", func(t *testing.T) {
+	t.Run("FetchingNotEnabledWhenNoBeaconUrlSpecified", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
+		cfg.L1URLs = []string{"https://example.com:1234"}
+		cfg.L2URL = "https://example.com:5678"
+		require.False(t, cfg.FetchingEnabled(), "Should not enable fetching when required beacon URL not supplied")
+	})
+
+	t.Run("FetchingEnabledWhenAllFetcherUrlsSpecified", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1URLs = []string{"https://example.com:1234"}
+		cfg.L1BeaconURL = "https://example.com:9012"
 		cfg.L2URL = "https://example.com:5678"
 		require.True(t, cfg.FetchingEnabled(), "Should enable fetching when node URL supplied")
 	})
@@ -146,7 +161,7 @@ func TestFetchingEnabled(t *testing.T) {
 func TestRequireDataDirInNonFetchingMode(t *testing.T) {
 	cfg := validConfig()
 	cfg.DataDir = ""
-	cfg.L1URL = ""
+	cfg.L1URLs = nil
 	cfg.L2URL = ""
 	err := cfg.Check()
 	require.ErrorIs(t, err, ErrDataDirRequired)
@@ -173,6 +188,167 @@ func TestIsCustomChainConfig(t *testing.T) {
 
 }
 
+func TestL1URLs(t *testing.T) {
+	t.Run("AllValid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1URLs = []string{"https://example.com:1234", "http://localhost:8545"}
+		cfg.L1BeaconURL = "https://example.com:9012"
+		cfg.L2URL = "https://example.com:5678"
+		require.NoError(t, cfg.Check())
+		require.True(t, cfg.FetchingEnabled())
+		require.Equal(t, "https://example.com:1234", cfg.L1URL())
+	})
+	for _, invalid := range []string{"", "example.com", "http://", "://example.com", "http://exa mple.com"} {
+		invalid := invalid
+		t.Run("Mixed "+invalid, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.L1URLs = []string{"https://example.com:1234", invalid}
+			cfg.L2URL = "https://example.com:5678"
+			err := cfg.Check()
+			require.ErrorIs(t, err, ErrInvalidL1URL)
+			require.ErrorContains(t, err, fmt.Sprintf("%q", invalid))
+		})
+	}
+	t.Run("EmptyAccessor", func(t *testing.T) {
+		require.Equal(t, "", validConfig().L1URL())
+	})
+}
+
+func TestCheckErrors(t *testing.T) {
+	tlsFiles := writeTLSFiles(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte("not a certificate"), 0o600))
+	fetching := func(cfg *Config) {
+		cfg.L1URLs = []string{"http://l1"}
+		cfg.L1BeaconURL = "http://beacon"
+		cfg.L2URL = "http://l2"
+	}
+	otherChain := *validL2Genesis
+	otherChain.ChainID = big.NewInt(10)
+
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		flag     string
+		expected error
+	}{
+		{"MissingRollupConfig", func(cfg *Config) { cfg.Rollup = nil }, flags.RollupConfig.Name, ErrMissingRollupConfig},
+		{"InvalidRollupConfig", func(cfg *Config) { cfg.Rollup = &rollup.Config{} }, flags.RollupConfig.Name, ErrInvalidRollupConfig},
+		{"UnknownPreset", func(cfg *Config) { cfg.Preset = "unknown" }, flags.Preset.Name, ErrUnknownPreset},
+		{"PresetChainMismatch", func(cfg *Config) { cfg.Preset = "op-mainnet" }, flags.Preset.Name, ErrPresetChainMismatch},
+		{"MissingL2ChainConfig", func(cfg *Config) { cfg.L2ChainConfig = nil }, flags.L2GenesisPath.Name, ErrMissingL2Genesis},
+		{"L2ChainIDMismatch", func(cfg *Config) { cfg.L2ChainConfig = &otherChain }, flags.L2GenesisPath.Name, ErrL2ChainIDMismatch},
+		{"MissingL1Head", func(cfg *Config) { cfg.L1Head = common.Hash{} }, flags.L1Head.Name, ErrInvalidL1Head},
+		{"MissingL1HeadInServerModeWithoutFetching", func(cfg *Config) { cfg.L1Head, cfg.ServerMode = common.Hash{}, true }, flags.L1Head.Name, ErrInvalidL1Head},
+		{"MissingL1HeadWithoutServerMode", func(cfg *Config) { fetching(cfg); cfg.L1Head = common.Hash{} }, flags.L1Head.Name, ErrInvalidL1Head},
+		{"MissingL2Head", func(cfg *Config) { cfg.L2Head = common.Hash{} }, flags.L2Head.Name, ErrInvalidL2Head},
+		{"MissingL2OutputRoot", func(cfg *Config) { cfg.L2OutputRoot = common.Hash{} }, flags.L2OutputRoot.Name, ErrInvalidL2OutputRoot},
+		{"MissingL2ClaimBlockNumber", func(cfg *Config) { cfg.L2ClaimBlockNumber = 0 }, flags.L2BlockNumber.Name, ErrInvalidL2ClaimBlock},
+		{"InvalidL1URL", func(cfg *Config) { fetching(cfg); cfg.L1URLs = []string{"l1"} }, flags.L1NodeAddr.Name, ErrInvalidL1URL},
+		{"InvalidL2URL", func(cfg *Config) { fetching(cfg); cfg.L2URL = "l2" }, flags.L2NodeAddr.Name, ErrInvalidL2URL},
+		{"L1WithoutL2", func(cfg *Config) { cfg.L1URLs = []string{"http://l1"} }, flags.L2NodeAddr.Name, ErrL1AndL2Inconsistent},
+		{"L2WithoutL1", func(cfg *Config) { cfg.L2URL = "http://l2" }, flags.L1NodeAddr.Name, ErrL1AndL2Inconsistent},
+		{"InvalidL1BeaconURL", func(cfg *Config) { fetching(cfg); cfg.L1BeaconURL = "beacon" }, flags.L1BeaconAddr.Name, ErrInvalidL1BeaconURL},
+		{"MissingL1BeaconURL", func(cfg *Config) { fetching(cfg); cfg.L1BeaconURL = "" }, flags.L1BeaconAddr.Name, ErrMissingL1BeaconURL},
+		{"InvalidL1RPCKind", func(cfg *Config) { cfg.L1RPCKind = "unknown" }, flags.L1RPCProviderKind.Name, ErrInvalidL1RPCKind},
+		{"InvalidDAURL", func(cfg *Config) { cfg.DAURL = "da" }, flags.DAServerAddr.Name, ErrInvalidDAURL},
+		{"MissingDataDirWithoutFetching", func(cfg *Config) { cfg.DataDir = "" }, flags.DataDir.Name, ErrDataDirRequired},
+		{"InvalidL1DialAttempts", func(cfg *Config) { cfg.L1DialAttempts = 0 }, flags.L1DialAttempts.Name, ErrInvalidL1DialAttempts},
+		{"InvalidL1RetryBackoff", func(cfg *Config) { cfg.L1RetryBackoff = 0 }, flags.L1RetryBackoff.Name, ErrInvalidL1RetryBackoff},
+		{"InvalidL1RequestTimeout", func(cfg *Config) { cfg.L1RequestTimeout = -1 }, flags.L1RequestTimeout.Name, ErrInvalidRequestTimeout},
+		{"InvalidBeaconRequestTimeout", func(cfg *Config) { cfg.BeaconRequestTimeout = 0 }, flags.BeaconRequestTimeout.Name, ErrInvalidRequestTimeout},
+		{"InvalidKVBackend", func(cfg *Config) { cfg.KVBackend = "unknown" }, flags.KVBackend.Name, ErrInvalidKVBackend},
+		{"KVBackendWithoutDataDir", func(cfg *Config) { fetching(cfg); cfg.DataDir, cfg.KVBackend = "", types.KVBackendDisk }, flags.DataDir.Name, ErrKVBackendRequiresDataDir},
+		{"MemKVReadOnly", func(cfg *Config) { fetching(cfg); cfg.DataDir, cfg.DataDirReadOnly = "", true }, flags.DataDirReadOnly.Name, ErrMemKVReadOnly},
+		{"MemKVWithDataDir", func(cfg *Config) { cfg.KVBackend = types.KVBackendMem }, flags.DataDir.Name, ErrMemKVWithDataDir},
+		{"ReadOnlyWithFetching", func(cfg *Config) { fetching(cfg); cfg.DataDirReadOnly = true }, flags.DataDirReadOnly.Name, ErrDataDirReadOnlyWithFetching},
+		{"MaxSizeWithoutDataDir", func(cfg *Config) { fetching(cfg); cfg.DataDir, cfg.DataDirMaxSize = "", 1024 }, flags.DataDirMaxSize.Name, ErrDataDirMaxSizeWithoutDataDir},
+		{"InvalidPebbleCacheSize", func(cfg *Config) { cfg.KVBackend, cfg.KVPebbleCacheSize = types.KVBackendPebble, 0 }, flags.KVPebbleCacheSize.Name, ErrInvalidKVPebbleCacheSize},
+		{"ExecInServerMode", func(cfg *Config) { cfg.ServerMode, cfg.ExecCmd = true, "echo" }, flags.Exec.Name, ErrNoExecInServerMode},
+		{"BlankExecCmd", func(cfg *Config) { cfg.ExecCmd = "  " }, flags.Exec.Name, ErrInvalidExecCmd},
+		{"UnknownHintType", func(cfg *Config) { cfg.AllowedHints = []string{"unknown"} }, flags.HintsAllowed.Name, ErrUnknownHintType},
+		{"CartesiSourcesExclusive", func(cfg *Config) { cfg.CartesiMachineURL, cfg.CartesiSnapshotDir = "http://machine", dir }, flags.CartesiSnapshotDir.Name, ErrCartesiSourcesExclusive},
+		{"InvalidCartesiMachineURL", func(cfg *Config) { cfg.CartesiMachineURL = "ftp://machine" }, flags.CartesiMachineURL.Name, ErrInvalidCartesiMachineURL},
+		{"InvalidCartesiSnapshotDir", func(cfg *Config) { cfg.CartesiSnapshotDir = file }, flags.CartesiSnapshotDir.Name, ErrInvalidCartesiSnapshotDir},
+		{"MissingMetricsAddr", func(cfg *Config) { cfg.MetricsConfig.Enabled, cfg.MetricsConfig.ListenAddr = true, "" }, opmetrics.ListenAddrFlagName, ErrMissingMetricsAddr},
+		{"InvalidMetricsPort", func(cfg *Config) { cfg.MetricsConfig.Enabled, cfg.MetricsConfig.ListenPort = true, -1 }, opmetrics.PortFlagName, ErrInvalidMetricsPort},
+		{"MissingAPIAddress", func(cfg *Config) { cfg.APIAddress = "" }, flags.APIAddress.Name, ErrMissingAPIAddress},
+		{"InvalidAPIAddress", func(cfg *Config) { cfg.APIAddress = "localhost" }, flags.APIAddress.Name, ErrInvalidAPIAddress},
+		{"APITLSKeyWithoutCert", func(cfg *Config) { cfg.APITLSKey = tlsFiles.key }, flags.APITLSCert.Name, ErrAPITLSCertKeyInconsistent},
+		{"APITLSCertWithoutKey", func(cfg *Config) { cfg.APITLSCert = tlsFiles.cert }, flags.APITLSKey.Name, ErrAPITLSCertKeyInconsistent},
+		{"APITLSClientCAWithoutCert", func(cfg *Config) { cfg.APITLSClientCA = tlsFiles.ca }, flags.APITLSClientCA.Name, ErrAPITLSClientCAWithoutCert},
+		{"InvalidAPITLSKeyPair", func(cfg *Config) { cfg.APITLSCert, cfg.APITLSKey = file, tlsFiles.key }, flags.APITLSCert.Name, ErrInvalidAPITLS},
+		{"InvalidAPITLSClientCA", func(cfg *Config) {
+			cfg.APITLSCert, cfg.APITLSKey, cfg.APITLSClientCA = tlsFiles.cert, tlsFiles.key, file
+		}, flags.APITLSClientCA.Name, ErrInvalidAPITLSClientCA},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			test.modify(cfg)
+			err := cfg.Check()
+			require.ErrorIs(t, err, test.expected)
+			var flagErr *FlagError
+			require.ErrorAs(t, err, &flagErr)
+			require.Equal(t, test.flag, flagErr.Flag)
+			require.ErrorContains(t, err, "flag "+test.flag+": ")
+		})
+	}
+}
+
+func TestCheckValidConfigs(t *testing.T) {
+	tlsFiles := writeTLSFiles(t)
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{"Offline", func(cfg *Config) {}},
+		{"OfflineReadOnly", func(cfg *Config) { cfg.DataDirReadOnly = true }},
+		{"Fetching", func(cfg *Config) {
+			cfg.L1URLs = []string{"http://l1", "https://l1-fallback"}
+			cfg.L1BeaconURL = "http://beacon"
+			cfg.L2URL = "http://l2"
+			cfg.DataDir = ""
+		}},
+		{"FetchingWithoutBeacon", func(cfg *Config) {
+			cfg.L1URLs = []string{"http://l1"}
+			cfg.L2URL = "http://l2"
+			cfg.L1BeaconIgnore = true
+		}},
+		{"ServerMode", func(cfg *Config) { cfg.ServerMode = true }},
+		{"ServerModeResolvesL1Head", func(cfg *Config) {
+			cfg.L1URLs = []string{"http://l1"}
+			cfg.L1BeaconURL = "http://beacon"
+			cfg.L2URL = "http://l2"
+			cfg.ServerMode = true
+			cfg.L1Head = common.Hash{}
+		}},
+		{"Exec", func(cfg *Config) { cfg.ExecCmd = "op-program --server" }},
+		{"PebbleWithDAServer", func(cfg *Config) {
+			cfg.KVBackend = types.KVBackendPebble
+			cfg.DataDirMaxSize = 1 << 30
+			cfg.DAURL = "http://da"
+		}},
+		{"AllowedHints", func(cfg *Config) { cfg.AllowedHints = types.HintTypes }},
+		{"CartesiSnapshot", func(cfg *Config) { cfg.CartesiSnapshotDir = t.TempDir() }},
+		{"Metrics", func(cfg *Config) { cfg.MetricsConfig.Enabled = true }},
+		{"UnixSocketAPI", func(cfg *Config) { cfg.APIAddress = "unix:///tmp/op-program.sock" }},
+		{"APITLS", func(cfg *Config) {
+			cfg.APITLSCert, cfg.APITLSKey, cfg.APITLSClientCA = tlsFiles.cert, tlsFiles.key, tlsFiles.ca
+		}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			test.modify(cfg)
+			require.NoError(t, cfg.Check())
+		})
+	}
+}
+
 func validConfig() *Config {
 	cfg := NewConfig(validRollupConfig, validL2Genesis, validL1Head, validL2Head, validL2OutputRoot, validL2Claim, validL2ClaimBlockNum)
 	cfg.DataDir = "/tmp/configTest"
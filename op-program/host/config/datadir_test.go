@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+)
+
+func TestDataDirMaxSize(t *testing.T) {
+	t.Run("WithDataDir", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDirMaxSize = 1024
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("WithoutDataDir", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDir = ""
+		cfg.L1URLs = []string{"http://l1"}
+		cfg.L1BeaconURL = "http://beacon"
+		cfg.L2URL = "http://l2"
+		cfg.DataDirMaxSize = 1024
+		require.ErrorIs(t, cfg.Check(), ErrDataDirMaxSizeWithoutDataDir)
+	})
+
+	t.Run("MemBackend", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDir = ""
+		cfg.KVBackend = types.KVBackendMem
+		cfg.L1URLs = []string{"http://l1"}
+		cfg.L1BeaconURL = "http://beacon"
+		cfg.L2URL = "http://l2"
+		cfg.DataDirMaxSize = 1024
+		require.ErrorIs(t, cfg.Check(), ErrDataDirMaxSizeWithoutDataDir)
+	})
+
+	t.Run("FromCLI", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--datadir.max-size", "10GiB")...)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10<<30), cfg.DataDirMaxSize)
+		require.NoError(t, cfg.Check())
+
+		_, err = configFromArgs(t, append(requiredArgs(t), "--datadir.max-size", "10 parsecs")...)
+		require.ErrorContains(t, err, "invalid size")
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", requiredFileFields(t)+"data_dir = \"/data\"\ndata_dir_max_size = \"512MB\"\n")
+		cfg, err := configFromArgs(t, "--config", path)
+		require.NoError(t, err)
+		require.Equal(t, uint64(512_000_000), cfg.DataDirMaxSize)
+	})
+}
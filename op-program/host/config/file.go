@@ -0,0 +1,183 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"golang.org/x/exp/slog"
+)
+
+// ErrUnknownConfigKeys is returned when a config file contains keys that do not match any config field.
+var ErrUnknownConfigKeys = errors.New("unknown keys in config file")
+
+// fileConfig is the content of a config file given with --config. Its fields mirror the Config fields that
+// can be set with CLI flags. Fields are pointers so a file may only set a subset of them, the other fields
+// keep their default value unless set with a CLI flag or env var.
+type fileConfig struct {
+	// RollupConfig is the path of the rollup config file, L2Genesis the path of the l2 genesis file and
+	// Network the name of a predefined network, loaded like the rollup.config, l2.genesis and network flags.
+	// Secrets can't be set in the file, only the path of the file they are read from.
+	RollupConfig         *string                  `toml:"rollup_config" json:"rollup_config"`
+	L2Genesis            *string                  `toml:"l2_genesis" json:"l2_genesis"`
+	Network              *string                  `toml:"network" json:"network"`
+	Preset               *string                  `toml:"preset" json:"preset"`
+	DataDir              *string                  `toml:"data_dir" json:"data_dir"`
+	DataDirReadOnly      *bool                    `toml:"data_dir_read_only" json:"data_dir_read_only"`
+	DataDirMaxSize       *types.ByteSize          `toml:"data_dir_max_size" json:"data_dir_max_size"`
+	KVBackend            *types.KVBackend         `toml:"kv_backend" json:"kv_backend"`
+	KVPebbleCacheSize    *uint64                  `toml:"kv_pebble_cache_size" json:"kv_pebble_cache_size"`
+	L1Head               *common.Hash             `toml:"l1_head" json:"l1_head"`
+	L1HeadFile           *string                  `toml:"l1_head_file" json:"l1_head_file"`
+	L1URLs               *[]string                `toml:"l1_urls" json:"l1_urls"`
+	L1BeaconURL          *string                  `toml:"l1_beacon_url" json:"l1_beacon_url"`
+	L1BeaconIgnore       *bool                    `toml:"l1_beacon_ignore" json:"l1_beacon_ignore"`
+	L1TrustRPC           *bool                    `toml:"l1_trust_rpc" json:"l1_trust_rpc"`
+	L1RPCKind            *sources.RPCProviderKind `toml:"l1_rpc_kind" json:"l1_rpc_kind"`
+	L1DialAttempts       *uint                    `toml:"l1_dial_attempts" json:"l1_dial_attempts"`
+	L1Retries            *uint                    `toml:"l1_retries" json:"l1_retries"`
+	L1RetryBackoff       *duration                `toml:"l1_retry_backoff" json:"l1_retry_backoff"`
+	L1RequestTimeout     *duration                `toml:"l1_request_timeout" json:"l1_request_timeout"`
+	BeaconRequestTimeout *duration                `toml:"l1_beacon_request_timeout" json:"l1_beacon_request_timeout"`
+	L2Head               *common.Hash             `toml:"l2_head" json:"l2_head"`
+	L2OutputRoot         *common.Hash             `toml:"l2_output_root" json:"l2_output_root"`
+	L2URL                *string                  `toml:"l2_url" json:"l2_url"`
+	L2Claim              *common.Hash             `toml:"l2_claim" json:"l2_claim"`
+	L2ClaimBlockNumber   *uint64                  `toml:"l2_claim_block_number" json:"l2_claim_block_number"`
+	ExecCmd              *string                  `toml:"exec_cmd" json:"exec_cmd"`
+	ServerMode           *bool                    `toml:"server_mode" json:"server_mode"`
+	APIAddress           *string                  `toml:"api_address" json:"api_address"`
+	APITLSCert           *string                  `toml:"api_tls_cert" json:"api_tls_cert"`
+	APITLSKey            *string                  `toml:"api_tls_key" json:"api_tls_key"`
+	APITLSClientCA       *string                  `toml:"api_tls_client_ca" json:"api_tls_client_ca"`
+	APIAuthTokenFile     *string                  `toml:"api_auth_token_file" json:"api_auth_token_file"`
+	AllowedHints         *[]string                `toml:"hints_allowed" json:"hints_allowed"`
+	MaxPreimageSize      *types.ByteSize          `toml:"max_preimage_size" json:"max_preimage_size"`
+	CartesiMachineURL    *string                  `toml:"cartesi_machine_url" json:"cartesi_machine_url"`
+	CartesiSnapshotDir   *string                  `toml:"cartesi_snapshot_dir" json:"cartesi_snapshot_dir"`
+	CartesiTimeout       *duration                `toml:"cartesi_request_timeout" json:"cartesi_request_timeout"`
+	DAURL                *string                  `toml:"da_url" json:"da_url"`
+	MetricsEnabled       *bool                    `toml:"metrics_enabled" json:"metrics_enabled"`
+	MetricsAddr          *string                  `toml:"metrics_addr" json:"metrics_addr"`
+	MetricsPort          *int                     `toml:"metrics_port" json:"metrics_port"`
+	LogLevel             *logLevel                `toml:"log_level" json:"log_level"`
+}
+
+// loadConfigFile reads the config file at path, as JSON if it has a .json extension and as TOML otherwise.
+// Keys that do not match any field are rejected with ErrUnknownConfigKeys to catch typos.
+func loadConfigFile(path string) (*fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	var file fileConfig
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&file); err != nil {
+			if strings.HasPrefix(err.Error(), "json: unknown field") {
+				return nil, fmt.Errorf("%w %s: %v", ErrUnknownConfigKeys, path, err)
+			}
+			return nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	} else {
+		md, err := toml.Decode(string(data), &file)
+		if err != nil {
+			return nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("%w %s: %v", ErrUnknownConfigKeys, path, undecoded)
+		}
+	}
+	if file.L1RPCKind != nil && !sources.ValidRPCProviderKind(*file.L1RPCKind) {
+		return nil, fmt.Errorf("invalid l1_rpc_kind %q in config file %s", *file.L1RPCKind, path)
+	}
+	return &file, nil
+}
+
+// apply sets the fields of cfg that are set in the file, except for the rollup config and l2 genesis paths,
+// network, preset and secret files.
+func (f *fileConfig) apply(cfg *Config) {
+	setIfPresent(&cfg.DataDir, f.DataDir)
+	setIfPresent(&cfg.DataDirReadOnly, f.DataDirReadOnly)
+	setIfPresent((*types.ByteSize)(&cfg.DataDirMaxSize), f.DataDirMaxSize)
+	setIfPresent(&cfg.KVBackend, f.KVBackend)
+	setIfPresent(&cfg.KVPebbleCacheSize, f.KVPebbleCacheSize)
+	setIfPresent(&cfg.L1Head, f.L1Head)
+	setIfPresent(&cfg.L1HeadFile, f.L1HeadFile)
+	setIfPresent(&cfg.L1URLs, f.L1URLs)
+	setIfPresent(&cfg.L1BeaconURL, f.L1BeaconURL)
+	setIfPresent(&cfg.L1BeaconIgnore, f.L1BeaconIgnore)
+	setIfPresent(&cfg.L1TrustRPC, f.L1TrustRPC)
+	setIfPresent(&cfg.L1RPCKind, f.L1RPCKind)
+	setIfPresent(&cfg.L1DialAttempts, f.L1DialAttempts)
+	setIfPresent(&cfg.L1Retries, f.L1Retries)
+	setIfPresent((*duration)(&cfg.L1RetryBackoff), f.L1RetryBackoff)
+	setIfPresent((*duration)(&cfg.L1RequestTimeout), f.L1RequestTimeout)
+	setIfPresent((*duration)(&cfg.BeaconRequestTimeout), f.BeaconRequestTimeout)
+	setIfPresent(&cfg.L2Head, f.L2Head)
+	setIfPresent(&cfg.L2OutputRoot, f.L2OutputRoot)
+	setIfPresent(&cfg.L2URL, f.L2URL)
+	setIfPresent(&cfg.L2Claim, f.L2Claim)
+	setIfPresent(&cfg.L2ClaimBlockNumber, f.L2ClaimBlockNumber)
+	setIfPresent(&cfg.ExecCmd, f.ExecCmd)
+	setIfPresent(&cfg.ServerMode, f.ServerMode)
+	setIfPresent(&cfg.APIAddress, f.APIAddress)
+	setIfPresent(&cfg.APITLSCert, f.APITLSCert)
+	setIfPresent(&cfg.APITLSKey, f.APITLSKey)
+	setIfPresent(&cfg.APITLSClientCA, f.APITLSClientCA)
+	setIfPresent(&cfg.AllowedHints, f.AllowedHints)
+	setIfPresent((*types.ByteSize)(&cfg.MaxPreimageSize), f.MaxPreimageSize)
+	setIfPresent(&cfg.CartesiMachineURL, f.CartesiMachineURL)
+	setIfPresent(&cfg.CartesiSnapshotDir, f.CartesiSnapshotDir)
+	setIfPresent((*duration)(&cfg.CartesiTimeout), f.CartesiTimeout)
+	setIfPresent(&cfg.DAURL, f.DAURL)
+	setIfPresent(&cfg.MetricsConfig.Enabled, f.MetricsEnabled)
+	setIfPresent(&cfg.MetricsConfig.ListenAddr, f.MetricsAddr)
+	setIfPresent(&cfg.MetricsConfig.ListenPort, f.MetricsPort)
+	if f.LogLevel != nil {
+		lvl := slog.Level(*f.LogLevel)
+		cfg.LogLevel = &lvl
+	}
+}
+
+// duration is a time.Duration written as a string, like "10s", in config files.
+type duration time.Duration
+
+func (d *duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+// logLevel is a log level written by name, like "debug", in config files.
+type logLevel slog.Level
+
+func (l *logLevel) UnmarshalText(text []byte) error {
+	lvl, err := oplog.LevelFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*l = logLevel(lvl)
+	return nil
+}
+
+func setIfPresent[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
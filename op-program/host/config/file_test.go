@@ -0,0 +1,262 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func writeRollupConfig(t *testing.T) string {
+	data, err := json.Marshal(validRollupConfig)
+	require.NoError(t, err)
+	return writeConfigFile(t, "rollup.json", string(data))
+}
+
+func writeL2Genesis(t *testing.T) string {
+	data, err := json.Marshal(core.Genesis{Config: validL2Genesis, Difficulty: common.Big0, Alloc: core.GenesisAlloc{}})
+	require.NoError(t, err)
+	return writeConfigFile(t, "genesis.json", string(data))
+}
+
+// requiredFileFields returns TOML setting the options without default value.
+func requiredFileFields(t *testing.T) string {
+	return fmt.Sprintf(`
+rollup_config = %q
+l2_genesis = %q
+l1_head = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+l2_head = "0x00000000000000000000000000000000000000000000000000000000000000bb"
+l2_output_root = "0x00000000000000000000000000000000000000000000000000000000000000cc"
+l2_claim = "0x0000000000000000000000000000000000000000000000000000000000000000"
+l2_claim_block_number = 15
+`, writeRollupConfig(t), writeL2Genesis(t))
+}
+
+// requiredArgs returns the CLI flags setting the options without default value.
+func requiredArgs(t *testing.T) []string {
+	return []string{
+		"--rollup.config", writeRollupConfig(t),
+		"--l2.genesis", writeL2Genesis(t),
+		"--l1.head", validL1Head.Hex(),
+		"--l2.head", validL2Head.Hex(),
+		"--l2.outputroot", validL2OutputRoot.Hex(),
+		"--l2.claim", validL2Claim.Hex(),
+		"--l2.blocknumber", fmt.Sprint(validL2ClaimBlockNum),
+	}
+}
+
+// freshFlags returns copies of the flags, as urfave/cli records the env vars it read and parsed values in the flags themselves.
+func freshFlags() []cli.Flag {
+	out := make([]cli.Flag, len(flags.Flags))
+	for i, f := range flags.Flags {
+		v := reflect.ValueOf(f).Elem()
+		c := reflect.New(v.Type())
+		c.Elem().Set(v)
+		out[i] = c.Interface().(cli.Flag)
+		// generic flags parse into their value, which is shared by shallow copies
+		if g, ok := out[i].(*cli.GenericFlag); ok {
+			if value, ok := g.Value.(interface{ Clone() any }); ok {
+				g.Value = value.Clone().(cli.Generic)
+			}
+		}
+	}
+	return out
+}
+
+func configFromArgs(t *testing.T, args ...string) (*Config, error) {
+	var cfg *Config
+	app := cli.NewApp()
+	app.Flags = freshFlags()
+	app.Action = func(ctx *cli.Context) (err error) {
+		cfg, err = NewConfigFromCLI(testlog.Logger(t, 0), ctx)
+		return err
+	}
+	err := app.Run(append([]string{"op-program"}, args...))
+	return cfg, err
+}
+
+func TestConfigFilePrecedence(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", requiredFileFields(t)+`
+l1_urls = ["http://file-l1"]
+l1_beacon_url = "http://file-beacon"
+l2_url = "http://file-l2"
+data_dir = "/file/data"
+`)
+
+	t.Run("FileOverridesDefaults", func(t *testing.T) {
+		cfg, err := configFromArgs(t, "--config", path)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{31: 0xaa}, cfg.L1Head)
+		require.Equal(t, common.Hash{31: 0xbb}, cfg.L2Head)
+		require.Equal(t, common.Hash{31: 0xcc}, cfg.L2OutputRoot)
+		require.Equal(t, common.Hash{}, cfg.L2Claim)
+		require.EqualValues(t, 15, cfg.L2ClaimBlockNumber)
+		require.Equal(t, validRollupConfig, cfg.Rollup)
+		require.Equal(t, validL2Genesis, cfg.L2ChainConfig)
+		require.Equal(t, []string{"http://file-l1"}, cfg.L1URLs)
+		require.Equal(t, "http://file-l2", cfg.L2URL)
+		require.Equal(t, "/file/data", cfg.DataDir)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("EnvOverridesFile", func(t *testing.T) {
+		t.Setenv("OP_PROGRAM_L1_RPC", "http://env-l1")
+		t.Setenv("OP_PROGRAM_DATADIR", "/env/data")
+		cfg, err := configFromArgs(t, "--config", path)
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://env-l1"}, cfg.L1URLs)
+		require.Equal(t, "/env/data", cfg.DataDir)
+		require.Equal(t, "http://file-beacon", cfg.L1BeaconURL)
+	})
+
+	t.Run("CLIOverridesEnv", func(t *testing.T) {
+		t.Setenv("OP_PROGRAM_L1_RPC", "http://env-l1")
+		cfg, err := configFromArgs(t, "--config", path, "--l1", "http://cli-l1", "--l1.head", common.Hash{0xbb}.Hex(),
+			"--l2.blocknumber", "20")
+		require.NoError(t, err)
+		require.Equal(t, []string{"http://cli-l1"}, cfg.L1URLs)
+		require.Equal(t, common.Hash{0xbb}, cfg.L1Head)
+		require.EqualValues(t, 20, cfg.L2ClaimBlockNumber)
+		require.Equal(t, "/file/data", cfg.DataDir)
+	})
+}
+
+func TestConfigFileSubset(t *testing.T) {
+	// a JSON file only setting some of the fields leaves the others at their default
+	path := writeConfigFile(t, "config.json", `{"server_mode": true, "l1_rpc_kind": "alchemy"}`)
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--config", path, "--datadir", "/data")...)
+	require.NoError(t, err)
+
+	expected := validConfig()
+	expected.DataDir = "/data"
+	expected.ServerMode = true
+	expected.L1RPCKind = sources.RPCKindAlchemy
+	expected.IsCustomChainConfig = true
+	require.Equal(t, expected, cfg)
+	require.NoError(t, cfg.Check())
+}
+
+func TestConfigFileCheckedAfterMerge(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", requiredFileFields(t)+`
+server_mode = true
+exec_cmd = "/bin/client"
+data_dir = "/data"
+`)
+	cfg, err := configFromArgs(t, "--config", path)
+	require.NoError(t, err)
+	require.ErrorIs(t, cfg.Check(), ErrNoExecInServerMode)
+
+	cfg, err = configFromArgs(t, "--config", path, "--server=false")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Check())
+}
+
+func TestConfigFileErrors(t *testing.T) {
+	t.Run("UnknownTOMLKey", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `l1_urll = "http://l1"`)
+		_, err := configFromArgs(t, "--config", path)
+		require.ErrorIs(t, err, ErrUnknownConfigKeys)
+		require.ErrorContains(t, err, "l1_urll")
+	})
+
+	t.Run("UnknownJSONKey", func(t *testing.T) {
+		path := writeConfigFile(t, "config.json", `{"datadir": "/data"}`)
+		_, err := configFromArgs(t, "--config", path)
+		require.ErrorIs(t, err, ErrUnknownConfigKeys)
+		require.ErrorContains(t, err, "datadir")
+	})
+
+	t.Run("InvalidRPCKind", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `l1_rpc_kind = "foo"`)
+		_, err := configFromArgs(t, "--config", path)
+		require.ErrorContains(t, err, "invalid l1_rpc_kind")
+	})
+
+	t.Run("MissingL1Head", func(t *testing.T) {
+		fields := strings.Replace(requiredFileFields(t), "l1_head =", "# l1_head =", 1)
+		path := writeConfigFile(t, "config.toml", fields+`data_dir = "/data"`)
+		cfg, err := configFromArgs(t, "--config", path)
+		require.NoError(t, err)
+		require.ErrorIs(t, cfg.Check(), ErrInvalidL1Head)
+	})
+
+	t.Run("MissingRollupConfigFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", fmt.Sprintf("rollup_config = \"/does/not/exist.json\"\nl2_genesis = %q", writeL2Genesis(t)))
+		_, err := configFromArgs(t, "--config", path)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := configFromArgs(t, "--config", filepath.Join(t.TempDir(), "missing.toml"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("L1HeadRequiredWithoutFile", func(t *testing.T) {
+		_, err := configFromArgs(t)
+		require.ErrorContains(t, err, "flag l1.head is required")
+	})
+}
+
+func TestL2ClaimFromCLI(t *testing.T) {
+	args := func(claim string) []string {
+		return append(requiredArgs(t), "--datadir", "/data", "--l2.claim", claim)
+	}
+	for _, zero := range []string{common.Hash{}.Hex(), common.Hash{}.Hex()[2:]} {
+		cfg, err := configFromArgs(t, args(zero)...)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{}, cfg.L2Claim)
+	}
+	_, err := configFromArgs(t, args("something")...)
+	require.ErrorIs(t, err, ErrInvalidL2Claim)
+	_, err = configFromArgs(t, args("0x0")...)
+	require.ErrorIs(t, err, ErrInvalidL2Claim)
+}
+
+func TestL1URLsFromCLI(t *testing.T) {
+	args := append(requiredArgs(t), "--l2", "http://l2", "--l1.beacon", "http://beacon")
+	expected := []string{"http://l1-a", "http://l1-b", "http://l1-c"}
+
+	t.Run("Repeated", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(args, "--l1", "http://l1-a", "--l1", "http://l1-b", "--l1", "http://l1-c")...)
+		require.NoError(t, err)
+		require.Equal(t, expected, cfg.L1URLs)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("CommaSeparated", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(args, "--l1", "http://l1-a,http://l1-b", "--l1", "http://l1-c")...)
+		require.NoError(t, err)
+		require.Equal(t, expected, cfg.L1URLs)
+	})
+
+	t.Run("EnvVar", func(t *testing.T) {
+		t.Setenv("OP_PROGRAM_L1_RPC", "http://l1-a,http://l1-b,http://l1-c")
+		cfg, err := configFromArgs(t, args...)
+		require.NoError(t, err)
+		require.Equal(t, expected, cfg.L1URLs)
+	})
+
+	t.Run("MixedValidAndInvalid", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(args, "--l1", "http://l1-a,not-a-url")...)
+		require.NoError(t, err)
+		require.ErrorIs(t, cfg.Check(), ErrInvalidL1URL)
+	})
+}
@@ -0,0 +1,27 @@
+package config
+
+import "fmt"
+
+// FlagError is the error returned by Check for an invalid config, naming the flag of the offending option.
+// It wraps the sentinel error of the failed check, so it can be matched with errors.Is.
+type FlagError struct {
+	// Flag is the name of the flag that sets the offending option.
+	Flag string
+	Err  error
+}
+
+func (e *FlagError) Error() string {
+	return fmt.Sprintf("flag %s: %v", e.Flag, e.Err)
+}
+
+func (e *FlagError) Unwrap() error {
+	return e.Err
+}
+
+// flagError returns err as a FlagError of the named flag, or nil if err is nil.
+func flagError(flag string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &FlagError{Flag: flag, Err: err}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestL2GenesisFromCLI(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg, err := configFromArgs(t, requiredArgs(t)...)
+		require.NoError(t, err)
+		require.Equal(t, validL2Genesis, cfg.L2ChainConfig)
+		require.True(t, cfg.IsCustomChainConfig)
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", requiredFileFields(t))
+		cfg, err := configFromArgs(t, "--config", path)
+		require.NoError(t, err)
+		require.Equal(t, validL2Genesis, cfg.L2ChainConfig)
+		require.True(t, cfg.IsCustomChainConfig)
+	})
+
+	t.Run("RequiredWithRollupConfig", func(t *testing.T) {
+		_, err := configFromArgs(t, append(networkArgs(t), "--rollup.config", writeRollupConfig(t))...)
+		require.ErrorIs(t, err, ErrL2GenesisRequired)
+	})
+
+	t.Run("ConflictsWithNetwork", func(t *testing.T) {
+		_, err := configFromArgs(t, append(networkArgs(t), "--network", "op-sepolia", "--l2.genesis", writeL2Genesis(t))...)
+		require.ErrorIs(t, err, ErrNetworkAndL2Genesis)
+	})
+
+	tests := []struct {
+		name     string
+		genesis  func(t *testing.T) string
+		expected error
+	}{
+		{"MissingFile", func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.json") }, ErrL2GenesisNotFound},
+		{"InvalidJSON", func(t *testing.T) string { return writeConfigFile(t, "genesis.json", `{"config":`) }, ErrInvalidL2Genesis},
+		{"NotAGenesis", func(t *testing.T) string { return writeConfigFile(t, "genesis.json", `{"config": {}}`) }, ErrInvalidL2Genesis},
+		{"NoChainConfig", func(t *testing.T) string {
+			return writeConfigFile(t, "genesis.json", `{"difficulty": "0x0", "gasLimit": "0x0", "alloc": {}}`)
+		}, ErrL2GenesisWithoutConfig},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			path := test.genesis(t)
+			_, err := configFromArgs(t, append(networkArgs(t), "--rollup.config", writeRollupConfig(t), "--l2.genesis", path)...)
+			require.ErrorIs(t, err, test.expected)
+			require.ErrorContains(t, err, path)
+		})
+	}
+}
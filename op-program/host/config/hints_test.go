@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+)
+
+func TestAllowedHints(t *testing.T) {
+	t.Run("AllByDefault", func(t *testing.T) {
+		cfg := validConfig()
+		require.Empty(t, cfg.AllowedHints)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Known", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.AllowedHints = types.HintTypes
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.AllowedHints = []string{l1.HintL1BlockHeader, "l2-block-header"}
+		err := cfg.Check()
+		require.ErrorIs(t, err, ErrUnknownHintType)
+		require.ErrorContains(t, err, "l2-block-header")
+		require.ErrorContains(t, err, l1.HintL1KZGPointEvaluation)
+	})
+}
+
+func TestAllowedHintsFromCLI(t *testing.T) {
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data",
+		"--hints.allowed", l1.HintL1BlockHeader+","+l1.HintL1Receipts)...)
+	require.NoError(t, err)
+	require.Equal(t, []string{l1.HintL1BlockHeader, l1.HintL1Receipts}, cfg.AllowedHints)
+	require.NoError(t, cfg.Check())
+
+	path := writeConfigFile(t, "config.toml", requiredFileFields(t)+`
+data_dir = "/data"
+hints_allowed = ["l1-blob"]
+`)
+	cfg, err = configFromArgs(t, "--config", path)
+	require.NoError(t, err)
+	require.Equal(t, []string{l1.HintL1Blob}, cfg.AllowedHints)
+	require.NoError(t, cfg.Check())
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+)
+
+func TestKVBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  types.KVBackend
+		dataDir  string
+		readOnly bool
+		fetching bool
+		expected error
+	}{
+		{name: "DefaultWithDataDir", dataDir: "/data"},
+		{name: "DefaultWithoutDataDir", fetching: true},
+		{name: "DefaultReadOnly", dataDir: "/data", readOnly: true},
+		{name: "DefaultReadOnlyWithoutDataDir", readOnly: true, fetching: true, expected: ErrMemKVReadOnly},
+
+		{name: "Mem", backend: types.KVBackendMem, fetching: true},
+		{name: "MemWithDataDir", backend: types.KVBackendMem, dataDir: "/data", expected: ErrMemKVWithDataDir},
+		{name: "MemReadOnly", backend: types.KVBackendMem, readOnly: true, fetching: true, expected: ErrMemKVReadOnly},
+		{name: "MemWithoutFetching", backend: types.KVBackendMem, expected: ErrDataDirRequired},
+
+		{name: "Disk", backend: types.KVBackendDisk, dataDir: "/data"},
+		{name: "DiskFetching", backend: types.KVBackendDisk, dataDir: "/data", fetching: true},
+		{name: "DiskReadOnly", backend: types.KVBackendDisk, dataDir: "/data", readOnly: true},
+		{name: "DiskWithoutDataDir", backend: types.KVBackendDisk, fetching: true, expected: ErrKVBackendRequiresDataDir},
+		{name: "DiskReadOnlyFetching", backend: types.KVBackendDisk, dataDir: "/data", readOnly: true, fetching: true, expected: ErrDataDirReadOnlyWithFetching},
+
+		{name: "Pebble", backend: types.KVBackendPebble, dataDir: "/data"},
+		{name: "PebbleFetching", backend: types.KVBackendPebble, dataDir: "/data", fetching: true},
+		{name: "PebbleReadOnly", backend: types.KVBackendPebble, dataDir: "/data", readOnly: true},
+		{name: "PebbleWithoutDataDir", backend: types.KVBackendPebble, fetching: true, expected: ErrKVBackendRequiresDataDir},
+		{name: "PebbleReadOnlyFetching", backend: types.KVBackendPebble, dataDir: "/data", readOnly: true, fetching: true, expected: ErrDataDirReadOnlyWithFetching},
+
+		{name: "Unknown", backend: "leveldb", dataDir: "/data", expected: ErrInvalidKVBackend},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.KVBackend = test.backend
+			cfg.DataDir = test.dataDir
+			cfg.DataDirReadOnly = test.readOnly
+			if test.fetching {
+				cfg.L1URLs = []string{"http://l1"}
+				cfg.L1BeaconURL = "http://beacon"
+				cfg.L2URL = "http://l2"
+			}
+			err := cfg.Check()
+			if test.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.expected)
+			}
+		})
+	}
+
+	t.Run("UnknownListsValidOptions", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.KVBackend = "leveldb"
+		require.ErrorContains(t, cfg.Check(), "mem, disk, pebble")
+	})
+
+	t.Run("PebbleWithoutCache", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.KVBackend = types.KVBackendPebble
+		cfg.KVPebbleCacheSize = 0
+		require.ErrorIs(t, cfg.Check(), ErrInvalidKVPebbleCacheSize)
+	})
+}
+
+func TestResolvedKVBackend(t *testing.T) {
+	cfg := validConfig()
+	require.Equal(t, types.KVBackendDisk, cfg.ResolvedKVBackend())
+	cfg.DataDir = ""
+	require.Equal(t, types.KVBackendMem, cfg.ResolvedKVBackend())
+	cfg.KVBackend = types.KVBackendPebble
+	require.Equal(t, types.KVBackendPebble, cfg.ResolvedKVBackend())
+}
+
+func TestKVBackendFromCLI(t *testing.T) {
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data",
+		"--kv.backend", "pebble", "--kv.pebble.cache-size", "64", "--datadir.readonly")...)
+	require.NoError(t, err)
+	require.Equal(t, types.KVBackendPebble, cfg.KVBackend)
+	require.EqualValues(t, 64, cfg.KVPebbleCacheSize)
+	require.True(t, cfg.DataDirReadOnly)
+	require.NoError(t, cfg.Check())
+
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--kv.backend", "leveldb")...)
+	require.NoError(t, err)
+	require.ErrorIs(t, cfg.Check(), ErrInvalidKVBackend)
+}
+
+func TestKVBackendFromFile(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", requiredFileFields(t)+`
+data_dir = "/data"
+kv_backend = "pebble"
+kv_pebble_cache_size = 16
+data_dir_read_only = true
+`)
+	cfg, err := configFromArgs(t, "--config", path, "--kv.backend", "disk")
+	require.NoError(t, err)
+	require.Equal(t, types.KVBackendDisk, cfg.KVBackend)
+	require.EqualValues(t, 16, cfg.KVPebbleCacheSize)
+	require.True(t, cfg.DataDirReadOnly)
+	require.NoError(t, cfg.Check())
+}
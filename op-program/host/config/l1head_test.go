@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// l1HeadArgs returns the required args without the l1 head.
+func l1HeadArgs(t *testing.T) []string {
+	args := requiredArgs(t)
+	for i, arg := range args {
+		if arg == "--l1.head" {
+			return append(args[:i:i], args[i+2:]...)
+		}
+	}
+	t.Fatal("no l1.head in required args")
+	return nil
+}
+
+func TestL1HeadFile(t *testing.T) {
+	l1Head := common.Hash{0xee}
+
+	t.Run("FromCLI", func(t *testing.T) {
+		path := writeConfigFile(t, "l1head", fmt.Sprintf("  %v\n", l1Head))
+		cfg, err := configFromArgs(t, append(l1HeadArgs(t), "--l1.head.file", path, "--datadir", "/data")...)
+		require.NoError(t, err)
+		require.Equal(t, l1Head, cfg.L1Head)
+		require.Equal(t, path, cfg.L1HeadFile)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		path := writeConfigFile(t, "l1head", l1Head.Hex())
+		configPath := writeConfigFile(t, "config.toml", fmt.Sprintf("l1_head_file = %q", path))
+		cfg, err := configFromArgs(t, append(l1HeadArgs(t), "--config", configPath)...)
+		require.NoError(t, err)
+		require.Equal(t, l1Head, cfg.L1Head)
+	})
+
+	t.Run("ReadOnReload", func(t *testing.T) {
+		path := writeConfigFile(t, "l1head", l1Head.Hex())
+		args := append(l1HeadArgs(t), "--l1.head.file", path)
+		cfg, err := configFromArgs(t, args...)
+		require.NoError(t, err)
+
+		next := common.Hash{0xff}
+		require.NoError(t, os.WriteFile(path, []byte(next.Hex()), 0o644))
+		reloaded, err := configFromArgs(t, args...)
+		require.NoError(t, err)
+		require.Equal(t, next, reloaded.L1Head)
+		require.Equal(t, []string{"l1Head"}, ChangedSettings(cfg, reloaded))
+		require.Equal(t, next, cfg.WithReloadedSettings(reloaded).L1Head)
+	})
+
+	t.Run("ConflictsWithL1Head", func(t *testing.T) {
+		path := writeConfigFile(t, "l1head", l1Head.Hex())
+		_, err := configFromArgs(t, append(requiredArgs(t), "--l1.head.file", path)...)
+		require.ErrorIs(t, err, ErrL1HeadAndL1HeadFile)
+	})
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"Empty", ""},
+		{"ZeroHash", common.Hash{}.Hex()},
+		{"NotAHash", "latest"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			path := writeConfigFile(t, "l1head", test.content)
+			_, err := configFromArgs(t, append(l1HeadArgs(t), "--l1.head.file", path)...)
+			require.ErrorIs(t, err, ErrInvalidL1HeadFile)
+			require.ErrorContains(t, err, path)
+		})
+	}
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := configFromArgs(t, append(l1HeadArgs(t), "--l1.head.file", filepath.Join(t.TempDir(), "missing"))...)
+		require.ErrorIs(t, err, ErrInvalidL1HeadFile)
+	})
+}
+
+func TestResolvesL1Head(t *testing.T) {
+	fetchingServer := func() *Config {
+		cfg := validConfig()
+		cfg.L1URLs = []string{"http://l1"}
+		cfg.L1BeaconURL = "http://beacon"
+		cfg.L2URL = "http://l2"
+		cfg.ServerMode = true
+		cfg.L1Head = common.Hash{}
+		return cfg
+	}
+	require.True(t, fetchingServer().ResolvesL1Head())
+
+	cfg := fetchingServer()
+	cfg.L1Head = common.Hash{0xee}
+	require.False(t, cfg.ResolvesL1Head(), "l1 head set")
+
+	cfg = fetchingServer()
+	cfg.ServerMode = false
+	require.False(t, cfg.ResolvesL1Head(), "not in server mode")
+	require.ErrorIs(t, cfg.Check(), ErrInvalidL1Head)
+
+	cfg = fetchingServer()
+	cfg.L1URLs, cfg.L2URL = nil, ""
+	require.False(t, cfg.ResolvesL1Head(), "fetching disabled")
+	require.ErrorIs(t, cfg.Check(), ErrInvalidL1Head)
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsConfig(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data")...)
+		require.NoError(t, err)
+		require.False(t, cfg.MetricsConfig.Enabled)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("FromCLI", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--metrics.enabled", "--metrics.addr", "127.0.0.1", "--metrics.port", "7400")...)
+		require.NoError(t, err)
+		require.True(t, cfg.MetricsConfig.Enabled)
+		require.Equal(t, "127.0.0.1", cfg.MetricsConfig.ListenAddr)
+		require.Equal(t, 7400, cfg.MetricsConfig.ListenPort)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("FromEnv", func(t *testing.T) {
+		t.Setenv("OP_PROGRAM_METRICS_ENABLED", "true")
+		t.Setenv("OP_PROGRAM_METRICS_PORT", "7500")
+		cfg, err := configFromArgs(t, requiredArgs(t)...)
+		require.NoError(t, err)
+		require.True(t, cfg.MetricsConfig.Enabled)
+		require.Equal(t, 7500, cfg.MetricsConfig.ListenPort)
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", requiredFileFields(t)+`
+metrics_enabled = true
+metrics_addr = "127.0.0.1"
+metrics_port = 7400
+`)
+		cfg, err := configFromArgs(t, "--config", path)
+		require.NoError(t, err)
+		require.True(t, cfg.MetricsConfig.Enabled)
+		require.Equal(t, "127.0.0.1", cfg.MetricsConfig.ListenAddr)
+		require.Equal(t, 7400, cfg.MetricsConfig.ListenPort)
+	})
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		addr     string
+		port     int
+		expected error
+	}{
+		{"Valid", true, "127.0.0.1", 7400, nil},
+		{"AnyPort", true, "127.0.0.1", 0, nil},
+		{"MissingAddr", true, "", 7400, ErrMissingMetricsAddr},
+		{"NegativePort", true, "127.0.0.1", -1, ErrInvalidMetricsPort},
+		{"PortTooLarge", true, "127.0.0.1", 65536, ErrInvalidMetricsPort},
+		{"IgnoredWhenDisabled", false, "", -1, nil},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.MetricsConfig.Enabled = test.enabled
+			cfg.MetricsConfig.ListenAddr = test.addr
+			cfg.MetricsConfig.ListenPort = test.port
+			require.ErrorIs(t, cfg.Check(), test.expected)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+)
+
+// networkArgs returns the CLI flags setting the options without default value, except the rollup config
+// and l2 genesis.
+func networkArgs(t *testing.T) []string {
+	args := requiredArgs(t)
+	require.Equal(t, "--rollup.config", args[0])
+	require.Equal(t, "--l2.genesis", args[2])
+	return args[4:]
+}
+
+func TestNetwork(t *testing.T) {
+	t.Run("Known", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(networkArgs(t), "--network", "op-sepolia", "--datadir", "/data")...)
+		require.NoError(t, err)
+		require.Equal(t, chaincfg.Sepolia, cfg.Rollup)
+		require.Equal(t, chainconfig.OPSepoliaChainConfig, cfg.L2ChainConfig)
+		require.False(t, cfg.IsCustomChainConfig)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("LegacyName", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(networkArgs(t), "--network", "sepolia")...)
+		require.NoError(t, err)
+		require.Equal(t, chaincfg.Sepolia, cfg.Rollup)
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `network = "op-sepolia"`)
+		cfg, err := configFromArgs(t, append(networkArgs(t), "--config", path)...)
+		require.NoError(t, err)
+		require.Equal(t, chaincfg.Sepolia, cfg.Rollup)
+		require.Equal(t, chainconfig.OPSepoliaChainConfig, cfg.L2ChainConfig)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := configFromArgs(t, append(networkArgs(t), "--network", "bar")...)
+		require.ErrorIs(t, err, ErrInvalidNetwork)
+		require.ErrorContains(t, err, `invalid network: "bar"`)
+		require.ErrorContains(t, err, "op-sepolia")
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		_, err := configFromArgs(t, networkArgs(t)...)
+		require.ErrorIs(t, err, ErrRollupConfigOrNetworkRequired)
+	})
+
+	t.Run("ConflictsWithRollupConfig", func(t *testing.T) {
+		_, err := configFromArgs(t, append(requiredArgs(t), "--network", "op-sepolia")...)
+		require.ErrorIs(t, err, ErrNetworkAndRollupConfig)
+	})
+
+	t.Run("ConflictsWithRollupConfigInFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", requiredFileFields(t))
+		_, err := configFromArgs(t, "--config", path, "--network", "op-sepolia")
+		require.ErrorIs(t, err, ErrNetworkAndRollupConfig)
+	})
+}
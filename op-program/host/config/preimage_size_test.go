@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxPreimageSizeFromCLI(t *testing.T) {
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data")...)
+	require.NoError(t, err)
+	require.Zero(t, cfg.MaxPreimageSize)
+
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data", "--max-preimage-size", "64MiB")...)
+	require.NoError(t, err)
+	require.EqualValues(t, 64<<20, cfg.MaxPreimageSize)
+	require.NoError(t, cfg.Check())
+
+	t.Setenv("OP_PROGRAM_MAX_PREIMAGE_SIZE", "1000")
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data")...)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, cfg.MaxPreimageSize)
+}
+
+func TestMaxPreimageSizeFromCLIInvalid(t *testing.T) {
+	_, err := configFromArgs(t, append(requiredArgs(t), "--max-preimage-size", "64 lots")...)
+	require.ErrorContains(t, err, "unknown unit")
+}
+
+func TestMaxPreimageSizeFromFile(t *testing.T) {
+	for _, value := range []string{`"2KiB"`, `2048`} {
+		path := writeConfigFile(t, "config.toml", requiredFileFields(t)+"max_preimage_size = "+value)
+		cfg, err := configFromArgs(t, "--config", path)
+		require.NoError(t, err)
+		require.EqualValues(t, 2048, cfg.MaxPreimageSize, value)
+	}
+
+	path := writeConfigFile(t, "config.json", `{"max_preimage_size": "1MB"}`)
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--config", path)...)
+	require.NoError(t, err)
+	require.EqualValues(t, 1_000_000, cfg.MaxPreimageSize)
+
+	path = writeConfigFile(t, "config.toml", requiredFileFields(t)+`max_preimage_size = "big"`)
+	_, err = configFromArgs(t, "--config", path)
+	require.ErrorContains(t, err, "invalid size")
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+var (
+	ErrUnknownPreset       = errors.New("unknown preset")
+	ErrPresetChainMismatch = errors.New("rollup config does not match the chains of the preset")
+)
+
+// Preset is a bundle of defaults for a well-known network, selected with the preset flag.
+// Options set in the config file, with flags or with env vars take precedence over the preset.
+type Preset struct {
+	// Network is the predefined network the rollup and L2 chain config are loaded from,
+	// unless the network or rollup.config flag is set.
+	Network string
+	// L1ChainID and L2ChainID are the chain IDs the rollup config is checked against.
+	L1ChainID uint64
+	L2ChainID uint64
+	// L1RPCKind is the receipts fetching method of the L1 RPC.
+	L1RPCKind sources.RPCProviderKind
+	// L1BeaconRequired requires an L1 beacon to fetch blobs, it sets l1.beacon.ignore if false.
+	L1BeaconRequired bool
+	// L1Retries, L1RetryBackoff and L1RequestTimeout are the retry settings of L1 and beacon requests.
+	L1Retries        uint
+	L1RetryBackoff   time.Duration
+	L1RequestTimeout time.Duration
+}
+
+// Presets are the presets by name.
+var Presets = map[string]Preset{
+	"op-mainnet": {
+		Network:          "op-mainnet",
+		L1ChainID:        1,
+		L2ChainID:        10,
+		L1RPCKind:        sources.RPCKindStandard,
+		L1BeaconRequired: true,
+		L1Retries:        10,
+		L1RetryBackoff:   30 * time.Second,
+		L1RequestTimeout: 30 * time.Second,
+	},
+	"op-sepolia": {
+		Network:          "op-sepolia",
+		L1ChainID:        11155111,
+		L2ChainID:        11155420,
+		L1RPCKind:        sources.RPCKindStandard,
+		L1BeaconRequired: true,
+		L1Retries:        5,
+		L1RetryBackoff:   10 * time.Second,
+		L1RequestTimeout: 30 * time.Second,
+	},
+	"base-mainnet": {
+		Network:          "base-mainnet",
+		L1ChainID:        1,
+		L2ChainID:        8453,
+		L1RPCKind:        sources.RPCKindStandard,
+		L1BeaconRequired: true,
+		L1Retries:        10,
+		L1RetryBackoff:   30 * time.Second,
+		L1RequestTimeout: 30 * time.Second,
+	},
+	"base-sepolia": {
+		Network:          "base-sepolia",
+		L1ChainID:        11155111,
+		L2ChainID:        84532,
+		L1RPCKind:        sources.RPCKindStandard,
+		L1BeaconRequired: true,
+		L1Retries:        5,
+		L1RetryBackoff:   10 * time.Second,
+		L1RequestTimeout: 30 * time.Second,
+	},
+}
+
+// PresetNames returns the names of the presets, sorted.
+func PresetNames() []string {
+	names := make([]string, 0, len(Presets))
+	for name := range Presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LookupPreset returns the preset with the given name.
+func LookupPreset(name string) (Preset, error) {
+	preset, ok := Presets[name]
+	if !ok {
+		return Preset{}, fmt.Errorf("%w %q, available presets: %s", ErrUnknownPreset, name, strings.Join(PresetNames(), ", "))
+	}
+	return preset, nil
+}
+
+// apply sets the defaults of the preset in cfg.
+func (p Preset) apply(cfg *Config) {
+	cfg.L1RPCKind = p.L1RPCKind
+	cfg.L1BeaconIgnore = !p.L1BeaconRequired
+	cfg.L1Retries = p.L1Retries
+	cfg.L1RetryBackoff = p.L1RetryBackoff
+	cfg.L1RequestTimeout = p.L1RequestTimeout
+}
+
+// checkPreset verifies the rollup config is for the chains of the preset, if one is set.
+func (c *Config) checkPreset() error {
+	if c.Preset == "" {
+		return nil
+	}
+	preset, err := LookupPreset(c.Preset)
+	if err != nil {
+		return err
+	}
+	if c.Rollup.L1ChainID == nil || c.Rollup.L1ChainID.Uint64() != preset.L1ChainID ||
+		c.Rollup.L2ChainID == nil || c.Rollup.L2ChainID.Uint64() != preset.L2ChainID {
+		return fmt.Errorf("%w %s: expected l1 chain %d and l2 chain %d, got l1 chain %v and l2 chain %v",
+			ErrPresetChainMismatch, c.Preset, preset.L1ChainID, preset.L2ChainID, c.Rollup.L1ChainID, c.Rollup.L2ChainID)
+	}
+	return nil
+}
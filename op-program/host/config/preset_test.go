@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// presetArgs returns the CLI flags a user of a preset supplies: the proof inputs and the L1, beacon and L2 URLs.
+func presetArgs(t *testing.T, preset string) []string {
+	return append(networkArgs(t), "--preset", preset,
+		"--l1", "http://l1", "--l1.beacon", "http://beacon", "--l2", "http://l2")
+}
+
+func TestPresets(t *testing.T) {
+	for _, name := range PresetNames() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			preset := Presets[name]
+			cfg, err := configFromArgs(t, presetArgs(t, name)...)
+			require.NoError(t, err)
+			require.NoError(t, cfg.Check())
+			require.Equal(t, name, cfg.Preset)
+			require.EqualValues(t, preset.L1ChainID, cfg.Rollup.L1ChainID.Uint64())
+			require.EqualValues(t, preset.L2ChainID, cfg.Rollup.L2ChainID.Uint64())
+			require.False(t, cfg.IsCustomChainConfig)
+			require.Equal(t, preset.L1RPCKind, cfg.L1RPCKind)
+			require.Equal(t, preset.L1Retries, cfg.L1Retries)
+			require.Equal(t, preset.L1RetryBackoff, cfg.L1RetryBackoff)
+			require.Equal(t, preset.L1RequestTimeout, cfg.L1RequestTimeout)
+			require.Equal(t, preset.L1BeaconRequired, cfg.L1BeaconRequired())
+			require.True(t, cfg.FetchingEnabled())
+		})
+	}
+}
+
+func TestPresetOverrides(t *testing.T) {
+	t.Run("FlagsOverridePreset", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(presetArgs(t, "op-sepolia"),
+			"--l1.rpckind", "alchemy", "--l1.retries", "2", "--l1.beacon.ignore")...)
+		require.NoError(t, err)
+		require.Equal(t, sources.RPCKindAlchemy, cfg.L1RPCKind)
+		require.EqualValues(t, 2, cfg.L1Retries)
+		require.True(t, cfg.L1BeaconIgnore)
+		require.Equal(t, Presets["op-sepolia"].L1RetryBackoff, cfg.L1RetryBackoff)
+	})
+
+	t.Run("FileOverridesPreset", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `
+l1_retry_backoff = "1s"
+`)
+		cfg, err := configFromArgs(t, append(presetArgs(t, "op-sepolia"), "--config", path)...)
+		require.NoError(t, err)
+		require.Equal(t, time.Second, cfg.L1RetryBackoff)
+		require.Equal(t, Presets["op-sepolia"].L1Retries, cfg.L1Retries)
+	})
+
+	t.Run("PresetFromFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `preset = "base-mainnet"`)
+		cfg, err := configFromArgs(t, append(networkArgs(t), "--config", path)...)
+		require.NoError(t, err)
+		require.Equal(t, "base-mainnet", cfg.Preset)
+		require.EqualValues(t, 8453, cfg.Rollup.L2ChainID.Uint64())
+	})
+}
+
+func TestPresetErrors(t *testing.T) {
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := configFromArgs(t, presetArgs(t, "op-moonnet")...)
+		require.ErrorIs(t, err, ErrUnknownPreset)
+		for _, name := range PresetNames() {
+			require.ErrorContains(t, err, name)
+		}
+	})
+
+	t.Run("ChainMismatch", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(presetArgs(t, "op-mainnet"), "--network", "op-sepolia")...)
+		require.NoError(t, err)
+		require.ErrorIs(t, cfg.Check(), ErrPresetChainMismatch)
+	})
+
+	t.Run("CustomRollupConfigMismatch", func(t *testing.T) {
+		cfg, err := configFromArgs(t, append(requiredArgs(t), "--preset", "op-mainnet", "--datadir", "/data")...)
+		require.NoError(t, err)
+		require.ErrorIs(t, cfg.Check(), ErrPresetChainMismatch)
+	})
+}
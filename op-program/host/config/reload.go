@@ -0,0 +1,40 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+)
+
+// ReloadableSettings are the LogSafeConfig keys of the settings a running preimage server applies when it
+// reloads its config. Changes to any other setting only take effect after a restart.
+var ReloadableSettings = []string{"logLevel", "l1Head", "allowedHints", "maxPreimageSize"}
+
+// ChangedSettings returns the LogSafeConfig keys of the settings that differ between the configs.
+func ChangedSettings(prev *Config, next *Config) []string {
+	prevKVs, nextKVs := prev.LogSafeConfig(), next.LogSafeConfig()
+	var changed []string
+	for i := 0; i < len(prevKVs); i += 2 {
+		if !reflect.DeepEqual(prevKVs[i+1], nextKVs[i+1]) {
+			changed = append(changed, prevKVs[i].(string))
+		}
+	}
+	return changed
+}
+
+// WithReloadedSettings returns a copy of the config with the reloadable settings of next.
+func (c *Config) WithReloadedSettings(next *Config) *Config {
+	cfg := *c
+	cfg.LogLevel = next.LogLevel
+	cfg.L1Head = next.L1Head
+	cfg.AllowedHints = slices.Clone(next.AllowedHints)
+	cfg.MaxPreimageSize = next.MaxPreimageSize
+	return &cfg
+}
+
+// logLevelName returns the name of the log level, or an empty string if it is not set.
+func (c *Config) logLevelName() string {
+	if c.LogLevel == nil {
+		return ""
+	}
+	return c.LogLevel.String()
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevel(t *testing.T) {
+	t.Run("NotSet", func(t *testing.T) {
+		cfg, err := configFromArgs(t, requiredArgs(t)...)
+		require.NoError(t, err)
+		require.Nil(t, cfg.LogLevel)
+	})
+
+	t.Run("FromFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `log_level = "debug"`)
+		cfg, err := configFromArgs(t, append(requiredArgs(t), "--config", path)...)
+		require.NoError(t, err)
+		require.Equal(t, log.LevelDebug, *cfg.LogLevel)
+	})
+
+	t.Run("CLIOverridesFile", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `log_level = "debug"`)
+		cfg, err := configFromArgs(t, append(requiredArgs(t), "--config", path, "--log.level", "warn")...)
+		require.NoError(t, err)
+		require.Equal(t, log.LevelWarn, *cfg.LogLevel)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		path := writeConfigFile(t, "config.toml", `log_level = "loud"`)
+		_, err := configFromArgs(t, append(requiredArgs(t), "--config", path)...)
+		require.ErrorContains(t, err, "unknown level: loud")
+	})
+}
+
+func TestChangedSettings(t *testing.T) {
+	cfg := validConfig()
+	require.Empty(t, ChangedSettings(cfg, validConfig()))
+
+	next := validConfig()
+	next.DataDir = "/other"
+	next.MaxPreimageSize = 1024
+	lvl := log.LevelDebug
+	next.LogLevel = &lvl
+	require.Equal(t, []string{"dataDir", "maxPreimageSize", "logLevel"}, ChangedSettings(cfg, next))
+
+	reloaded := cfg.WithReloadedSettings(next)
+	require.Equal(t, []string{"dataDir"}, ChangedSettings(reloaded, next))
+	require.Equal(t, cfg.DataDir, reloaded.DataDir)
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryDefaults(t *testing.T) {
+	cfg := validConfig()
+	require.EqualValues(t, 10, cfg.L1DialAttempts)
+	require.Zero(t, cfg.L1Retries)
+	require.Equal(t, 10*time.Second, cfg.L1RetryBackoff)
+	require.Zero(t, cfg.L1RequestTimeout)
+	require.Equal(t, 30*time.Second, cfg.BeaconRequestTimeout)
+}
+
+func TestCheckRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected error
+	}{
+		{"MaxDialAttempts", func(cfg *Config) { cfg.L1DialAttempts = maxL1DialAttempts }, nil},
+		{"NoDialAttempts", func(cfg *Config) { cfg.L1DialAttempts = 0 }, ErrInvalidL1DialAttempts},
+		{"TooManyDialAttempts", func(cfg *Config) { cfg.L1DialAttempts = maxL1DialAttempts + 1 }, ErrInvalidL1DialAttempts},
+		{"Retries", func(cfg *Config) { cfg.L1Retries = 5 }, nil},
+		{"MaxRetryBackoff", func(cfg *Config) { cfg.L1RetryBackoff = maxL1RetryBackoff }, nil},
+		{"ZeroRetryBackoff", func(cfg *Config) { cfg.L1RetryBackoff = 0 }, ErrInvalidL1RetryBackoff},
+		{"NegativeRetryBackoff", func(cfg *Config) { cfg.L1RetryBackoff = -time.Second }, ErrInvalidL1RetryBackoff},
+		{"TooLongRetryBackoff", func(cfg *Config) { cfg.L1RetryBackoff = maxL1RetryBackoff + 1 }, ErrInvalidL1RetryBackoff},
+		{"L1RequestTimeout", func(cfg *Config) { cfg.L1RequestTimeout = time.Minute }, nil},
+		{"NegativeL1RequestTimeout", func(cfg *Config) { cfg.L1RequestTimeout = -time.Second }, ErrInvalidRequestTimeout},
+		{"TooLongL1RequestTimeout", func(cfg *Config) { cfg.L1RequestTimeout = maxRequestTimeout + 1 }, ErrInvalidRequestTimeout},
+		{"ZeroBeaconRequestTimeout", func(cfg *Config) { cfg.BeaconRequestTimeout = 0 }, ErrInvalidRequestTimeout},
+		{"TooLongBeaconRequestTimeout", func(cfg *Config) { cfg.BeaconRequestTimeout = maxRequestTimeout + 1 }, ErrInvalidRequestTimeout},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			test.modify(cfg)
+			err := cfg.Check()
+			if test.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestRetriesFromCLI(t *testing.T) {
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data",
+		"--l1.dial-attempts", "3", "--l1.retries", "4", "--l1.retry-backoff", "2s",
+		"--l1.request-timeout", "5s", "--l1.beacon.request-timeout", "1m")...)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, cfg.L1DialAttempts)
+	require.EqualValues(t, 4, cfg.L1Retries)
+	require.Equal(t, 2*time.Second, cfg.L1RetryBackoff)
+	require.Equal(t, 5*time.Second, cfg.L1RequestTimeout)
+	require.Equal(t, time.Minute, cfg.BeaconRequestTimeout)
+	require.NoError(t, cfg.Check())
+}
+
+func TestRetriesFromFile(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+	}{
+		{"config.toml", requiredFileFields(t) + `
+data_dir = "/data"
+l1_dial_attempts = 3
+l1_retries = 4
+l1_retry_backoff = "2s"
+l1_request_timeout = "5s"
+l1_beacon_request_timeout = "1m"
+`},
+		{"config.json", `{"data_dir": "/data", "l1_dial_attempts": 3, "l1_retries": 4, "l1_retry_backoff": "2s",
+"l1_request_timeout": "5s", "l1_beacon_request_timeout": "1m"}`},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			args := []string{"--config", writeConfigFile(t, test.name, test.content)}
+			if test.name == "config.json" {
+				args = append(args, requiredArgs(t)...)
+			}
+			cfg, err := configFromArgs(t, args...)
+			require.NoError(t, err)
+			require.EqualValues(t, 3, cfg.L1DialAttempts)
+			require.EqualValues(t, 4, cfg.L1Retries)
+			require.Equal(t, 2*time.Second, cfg.L1RetryBackoff)
+			require.Equal(t, 5*time.Second, cfg.L1RequestTimeout)
+			require.Equal(t, time.Minute, cfg.BeaconRequestTimeout)
+		})
+	}
+
+	_, err := configFromArgs(t, "--config", writeConfigFile(t, "config.toml", requiredFileFields(t)+`
+l1_retry_backoff = "soon"
+`))
+	require.ErrorContains(t, err, "invalid duration")
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// redacted replaces secrets in the log representation of the config.
+const redacted = "redacted"
+
+// readSecret returns the secret set with the env var or in the file at path, if any.
+// Setting both is rejected so it is never ambiguous which secret is in use.
+func readSecret(envVar string, path string) (string, error) {
+	secret, inEnv := os.LookupEnv(envVar)
+	if inEnv && path != "" {
+		return "", fmt.Errorf("%w: %s and a secret file are both set", ErrSecretSources, envVar)
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read secret file: %w", err)
+		}
+		secret = string(data)
+	}
+	secret = strings.TrimSpace(secret)
+	if (inEnv || path != "") && secret == "" {
+		return "", fmt.Errorf("%w: secret of %s is empty", ErrEmptySecret, envVar)
+	}
+	return secret, nil
+}
+
+// redactURL returns the URL with its user info, query and fragment redacted, as RPC providers commonly put
+// credentials in them. URLs that cannot be parsed are redacted completely.
+func redactURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Opaque != "" {
+		return redacted
+	}
+	if u.User != nil {
+		u.User = url.User(redacted)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = redacted
+	}
+	if u.Fragment != "" {
+		u.Fragment = redacted
+	}
+	return u.String()
+}
+
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
+
+// LogSafeConfig returns the config as key-value pairs for logging, with secrets and URL credentials redacted.
+func (c *Config) LogSafeConfig() []any {
+	l1URLs := make([]string, len(c.L1URLs))
+	for i, l1URL := range c.L1URLs {
+		l1URLs[i] = redactURL(l1URL)
+	}
+	var l2ChainID any
+	if c.Rollup != nil {
+		l2ChainID = c.Rollup.L2ChainID
+	}
+	return []any{
+		"l2ChainID", l2ChainID,
+		"customChainConfig", c.IsCustomChainConfig,
+		"preset", c.Preset,
+		"l1Head", c.L1Head,
+		"l1HeadFile", c.L1HeadFile,
+		"l1URLs", l1URLs,
+		"l1BeaconURL", redactURL(c.L1BeaconURL),
+		"l1BeaconIgnore", c.L1BeaconIgnore,
+		"l1TrustRPC", c.L1TrustRPC,
+		"l1RPCKind", c.L1RPCKind,
+		"l1DialAttempts", c.L1DialAttempts,
+		"l1Retries", c.L1Retries,
+		"l1RetryBackoff", c.L1RetryBackoff,
+		"l1RequestTimeout", c.L1RequestTimeout,
+		"beaconRequestTimeout", c.BeaconRequestTimeout,
+		"l2Head", c.L2Head,
+		"l2OutputRoot", c.L2OutputRoot,
+		"l2URL", redactURL(c.L2URL),
+		"l2Claim", c.L2Claim,
+		"l2ClaimBlockNumber", c.L2ClaimBlockNumber,
+		"dataDir", c.DataDir,
+		"dataDirReadOnly", c.DataDirReadOnly,
+		"dataDirMaxSize", c.DataDirMaxSize,
+		"kvBackend", c.ResolvedKVBackend(),
+		"execCmd", c.ExecCmd,
+		"serverMode", c.ServerMode,
+		"apiAddress", c.APIAddress,
+		"apiTLS", c.APITLSCert != "",
+		"apiAuthToken", redactSecret(c.APIAuthToken),
+		"allowedHints", c.AllowedHints,
+		"maxPreimageSize", c.MaxPreimageSize,
+		"cartesiMachineURL", redactURL(c.CartesiMachineURL),
+		"cartesiSnapshotDir", c.CartesiSnapshotDir,
+		"cartesiTimeout", c.CartesiTimeout,
+		"daURL", redactURL(c.DAURL),
+		"metricsEnabled", c.MetricsConfig.Enabled,
+		"metricsAddr", net.JoinHostPort(c.MetricsConfig.ListenAddr, strconv.Itoa(c.MetricsConfig.ListenPort)),
+		"logLevel", c.logLevelName(),
+	}
+}
+
+// String returns the log representation of the config, see LogSafeConfig.
+func (c *Config) String() string {
+	var b strings.Builder
+	kvs := c.LogSafeConfig()
+	for i := 0; i < len(kvs); i += 2 {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s=%v", kvs[i], kvs[i+1])
+	}
+	return b.String()
+}
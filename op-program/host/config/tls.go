@@ -0,0 +1,48 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// APITLSConfig returns the TLS config the HTTP API is served with, or nil if it is served over plain HTTP.
+// The certificates are loaded once, by Check or the first call, so invalid files are reported before serving.
+// Clients must present a certificate signed by one of the client CAs, if configured.
+func (c *Config) APITLSConfig() (*tls.Config, error) {
+	if (c.APITLSCert == "") != (c.APITLSKey == "") {
+		return nil, ErrAPITLSCertKeyInconsistent
+	}
+	if c.APITLSCert == "" {
+		if c.APITLSClientCA != "" {
+			return nil, ErrAPITLSClientCAWithoutCert
+		}
+		return nil, nil
+	}
+	if c.apiTLS != nil {
+		return c.apiTLS, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.APITLSCert, c.APITLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: load key pair: %w", ErrInvalidAPITLS, err)
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.APITLSClientCA != "" {
+		data, err := os.ReadFile(c.APITLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: %w", ErrInvalidAPITLS, ErrInvalidAPITLSClientCA, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("%w: %w: no certificates in %s", ErrInvalidAPITLS, ErrInvalidAPITLSClientCA, c.APITLSClientCA)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	c.apiTLS = tlsCfg
+	return tlsCfg, nil
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tlsFiles struct {
+	cert, key, ca string
+}
+
+// writeTLSFiles writes a self-signed CA and a server certificate and key signed by it.
+func writeTLSFiles(t *testing.T) tlsFiles {
+	dir := t.TempDir()
+	write := func(name string, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+		return path
+	}
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		return key
+	}
+	caKey := newKey()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	serverKey := newKey()
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caTemplate, &serverKey.PublicKey, caKey)
+	require.NoError(t, err)
+	serverKeyDER, err := x509.MarshalECPrivateKey(serverKey)
+	require.NoError(t, err)
+	return tlsFiles{
+		cert: write("server.crt", "CERTIFICATE", serverDER),
+		key:  write("server.key", "EC PRIVATE KEY", serverKeyDER),
+		ca:   write("ca.crt", "CERTIFICATE", caDER),
+	}
+}
+
+func TestAPITLSConfig(t *testing.T) {
+	files := writeTLSFiles(t)
+	other := writeTLSFiles(t)
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := validConfig()
+		require.NoError(t, cfg.Check())
+		tlsCfg, err := cfg.APITLSConfig()
+		require.NoError(t, err)
+		require.Nil(t, tlsCfg)
+	})
+
+	t.Run("ServerOnly", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.APITLSCert, cfg.APITLSKey = files.cert, files.key
+		require.NoError(t, cfg.Check())
+		tlsCfg, err := cfg.APITLSConfig()
+		require.NoError(t, err)
+		require.Len(t, tlsCfg.Certificates, 1)
+		require.Equal(t, tls.NoClientCert, tlsCfg.ClientAuth)
+	})
+
+	t.Run("ClientCA", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.APITLSCert, cfg.APITLSKey, cfg.APITLSClientCA = files.cert, files.key, files.ca
+		require.NoError(t, cfg.Check())
+		tlsCfg, err := cfg.APITLSConfig()
+		require.NoError(t, err)
+		require.Equal(t, tls.RequireAndVerifyClientCert, tlsCfg.ClientAuth)
+		require.NotNil(t, tlsCfg.ClientCAs)
+	})
+
+	t.Run("LoadedOnce", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.APITLSCert, cfg.APITLSKey = files.cert, files.key
+		require.NoError(t, cfg.Check())
+		first, err := cfg.APITLSConfig()
+		require.NoError(t, err)
+		second, err := cfg.APITLSConfig()
+		require.NoError(t, err)
+		require.Same(t, first, second)
+	})
+
+	notPEM := filepath.Join(t.TempDir(), "not-pem.crt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	missing := filepath.Join(t.TempDir(), "missing.crt")
+	tests := []struct {
+		name     string
+		cert     string
+		key      string
+		clientCA string
+		expected error
+	}{
+		{"CertWithoutKey", files.cert, "", "", ErrAPITLSCertKeyInconsistent},
+		{"KeyWithoutCert", "", files.key, "", ErrAPITLSCertKeyInconsistent},
+		{"ClientCAWithoutCertAndKey", "", "", files.ca, ErrAPITLSClientCAWithoutCert},
+		{"ClientCAWithoutKey", files.cert, "", files.ca, ErrAPITLSCertKeyInconsistent},
+		{"MissingCert", missing, files.key, "", ErrInvalidAPITLS},
+		{"MissingKey", files.cert, missing, "", ErrInvalidAPITLS},
+		{"InvalidCert", notPEM, files.key, "", ErrInvalidAPITLS},
+		{"MismatchedKey", files.cert, other.key, "", ErrInvalidAPITLS},
+		{"MissingClientCA", files.cert, files.key, missing, ErrInvalidAPITLS},
+		{"InvalidClientCA", files.cert, files.key, notPEM, ErrInvalidAPITLS},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.APITLSCert, cfg.APITLSKey, cfg.APITLSClientCA = test.cert, test.key, test.clientCA
+			require.ErrorIs(t, cfg.Check(), test.expected)
+		})
+	}
+}
+
+func TestAPITLSFromCLI(t *testing.T) {
+	files := writeTLSFiles(t)
+	cfg, err := configFromArgs(t, append(requiredArgs(t), "--datadir", "/data",
+		"--api.tls.cert", files.cert, "--api.tls.key", files.key, "--api.tls.client-ca", files.ca)...)
+	require.NoError(t, err)
+	require.Equal(t, files.cert, cfg.APITLSCert)
+	require.Equal(t, files.key, cfg.APITLSKey)
+	require.Equal(t, files.ca, cfg.APITLSClientCA)
+	require.NoError(t, cfg.Check())
+
+	t.Setenv("OP_PROGRAM_API_TLS_CLIENT_CA", files.ca)
+	cfg, err = configFromArgs(t, append(requiredArgs(t), "--datadir", "/data")...)
+	require.NoError(t, err)
+	require.ErrorIs(t, cfg.Check(), ErrAPITLSClientCAWithoutCert)
+}
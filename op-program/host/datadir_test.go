@@ -0,0 +1,60 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestLimitDataDir(t *testing.T) {
+	setup := func(t *testing.T, existing int, maxSize uint64) (kvstore.KV, *metrics.Metrics, *testlog.CapturingHandler) {
+		cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		cfg.DataDir = t.TempDir()
+		cfg.DataDirMaxSize = maxSize
+		require.NoError(t, os.WriteFile(filepath.Join(cfg.DataDir, "existing.txt"), make([]byte, existing), 0o644))
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		m := metrics.NewMetrics()
+		kv, err := limitDataDir(logger, kvstore.NewDiskKV(cfg.DataDir), cfg, m)
+		require.NoError(t, err)
+		return kv, m, logs
+	}
+	usage := func(m *metrics.Metrics) float64 {
+		families, err := m.Registry().Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() == "op_program_datadir_usage_bytes" {
+				return family.Metric[0].GetGauge().GetValue()
+			}
+		}
+		require.FailNow(t, "datadir usage metric not found")
+		return 0
+	}
+
+	t.Run("BelowMaxSize", func(t *testing.T) {
+		kv, m, logs := setup(t, 10, 16)
+		require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Limiting datadir size")))
+		require.Equal(t, float64(10), usage(m))
+
+		require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1, 2, 3}))
+		require.Equal(t, float64(16), usage(m))
+		require.ErrorIs(t, kv.Put(common.Hash{0xbb}, []byte{1}), kvstore.ErrDataDirFull)
+	})
+
+	t.Run("AboveMaxSize", func(t *testing.T) {
+		kv, m, logs := setup(t, 20, 16)
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageContainsFilter("max size")))
+		require.Equal(t, float64(20), usage(m))
+		require.ErrorIs(t, kv.Put(common.Hash{0xaa}, []byte{1}), kvstore.ErrDataDirFull)
+	})
+}
@@ -0,0 +1,146 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// daStore is an in-memory plasma.Storage.
+type daStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (s *daStore) Get(ctx context.Context, key plasma.Commitment) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[string(key)]
+	if !ok {
+		return nil, plasma.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *daStore) Put(ctx context.Context, key plasma.Commitment, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[string(key)] = value
+	return nil
+}
+
+func (s *daStore) Has(ctx context.Context, key plasma.Commitment) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[string(key)]
+	return ok, nil
+}
+
+func (s *daStore) Delete(ctx context.Context, key plasma.Commitment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, string(key))
+	return nil
+}
+
+func TestDehashFromDAServer(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelDebug)
+
+	daServer := plasma.NewDAServer("127.0.0.1:0", &daStore{data: make(map[string][]byte)}, logger)
+	require.NoError(t, daServer.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, daServer.Stop(ctx))
+	})
+	daURL := fmt.Sprintf("http://%s", daServer.Addr())
+	daInput := []byte("fault proof input stored in the DA server")
+	comm, err := plasma.NewDAClient(daURL, true).SetInput(ctx, daInput)
+	require.NoError(t, err)
+
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = t.TempDir()
+	cfg.DAURL = daURL
+	kv := kvstore.NewDiskKV(cfg.DataDir)
+	localInput := []byte("pre-populated input")
+	require.NoError(t, kv.Put(preimage.Keccak256Key(crypto.Keccak256Hash(localInput)).PreimageKey(), localInput))
+
+	preimageSource, hintHandler, err := makeSources(ctx, logger, kv, cfg, newReloader(logger, cfg))
+	require.NoError(t, err)
+	srv := httptest.NewServer(newHTTPHandler(logger, cfg, preimageSource, hintHandler))
+	t.Cleanup(srv.Close)
+
+	dehash := func(hash []byte) (int, []byte) {
+		resp, err := http.Get(fmt.Sprintf("%s/dehash/%x", srv.URL, hash))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, body
+	}
+
+	status, body := dehash(comm.Digest())
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, daInput, body)
+
+	status, body = dehash(crypto.Keccak256(localInput))
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, localInput, body)
+
+	status, _ = dehash(crypto.Keccak256([]byte("unknown")))
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestDehashMaxPreimageSize(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	small, large := []byte("small"), []byte("pre-image above the max size")
+	kv := kvstore.NewMemKV()
+	for _, v := range [][]byte{small, large} {
+		require.NoError(t, kv.Put(preimage.Keccak256Key(crypto.Keccak256Hash(v)).PreimageKey(), v))
+	}
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.MaxPreimageSize = 16
+	srv := httptest.NewServer(newHTTPHandler(logger, cfg, kv.Get, func(string) error { return nil }))
+	t.Cleanup(srv.Close)
+
+	dehash := func(hash []byte, byteRange string) (int, []byte) {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/dehash/%x", srv.URL, hash), nil)
+		require.NoError(t, err)
+		if byteRange != "" {
+			req.Header.Set("Range", byteRange)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, body
+	}
+
+	status, body := dehash(crypto.Keccak256(small), "")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, small, body)
+
+	status, _ = dehash(crypto.Keccak256(large), "")
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+
+	status, body = dehash(crypto.Keccak256(large), "bytes=0-7")
+	require.Equal(t, http.StatusPartialContent, status)
+	require.Equal(t, large[:8], body)
+}
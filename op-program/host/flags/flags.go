@@ -3,13 +3,16 @@ package flags
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	service "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
@@ -20,31 +23,112 @@ func prefixEnvVars(name string) []string {
 }
 
 var (
+	ConfigFile = &cli.StringFlag{
+		Name:    "config",
+		Usage:   "Path to a TOML config file, or JSON if it has a .json extension, setting any of the options. CLI flags and env vars take precedence over the file.",
+		EnvVars: prefixEnvVars("CONFIG"),
+	}
 	Network = &cli.StringFlag{
 		Name:    "network",
 		Usage:   fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
 		EnvVars: prefixEnvVars("NETWORK"),
 	}
+	Preset = &cli.StringFlag{
+		Name: "preset",
+		Usage: "Preset of defaults for a well-known network, like op-mainnet or op-sepolia, setting the network, " +
+			"L1 RPC kind, L1 beacon requirement and retry settings. Other options take precedence over the preset",
+		EnvVars: prefixEnvVars("PRESET"),
+	}
 	DataDir = &cli.StringFlag{
 		Name:    "datadir",
 		Usage:   "Directory to use for preimage data storage. Default uses in-memory storage",
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
+	DataDirReadOnly = &cli.BoolFlag{
+		Name:    "datadir.readonly",
+		Usage:   "Only read pre-images from the datadir, never write to it. Requires fetching to be disabled",
+		EnvVars: prefixEnvVars("DATADIR_READONLY"),
+	}
+	DataDirMaxSize = &cli.GenericFlag{
+		Name: "datadir.max-size",
+		Usage: "Maximum size of the pre-image data in the datadir, in bytes with an optional unit like 10GiB. " +
+			"New pre-images are not stored once it is reached. Default is unlimited",
+		EnvVars: prefixEnvVars("DATADIR_MAX_SIZE"),
+		Value:   new(types.ByteSize),
+	}
+	KVBackend = &cli.StringFlag{
+		Name: "kv.backend",
+		Usage: "Key-value store to keep pre-images in. Default is disk if the datadir is set and mem otherwise. Valid options: " +
+			openum.EnumString(types.KVBackends),
+		EnvVars: prefixEnvVars("KV_BACKEND"),
+	}
+	KVPebbleCacheSize = &cli.Uint64Flag{
+		Name:    "kv.pebble.cache-size",
+		Usage:   "Size of the pebble kv backend block cache in MiB",
+		EnvVars: prefixEnvVars("KV_PEBBLE_CACHE_SIZE"),
+		Value:   32,
+	}
+	RollupConfig = &cli.StringFlag{
+		Name:    "rollup.config",
+		Usage:   "Rollup chain parameters",
+		EnvVars: prefixEnvVars("ROLLUP_CONFIG"),
+	}
+	L2GenesisPath = &cli.StringFlag{
+		Name:    "l2.genesis",
+		Usage:   "Path to the op-geth genesis file of a custom network. Required with rollup.config, not allowed with network",
+		EnvVars: prefixEnvVars("L2_GENESIS"),
+	}
+	L2NodeAddr = &cli.StringFlag{
+		Name:    "l2",
+		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)",
+		EnvVars: prefixEnvVars("L2_RPC"),
+	}
 	L1Head = &cli.StringFlag{
-		Name:    "l1.head",
-		Usage:   "Hash of the L1 head block. Derivation stops after this block is processed.",
+		Name: "l1.head",
+		Usage: "Hash of the L1 head block. Derivation stops after this block is processed. " +
+			"Optional in server mode with fetching enabled, where the latest finalized L1 block is used",
 		EnvVars: prefixEnvVars("L1_HEAD"),
 	}
-	L1NodeAddr = &cli.StringFlag{
+	L1HeadFile = &cli.StringFlag{
+		Name:    "l1.head.file",
+		Usage:   "File containing the hash of the L1 head block, instead of l1.head. Re-read when the config is reloaded",
+		EnvVars: prefixEnvVars("L1_HEAD_FILE"),
+	}
+	L2Head = &cli.StringFlag{
+		Name:    "l2.head",
+		Usage:   "Hash of the L2 block at l2.outputroot",
+		EnvVars: prefixEnvVars("L2_HEAD"),
+	}
+	L2OutputRoot = &cli.StringFlag{
+		Name:    "l2.outputroot",
+		Usage:   "Agreed L2 Output Root to start derivation from",
+		EnvVars: prefixEnvVars("L2_OUTPUT_ROOT"),
+	}
+	L2Claim = &cli.StringFlag{
+		Name:    "l2.claim",
+		Usage:   "Claimed L2 output root to validate",
+		EnvVars: prefixEnvVars("L2_CLAIM"),
+	}
+	L2BlockNumber = &cli.Uint64Flag{
+		Name:    "l2.blocknumber",
+		Usage:   "Number of the L2 block that the claim is from",
+		EnvVars: prefixEnvVars("L2_BLOCK_NUM"),
+	}
+	L1NodeAddr = &cli.StringSliceFlag{
 		Name:    "l1",
-		Usage:   "Address of L1 JSON-RPC endpoint to use (eth namespace required)",
+		Usage:   "Addresses of L1 JSON-RPC endpoints to use (eth namespace required). Can be repeated or comma-separated, endpoints are tried in order",
 		EnvVars: prefixEnvVars("L1_RPC"),
 	}
 	L1BeaconAddr = &cli.StringFlag{
 		Name:    "l1.beacon",
-		Usage:   "Address of L1 Beacon API endpoint to use",
+		Usage:   "Address of L1 Beacon API endpoint to use. Required with l1 once ecotone is scheduled on the chain",
 		EnvVars: prefixEnvVars("L1_BEACON_API"),
 	}
+	L1BeaconIgnore = &cli.BoolFlag{
+		Name:    "l1.beacon.ignore",
+		Usage:   "Fetch pre-images without an L1 Beacon API endpoint when one is required, failing to fetch blobs",
+		EnvVars: prefixEnvVars("L1_BEACON_IGNORE"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -60,6 +144,34 @@ var (
 			return &out
 		}(),
 	}
+	L1DialAttempts = &cli.UintFlag{
+		Name:    "l1.dial-attempts",
+		Usage:   "Number of attempts to dial each L1 RPC, with an exponential backoff",
+		EnvVars: prefixEnvVars("L1_DIAL_ATTEMPTS"),
+		Value:   10,
+	}
+	L1Retries = &cli.UintFlag{
+		Name:    "l1.retries",
+		Usage:   "Maximum number of retries of a failed L1 RPC or beacon request. Default retries until the request succeeds",
+		EnvVars: prefixEnvVars("L1_RETRIES"),
+	}
+	L1RetryBackoff = &cli.DurationFlag{
+		Name:    "l1.retry-backoff",
+		Usage:   "Maximum delay between retries of a failed L1 RPC or beacon request, the delay grows exponentially up to it",
+		EnvVars: prefixEnvVars("L1_RETRY_BACKOFF"),
+		Value:   10 * time.Second,
+	}
+	L1RequestTimeout = &cli.DurationFlag{
+		Name:    "l1.request-timeout",
+		Usage:   "Timeout of each attempt of an L1 RPC request. Default has no timeout",
+		EnvVars: prefixEnvVars("L1_REQUEST_TIMEOUT"),
+	}
+	BeaconRequestTimeout = &cli.DurationFlag{
+		Name:    "l1.beacon.request-timeout",
+		Usage:   "Timeout of each L1 beacon request",
+		EnvVars: prefixEnvVars("L1_BEACON_REQUEST_TIMEOUT"),
+		Value:   30 * time.Second,
+	}
 	Exec = &cli.StringFlag{
 		Name:    "exec",
 		Usage:   "Run the specified client program as a separate process detached from the host. Default is to run the client program in the host process.",
@@ -72,38 +184,142 @@ var (
 	}
 	APIAddress = &cli.StringFlag{
 		Name:    "api.address",
-		Usage:   "Http API address.",
+		Usage:   "Address the HTTP API listens on, host:port or unix:// followed by the path of a unix domain socket",
 		EnvVars: prefixEnvVars("API_ADDRESS"),
+		Value:   "127.0.0.1:7300",
+	}
+	APITLSCert = &cli.StringFlag{
+		Name:    "api.tls.cert",
+		Usage:   "Path to the TLS certificate the HTTP API is served with. Requires api.tls.key. Default serves plain HTTP",
+		EnvVars: prefixEnvVars("API_TLS_CERT"),
+	}
+	APITLSKey = &cli.StringFlag{
+		Name:    "api.tls.key",
+		Usage:   "Path to the TLS key of the api.tls.cert certificate",
+		EnvVars: prefixEnvVars("API_TLS_KEY"),
+	}
+	APITLSClientCA = &cli.StringFlag{
+		Name:    "api.tls.client-ca",
+		Usage:   "Path to the CA certificates HTTP API clients must present a certificate signed by. Requires api.tls.cert and api.tls.key",
+		EnvVars: prefixEnvVars("API_TLS_CLIENT_CA"),
+	}
+	APIAuthTokenFile = &cli.StringFlag{
+		Name: "api.auth-token-file",
+		Usage: "Path to a file containing the bearer token HTTP API requests must be authorized with. " +
+			"The token may also be set with the " + APIAuthTokenEnvVar + " env var. Default serves requests without authorization",
+		EnvVars: prefixEnvVars("API_AUTH_TOKEN_FILE"),
+	}
+	HintsAllowed = &cli.StringSliceFlag{
+		Name: "hints.allowed",
+		Usage: "Comma-separated list of the hint types accepted by the HTTP API and the prefetcher. Default accepts all hint types: " +
+			strings.Join(types.HintTypes, ", "),
+		EnvVars: prefixEnvVars("HINTS_ALLOWED"),
+	}
+	MaxPreimageSize = &cli.GenericFlag{
+		Name: "max-preimage-size",
+		Usage: "Maximum size of a pre-image to fetch, store or serve in a single HTTP response, in bytes with an optional unit like 64MiB or 1GB. " +
+			"Larger pre-images can only be served with range requests. Default is unlimited",
+		EnvVars: prefixEnvVars("MAX_PREIMAGE_SIZE"),
+		Value:   new(types.ByteSize),
+	}
+	CartesiMachineURL = &cli.StringFlag{
+		Name:    "cartesi.machine-url",
+		Usage:   "HTTP endpoint of the machine server to fetch Cartesi machine pages from. Cartesi machine page hints are rejected if neither it nor cartesi.snapshot-dir is set",
+		EnvVars: prefixEnvVars("CARTESI_MACHINE_URL"),
+	}
+	CartesiSnapshotDir = &cli.StringFlag{
+		Name:    "cartesi.snapshot-dir",
+		Usage:   "Directory of a Cartesi machine snapshot to read machine pages from, as alternative to cartesi.machine-url",
+		EnvVars: prefixEnvVars("CARTESI_SNAPSHOT_DIR"),
+	}
+	CartesiRequestTimeout = &cli.DurationFlag{
+		Name:    "cartesi.request-timeout",
+		Usage:   "Timeout of each request for a Cartesi machine page from cartesi.machine-url",
+		EnvVars: prefixEnvVars("CARTESI_REQUEST_TIMEOUT"),
+		Value:   30 * time.Second,
+	}
+	DAServerAddr = &cli.StringFlag{
+		Name:    "da.server",
+		Usage:   "Address of the plasma DA storage service to resolve keccak256 pre-images missing from the datadir when fetching is disabled",
+		EnvVars: prefixEnvVars("DA_SERVER"),
 	}
 )
 
+// APIAuthTokenEnvVar is the env var the HTTP API auth token may be set with.
+// Secrets have no CLI flag so they never show up in process listings. They are set with an env var
+// or read from the file at the path of a flag.
+const APIAuthTokenEnvVar = EnvVarPrefix + "_API_AUTH_TOKEN"
+
+// SecretEnvVars are the env vars that are read without a corresponding flag.
+var SecretEnvVars = []string{APIAuthTokenEnvVar}
+
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
 var requiredFlags = []cli.Flag{
 	L1Head,
+	L2Head,
+	L2OutputRoot,
+	L2Claim,
+	L2BlockNumber,
 }
 
 var programFlags = []cli.Flag{
+	ConfigFile,
 	Network,
+	Preset,
+	RollupConfig,
+	L2GenesisPath,
 	DataDir,
+	DataDirReadOnly,
+	DataDirMaxSize,
+	KVBackend,
+	KVPebbleCacheSize,
+	L1HeadFile,
+	L2NodeAddr,
 	L1NodeAddr,
 	L1BeaconAddr,
+	L1BeaconIgnore,
 	L1TrustRPC,
 	L1RPCProviderKind,
+	L1DialAttempts,
+	L1Retries,
+	L1RetryBackoff,
+	L1RequestTimeout,
+	BeaconRequestTimeout,
 	Exec,
 	Server,
 	APIAddress,
+	APITLSCert,
+	APITLSKey,
+	APITLSClientCA,
+	APIAuthTokenFile,
+	HintsAllowed,
+	MaxPreimageSize,
+	CartesiMachineURL,
+	CartesiSnapshotDir,
+	CartesiRequestTimeout,
+	DAServerAddr,
 }
 
 func init() {
 	Flags = append(Flags, oplog.CLIFlags(EnvVarPrefix)...)
+	Flags = append(Flags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, requiredFlags...)
 	Flags = append(Flags, programFlags...)
 }
 
+// CheckRequired returns an error if a required flag is not set. Required options may also be set in
+// the config file instead, which is then validated once loaded.
 func CheckRequired(ctx *cli.Context) error {
+	if ctx.IsSet(ConfigFile.Name) {
+		return nil
+	}
 	for _, flag := range requiredFlags {
+		if flag == L1Head && (ctx.IsSet(L1HeadFile.Name) || ctx.Bool(Server.Name)) {
+			// the l1 head is read from its file, or resolved from the L1 node in server mode
+			continue
+		}
 		if !ctx.IsSet(flag.Names()[0]) {
 			return fmt.Errorf("flag %s is required", flag.Names()[0])
 		}
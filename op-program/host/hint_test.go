@@ -0,0 +1,66 @@
+package host
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestHTTPHintAllowlist(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	blockHint := l1.BlockHeaderHint(common.Hash{0xaa}).Hint()
+	blobHint := fmt.Sprintf("%s 0x%x", l1.HintL1Blob, make([]byte, 48))
+
+	newServer := func(t *testing.T, allowedHints []string) (*httptest.Server, *[]string) {
+		var received []string
+		hintHandler := func(hint string) error {
+			received = append(received, hint)
+			return nil
+		}
+		cfg := &config.Config{AllowedHints: allowedHints}
+		srv := httptest.NewServer(newHTTPHandler(logger, cfg, kvstore.NewMemKV().Get, hintHandler))
+		t.Cleanup(srv.Close)
+		return srv, &received
+	}
+	sendHint := func(t *testing.T, srv *httptest.Server, hint string) int {
+		resp, err := http.Get(srv.URL + "/hint/" + url.PathEscape(hint))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("AllByDefault", func(t *testing.T) {
+		srv, received := newServer(t, nil)
+		for _, hintType := range types.HintTypes {
+			require.Equal(t, http.StatusOK, sendHint(t, srv, hintType+" 0x00"), hintType)
+		}
+		require.Len(t, *received, len(types.HintTypes))
+	})
+
+	t.Run("RejectDisallowed", func(t *testing.T) {
+		srv, received := newServer(t, []string{l1.HintL1BlockHeader})
+		require.Equal(t, http.StatusOK, sendHint(t, srv, blockHint))
+		require.Equal(t, http.StatusBadRequest, sendHint(t, srv, blobHint))
+		require.Equal(t, []string{blockHint}, *received)
+	})
+
+	t.Run("RejectUnknown", func(t *testing.T) {
+		srv, received := newServer(t, nil)
+		require.Equal(t, http.StatusBadRequest, sendHint(t, srv, "unknown-type 0x00"))
+		// hint types must match exactly, not just be contained in the hint
+		require.Equal(t, http.StatusBadRequest, sendHint(t, srv, "x"+blockHint))
+		require.Empty(t, *received)
+	})
+}
@@ -1,21 +1,37 @@
 package host
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
-	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/host/cartesi"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -28,11 +44,24 @@ type L2Source struct {
 
 func Main(logger log.Logger, cfg *config.Config) error {
 	if err := cfg.Check(); err != nil {
+		var flagErr *config.FlagError
+		if errors.As(err, &flagErr) {
+			return fmt.Errorf("invalid config, check flag --%s: %w", flagErr.Flag, flagErr.Err)
+		}
 		return fmt.Errorf("invalid config: %w", err)
 	}
-	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, logger)
+	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, logger, flags.SecretEnvVars...)
+	if cfg.LogLevel != nil {
+		setLogLevel(logger, *cfg.LogLevel)
+	}
+	logger.Info("Loaded config", cfg.LogSafeConfig()...)
 
-	return PreimageServer(context.Background(), logger, cfg)
+	r := newReloader(logger, cfg)
+	if cfg.Reload != nil {
+		stop := r.reloadOnSignal(cfg.Reload)
+		defer stop()
+	}
+	return preimageServer(context.Background(), logger, cfg, r)
 }
 
 // PreimageServer reads hints and preimage requests from the provided channels and processes those requests.
@@ -40,67 +69,307 @@ func Main(logger log.Logger, cfg *config.Config) error {
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
 func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+	return preimageServer(ctx, logger, cfg, newReloader(logger, cfg))
+}
+
+// preimageServer runs the preimage server, registering its components to apply the settings reloaded by r.
+func preimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, r *reloader) error {
 	logger.Info("Starting preimage server")
 
-	var kv kvstore.KV
-	if cfg.DataDir == "" {
+	kv, err := makeKV(logger, cfg)
+	if err != nil {
+		return err
+	}
+	if closer, ok := kv.(io.Closer); ok {
+		defer closer.Close()
+	}
+	m, metricsSrv, err := startMetrics(logger, cfg)
+	if err != nil {
+		return err
+	}
+	if metricsSrv != nil {
+		defer metricsSrv.Close()
+	}
+	if cfg.DataDirMaxSize > 0 {
+		kv, err = limitDataDir(logger, kv, cfg, m)
+		if err != nil {
+			return err
+		}
+	}
+	maxSizeKV := kvstore.NewMaxSizeKV(kv, cfg.MaxPreimageSize)
+	r.onReload(func(cfg *config.Config) { maxSizeKV.SetMaxSize(cfg.MaxPreimageSize) })
+
+	preimageSource, hintHandler, err := makeSources(ctx, logger, maxSizeKV, cfg, r)
+	if err != nil {
+		return err
+	}
+	return httpServer(logger, cfg, r, preimageSource, hintHandler)
+}
+
+// startMetrics starts the metrics server if it is enabled in the config.
+// Otherwise no metrics are recorded and the returned server is nil.
+func startMetrics(logger log.Logger, cfg *config.Config) (metrics.Metricer, *httputil.HTTPServer, error) {
+	if !cfg.MetricsConfig.Enabled {
+		return metrics.NoopMetrics, nil, nil
+	}
+	m := metrics.NewMetrics()
+	srv, err := opmetrics.StartServer(m.Registry(), cfg.MetricsConfig.ListenAddr, cfg.MetricsConfig.ListenPort)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start metrics server: %w", err)
+	}
+	logger.Info("Started metrics server", "addr", srv.Addr())
+	return m, srv, nil
+}
+
+// makeKV creates the KV store of the configured backend.
+func makeKV(logger log.Logger, cfg *config.Config) (kvstore.KV, error) {
+	backend := cfg.ResolvedKVBackend()
+	if backend == types.KVBackendMem {
 		logger.Info("Using in-memory storage")
-		kv = kvstore.NewMemKV()
-	} else {
-		logger.Info("Creating disk storage", "datadir", cfg.DataDir)
+		return kvstore.NewMemKV(), nil
+	}
+	logger.Info("Creating disk storage", "backend", backend, "datadir", cfg.DataDir, "readonly", cfg.DataDirReadOnly)
+	if !cfg.DataDirReadOnly {
 		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
-			return fmt.Errorf("creating datadir: %w", err)
+			return nil, fmt.Errorf("creating datadir: %w", err)
+		}
+	}
+	switch backend {
+	case types.KVBackendDisk:
+		var kv kvstore.KV = kvstore.NewDiskKV(cfg.DataDir)
+		if cfg.DataDirReadOnly {
+			kv = kvstore.NewReadOnlyKV(kv)
+		}
+		return kv, nil
+	case types.KVBackendPebble:
+		kv, err := kvstore.NewPebbleKV(cfg.DataDir, int64(cfg.KVPebbleCacheSize)*1024*1024, cfg.DataDirReadOnly)
+		if err != nil {
+			return nil, err
 		}
-		kv = kvstore.NewDiskKV(cfg.DataDir)
+		return kv, nil
+	default:
+		return nil, fmt.Errorf("%w %q", config.ErrInvalidKVBackend, backend)
+	}
+}
+
+// limitDataDir limits the size of the pre-image data in the datadir to the max size of the config.
+func limitDataDir(logger log.Logger, kv kvstore.KV, cfg *config.Config, m metrics.Metricer) (kvstore.KV, error) {
+	used, err := kvstore.DirSize(cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	if used >= cfg.DataDirMaxSize {
+		logger.Warn("Datadir is at its max size, new pre-images will not be stored", "datadir", cfg.DataDir, "used", used, "max", cfg.DataDirMaxSize)
+	} else {
+		logger.Info("Limiting datadir size", "datadir", cfg.DataDir, "used", used, "max", cfg.DataDirMaxSize)
 	}
+	return kvstore.NewQuotaKV(kv, used, cfg.DataDirMaxSize, m), nil
+}
 
-	var (
-		preimageSource kvstore.PreimageSource
-		hintHander     preimage.HintHandler
-	)
+// makeSources creates the pre-image source and hint handler serving the API for the config.
+// The prefetcher, if fetching is enabled, applies the settings reloaded by r.
+// If the config ResolvesL1Head, the L1 head is set to the latest finalized block of the L1 nodes.
+func makeSources(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config, r *reloader) (kvstore.PreimageSource, preimage.HintHandler, error) {
 	if cfg.FetchingEnabled() {
-		prefetch, err := makePrefetcher(ctx, logger, kv, cfg)
+		l1Clients, err := dialL1(ctx, logger, cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create prefetcher: %w", err)
+			return nil, nil, fmt.Errorf("failed to create prefetcher: %w", err)
 		}
-		preimageSource = func(key common.Hash) ([]byte, error) { return prefetch.GetPreimage(ctx, key) }
-		hintHander = prefetch.Hint
-	} else {
-		logger.Info("Using offline mode. All required pre-images must be pre-populated.")
-		preimageSource = kv.Get
-		hintHander = func(hint string) error {
-			logger.Debug("ignoring prefetch hint", "hint", hint)
-			return nil
+		if cfg.ResolvesL1Head() {
+			l1Head, err := resolveL1Head(ctx, logger, l1Clients...)
+			if err != nil {
+				return nil, nil, err
+			}
+			r.setL1Head(l1Head)
 		}
+		prefetch := makePrefetcher(logger, kv, cfg, l1Clients)
+		r.onReload(func(cfg *config.Config) { prefetch.Reconfigure(cfg.AllowedHints, cfg.MaxPreimageSize) })
+		preimageSource := func(key common.Hash) ([]byte, error) { return prefetch.GetPreimage(ctx, key) }
+		return preimageSource, prefetch.Hint, nil
+	}
+	logger.Info("Using offline mode. All required pre-images must be pre-populated.")
+	preimageSource := kvstore.PreimageSource(kv.Get)
+	if cfg.DAURL != "" {
+		logger.Info("Resolving missing keccak256 pre-images from DA server", "url", cfg.DAURL)
+		da := kvstore.NewDAPreimageSource(ctx, plasma.NewDAClient(cfg.DAURL, true))
+		preimageSource = kvstore.NewFallbackSource(kv.Get, da.Get)
+	}
+	hintHandler := func(hint string) error {
+		logger.Debug("ignoring prefetch hint", "hint", hint)
+		return nil
 	}
+	return preimageSource, hintHandler, nil
+}
 
-	return httpServer(logger, cfg.APIAddress, preimageSource, hintHander)
+// dialL1 creates a client of each L1 RPC of the config, in order, checking they are on the L1 chain of the rollup.
+func dialL1(ctx context.Context, logger log.Logger, cfg *config.Config) ([]*sources.L1Client, error) {
+	l1ClCfg := sources.L1ClientDefaultConfig(cfg.L1TrustRPC, cfg.L1RPCKind)
+	l1Clients := make([]*sources.L1Client, 0, len(cfg.L1URLs))
+	for _, l1URL := range cfg.L1URLs {
+		logger.Info("Connecting to L1 node", "l1", l1URL)
+		l1RPC, err := client.NewRPC(ctx, logger, l1URL, client.WithDialBackoff(int(cfg.L1DialAttempts)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup L1 RPC %s: %w", l1URL, err)
+		}
+		l1Cl, err := sources.NewL1Client(l1RPC, logger, nil, l1ClCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create L1 client: %w", err)
+		}
+		if err := checkL1ChainID(ctx, l1Cl, cfg); err != nil {
+			return nil, fmt.Errorf("L1 RPC %s: %w", l1URL, err)
+		}
+		l1Clients = append(l1Clients, l1Cl)
+	}
+	return l1Clients, nil
 }
 
-func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (*prefetcher.Prefetcher, error) {
-	logger.Info("Connecting to L1 node", "l1", cfg.L1URL)
-	l1RPC, err := client.NewRPC(ctx, logger, cfg.L1URL, client.WithDialBackoff(10))
-	if err != nil {
-		return nil, fmt.Errorf("failed to setup L1 RPC: %w", err)
+// finalizedL1Source is an L1 source the latest finalized block can be fetched from.
+type finalizedL1Source interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+}
+
+// resolveL1Head returns the hash of the latest finalized L1 block, from the first of the sources that returns it.
+func resolveL1Head[T finalizedL1Source](ctx context.Context, logger log.Logger, l1Sources ...T) (common.Hash, error) {
+	var errs []error
+	for _, l1Source := range l1Sources {
+		ref, err := l1Source.L1BlockRefByLabel(ctx, eth.Finalized)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		logger.Info("Using latest finalized L1 block as L1 head", "l1Head", ref.Hash, "number", ref.Number)
+		return ref.Hash, nil
 	}
+	return common.Hash{}, fmt.Errorf("failed to resolve l1 head from the finalized L1 block: %w", errors.Join(errs...))
+}
 
-	l1ClCfg := sources.L1ClientDefaultConfig(cfg.L1TrustRPC, cfg.L1RPCKind)
-	l1Cl, err := sources.NewL1Client(l1RPC, logger, nil, l1ClCfg)
+// makePrefetcher creates the prefetcher fetching pre-images from the L1 clients, failing over in order.
+func makePrefetcher(logger log.Logger, kv kvstore.KV, cfg *config.Config, l1Clients []*sources.L1Client) *prefetcher.Prefetcher {
+	l1Sources := make([]prefetcher.L1Source, len(l1Clients))
+	for i, l1Cl := range l1Clients {
+		l1Sources[i] = l1Cl
+	}
+	l1Source := l1Sources[0]
+	if len(l1Sources) > 1 {
+		l1Source = prefetcher.NewFailoverL1Source(logger, l1Sources...)
+	}
+	var l1BlobFetcher prefetcher.L1BlobSource
+	if cfg.L1BeaconURL != "" {
+		l1Beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger, client.WithTimeout(cfg.BeaconRequestTimeout)))
+		l1BlobFetcher = sources.NewL1BeaconClient(l1Beacon, sources.L1BeaconClientConfig{FetchAllSidecars: false})
+	} else {
+		logger.Warn("No L1 beacon configured, blobs can't be fetched")
+	}
+	return prefetcher.NewPrefetcher(logger, l1Source, l1BlobFetcher, kv, makePrefetcherConfig(cfg))
+}
+
+// makePrefetcherConfig creates the prefetcher config of the config, with the hint handlers of the configured sources.
+func makePrefetcherConfig(cfg *config.Config) prefetcher.Config {
+	prefetchCfg := prefetcher.Config{
+		Retry: prefetcher.RetryConfig{
+			MaxAttempts:    int(cfg.L1Retries) + 1,
+			MaxBackoff:     cfg.L1RetryBackoff,
+			RequestTimeout: cfg.L1RequestTimeout,
+		},
+		AllowedHints:    cfg.AllowedHints,
+		MaxPreimageSize: cfg.MaxPreimageSize,
+	}
+	if cfg.L1Retries == 0 {
+		prefetchCfg.Retry.MaxAttempts = 0 // retry until the request succeeds
+	}
+	if cfg.CartesiEnabled() {
+		prefetchCfg.HintHandlers = map[string]prefetcher.HintHandler{
+			cartesi.HintMachinePage: cartesi.NewHintHandler(makeCartesiPageSource(cfg)),
+		}
+	}
+	return prefetchCfg
+}
+
+// makeCartesiPageSource creates the source of Cartesi machine pages of the config.
+func makeCartesiPageSource(cfg *config.Config) cartesi.PageSource {
+	if cfg.CartesiMachineURL != "" {
+		return cartesi.NewHTTPPageSource(cfg.CartesiMachineURL, &http.Client{Timeout: cfg.CartesiTimeout})
+	}
+	return cartesi.NewSnapshotPageSource(cfg.CartesiSnapshotDir)
+}
+
+// checkL1ChainID verifies the L1 RPC serves the L1 chain of the rollup config, to catch a misconfigured network.
+func checkL1ChainID(ctx context.Context, l1Cl *sources.L1Client, cfg *config.Config) error {
+	if cfg.Rollup.L1ChainID == nil {
+		return nil
+	}
+	chainID, err := l1Cl.ChainID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create L1 client: %w", err)
+		return fmt.Errorf("failed to fetch chain ID: %w", err)
+	}
+	if chainID.Cmp(cfg.Rollup.L1ChainID) != 0 {
+		return fmt.Errorf("chain ID %v does not match the L1 chain ID %v of the rollup config", chainID, cfg.Rollup.L1ChainID)
 	}
-	l1Beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger))
-	l1BlobFetcher := sources.NewL1BeaconClient(l1Beacon, sources.L1BeaconClientConfig{FetchAllSidecars: false})
-	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, kv), nil
+	return nil
 }
 
+// httpServer serves the HTTP API on the API address of the config, over TLS if configured.
+// The API applies the settings reloaded by r.
 func httpServer(
 	logger log.Logger,
-	hostPort string,
+	cfg *config.Config,
+	r *reloader,
 	preimageSource kvstore.PreimageSource,
 	hintHandler preimage.HintHandler,
 ) error {
-	http.HandleFunc("/dehash/", func(w http.ResponseWriter, req *http.Request) {
+	tlsCfg, err := cfg.APITLSConfig()
+	if err != nil {
+		return err
+	}
+	network, address, err := cfg.APIListenAddress()
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on api address %s: %w", cfg.APIAddress, err)
+	}
+	handler := newHTTPHandler(logger, cfg, preimageSource, hintHandler)
+	r.onReload(handler.apply)
+	srv := &http.Server{
+		Handler:   handler,
+		TLSConfig: tlsCfg,
+	}
+	if tlsCfg != nil {
+		logger.Info("Serving HTTP API over TLS", "addr", cfg.APIAddress, "clientAuth", tlsCfg.ClientAuth == tls.RequireAndVerifyClientCert)
+		return srv.ServeTLS(listener, "", "")
+	}
+	logger.Info("Serving HTTP API", "addr", cfg.APIAddress)
+	return srv.Serve(listener)
+}
+
+// NewHTTPHandler returns the handler of the HTTP API without serving it, see newHTTPHandler.
+// It allows the API to be embedded in other servers and clients of the API to be tested against it.
+func NewHTTPHandler(
+	logger log.Logger,
+	cfg *config.Config,
+	preimageSource kvstore.PreimageSource,
+	hintHandler preimage.HintHandler,
+) http.Handler {
+	return newHTTPHandler(logger, cfg, preimageSource, hintHandler)
+}
+
+// newHTTPHandler returns the handler serving pre-images on /dehash/ and accepting hints on /hint/.
+// The local inputs of the program, like the L1 head, are served on /local/ followed by their local index.
+// Only hints of the allowed hint types of the config are accepted, or of all types in types.HintTypes if none are set.
+// Pre-images above the max pre-image size of the config are only served to range requests.
+// If the config has an API auth token, requests must be authorized with it as bearer token.
+// The allowed hint types and max pre-image size can be changed with apply while serving.
+func newHTTPHandler(
+	logger log.Logger,
+	cfg *config.Config,
+	preimageSource kvstore.PreimageSource,
+	hintHandler preimage.HintHandler,
+) *apiHandler {
+	h := &apiHandler{}
+	h.apply(cfg)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dehash/", func(w http.ResponseWriter, req *http.Request) {
 		keyStr := req.URL.Path[len("/dehash/"):]
 		key, err := hex.DecodeString(keyStr)
 		if err != nil {
@@ -114,6 +383,13 @@ func httpServer(
 		if err != nil {
 			logger.Error("failed to get preimage value for key", keyStr, err)
 			w.WriteHeader(http.StatusNotFound)
+		} else if req.Header.Get("Range") != "" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(val))
+		} else if maxSize := h.settings.Load().maxPreimageSize; maxSize > 0 && uint64(len(val)) > maxSize {
+			logger.Warn("refusing to serve preimage above max size without range request", "key", keyStr, "size", len(val), "max", maxSize)
+			http.Error(w, fmt.Sprintf("pre-image of %d bytes exceeds the max pre-image size of %d bytes, use range requests", len(val), maxSize),
+				http.StatusRequestEntityTooLarge)
 		} else {
 			w.WriteHeader(http.StatusOK)
 			w.Header().Add("Content-type", "application/octet-stream")
@@ -123,15 +399,33 @@ func httpServer(
 		}
 	})
 
-	http.HandleFunc("/hint/", func(w http.ResponseWriter, req *http.Request) {
+	mux.HandleFunc("/local/", func(w http.ResponseWriter, req *http.Request) {
+		indexStr := req.URL.Path[len("/local/"):]
+		index, err := strconv.ParseUint(indexStr, 10, 64)
+		if err != nil {
+			logger.Error("failed to parse local index", "index", indexStr, "err", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		val, err := h.settings.Load().local.Get(preimage.LocalIndexKey(index).PreimageKey())
+		if err != nil {
+			logger.Error("failed to get local preimage", "index", index, "err", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(val); err != nil {
+			logger.Error("failed to write local preimage to http response", "err", err)
+		}
+	})
+
+	mux.HandleFunc("/hint/", func(w http.ResponseWriter, req *http.Request) {
 		hint := req.URL.Path[len("/hint/"):]
 
-		if !strings.Contains(hint, l1.HintL1BlockHeader) &&
-			!strings.Contains(hint, l1.HintL1Transactions) &&
-			!strings.Contains(hint, l1.HintL1Receipts) &&
-			!strings.Contains(hint, l1.HintL1Blob) &&
-			!strings.Contains(hint, l1.HintL1KZGPointEvaluation) {
-			logger.Error("invalid hint type")
+		hintType, _, _ := strings.Cut(hint, " ")
+		if !slices.Contains(h.settings.Load().allowedHints, hintType) {
+			logger.Error("invalid hint type", "type", hintType)
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
@@ -146,5 +440,49 @@ func httpServer(
 		}
 	})
 
-	return http.ListenAndServe(hostPort, nil)
+	h.Handler = mux
+	if cfg.APIAuthToken != "" {
+		h.Handler = requireAuthToken(logger, cfg.APIAuthToken, mux)
+	}
+	return h
+}
+
+// apiHandler is the handler of the HTTP API, see newHTTPHandler.
+type apiHandler struct {
+	http.Handler
+	settings atomic.Pointer[apiSettings]
+}
+
+// apiSettings are the settings of the HTTP API that can be changed while serving.
+type apiSettings struct {
+	allowedHints    []string
+	maxPreimageSize uint64
+	local           *kvstore.LocalPreimageSource
+}
+
+// apply changes the allowed hint types, max pre-image size and local inputs to those of the config.
+func (h *apiHandler) apply(cfg *config.Config) {
+	allowedHints := cfg.AllowedHints
+	if len(allowedHints) == 0 {
+		allowedHints = types.HintTypes
+	}
+	h.settings.Store(&apiSettings{
+		allowedHints:    allowedHints,
+		maxPreimageSize: cfg.MaxPreimageSize,
+		local:           kvstore.NewLocalPreimageSource(cfg),
+	})
+}
+
+// requireAuthToken only passes requests authorized with the bearer token on to the handler.
+func requireAuthToken(logger log.Logger, token string, handler http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), expected) != 1 {
+			logger.Warn("Rejecting unauthorized request", "path", req.URL.Path, "remote", req.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
 }
@@ -0,0 +1,59 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// DAInputs fetches inputs from a plasma DA storage service, see plasma.DAClient.
+type DAInputs interface {
+	GetInputs(ctx context.Context, keys []plasma.Commitment) ([]plasma.InputResult, error)
+}
+
+// DAPreimageSource resolves keccak256 pre-images from a plasma DA storage service.
+//
+// A pre-image key replaces the first byte of the keccak256 hash with the key type, so the commitment of the
+// input is only known up to its first byte. All 256 candidate commitments are requested in a single batch and
+// the client must verify inputs on read, so only the input actually hashing to the key can be returned.
+// Keys of other types are not served and return ErrNotFound, for the lookup to fall through to other sources.
+type DAPreimageSource struct {
+	ctx    context.Context
+	client DAInputs
+}
+
+func NewDAPreimageSource(ctx context.Context, client DAInputs) *DAPreimageSource {
+	return &DAPreimageSource{ctx: ctx, client: client}
+}
+
+func (s *DAPreimageSource) Get(key common.Hash) ([]byte, error) {
+	if key[0] != byte(preimage.Keccak256KeyType) {
+		return nil, ErrNotFound
+	}
+	comms := make([]plasma.Commitment, 256)
+	for i := range comms {
+		hash := key
+		hash[0] = byte(i)
+		comms[i] = append(plasma.Commitment{byte(plasma.Keccak256CommitmentType)}, hash[:]...)
+	}
+	results, err := s.client.GetInputs(s.ctx, comms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pre-image %s from DA server: %w", key, err)
+	}
+	for _, res := range results {
+		if res.Err == nil {
+			return res.Input, nil
+		}
+	}
+	for _, res := range results {
+		if !errors.Is(res.Err, plasma.ErrNotFound) {
+			return nil, fmt.Errorf("failed to fetch pre-image %s from DA server: %w", key, res.Err)
+		}
+	}
+	return nil, ErrNotFound
+}
@@ -0,0 +1,88 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+type stubDAInputs struct {
+	inputs   map[string][]byte
+	itemErrs map[string]error
+	err      error
+	calls    int
+}
+
+func (s *stubDAInputs) GetInputs(ctx context.Context, keys []plasma.Commitment) ([]plasma.InputResult, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	res := make([]plasma.InputResult, len(keys))
+	for i, key := range keys {
+		if err, ok := s.itemErrs[string(key.Encode())]; ok {
+			res[i].Err = err
+			continue
+		}
+		input, ok := s.inputs[string(key.Encode())]
+		if !ok {
+			res[i].Err = plasma.ErrNotFound
+			continue
+		}
+		res[i].Input = input
+	}
+	return res, nil
+}
+
+func TestDAPreimageSource(t *testing.T) {
+	input := []byte("stored in the DA server")
+	da := &stubDAInputs{inputs: map[string][]byte{string(plasma.Keccak256(input).Encode()): input}}
+	source := NewDAPreimageSource(context.Background(), da)
+
+	value, err := source.Get(preimage.Keccak256Key(crypto.Keccak256Hash(input)).PreimageKey())
+	require.NoError(t, err)
+	require.Equal(t, input, value)
+	require.Equal(t, 1, da.calls, "all candidate commitments must be requested at once")
+
+	_, err = source.Get(preimage.Keccak256Key(crypto.Keccak256Hash([]byte("unknown"))).PreimageKey())
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// other key types fall through without querying the DA server
+	_, err = source.Get(preimage.Sha256Key(crypto.Keccak256Hash(input)).PreimageKey())
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, 2, da.calls)
+
+	// a failed lookup of any candidate is reported rather than treated as not found
+	unknown := crypto.Keccak256Hash([]byte("unknown"))
+	itemErr := &plasma.ServerError{Message: "disk failure"}
+	da.itemErrs = map[string]error{string(plasma.Keccak256([]byte("unknown")).Encode()): itemErr}
+	_, err = source.Get(preimage.Keccak256Key(unknown).PreimageKey())
+	require.ErrorIs(t, err, itemErr)
+
+	da.err = errors.New("connection refused")
+	_, err = source.Get(preimage.Keccak256Key(crypto.Keccak256Hash(input)).PreimageKey())
+	require.ErrorIs(t, err, da.err)
+}
+
+func TestFallbackSource(t *testing.T) {
+	notFound := func(key common.Hash) ([]byte, error) { return nil, ErrNotFound }
+	found := func(key common.Hash) ([]byte, error) { return []byte{1}, nil }
+	failing := func(key common.Hash) ([]byte, error) { return nil, errors.New("boom") }
+
+	value, err := NewFallbackSource(notFound, found, failing)(common.Hash{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, value)
+
+	_, err = NewFallbackSource(failing, found)(common.Hash{})
+	require.ErrorContains(t, err, "boom")
+
+	_, err = NewFallbackSource(notFound, notFound)(common.Hash{})
+	require.ErrorIs(t, err, ErrNotFound)
+}
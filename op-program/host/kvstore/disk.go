@@ -57,6 +57,11 @@ func (d *DiskKV) Put(k common.Hash, v []byte) error {
 	return nil
 }
 
+// StoredSize returns the size of the file value v is stored in, as values are stored hex-encoded.
+func (d *DiskKV) StoredSize(v []byte) uint64 {
+	return uint64(hex.EncodedLen(len(v)))
+}
+
 func openTempFile(dir string, nameTemplate string) (*os.File, error) {
 	f, err := os.CreateTemp(dir, nameTemplate)
 	// Directory has been deleted out from underneath us. Recreate it.
@@ -90,4 +95,17 @@ func (d *DiskKV) Get(k common.Hash) ([]byte, error) {
 	return hex.DecodeString(string(dat))
 }
 
+func (d *DiskKV) Delete(k common.Hash) error {
+	d.Lock()
+	defer d.Unlock()
+	if err := os.Remove(d.pathKey(k)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to remove pre-image file %s: %w", k, err)
+	}
+	return nil
+}
+
 var _ KV = (*DiskKV)(nil)
+var _ Deleter = (*DiskKV)(nil)
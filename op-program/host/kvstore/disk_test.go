@@ -14,6 +14,10 @@ func TestDiskKV(t *testing.T) {
 	kvTest(t, kv)
 }
 
+func TestDiskKVDelete(t *testing.T) {
+	deleteTest(t, NewDiskKV(t.TempDir()))
+}
+
 func TestCreateMissingDirectory(t *testing.T) {
 	tmp := t.TempDir()
 	dir := filepath.Join(tmp, "data")
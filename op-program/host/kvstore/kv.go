@@ -6,8 +6,14 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-// ErrNotFound is returned when a pre-image cannot be found in the KV store.
-var ErrNotFound = errors.New("not found")
+var (
+	// ErrNotFound is returned when a pre-image cannot be found in the KV store.
+	ErrNotFound = errors.New("not found")
+	// ErrPreimageTooLarge is returned when a pre-image is larger than the configured maximum pre-image size.
+	ErrPreimageTooLarge = errors.New("pre-image too large")
+	// ErrDataDirFull is returned when storing a pre-image would exceed the configured maximum datadir size.
+	ErrDataDirFull = errors.New("datadir full")
+)
 
 // KV is a Key-Value store interface for pre-image data.
 type KV interface {
@@ -20,3 +26,10 @@ type KV interface {
 	// KV store implementations may return additional errors specific to the KV storage.
 	Get(k common.Hash) ([]byte, error)
 }
+
+// Deleter is implemented by KV stores that support removing pre-images.
+type Deleter interface {
+	// Delete removes the pre-image with key k from the key-value store.
+	// It returns ErrNotFound when the pre-image cannot be found.
+	Delete(k common.Hash) error
+}
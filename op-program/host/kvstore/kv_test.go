@@ -51,3 +51,16 @@ func kvTest(t *testing.T, kv KV) {
 		require.NoError(t, kv.Put(common.Hash{0xdd}, []byte{4, 2}))
 	})
 }
+
+func deleteTest(t *testing.T, kv interface {
+	KV
+	Deleter
+}) {
+	require.ErrorIs(t, kv.Delete(common.Hash{0xee}), ErrNotFound)
+
+	require.NoError(t, kv.Put(common.Hash{0xee}, []byte("hello world")))
+	require.NoError(t, kv.Delete(common.Hash{0xee}))
+	_, err := kv.Get(common.Hash{0xee})
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, kv.Delete(common.Hash{0xee}), ErrNotFound)
+}
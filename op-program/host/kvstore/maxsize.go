@@ -0,0 +1,39 @@
+package kvstore
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MaxSizeKV rejects pre-images larger than a maximum size from being put into the underlying KV store.
+// A maximum size of 0 does not limit the size.
+type MaxSizeKV struct {
+	kv      KV
+	maxSize atomic.Uint64
+}
+
+var _ KV = (*MaxSizeKV)(nil)
+
+func NewMaxSizeKV(kv KV, maxSize uint64) *MaxSizeKV {
+	m := &MaxSizeKV{kv: kv}
+	m.maxSize.Store(maxSize)
+	return m
+}
+
+// SetMaxSize changes the maximum size of pre-images put from now on.
+func (m *MaxSizeKV) SetMaxSize(maxSize uint64) {
+	m.maxSize.Store(maxSize)
+}
+
+func (m *MaxSizeKV) Put(k common.Hash, v []byte) error {
+	if maxSize := m.maxSize.Load(); maxSize > 0 && uint64(len(v)) > maxSize {
+		return fmt.Errorf("%w: %d bytes for key %s, max %d bytes", ErrPreimageTooLarge, len(v), k, maxSize)
+	}
+	return m.kv.Put(k, v)
+}
+
+func (m *MaxSizeKV) Get(k common.Hash) ([]byte, error) {
+	return m.kv.Get(k)
+}
@@ -0,0 +1,32 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxSizeKV(t *testing.T) {
+	mem := NewMemKV()
+	kv := NewMaxSizeKV(mem, 4)
+
+	require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1, 2, 3, 4}))
+	dat, err := kv.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4}, dat)
+
+	require.ErrorIs(t, kv.Put(common.Hash{0xbb}, []byte{1, 2, 3, 4, 5}), ErrPreimageTooLarge)
+	_, err = mem.Get(common.Hash{0xbb})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMaxSizeKVSetMaxSize(t *testing.T) {
+	kv := NewMaxSizeKV(NewMemKV(), 4)
+	kv.SetMaxSize(0)
+	require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1, 2, 3, 4, 5}))
+
+	kv.SetMaxSize(2)
+	require.ErrorIs(t, kv.Put(common.Hash{0xbb}, []byte{1, 2, 3}), ErrPreimageTooLarge)
+	require.NoError(t, kv.Put(common.Hash{0xcc}, []byte{1, 2}))
+}
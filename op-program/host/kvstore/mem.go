@@ -16,6 +16,7 @@ type MemKV struct {
 }
 
 var _ KV = (*MemKV)(nil)
+var _ Deleter = (*MemKV)(nil)
 
 func NewMemKV() *MemKV {
 	return &MemKV{m: make(map[common.Hash][]byte)}
@@ -37,3 +38,13 @@ func (m *MemKV) Get(k common.Hash) ([]byte, error) {
 	}
 	return slices.Clone(v), nil
 }
+
+func (m *MemKV) Delete(k common.Hash) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.m[k]; !ok {
+		return ErrNotFound
+	}
+	delete(m.m, k)
+	return nil
+}
@@ -6,3 +6,7 @@ func TestMemKV(t *testing.T) {
 	kv := NewMemKV()
 	kvTest(t, kv)
 }
+
+func TestMemKVDelete(t *testing.T) {
+	deleteTest(t, NewMemKV())
+}
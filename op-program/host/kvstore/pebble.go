@@ -0,0 +1,79 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PebbleKV is a disk-backed key-value store, with PebbleDB as the underlying DBMS.
+// PebbleKV is safe for concurrent use with a single PebbleKV instance.
+// The database must be closed with Close to release its lock on the directory.
+type PebbleKV struct {
+	sync.RWMutex
+	db *pebble.DB
+}
+
+var _ KV = (*PebbleKV)(nil)
+var _ Deleter = (*PebbleKV)(nil)
+
+// NewPebbleKV opens, or creates when not readOnly, the PebbleDB database in the given directory path.
+// cacheSize is the size of the block cache in bytes.
+func NewPebbleKV(path string, cacheSize int64, readOnly bool) (*PebbleKV, error) {
+	cache := pebble.NewCache(cacheSize)
+	defer cache.Unref()
+	db, err := pebble.Open(path, &pebble.Options{
+		Cache:                    cache,
+		ReadOnly:                 readOnly,
+		MaxConcurrentCompactions: runtime.NumCPU,
+		Levels: []pebble.LevelOptions{
+			{Compression: pebble.SnappyCompression},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pebble db at %s: %w", path, err)
+	}
+	return &PebbleKV{db: db}, nil
+}
+
+func (d *PebbleKV) Put(k common.Hash, v []byte) error {
+	d.Lock()
+	defer d.Unlock()
+	return d.db.Set(k.Bytes(), v, pebble.NoSync)
+}
+
+func (d *PebbleKV) Get(k common.Hash) ([]byte, error) {
+	d.RLock()
+	defer d.RUnlock()
+	dat, closer, err := d.db.Get(k.Bytes())
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return common.CopyBytes(dat), nil
+}
+
+func (d *PebbleKV) Delete(k common.Hash) error {
+	d.Lock()
+	defer d.Unlock()
+	_, closer, err := d.db.Get(k.Bytes())
+	if errors.Is(err, pebble.ErrNotFound) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	_ = closer.Close()
+	return d.db.Delete(k.Bytes(), pebble.NoSync)
+}
+
+func (d *PebbleKV) Close() error {
+	d.Lock()
+	defer d.Unlock()
+	return d.db.Close()
+}
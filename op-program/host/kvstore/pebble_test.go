@@ -0,0 +1,39 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPebbleKV(t *testing.T, path string, readOnly bool) *PebbleKV {
+	kv, err := NewPebbleKV(path, 1024*1024, readOnly)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, kv.Close())
+	})
+	return kv
+}
+
+func TestPebbleKV(t *testing.T) {
+	kvTest(t, newTestPebbleKV(t, t.TempDir(), false))
+}
+
+func TestPebbleKVDelete(t *testing.T) {
+	deleteTest(t, newTestPebbleKV(t, t.TempDir(), false))
+}
+
+func TestPebbleKVReopen(t *testing.T) {
+	dir := t.TempDir()
+	kv, err := NewPebbleKV(dir, 1024*1024, false)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(common.Hash{0xaa}, []byte("hello world")))
+	require.NoError(t, kv.Close())
+
+	readOnly := newTestPebbleKV(t, dir, true)
+	dat, err := readOnly.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(dat))
+	require.Error(t, readOnly.Put(common.Hash{0xbb}, []byte("rejected")))
+}
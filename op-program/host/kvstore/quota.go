@@ -0,0 +1,95 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UsageMetricer records the bytes stored in a QuotaKV.
+type UsageMetricer interface {
+	RecordDataDirUsage(bytes uint64)
+}
+
+// storedSizer is implemented by KV stores that store values in a different size than their length.
+type storedSizer interface {
+	StoredSize(v []byte) uint64
+}
+
+// QuotaKV rejects pre-images from being put into the underlying KV store once the bytes stored reach a maximum size.
+// The bytes stored start from the usage when created and grow by the size of every value put, so they are an estimate:
+// values put again are counted twice and deletes from the underlying KV store are not tracked.
+type QuotaKV struct {
+	mu      sync.Mutex
+	kv      KV
+	used    uint64
+	maxSize uint64
+	m       UsageMetricer
+}
+
+var _ KV = (*QuotaKV)(nil)
+
+func NewQuotaKV(kv KV, used uint64, maxSize uint64, m UsageMetricer) *QuotaKV {
+	m.RecordDataDirUsage(used)
+	return &QuotaKV{kv: kv, used: used, maxSize: maxSize, m: m}
+}
+
+func (q *QuotaKV) Put(k common.Hash, v []byte) error {
+	size := uint64(len(v))
+	if sizer, ok := q.kv.(storedSizer); ok {
+		size = sizer.StoredSize(v)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.used+size > q.maxSize {
+		return fmt.Errorf("%w: storing %d bytes for key %s would exceed the max size, %d of %d bytes used", ErrDataDirFull, size, k, q.used, q.maxSize)
+	}
+	if err := q.kv.Put(k, v); err != nil {
+		return err
+	}
+	q.used += size
+	q.m.RecordDataDirUsage(q.used)
+	return nil
+}
+
+func (q *QuotaKV) Get(k common.Hash) ([]byte, error) {
+	return q.kv.Get(k)
+}
+
+// Used returns the bytes stored.
+func (q *QuotaKV) Used() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.used
+}
+
+// DirSize returns the total size of the files in the directory and its subdirectories, or 0 if it does not exist.
+func DirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure size of %s: %w", dir, err)
+	}
+	return size, nil
+}
@@ -0,0 +1,80 @@
+package kvstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type usageMetrics struct {
+	usage uint64
+}
+
+func (m *usageMetrics) RecordDataDirUsage(bytes uint64) {
+	m.usage = bytes
+}
+
+func TestQuotaKV(t *testing.T) {
+	t.Run("Mem", func(t *testing.T) {
+		m := new(usageMetrics)
+		mem := NewMemKV()
+		kv := NewQuotaKV(mem, 2, 8, m)
+		require.Equal(t, uint64(2), m.usage)
+
+		require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1, 2, 3, 4}))
+		require.Equal(t, uint64(6), kv.Used())
+		require.Equal(t, uint64(6), m.usage)
+		dat, err := kv.Get(common.Hash{0xaa})
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3, 4}, dat)
+
+		require.ErrorIs(t, kv.Put(common.Hash{0xbb}, []byte{1, 2, 3}), ErrDataDirFull)
+		_, err = mem.Get(common.Hash{0xbb})
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Equal(t, uint64(6), m.usage)
+
+		require.NoError(t, kv.Put(common.Hash{0xcc}, []byte{1, 2}))
+		require.Equal(t, uint64(8), m.usage)
+	})
+
+	t.Run("Disk", func(t *testing.T) {
+		dir := t.TempDir()
+		m := new(usageMetrics)
+		kv := NewQuotaKV(NewDiskKV(dir), 0, 8, m)
+
+		// values are stored hex-encoded so take twice their length
+		require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1, 2, 3}))
+		require.Equal(t, uint64(6), m.usage)
+		require.ErrorIs(t, kv.Put(common.Hash{0xbb}, []byte{1, 2}), ErrDataDirFull)
+
+		size, err := DirSize(dir)
+		require.NoError(t, err)
+		require.Equal(t, kv.Used(), size)
+	})
+
+	t.Run("AlreadyFull", func(t *testing.T) {
+		kv := NewQuotaKV(NewMemKV(), 10, 8, new(usageMetrics))
+		require.ErrorIs(t, kv.Put(common.Hash{0xaa}, []byte{1}), ErrDataDirFull)
+	})
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	size, err := DirSize(dir)
+	require.NoError(t, err)
+	require.Zero(t, size)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), make([]byte, 10), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 5), 0o644))
+	size, err = DirSize(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), size)
+
+	size, err = DirSize(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	require.Zero(t, size)
+}
@@ -0,0 +1,29 @@
+package kvstore
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrReadOnly is returned when putting a pre-image into a read-only KV store.
+var ErrReadOnly = errors.New("kv store is read-only")
+
+// ReadOnlyKV serves the pre-images of the underlying KV store and rejects all writes.
+type ReadOnlyKV struct {
+	kv KV
+}
+
+var _ KV = (*ReadOnlyKV)(nil)
+
+func NewReadOnlyKV(kv KV) *ReadOnlyKV {
+	return &ReadOnlyKV{kv: kv}
+}
+
+func (r *ReadOnlyKV) Put(k common.Hash, v []byte) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlyKV) Get(k common.Hash) ([]byte, error) {
+	return r.kv.Get(k)
+}
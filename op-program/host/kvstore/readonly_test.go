@@ -0,0 +1,24 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyKV(t *testing.T) {
+	mem := NewMemKV()
+	require.NoError(t, mem.Put(common.Hash{0xaa}, []byte("hello world")))
+	kv := NewReadOnlyKV(mem)
+
+	dat, err := kv.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(dat))
+	_, err = kv.Get(common.Hash{0xbb})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.ErrorIs(t, kv.Put(common.Hash{0xbb}, []byte("rejected")), ErrReadOnly)
+	_, err = mem.Get(common.Hash{0xbb})
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -1,12 +1,28 @@
 package kvstore
 
 import (
+	"errors"
+
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type PreimageSource func(key common.Hash) ([]byte, error)
 
+// NewFallbackSource returns a PreimageSource querying the sources in order,
+// moving on to the next one when a source returns ErrNotFound.
+func NewFallbackSource(sources ...PreimageSource) PreimageSource {
+	return func(key common.Hash) ([]byte, error) {
+		for _, source := range sources {
+			value, err := source(key)
+			if !errors.Is(err, ErrNotFound) {
+				return value, err
+			}
+		}
+		return nil, ErrNotFound
+	}
+}
+
 type PreimageSourceSplitter struct {
 	local  PreimageSource
 	global PreimageSource
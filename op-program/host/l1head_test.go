@@ -0,0 +1,130 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubFinalizedL1Source struct {
+	ref eth.L1BlockRef
+	err error
+}
+
+func (s *stubFinalizedL1Source) L1BlockRefByLabel(_ context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	if label != eth.Finalized {
+		return eth.L1BlockRef{}, errors.New("unexpected label " + string(label))
+	}
+	return s.ref, s.err
+}
+
+func TestResolveL1Head(t *testing.T) {
+	finalized := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 100}
+	errUnavailable := errors.New("connection refused")
+
+	t.Run("Finalized", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		l1Head, err := resolveL1Head(context.Background(), logger, &stubFinalizedL1Source{ref: finalized})
+		require.NoError(t, err)
+		require.Equal(t, finalized.Hash, l1Head)
+		record := logs.FindLog(testlog.NewMessageContainsFilter("finalized L1 block as L1 head"))
+		require.NotNil(t, record)
+		require.Equal(t, finalized.Hash, record.AttrValue("l1Head"))
+	})
+
+	t.Run("FailsOver", func(t *testing.T) {
+		l1Head, err := resolveL1Head(context.Background(), testlog.Logger(t, log.LevelInfo),
+			&stubFinalizedL1Source{err: errUnavailable}, &stubFinalizedL1Source{ref: finalized})
+		require.NoError(t, err)
+		require.Equal(t, finalized.Hash, l1Head)
+	})
+
+	t.Run("RPCUnavailable", func(t *testing.T) {
+		_, err := resolveL1Head(context.Background(), testlog.Logger(t, log.LevelInfo),
+			&stubFinalizedL1Source{err: errUnavailable}, &stubFinalizedL1Source{err: errUnavailable})
+		require.ErrorIs(t, err, errUnavailable)
+		require.ErrorContains(t, err, "failed to resolve l1 head")
+	})
+}
+
+func TestServeL1Head(t *testing.T) {
+	newConfig := func(l1Head common.Hash) *config.Config {
+		cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, l1Head, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		cfg.ServerMode = true
+		cfg.L1URLs = []string{"http://l1"}
+		cfg.L1BeaconURL = "http://beacon"
+		cfg.L2URL = "http://l2"
+		return cfg
+	}
+	newServer := func(t *testing.T, cfg *config.Config) (*reloader, *httptest.Server) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		r := newReloader(logger, cfg)
+		handler := newHTTPHandler(logger, cfg, kvstore.NewMemKV().Get, func(string) error { return nil })
+		r.onReload(handler.apply)
+		srv := httptest.NewServer(handler)
+		t.Cleanup(srv.Close)
+		return r, srv
+	}
+	getL1Head := func(t *testing.T, srv *httptest.Server) common.Hash {
+		resp, err := http.Get(srv.URL + "/local/1")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return common.BytesToHash(body)
+	}
+
+	t.Run("Resolved", func(t *testing.T) {
+		cfg := newConfig(common.Hash{})
+		require.True(t, cfg.ResolvesL1Head())
+		r, srv := newServer(t, cfg)
+		r.setL1Head(common.Hash{0xaa})
+		require.Equal(t, common.Hash{0xaa}, getL1Head(t, srv))
+
+		// a reloaded config without l1 head keeps the resolved head
+		require.NoError(t, r.reload(newConfig(common.Hash{})))
+		require.Equal(t, common.Hash{0xaa}, r.cfg.L1Head)
+	})
+
+	t.Run("Reloaded", func(t *testing.T) {
+		r, srv := newServer(t, newConfig(common.Hash{0xaa}))
+		require.Equal(t, common.Hash{0xaa}, getL1Head(t, srv))
+
+		require.NoError(t, r.reload(newConfig(common.Hash{0xbb})))
+		require.Equal(t, common.Hash{0xbb}, getL1Head(t, srv))
+	})
+
+	t.Run("LocalInputs", func(t *testing.T) {
+		_, srv := newServer(t, newConfig(common.Hash{0xaa}))
+		resp, err := http.Get(srv.URL + fmt.Sprintf("/local/%d", client.L2ClaimLocalIndex))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0x44}.Bytes(), body)
+
+		for _, path := range []string{"/local/1000", "/local/abc"} {
+			resp, err := http.Get(srv.URL + path)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.NotEqual(t, http.StatusOK, resp.StatusCode, path)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestMainReportsInvalidFlag(t *testing.T) {
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = t.TempDir()
+	err := Main(testlog.Logger(t, log.LevelInfo), cfg)
+	require.ErrorIs(t, err, config.ErrInvalidL1Head)
+	require.EqualError(t, err, "invalid config, check flag --l1.head: invalid l1 head")
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_program"
+
+type Metricer interface {
+	RecordDataDirUsage(bytes uint64)
+}
+
+// Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
+var _ opmetrics.RegistryMetricer = (*Metrics)(nil)
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	dataDirUsage prometheus.Gauge
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       Namespace,
+		registry: registry,
+		factory:  factory,
+
+		dataDirUsage: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "datadir_usage_bytes",
+			Help:      "Bytes of pre-image data stored in the datadir",
+		}),
+	}
+}
+
+func (m *Metrics) RecordDataDirUsage(bytes uint64) {
+	m.dataDirUsage.Set(float64(bytes))
+}
@@ -0,0 +1,68 @@
+package host
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestStartMetrics(t *testing.T) {
+	newConfig := func() *config.Config {
+		cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		cfg.MetricsConfig.ListenAddr = "127.0.0.1"
+		cfg.MetricsConfig.ListenPort = 0
+		return cfg
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		m, srv, err := startMetrics(testlog.Logger(t, log.LevelInfo), newConfig())
+		require.NoError(t, err)
+		require.Nil(t, srv)
+		require.Equal(t, metrics.NoopMetrics, m)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.MetricsConfig.Enabled = true
+		m, srv, err := startMetrics(testlog.Logger(t, log.LevelInfo), cfg)
+		require.NoError(t, err)
+		require.NotNil(t, srv)
+		m.RecordDataDirUsage(42)
+
+		resp, err := http.Get(fmt.Sprintf("http://%s/metrics", srv.Addr()))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, string(body), "op_program_datadir_usage_bytes 42")
+
+		require.NoError(t, srv.Close())
+		_, err = http.Get(fmt.Sprintf("http://%s/metrics", srv.Addr()))
+		require.Error(t, err)
+	})
+
+	t.Run("AddressInUse", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.MetricsConfig.Enabled = true
+		_, srv, err := startMetrics(testlog.Logger(t, log.LevelInfo), cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = srv.Close() })
+
+		cfg.MetricsConfig.ListenPort = srv.Addr().(*net.TCPAddr).Port
+		_, _, err = startMetrics(testlog.Logger(t, log.LevelInfo), cfg)
+		require.ErrorContains(t, err, "failed to start metrics server")
+	})
+}
@@ -0,0 +1,79 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// FailoverL1Source queries L1 sources in order, moving on to the next source when one fails.
+// It fails with the errors of all sources if none of them succeeds.
+type FailoverL1Source struct {
+	logger  log.Logger
+	sources []L1Source
+}
+
+func NewFailoverL1Source(logger log.Logger, sources ...L1Source) *FailoverL1Source {
+	return &FailoverL1Source{
+		logger:  logger,
+		sources: sources,
+	}
+}
+
+func (s *FailoverL1Source) InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error) {
+	return failover(ctx, s, "InfoByHash", func(source L1Source) (eth.BlockInfo, error) {
+		return source.InfoByHash(ctx, blockHash)
+	})
+}
+
+func (s *FailoverL1Source) InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	res, err := failover(ctx, s, "InfoAndTxsByHash", func(source L1Source) (infoAndTxs, error) {
+		info, txs, err := source.InfoAndTxsByHash(ctx, blockHash)
+		return infoAndTxs{info, txs}, err
+	})
+	return res.info, res.txs, err
+}
+
+func (s *FailoverL1Source) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	res, err := failover(ctx, s, "FetchReceipts", func(source L1Source) (infoAndReceipts, error) {
+		info, receipts, err := source.FetchReceipts(ctx, blockHash)
+		return infoAndReceipts{info, receipts}, err
+	})
+	return res.info, res.receipts, err
+}
+
+type infoAndTxs struct {
+	info eth.BlockInfo
+	txs  types.Transactions
+}
+
+type infoAndReceipts struct {
+	info     eth.BlockInfo
+	receipts types.Receipts
+}
+
+// failover runs op against each source in order until one succeeds or the context is done.
+func failover[T any](ctx context.Context, s *FailoverL1Source, method string, op func(source L1Source) (T, error)) (T, error) {
+	var errs []error
+	for i, source := range s.sources {
+		res, err := op(source)
+		if err == nil {
+			return res, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+		if i < len(s.sources)-1 {
+			s.logger.Warn("L1 source failed, trying next", "method", method, "source", i, "err", err)
+		}
+	}
+	var empty T
+	return empty, errors.Join(errs...)
+}
+
+var _ L1Source = (*FailoverL1Source)(nil)
@@ -0,0 +1,72 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFailoverL1Source(t *testing.T) {
+	ctx := context.Background()
+	hash := common.Hash{0xab}
+	info := &testutils.MockBlockInfo{InfoHash: hash}
+	// The mock really doesn't like returning nil for a eth.BlockInfo so return a value we expect to be ignored instead
+	wrongInfo := &testutils.MockBlockInfo{InfoHash: common.Hash{0x99}}
+	txs := types.Transactions{&types.Transaction{}}
+	rcpts := types.Receipts{&types.Receipt{}}
+	logger := testlog.Logger(t, log.LevelDebug)
+
+	t.Run("PrimarySucceeds", func(t *testing.T) {
+		primary, secondary := &testutils.MockL1Source{}, &testutils.MockL1Source{}
+		defer primary.AssertExpectations(t)
+		defer secondary.AssertExpectations(t)
+		primary.ExpectInfoByHash(hash, info, nil)
+
+		result, err := NewFailoverL1Source(logger, primary, secondary).InfoByHash(ctx, hash)
+		require.NoError(t, err)
+		require.Equal(t, info, result)
+	})
+
+	t.Run("FailsOver", func(t *testing.T) {
+		primary, secondary := &testutils.MockL1Source{}, &testutils.MockL1Source{}
+		defer primary.AssertExpectations(t)
+		defer secondary.AssertExpectations(t)
+		primary.ExpectInfoAndTxsByHash(hash, wrongInfo, nil, errors.New("boom"))
+		secondary.ExpectInfoAndTxsByHash(hash, info, txs, nil)
+		primary.ExpectFetchReceipts(hash, wrongInfo, nil, errors.New("boom"))
+		secondary.ExpectFetchReceipts(hash, info, rcpts, nil)
+		source := NewFailoverL1Source(logger, primary, secondary)
+
+		resultInfo, resultTxs, err := source.InfoAndTxsByHash(ctx, hash)
+		require.NoError(t, err)
+		require.Equal(t, info, resultInfo)
+		require.Equal(t, txs, resultTxs)
+
+		resultInfo, resultRcpts, err := source.FetchReceipts(ctx, hash)
+		require.NoError(t, err)
+		require.Equal(t, info, resultInfo)
+		require.Equal(t, rcpts, resultRcpts)
+	})
+
+	t.Run("AllFail", func(t *testing.T) {
+		primary, secondary := &testutils.MockL1Source{}, &testutils.MockL1Source{}
+		defer primary.AssertExpectations(t)
+		defer secondary.AssertExpectations(t)
+		primaryErr, secondaryErr := errors.New("primary down"), errors.New("secondary down")
+		primary.ExpectInfoByHash(hash, wrongInfo, primaryErr)
+		secondary.ExpectInfoByHash(hash, wrongInfo, secondaryErr)
+
+		_, err := NewFailoverL1Source(logger, primary, secondary).InfoByHash(ctx, hash)
+		require.ErrorIs(t, err, primaryErr)
+		require.ErrorIs(t, err, secondaryErr)
+	})
+}
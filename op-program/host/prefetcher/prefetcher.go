@@ -5,11 +5,14 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync/atomic"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-program/host/cartesi"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,6 +24,21 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
+var (
+	// ErrHintNotAllowed is returned when prefetching for a hint of a type that is not allowed.
+	ErrHintNotAllowed = errors.New("hint type not allowed")
+	// ErrNoL1BlobSource is returned when prefetching for a blob hint without an L1 blob source.
+	ErrNoL1BlobSource = errors.New("no l1 beacon configured to fetch blobs")
+	// ErrHintNotConfigured is returned when prefetching for a hint of an optional hint type without a handler.
+	ErrHintNotConfigured = errors.New("hint type not configured")
+)
+
+// optionalHintTypes are the hint types that are only handled if a HintHandler is configured for them.
+var optionalHintTypes = []string{cartesi.HintMachinePage}
+
+// HintHandler fetches the pre-images for the bytes of a hint and stores them with put.
+type HintHandler func(ctx context.Context, hintBytes []byte, put func(key common.Hash, value []byte) error) error
+
 var (
 	kzgPointEvaluationSuccess = [1]byte{1}
 	kzgPointEvaluationFailure = [1]byte{0}
@@ -41,21 +59,56 @@ type L1PrecompileSource interface {
 	KZGPointEvaluation(input []byte) ([]byte, error)
 }
 
+// Config configures the fetching and storing of pre-images by a Prefetcher.
+type Config struct {
+	// Retry configures the retries of failed L1 and blob requests.
+	// The request timeout only applies to L1 requests, blob requests are limited by the timeout of the beacon client.
+	Retry RetryConfig
+	// AllowedHints are the hint types pre-images are prefetched for. If empty, all hint types are allowed.
+	AllowedHints []string
+	// MaxPreimageSize is the maximum size in bytes of a pre-image to store. If 0, the size is not limited.
+	MaxPreimageSize uint64
+	// HintHandlers handle hints of optional hint types, by hint type.
+	HintHandlers map[string]HintHandler
+}
+
+// DefaultConfig retries requests until they succeed and fetches pre-images of any hint type and size.
+func DefaultConfig() Config {
+	return Config{Retry: DefaultRetryConfig()}
+}
+
 type Prefetcher struct {
 	logger        log.Logger
 	l1Fetcher     L1Source
 	l1BlobFetcher L1BlobSource
 	lastHint      string
 	kvStore       kvstore.KV
+	cfg           atomic.Pointer[Config]
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, kvStore kvstore.KV) *Prefetcher {
-	return &Prefetcher{
-		logger:        logger,
-		l1Fetcher:     NewRetryingL1Source(logger, l1Fetcher),
-		l1BlobFetcher: NewRetryingL1BlobSource(logger, l1BlobFetcher),
-		kvStore:       kvStore,
+// NewPrefetcher creates a Prefetcher. The l1BlobFetcher may be nil, in which case blob hints fail with ErrNoL1BlobSource.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, kvStore kvstore.KV, cfg Config) *Prefetcher {
+	p := &Prefetcher{
+		logger:    logger,
+		l1Fetcher: NewRetryingL1Source(logger, l1Fetcher, cfg.Retry),
+		kvStore:   kvStore,
+	}
+	p.cfg.Store(&cfg)
+	if l1BlobFetcher != nil {
+		blobRetryCfg := cfg.Retry
+		blobRetryCfg.RequestTimeout = 0
+		p.l1BlobFetcher = NewRetryingL1BlobSource(logger, l1BlobFetcher, blobRetryCfg)
 	}
+	return p
+}
+
+// Reconfigure changes the allowed hint types and the maximum pre-image size of the running prefetcher.
+// Hints already being prefetched complete with the previous settings.
+func (p *Prefetcher) Reconfigure(allowedHints []string, maxPreimageSize uint64) {
+	cfg := *p.cfg.Load()
+	cfg.AllowedHints = allowedHints
+	cfg.MaxPreimageSize = maxPreimageSize
+	p.cfg.Store(&cfg)
 }
 
 func (p *Prefetcher) Hint(hint string) error {
@@ -88,6 +141,10 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	if err != nil {
 		return err
 	}
+	cfg := p.cfg.Load()
+	if len(cfg.AllowedHints) > 0 && !slices.Contains(cfg.AllowedHints, hintType) {
+		return fmt.Errorf("%w: %v", ErrHintNotAllowed, hintType)
+	}
 	p.logger.Debug("Prefetching", "type", hintType, "bytes", hexutil.Bytes(hintBytes))
 	switch hintType {
 	case l1.HintL1BlockHeader:
@@ -103,7 +160,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("marshall header: %w", err)
 		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
+		return p.put(preimage.Keccak256Key(hash).PreimageKey(), data)
 	case l1.HintL1Transactions:
 		if len(hintBytes) != 32 {
 			return fmt.Errorf("invalid L1 transactions hint: %x", hint)
@@ -128,6 +185,9 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if len(hintBytes) != 48 {
 			return fmt.Errorf("invalid blob hint: %x", hint)
 		}
+		if p.l1BlobFetcher == nil {
+			return ErrNoL1BlobSource
+		}
 
 		blobVersionHash := common.Hash(hintBytes[:32])
 		blobHashIndex := binary.BigEndian.Uint64(hintBytes[32:40])
@@ -146,7 +206,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		sidecar := sidecars[0]
 
 		// Put the preimage for the versioned hash into the kv store
-		if err = p.kvStore.Put(preimage.Sha256Key(blobVersionHash).PreimageKey(), sidecar.KZGCommitment[:]); err != nil {
+		if err = p.put(preimage.Sha256Key(blobVersionHash).PreimageKey(), sidecar.KZGCommitment[:]); err != nil {
 			return err
 		}
 
@@ -157,10 +217,10 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
 			binary.BigEndian.PutUint64(blobKey[72:], uint64(i))
 			blobKeyHash := crypto.Keccak256Hash(blobKey)
-			if err := p.kvStore.Put(preimage.Keccak256Key(blobKeyHash).PreimageKey(), blobKey); err != nil {
+			if err := p.put(preimage.Keccak256Key(blobKeyHash).PreimageKey(), blobKey); err != nil {
 				return err
 			}
-			if err = p.kvStore.Put(preimage.BlobKey(blobKeyHash).PreimageKey(), sidecar.Blob[i<<5:(i+1)<<5]); err != nil {
+			if err = p.put(preimage.BlobKey(blobKeyHash).PreimageKey(), sidecar.Blob[i<<5:(i+1)<<5]); err != nil {
 				return err
 			}
 		}
@@ -177,14 +237,28 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		}
 		inputHash := crypto.Keccak256Hash(hintBytes)
 		// Put the input preimage so it can be loaded later
-		if err := p.kvStore.Put(preimage.Keccak256Key(inputHash).PreimageKey(), hintBytes); err != nil {
+		if err := p.put(preimage.Keccak256Key(inputHash).PreimageKey(), hintBytes); err != nil {
 			return err
 		}
-		return p.kvStore.Put(preimage.KZGPointEvaluationKey(inputHash).PreimageKey(), result[:])
+		return p.put(preimage.KZGPointEvaluationKey(inputHash).PreimageKey(), result[:])
+	}
+	if handler, ok := cfg.HintHandlers[hintType]; ok {
+		return handler(ctx, hintBytes, p.put)
+	}
+	if slices.Contains(optionalHintTypes, hintType) {
+		return fmt.Errorf("%w: %v", ErrHintNotConfigured, hintType)
 	}
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
 
+// put stores a fetched pre-image, unless it is larger than the maximum pre-image size.
+func (p *Prefetcher) put(key common.Hash, value []byte) error {
+	if maxSize := p.cfg.Load().MaxPreimageSize; maxSize > 0 && uint64(len(value)) > maxSize {
+		return fmt.Errorf("%w: fetched %d bytes for key %s, max %d bytes", kvstore.ErrPreimageTooLarge, len(value), key, maxSize)
+	}
+	return p.kvStore.Put(key, value)
+}
+
 func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
 	opaqueReceipts, err := eth.EncodeReceipts(receipts)
 	if err != nil {
@@ -205,7 +279,7 @@ func (p *Prefetcher) storeTrieNodes(values []hexutil.Bytes) error {
 	_, nodes := mpt.WriteTrie(values)
 	for _, node := range nodes {
 		key := preimage.Keccak256Key(crypto.Keccak256Hash(node)).PreimageKey()
-		if err := p.kvStore.Put(key, node); err != nil {
+		if err := p.put(key, node); err != nil {
 			return fmt.Errorf("failed to store node: %w", err)
 		}
 	}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
@@ -21,6 +24,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-program/host/cartesi"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -431,7 +435,7 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	_, l1Source, l1BlobSource, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, kv, DefaultConfig())
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -483,7 +487,7 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *test
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, kv, DefaultConfig())
 	return prefetcher, l1Source, l1BlobSource, l2Source, kv
 }
 
@@ -527,6 +531,43 @@ func storeBlob(t *testing.T, kv kvstore.KV, commitment eth.Bytes48, blob *eth.Bl
 	}
 }
 
+func TestFetchL1BlockHeaderAttempts(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, _ := testutils.RandomBlock(rng, 2)
+	hash := block.Hash()
+	key := preimage.Keccak256Key(hash).PreimageKey()
+	expectedErr := errors.New("boom")
+
+	l1Cl := new(testutils.MockL1Source)
+	defer l1Cl.AssertExpectations(t)
+	for i := 0; i < 3; i++ {
+		l1Cl.ExpectInfoByHash(hash, eth.HeaderBlockInfo(block.Header()), expectedErr)
+	}
+	cfg := Config{Retry: RetryConfig{MaxAttempts: 3, MaxBackoff: time.Millisecond}}
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, new(testutils.MockBlobsFetcher), kvstore.NewMemKV(), cfg)
+
+	require.NoError(t, prefetcher.Hint(l1.BlockHeaderHint(hash).Hint()))
+	_, err := prefetcher.GetPreimage(context.Background(), key)
+	require.ErrorIs(t, err, expectedErr)
+}
+
+func TestHintNotAllowed(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, _ := testutils.RandomBlock(rng, 2)
+	hash := block.Hash()
+	key := preimage.Keccak256Key(hash).PreimageKey()
+
+	l1Cl := new(testutils.MockL1Source)
+	defer l1Cl.AssertExpectations(t)
+	cfg := DefaultConfig()
+	cfg.AllowedHints = []string{l1.HintL1Transactions}
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, new(testutils.MockBlobsFetcher), kvstore.NewMemKV(), cfg)
+
+	require.NoError(t, prefetcher.Hint(l1.BlockHeaderHint(hash).Hint()))
+	_, err := prefetcher.GetPreimage(context.Background(), key)
+	require.ErrorIs(t, err, ErrHintNotAllowed)
+}
+
 func asOracleFn(t *testing.T, prefetcher *Prefetcher) preimage.OracleFn {
 	return func(key preimage.Key) []byte {
 		pre, err := prefetcher.GetPreimage(context.Background(), key.PreimageKey())
@@ -560,3 +601,94 @@ func assertReceiptsEqual(t *testing.T, expectedRcpt types.Receipts, actualRcpt t
 		require.Equal(t, expected, actual)
 	}
 }
+
+func TestFetchL1BlockHeaderTooLarge(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, _ := testutils.RandomBlock(rng, 2)
+	hash := block.Hash()
+	key := preimage.Keccak256Key(hash).PreimageKey()
+
+	l1Cl := new(testutils.MockL1Source)
+	defer l1Cl.AssertExpectations(t)
+	l1Cl.ExpectInfoByHash(hash, eth.HeaderBlockInfo(block.Header()), nil)
+	cfg := DefaultConfig()
+	cfg.MaxPreimageSize = 16
+	kv := kvstore.NewMemKV()
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, new(testutils.MockBlobsFetcher), kv, cfg)
+
+	require.NoError(t, prefetcher.Hint(l1.BlockHeaderHint(hash).Hint()))
+	_, err := prefetcher.GetPreimage(context.Background(), key)
+	require.ErrorIs(t, err, kvstore.ErrPreimageTooLarge)
+	_, err = kv.Get(key)
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+}
+
+func TestFetchL1BlobWithoutBlobSource(t *testing.T) {
+	hint := l1.BlobHint(make([]byte, 48)).Hint()
+	l1Cl := new(testutils.MockL1Source)
+	defer l1Cl.AssertExpectations(t)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, nil, kvstore.NewMemKV(), DefaultConfig())
+
+	require.NoError(t, prefetcher.Hint(hint))
+	_, err := prefetcher.GetPreimage(context.Background(), common.Hash{0xaa})
+	require.ErrorIs(t, err, ErrNoL1BlobSource)
+}
+
+func TestHintHandlers(t *testing.T) {
+	value := []byte("machine page")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(value)).PreimageKey()
+	hint := fmt.Sprintf("%s 0x%x", cartesi.HintMachinePage, crypto.Keccak256(value))
+	l1Cl := new(testutils.MockL1Source)
+	defer l1Cl.AssertExpectations(t)
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, nil, kvstore.NewMemKV(), DefaultConfig())
+		require.NoError(t, prefetcher.Hint(hint))
+		_, err := prefetcher.GetPreimage(context.Background(), key)
+		require.ErrorIs(t, err, ErrHintNotConfigured)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.HintHandlers = map[string]HintHandler{
+			cartesi.HintMachinePage: func(ctx context.Context, hintBytes []byte, put func(key common.Hash, value []byte) error) error {
+				require.Equal(t, crypto.Keccak256(value), hintBytes)
+				return put(key, value)
+			},
+		}
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, nil, kvstore.NewMemKV(), cfg)
+		require.NoError(t, prefetcher.Hint(hint))
+		actual, err := prefetcher.GetPreimage(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+	})
+}
+
+func TestReconfigure(t *testing.T) {
+	value := []byte("machine page")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(value)).PreimageKey()
+	hint := fmt.Sprintf("%s 0x%x", cartesi.HintMachinePage, crypto.Keccak256(value))
+	l1Cl := new(testutils.MockL1Source)
+	defer l1Cl.AssertExpectations(t)
+	cfg := DefaultConfig()
+	cfg.HintHandlers = map[string]HintHandler{
+		cartesi.HintMachinePage: func(ctx context.Context, hintBytes []byte, put func(key common.Hash, value []byte) error) error {
+			return put(key, value)
+		},
+	}
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, nil, kvstore.NewMemKV(), cfg)
+
+	prefetcher.Reconfigure([]string{l1.HintL1BlockHeader}, 0)
+	require.NoError(t, prefetcher.Hint(hint))
+	_, err := prefetcher.GetPreimage(context.Background(), key)
+	require.ErrorIs(t, err, ErrHintNotAllowed)
+
+	prefetcher.Reconfigure(nil, 4)
+	_, err = prefetcher.GetPreimage(context.Background(), key)
+	require.ErrorIs(t, err, kvstore.ErrPreimageTooLarge)
+
+	prefetcher.Reconfigure(nil, 0)
+	actual, err := prefetcher.GetPreimage(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+}
@@ -3,6 +3,7 @@ package prefetcher
 import (
 	"context"
 	"math"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
@@ -11,24 +12,70 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-const maxAttempts = math.MaxInt // Succeed or die trying
+const (
+	maxAttempts       = math.MaxInt // Succeed or die trying
+	defaultMaxBackoff = 10 * time.Second
+	maxBackoffJitter  = 250 * time.Millisecond
+)
+
+// RetryConfig configures how failed L1 and beacon requests are retried.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts of a request. 0 retries until the request succeeds.
+	MaxAttempts int
+	// MaxBackoff is the maximum delay between attempts, the delay grows exponentially up to it.
+	MaxBackoff time.Duration
+	// RequestTimeout limits the duration of each attempt. 0 sets no limit.
+	RequestTimeout time.Duration
+}
+
+// DefaultRetryConfig retries requests until they succeed, without a timeout per attempt.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{MaxBackoff: defaultMaxBackoff}
+}
+
+func (c RetryConfig) attempts() int {
+	if c.MaxAttempts <= 0 {
+		return maxAttempts
+	}
+	return c.MaxAttempts
+}
+
+func (c RetryConfig) strategy() retry.Strategy {
+	return &retry.ExponentialStrategy{
+		Min:       0,
+		Max:       c.MaxBackoff,
+		MaxJitter: maxBackoffJitter,
+	}
+}
+
+// withTimeout returns the context of a single attempt.
+func (c RetryConfig) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.RequestTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.RequestTimeout)
+}
 
 type RetryingL1Source struct {
 	logger   log.Logger
 	source   L1Source
 	strategy retry.Strategy
+	cfg      RetryConfig
 }
 
-func NewRetryingL1Source(logger log.Logger, source L1Source) *RetryingL1Source {
+func NewRetryingL1Source(logger log.Logger, source L1Source, cfg RetryConfig) *RetryingL1Source {
 	return &RetryingL1Source{
 		logger:   logger,
 		source:   source,
-		strategy: retry.Exponential(),
+		strategy: cfg.strategy(),
+		cfg:      cfg,
 	}
 }
 
 func (s *RetryingL1Source) InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error) {
-	return retry.Do(ctx, maxAttempts, s.strategy, func() (eth.BlockInfo, error) {
+	return retry.Do(ctx, s.cfg.attempts(), s.strategy, func() (eth.BlockInfo, error) {
+		ctx, cancel := s.cfg.withTimeout(ctx)
+		defer cancel()
 		res, err := s.source.InfoByHash(ctx, blockHash)
 		if err != nil {
 			s.logger.Warn("Failed to retrieve info", "hash", blockHash, "err", err)
@@ -38,7 +85,9 @@ func (s *RetryingL1Source) InfoByHash(ctx context.Context, blockHash common.Hash
 }
 
 func (s *RetryingL1Source) InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
-	return retry.Do2(ctx, maxAttempts, s.strategy, func() (eth.BlockInfo, types.Transactions, error) {
+	return retry.Do2(ctx, s.cfg.attempts(), s.strategy, func() (eth.BlockInfo, types.Transactions, error) {
+		ctx, cancel := s.cfg.withTimeout(ctx)
+		defer cancel()
 		i, t, err := s.source.InfoAndTxsByHash(ctx, blockHash)
 		if err != nil {
 			s.logger.Warn("Failed to retrieve l1 info and txs", "hash", blockHash, "err", err)
@@ -48,7 +97,9 @@ func (s *RetryingL1Source) InfoAndTxsByHash(ctx context.Context, blockHash commo
 }
 
 func (s *RetryingL1Source) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
-	return retry.Do2(ctx, maxAttempts, s.strategy, func() (eth.BlockInfo, types.Receipts, error) {
+	return retry.Do2(ctx, s.cfg.attempts(), s.strategy, func() (eth.BlockInfo, types.Receipts, error) {
+		ctx, cancel := s.cfg.withTimeout(ctx)
+		defer cancel()
 		i, r, err := s.source.FetchReceipts(ctx, blockHash)
 		if err != nil {
 			s.logger.Warn("Failed to fetch receipts", "hash", blockHash, "err", err)
@@ -63,18 +114,22 @@ type RetryingL1BlobSource struct {
 	logger   log.Logger
 	source   L1BlobSource
 	strategy retry.Strategy
+	cfg      RetryConfig
 }
 
-func NewRetryingL1BlobSource(logger log.Logger, source L1BlobSource) *RetryingL1BlobSource {
+func NewRetryingL1BlobSource(logger log.Logger, source L1BlobSource, cfg RetryConfig) *RetryingL1BlobSource {
 	return &RetryingL1BlobSource{
 		logger:   logger,
 		source:   source,
-		strategy: retry.Exponential(),
+		strategy: cfg.strategy(),
+		cfg:      cfg,
 	}
 }
 
 func (s *RetryingL1BlobSource) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
-	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]*eth.BlobSidecar, error) {
+	return retry.Do(ctx, s.cfg.attempts(), s.strategy, func() ([]*eth.BlobSidecar, error) {
+		ctx, cancel := s.cfg.withTimeout(ctx)
+		defer cancel()
 		sidecars, err := s.source.GetBlobSidecars(ctx, ref, hashes)
 		if err != nil {
 			s.logger.Warn("Failed to retrieve blob sidecars", "ref", ref, "err", err)
@@ -84,7 +139,9 @@ func (s *RetryingL1BlobSource) GetBlobSidecars(ctx context.Context, ref eth.L1Bl
 }
 
 func (s *RetryingL1BlobSource) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
-	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]*eth.Blob, error) {
+	return retry.Do(ctx, s.cfg.attempts(), s.strategy, func() ([]*eth.Blob, error) {
+		ctx, cancel := s.cfg.withTimeout(ctx)
+		defer cancel()
 		blobs, err := s.source.GetBlobs(ctx, ref, hashes)
 		if err != nil {
 			s.logger.Warn("Failed to retrieve blobs", "ref", ref, "err", err)
@@ -94,4 +151,3 @@ func (s *RetryingL1BlobSource) GetBlobs(ctx context.Context, ref eth.L1BlockRef,
 }
 
 var _ L1BlobSource = (*RetryingL1BlobSource)(nil)
-
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -104,10 +105,54 @@ func TestRetryingL1Source(t *testing.T) {
 	})
 }
 
+func TestRetryingL1SourceConfig(t *testing.T) {
+	ctx := context.Background()
+	hash := common.Hash{0xab}
+	wrongInfo := &testutils.MockBlockInfo{InfoHash: common.Hash{0x99}}
+	logger := testlog.Logger(t, log.LevelDebug)
+
+	t.Run("MaxAttempts", func(t *testing.T) {
+		mock := &testutils.MockL1Source{}
+		defer mock.AssertExpectations(t)
+		source := NewRetryingL1Source(logger, mock, RetryConfig{MaxAttempts: 2})
+		source.strategy = retry.Fixed(0)
+		expectedErr := errors.New("boom")
+		mock.ExpectInfoByHash(hash, wrongInfo, expectedErr)
+		mock.ExpectInfoByHash(hash, wrongInfo, expectedErr)
+
+		_, err := source.InfoByHash(ctx, hash)
+		require.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("RequestTimeout", func(t *testing.T) {
+		source := NewRetryingL1Source(logger, &deadlineL1Source{}, RetryConfig{MaxAttempts: 1, RequestTimeout: time.Minute})
+		_, err := source.InfoByHash(ctx, hash)
+		require.NoError(t, err)
+
+		source = NewRetryingL1Source(logger, &deadlineL1Source{}, RetryConfig{MaxAttempts: 1})
+		_, err = source.InfoByHash(ctx, hash)
+		require.ErrorIs(t, err, errNoDeadline)
+	})
+}
+
+var errNoDeadline = errors.New("no deadline")
+
+// deadlineL1Source fails requests without a deadline.
+type deadlineL1Source struct {
+	L1Source
+}
+
+func (s *deadlineL1Source) InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errNoDeadline
+	}
+	return &testutils.MockBlockInfo{InfoHash: blockHash}, nil
+}
+
 func createL1Source(t *testing.T) (*RetryingL1Source, *testutils.MockL1Source) {
 	logger := testlog.Logger(t, log.LevelDebug)
 	mock := &testutils.MockL1Source{}
-	source := NewRetryingL1Source(logger, mock)
+	source := NewRetryingL1Source(logger, mock, DefaultRetryConfig())
 	// Avoid sleeping in tests by using a fixed retry strategy with no delay
 	source.strategy = retry.Fixed(0)
 	return source, mock
@@ -218,7 +263,7 @@ func TestRetryingL1BlobSource(t *testing.T) {
 func createL1BlobSource(t *testing.T) (*RetryingL1BlobSource, *testutils.MockBlobsFetcher) {
 	logger := testlog.Logger(t, log.LvlDebug)
 	mock := &testutils.MockBlobsFetcher{}
-	source := NewRetryingL1BlobSource(logger, mock)
+	source := NewRetryingL1BlobSource(logger, mock, DefaultRetryConfig())
 	// Avoid sleeping in tests by using a fixed retry strategy with no delay
 	source.strategy = retry.Fixed(0)
 	return source, mock
@@ -0,0 +1,122 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slog"
+)
+
+// reloader applies the reloadable settings of a reloaded config to the running preimage server.
+// Changes to settings that are not in config.ReloadableSettings are reported as requiring a restart.
+type reloader struct {
+	logger   log.Logger
+	mu       sync.Mutex
+	cfg      *config.Config
+	appliers []func(cfg *config.Config)
+}
+
+func newReloader(logger log.Logger, cfg *config.Config) *reloader {
+	r := &reloader{logger: logger, cfg: cfg}
+	r.onReload(func(cfg *config.Config) {
+		if cfg.LogLevel != nil {
+			setLogLevel(logger, *cfg.LogLevel)
+		}
+	})
+	return r
+}
+
+// onReload registers apply to be called with the new config when reloadable settings change.
+func (r *reloader) onReload(apply func(cfg *config.Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.appliers = append(r.appliers, apply)
+}
+
+// reload applies the reloadable settings of next and logs which settings changed.
+// Invalid configs are rejected and the current settings are kept.
+func (r *reloader) reload(next *config.Config) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if next.ResolvesL1Head() {
+		// the l1 head resolved at startup is kept, it is only resolved again on restart
+		next.L1Head = r.cfg.L1Head
+	}
+	if err := next.Check(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	var applied, restart []string
+	for _, setting := range config.ChangedSettings(r.cfg, next) {
+		if slices.Contains(config.ReloadableSettings, setting) {
+			applied = append(applied, setting)
+		} else {
+			restart = append(restart, setting)
+		}
+	}
+	if len(restart) > 0 {
+		r.logger.Warn("Changed settings require a restart to take effect", "settings", restart)
+	}
+	if len(applied) == 0 {
+		r.logger.Info("Reloaded config, no settings to apply")
+		return nil
+	}
+	cfg := r.cfg.WithReloadedSettings(next)
+	for _, apply := range r.appliers {
+		apply(cfg)
+	}
+	r.cfg = cfg
+	r.logger.Info("Reloaded config", "applied", applied)
+	return nil
+}
+
+// setL1Head sets the L1 head of the config, once it is resolved at startup.
+func (r *reloader) setL1Head(l1Head common.Hash) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cfg.L1Head = l1Head
+}
+
+// reloadOnSignal reloads the config loaded by load whenever the process receives SIGHUP, until stop is called.
+func (r *reloader) reloadOnSignal(load func() (*config.Config, error)) (stop func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sigs:
+				r.logger.Info("Received SIGHUP, reloading config")
+				next, err := load()
+				if err == nil {
+					err = r.reload(next)
+				}
+				if err != nil {
+					r.logger.Error("Failed to reload config, keeping current settings", "err", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
+
+// setLogLevel changes the level of the logger, if its handler supports it.
+func setLogLevel(logger log.Logger, lvl slog.Level) {
+	if h, ok := logger.Handler().(oplog.LvlSetter); ok {
+		h.SetLogLevel(lvl)
+		return
+	}
+	logger.Warn("Logger does not support changing the log level", "level", lvl)
+}
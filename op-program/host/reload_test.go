@@ -0,0 +1,115 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestReload(t *testing.T) {
+	value := []byte("pre-image served before and after reload")
+	hint := l1.BlockHeaderHint(common.Hash{0xaa}).Hint()
+	newConfig := func() *config.Config {
+		cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		cfg.DataDir = "/data"
+		return cfg
+	}
+	// newServer serves the HTTP API from a KV store limited to the max pre-image size, like preimageServer.
+	newServer := func(t *testing.T, logger log.Logger, cfg *config.Config) (*reloader, *httptest.Server, kvstore.KV) {
+		r := newReloader(logger, cfg)
+		kv := kvstore.NewMaxSizeKV(kvstore.NewMemKV(), cfg.MaxPreimageSize)
+		r.onReload(func(cfg *config.Config) { kv.SetMaxSize(cfg.MaxPreimageSize) })
+		require.NoError(t, kv.Put(preimage.Keccak256Key(crypto.Keccak256Hash(value)).PreimageKey(), value))
+		handler := newHTTPHandler(logger, cfg, kv.Get, func(string) error { return nil })
+		r.onReload(handler.apply)
+		srv := httptest.NewServer(handler)
+		t.Cleanup(srv.Close)
+		return r, srv, kv
+	}
+	get := func(t *testing.T, srv *httptest.Server, path string) int {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+	dehashPath := fmt.Sprintf("/dehash/%x", crypto.Keccak256(value))
+	hintPath := "/hint/" + url.PathEscape(hint)
+
+	t.Run("AppliesReloadableSettings", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		r, srv, kv := newServer(t, logger, newConfig())
+		require.Equal(t, http.StatusOK, get(t, srv, dehashPath))
+		require.Equal(t, http.StatusOK, get(t, srv, hintPath))
+
+		next := newConfig()
+		next.AllowedHints = []string{l1.HintL1Blob}
+		next.MaxPreimageSize = 8
+		require.NoError(t, r.reload(next))
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, get(t, srv, dehashPath))
+		require.Equal(t, http.StatusBadRequest, get(t, srv, hintPath))
+		require.ErrorIs(t, kv.Put(common.Hash{0xbb}, value), kvstore.ErrPreimageTooLarge)
+		record := logs.FindLog(testlog.NewMessageFilter("Reloaded config"))
+		require.NotNil(t, record)
+		require.Equal(t, []string{"allowedHints", "maxPreimageSize"}, record.AttrValue("applied"))
+		require.Nil(t, logs.FindLog(testlog.NewMessageContainsFilter("require a restart")))
+	})
+
+	t.Run("ReportsRestartRequired", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		r, srv, _ := newServer(t, logger, newConfig())
+
+		next := newConfig()
+		next.DataDir = "/other"
+		next.APIAddress = "127.0.0.1:9000"
+		next.MaxPreimageSize = 8
+		require.NoError(t, r.reload(next))
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, get(t, srv, dehashPath))
+		record := logs.FindLog(testlog.NewMessageContainsFilter("require a restart"))
+		require.NotNil(t, record)
+		require.Equal(t, []string{"dataDir", "apiAddress"}, record.AttrValue("settings"))
+		require.Equal(t, "/data", r.cfg.DataDir)
+		require.Equal(t, uint64(8), r.cfg.MaxPreimageSize)
+	})
+
+	t.Run("RejectsInvalidConfig", func(t *testing.T) {
+		r, srv, _ := newServer(t, testlog.Logger(t, log.LevelInfo), newConfig())
+
+		next := newConfig()
+		next.MaxPreimageSize = 8
+		next.L1Head = common.Hash{}
+		require.ErrorIs(t, r.reload(next), config.ErrInvalidL1Head)
+		require.Equal(t, http.StatusOK, get(t, srv, dehashPath))
+	})
+
+	t.Run("SetsLogLevel", func(t *testing.T) {
+		logger := oplog.NewLogger(io.Discard, oplog.CLIConfig{Level: log.LevelInfo, Format: oplog.FormatTerminal})
+		r := newReloader(logger, newConfig())
+		require.False(t, logger.Enabled(context.Background(), log.LevelDebug))
+
+		next := newConfig()
+		lvl := log.LevelDebug
+		next.LogLevel = &lvl
+		require.NoError(t, r.reload(next))
+		require.True(t, logger.Enabled(context.Background(), log.LevelDebug))
+	})
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/host/cartesi"
+)
+
+// HintTypes are the types of the hints the prefetcher fetches pre-images for.
+var HintTypes = []string{
+	l1.HintL1BlockHeader,
+	l1.HintL1Transactions,
+	l1.HintL1Receipts,
+	l1.HintL1Blob,
+	l1.HintL1KZGPointEvaluation,
+	cartesi.HintMachinePage,
+}
+
+// KVBackend identifies the key-value store pre-images are stored in.
+type KVBackend string
+
+const (
+	// KVBackendMem keeps pre-images in memory, they are lost when the host exits.
+	KVBackendMem KVBackend = "mem"
+	// KVBackendDisk stores every pre-image as a file in the datadir.
+	KVBackendDisk KVBackend = "disk"
+	// KVBackendPebble stores pre-images in a PebbleDB database in the datadir.
+	KVBackendPebble KVBackend = "pebble"
+)
+
+var KVBackends = []KVBackend{KVBackendMem, KVBackendDisk, KVBackendPebble}
+
+func (b KVBackend) String() string {
+	return string(b)
+}
+
+func (b *KVBackend) Set(value string) error {
+	if !ValidKVBackend(KVBackend(value)) {
+		return fmt.Errorf("unknown kv backend: %q", value)
+	}
+	*b = KVBackend(value)
+	return nil
+}
+
+func (b *KVBackend) Clone() any {
+	cpy := *b
+	return &cpy
+}
+
+// UsesDataDir reports whether the backend stores pre-images in the datadir.
+func (b KVBackend) UsesDataDir() bool {
+	return b == KVBackendDisk || b == KVBackendPebble
+}
+
+func ValidKVBackend(value KVBackend) bool {
+	return slices.Contains(KVBackends, value)
+}
+
+// ByteSize is a size in bytes, written as a number with an optional unit like "512KiB" or "1GB".
+type ByteSize uint64
+
+var byteSizeUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1_000,
+	"kib": 1 << 10,
+	"mb":  1_000_000,
+	"mib": 1 << 20,
+	"gb":  1_000_000_000,
+	"gib": 1 << 30,
+	"tb":  1_000_000_000_000,
+	"tib": 1 << 40,
+}
+
+// ParseByteSize parses a size in bytes with an optional, case-insensitive, decimal (KB, MB, ...)
+// or binary (KiB, MiB, ...) unit.
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	numEnd := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if numEnd < 0 {
+		numEnd = len(s)
+	}
+	n, err := strconv.ParseUint(s[:numEnd], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	unit, ok := byteSizeUnits[strings.ToLower(strings.TrimSpace(s[numEnd:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, s[numEnd:])
+	}
+	if n > math.MaxUint64/unit {
+		return 0, fmt.Errorf("invalid size %q: too large", s)
+	}
+	return ByteSize(n * unit), nil
+}
+
+func (b ByteSize) String() string {
+	return strconv.FormatUint(uint64(b), 10)
+}
+
+func (b *ByteSize) Set(value string) error {
+	size, err := ParseByteSize(value)
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+func (b *ByteSize) Clone() any {
+	cpy := *b
+	return &cpy
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	valid := []struct {
+		input    string
+		expected ByteSize
+	}{
+		{"0", 0},
+		{"1234", 1234},
+		{"10B", 10},
+		{"2KB", 2_000},
+		{"2KiB", 2048},
+		{"2kib", 2048},
+		{"64MiB", 64 << 20},
+		{"64 MB", 64_000_000},
+		{"1GB", 1_000_000_000},
+		{"1GiB", 1 << 30},
+		{"3TiB", 3 << 40},
+		{" 5mib ", 5 << 20},
+		{"18446744073709551615", math.MaxUint64},
+	}
+	for _, test := range valid {
+		test := test
+		t.Run("Valid_"+test.input, func(t *testing.T) {
+			size, err := ParseByteSize(test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, size)
+		})
+	}
+
+	for _, input := range []string{"", "MiB", "-1", "1.5GiB", "10XB", "0x10", "20000000TiB", "18446744073709551616"} {
+		input := input
+		t.Run("Invalid_"+input, func(t *testing.T) {
+			_, err := ParseByteSize(input)
+			require.Error(t, err)
+		})
+	}
+}
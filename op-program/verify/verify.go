@@ -139,7 +139,7 @@ func Run(l1RpcUrl string, l1RpcKind string, l2RpcUrl string, l2OracleAddr common
 		L1Head:             l1Head,
 	}
 	onlineCfg := offlineCfg
-	onlineCfg.L1URL = l1RpcUrl
+	onlineCfg.L1URLs = []string{l1RpcUrl}
 	onlineCfg.L2URL = l2RpcUrl
 	onlineCfg.L1RPCKind = sources.RPCProviderKind(l1RpcKind)
 
@@ -59,6 +59,13 @@ func WithHeader(h http.Header) BasicHTTPClientOption {
 	})
 }
 
+// WithTimeout sets the timeout of every request, instead of DefaultTimeoutSeconds.
+func WithTimeout(timeout time.Duration) BasicHTTPClientOption {
+	return BasicHTTPClientOptionFn(func(c *BasicHTTPClient) {
+		c.client.Timeout = timeout
+	})
+}
+
 var ErrNoEndpoint = errors.New("no endpoint is configured")
 
 func (cl *BasicHTTPClient) Get(ctx context.Context, p string, query url.Values, headers http.Header) (*http.Response, error) {
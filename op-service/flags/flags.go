@@ -2,8 +2,10 @@ package flags
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
@@ -88,3 +90,27 @@ func CheckRequiredXor(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+// ParseGameTypeAddresses parses the <game-type>=<address> entries of flag.
+func ParseGameTypeAddresses(ctx *cli.Context, flag *cli.StringSliceFlag) (map[uint32]common.Address, error) {
+	var addresses map[uint32]common.Address
+	for _, entry := range ctx.StringSlice(flag.Name) {
+		gameTypeStr, addrStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %v value %q, must be <game-type>=<address>", flag.Name, entry)
+		}
+		gameType, err := strconv.ParseUint(gameTypeStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v game type %q: %w", flag.Name, gameTypeStr, err)
+		}
+		addr, err := opservice.ParseAddress(addrStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v address %q: %w", flag.Name, addrStr, err)
+		}
+		if addresses == nil {
+			addresses = make(map[uint32]common.Address)
+		}
+		addresses[uint32(gameType)] = addr
+	}
+	return addresses, nil
+}
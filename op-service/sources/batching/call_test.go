@@ -169,6 +169,13 @@ func TestCallResult_GetValues(t *testing.T) {
 			},
 			expected: [][32]byte{{0xaa, 0xbb, 0xcc}, {0xdd, 0xee, 0xff}, {0x11, 0x22, 0x33}},
 		},
+		{
+			name: "GetString",
+			getter: func(result *CallResult, i int) interface{} {
+				return result.GetString(i)
+			},
+			expected: "1.2.3",
+		},
 		{
 			name: "GetBigInt",
 			getter: func(result *CallResult, i int) interface{} {
@@ -41,3 +41,8 @@ func NewLRUCache[K comparable, V any](m Metrics, label string, maxSize int) *LRU
 		inner: cache,
 	}
 }
+
+// Remove removes the key from the cache, returning true if it was present.
+func (c *LRUCache[K, V]) Remove(key K) (present bool) {
+	return c.inner.Remove(key)
+}
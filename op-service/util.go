@@ -27,9 +27,16 @@ func PrefixEnvVar(prefix, suffix string) []string {
 // prefixed with the supplied prefix (like OP_BATCHER) but there is no
 // actual env var with that name.
 // It helps validate that the supplied env vars are in fact valid.
-func ValidateEnvVars(prefix string, flags []cli.Flag, log log.Logger) {
-	for _, envVar := range validateEnvVars(prefix, os.Environ(), cliFlagsToEnvVars(flags)) {
-		log.Warn("Unknown env var", "prefix", prefix, "env_var", envVar)
+// Env vars without a flag, like secrets that must not be set on the command line, can be passed as known env vars.
+// Only the names of unknown env vars are logged, as their values may be secrets.
+func ValidateEnvVars(prefix string, flags []cli.Flag, log log.Logger, knownEnvVars ...string) {
+	defined := cliFlagsToEnvVars(flags)
+	for _, envVar := range knownEnvVars {
+		defined[envVar] = struct{}{}
+	}
+	for _, envVar := range validateEnvVars(prefix, os.Environ(), defined) {
+		name, _, _ := strings.Cut(envVar, "=")
+		log.Warn("Unknown env var", "prefix", prefix, "env_var", name)
 	}
 }
 
@@ -3,8 +3,11 @@ package op_service
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestCLIFlagsToEnvVars(t *testing.T) {
@@ -30,3 +33,16 @@ func TestValidateEnvVars(t *testing.T) {
 	invalids := validateEnvVars("OP_BATCHER", provided, defined)
 	require.ElementsMatch(t, invalids, []string{"OP_BATCHER_FAKE=false"})
 }
+
+func TestValidateEnvVarsKnownAndUnknown(t *testing.T) {
+	t.Setenv("OP_TEST_FLAG", "1")
+	t.Setenv("OP_TEST_SECRET", "known-secret")
+	t.Setenv("OP_TEST_SECRT", "mistyped-secret")
+	flags := []cli.Flag{&cli.StringFlag{Name: "flag", EnvVars: []string{"OP_TEST_FLAG"}}}
+	logger, logs := testlog.CaptureLogger(t, log.LevelWarn)
+
+	ValidateEnvVars("OP_TEST", flags, logger, "OP_TEST_SECRET")
+	recs := logs.FindLogs(testlog.NewMessageFilter("Unknown env var"))
+	require.Len(t, recs, 1)
+	require.Equal(t, "OP_TEST_SECRT", recs[0].AttrValue("env_var"))
+}